@@ -14,15 +14,23 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rycln/gokeep/client/internal/config"
 	client "github.com/rycln/gokeep/client/internal/grpc"
 	"github.com/rycln/gokeep/client/internal/services"
 	"github.com/rycln/gokeep/client/internal/storage"
 	"github.com/rycln/gokeep/client/internal/strategies/crypto"
 	"github.com/rycln/gokeep/client/internal/tui"
+	"github.com/rycln/gokeep/client/internal/tui/screens/account"
 	"github.com/rycln/gokeep/client/internal/tui/screens/add"
 	"github.com/rycln/gokeep/client/internal/tui/screens/auth"
+	"github.com/rycln/gokeep/client/internal/tui/screens/emergency"
+	"github.com/rycln/gokeep/client/internal/tui/screens/lock"
 	"github.com/rycln/gokeep/client/internal/tui/screens/update"
 	"github.com/rycln/gokeep/client/internal/tui/screens/vault"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
@@ -36,27 +44,67 @@ var (
 
 // Application constants
 const (
-	grpcTarget = ":50051"
-	DBpath     = "./gophkeeper.db"
-	timeout    = time.Duration(5) * time.Second
+	DBpath  = "./gophkeeper.db"
+	timeout = time.Duration(5) * time.Second
 )
 
+// traceFlushTimeout limits export of pending spans on exit
+const traceFlushTimeout = 5 * time.Second
+
 // App represents the main application structure
 type App struct {
-	tui  *tea.Program     // TUI program instance
-	conn *grpc.ClientConn // gRPC connection
-	db   *sql.DB          // Database connection
+	tui     *tea.Program             // TUI program instance
+	conn    *grpc.ClientConn         // gRPC connection
+	db      *sql.DB                  // Database connection
+	tracing *sdktrace.TracerProvider // Trace provider flushed on exit
 }
 
 // New creates and initializes a new App instance
 func New() (*App, error) {
-	certPool, _ := x509.SystemCertPool()
+	cfg, err := config.NewConfigBuilder().
+		WithFlagParsing().
+		WithEnvParsing().
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("can't initialize config: %v", err)
+	}
+
+	tp, err := newTracerProvider(cfg.TraceEndpoint, cfg.TraceInsecure)
+	if err != nil {
+		return nil, fmt.Errorf("tracing error: %v", err)
+	}
+
+	certPool, err := x509.SystemCertPool()
+	if err != nil {
+		certPool = x509.NewCertPool()
+	}
+	if cfg.CAFileName != "" {
+		pem, err := os.ReadFile(cfg.CAFileName)
+		if err != nil {
+			return nil, fmt.Errorf("can't load server CA: %v", err)
+		}
+		if !certPool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in server CA file %s", cfg.CAFileName)
+		}
+	}
 
 	tlsConfig := &tls.Config{
 		RootCAs:    certPool,
 		MinVersion: tls.VersionTLS12,
 	}
-	conn, err := grpc.NewClient(grpcTarget, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if cfg.CertFileName != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFileName, cfg.CertKeyFileName)
+		if err != nil {
+			return nil, fmt.Errorf("can't load client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	conn, err := grpc.NewClient(
+		cfg.ServerAddr,
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithUserAgent(userAgent()),
+		grpc.WithChainUnaryInterceptor(client.TracingInterceptor, client.ErrorInterceptor),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("grpc client error: %v", err)
 	}
@@ -66,34 +114,36 @@ func New() (*App, error) {
 		return nil, fmt.Errorf("db creation error: %v", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	err = storage.InitDB(ctx, db)
-	if err != nil {
-		return nil, fmt.Errorf("db init error: %v", err)
-	}
+	// Schema is created on unlock, see ItemStorage.Open
+	crypt := crypto.NewAESCrypter()
+	itemStorage := storage.NewItemStorage(db, crypt)
 
-	itemStorage := storage.NewItemStorage(db)
+	accountStorage := storage.NewAccountStorage(db)
 
-	authService := services.NewAuthService(client.NewGophKeeperClient(conn))
+	authService := services.NewAuthService(client.NewGophKeeperClient(conn), accountStorage, itemStorage)
 
-	crypt := crypto.NewAESCrypter()
 	itemService := services.NewItemService(itemStorage, crypt)
-	syncService := services.NewSyncService(client.NewGophKeeperClient(conn), itemStorage)
+	orgService := services.NewOrgService(client.NewGophKeeperClient(conn), crypt, crypto.NewBox())
+	syncService := services.NewSyncService(client.NewGophKeeperClient(conn), itemStorage, orgService, crypt)
+	shareService := services.NewShareService(client.NewGophKeeperClient(conn), crypt, crypto.NewBox())
+	emergencyService := services.NewEmergencyService(client.NewGophKeeperClient(conn), crypt, crypto.NewBox())
 	keyService := services.NewKeyService()
 
-	authScreen := auth.InitialModel(authService, keyService, crypt, timeout)
-	vaultScreen := vault.InitialModel(itemService, syncService, timeout)
+	authScreen := auth.InitialModel(authService, keyService, crypt, itemStorage, timeout)
+	vaultScreen := vault.InitialModel(itemService, syncService, shareService, orgService, timeout)
 	addScreen := add.InitialModel(itemService, timeout)
 	updateScreen := update.InitialModel(itemService, timeout)
+	lockScreen := lock.InitialModel(keyService, crypt)
+	emergencyScreen := emergency.InitialModel(emergencyService, timeout)
+	accountScreen := account.InitialModel(authService, crypt, timeout)
 
-	p := tea.NewProgram(tui.InitialRootModel(authScreen, vaultScreen, addScreen, updateScreen))
+	p := tea.NewProgram(tui.InitialRootModel(authScreen, vaultScreen, addScreen, updateScreen, lockScreen, emergencyScreen, accountScreen, cfg.IdleTimeout))
 
 	return &App{
-		tui:  p,
-		conn: conn,
-		db:   db,
+		tui:     p,
+		conn:    conn,
+		db:      db,
+		tracing: tp,
 	}, nil
 }
 
@@ -132,9 +182,52 @@ func (app *App) cleanup() error {
 		return fmt.Errorf("conn close failed: %w", err)
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), traceFlushTimeout)
+	defer cancel()
+
+	if err := app.tracing.Shutdown(ctx); err != nil {
+		return fmt.Errorf("trace flush failed: %w", err)
+	}
+
 	return nil
 }
 
+// newTracerProvider installs trace provider and W3C trace context propagation
+// Spans get trace IDs sent to the server even when export is disabled
+func newTracerProvider(endpoint string, insecure bool) (*sdktrace.TracerProvider, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var opts []sdktrace.TracerProviderOption
+	if endpoint != "" {
+		clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+		if insecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+		exp, err := otlptracegrpc.New(context.Background(), clientOpts...)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	}
+
+	tp := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tp)
+	return tp, nil
+}
+
+// userAgent identifies the device in the server audit log
+func userAgent() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	version := buildVersion
+	if version == "" {
+		version = "dev"
+	}
+	return fmt.Sprintf("gophkeeper-client/%s (%s)", version, host)
+}
+
 // printBuildInfo displays version information
 func printBuildInfo() {
 	if buildVersion == "" {
@@ -0,0 +1,115 @@
+// Package config provides client configuration management.
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/caarlos0/env/v11"
+	flag "github.com/spf13/pflag"
+)
+
+// Config default values
+const (
+	defaultServerAddr  = ":50051"
+	defaultIdleTimeout = time.Duration(5) * time.Minute
+)
+
+// Cfg contains all client configuration parameters.
+//
+// The structure supports loading from multiple sources:
+// - Environment variables (primary)
+// - Command-line flags (secondary)
+// - Default values (fallback)
+//
+// Tags specify the corresponding environment variable names.
+type Cfg struct {
+	// ServerAddr defines gRPC server address, its host is checked against the server certificate
+	ServerAddr string `env:"SERVER_ADDRESS"`
+
+	// IdleTimeout defines inactivity period before the vault is locked, zero disables locking
+	IdleTimeout time.Duration `env:"IDLE_TIMEOUT"`
+
+	// TraceEndpoint defines OTLP gRPC collector address, empty disables export
+	TraceEndpoint string `env:"TRACE_ENDPOINT"`
+
+	// TraceInsecure disables TLS to the trace collector
+	TraceInsecure bool `env:"TRACE_INSECURE"`
+
+	// CAFileName specifies extra root certificates to trust the server, e.g. of a development server
+	CAFileName string `env:"TLS_CA"`
+
+	// CertFileName specifies client certificate for servers requiring mTLS
+	CertFileName string `env:"TLS_CERT"`
+
+	// CertKeyFileName specifies client certificate key file
+	CertKeyFileName string `env:"TLS_KEY"`
+}
+
+// ConfigBuilder implements builder pattern for Cfg.
+type ConfigBuilder struct {
+	cfg *Cfg
+	err error
+}
+
+// NewConfigBuilder creates a new configuration builder with default values.
+func NewConfigBuilder() *ConfigBuilder {
+	return &ConfigBuilder{
+		cfg: &Cfg{
+			ServerAddr:  defaultServerAddr,
+			IdleTimeout: defaultIdleTimeout,
+		},
+		err: nil,
+	}
+}
+
+// WithFlagParsing parses command-line flags into configuration.
+func (b *ConfigBuilder) WithFlagParsing() *ConfigBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	flag.StringVarP(&b.cfg.ServerAddr, "s", "s", b.cfg.ServerAddr, "gRPC server address")
+	flag.DurationVarP(&b.cfg.IdleTimeout, "i", "i", b.cfg.IdleTimeout, "Idle timeout before vault lock, 0 to disable")
+	flag.StringVar(&b.cfg.TraceEndpoint, "trace-endpoint", b.cfg.TraceEndpoint, "OTLP gRPC trace collector address")
+	flag.BoolVar(&b.cfg.TraceInsecure, "trace-insecure", b.cfg.TraceInsecure, "Disable TLS to trace collector")
+	flag.StringVar(&b.cfg.CAFileName, "tls-ca", b.cfg.CAFileName, "Path to extra root certificates of the server")
+	flag.StringVar(&b.cfg.CertFileName, "tls-cert", b.cfg.CertFileName, "Path to client certificate")
+	flag.StringVar(&b.cfg.CertKeyFileName, "tls-key", b.cfg.CertKeyFileName, "Path to client certificate key")
+	flag.Parse()
+
+	return b
+}
+
+// WithEnvParsing loads environment variables into configuration.
+func (b *ConfigBuilder) WithEnvParsing() *ConfigBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	err := env.Parse(b.cfg)
+	if err != nil {
+		b.cfg = nil
+		b.err = fmt.Errorf("can't parse env vars: %v", err)
+		return b
+	}
+
+	return b
+}
+
+// Build finalizes configuration.
+func (b *ConfigBuilder) Build() (*Cfg, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if b.cfg.IdleTimeout < 0 {
+		return nil, fmt.Errorf("negative idle timeout: %v", b.cfg.IdleTimeout)
+	}
+
+	if (b.cfg.CertFileName == "") != (b.cfg.CertKeyFileName == "") {
+		return nil, fmt.Errorf("client certificate and key must be set together")
+	}
+
+	return b.cfg, nil
+}
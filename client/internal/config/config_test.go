@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testServerAddr  = "localhost:50052"
+	testIdleTimeout = time.Duration(30) * time.Second
+	testTraceAddr   = "localhost:4317"
+	testCA          = "ca.pem"
+	testCert        = "client.pem"
+	testCertKey     = "client-key.pem"
+)
+
+var testCfg = &Cfg{
+	ServerAddr:  testServerAddr,
+	IdleTimeout: testIdleTimeout,
+
+	TraceEndpoint: testTraceAddr,
+	TraceInsecure: true,
+
+	CAFileName:      testCA,
+	CertFileName:    testCert,
+	CertKeyFileName: testCertKey,
+}
+
+func TestNewConfigBuilder(t *testing.T) {
+	t.Run("should use defaults", func(t *testing.T) {
+		cfg, err := NewConfigBuilder().Build()
+		require.NoError(t, err)
+		assert.Equal(t, defaultServerAddr, cfg.ServerAddr)
+		assert.Equal(t, defaultIdleTimeout, cfg.IdleTimeout)
+	})
+}
+
+func TestConfigBuilder_WithEnvParsing(t *testing.T) {
+	t.Run("valid test", func(t *testing.T) {
+		t.Setenv("SERVER_ADDRESS", testCfg.ServerAddr)
+		t.Setenv("IDLE_TIMEOUT", testCfg.IdleTimeout.String())
+		t.Setenv("TRACE_ENDPOINT", testTraceAddr)
+		t.Setenv("TRACE_INSECURE", "true")
+		t.Setenv("TLS_CA", testCA)
+		t.Setenv("TLS_CERT", testCert)
+		t.Setenv("TLS_KEY", testCertKey)
+
+		cfg, err := NewConfigBuilder().
+			WithEnvParsing().
+			Build()
+		assert.NoError(t, err)
+		assert.Equal(t, testCfg, cfg)
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		t.Setenv("IDLE_TIMEOUT", "soon")
+
+		_, err := NewConfigBuilder().
+			WithEnvParsing().
+			Build()
+		assert.Error(t, err)
+	})
+
+	t.Run("negative idle timeout", func(t *testing.T) {
+		t.Setenv("IDLE_TIMEOUT", "-1s")
+
+		_, err := NewConfigBuilder().
+			WithEnvParsing().
+			Build()
+		assert.Error(t, err)
+	})
+
+	t.Run("certificate without key", func(t *testing.T) {
+		t.Setenv("TLS_CERT", testCert)
+
+		_, err := NewConfigBuilder().
+			WithEnvParsing().
+			Build()
+		assert.Error(t, err)
+	})
+}
+
+func TestConfigBuilder_WithFlagParsing(t *testing.T) {
+	pflag.CommandLine = pflag.NewFlagSet(os.Args[0], pflag.ExitOnError)
+
+	oldArgs := os.Args
+	defer func() {
+		os.Args = oldArgs
+	}()
+
+	t.Run("valid test", func(t *testing.T) {
+		os.Args = []string{
+			"./client",
+			"-s=" + testCfg.ServerAddr,
+			"-i=" + testCfg.IdleTimeout.String(),
+			"--trace-endpoint=" + testTraceAddr,
+			"--trace-insecure",
+			"--tls-ca=" + testCA,
+			"--tls-cert=" + testCert,
+			"--tls-key=" + testCertKey,
+		}
+
+		cfg, err := NewConfigBuilder().
+			WithFlagParsing().
+			Build()
+		assert.NoError(t, err)
+		assert.Equal(t, testCfg, cfg)
+	})
+}
@@ -2,11 +2,14 @@ package grpc
 
 import (
 	"context"
+	"time"
 
 	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
 	"github.com/rycln/gokeep/shared/models"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -25,22 +28,59 @@ func NewGophKeeperClient(conn *grpc.ClientConn) *GophKeeperClient {
 // Register performs user registration via gRPC
 func (c *GophKeeperClient) Register(ctx context.Context, req *models.UserRegReq) (*models.User, error) {
 	res, err := c.client.Register(ctx, &pb.RegisterRequest{
-		Username: req.Username,
-		Password: req.Password,
-		Salt:     req.Salt,
+		Username:            req.Username,
+		Password:            req.Password,
+		Salt:                req.Salt,
+		EncryptedKey:        req.EncryptedKey,
+		RecoveryKey:         req.RecoveryKey,
+		RecoveryAuth:        req.RecoveryAuth,
+		PublicKey:           req.PublicKey,
+		EncryptedPrivateKey: req.EncryptedPrivateKey,
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, validationError(err)
 	}
 
 	return &models.User{
-		ID:   models.UserID(res.UserId),
-		JWT:  res.Token,
-		Salt: res.Salt,
+		ID:           models.UserID(res.UserId),
+		JWT:          res.Token,
+		Salt:         res.Salt,
+		EncryptedKey: res.EncryptedKey,
+		KeyPair: models.KeyPair{
+			PublicKey:           res.PublicKey,
+			EncryptedPrivateKey: res.EncryptedPrivateKey,
+		},
 	}, err
 }
 
+// validationError converts BadRequest details of gRPC status to *models.ValidationError
+// Other errors are returned unchanged
+func validationError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	for _, detail := range st.Details() {
+		badReq, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		verr := &models.ValidationError{}
+		for _, v := range badReq.FieldViolations {
+			verr.Violations = append(verr.Violations, models.FieldViolation{
+				Field:       v.Field,
+				Reason:      v.Reason,
+				Description: v.Description,
+			})
+		}
+		return verr
+	}
+
+	return err
+}
+
 // Login performs user authentication via gRPC
 func (c *GophKeeperClient) Login(ctx context.Context, req *models.UserLoginReq) (*models.User, error) {
 	res, err := c.client.Login(ctx, &pb.LoginRequest{
@@ -53,12 +93,69 @@ func (c *GophKeeperClient) Login(ctx context.Context, req *models.UserLoginReq)
 	}
 
 	return &models.User{
-		ID:   models.UserID(res.UserId),
-		JWT:  res.Token,
-		Salt: res.Salt,
+		ID:           models.UserID(res.UserId),
+		JWT:          res.Token,
+		Salt:         res.Salt,
+		EncryptedKey: res.EncryptedKey,
+		KeyPair: models.KeyPair{
+			PublicKey:           res.PublicKey,
+			EncryptedPrivateKey: res.EncryptedPrivateKey,
+		},
 	}, err
 }
 
+// Recover performs recovery key authentication via gRPC
+func (c *GophKeeperClient) Recover(ctx context.Context, req *models.UserRecoverReq) (*models.User, error) {
+	res, err := c.client.Recover(ctx, &pb.RecoverRequest{
+		Username:     req.Username,
+		RecoveryAuth: req.RecoveryAuth,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.User{
+		ID:          models.UserID(res.UserId),
+		JWT:         res.Token,
+		Salt:        res.Salt,
+		RecoveryKey: res.RecoveryKey,
+		KeyPair: models.KeyPair{
+			PublicKey:           res.PublicKey,
+			EncryptedPrivateKey: res.EncryptedPrivateKey,
+		},
+	}, nil
+}
+
+// ChangePassword replaces user credentials and wrapped vault key via gRPC
+func (c *GophKeeperClient) ChangePassword(ctx context.Context, req *models.PasswordChangeReq, jwt string) error {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := c.client.ChangePassword(ctx, &pb.ChangePasswordRequest{
+		Password:        req.Password,
+		Salt:            req.Salt,
+		EncryptedKey:    req.EncryptedKey,
+		CurrentPassword: req.CurrentPassword,
+		RecoveryAuth:    req.RecoveryAuth,
+	})
+
+	return validationError(err)
+}
+
+// DeleteAccount removes the account with all server data via gRPC
+// The password is verified again by the server
+func (c *GophKeeperClient) DeleteAccount(ctx context.Context, password string, jwt string) error {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := c.client.DeleteAccount(ctx, &pb.DeleteAccountRequest{
+		Password: password,
+	})
+
+	return err
+}
+
 // Sync performs bidirectional items synchronization with server via gRPC
 // Converts local items to protobuf format and back
 func (c *GophKeeperClient) Sync(ctx context.Context, clientItems []models.Item, jwt string) ([]models.Item, error) {
@@ -67,6 +164,7 @@ func (c *GophKeeperClient) Sync(ctx context.Context, clientItems []models.Item,
 		var reqitem = &pb.Item{}
 		reqitem.Id = string(item.ID)
 		reqitem.UserId = string(item.UserID)
+		reqitem.CollectionId = string(item.CollectionID)
 		reqitem.Type = string(item.ItemType)
 		reqitem.Name = item.Name
 		reqitem.Metadata = item.Metadata
@@ -91,6 +189,7 @@ func (c *GophKeeperClient) Sync(ctx context.Context, clientItems []models.Item,
 	for i, resitem := range res.Items {
 		serverItems[i].ID = models.ItemID(resitem.Id)
 		serverItems[i].UserID = models.UserID(resitem.UserId)
+		serverItems[i].CollectionID = models.CollectionID(resitem.CollectionId)
 		serverItems[i].ItemType = models.ItemType(resitem.Type)
 		serverItems[i].Name = resitem.Name
 		serverItems[i].Metadata = resitem.Metadata
@@ -101,3 +200,355 @@ func (c *GophKeeperClient) Sync(ctx context.Context, clientItems []models.Item,
 
 	return serverItems, nil
 }
+
+// SetKeyPair uploads sharing keypair of the user via gRPC
+func (c *GophKeeperClient) SetKeyPair(ctx context.Context, kp *models.KeyPair, jwt string) error {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := c.client.SetKeyPair(ctx, &pb.KeyPairRequest{
+		PublicKey:           kp.PublicKey,
+		EncryptedPrivateKey: kp.EncryptedPrivateKey,
+	})
+
+	return err
+}
+
+// GetPublicKey fetches public key of a sharing recipient via gRPC
+func (c *GophKeeperClient) GetPublicKey(ctx context.Context, username string, jwt string) (*models.PublicKey, error) {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	res, err := c.client.GetPublicKey(ctx, &pb.PublicKeyRequest{
+		Username: username,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PublicKey{
+		UserID: models.UserID(res.UserId),
+		Key:    res.PublicKey,
+	}, nil
+}
+
+// ShareItem sends an item encrypted for the recipient via gRPC
+func (c *GophKeeperClient) ShareItem(ctx context.Context, share *models.Share, jwt string) error {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := c.client.ShareItem(ctx, &pb.ShareItemRequest{
+		ItemId:     string(share.ItemID),
+		Recipient:  share.Recipient,
+		WrappedKey: share.WrappedKey,
+		Payload:    share.Payload,
+	})
+
+	return err
+}
+
+// ListSharedWithMe fetches items shared with the user via gRPC
+func (c *GophKeeperClient) ListSharedWithMe(ctx context.Context, jwt string) ([]models.Share, error) {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	res, err := c.client.ListSharedWithMe(ctx, &pb.ListSharedRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	var shares = make([]models.Share, len(res.Items))
+	for i, resitem := range res.Items {
+		shares[i].ItemID = models.ItemID(resitem.ItemId)
+		shares[i].Owner = resitem.Owner
+		shares[i].WrappedKey = resitem.WrappedKey
+		shares[i].Payload = resitem.Payload
+		shares[i].SharedAt = resitem.SharedAt.AsTime()
+	}
+
+	return shares, nil
+}
+
+// RevokeShare removes recipient access to an item via gRPC
+func (c *GophKeeperClient) RevokeShare(ctx context.Context, id models.ItemID, recipient string, jwt string) error {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := c.client.RevokeShare(ctx, &pb.RevokeShareRequest{
+		ItemId:    string(id),
+		Recipient: recipient,
+	})
+
+	return err
+}
+
+// CreateOrganization creates organization with its first collection via gRPC
+func (c *GophKeeperClient) CreateOrganization(
+	ctx context.Context,
+	name string,
+	col *models.Collection,
+	jwt string,
+) (*models.Collection, error) {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	res, err := c.client.CreateOrganization(ctx, &pb.CreateOrganizationRequest{
+		Name:           name,
+		CollectionName: col.Name,
+		WrappedKey:     col.WrappedKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Collection{
+		ID:         models.CollectionID(res.CollectionId),
+		OrgID:      models.OrgID(res.OrgId),
+		OrgName:    name,
+		Name:       col.Name,
+		Role:       models.RoleOwner,
+		WrappedKey: col.WrappedKey,
+	}, nil
+}
+
+// CreateCollection creates organization collection with keys wrapped for members via gRPC
+func (c *GophKeeperClient) CreateCollection(
+	ctx context.Context,
+	col *models.Collection,
+	keys []models.CollectionKey,
+	jwt string,
+) (*models.Collection, error) {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	res, err := c.client.CreateCollection(ctx, &pb.CreateCollectionRequest{
+		OrgId: string(col.OrgID),
+		Name:  col.Name,
+		Keys:  pbCollectionKeys(keys),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created := *col
+	created.ID = models.CollectionID(res.CollectionId)
+	return &created, nil
+}
+
+// AddMember adds account to organization with collection keys wrapped for it via gRPC
+func (c *GophKeeperClient) AddMember(
+	ctx context.Context,
+	org models.OrgID,
+	member *models.Member,
+	keys []models.CollectionKey,
+	jwt string,
+) error {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := c.client.AddMember(ctx, &pb.AddMemberRequest{
+		OrgId:    string(org),
+		Username: member.Username,
+		Role:     string(member.Role),
+		Keys:     pbCollectionKeys(keys),
+	})
+
+	return err
+}
+
+// ListMembers fetches organization members with their public keys via gRPC
+func (c *GophKeeperClient) ListMembers(ctx context.Context, org models.OrgID, jwt string) ([]models.Member, error) {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	res, err := c.client.ListMembers(ctx, &pb.ListMembersRequest{
+		OrgId: string(org),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var members = make([]models.Member, len(res.Members))
+	for i, resmember := range res.Members {
+		members[i].UserID = models.UserID(resmember.UserId)
+		members[i].Username = resmember.Username
+		members[i].Role = models.Role(resmember.Role)
+		members[i].PublicKey = resmember.PublicKey
+	}
+
+	return members, nil
+}
+
+// ListCollections fetches collections available to the user via gRPC
+func (c *GophKeeperClient) ListCollections(ctx context.Context, jwt string) ([]models.Collection, error) {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	res, err := c.client.ListCollections(ctx, &pb.ListCollectionsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	var cols = make([]models.Collection, len(res.Collections))
+	for i, rescol := range res.Collections {
+		cols[i].ID = models.CollectionID(rescol.Id)
+		cols[i].OrgID = models.OrgID(rescol.OrgId)
+		cols[i].OrgName = rescol.OrgName
+		cols[i].Name = rescol.Name
+		cols[i].Role = models.Role(rescol.Role)
+		cols[i].WrappedKey = rescol.WrappedKey
+	}
+
+	return cols, nil
+}
+
+// pbCollectionKeys converts collection keys to protobuf format
+func pbCollectionKeys(keys []models.CollectionKey) []*pb.CollectionKey {
+	var reqkeys = make([]*pb.CollectionKey, len(keys))
+	for i, key := range keys {
+		reqkeys[i] = &pb.CollectionKey{
+			CollectionId: string(key.CollectionID),
+			UserId:       string(key.UserID),
+			WrappedKey:   key.WrappedKey,
+		}
+	}
+	return reqkeys
+}
+
+// AddEmergencyContact nominates trusted contact with vault key wrapped for it via gRPC
+func (c *GophKeeperClient) AddEmergencyContact(ctx context.Context, contact *models.EmergencyContact, jwt string) error {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := c.client.AddEmergencyContact(ctx, &pb.AddEmergencyContactRequest{
+		Grantee:     contact.Grantee,
+		WaitSeconds: int64(contact.WaitPeriod / time.Second),
+		WrappedKey:  contact.WrappedKey,
+	})
+
+	return err
+}
+
+// ListEmergencyContacts fetches trusted contacts nominated by the user via gRPC
+func (c *GophKeeperClient) ListEmergencyContacts(ctx context.Context, jwt string) ([]models.EmergencyContact, error) {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	res, err := c.client.ListEmergencyContacts(ctx, &pb.ListEmergencyContactsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return emergencyContacts(res.Contacts), nil
+}
+
+// ListEmergencyGrants fetches vaults the user is trusted with via gRPC
+func (c *GophKeeperClient) ListEmergencyGrants(ctx context.Context, jwt string) ([]models.EmergencyContact, error) {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	res, err := c.client.ListEmergencyGrants(ctx, &pb.ListEmergencyGrantsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return emergencyContacts(res.Grants), nil
+}
+
+// RequestEmergencyAccess starts waiting period for the grantor vault via gRPC
+func (c *GophKeeperClient) RequestEmergencyAccess(ctx context.Context, grantor, jwt string) error {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := c.client.RequestEmergencyAccess(ctx, &pb.RequestEmergencyAccessRequest{
+		Grantor: grantor,
+	})
+
+	return err
+}
+
+// DenyEmergencyAccess denies pending request of the trusted contact via gRPC
+func (c *GophKeeperClient) DenyEmergencyAccess(ctx context.Context, grantee, jwt string) error {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := c.client.DenyEmergencyAccess(ctx, &pb.DenyEmergencyAccessRequest{
+		Grantee: grantee,
+	})
+
+	return err
+}
+
+// GetEmergencyVault fetches the grantor vault released to the user via gRPC
+func (c *GophKeeperClient) GetEmergencyVault(ctx context.Context, grantor, jwt string) (*models.EmergencyVault, error) {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	res, err := c.client.GetEmergencyVault(ctx, &pb.EmergencyVaultRequest{
+		Grantor: grantor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var vault = &models.EmergencyVault{
+		WrappedKey: res.WrappedKey,
+		Items:      make([]models.Item, len(res.Items)),
+	}
+	for i, resitem := range res.Items {
+		vault.Items[i].ID = models.ItemID(resitem.Id)
+		vault.Items[i].UserID = models.UserID(resitem.UserId)
+		vault.Items[i].ItemType = models.ItemType(resitem.Type)
+		vault.Items[i].Name = resitem.Name
+		vault.Items[i].Metadata = resitem.Metadata
+		vault.Items[i].Data = resitem.Data
+		vault.Items[i].UpdatedAt = resitem.UpdatedAt.AsTime()
+	}
+
+	return vault, nil
+}
+
+// ListAuditEvents fetches a page of the account audit log via gRPC
+// Empty token requests the newest events
+func (c *GophKeeperClient) ListAuditEvents(ctx context.Context, token string, size int, jwt string) (*models.AuditPage, error) {
+	md := metadata.Pairs("authorization", "Bearer "+jwt)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	res, err := c.client.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{
+		PageSize:  int32(size),
+		PageToken: token,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var page = &models.AuditPage{
+		Events:    make([]models.AuditEvent, len(res.Events)),
+		NextToken: res.NextPageToken,
+	}
+	for i, resevent := range res.Events {
+		page.Events[i].ID = resevent.Id
+		page.Events[i].Device = resevent.Device
+		page.Events[i].IP = resevent.Ip
+		page.Events[i].Action = models.AuditAction(resevent.Action)
+		page.Events[i].Result = models.AuditResult(resevent.Result)
+		page.Events[i].CreatedAt = resevent.CreatedAt.AsTime()
+	}
+
+	return page, nil
+}
+
+// emergencyContacts converts emergency contacts from protobuf format
+func emergencyContacts(rescontacts []*pb.EmergencyContact) []models.EmergencyContact {
+	var contacts = make([]models.EmergencyContact, len(rescontacts))
+	for i, rescontact := range rescontacts {
+		contacts[i].Grantor = rescontact.Grantor
+		contacts[i].Grantee = rescontact.Grantee
+		contacts[i].WaitPeriod = time.Duration(rescontact.WaitSeconds) * time.Second
+		contacts[i].Status = models.EmergencyStatus(rescontact.Status)
+		if rescontact.RequestedAt != nil {
+			contacts[i].RequestedAt = rescontact.RequestedAt.AsTime()
+		}
+	}
+	return contacts
+}
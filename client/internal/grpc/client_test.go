@@ -10,8 +10,11 @@ import (
 	"github.com/rycln/gokeep/shared/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -30,6 +33,23 @@ type mockGophKeeperClient struct {
 	registerFunc func(ctx context.Context, in *gophkeeper.RegisterRequest, opts ...grpc.CallOption) (*gophkeeper.AuthResponse, error)
 	loginFunc    func(ctx context.Context, in *gophkeeper.LoginRequest, opts ...grpc.CallOption) (*gophkeeper.AuthResponse, error)
 	syncFunc     func(ctx context.Context, in *gophkeeper.SyncRequest, opts ...grpc.CallOption) (*gophkeeper.SyncResponse, error)
+	recoverFunc  func(ctx context.Context, in *gophkeeper.RecoverRequest, opts ...grpc.CallOption) (*gophkeeper.AuthResponse, error)
+	changeFunc   func(ctx context.Context, in *gophkeeper.ChangePasswordRequest, opts ...grpc.CallOption) (*gophkeeper.ChangePasswordResponse, error)
+	deleteFunc   func(ctx context.Context, in *gophkeeper.DeleteAccountRequest, opts ...grpc.CallOption) (*gophkeeper.DeleteAccountResponse, error)
+	keyPairFunc  func(ctx context.Context, in *gophkeeper.KeyPairRequest, opts ...grpc.CallOption) (*gophkeeper.KeyPairResponse, error)
+	pubKeyFunc   func(ctx context.Context, in *gophkeeper.PublicKeyRequest, opts ...grpc.CallOption) (*gophkeeper.PublicKeyResponse, error)
+	shareFunc    func(ctx context.Context, in *gophkeeper.ShareItemRequest, opts ...grpc.CallOption) (*gophkeeper.ShareItemResponse, error)
+	listFunc     func(ctx context.Context, in *gophkeeper.ListSharedRequest, opts ...grpc.CallOption) (*gophkeeper.ListSharedResponse, error)
+	revokeFunc   func(ctx context.Context, in *gophkeeper.RevokeShareRequest, opts ...grpc.CallOption) (*gophkeeper.RevokeShareResponse, error)
+	createOrg    func(ctx context.Context, in *gophkeeper.CreateOrganizationRequest, opts ...grpc.CallOption) (*gophkeeper.CreateOrganizationResponse, error)
+	createCol    func(ctx context.Context, in *gophkeeper.CreateCollectionRequest, opts ...grpc.CallOption) (*gophkeeper.CreateCollectionResponse, error)
+	addMember    func(ctx context.Context, in *gophkeeper.AddMemberRequest, opts ...grpc.CallOption) (*gophkeeper.AddMemberResponse, error)
+	listMembers  func(ctx context.Context, in *gophkeeper.ListMembersRequest, opts ...grpc.CallOption) (*gophkeeper.ListMembersResponse, error)
+	listCols     func(ctx context.Context, in *gophkeeper.ListCollectionsRequest, opts ...grpc.CallOption) (*gophkeeper.ListCollectionsResponse, error)
+	addContact   func(ctx context.Context, in *gophkeeper.AddEmergencyContactRequest, opts ...grpc.CallOption) (*gophkeeper.AddEmergencyContactResponse, error)
+	listGrants   func(ctx context.Context, in *gophkeeper.ListEmergencyGrantsRequest, opts ...grpc.CallOption) (*gophkeeper.ListEmergencyGrantsResponse, error)
+	getVault     func(ctx context.Context, in *gophkeeper.EmergencyVaultRequest, opts ...grpc.CallOption) (*gophkeeper.EmergencyVaultResponse, error)
+	listAudit    func(ctx context.Context, in *gophkeeper.ListAuditEventsRequest, opts ...grpc.CallOption) (*gophkeeper.ListAuditEventsResponse, error)
 }
 
 func (m *mockGophKeeperClient) Register(ctx context.Context, in *gophkeeper.RegisterRequest, opts ...grpc.CallOption) (*gophkeeper.AuthResponse, error) {
@@ -44,6 +64,74 @@ func (m *mockGophKeeperClient) Sync(ctx context.Context, in *gophkeeper.SyncRequ
 	return m.syncFunc(ctx, in, opts...)
 }
 
+func (m *mockGophKeeperClient) Recover(ctx context.Context, in *gophkeeper.RecoverRequest, opts ...grpc.CallOption) (*gophkeeper.AuthResponse, error) {
+	return m.recoverFunc(ctx, in, opts...)
+}
+
+func (m *mockGophKeeperClient) ChangePassword(ctx context.Context, in *gophkeeper.ChangePasswordRequest, opts ...grpc.CallOption) (*gophkeeper.ChangePasswordResponse, error) {
+	return m.changeFunc(ctx, in, opts...)
+}
+
+func (m *mockGophKeeperClient) DeleteAccount(ctx context.Context, in *gophkeeper.DeleteAccountRequest, opts ...grpc.CallOption) (*gophkeeper.DeleteAccountResponse, error) {
+	return m.deleteFunc(ctx, in, opts...)
+}
+
+func (m *mockGophKeeperClient) SetKeyPair(ctx context.Context, in *gophkeeper.KeyPairRequest, opts ...grpc.CallOption) (*gophkeeper.KeyPairResponse, error) {
+	return m.keyPairFunc(ctx, in, opts...)
+}
+
+func (m *mockGophKeeperClient) GetPublicKey(ctx context.Context, in *gophkeeper.PublicKeyRequest, opts ...grpc.CallOption) (*gophkeeper.PublicKeyResponse, error) {
+	return m.pubKeyFunc(ctx, in, opts...)
+}
+
+func (m *mockGophKeeperClient) ShareItem(ctx context.Context, in *gophkeeper.ShareItemRequest, opts ...grpc.CallOption) (*gophkeeper.ShareItemResponse, error) {
+	return m.shareFunc(ctx, in, opts...)
+}
+
+func (m *mockGophKeeperClient) ListSharedWithMe(ctx context.Context, in *gophkeeper.ListSharedRequest, opts ...grpc.CallOption) (*gophkeeper.ListSharedResponse, error) {
+	return m.listFunc(ctx, in, opts...)
+}
+
+func (m *mockGophKeeperClient) RevokeShare(ctx context.Context, in *gophkeeper.RevokeShareRequest, opts ...grpc.CallOption) (*gophkeeper.RevokeShareResponse, error) {
+	return m.revokeFunc(ctx, in, opts...)
+}
+
+func (m *mockGophKeeperClient) CreateOrganization(ctx context.Context, in *gophkeeper.CreateOrganizationRequest, opts ...grpc.CallOption) (*gophkeeper.CreateOrganizationResponse, error) {
+	return m.createOrg(ctx, in, opts...)
+}
+
+func (m *mockGophKeeperClient) CreateCollection(ctx context.Context, in *gophkeeper.CreateCollectionRequest, opts ...grpc.CallOption) (*gophkeeper.CreateCollectionResponse, error) {
+	return m.createCol(ctx, in, opts...)
+}
+
+func (m *mockGophKeeperClient) AddMember(ctx context.Context, in *gophkeeper.AddMemberRequest, opts ...grpc.CallOption) (*gophkeeper.AddMemberResponse, error) {
+	return m.addMember(ctx, in, opts...)
+}
+
+func (m *mockGophKeeperClient) ListMembers(ctx context.Context, in *gophkeeper.ListMembersRequest, opts ...grpc.CallOption) (*gophkeeper.ListMembersResponse, error) {
+	return m.listMembers(ctx, in, opts...)
+}
+
+func (m *mockGophKeeperClient) ListCollections(ctx context.Context, in *gophkeeper.ListCollectionsRequest, opts ...grpc.CallOption) (*gophkeeper.ListCollectionsResponse, error) {
+	return m.listCols(ctx, in, opts...)
+}
+
+func (m *mockGophKeeperClient) AddEmergencyContact(ctx context.Context, in *gophkeeper.AddEmergencyContactRequest, opts ...grpc.CallOption) (*gophkeeper.AddEmergencyContactResponse, error) {
+	return m.addContact(ctx, in, opts...)
+}
+
+func (m *mockGophKeeperClient) ListEmergencyGrants(ctx context.Context, in *gophkeeper.ListEmergencyGrantsRequest, opts ...grpc.CallOption) (*gophkeeper.ListEmergencyGrantsResponse, error) {
+	return m.listGrants(ctx, in, opts...)
+}
+
+func (m *mockGophKeeperClient) GetEmergencyVault(ctx context.Context, in *gophkeeper.EmergencyVaultRequest, opts ...grpc.CallOption) (*gophkeeper.EmergencyVaultResponse, error) {
+	return m.getVault(ctx, in, opts...)
+}
+
+func (m *mockGophKeeperClient) ListAuditEvents(ctx context.Context, in *gophkeeper.ListAuditEventsRequest, opts ...grpc.CallOption) (*gophkeeper.ListAuditEventsResponse, error) {
+	return m.listAudit(ctx, in, opts...)
+}
+
 func TestNewGophKeeperClient(t *testing.T) {
 	t.Run("should create new client", func(t *testing.T) {
 		conn := &grpc.ClientConn{}
@@ -99,6 +187,29 @@ func TestGophKeeperClient_Register(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, expectedErr, err)
 	})
+
+	t.Run("validation error", func(t *testing.T) {
+		st, err := status.New(codes.InvalidArgument, "invalid credentials").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: models.FieldUsername, Reason: models.ReasonUsernameTaken, Description: "username is taken"},
+			},
+		})
+		require.NoError(t, err)
+		mockClient := &mockGophKeeperClient{
+			registerFunc: func(ctx context.Context, in *gophkeeper.RegisterRequest, opts ...grpc.CallOption) (*gophkeeper.AuthResponse, error) {
+				return nil, st.Err()
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		_, err = client.Register(ctx, testReq)
+
+		var verr *models.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Equal(t, []models.FieldViolation{
+			{Field: models.FieldUsername, Reason: models.ReasonUsernameTaken, Description: "username is taken"},
+		}, verr.Violations)
+	})
 }
 
 func TestGophKeeperClient_Login(t *testing.T) {
@@ -146,6 +257,151 @@ func TestGophKeeperClient_Login(t *testing.T) {
 	})
 }
 
+func TestGophKeeperClient_Recover(t *testing.T) {
+	ctx := context.Background()
+	testReq := &models.UserRecoverReq{
+		Username:     testUser,
+		RecoveryAuth: "recovery_auth",
+	}
+
+	t.Run("successful recovery", func(t *testing.T) {
+		mockClient := &mockGophKeeperClient{
+			recoverFunc: func(ctx context.Context, in *gophkeeper.RecoverRequest, opts ...grpc.CallOption) (*gophkeeper.AuthResponse, error) {
+				assert.Equal(t, testUser, in.Username)
+				assert.Equal(t, "recovery_auth", in.RecoveryAuth)
+				return &gophkeeper.AuthResponse{
+					UserId:      testUserID,
+					Token:       testToken,
+					Salt:        testSalt,
+					RecoveryKey: "recovery_key",
+				}, nil
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		user, err := client.Recover(ctx, testReq)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.UserID(testUserID), user.ID)
+		assert.Equal(t, testToken, user.JWT)
+		assert.Equal(t, "recovery_key", user.RecoveryKey)
+	})
+
+	t.Run("recovery error", func(t *testing.T) {
+		expectedErr := errors.New("recovery failed")
+		mockClient := &mockGophKeeperClient{
+			recoverFunc: func(ctx context.Context, in *gophkeeper.RecoverRequest, opts ...grpc.CallOption) (*gophkeeper.AuthResponse, error) {
+				return nil, expectedErr
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		_, err := client.Recover(ctx, testReq)
+
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
+func TestGophKeeperClient_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	testReq := &models.PasswordChangeReq{
+		Password:     testPass,
+		Salt:         testSalt,
+		EncryptedKey: "encrypted_key",
+		RecoveryAuth: "recovery_auth",
+	}
+
+	t.Run("successful change", func(t *testing.T) {
+		mockClient := &mockGophKeeperClient{
+			changeFunc: func(ctx context.Context, in *gophkeeper.ChangePasswordRequest, opts ...grpc.CallOption) (*gophkeeper.ChangePasswordResponse, error) {
+				md, ok := metadata.FromOutgoingContext(ctx)
+				require.True(t, ok)
+				assert.Equal(t, []string{"Bearer " + testToken}, md.Get("authorization"))
+				assert.Equal(t, testPass, in.Password)
+				assert.Equal(t, testSalt, in.Salt)
+				assert.Equal(t, "encrypted_key", in.EncryptedKey)
+				assert.Equal(t, "recovery_auth", in.RecoveryAuth)
+				return &gophkeeper.ChangePasswordResponse{}, nil
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		err := client.ChangePassword(ctx, testReq, testToken)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("change error", func(t *testing.T) {
+		expectedErr := errors.New("change failed")
+		mockClient := &mockGophKeeperClient{
+			changeFunc: func(ctx context.Context, in *gophkeeper.ChangePasswordRequest, opts ...grpc.CallOption) (*gophkeeper.ChangePasswordResponse, error) {
+				return nil, expectedErr
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		err := client.ChangePassword(ctx, testReq, testToken)
+
+		assert.Equal(t, expectedErr, err)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		st, err := status.New(codes.InvalidArgument, "invalid request").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: models.FieldPassword, Reason: models.ReasonPasswordWeak, Description: "too weak"},
+			},
+		})
+		require.NoError(t, err)
+		mockClient := &mockGophKeeperClient{
+			changeFunc: func(ctx context.Context, in *gophkeeper.ChangePasswordRequest, opts ...grpc.CallOption) (*gophkeeper.ChangePasswordResponse, error) {
+				return nil, st.Err()
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		err = client.ChangePassword(ctx, testReq, testToken)
+
+		var verr *models.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Equal(t, models.ReasonPasswordWeak, verr.Violations[0].Reason)
+	})
+}
+
+func TestGophKeeperClient_DeleteAccount(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful deletion", func(t *testing.T) {
+		mockClient := &mockGophKeeperClient{
+			deleteFunc: func(ctx context.Context, in *gophkeeper.DeleteAccountRequest, opts ...grpc.CallOption) (*gophkeeper.DeleteAccountResponse, error) {
+				md, ok := metadata.FromOutgoingContext(ctx)
+				require.True(t, ok)
+				assert.Equal(t, []string{"Bearer " + testToken}, md.Get("authorization"))
+				assert.Equal(t, testPass, in.Password)
+				return &gophkeeper.DeleteAccountResponse{}, nil
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		err := client.DeleteAccount(ctx, testPass, testToken)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("deletion error", func(t *testing.T) {
+		expectedErr := errors.New("delete failed")
+		mockClient := &mockGophKeeperClient{
+			deleteFunc: func(ctx context.Context, in *gophkeeper.DeleteAccountRequest, opts ...grpc.CallOption) (*gophkeeper.DeleteAccountResponse, error) {
+				return nil, expectedErr
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		err := client.DeleteAccount(ctx, testPass, testToken)
+
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
 func TestGophKeeperClient_Sync(t *testing.T) {
 	ctx := context.Background()
 	testTime := time.Now()
@@ -242,3 +498,430 @@ func TestGophKeeperClient_Sync(t *testing.T) {
 		assert.Empty(t, items)
 	})
 }
+
+func TestGophKeeperClient_SetKeyPair(t *testing.T) {
+	ctx := context.Background()
+	kp := &models.KeyPair{
+		PublicKey:           []byte("public_key"),
+		EncryptedPrivateKey: "encrypted_private_key",
+	}
+
+	t.Run("successful upload", func(t *testing.T) {
+		mockClient := &mockGophKeeperClient{
+			keyPairFunc: func(ctx context.Context, in *gophkeeper.KeyPairRequest, opts ...grpc.CallOption) (*gophkeeper.KeyPairResponse, error) {
+				md, ok := metadata.FromOutgoingContext(ctx)
+				require.True(t, ok)
+				assert.Equal(t, []string{"Bearer " + testToken}, md.Get("authorization"))
+				assert.Equal(t, kp.PublicKey, in.PublicKey)
+				assert.Equal(t, kp.EncryptedPrivateKey, in.EncryptedPrivateKey)
+				return &gophkeeper.KeyPairResponse{}, nil
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		err := client.SetKeyPair(ctx, kp, testToken)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestGophKeeperClient_GetPublicKey(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful lookup", func(t *testing.T) {
+		mockClient := &mockGophKeeperClient{
+			pubKeyFunc: func(ctx context.Context, in *gophkeeper.PublicKeyRequest, opts ...grpc.CallOption) (*gophkeeper.PublicKeyResponse, error) {
+				assert.Equal(t, testUser, in.Username)
+				return &gophkeeper.PublicKeyResponse{UserId: testUserID, PublicKey: []byte("public_key")}, nil
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		pk, err := client.GetPublicKey(ctx, testUser, testToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, &models.PublicKey{UserID: testUserID, Key: []byte("public_key")}, pk)
+	})
+
+	t.Run("lookup error", func(t *testing.T) {
+		expectedErr := errors.New("not found")
+		mockClient := &mockGophKeeperClient{
+			pubKeyFunc: func(ctx context.Context, in *gophkeeper.PublicKeyRequest, opts ...grpc.CallOption) (*gophkeeper.PublicKeyResponse, error) {
+				return nil, expectedErr
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		_, err := client.GetPublicKey(ctx, testUser, testToken)
+
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
+func TestGophKeeperClient_ShareItem(t *testing.T) {
+	ctx := context.Background()
+	share := &models.Share{
+		ItemID:     testItemID,
+		Recipient:  testUser,
+		WrappedKey: []byte("wrapped"),
+		Payload:    []byte("payload"),
+	}
+
+	t.Run("successful share", func(t *testing.T) {
+		mockClient := &mockGophKeeperClient{
+			shareFunc: func(ctx context.Context, in *gophkeeper.ShareItemRequest, opts ...grpc.CallOption) (*gophkeeper.ShareItemResponse, error) {
+				md, ok := metadata.FromOutgoingContext(ctx)
+				require.True(t, ok)
+				assert.Equal(t, []string{"Bearer " + testToken}, md.Get("authorization"))
+				assert.Equal(t, testItemID, in.ItemId)
+				assert.Equal(t, testUser, in.Recipient)
+				assert.Equal(t, share.WrappedKey, in.WrappedKey)
+				assert.Equal(t, share.Payload, in.Payload)
+				return &gophkeeper.ShareItemResponse{}, nil
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		err := client.ShareItem(ctx, share, testToken)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestGophKeeperClient_ListSharedWithMe(t *testing.T) {
+	ctx := context.Background()
+	sharedAt := time.Now().UTC()
+
+	t.Run("successful listing", func(t *testing.T) {
+		mockClient := &mockGophKeeperClient{
+			listFunc: func(ctx context.Context, in *gophkeeper.ListSharedRequest, opts ...grpc.CallOption) (*gophkeeper.ListSharedResponse, error) {
+				return &gophkeeper.ListSharedResponse{
+					Items: []*gophkeeper.SharedItem{{
+						ItemId:     testItemID,
+						Owner:      testUser,
+						WrappedKey: []byte("wrapped"),
+						Payload:    []byte("payload"),
+						SharedAt:   timestamppb.New(sharedAt),
+					}},
+				}, nil
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		shares, err := client.ListSharedWithMe(ctx, testToken)
+
+		require.NoError(t, err)
+		require.Len(t, shares, 1)
+		assert.Equal(t, models.ItemID(testItemID), shares[0].ItemID)
+		assert.Equal(t, testUser, shares[0].Owner)
+		assert.Equal(t, []byte("wrapped"), shares[0].WrappedKey)
+		assert.Equal(t, []byte("payload"), shares[0].Payload)
+		assert.True(t, sharedAt.Equal(shares[0].SharedAt))
+	})
+}
+
+func TestGophKeeperClient_RevokeShare(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful revoke", func(t *testing.T) {
+		mockClient := &mockGophKeeperClient{
+			revokeFunc: func(ctx context.Context, in *gophkeeper.RevokeShareRequest, opts ...grpc.CallOption) (*gophkeeper.RevokeShareResponse, error) {
+				assert.Equal(t, testItemID, in.ItemId)
+				assert.Equal(t, testUser, in.Recipient)
+				return &gophkeeper.RevokeShareResponse{}, nil
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		err := client.RevokeShare(ctx, testItemID, testUser, testToken)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestGophKeeperClient_CreateOrganization(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful creation", func(t *testing.T) {
+		mockClient := &mockGophKeeperClient{
+			createOrg: func(ctx context.Context, in *gophkeeper.CreateOrganizationRequest, opts ...grpc.CallOption) (*gophkeeper.CreateOrganizationResponse, error) {
+				md, ok := metadata.FromOutgoingContext(ctx)
+				require.True(t, ok)
+				assert.Equal(t, []string{"Bearer " + testToken}, md.Get("authorization"))
+				assert.Equal(t, "team", in.Name)
+				assert.Equal(t, "shared", in.CollectionName)
+				assert.Equal(t, []byte("wrapped"), in.WrappedKey)
+				return &gophkeeper.CreateOrganizationResponse{OrgId: "org1", CollectionId: "col1"}, nil
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		col, err := client.CreateOrganization(ctx, "team", &models.Collection{Name: "shared", WrappedKey: []byte("wrapped")}, testToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, &models.Collection{
+			ID:         "col1",
+			OrgID:      "org1",
+			OrgName:    "team",
+			Name:       "shared",
+			Role:       models.RoleOwner,
+			WrappedKey: []byte("wrapped"),
+		}, col)
+	})
+}
+
+func TestGophKeeperClient_CreateCollection(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful creation", func(t *testing.T) {
+		mockClient := &mockGophKeeperClient{
+			createCol: func(ctx context.Context, in *gophkeeper.CreateCollectionRequest, opts ...grpc.CallOption) (*gophkeeper.CreateCollectionResponse, error) {
+				assert.Equal(t, "org1", in.OrgId)
+				assert.Equal(t, "ops", in.Name)
+				require.Len(t, in.Keys, 1)
+				assert.Equal(t, testUserID, in.Keys[0].UserId)
+				return &gophkeeper.CreateCollectionResponse{CollectionId: "col2"}, nil
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		col, err := client.CreateCollection(ctx,
+			&models.Collection{OrgID: "org1", Name: "ops"},
+			[]models.CollectionKey{{UserID: testUserID, WrappedKey: []byte("wrapped")}},
+			testToken,
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.CollectionID("col2"), col.ID)
+		assert.Equal(t, "ops", col.Name)
+	})
+}
+
+func TestGophKeeperClient_AddMember(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("add error", func(t *testing.T) {
+		expectedErr := errors.New("permission denied")
+		mockClient := &mockGophKeeperClient{
+			addMember: func(ctx context.Context, in *gophkeeper.AddMemberRequest, opts ...grpc.CallOption) (*gophkeeper.AddMemberResponse, error) {
+				assert.Equal(t, "org1", in.OrgId)
+				assert.Equal(t, "bob", in.Username)
+				assert.Equal(t, "readonly", in.Role)
+				require.Len(t, in.Keys, 1)
+				assert.Equal(t, "col1", in.Keys[0].CollectionId)
+				return nil, expectedErr
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		err := client.AddMember(ctx, "org1",
+			&models.Member{Username: "bob", Role: models.RoleReadOnly},
+			[]models.CollectionKey{{CollectionID: "col1", WrappedKey: []byte("wrapped")}},
+			testToken,
+		)
+
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
+func TestGophKeeperClient_ListMembers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful listing", func(t *testing.T) {
+		mockClient := &mockGophKeeperClient{
+			listMembers: func(ctx context.Context, in *gophkeeper.ListMembersRequest, opts ...grpc.CallOption) (*gophkeeper.ListMembersResponse, error) {
+				assert.Equal(t, "org1", in.OrgId)
+				return &gophkeeper.ListMembersResponse{Members: []*gophkeeper.Member{
+					{UserId: testUserID, Username: testUser, Role: "admin", PublicKey: []byte("pub")},
+				}}, nil
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		members, err := client.ListMembers(ctx, "org1", testToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.Member{
+			{UserID: testUserID, Username: testUser, Role: models.RoleAdmin, PublicKey: []byte("pub")},
+		}, members)
+	})
+}
+
+func TestGophKeeperClient_ListCollections(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful listing", func(t *testing.T) {
+		mockClient := &mockGophKeeperClient{
+			listCols: func(ctx context.Context, in *gophkeeper.ListCollectionsRequest, opts ...grpc.CallOption) (*gophkeeper.ListCollectionsResponse, error) {
+				return &gophkeeper.ListCollectionsResponse{Collections: []*gophkeeper.Collection{
+					{Id: "col1", OrgId: "org1", OrgName: "team", Name: "shared", Role: "member", WrappedKey: []byte("wrapped")},
+				}}, nil
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		cols, err := client.ListCollections(ctx, testToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.Collection{
+			{ID: "col1", OrgID: "org1", OrgName: "team", Name: "shared", Role: models.RoleMember, WrappedKey: []byte("wrapped")},
+		}, cols)
+	})
+
+	t.Run("listing error", func(t *testing.T) {
+		expectedErr := errors.New("unavailable")
+		mockClient := &mockGophKeeperClient{
+			listCols: func(ctx context.Context, in *gophkeeper.ListCollectionsRequest, opts ...grpc.CallOption) (*gophkeeper.ListCollectionsResponse, error) {
+				return nil, expectedErr
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		_, err := client.ListCollections(ctx, testToken)
+
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
+func TestGophKeeperClient_AddEmergencyContact(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("wait period sent in seconds", func(t *testing.T) {
+		mockClient := &mockGophKeeperClient{
+			addContact: func(ctx context.Context, in *gophkeeper.AddEmergencyContactRequest, opts ...grpc.CallOption) (*gophkeeper.AddEmergencyContactResponse, error) {
+				md, ok := metadata.FromOutgoingContext(ctx)
+				require.True(t, ok)
+				assert.Equal(t, []string{"Bearer " + testToken}, md.Get("authorization"))
+				assert.Equal(t, "contact", in.Grantee)
+				assert.Equal(t, int64(259200), in.WaitSeconds)
+				assert.Equal(t, []byte("wrapped"), in.WrappedKey)
+				return &gophkeeper.AddEmergencyContactResponse{}, nil
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		err := client.AddEmergencyContact(ctx, &models.EmergencyContact{
+			Grantee:    "contact",
+			WaitPeriod: 72 * time.Hour,
+			WrappedKey: []byte("wrapped"),
+		}, testToken)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestGophKeeperClient_ListEmergencyGrants(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful listing", func(t *testing.T) {
+		requestedAt := time.Now()
+		mockClient := &mockGophKeeperClient{
+			listGrants: func(ctx context.Context, in *gophkeeper.ListEmergencyGrantsRequest, opts ...grpc.CallOption) (*gophkeeper.ListEmergencyGrantsResponse, error) {
+				return &gophkeeper.ListEmergencyGrantsResponse{Grants: []*gophkeeper.EmergencyContact{
+					{Grantor: "owner", WaitSeconds: 60, Status: "requested", RequestedAt: timestamppb.New(requestedAt)},
+					{Grantor: "other", WaitSeconds: 60, Status: "idle"},
+				}}, nil
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		grants, err := client.ListEmergencyGrants(ctx, testToken)
+
+		require.NoError(t, err)
+		require.Len(t, grants, 2)
+		assert.Equal(t, "owner", grants[0].Grantor)
+		assert.Equal(t, time.Minute, grants[0].WaitPeriod)
+		assert.Equal(t, models.EmergencyRequested, grants[0].Status)
+		assert.True(t, requestedAt.Equal(grants[0].RequestedAt))
+		assert.True(t, grants[1].RequestedAt.IsZero())
+	})
+}
+
+func TestGophKeeperClient_GetEmergencyVault(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("vault converted", func(t *testing.T) {
+		mockClient := &mockGophKeeperClient{
+			getVault: func(ctx context.Context, in *gophkeeper.EmergencyVaultRequest, opts ...grpc.CallOption) (*gophkeeper.EmergencyVaultResponse, error) {
+				assert.Equal(t, "owner", in.Grantor)
+				return &gophkeeper.EmergencyVaultResponse{
+					WrappedKey: []byte("wrapped"),
+					Items:      []*gophkeeper.Item{{Id: testItemID, Name: "bank", Data: []byte("data"), UpdatedAt: timestamppb.Now()}},
+				}, nil
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		vault, err := client.GetEmergencyVault(ctx, "owner", testToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, []byte("wrapped"), vault.WrappedKey)
+		require.Len(t, vault.Items, 1)
+		assert.Equal(t, models.ItemID(testItemID), vault.Items[0].ID)
+		assert.Equal(t, []byte("data"), vault.Items[0].Data)
+	})
+
+	t.Run("access not approved", func(t *testing.T) {
+		expectedErr := errors.New("failed precondition")
+		mockClient := &mockGophKeeperClient{
+			getVault: func(ctx context.Context, in *gophkeeper.EmergencyVaultRequest, opts ...grpc.CallOption) (*gophkeeper.EmergencyVaultResponse, error) {
+				return nil, expectedErr
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		_, err := client.GetEmergencyVault(ctx, "owner", testToken)
+
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
+func TestGophKeeperClient_ListAuditEvents(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("page converted", func(t *testing.T) {
+		createdAt := time.Now()
+		mockClient := &mockGophKeeperClient{
+			listAudit: func(ctx context.Context, in *gophkeeper.ListAuditEventsRequest, opts ...grpc.CallOption) (*gophkeeper.ListAuditEventsResponse, error) {
+				md, ok := metadata.FromOutgoingContext(ctx)
+				require.True(t, ok)
+				assert.Equal(t, []string{"Bearer " + testToken}, md.Get("authorization"))
+				assert.Equal(t, "12", in.PageToken)
+				assert.Equal(t, int32(20), in.PageSize)
+				return &gophkeeper.ListAuditEventsResponse{
+					Events: []*gophkeeper.AuditEvent{
+						{Id: 11, Device: "laptop", Ip: "192.0.2.1", Action: "login", Result: "failure", CreatedAt: timestamppb.New(createdAt)},
+					},
+					NextPageToken: "11",
+				}, nil
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		page, err := client.ListAuditEvents(ctx, "12", 20, testToken)
+
+		require.NoError(t, err)
+		require.Len(t, page.Events, 1)
+		assert.Equal(t, int64(11), page.Events[0].ID)
+		assert.Equal(t, "laptop", page.Events[0].Device)
+		assert.Equal(t, "192.0.2.1", page.Events[0].IP)
+		assert.Equal(t, models.AuditLogin, page.Events[0].Action)
+		assert.Equal(t, models.AuditFailure, page.Events[0].Result)
+		assert.True(t, createdAt.Equal(page.Events[0].CreatedAt))
+		assert.Equal(t, "11", page.NextToken)
+	})
+
+	t.Run("listing error", func(t *testing.T) {
+		expectedErr := errors.New("unauthenticated")
+		mockClient := &mockGophKeeperClient{
+			listAudit: func(ctx context.Context, in *gophkeeper.ListAuditEventsRequest, opts ...grpc.CallOption) (*gophkeeper.ListAuditEventsResponse, error) {
+				return nil, expectedErr
+			},
+		}
+
+		client := &GophKeeperClient{client: mockClient}
+		_, err := client.ListAuditEvents(ctx, "", 20, testToken)
+
+		assert.Equal(t, expectedErr, err)
+	})
+}
@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/rycln/gokeep/shared/models"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// codeReasons maps status codes to reasons of errors without ErrorInfo,
+// e.g. returned by older servers or by the transport itself
+var codeReasons = map[codes.Code]string{
+	codes.Unauthenticated:   models.ReasonSessionExpired,
+	codes.PermissionDenied:  models.ReasonForbidden,
+	codes.ResourceExhausted: models.ReasonTooManyAttempts,
+	codes.NotFound:          models.ReasonNotFound,
+	codes.AlreadyExists:     models.ReasonAlreadyExists,
+	codes.DeadlineExceeded:  models.ReasonTimeout,
+	codes.Unavailable:       models.ReasonUnavailable,
+	codes.Internal:          models.ReasonInternal,
+}
+
+// ErrorInterceptor converts failed calls to *models.ServerError
+// with the reason and retry delay reported by the server.
+// Status of the original error stays available to status.Code and status.FromError.
+func ErrorInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply any,
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err == nil {
+		return nil
+	}
+	return serverError(err)
+}
+
+// serverError wraps status error into *models.ServerError
+// Errors without status or known reason are returned unchanged
+func serverError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	serr := &models.ServerError{Reason: codeReasons[st.Code()], Err: err}
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if d.Domain == models.ErrorDomain {
+				serr.Reason = d.Reason
+			}
+		case *errdetails.RetryInfo:
+			serr.RetryAfter = d.RetryDelay.AsDuration()
+		}
+	}
+
+	if serr.Reason == "" {
+		return err
+	}
+	return serr
+}
@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestErrorInterceptor(t *testing.T) {
+	const method = "/gophkeeper.GophKeeper/Login"
+
+	call := func(callErr error) error {
+		return ErrorInterceptor(context.Background(), method, nil, nil, nil,
+			func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return callErr
+			})
+	}
+
+	t.Run("successful call", func(t *testing.T) {
+		assert.NoError(t, call(nil))
+	})
+
+	t.Run("reason and retry delay from details", func(t *testing.T) {
+		st, err := status.New(codes.ResourceExhausted, "locked").WithDetails(
+			&errdetails.ErrorInfo{Reason: models.ReasonTooManyAttempts, Domain: models.ErrorDomain},
+			&errdetails.RetryInfo{RetryDelay: durationpb.New(90 * time.Second)},
+		)
+		require.NoError(t, err)
+
+		err = call(st.Err())
+		var serr *models.ServerError
+		require.ErrorAs(t, err, &serr)
+		assert.Equal(t, models.ReasonTooManyAttempts, serr.Reason)
+		assert.Equal(t, 90*time.Second, serr.RetryAfter)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("reason of other domain ignored", func(t *testing.T) {
+		st, err := status.New(codes.Unauthenticated, "expired").WithDetails(
+			&errdetails.ErrorInfo{Reason: "TOKEN_EXPIRED", Domain: "example.com"},
+		)
+		require.NoError(t, err)
+
+		var serr *models.ServerError
+		require.ErrorAs(t, call(st.Err()), &serr)
+		assert.Equal(t, models.ReasonSessionExpired, serr.Reason)
+	})
+
+	t.Run("reason derived from code", func(t *testing.T) {
+		err := call(status.Error(codes.Unavailable, "connection refused"))
+		var serr *models.ServerError
+		require.ErrorAs(t, err, &serr)
+		assert.Equal(t, models.ReasonUnavailable, serr.Reason)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("details stay available", func(t *testing.T) {
+		st, err := status.New(codes.InvalidArgument, "invalid").WithDetails(
+			&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: models.FieldPassword, Reason: models.ReasonPasswordLength},
+			}},
+		)
+		require.NoError(t, err)
+
+		var verr *models.ValidationError
+		require.ErrorAs(t, validationError(call(st.Err())), &verr)
+		assert.Equal(t, models.FieldPassword, verr.Violations[0].Field)
+	})
+
+	t.Run("unknown errors unchanged", func(t *testing.T) {
+		testErr := errors.New("test error")
+		assert.Equal(t, testErr, call(testErr))
+
+		statusErr := status.Error(codes.FailedPrecondition, "precondition")
+		assert.Equal(t, statusErr, call(statusErr))
+	})
+}
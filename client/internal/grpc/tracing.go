@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// instrumentationName identifies spans created by the client
+const instrumentationName = "github.com/rycln/gokeep/client"
+
+// TracingInterceptor starts a client span for each call
+// and sends its trace context to the server in metadata
+func TracingInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply any,
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, strings.TrimPrefix(method, "/"),
+		trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	carrier := make(metadataCarrier)
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	for k, v := range carrier {
+		ctx = metadata.AppendToOutgoingContext(ctx, k, v)
+	}
+
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err != nil {
+		span.SetStatus(codes.Error, status.Convert(err).Message())
+	}
+	return err
+}
+
+// metadataCarrier collects propagated trace headers
+type metadataCarrier map[string]string
+
+// Get returns header value
+func (c metadataCarrier) Get(key string) string { return c[key] }
+
+// Set stores header value
+func (c metadataCarrier) Set(key, value string) { c[key] = value }
+
+// Keys lists header names
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
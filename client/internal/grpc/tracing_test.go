@@ -0,0 +1,60 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTracingInterceptor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	oldProvider, oldPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(oldProvider)
+		otel.SetTextMapPropagator(oldPropagator)
+	}()
+
+	const method = "/gophkeeper.GophKeeper/Sync"
+
+	t.Run("trace context sent with existing metadata", func(t *testing.T) {
+		ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+testToken))
+
+		var md metadata.MD
+		err := TracingInterceptor(ctx, method, nil, nil, nil,
+			func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				md, _ = metadata.FromOutgoingContext(ctx)
+				return nil
+			})
+		require.NoError(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "gophkeeper.GophKeeper/Sync", spans[0].Name())
+		assert.Equal(t, []string{"Bearer " + testToken}, md.Get("authorization"))
+		require.Len(t, md.Get("traceparent"), 1)
+		assert.Contains(t, md.Get("traceparent")[0], spans[0].SpanContext().TraceID().String())
+	})
+
+	t.Run("failed call marks span", func(t *testing.T) {
+		err := TracingInterceptor(context.Background(), method, nil, nil, nil,
+			func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return errors.New("unavailable")
+			})
+		require.Error(t, err)
+
+		spans := recorder.Ended()
+		assert.Equal(t, otelcodes.Error, spans[len(spans)-1].Status().Code)
+	})
+}
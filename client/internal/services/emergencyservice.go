@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/rycln/gokeep/client/internal/strategies/crypto"
+	"github.com/rycln/gokeep/shared/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// emergencyAPI defines remote operations for emergency access
+type emergencyAPI interface {
+	GetPublicKey(context.Context, string, string) (*models.PublicKey, error)
+	AddEmergencyContact(context.Context, *models.EmergencyContact, string) error
+	ListEmergencyContacts(context.Context, string) ([]models.EmergencyContact, error)
+	ListEmergencyGrants(context.Context, string) ([]models.EmergencyContact, error)
+	RequestEmergencyAccess(context.Context, string, string) error
+	DenyEmergencyAccess(context.Context, string, string) error
+	GetEmergencyVault(context.Context, string, string) (*models.EmergencyVault, error)
+}
+
+// vaultCrypter handles vault encryption and exposes the vault key
+type vaultCrypter interface {
+	Encrypt([]byte) ([]byte, error)
+	Decrypt([]byte) ([]byte, error)
+	Key() ([]byte, error)
+}
+
+// EmergencyService handles emergency access through trusted contacts
+// The vault key is sealed to the contact public key when the contact is nominated
+// and released by the server only after the waiting period
+type EmergencyService struct {
+	api   emergencyAPI
+	crypt vaultCrypter
+	box   sealer
+}
+
+// NewEmergencyService creates a new EmergencyService instance
+func NewEmergencyService(api emergencyAPI, crypt vaultCrypter, box sealer) *EmergencyService {
+	return &EmergencyService{
+		api:   api,
+		crypt: crypt,
+		box:   box,
+	}
+}
+
+// AddContact nominates trusted contact with the waiting period
+func (s *EmergencyService) AddContact(ctx context.Context, user *models.User, grantee string, wait time.Duration) error {
+	pk, err := s.api.GetPublicKey(ctx, grantee, user.JWT)
+	if err != nil {
+		return err
+	}
+
+	vaultKey, err := s.crypt.Key()
+	if err != nil {
+		return err
+	}
+
+	wrapped, err := s.box.Seal(pk.Key, vaultKey)
+	if err != nil {
+		return err
+	}
+
+	return s.api.AddEmergencyContact(ctx, &models.EmergencyContact{
+		Grantee:    grantee,
+		WaitPeriod: wait,
+		WrappedKey: wrapped,
+	}, user.JWT)
+}
+
+// ListContacts fetches trusted contacts nominated by the user
+func (s *EmergencyService) ListContacts(ctx context.Context, user *models.User) ([]models.EmergencyContact, error) {
+	return s.api.ListEmergencyContacts(ctx, user.JWT)
+}
+
+// ListGrants fetches vaults the user is trusted with
+func (s *EmergencyService) ListGrants(ctx context.Context, user *models.User) ([]models.EmergencyContact, error) {
+	return s.api.ListEmergencyGrants(ctx, user.JWT)
+}
+
+// RequestAccess starts waiting period for the grantor vault
+func (s *EmergencyService) RequestAccess(ctx context.Context, user *models.User, grantor string) error {
+	return s.api.RequestEmergencyAccess(ctx, grantor, user.JWT)
+}
+
+// DenyAccess denies pending request of the trusted contact
+func (s *EmergencyService) DenyAccess(ctx context.Context, user *models.User, grantee string) error {
+	return s.api.DenyEmergencyAccess(ctx, grantee, user.JWT)
+}
+
+// OpenVault fetches and decrypts the grantor vault released to the user
+func (s *EmergencyService) OpenVault(ctx context.Context, user *models.User, grantor string) ([]models.SharedItem, error) {
+	priv, err := openPrivateKey(s.crypt, user)
+	if err != nil {
+		return nil, err
+	}
+
+	vault, err := s.api.GetEmergencyVault(ctx, grantor, user.JWT)
+	if err != nil {
+		return nil, err
+	}
+
+	vaultKey, err := s.box.Open(priv, vault.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	c := crypto.NewAESCrypter()
+	if err := c.SetKey(vaultKey); err != nil {
+		return nil, err
+	}
+	defer c.Wipe()
+
+	items := make([]models.SharedItem, 0, len(vault.Items))
+	for _, item := range vault.Items {
+		content, err := c.Decrypt(item.Data)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, models.SharedItem{
+			ID:       item.ID,
+			Owner:    grantor,
+			ItemType: item.ItemType,
+			Name:     item.Name,
+			Metadata: item.Metadata,
+			Content:  content,
+			SharedAt: item.UpdatedAt,
+		})
+	}
+
+	return items, nil
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/client/internal/services/mocks"
+	"github.com/rycln/gokeep/client/internal/strategies/crypto"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmergencyService_AddContactAndOpenVault(t *testing.T) {
+	ctx := context.Background()
+	box := crypto.NewBox()
+
+	contactPub, contactPriv, err := box.GenerateKeyPair()
+	require.NoError(t, err)
+
+	vaultKey := make([]byte, keyLength)
+	vaultKey[0] = 7
+	ownerVault := crypto.NewAESCrypter()
+	require.NoError(t, ownerVault.SetKey(vaultKey))
+
+	owner := &models.User{ID: "owner", JWT: "owner.jwt"}
+	contact := &models.User{
+		ID:  "contact",
+		JWT: "contact.jwt",
+		KeyPair: models.KeyPair{
+			PublicKey:           contactPub,
+			EncryptedPrivateKey: base64.StdEncoding.EncodeToString([]byte("encrypted private key")),
+		},
+	}
+
+	t.Run("contact opens released vault", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockemergencyAPI(ctrl)
+		service := NewEmergencyService(mockAPI, ownerVault, box)
+
+		var sent *models.EmergencyContact
+		gomock.InOrder(
+			mockAPI.EXPECT().
+				GetPublicKey(ctx, "contact", owner.JWT).
+				Return(&models.PublicKey{UserID: contact.ID, Key: contactPub}, nil),
+			mockAPI.EXPECT().
+				AddEmergencyContact(ctx, gomock.Any(), owner.JWT).
+				DoAndReturn(func(_ context.Context, c *models.EmergencyContact, _ string) error {
+					sent = c
+					return nil
+				}),
+		)
+
+		err := service.AddContact(ctx, owner, "contact", 72*time.Hour)
+		require.NoError(t, err)
+		require.NotNil(t, sent)
+		assert.Equal(t, "contact", sent.Grantee)
+		assert.Equal(t, 72*time.Hour, sent.WaitPeriod)
+
+		data, err := ownerVault.Encrypt([]byte("secret"))
+		require.NoError(t, err)
+
+		mockContactVault := mocks.NewMockvaultCrypter(ctrl)
+		contactService := NewEmergencyService(mockAPI, mockContactVault, box)
+
+		gomock.InOrder(
+			mockContactVault.EXPECT().
+				Decrypt([]byte("encrypted private key")).
+				Return(contactPriv, nil),
+			mockAPI.EXPECT().
+				GetEmergencyVault(ctx, "owner", contact.JWT).
+				Return(&models.EmergencyVault{
+					WrappedKey: sent.WrappedKey,
+					Items:      []models.Item{{ID: "item1", ItemType: models.TypeText, Name: "note", Data: data}},
+				}, nil),
+		)
+
+		items, err := contactService.OpenVault(ctx, contact, "owner")
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "owner", items[0].Owner)
+		assert.Equal(t, "note", items[0].Name)
+		assert.Equal(t, []byte("secret"), items[0].Content)
+	})
+
+	t.Run("vault not approved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockemergencyAPI(ctrl)
+		mockVault := mocks.NewMockvaultCrypter(ctrl)
+		service := NewEmergencyService(mockAPI, mockVault, box)
+
+		expectedErr := errors.New("not approved")
+		mockVault.EXPECT().Decrypt(gomock.Any()).Return(contactPriv, nil)
+		mockAPI.EXPECT().
+			GetEmergencyVault(ctx, "owner", contact.JWT).
+			Return(nil, expectedErr)
+
+		_, err := service.OpenVault(ctx, contact, "owner")
+		assert.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("open without keypair", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service := NewEmergencyService(mocks.NewMockemergencyAPI(ctrl), mocks.NewMockvaultCrypter(ctrl), box)
+
+		_, err := service.OpenVault(ctx, owner, "someone")
+		assert.ErrorIs(t, err, ErrNoKeyPair)
+	})
+}
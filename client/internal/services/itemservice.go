@@ -28,6 +28,10 @@ type itemUpdater interface {
 	UpdateItem(context.Context, *models.ItemInfo, []byte) error
 }
 
+type collectionGetter interface {
+	ListUserCollections(context.Context, models.UserID) ([]models.Collection, error)
+}
+
 // crypter handles item encrypt/decrypt operations
 type crypter interface {
 	Encrypt([]byte) ([]byte, error)
@@ -40,6 +44,7 @@ type itemStorage interface {
 	itemGetter
 	itemDeleter
 	itemUpdater
+	collectionGetter
 }
 
 // ItemService handles business logic for item operations
@@ -103,3 +108,29 @@ func (s *ItemService) Update(ctx context.Context, info *models.ItemInfo, content
 
 	return s.storage.UpdateItem(ctx, info, crypted)
 }
+
+// Collections retrieves organization collections cached for a specific user
+func (s *ItemService) Collections(ctx context.Context, uid models.UserID) ([]models.Collection, error) {
+	return s.storage.ListUserCollections(ctx, uid)
+}
+
+// MoveToCollection copies item into organization collection and removes the original
+// The copy gets a new ID since server never moves existing rows between owners
+func (s *ItemService) MoveToCollection(ctx context.Context, info *models.ItemInfo, cid models.CollectionID) error {
+	crypted, err := s.storage.GetContent(ctx, info.ID)
+	if err != nil {
+		return err
+	}
+
+	moved := *info
+	moved.ID = models.ItemID(uuid.New().String())
+	moved.CollectionID = cid
+	moved.UpdatedAt = time.Now()
+
+	err = s.storage.Add(ctx, &moved, crypted)
+	if err != nil {
+		return err
+	}
+
+	return s.storage.DeleteItem(ctx, info.ID)
+}
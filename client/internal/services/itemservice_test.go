@@ -334,3 +334,59 @@ func TestItemService_Update(t *testing.T) {
 		assert.Equal(t, expectedErr, err)
 	})
 }
+
+func TestItemService_MoveToCollection(t *testing.T) {
+	ctx := context.Background()
+	info := &models.ItemInfo{
+		ID:       "item1",
+		UserID:   "user1",
+		ItemType: models.TypeText,
+		Name:     "note",
+	}
+	encryptedContent := []byte("encrypted")
+
+	t.Run("item copied under new id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockitemStorage(ctrl)
+		service := NewItemService(mockStorage, mocks.NewMockcrypter(ctrl))
+
+		gomock.InOrder(
+			mockStorage.EXPECT().
+				GetContent(ctx, info.ID).
+				Return(encryptedContent, nil),
+			mockStorage.EXPECT().
+				Add(ctx, gomock.Any(), encryptedContent).
+				Do(func(_ context.Context, moved *models.ItemInfo, _ []byte) {
+					assert.NotEqual(t, info.ID, moved.ID)
+					assert.Equal(t, info.Name, moved.Name)
+					assert.Equal(t, models.CollectionID("col1"), moved.CollectionID)
+				}).
+				Return(nil),
+			mockStorage.EXPECT().
+				DeleteItem(ctx, info.ID).
+				Return(nil),
+		)
+
+		err := service.MoveToCollection(ctx, info, "col1")
+		assert.NoError(t, err)
+		assert.Empty(t, info.CollectionID)
+	})
+
+	t.Run("content error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockitemStorage(ctrl)
+		service := NewItemService(mockStorage, mocks.NewMockcrypter(ctrl))
+
+		expectedErr := errors.New("storage error")
+		mockStorage.EXPECT().
+			GetContent(ctx, info.ID).
+			Return(nil, expectedErr)
+
+		err := service.MoveToCollection(ctx, info, "col1")
+		assert.Equal(t, expectedErr, err)
+	})
+}
@@ -1,22 +1,45 @@
 package services
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base32"
 	"encoding/base64"
 	"errors"
+	"strings"
 
+	"github.com/rycln/gokeep/client/internal/strategies/crypto"
+	"github.com/rycln/gokeep/shared/models"
 	"golang.org/x/crypto/pbkdf2"
 )
 
 // Security parameters for key derivation
 const (
-	keyLength        = 32     // 256-bit key length for AES-256
-	saltLength       = 16     // 128-bit salt length
-	pbkdf2Iterations = 600000 // NIST recommended minimum iterations
+	keyLength         = 32     // 256-bit key length for AES-256
+	saltLength        = 16     // 128-bit salt length
+	pbkdf2Iterations  = 600000 // NIST recommended minimum iterations
+	recoveryKeyLength = 20     // 160-bit recovery key, 32 base32 characters
+	recoveryGroupSize = 4      // Characters per printable recovery key group
 )
 
-var errInvalidSaltLength = errors.New("invalid salt length")
+// keyCheckValue is encrypted with the vault key to verify it offline
+const keyCheckValue = "gophkeeper key check"
+
+// Labels separating keys derived from the recovery key
+const (
+	recoveryKEKLabel  = "gophkeeper recovery key encryption"
+	recoveryAuthLabel = "gophkeeper recovery authentication"
+)
+
+var (
+	errInvalidSaltLength  = errors.New("invalid salt length")
+	errInvalidRecoveryKey = errors.New("invalid recovery key")
+	errKeyCheckMismatch   = errors.New("key check mismatch")
+)
+
+// recoveryEncoding is used for printable recovery keys
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
 
 // KeyService handles cryptographic key operations
 type KeyService struct{}
@@ -46,6 +69,11 @@ func (s *KeyService) DeriveKeyFromPasswordAndSalt(password string, salt []byte)
 	)
 }
 
+// KDFIterations returns PBKDF2 iteration count used for key derivation
+func (s *KeyService) KDFIterations() int {
+	return pbkdf2Iterations
+}
+
 // DecodeSalt converts base64-encoded salt back to bytes
 func (s *KeyService) DecodeSalt(salt string) ([]byte, error) {
 	decoded, err := base64.StdEncoding.DecodeString(salt)
@@ -62,3 +90,119 @@ func (s *KeyService) DecodeSalt(salt string) ([]byte, error) {
 func (s *KeyService) EncodeSalt(salt []byte) string {
 	return base64.StdEncoding.EncodeToString(salt)
 }
+
+// GenerateVaultKey creates a new random key used to encrypt vault items
+func (s *KeyService) GenerateVaultKey() ([]byte, error) {
+	key := make([]byte, keyLength)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// WrapKey encrypts key with key encryption key and encodes it to base64
+func (s *KeyService) WrapKey(kek, key []byte) (string, error) {
+	c := crypto.NewAESCrypter()
+	if err := c.SetKey(kek); err != nil {
+		return "", err
+	}
+
+	wrapped, err := c.Encrypt(key)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(wrapped), nil
+}
+
+// UnwrapKey decodes and decrypts key wrapped by WrapKey
+// Fails if key encryption key does not match
+func (s *KeyService) UnwrapKey(kek []byte, wrapped string) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	c := crypto.NewAESCrypter()
+	if err := c.SetKey(kek); err != nil {
+		return nil, err
+	}
+
+	return c.Decrypt(decoded)
+}
+
+// GenerateRecoveryKey creates a new printable recovery key
+// Key is shown to the user once and never sent to the server
+func (s *KeyService) GenerateRecoveryKey() (string, error) {
+	raw := make([]byte, recoveryKeyLength)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	encoded := recoveryEncoding.EncodeToString(raw)
+
+	groups := make([]string, 0, len(encoded)/recoveryGroupSize)
+	for i := 0; i < len(encoded); i += recoveryGroupSize {
+		groups = append(groups, encoded[i:i+recoveryGroupSize])
+	}
+
+	return strings.Join(groups, "-"), nil
+}
+
+// DeriveRecoveryKeys derives key encryption key and server verifier from recovery key
+// Input is case-insensitive and group separators are optional
+func (s *KeyService) DeriveRecoveryKeys(recoveryKey string) ([]byte, string, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(recoveryKey, "-", ""))
+
+	raw, err := recoveryEncoding.DecodeString(normalized)
+	if err != nil || len(raw) != recoveryKeyLength {
+		return nil, "", errInvalidRecoveryKey
+	}
+
+	kek := hmacSum(raw, recoveryKEKLabel)
+	auth := base64.StdEncoding.EncodeToString(hmacSum(raw, recoveryAuthLabel))
+
+	return kek, auth, nil
+}
+
+// KeyCheck encrypts known value with key for later verification
+func (s *KeyService) KeyCheck(key []byte) (string, error) {
+	return s.WrapKey(key, []byte(keyCheckValue))
+}
+
+// VerifyKeyCheck reports error if check was not produced by KeyCheck with key
+func (s *KeyService) VerifyKeyCheck(key []byte, check string) error {
+	value, err := s.UnwrapKey(key, check)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(value, []byte(keyCheckValue)) {
+		return errKeyCheckMismatch
+	}
+	return nil
+}
+
+// NewKeyPair generates sharing keypair with private key wrapped by vault key
+func (s *KeyService) NewKeyPair(vaultKey []byte) (*models.KeyPair, error) {
+	pub, priv, err := crypto.NewBox().GenerateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	encPriv, err := s.WrapKey(vaultKey, priv)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.KeyPair{
+		PublicKey:           pub,
+		EncryptedPrivateKey: encPriv,
+	}, nil
+}
+
+// hmacSum computes HMAC-SHA256 of label keyed with key
+func hmacSum(key []byte, label string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(label))
+	return mac.Sum(nil)
+}
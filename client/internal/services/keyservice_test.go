@@ -2,9 +2,11 @@ package services
 
 import (
 	"encoding/base64"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewKeyService(t *testing.T) {
@@ -106,3 +108,136 @@ func TestEncodeSalt(t *testing.T) {
 		assert.Empty(t, encoded)
 	})
 }
+
+func TestGenerateVaultKey(t *testing.T) {
+	t.Run("should generate random key of valid length", func(t *testing.T) {
+		service := NewKeyService()
+
+		key1, err := service.GenerateVaultKey()
+		require.NoError(t, err)
+		key2, err := service.GenerateVaultKey()
+		require.NoError(t, err)
+
+		assert.Len(t, key1, keyLength)
+		assert.NotEqual(t, key1, key2)
+	})
+}
+
+func TestWrapUnwrapKey(t *testing.T) {
+	service := NewKeyService()
+	kek := make([]byte, keyLength)
+	vaultKey := []byte("0123456789abcdef0123456789abcdef")
+
+	t.Run("should roundtrip wrapped key", func(t *testing.T) {
+		wrapped, err := service.WrapKey(kek, vaultKey)
+		require.NoError(t, err)
+
+		unwrapped, err := service.UnwrapKey(kek, wrapped)
+		require.NoError(t, err)
+		assert.Equal(t, vaultKey, unwrapped)
+	})
+
+	t.Run("should fail with wrong key encryption key", func(t *testing.T) {
+		wrapped, err := service.WrapKey(kek, vaultKey)
+		require.NoError(t, err)
+
+		wrongKEK := make([]byte, keyLength)
+		wrongKEK[0] = 1
+
+		_, err = service.UnwrapKey(wrongKEK, wrapped)
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail on invalid base64", func(t *testing.T) {
+		_, err := service.UnwrapKey(kek, "!!!")
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail on invalid key size", func(t *testing.T) {
+		_, err := service.WrapKey([]byte("short"), vaultKey)
+		assert.Error(t, err)
+	})
+}
+
+func TestRecoveryKey(t *testing.T) {
+	service := NewKeyService()
+
+	t.Run("should generate printable grouped key", func(t *testing.T) {
+		key, err := service.GenerateRecoveryKey()
+		require.NoError(t, err)
+
+		assert.Regexp(t, `^([A-Z2-7]{4}-){7}[A-Z2-7]{4}$`, key)
+	})
+
+	t.Run("should derive same keys regardless of formatting", func(t *testing.T) {
+		key, err := service.GenerateRecoveryKey()
+		require.NoError(t, err)
+
+		kek1, auth1, err := service.DeriveRecoveryKeys(key)
+		require.NoError(t, err)
+		kek2, auth2, err := service.DeriveRecoveryKeys(strings.ToLower(strings.ReplaceAll(key, "-", "")))
+		require.NoError(t, err)
+
+		assert.Len(t, kek1, keyLength)
+		assert.Equal(t, kek1, kek2)
+		assert.Equal(t, auth1, auth2)
+		assert.NotEqual(t, base64.StdEncoding.EncodeToString(kek1), auth1)
+	})
+
+	t.Run("should reject malformed key", func(t *testing.T) {
+		_, _, err := service.DeriveRecoveryKeys("not-a-key")
+		assert.ErrorIs(t, err, errInvalidRecoveryKey)
+	})
+}
+
+func TestKeyCheck(t *testing.T) {
+	s := NewKeyService()
+	key, err := s.GenerateVaultKey()
+	require.NoError(t, err)
+
+	t.Run("should verify check with the same key", func(t *testing.T) {
+		check, err := s.KeyCheck(key)
+		require.NoError(t, err)
+		assert.NoError(t, s.VerifyKeyCheck(key, check))
+	})
+
+	t.Run("should reject other key", func(t *testing.T) {
+		check, err := s.KeyCheck(key)
+		require.NoError(t, err)
+
+		other, err := s.GenerateVaultKey()
+		require.NoError(t, err)
+		assert.Error(t, s.VerifyKeyCheck(other, check))
+	})
+
+	t.Run("should reject other wrapped value", func(t *testing.T) {
+		wrapped, err := s.WrapKey(key, []byte("something else"))
+		require.NoError(t, err)
+		assert.Equal(t, errKeyCheckMismatch, s.VerifyKeyCheck(key, wrapped))
+	})
+
+	t.Run("should report KDF iterations", func(t *testing.T) {
+		assert.Equal(t, pbkdf2Iterations, s.KDFIterations())
+	})
+}
+
+func TestNewKeyPair(t *testing.T) {
+	s := NewKeyService()
+	key, err := s.GenerateVaultKey()
+	require.NoError(t, err)
+
+	t.Run("should wrap private key with vault key", func(t *testing.T) {
+		kp, err := s.NewKeyPair(key)
+		require.NoError(t, err)
+		assert.Len(t, kp.PublicKey, 32)
+
+		priv, err := s.UnwrapKey(key, kp.EncryptedPrivateKey)
+		require.NoError(t, err)
+		assert.Len(t, priv, 32)
+	})
+
+	t.Run("should fail with invalid vault key", func(t *testing.T) {
+		_, err := s.NewKeyPair([]byte("short"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,206 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: emergencyservice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/gokeep/shared/models"
+)
+
+// MockemergencyAPI is a mock of emergencyAPI interface.
+type MockemergencyAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockemergencyAPIMockRecorder
+}
+
+// MockemergencyAPIMockRecorder is the mock recorder for MockemergencyAPI.
+type MockemergencyAPIMockRecorder struct {
+	mock *MockemergencyAPI
+}
+
+// NewMockemergencyAPI creates a new mock instance.
+func NewMockemergencyAPI(ctrl *gomock.Controller) *MockemergencyAPI {
+	mock := &MockemergencyAPI{ctrl: ctrl}
+	mock.recorder = &MockemergencyAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockemergencyAPI) EXPECT() *MockemergencyAPIMockRecorder {
+	return m.recorder
+}
+
+// AddEmergencyContact mocks base method.
+func (m *MockemergencyAPI) AddEmergencyContact(arg0 context.Context, arg1 *models.EmergencyContact, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEmergencyContact", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEmergencyContact indicates an expected call of AddEmergencyContact.
+func (mr *MockemergencyAPIMockRecorder) AddEmergencyContact(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEmergencyContact", reflect.TypeOf((*MockemergencyAPI)(nil).AddEmergencyContact), arg0, arg1, arg2)
+}
+
+// DenyEmergencyAccess mocks base method.
+func (m *MockemergencyAPI) DenyEmergencyAccess(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DenyEmergencyAccess", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DenyEmergencyAccess indicates an expected call of DenyEmergencyAccess.
+func (mr *MockemergencyAPIMockRecorder) DenyEmergencyAccess(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DenyEmergencyAccess", reflect.TypeOf((*MockemergencyAPI)(nil).DenyEmergencyAccess), arg0, arg1, arg2)
+}
+
+// GetEmergencyVault mocks base method.
+func (m *MockemergencyAPI) GetEmergencyVault(arg0 context.Context, arg1, arg2 string) (*models.EmergencyVault, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmergencyVault", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.EmergencyVault)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmergencyVault indicates an expected call of GetEmergencyVault.
+func (mr *MockemergencyAPIMockRecorder) GetEmergencyVault(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmergencyVault", reflect.TypeOf((*MockemergencyAPI)(nil).GetEmergencyVault), arg0, arg1, arg2)
+}
+
+// GetPublicKey mocks base method.
+func (m *MockemergencyAPI) GetPublicKey(arg0 context.Context, arg1, arg2 string) (*models.PublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.PublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicKey indicates an expected call of GetPublicKey.
+func (mr *MockemergencyAPIMockRecorder) GetPublicKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKey", reflect.TypeOf((*MockemergencyAPI)(nil).GetPublicKey), arg0, arg1, arg2)
+}
+
+// ListEmergencyContacts mocks base method.
+func (m *MockemergencyAPI) ListEmergencyContacts(arg0 context.Context, arg1 string) ([]models.EmergencyContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEmergencyContacts", arg0, arg1)
+	ret0, _ := ret[0].([]models.EmergencyContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEmergencyContacts indicates an expected call of ListEmergencyContacts.
+func (mr *MockemergencyAPIMockRecorder) ListEmergencyContacts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmergencyContacts", reflect.TypeOf((*MockemergencyAPI)(nil).ListEmergencyContacts), arg0, arg1)
+}
+
+// ListEmergencyGrants mocks base method.
+func (m *MockemergencyAPI) ListEmergencyGrants(arg0 context.Context, arg1 string) ([]models.EmergencyContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEmergencyGrants", arg0, arg1)
+	ret0, _ := ret[0].([]models.EmergencyContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEmergencyGrants indicates an expected call of ListEmergencyGrants.
+func (mr *MockemergencyAPIMockRecorder) ListEmergencyGrants(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmergencyGrants", reflect.TypeOf((*MockemergencyAPI)(nil).ListEmergencyGrants), arg0, arg1)
+}
+
+// RequestEmergencyAccess mocks base method.
+func (m *MockemergencyAPI) RequestEmergencyAccess(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestEmergencyAccess", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestEmergencyAccess indicates an expected call of RequestEmergencyAccess.
+func (mr *MockemergencyAPIMockRecorder) RequestEmergencyAccess(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmergencyAccess", reflect.TypeOf((*MockemergencyAPI)(nil).RequestEmergencyAccess), arg0, arg1, arg2)
+}
+
+// MockvaultCrypter is a mock of vaultCrypter interface.
+type MockvaultCrypter struct {
+	ctrl     *gomock.Controller
+	recorder *MockvaultCrypterMockRecorder
+}
+
+// MockvaultCrypterMockRecorder is the mock recorder for MockvaultCrypter.
+type MockvaultCrypterMockRecorder struct {
+	mock *MockvaultCrypter
+}
+
+// NewMockvaultCrypter creates a new mock instance.
+func NewMockvaultCrypter(ctrl *gomock.Controller) *MockvaultCrypter {
+	mock := &MockvaultCrypter{ctrl: ctrl}
+	mock.recorder = &MockvaultCrypterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockvaultCrypter) EXPECT() *MockvaultCrypterMockRecorder {
+	return m.recorder
+}
+
+// Decrypt mocks base method.
+func (m *MockvaultCrypter) Decrypt(arg0 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decrypt", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decrypt indicates an expected call of Decrypt.
+func (mr *MockvaultCrypterMockRecorder) Decrypt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrypt", reflect.TypeOf((*MockvaultCrypter)(nil).Decrypt), arg0)
+}
+
+// Encrypt mocks base method.
+func (m *MockvaultCrypter) Encrypt(arg0 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Encrypt", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Encrypt indicates an expected call of Encrypt.
+func (mr *MockvaultCrypterMockRecorder) Encrypt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encrypt", reflect.TypeOf((*MockvaultCrypter)(nil).Encrypt), arg0)
+}
+
+// Key mocks base method.
+func (m *MockvaultCrypter) Key() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Key")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Key indicates an expected call of Key.
+func (mr *MockvaultCrypterMockRecorder) Key() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Key", reflect.TypeOf((*MockvaultCrypter)(nil).Key))
+}
@@ -176,6 +176,44 @@ func (mr *MockitemUpdaterMockRecorder) UpdateItem(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockitemUpdater)(nil).UpdateItem), arg0, arg1, arg2)
 }
 
+// MockcollectionGetter is a mock of collectionGetter interface.
+type MockcollectionGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockcollectionGetterMockRecorder
+}
+
+// MockcollectionGetterMockRecorder is the mock recorder for MockcollectionGetter.
+type MockcollectionGetterMockRecorder struct {
+	mock *MockcollectionGetter
+}
+
+// NewMockcollectionGetter creates a new mock instance.
+func NewMockcollectionGetter(ctrl *gomock.Controller) *MockcollectionGetter {
+	mock := &MockcollectionGetter{ctrl: ctrl}
+	mock.recorder = &MockcollectionGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcollectionGetter) EXPECT() *MockcollectionGetterMockRecorder {
+	return m.recorder
+}
+
+// ListUserCollections mocks base method.
+func (m *MockcollectionGetter) ListUserCollections(arg0 context.Context, arg1 models.UserID) ([]models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserCollections", arg0, arg1)
+	ret0, _ := ret[0].([]models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserCollections indicates an expected call of ListUserCollections.
+func (mr *MockcollectionGetterMockRecorder) ListUserCollections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserCollections", reflect.TypeOf((*MockcollectionGetter)(nil).ListUserCollections), arg0, arg1)
+}
+
 // Mockcrypter is a mock of crypter interface.
 type Mockcrypter struct {
 	ctrl     *gomock.Controller
@@ -310,6 +348,21 @@ func (mr *MockitemStorageMockRecorder) ListByUser(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockitemStorage)(nil).ListByUser), arg0, arg1)
 }
 
+// ListUserCollections mocks base method.
+func (m *MockitemStorage) ListUserCollections(arg0 context.Context, arg1 models.UserID) ([]models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserCollections", arg0, arg1)
+	ret0, _ := ret[0].([]models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserCollections indicates an expected call of ListUserCollections.
+func (mr *MockitemStorageMockRecorder) ListUserCollections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserCollections", reflect.TypeOf((*MockitemStorage)(nil).ListUserCollections), arg0, arg1)
+}
+
 // UpdateItem mocks base method.
 func (m *MockitemStorage) UpdateItem(arg0 context.Context, arg1 *models.ItemInfo, arg2 []byte) error {
 	m.ctrl.T.Helper()
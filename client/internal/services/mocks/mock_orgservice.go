@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: orgservice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/gokeep/shared/models"
+)
+
+// MockorgAPI is a mock of orgAPI interface.
+type MockorgAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockorgAPIMockRecorder
+}
+
+// MockorgAPIMockRecorder is the mock recorder for MockorgAPI.
+type MockorgAPIMockRecorder struct {
+	mock *MockorgAPI
+}
+
+// NewMockorgAPI creates a new mock instance.
+func NewMockorgAPI(ctrl *gomock.Controller) *MockorgAPI {
+	mock := &MockorgAPI{ctrl: ctrl}
+	mock.recorder = &MockorgAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorgAPI) EXPECT() *MockorgAPIMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockorgAPI) AddMember(arg0 context.Context, arg1 models.OrgID, arg2 *models.Member, arg3 []models.CollectionKey, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockorgAPIMockRecorder) AddMember(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockorgAPI)(nil).AddMember), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CreateCollection mocks base method.
+func (m *MockorgAPI) CreateCollection(arg0 context.Context, arg1 *models.Collection, arg2 []models.CollectionKey, arg3 string) (*models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockorgAPIMockRecorder) CreateCollection(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockorgAPI)(nil).CreateCollection), arg0, arg1, arg2, arg3)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockorgAPI) CreateOrganization(arg0 context.Context, arg1 string, arg2 *models.Collection, arg3 string) (*models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockorgAPIMockRecorder) CreateOrganization(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockorgAPI)(nil).CreateOrganization), arg0, arg1, arg2, arg3)
+}
+
+// GetPublicKey mocks base method.
+func (m *MockorgAPI) GetPublicKey(arg0 context.Context, arg1, arg2 string) (*models.PublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.PublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicKey indicates an expected call of GetPublicKey.
+func (mr *MockorgAPIMockRecorder) GetPublicKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKey", reflect.TypeOf((*MockorgAPI)(nil).GetPublicKey), arg0, arg1, arg2)
+}
+
+// ListCollections mocks base method.
+func (m *MockorgAPI) ListCollections(arg0 context.Context, arg1 string) ([]models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollections", arg0, arg1)
+	ret0, _ := ret[0].([]models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollections indicates an expected call of ListCollections.
+func (mr *MockorgAPIMockRecorder) ListCollections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollections", reflect.TypeOf((*MockorgAPI)(nil).ListCollections), arg0, arg1)
+}
+
+// ListMembers mocks base method.
+func (m *MockorgAPI) ListMembers(arg0 context.Context, arg1 models.OrgID, arg2 string) ([]models.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockorgAPIMockRecorder) ListMembers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockorgAPI)(nil).ListMembers), arg0, arg1, arg2)
+}
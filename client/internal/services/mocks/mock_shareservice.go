@@ -0,0 +1,147 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: shareservice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/gokeep/shared/models"
+)
+
+// MockshareAPI is a mock of shareAPI interface.
+type MockshareAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockshareAPIMockRecorder
+}
+
+// MockshareAPIMockRecorder is the mock recorder for MockshareAPI.
+type MockshareAPIMockRecorder struct {
+	mock *MockshareAPI
+}
+
+// NewMockshareAPI creates a new mock instance.
+func NewMockshareAPI(ctrl *gomock.Controller) *MockshareAPI {
+	mock := &MockshareAPI{ctrl: ctrl}
+	mock.recorder = &MockshareAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockshareAPI) EXPECT() *MockshareAPIMockRecorder {
+	return m.recorder
+}
+
+// GetPublicKey mocks base method.
+func (m *MockshareAPI) GetPublicKey(arg0 context.Context, arg1, arg2 string) (*models.PublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.PublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicKey indicates an expected call of GetPublicKey.
+func (mr *MockshareAPIMockRecorder) GetPublicKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKey", reflect.TypeOf((*MockshareAPI)(nil).GetPublicKey), arg0, arg1, arg2)
+}
+
+// ListSharedWithMe mocks base method.
+func (m *MockshareAPI) ListSharedWithMe(arg0 context.Context, arg1 string) ([]models.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSharedWithMe", arg0, arg1)
+	ret0, _ := ret[0].([]models.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSharedWithMe indicates an expected call of ListSharedWithMe.
+func (mr *MockshareAPIMockRecorder) ListSharedWithMe(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSharedWithMe", reflect.TypeOf((*MockshareAPI)(nil).ListSharedWithMe), arg0, arg1)
+}
+
+// RevokeShare mocks base method.
+func (m *MockshareAPI) RevokeShare(arg0 context.Context, arg1 models.ItemID, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShare", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockshareAPIMockRecorder) RevokeShare(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockshareAPI)(nil).RevokeShare), arg0, arg1, arg2, arg3)
+}
+
+// ShareItem mocks base method.
+func (m *MockshareAPI) ShareItem(arg0 context.Context, arg1 *models.Share, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareItem", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShareItem indicates an expected call of ShareItem.
+func (mr *MockshareAPIMockRecorder) ShareItem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareItem", reflect.TypeOf((*MockshareAPI)(nil).ShareItem), arg0, arg1, arg2)
+}
+
+// Mocksealer is a mock of sealer interface.
+type Mocksealer struct {
+	ctrl     *gomock.Controller
+	recorder *MocksealerMockRecorder
+}
+
+// MocksealerMockRecorder is the mock recorder for Mocksealer.
+type MocksealerMockRecorder struct {
+	mock *Mocksealer
+}
+
+// NewMocksealer creates a new mock instance.
+func NewMocksealer(ctrl *gomock.Controller) *Mocksealer {
+	mock := &Mocksealer{ctrl: ctrl}
+	mock.recorder = &MocksealerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocksealer) EXPECT() *MocksealerMockRecorder {
+	return m.recorder
+}
+
+// Open mocks base method.
+func (m *Mocksealer) Open(arg0, arg1 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MocksealerMockRecorder) Open(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*Mocksealer)(nil).Open), arg0, arg1)
+}
+
+// Seal mocks base method.
+func (m *Mocksealer) Seal(arg0, arg1 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seal", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Seal indicates an expected call of Seal.
+func (mr *MocksealerMockRecorder) Seal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seal", reflect.TypeOf((*Mocksealer)(nil).Seal), arg0, arg1)
+}
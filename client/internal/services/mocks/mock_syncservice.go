@@ -101,3 +101,56 @@ func (mr *MocksyncStorageMockRecorder) ReplaceAllUserItems(arg0, arg1, arg2 inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceAllUserItems", reflect.TypeOf((*MocksyncStorage)(nil).ReplaceAllUserItems), arg0, arg1, arg2)
 }
+
+// ReplaceUserCollections mocks base method.
+func (m *MocksyncStorage) ReplaceUserCollections(arg0 context.Context, arg1 models.UserID, arg2 []models.Collection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceUserCollections", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceUserCollections indicates an expected call of ReplaceUserCollections.
+func (mr *MocksyncStorageMockRecorder) ReplaceUserCollections(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceUserCollections", reflect.TypeOf((*MocksyncStorage)(nil).ReplaceUserCollections), arg0, arg1, arg2)
+}
+
+// Mockkeyring is a mock of keyring interface.
+type Mockkeyring struct {
+	ctrl     *gomock.Controller
+	recorder *MockkeyringMockRecorder
+}
+
+// MockkeyringMockRecorder is the mock recorder for Mockkeyring.
+type MockkeyringMockRecorder struct {
+	mock *Mockkeyring
+}
+
+// NewMockkeyring creates a new mock instance.
+func NewMockkeyring(ctrl *gomock.Controller) *Mockkeyring {
+	mock := &Mockkeyring{ctrl: ctrl}
+	mock.recorder = &MockkeyringMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockkeyring) EXPECT() *MockkeyringMockRecorder {
+	return m.recorder
+}
+
+// OpenCollections mocks base method.
+func (m *Mockkeyring) OpenCollections(arg0 context.Context, arg1 *models.User) ([]models.Collection, map[models.CollectionID][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenCollections", arg0, arg1)
+	ret0, _ := ret[0].([]models.Collection)
+	ret1, _ := ret[1].(map[models.CollectionID][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenCollections indicates an expected call of OpenCollections.
+func (mr *MockkeyringMockRecorder) OpenCollections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenCollections", reflect.TypeOf((*Mockkeyring)(nil).OpenCollections), arg0, arg1)
+}
@@ -35,6 +35,49 @@ func (m *MockauthAPI) EXPECT() *MockauthAPIMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockauthAPI) ChangePassword(arg0 context.Context, arg1 *models.PasswordChangeReq, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockauthAPIMockRecorder) ChangePassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockauthAPI)(nil).ChangePassword), arg0, arg1, arg2)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockauthAPI) DeleteAccount(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockauthAPIMockRecorder) DeleteAccount(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockauthAPI)(nil).DeleteAccount), arg0, arg1, arg2)
+}
+
+// ListAuditEvents mocks base method.
+func (m *MockauthAPI) ListAuditEvents(arg0 context.Context, arg1 string, arg2 int, arg3 string) (*models.AuditPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEvents", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.AuditPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEvents indicates an expected call of ListAuditEvents.
+func (mr *MockauthAPIMockRecorder) ListAuditEvents(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEvents", reflect.TypeOf((*MockauthAPI)(nil).ListAuditEvents), arg0, arg1, arg2, arg3)
+}
+
 // Login mocks base method.
 func (m *MockauthAPI) Login(arg0 context.Context, arg1 *models.UserLoginReq) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +93,21 @@ func (mr *MockauthAPIMockRecorder) Login(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockauthAPI)(nil).Login), arg0, arg1)
 }
 
+// Recover mocks base method.
+func (m *MockauthAPI) Recover(arg0 context.Context, arg1 *models.UserRecoverReq) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recover", arg0, arg1)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recover indicates an expected call of Recover.
+func (mr *MockauthAPIMockRecorder) Recover(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recover", reflect.TypeOf((*MockauthAPI)(nil).Recover), arg0, arg1)
+}
+
 // Register mocks base method.
 func (m *MockauthAPI) Register(arg0 context.Context, arg1 *models.UserRegReq) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -64,3 +122,106 @@ func (mr *MockauthAPIMockRecorder) Register(arg0, arg1 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockauthAPI)(nil).Register), arg0, arg1)
 }
+
+// SetKeyPair mocks base method.
+func (m *MockauthAPI) SetKeyPair(arg0 context.Context, arg1 *models.KeyPair, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKeyPair", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKeyPair indicates an expected call of SetKeyPair.
+func (mr *MockauthAPIMockRecorder) SetKeyPair(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeyPair", reflect.TypeOf((*MockauthAPI)(nil).SetKeyPair), arg0, arg1, arg2)
+}
+
+// MockaccountCache is a mock of accountCache interface.
+type MockaccountCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockaccountCacheMockRecorder
+}
+
+// MockaccountCacheMockRecorder is the mock recorder for MockaccountCache.
+type MockaccountCacheMockRecorder struct {
+	mock *MockaccountCache
+}
+
+// NewMockaccountCache creates a new mock instance.
+func NewMockaccountCache(ctrl *gomock.Controller) *MockaccountCache {
+	mock := &MockaccountCache{ctrl: ctrl}
+	mock.recorder = &MockaccountCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockaccountCache) EXPECT() *MockaccountCacheMockRecorder {
+	return m.recorder
+}
+
+// GetAccount mocks base method.
+func (m *MockaccountCache) GetAccount(arg0 context.Context, arg1 string) (*models.LocalAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", arg0, arg1)
+	ret0, _ := ret[0].(*models.LocalAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockaccountCacheMockRecorder) GetAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockaccountCache)(nil).GetAccount), arg0, arg1)
+}
+
+// SaveAccount mocks base method.
+func (m *MockaccountCache) SaveAccount(arg0 context.Context, arg1 *models.LocalAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAccount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAccount indicates an expected call of SaveAccount.
+func (mr *MockaccountCacheMockRecorder) SaveAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAccount", reflect.TypeOf((*MockaccountCache)(nil).SaveAccount), arg0, arg1)
+}
+
+// MocklocalWiper is a mock of localWiper interface.
+type MocklocalWiper struct {
+	ctrl     *gomock.Controller
+	recorder *MocklocalWiperMockRecorder
+}
+
+// MocklocalWiperMockRecorder is the mock recorder for MocklocalWiper.
+type MocklocalWiperMockRecorder struct {
+	mock *MocklocalWiper
+}
+
+// NewMocklocalWiper creates a new mock instance.
+func NewMocklocalWiper(ctrl *gomock.Controller) *MocklocalWiper {
+	mock := &MocklocalWiper{ctrl: ctrl}
+	mock.recorder = &MocklocalWiperMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocklocalWiper) EXPECT() *MocklocalWiperMockRecorder {
+	return m.recorder
+}
+
+// WipeUser mocks base method.
+func (m *MocklocalWiper) WipeUser(arg0 context.Context, arg1 models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WipeUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WipeUser indicates an expected call of WipeUser.
+func (mr *MocklocalWiperMockRecorder) WipeUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WipeUser", reflect.TypeOf((*MocklocalWiper)(nil).WipeUser), arg0, arg1)
+}
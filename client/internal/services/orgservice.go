@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+
+	"github.com/rycln/gokeep/shared/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// orgAPI defines remote operations for organizations and collections
+type orgAPI interface {
+	GetPublicKey(context.Context, string, string) (*models.PublicKey, error)
+	CreateOrganization(context.Context, string, *models.Collection, string) (*models.Collection, error)
+	CreateCollection(context.Context, *models.Collection, []models.CollectionKey, string) (*models.Collection, error)
+	AddMember(context.Context, models.OrgID, *models.Member, []models.CollectionKey, string) error
+	ListMembers(context.Context, models.OrgID, string) ([]models.Member, error)
+	ListCollections(context.Context, string) ([]models.Collection, error)
+}
+
+// OrgService manages organizations and keys of their collections
+// Every collection has a random key sealed to the public key of each member
+type OrgService struct {
+	api   orgAPI
+	crypt crypter // Vault crypter used to open the private key
+	box   sealer
+}
+
+// NewOrgService creates a new OrgService instance
+func NewOrgService(api orgAPI, crypt crypter, box sealer) *OrgService {
+	return &OrgService{
+		api:   api,
+		crypt: crypt,
+		box:   box,
+	}
+}
+
+// CreateOrganization creates organization owned by the user with its first collection
+func (s *OrgService) CreateOrganization(
+	ctx context.Context,
+	user *models.User,
+	name string,
+	colName string,
+) (*models.Collection, error) {
+	if len(user.PublicKey) == 0 {
+		return nil, ErrNoKeyPair
+	}
+
+	wrapped, err := s.newCollectionKey(user.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.api.CreateOrganization(ctx, name, &models.Collection{
+		Name:       colName,
+		WrappedKey: wrapped,
+	}, user.JWT)
+}
+
+// CreateCollection creates collection with a key sealed for every organization member
+func (s *OrgService) CreateCollection(
+	ctx context.Context,
+	user *models.User,
+	org models.OrgID,
+	name string,
+) (*models.Collection, error) {
+	members, err := s.api.ListMembers(ctx, org, user.JWT)
+	if err != nil {
+		return nil, err
+	}
+
+	colKey := make([]byte, keyLength)
+	if _, err := rand.Read(colKey); err != nil {
+		return nil, err
+	}
+
+	keys := make([]models.CollectionKey, 0, len(members))
+	for _, member := range members {
+		if len(member.PublicKey) == 0 {
+			continue
+		}
+		wrapped, err := s.box.Seal(member.PublicKey, colKey)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, models.CollectionKey{
+			UserID:     member.UserID,
+			WrappedKey: wrapped,
+		})
+	}
+
+	return s.api.CreateCollection(ctx, &models.Collection{
+		OrgID: org,
+		Name:  name,
+	}, keys, user.JWT)
+}
+
+// AddMember adds account to organization and shares keys of all its collections
+func (s *OrgService) AddMember(
+	ctx context.Context,
+	user *models.User,
+	org models.OrgID,
+	username string,
+	role models.Role,
+) error {
+	pk, err := s.api.GetPublicKey(ctx, username, user.JWT)
+	if err != nil {
+		return err
+	}
+
+	priv, err := openPrivateKey(s.crypt, user)
+	if err != nil {
+		return err
+	}
+
+	cols, err := s.api.ListCollections(ctx, user.JWT)
+	if err != nil {
+		return err
+	}
+
+	var keys []models.CollectionKey
+	for _, col := range cols {
+		if col.OrgID != org {
+			continue
+		}
+		colKey, err := s.box.Open(priv, col.WrappedKey)
+		if err != nil {
+			return err
+		}
+		wrapped, err := s.box.Seal(pk.Key, colKey)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, models.CollectionKey{
+			CollectionID: col.ID,
+			WrappedKey:   wrapped,
+		})
+	}
+
+	return s.api.AddMember(ctx, org, &models.Member{
+		Username: username,
+		Role:     role,
+	}, keys, user.JWT)
+}
+
+// OpenCollections fetches collections available to the user and opens their keys
+// Returns nothing for accounts without a sharing keypair
+func (s *OrgService) OpenCollections(
+	ctx context.Context,
+	user *models.User,
+) ([]models.Collection, map[models.CollectionID][]byte, error) {
+	if user.EncryptedPrivateKey == "" {
+		return nil, nil, nil
+	}
+
+	cols, err := s.api.ListCollections(ctx, user.JWT)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	priv, err := openPrivateKey(s.crypt, user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys := make(map[models.CollectionID][]byte, len(cols))
+	for _, col := range cols {
+		colKey, err := s.box.Open(priv, col.WrappedKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys[col.ID] = colKey
+	}
+
+	return cols, keys, nil
+}
+
+// newCollectionKey generates collection key sealed to the public key
+func (s *OrgService) newCollectionKey(pub []byte) ([]byte, error) {
+	colKey := make([]byte, keyLength)
+	if _, err := rand.Read(colKey); err != nil {
+		return nil, err
+	}
+
+	return s.box.Seal(pub, colKey)
+}
+
+// openPrivateKey decrypts user private key with the vault key
+func openPrivateKey(crypt crypter, user *models.User) ([]byte, error) {
+	if user.EncryptedPrivateKey == "" {
+		return nil, ErrNoKeyPair
+	}
+
+	encPriv, err := base64.StdEncoding.DecodeString(user.EncryptedPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypt.Decrypt(encPriv)
+}
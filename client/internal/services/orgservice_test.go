@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/client/internal/services/mocks"
+	"github.com/rycln/gokeep/client/internal/strategies/crypto"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgService_CreateAndAddMember(t *testing.T) {
+	ctx := context.Background()
+	box := crypto.NewBox()
+
+	ownerPub, ownerPriv, err := box.GenerateKeyPair()
+	require.NoError(t, err)
+	bobPub, bobPriv, err := box.GenerateKeyPair()
+	require.NoError(t, err)
+
+	owner := &models.User{
+		ID:  "owner",
+		JWT: "owner.jwt",
+		KeyPair: models.KeyPair{
+			PublicKey:           ownerPub,
+			EncryptedPrivateKey: base64.StdEncoding.EncodeToString([]byte("encrypted private key")),
+		},
+	}
+
+	t.Run("member receives collection key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockorgAPI(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		service := NewOrgService(mockAPI, mockCrypt, box)
+
+		mockAPI.EXPECT().
+			CreateOrganization(ctx, "team", gomock.Any(), owner.JWT).
+			DoAndReturn(func(_ context.Context, name string, col *models.Collection, _ string) (*models.Collection, error) {
+				created := *col
+				created.ID = "col1"
+				created.OrgID = "org1"
+				created.OrgName = name
+				created.Role = models.RoleOwner
+				return &created, nil
+			})
+
+		col, err := service.CreateOrganization(ctx, owner, "team", "shared")
+		require.NoError(t, err)
+		assert.Equal(t, "shared", col.Name)
+
+		colKey, err := box.Open(ownerPriv, col.WrappedKey)
+		require.NoError(t, err)
+		assert.Len(t, colKey, keyLength)
+
+		var keys []models.CollectionKey
+		gomock.InOrder(
+			mockAPI.EXPECT().
+				GetPublicKey(ctx, "bob", owner.JWT).
+				Return(&models.PublicKey{UserID: "bob", Key: bobPub}, nil),
+			mockCrypt.EXPECT().
+				Decrypt([]byte("encrypted private key")).
+				Return(ownerPriv, nil),
+			mockAPI.EXPECT().
+				ListCollections(ctx, owner.JWT).
+				Return([]models.Collection{*col, {ID: "other", OrgID: "org2"}}, nil),
+			mockAPI.EXPECT().
+				AddMember(ctx, models.OrgID("org1"), &models.Member{Username: "bob", Role: models.RoleReadOnly}, gomock.Any(), owner.JWT).
+				DoAndReturn(func(_ context.Context, _ models.OrgID, _ *models.Member, k []models.CollectionKey, _ string) error {
+					keys = k
+					return nil
+				}),
+		)
+
+		err = service.AddMember(ctx, owner, "org1", "bob", models.RoleReadOnly)
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, col.ID, keys[0].CollectionID)
+
+		bobKey, err := box.Open(bobPriv, keys[0].WrappedKey)
+		require.NoError(t, err)
+		assert.Equal(t, colKey, bobKey)
+	})
+
+	t.Run("create without keypair", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service := NewOrgService(mocks.NewMockorgAPI(ctrl), mocks.NewMockcrypter(ctrl), box)
+
+		_, err := service.CreateOrganization(ctx, &models.User{ID: "nokeys"}, "team", "shared")
+		assert.ErrorIs(t, err, ErrNoKeyPair)
+	})
+}
+
+func TestOrgService_CreateCollection(t *testing.T) {
+	ctx := context.Background()
+	box := crypto.NewBox()
+
+	pub, priv, err := box.GenerateKeyPair()
+	require.NoError(t, err)
+
+	user := &models.User{ID: "owner", JWT: "owner.jwt"}
+
+	t.Run("keys sealed for members with keypair", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockorgAPI(ctrl)
+		service := NewOrgService(mockAPI, mocks.NewMockcrypter(ctrl), box)
+
+		mockAPI.EXPECT().
+			ListMembers(ctx, models.OrgID("org1"), user.JWT).
+			Return([]models.Member{
+				{UserID: "owner", PublicKey: pub},
+				{UserID: "legacy"},
+			}, nil)
+
+		mockAPI.EXPECT().
+			CreateCollection(ctx, &models.Collection{OrgID: "org1", Name: "ops"}, gomock.Any(), user.JWT).
+			DoAndReturn(func(_ context.Context, col *models.Collection, keys []models.CollectionKey, _ string) (*models.Collection, error) {
+				require.Len(t, keys, 1)
+				assert.Equal(t, models.UserID("owner"), keys[0].UserID)
+				_, err := box.Open(priv, keys[0].WrappedKey)
+				assert.NoError(t, err)
+
+				created := *col
+				created.ID = "col2"
+				return &created, nil
+			})
+
+		col, err := service.CreateCollection(ctx, user, "org1", "ops")
+		require.NoError(t, err)
+		assert.Equal(t, models.CollectionID("col2"), col.ID)
+	})
+}
+
+func TestOrgService_OpenCollections(t *testing.T) {
+	ctx := context.Background()
+	box := crypto.NewBox()
+
+	pub, priv, err := box.GenerateKeyPair()
+	require.NoError(t, err)
+
+	colKey := []byte("0123456789abcdef0123456789abcdef")
+	wrapped, err := box.Seal(pub, colKey)
+	require.NoError(t, err)
+
+	user := &models.User{
+		ID:  "user",
+		JWT: "user.jwt",
+		KeyPair: models.KeyPair{
+			PublicKey:           pub,
+			EncryptedPrivateKey: base64.StdEncoding.EncodeToString([]byte("encrypted private key")),
+		},
+	}
+
+	t.Run("keys are opened", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockorgAPI(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		service := NewOrgService(mockAPI, mockCrypt, box)
+
+		cols := []models.Collection{{ID: "col1", OrgID: "org1", WrappedKey: wrapped}}
+		mockAPI.EXPECT().ListCollections(ctx, user.JWT).Return(cols, nil)
+		mockCrypt.EXPECT().Decrypt([]byte("encrypted private key")).Return(priv, nil)
+
+		gotCols, keys, err := service.OpenCollections(ctx, user)
+		require.NoError(t, err)
+		assert.Equal(t, cols, gotCols)
+		assert.Equal(t, map[models.CollectionID][]byte{"col1": colKey}, keys)
+	})
+
+	t.Run("account without keypair", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service := NewOrgService(mocks.NewMockorgAPI(ctrl), mocks.NewMockcrypter(ctrl), box)
+
+		cols, keys, err := service.OpenCollections(ctx, &models.User{ID: "nokeys"})
+		require.NoError(t, err)
+		assert.Nil(t, cols)
+		assert.Nil(t, keys)
+	})
+}
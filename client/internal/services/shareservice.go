@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/rycln/gokeep/client/internal/strategies/crypto"
+	"github.com/rycln/gokeep/shared/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// ErrNoKeyPair indicates that account has no sharing keypair yet
+var ErrNoKeyPair = errors.New("sharing keypair is not set")
+
+// shareAPI defines remote operations for item sharing
+type shareAPI interface {
+	GetPublicKey(context.Context, string, string) (*models.PublicKey, error)
+	ShareItem(context.Context, *models.Share, string) error
+	ListSharedWithMe(context.Context, string) ([]models.Share, error)
+	RevokeShare(context.Context, models.ItemID, string, string) error
+}
+
+// sealer handles public key encryption of item keys
+type sealer interface {
+	Seal([]byte, []byte) ([]byte, error)
+	Open([]byte, []byte) ([]byte, error)
+}
+
+// sharedPayload is the item representation encrypted for recipients
+type sharedPayload struct {
+	Type     models.ItemType `json:"type"`
+	Name     string          `json:"name"`
+	Metadata string          `json:"metadata"`
+	Content  []byte          `json:"content"`
+}
+
+// ShareService shares items between accounts with end-to-end encryption
+// Each share gets its own random item key sealed to the recipient public key
+type ShareService struct {
+	api   shareAPI
+	crypt crypter // Vault crypter used to open the private key
+	box   sealer
+}
+
+// NewShareService creates a new ShareService instance
+func NewShareService(api shareAPI, crypt crypter, box sealer) *ShareService {
+	return &ShareService{
+		api:   api,
+		crypt: crypt,
+		box:   box,
+	}
+}
+
+// ShareItem encrypts item with a fresh key and shares it with recipient by username
+func (s *ShareService) ShareItem(
+	ctx context.Context,
+	user *models.User,
+	info *models.ItemInfo,
+	content []byte,
+	recipient string,
+) error {
+	pk, err := s.api.GetPublicKey(ctx, recipient, user.JWT)
+	if err != nil {
+		return err
+	}
+
+	plain, err := json.Marshal(&sharedPayload{
+		Type:     info.ItemType,
+		Name:     info.Name,
+		Metadata: info.Metadata,
+		Content:  content,
+	})
+	if err != nil {
+		return err
+	}
+
+	itemKey := make([]byte, keyLength)
+	if _, err := rand.Read(itemKey); err != nil {
+		return err
+	}
+
+	c := crypto.NewAESCrypter()
+	if err := c.SetKey(itemKey); err != nil {
+		return err
+	}
+	defer c.Wipe()
+
+	payload, err := c.Encrypt(plain)
+	if err != nil {
+		return err
+	}
+
+	wrapped, err := s.box.Seal(pk.Key, itemKey)
+	if err != nil {
+		return err
+	}
+
+	return s.api.ShareItem(ctx, &models.Share{
+		ItemID:     info.ID,
+		Recipient:  recipient,
+		WrappedKey: wrapped,
+		Payload:    payload,
+	}, user.JWT)
+}
+
+// ListSharedWithMe fetches and decrypts items shared with the user
+func (s *ShareService) ListSharedWithMe(ctx context.Context, user *models.User) ([]models.SharedItem, error) {
+	if user.EncryptedPrivateKey == "" {
+		return nil, ErrNoKeyPair
+	}
+
+	shares, err := s.api.ListSharedWithMe(ctx, user.JWT)
+	if err != nil {
+		return nil, err
+	}
+
+	encPriv, err := base64.StdEncoding.DecodeString(user.EncryptedPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	priv, err := s.crypt.Decrypt(encPriv)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.SharedItem, 0, len(shares))
+	for _, share := range shares {
+		item, err := s.openShare(priv, &share)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+
+	return items, nil
+}
+
+// RevokeShare removes recipient access to the item
+func (s *ShareService) RevokeShare(ctx context.Context, user *models.User, id models.ItemID, recipient string) error {
+	return s.api.RevokeShare(ctx, id, recipient, user.JWT)
+}
+
+// openShare decrypts a single share with the private key
+func (s *ShareService) openShare(priv []byte, share *models.Share) (*models.SharedItem, error) {
+	itemKey, err := s.box.Open(priv, share.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	c := crypto.NewAESCrypter()
+	if err := c.SetKey(itemKey); err != nil {
+		return nil, err
+	}
+	defer c.Wipe()
+
+	plain, err := c.Decrypt(share.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload sharedPayload
+	if err := json.Unmarshal(plain, &payload); err != nil {
+		return nil, err
+	}
+
+	return &models.SharedItem{
+		ID:       share.ItemID,
+		Owner:    share.Owner,
+		ItemType: payload.Type,
+		Name:     payload.Name,
+		Metadata: payload.Metadata,
+		Content:  payload.Content,
+		SharedAt: share.SharedAt,
+	}, nil
+}
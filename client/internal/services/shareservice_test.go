@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/client/internal/services/mocks"
+	"github.com/rycln/gokeep/client/internal/strategies/crypto"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareService_ShareAndList(t *testing.T) {
+	ctx := context.Background()
+	box := crypto.NewBox()
+
+	pub, priv, err := box.GenerateKeyPair()
+	require.NoError(t, err)
+
+	owner := &models.User{ID: "owner", JWT: "owner.jwt"}
+	recipient := &models.User{
+		ID:  "recipient",
+		JWT: "recipient.jwt",
+		KeyPair: models.KeyPair{
+			PublicKey:           pub,
+			EncryptedPrivateKey: base64.StdEncoding.EncodeToString([]byte("encrypted private key")),
+		},
+	}
+
+	info := &models.ItemInfo{
+		ID:       "item1",
+		ItemType: models.TypePassword,
+		Name:     "github",
+		Metadata: "work",
+	}
+	content := []byte("secret content")
+
+	t.Run("recipient decrypts shared item", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockshareAPI(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		service := NewShareService(mockAPI, mockCrypt, box)
+
+		var sent *models.Share
+		gomock.InOrder(
+			mockAPI.EXPECT().
+				GetPublicKey(ctx, "recipient", owner.JWT).
+				Return(&models.PublicKey{UserID: recipient.ID, Key: pub}, nil),
+			mockAPI.EXPECT().
+				ShareItem(ctx, gomock.Any(), owner.JWT).
+				DoAndReturn(func(_ context.Context, share *models.Share, _ string) error {
+					sent = share
+					return nil
+				}),
+		)
+
+		err := service.ShareItem(ctx, owner, info, content, "recipient")
+		require.NoError(t, err)
+		require.NotNil(t, sent)
+		assert.Equal(t, info.ID, sent.ItemID)
+		assert.Equal(t, "recipient", sent.Recipient)
+		assert.NotContains(t, string(sent.Payload), string(content))
+		assert.NotContains(t, string(sent.Payload), info.Name)
+
+		sharedAt := time.Now()
+		gomock.InOrder(
+			mockAPI.EXPECT().
+				ListSharedWithMe(ctx, recipient.JWT).
+				Return([]models.Share{{
+					ItemID:     sent.ItemID,
+					Owner:      "owner",
+					WrappedKey: sent.WrappedKey,
+					Payload:    sent.Payload,
+					SharedAt:   sharedAt,
+				}}, nil),
+			mockCrypt.EXPECT().
+				Decrypt([]byte("encrypted private key")).
+				Return(priv, nil),
+		)
+
+		items, err := service.ListSharedWithMe(ctx, recipient)
+		require.NoError(t, err)
+		assert.Equal(t, []models.SharedItem{{
+			ID:       info.ID,
+			Owner:    "owner",
+			ItemType: info.ItemType,
+			Name:     info.Name,
+			Metadata: info.Metadata,
+			Content:  content,
+			SharedAt: sharedAt,
+		}}, items)
+	})
+
+	t.Run("unknown recipient", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockshareAPI(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		service := NewShareService(mockAPI, mockCrypt, box)
+
+		testErr := errors.New("not found")
+		mockAPI.EXPECT().
+			GetPublicKey(ctx, "ghost", owner.JWT).
+			Return(nil, testErr)
+
+		err := service.ShareItem(ctx, owner, info, content, "ghost")
+		assert.ErrorIs(t, err, testErr)
+	})
+
+	t.Run("list without keypair", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service := NewShareService(mocks.NewMockshareAPI(ctrl), mocks.NewMockcrypter(ctrl), box)
+
+		_, err := service.ListSharedWithMe(ctx, owner)
+		assert.ErrorIs(t, err, ErrNoKeyPair)
+	})
+
+	t.Run("list with wrong private key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockshareAPI(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		service := NewShareService(mockAPI, mockCrypt, box)
+
+		_, otherPriv, err := box.GenerateKeyPair()
+		require.NoError(t, err)
+		wrapped, err := box.Seal(pub, make([]byte, 32))
+		require.NoError(t, err)
+
+		mockAPI.EXPECT().
+			ListSharedWithMe(ctx, recipient.JWT).
+			Return([]models.Share{{ItemID: "item1", WrappedKey: wrapped}}, nil)
+		mockCrypt.EXPECT().
+			Decrypt(gomock.Any()).
+			Return(otherPriv, nil)
+
+		_, err = service.ListSharedWithMe(ctx, recipient)
+		assert.Error(t, err)
+	})
+}
+
+func TestShareService_RevokeShare(t *testing.T) {
+	ctx := context.Background()
+	user := &models.User{ID: "owner", JWT: "owner.jwt"}
+
+	t.Run("successful revoke", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockshareAPI(ctrl)
+		service := NewShareService(mockAPI, mocks.NewMockcrypter(ctrl), crypto.NewBox())
+
+		mockAPI.EXPECT().
+			RevokeShare(ctx, models.ItemID("item1"), "recipient", user.JWT).
+			Return(nil)
+
+		err := service.RevokeShare(ctx, user, "item1", "recipient")
+		assert.NoError(t, err)
+	})
+}
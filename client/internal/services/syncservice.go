@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 
+	"github.com/rycln/gokeep/client/internal/strategies/crypto"
 	"github.com/rycln/gokeep/shared/models"
 )
 
@@ -20,19 +21,32 @@ type syncStorage interface {
 	GetAllUserItems(context.Context, models.UserID) ([]models.Item, error)
 	// ReplaceAllUserItems completely replaces user's items in local storage
 	ReplaceAllUserItems(context.Context, models.UserID, []models.Item) error
+	// ReplaceUserCollections completely replaces user's collections in local storage
+	ReplaceUserCollections(context.Context, models.UserID, []models.Collection) error
+}
+
+// keyring defines the interface for fetching collections with opened keys
+type keyring interface {
+	OpenCollections(context.Context, *models.User) ([]models.Collection, map[models.CollectionID][]byte, error)
 }
 
 // SyncService handles synchronization between local storage and remote server
+// Collection items are kept encrypted with the vault key locally
+// and with the collection key on the server
 type SyncService struct {
-	sync syncAPI     // Remote synchronization API
-	strg syncStorage // Local items storage
+	sync  syncAPI     // Remote synchronization API
+	strg  syncStorage // Local items storage
+	keys  keyring     // Collection keys source
+	crypt crypter     // Vault crypter
 }
 
 // NewSyncService creates a new SyncService instance
-func NewSyncService(sync syncAPI, strg syncStorage) *SyncService {
+func NewSyncService(sync syncAPI, strg syncStorage, keys keyring, crypt crypter) *SyncService {
 	return &SyncService{
-		sync: sync,
-		strg: strg,
+		sync:  sync,
+		strg:  strg,
+		keys:  keys,
+		crypt: crypt,
 	}
 }
 
@@ -43,15 +57,79 @@ func (s *SyncService) SyncUserItems(ctx context.Context, user *models.User) erro
 		return err
 	}
 
-	serverItems, err := s.sync.Sync(ctx, clientItems, user.JWT)
+	cols, keys, err := s.keys.OpenCollections(ctx, user)
 	if err != nil {
 		return err
 	}
 
-	err = s.strg.ReplaceAllUserItems(ctx, user.ID, serverItems)
+	colCrypters := make(map[models.CollectionID]*crypto.AESCrypter, len(keys))
+	for cid, key := range keys {
+		c := crypto.NewAESCrypter()
+		if err := c.SetKey(key); err != nil {
+			return err
+		}
+		defer c.Wipe()
+		colCrypters[cid] = c
+	}
+
+	writable := make(map[models.CollectionID]bool, len(cols))
+	for _, col := range cols {
+		writable[col.ID] = col.Role.CanWrite()
+	}
+
+	outgoing := make([]models.Item, 0, len(clientItems))
+	for _, item := range clientItems {
+		if item.CollectionID != "" {
+			c, ok := colCrypters[item.CollectionID]
+			if !ok || !writable[item.CollectionID] {
+				continue
+			}
+			item.Data, err = reencrypt(item.Data, s.crypt, c)
+			if err != nil {
+				return err
+			}
+		}
+		outgoing = append(outgoing, item)
+	}
+
+	serverItems, err := s.sync.Sync(ctx, outgoing, user.JWT)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	incoming := make([]models.Item, 0, len(serverItems))
+	for _, item := range serverItems {
+		if item.CollectionID != "" {
+			c, ok := colCrypters[item.CollectionID]
+			if !ok {
+				continue
+			}
+			item.Data, err = reencrypt(item.Data, c, s.crypt)
+			if err != nil {
+				return err
+			}
+		}
+		incoming = append(incoming, item)
+	}
+
+	err = s.strg.ReplaceAllUserItems(ctx, user.ID, incoming)
+	if err != nil {
+		return err
+	}
+
+	return s.strg.ReplaceUserCollections(ctx, user.ID, cols)
+}
+
+// reencrypt moves item content from one key to another
+func reencrypt(data []byte, from, to crypter) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	plain, err := from.Decrypt(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return to.Encrypt(plain)
 }
@@ -7,8 +7,10 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/rycln/gokeep/client/internal/services/mocks"
+	"github.com/rycln/gokeep/client/internal/strategies/crypto"
 	"github.com/rycln/gokeep/shared/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSyncService_SyncUserItems(t *testing.T) {
@@ -31,13 +33,18 @@ func TestSyncService_SyncUserItems(t *testing.T) {
 	t.Run("successful synchronization", func(t *testing.T) {
 		mockSync := mocks.NewMocksyncAPI(ctrl)
 		mockStorage := mocks.NewMocksyncStorage(ctrl)
+		mockKeys := mocks.NewMockkeyring(ctrl)
 
-		svc := NewSyncService(mockSync, mockStorage)
+		svc := NewSyncService(mockSync, mockStorage, mockKeys, mocks.NewMockcrypter(ctrl))
 
 		mockStorage.EXPECT().
 			GetAllUserItems(gomock.Any(), testUser.ID).
 			Return(testClientItems, nil)
 
+		mockKeys.EXPECT().
+			OpenCollections(gomock.Any(), testUser).
+			Return(nil, nil, nil)
+
 		mockSync.EXPECT().
 			Sync(gomock.Any(), testClientItems, testUser.JWT).
 			Return(testServerItems, nil)
@@ -46,6 +53,10 @@ func TestSyncService_SyncUserItems(t *testing.T) {
 			ReplaceAllUserItems(gomock.Any(), testUser.ID, testServerItems).
 			Return(nil)
 
+		mockStorage.EXPECT().
+			ReplaceUserCollections(gomock.Any(), testUser.ID, nil).
+			Return(nil)
+
 		err := svc.SyncUserItems(context.Background(), testUser)
 
 		assert.NoError(t, err)
@@ -54,8 +65,9 @@ func TestSyncService_SyncUserItems(t *testing.T) {
 	t.Run("error getting local items", func(t *testing.T) {
 		mockSync := mocks.NewMocksyncAPI(ctrl)
 		mockStorage := mocks.NewMocksyncStorage(ctrl)
+		mockKeys := mocks.NewMockkeyring(ctrl)
 
-		svc := NewSyncService(mockSync, mockStorage)
+		svc := NewSyncService(mockSync, mockStorage, mockKeys, mocks.NewMockcrypter(ctrl))
 
 		expectedErr := errors.New("storage error")
 
@@ -71,8 +83,9 @@ func TestSyncService_SyncUserItems(t *testing.T) {
 	t.Run("error during sync with server", func(t *testing.T) {
 		mockSync := mocks.NewMocksyncAPI(ctrl)
 		mockStorage := mocks.NewMocksyncStorage(ctrl)
+		mockKeys := mocks.NewMockkeyring(ctrl)
 
-		svc := NewSyncService(mockSync, mockStorage)
+		svc := NewSyncService(mockSync, mockStorage, mockKeys, mocks.NewMockcrypter(ctrl))
 
 		expectedErr := errors.New("sync error")
 
@@ -80,6 +93,10 @@ func TestSyncService_SyncUserItems(t *testing.T) {
 			GetAllUserItems(gomock.Any(), testUser.ID).
 			Return(testClientItems, nil)
 
+		mockKeys.EXPECT().
+			OpenCollections(gomock.Any(), testUser).
+			Return(nil, nil, nil)
+
 		mockSync.EXPECT().
 			Sync(gomock.Any(), testClientItems, testUser.JWT).
 			Return(nil, expectedErr)
@@ -91,8 +108,9 @@ func TestSyncService_SyncUserItems(t *testing.T) {
 	t.Run("error saving synced items", func(t *testing.T) {
 		mockSync := mocks.NewMocksyncAPI(ctrl)
 		mockStorage := mocks.NewMocksyncStorage(ctrl)
+		mockKeys := mocks.NewMockkeyring(ctrl)
 
-		svc := NewSyncService(mockSync, mockStorage)
+		svc := NewSyncService(mockSync, mockStorage, mockKeys, mocks.NewMockcrypter(ctrl))
 
 		expectedErr := errors.New("save error")
 
@@ -100,6 +118,10 @@ func TestSyncService_SyncUserItems(t *testing.T) {
 			GetAllUserItems(gomock.Any(), testUser.ID).
 			Return(testClientItems, nil)
 
+		mockKeys.EXPECT().
+			OpenCollections(gomock.Any(), testUser).
+			Return(nil, nil, nil)
+
 		mockSync.EXPECT().
 			Sync(gomock.Any(), testClientItems, testUser.JWT).
 			Return(testServerItems, nil)
@@ -112,3 +134,106 @@ func TestSyncService_SyncUserItems(t *testing.T) {
 		assert.EqualError(t, err, expectedErr.Error())
 	})
 }
+
+func TestSyncService_SyncCollectionItems(t *testing.T) {
+	ctx := context.Background()
+
+	vaultKey := make([]byte, keyLength)
+	colKey := make([]byte, keyLength)
+	colKey[0] = 1
+
+	vault := crypto.NewAESCrypter()
+	require.NoError(t, vault.SetKey(vaultKey))
+	col := crypto.NewAESCrypter()
+	require.NoError(t, col.SetKey(colKey))
+
+	testUser := &models.User{ID: "user123", JWT: "token123"}
+	cols := []models.Collection{
+		{ID: "col1", OrgID: "org1", Role: models.RoleMember},
+		{ID: "col2", OrgID: "org1", Role: models.RoleReadOnly},
+	}
+	keys := map[models.CollectionID][]byte{"col1": colKey, "col2": colKey}
+
+	localData, err := vault.Encrypt([]byte("local"))
+	require.NoError(t, err)
+	remoteData, err := col.Encrypt([]byte("remote"))
+	require.NoError(t, err)
+
+	t.Run("collection items are reencrypted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSync := mocks.NewMocksyncAPI(ctrl)
+		mockStorage := mocks.NewMocksyncStorage(ctrl)
+		mockKeys := mocks.NewMockkeyring(ctrl)
+
+		svc := NewSyncService(mockSync, mockStorage, mockKeys, vault)
+
+		mockStorage.EXPECT().
+			GetAllUserItems(ctx, testUser.ID).
+			Return([]models.Item{
+				{ID: "item1", CollectionID: "col1", Data: localData},
+				{ID: "item2", CollectionID: "col2", Data: localData},
+				{ID: "item3", CollectionID: "unknown", Data: localData},
+			}, nil)
+
+		mockKeys.EXPECT().
+			OpenCollections(ctx, testUser).
+			Return(cols, keys, nil)
+
+		mockSync.EXPECT().
+			Sync(ctx, gomock.Any(), testUser.JWT).
+			DoAndReturn(func(_ context.Context, items []models.Item, _ string) ([]models.Item, error) {
+				require.Len(t, items, 1)
+				assert.Equal(t, models.ItemID("item1"), items[0].ID)
+				plain, err := col.Decrypt(items[0].Data)
+				require.NoError(t, err)
+				assert.Equal(t, []byte("local"), plain)
+
+				return []models.Item{
+					{ID: "item1", CollectionID: "col1", Data: remoteData},
+					{ID: "item4", CollectionID: "unknown", Data: remoteData},
+				}, nil
+			})
+
+		mockStorage.EXPECT().
+			ReplaceAllUserItems(ctx, testUser.ID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ models.UserID, items []models.Item) error {
+				require.Len(t, items, 1)
+				plain, err := vault.Decrypt(items[0].Data)
+				require.NoError(t, err)
+				assert.Equal(t, []byte("remote"), plain)
+				return nil
+			})
+
+		mockStorage.EXPECT().
+			ReplaceUserCollections(ctx, testUser.ID, cols).
+			Return(nil)
+
+		err := svc.SyncUserItems(ctx, testUser)
+		assert.NoError(t, err)
+	})
+
+	t.Run("collections fetch error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMocksyncStorage(ctrl)
+		mockKeys := mocks.NewMockkeyring(ctrl)
+
+		svc := NewSyncService(mocks.NewMocksyncAPI(ctrl), mockStorage, mockKeys, vault)
+
+		expectedErr := errors.New("unavailable")
+
+		mockStorage.EXPECT().
+			GetAllUserItems(ctx, testUser.ID).
+			Return(nil, nil)
+
+		mockKeys.EXPECT().
+			OpenCollections(ctx, testUser).
+			Return(nil, nil, expectedErr)
+
+		err := svc.SyncUserItems(ctx, testUser)
+		assert.Equal(t, expectedErr, err)
+	})
+}
@@ -2,8 +2,12 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/rycln/gokeep/shared/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 //go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
@@ -12,17 +16,40 @@ import (
 type authAPI interface {
 	Register(context.Context, *models.UserRegReq) (*models.User, error)
 	Login(context.Context, *models.UserLoginReq) (*models.User, error)
+	Recover(context.Context, *models.UserRecoverReq) (*models.User, error)
+	ChangePassword(context.Context, *models.PasswordChangeReq, string) error
+	DeleteAccount(context.Context, string, string) error
+	SetKeyPair(context.Context, *models.KeyPair, string) error
+	ListAuditEvents(context.Context, string, int, string) (*models.AuditPage, error)
 }
 
+// accountCache defines local storage of credentials for offline unlock
+type accountCache interface {
+	SaveAccount(context.Context, *models.LocalAccount) error
+	GetAccount(context.Context, string) (*models.LocalAccount, error)
+}
+
+// localWiper defines removal of local data of a deleted account
+type localWiper interface {
+	WipeUser(context.Context, models.UserID) error
+}
+
+// ErrOffline indicates that the server cannot be reached
+var ErrOffline = errors.New("server unavailable")
+
 // UserService handles user authentication business logic
 type UserService struct {
-	api authAPI // Authentication API implementation
+	api   authAPI      // Authentication API implementation
+	cache accountCache // Local credentials cache
+	local localWiper   // Local vault storage
 }
 
 // NewAuthService creates a new UserService instance
-func NewAuthService(api authAPI) *UserService {
+func NewAuthService(api authAPI, cache accountCache, local localWiper) *UserService {
 	return &UserService{
-		api: api,
+		api:   api,
+		cache: cache,
+		local: local,
 	}
 }
 
@@ -32,6 +59,75 @@ func (s *UserService) UserRegister(ctx context.Context, req *models.UserRegReq)
 }
 
 // UserLogin handles user authentication flow
+// Wraps network failures with ErrOffline so callers can fall back to the cache
 func (s *UserService) UserLogin(ctx context.Context, req *models.UserLoginReq) (*models.User, error) {
-	return s.api.Login(ctx, req)
+	user, err := s.api.Login(ctx, req)
+	if isUnavailable(err) {
+		return nil, fmt.Errorf("%w: %v", ErrOffline, err)
+	}
+	return user, err
+}
+
+// UserRecover handles recovery key authentication flow
+func (s *UserService) UserRecover(ctx context.Context, req *models.UserRecoverReq) (*models.User, error) {
+	return s.api.Recover(ctx, req)
+}
+
+// UserChangePassword handles credentials rotation of authenticated user
+func (s *UserService) UserChangePassword(ctx context.Context, req *models.PasswordChangeReq, user *models.User) error {
+	return s.api.ChangePassword(ctx, req, user.JWT)
+}
+
+// UserDeleteAccount removes the account on the server, then wipes its local rows
+// Local data is kept when the server refuses, so a mistyped password loses nothing
+func (s *UserService) UserDeleteAccount(ctx context.Context, password string, user *models.User) error {
+	if user.Offline {
+		return ErrOffline
+	}
+
+	err := s.api.DeleteAccount(ctx, password, user.JWT)
+	if err != nil {
+		return err
+	}
+
+	return s.local.WipeUser(ctx, user.ID)
+}
+
+// UserActivity fetches a page of the account audit log, newest events first
+func (s *UserService) UserActivity(ctx context.Context, token string, size int, user *models.User) (*models.AuditPage, error) {
+	if user.Offline {
+		return nil, ErrOffline
+	}
+
+	return s.api.ListAuditEvents(ctx, token, size, user.JWT)
+}
+
+// UserSetKeyPair uploads sharing keypair of authenticated user
+func (s *UserService) UserSetKeyPair(ctx context.Context, kp *models.KeyPair, user *models.User) error {
+	return s.api.SetKeyPair(ctx, kp, user.JWT)
+}
+
+// CacheAccount stores credentials for offline unlock
+func (s *UserService) CacheAccount(ctx context.Context, account *models.LocalAccount) error {
+	return s.cache.SaveAccount(ctx, account)
+}
+
+// CachedAccount returns credentials stored by CacheAccount
+func (s *UserService) CachedAccount(ctx context.Context, username string) (*models.LocalAccount, error) {
+	return s.cache.GetAccount(ctx, username)
+}
+
+// isUnavailable reports whether err means the server could not be reached
+func isUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
 }
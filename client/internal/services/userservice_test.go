@@ -10,6 +10,8 @@ import (
 	"github.com/rycln/gokeep/shared/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -26,10 +28,12 @@ func TestNewAuthService(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockAPI := mocks.NewMockauthAPI(ctrl)
-		service := NewAuthService(mockAPI)
+		mockCache := mocks.NewMockaccountCache(ctrl)
+		service := NewAuthService(mockAPI, mockCache, nil)
 
 		assert.NotNil(t, service)
 		assert.Equal(t, mockAPI, service.api)
+		assert.Equal(t, mockCache, service.cache)
 	})
 }
 
@@ -52,7 +56,8 @@ func TestUserService_UserRegister(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockAPI := mocks.NewMockauthAPI(ctrl)
-		service := NewAuthService(mockAPI)
+		mockCache := mocks.NewMockaccountCache(ctrl)
+		service := NewAuthService(mockAPI, mockCache, nil)
 
 		mockAPI.EXPECT().
 			Register(ctx, testReq).
@@ -68,7 +73,8 @@ func TestUserService_UserRegister(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockAPI := mocks.NewMockauthAPI(ctrl)
-		service := NewAuthService(mockAPI)
+		mockCache := mocks.NewMockaccountCache(ctrl)
+		service := NewAuthService(mockAPI, mockCache, nil)
 
 		expectedErr := errors.New("registration failed")
 		mockAPI.EXPECT().
@@ -99,7 +105,8 @@ func TestUserService_UserLogin(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockAPI := mocks.NewMockauthAPI(ctrl)
-		service := NewAuthService(mockAPI)
+		mockCache := mocks.NewMockaccountCache(ctrl)
+		service := NewAuthService(mockAPI, mockCache, nil)
 
 		mockAPI.EXPECT().
 			Login(ctx, testReq).
@@ -115,7 +122,8 @@ func TestUserService_UserLogin(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockAPI := mocks.NewMockauthAPI(ctrl)
-		service := NewAuthService(mockAPI)
+		mockCache := mocks.NewMockaccountCache(ctrl)
+		service := NewAuthService(mockAPI, mockCache, nil)
 
 		expectedErr := errors.New("login failed")
 		mockAPI.EXPECT().
@@ -125,5 +133,253 @@ func TestUserService_UserLogin(t *testing.T) {
 		_, err := service.UserLogin(ctx, testReq)
 		assert.Error(t, err)
 		assert.Equal(t, expectedErr, err)
+		assert.NotErrorIs(t, err, ErrOffline)
+	})
+
+	t.Run("should mark unreachable server as offline", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockauthAPI(ctrl)
+		service := NewAuthService(mockAPI, mocks.NewMockaccountCache(ctrl), nil)
+
+		for _, apiErr := range []error{
+			status.Error(codes.Unavailable, "connection refused"),
+			status.Error(codes.DeadlineExceeded, "deadline"),
+			context.DeadlineExceeded,
+		} {
+			mockAPI.EXPECT().
+				Login(ctx, testReq).
+				Return(nil, apiErr)
+
+			_, err := service.UserLogin(ctx, testReq)
+			assert.ErrorIs(t, err, ErrOffline)
+		}
+	})
+
+	t.Run("should not treat auth failure as offline", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockauthAPI(ctrl)
+		service := NewAuthService(mockAPI, mocks.NewMockaccountCache(ctrl), nil)
+
+		mockAPI.EXPECT().
+			Login(ctx, testReq).
+			Return(nil, status.Error(codes.Unauthenticated, "wrong password"))
+
+		_, err := service.UserLogin(ctx, testReq)
+		assert.NotErrorIs(t, err, ErrOffline)
+	})
+}
+
+func TestUserService_AccountCache(t *testing.T) {
+	ctx := context.Background()
+	account := &models.LocalAccount{
+		Username:      testUser,
+		UserID:        models.UserID(testUserID),
+		Salt:          testSalt,
+		KDFIterations: 600000,
+	}
+
+	t.Run("should store account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCache := mocks.NewMockaccountCache(ctrl)
+		service := NewAuthService(mocks.NewMockauthAPI(ctrl), mockCache, nil)
+
+		mockCache.EXPECT().SaveAccount(ctx, account).Return(nil)
+
+		assert.NoError(t, service.CacheAccount(ctx, account))
+	})
+
+	t.Run("should load account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCache := mocks.NewMockaccountCache(ctrl)
+		service := NewAuthService(mocks.NewMockauthAPI(ctrl), mockCache, nil)
+
+		mockCache.EXPECT().GetAccount(ctx, testUser).Return(account, nil)
+
+		cached, err := service.CachedAccount(ctx, testUser)
+		require.NoError(t, err)
+		assert.Equal(t, account, cached)
+	})
+}
+
+func TestUserService_UserRecover(t *testing.T) {
+	ctx := context.Background()
+	testReq := &models.UserRecoverReq{
+		Username:     testUser,
+		RecoveryAuth: "recovery_auth",
+	}
+
+	expectedUser := &models.User{
+		ID:          models.UserID(testUserID),
+		JWT:         testToken,
+		Salt:        testSalt,
+		RecoveryKey: "recovery_key",
+	}
+
+	t.Run("successful recovery", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockauthAPI(ctrl)
+		mockCache := mocks.NewMockaccountCache(ctrl)
+		service := NewAuthService(mockAPI, mockCache, nil)
+
+		mockAPI.EXPECT().
+			Recover(ctx, testReq).
+			Return(expectedUser, nil)
+
+		user, err := service.UserRecover(ctx, testReq)
+		require.NoError(t, err)
+		assert.Equal(t, expectedUser, user)
+	})
+
+	t.Run("recovery error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockauthAPI(ctrl)
+		mockCache := mocks.NewMockaccountCache(ctrl)
+		service := NewAuthService(mockAPI, mockCache, nil)
+
+		expectedErr := errors.New("recovery failed")
+		mockAPI.EXPECT().
+			Recover(ctx, testReq).
+			Return(nil, expectedErr)
+
+		_, err := service.UserRecover(ctx, testReq)
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
+func TestUserService_UserChangePassword(t *testing.T) {
+	ctx := context.Background()
+	testReq := &models.PasswordChangeReq{
+		Password:     testPass,
+		Salt:         testSalt,
+		EncryptedKey: "encrypted_key",
+	}
+	user := &models.User{ID: models.UserID(testUserID), JWT: testToken}
+
+	t.Run("passes user token to api", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockauthAPI(ctrl)
+		mockCache := mocks.NewMockaccountCache(ctrl)
+		service := NewAuthService(mockAPI, mockCache, nil)
+
+		mockAPI.EXPECT().
+			ChangePassword(ctx, testReq, testToken).
+			Return(nil)
+
+		err := service.UserChangePassword(ctx, testReq, user)
+		assert.NoError(t, err)
+	})
+}
+
+func TestUserService_UserSetKeyPair(t *testing.T) {
+	ctx := context.Background()
+	kp := &models.KeyPair{
+		PublicKey:           []byte("public_key"),
+		EncryptedPrivateKey: "encrypted_private_key",
+	}
+	user := &models.User{ID: models.UserID(testUserID), JWT: testToken}
+
+	t.Run("passes user token to api", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockauthAPI(ctrl)
+		mockCache := mocks.NewMockaccountCache(ctrl)
+		service := NewAuthService(mockAPI, mockCache, nil)
+
+		mockAPI.EXPECT().
+			SetKeyPair(ctx, kp, testToken).
+			Return(nil)
+
+		err := service.UserSetKeyPair(ctx, kp, user)
+		assert.NoError(t, err)
+	})
+}
+
+func TestUserService_UserDeleteAccount(t *testing.T) {
+	ctx := context.Background()
+	user := &models.User{ID: "user123", JWT: "token"}
+
+	t.Run("server and local data removed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockauthAPI(ctrl)
+		mockLocal := mocks.NewMocklocalWiper(ctrl)
+		service := NewAuthService(mockAPI, mocks.NewMockaccountCache(ctrl), mockLocal)
+
+		gomock.InOrder(
+			mockAPI.EXPECT().DeleteAccount(ctx, "pass", "token").Return(nil),
+			mockLocal.EXPECT().WipeUser(ctx, models.UserID("user123")).Return(nil),
+		)
+
+		err := service.UserDeleteAccount(ctx, "pass", user)
+		assert.NoError(t, err)
+	})
+
+	t.Run("local data kept when server refuses", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockauthAPI(ctrl)
+		service := NewAuthService(mockAPI, mocks.NewMockaccountCache(ctrl), mocks.NewMocklocalWiper(ctrl))
+
+		expectedErr := errors.New("wrong password")
+		mockAPI.EXPECT().DeleteAccount(ctx, "wrong", "token").Return(expectedErr)
+
+		err := service.UserDeleteAccount(ctx, "wrong", user)
+		assert.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("offline user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service := NewAuthService(mocks.NewMockauthAPI(ctrl), mocks.NewMockaccountCache(ctrl), mocks.NewMocklocalWiper(ctrl))
+
+		err := service.UserDeleteAccount(ctx, "pass", &models.User{ID: "user123", Offline: true})
+		assert.ErrorIs(t, err, ErrOffline)
+	})
+}
+
+func TestUserService_UserActivity(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("page fetched with user token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAPI := mocks.NewMockauthAPI(ctrl)
+		service := NewAuthService(mockAPI, nil, nil)
+
+		page := &models.AuditPage{Events: []models.AuditEvent{{ID: 5}}, NextToken: "5"}
+		mockAPI.EXPECT().ListAuditEvents(ctx, "", 20, "token").Return(page, nil)
+
+		got, err := service.UserActivity(ctx, "", 20, &models.User{ID: "user123", JWT: "token"})
+		require.NoError(t, err)
+		assert.Equal(t, page, got)
+	})
+
+	t.Run("offline user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service := NewAuthService(mocks.NewMockauthAPI(ctrl), nil, nil)
+
+		_, err := service.UserActivity(ctx, "", 20, &models.User{ID: "user123", Offline: true})
+		assert.ErrorIs(t, err, ErrOffline)
 	})
 }
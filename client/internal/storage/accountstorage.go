@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+
+	"github.com/rycln/gokeep/shared/models"
+)
+
+// ErrNoAccount is returned when no credentials are cached for the username
+var ErrNoAccount = errors.New("no cached account")
+
+// AccountStorage caches credentials required to unlock the vault offline
+// Usernames are stored hashed, secrets only in wrapped or encrypted form
+type AccountStorage struct {
+	db *sql.DB // Database connection
+}
+
+// NewAccountStorage creates a new AccountStorage instance
+func NewAccountStorage(db *sql.DB) *AccountStorage {
+	return &AccountStorage{db: db}
+}
+
+// SaveAccount creates or replaces cached credentials of the user
+func (s *AccountStorage) SaveAccount(ctx context.Context, account *models.LocalAccount) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		sqlSaveAccount,
+		hashUsername(account.Username),
+		account.UserID,
+		account.Salt,
+		account.KDFIterations,
+		account.EncryptedKey,
+		account.KeyCheck,
+	)
+	return err
+}
+
+// GetAccount returns cached credentials by username
+func (s *AccountStorage) GetAccount(ctx context.Context, username string) (*models.LocalAccount, error) {
+	account := &models.LocalAccount{Username: username}
+	err := s.db.QueryRowContext(ctx, sqlGetAccount, hashUsername(username)).Scan(
+		&account.UserID,
+		&account.Salt,
+		&account.KDFIterations,
+		&account.EncryptedKey,
+		&account.KeyCheck,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNoAccount
+	}
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// hashUsername hides usernames of cached accounts
+func hashUsername(username string) string {
+	sum := sha256.Sum256([]byte(username))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountStorage_SaveAccount(t *testing.T) {
+	ctx := context.Background()
+	account := &models.LocalAccount{
+		Username:      "testuser",
+		UserID:        "user123",
+		Salt:          "salt",
+		KDFIterations: 600000,
+		EncryptedKey:  "wrappedKey",
+		KeyCheck:      "keyCheck",
+	}
+
+	expectedQuery := regexp.QuoteMeta(sqlSaveAccount)
+
+	t.Run("successful save", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		storage := NewAccountStorage(db)
+
+		mock.ExpectExec(expectedQuery).
+			WithArgs(
+				hashUsername(account.Username),
+				account.UserID,
+				account.Salt,
+				account.KDFIterations,
+				account.EncryptedKey,
+				account.KeyCheck,
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err = storage.SaveAccount(ctx, account)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		storage := NewAccountStorage(db)
+
+		expectedErr := errors.New("database error")
+		mock.ExpectExec(expectedQuery).
+			WillReturnError(expectedErr)
+
+		err = storage.SaveAccount(ctx, account)
+		assert.Equal(t, expectedErr, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAccountStorage_GetAccount(t *testing.T) {
+	ctx := context.Background()
+	expectedQuery := regexp.QuoteMeta(sqlGetAccount)
+
+	t.Run("successful get", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		storage := NewAccountStorage(db)
+
+		rows := sqlmock.NewRows([]string{
+			"user_id", "salt", "kdf_iterations", "encrypted_key", "key_check",
+		}).AddRow("user123", "salt", 600000, "wrappedKey", "keyCheck")
+
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(hashUsername("testuser")).
+			WillReturnRows(rows)
+
+		account, err := storage.GetAccount(ctx, "testuser")
+		require.NoError(t, err)
+		assert.Equal(t, &models.LocalAccount{
+			Username:      "testuser",
+			UserID:        "user123",
+			Salt:          "salt",
+			KDFIterations: 600000,
+			EncryptedKey:  "wrappedKey",
+			KeyCheck:      "keyCheck",
+		}, account)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no cached account", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		storage := NewAccountStorage(db)
+
+		mock.ExpectQuery(expectedQuery).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err = storage.GetAccount(ctx, "testuser")
+		assert.ErrorIs(t, err, ErrNoAccount)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should not store plaintext username", func(t *testing.T) {
+		assert.NotContains(t, hashUsername("testuser"), "testuser")
+		assert.Equal(t, hashUsername("testuser"), hashUsername("testuser"))
+	})
+}
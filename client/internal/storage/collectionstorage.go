@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rycln/gokeep/shared/models"
+)
+
+// ReplaceUserCollections replaces cached organization collections of a user
+// Collections are cached to group items while offline, keys are never stored
+func (s *ItemStorage) ReplaceUserCollections(ctx context.Context, uid models.UserID, cols []models.Collection) (err error) {
+	blindUID, err := s.blind(uid)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			err = fmt.Errorf("%v; rollback failed: %w", err, rollbackErr)
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx, sqlDeleteUserCollections, blindUID); err != nil {
+		return fmt.Errorf("failed to clear collections table: %w", err)
+	}
+
+	for _, col := range cols {
+		orgName, err := s.seal(col.OrgName)
+		if err != nil {
+			return err
+		}
+		name, err := s.seal(col.Name)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, sqlAddUserCollection, col.ID, blindUID, col.OrgID, orgName, name, col.Role)
+		if err != nil {
+			return fmt.Errorf("failed to insert collection %s: %w", col.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListUserCollections retrieves cached organization collections of a user
+func (s *ItemStorage) ListUserCollections(ctx context.Context, uid models.UserID) ([]models.Collection, error) {
+	blindUID, err := s.blind(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, sqlGetUserCollections, blindUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cols []models.Collection
+	for rows.Next() {
+		var col models.Collection
+		var orgName, name string
+		if err := rows.Scan(&col.ID, &col.OrgID, &orgName, &name, &col.Role); err != nil {
+			return nil, err
+		}
+		if col.OrgName, err = s.open(orgName); err != nil {
+			return nil, fmt.Errorf("failed to decrypt collection %s: %w", col.ID, err)
+		}
+		if col.Name, err = s.open(name); err != nil {
+			return nil, fmt.Errorf("failed to decrypt collection %s: %w", col.ID, err)
+		}
+		cols = append(cols, col)
+	}
+
+	return cols, rows.Err()
+}
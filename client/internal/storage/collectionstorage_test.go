@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemStorage_ReplaceUserCollections(t *testing.T) {
+	ctx := context.Background()
+	userID := models.UserID("user123")
+
+	cols := []models.Collection{
+		{ID: "col1", OrgID: "org1", OrgName: "team", Name: "shared", Role: models.RoleMember},
+	}
+
+	t.Run("successful replace", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		storage := NewItemStorage(db, testCrypter{})
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteUserCollections)).
+			WithArgs(blinded(userID)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddUserCollection)).
+			WithArgs("col1", blinded(userID), "org1", sealed("team"), sealed("shared"), models.RoleMember).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err = storage.ReplaceUserCollections(ctx, userID, cols)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("insert error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		storage := NewItemStorage(db, testCrypter{})
+
+		expectedErr := errors.New("insert error")
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteUserCollections)).
+			WithArgs(blinded(userID)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddUserCollection)).
+			WillReturnError(expectedErr)
+		mock.ExpectRollback()
+
+		err = storage.ReplaceUserCollections(ctx, userID, cols)
+		assert.ErrorIs(t, err, expectedErr)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestItemStorage_ListUserCollections(t *testing.T) {
+	ctx := context.Background()
+	userID := models.UserID("user123")
+
+	expectedQuery := regexp.QuoteMeta(sqlGetUserCollections)
+
+	t.Run("successful list", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		storage := NewItemStorage(db, testCrypter{})
+
+		rows := sqlmock.NewRows([]string{"id", "org_id", "org_name", "name", "role"}).
+			AddRow("col1", "org1", sealed("team"), sealed("shared"), "readonly")
+
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(blinded(userID)).
+			WillReturnRows(rows)
+
+		cols, err := storage.ListUserCollections(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, []models.Collection{
+			{ID: "col1", OrgID: "org1", OrgName: "team", Name: "shared", Role: models.RoleReadOnly},
+		}, cols)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("plaintext column", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		storage := NewItemStorage(db, testCrypter{})
+
+		rows := sqlmock.NewRows([]string{"id", "org_id", "org_name", "name", "role"}).
+			AddRow("col1", "org1", "team", sealed("shared"), "member")
+
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(blinded(userID)).
+			WillReturnRows(rows)
+
+		_, err = storage.ListUserCollections(ctx, userID)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestItemStorage_WipeUser(t *testing.T) {
+	ctx := context.Background()
+	userID := models.UserID("user123")
+
+	t.Run("all user rows removed", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		storage := NewItemStorage(db, testCrypter{})
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlWipeUserItems)).
+			WithArgs(blinded(userID), userID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteUserCollections)).
+			WithArgs(blinded(userID)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteUserAccount)).
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err = storage.WipeUser(ctx, userID)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("items removal error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		storage := NewItemStorage(db, testCrypter{})
+
+		expectedErr := errors.New("delete error")
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlWipeUserItems)).
+			WillReturnError(expectedErr)
+		mock.ExpectRollback()
+
+		err = storage.WipeUser(ctx, userID)
+		assert.ErrorIs(t, err, expectedErr)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -25,5 +25,28 @@ func InitDB(ctx context.Context, db *sql.DB) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	_, err = db.ExecContext(ctx, sqlCreateAccountsTable)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, sqlCreateCollectionsTable)
+	if err != nil {
+		return err
+	}
+	return addCollectionColumn(ctx, db)
+}
+
+// addCollectionColumn adds collection column to items tables of older versions
+func addCollectionColumn(ctx context.Context, db *sql.DB) error {
+	var n int
+	err := db.QueryRowContext(ctx, sqlHasCollectionColumn).Scan(&n)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+
+	_, err = db.ExecContext(ctx, sqlAddCollectionColumn)
+	return err
 }
@@ -37,6 +37,14 @@ func TestInitDB(t *testing.T) {
 
 		mock.ExpectExec(expectedQuery).
 			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(sqlCreateAccountsTable)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(sqlCreateCollectionsTable)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(sqlHasCollectionColumn)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddCollectionColumn)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
 
 		err = InitDB(context.Background(), db)
 		assert.NoError(t, err)
@@ -79,3 +87,11 @@ func TestInitDB(t *testing.T) {
 		assert.Contains(t, err.Error(), "context canceled")
 	})
 }
+
+// expectCollectionsSchema expects creation of collections table on up to date items table
+func expectCollectionsSchema(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta(sqlCreateCollectionsTable)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(sqlHasCollectionColumn)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+}
@@ -3,40 +3,72 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 
 	"github.com/rycln/gokeep/shared/models"
 )
 
+// fieldCrypter encrypts column values with the vault key
+type fieldCrypter interface {
+	Encrypt([]byte) ([]byte, error)
+	Decrypt([]byte) ([]byte, error)
+	Blind([]byte) ([]byte, error)
+}
+
 // ItemStorage handles persistent storage operations for items
+// Name, type and metadata are stored encrypted, user ID as a blind index
+// Collection ID is kept in plaintext to group items without decryption
 type ItemStorage struct {
-	db *sql.DB // Database connection
+	db    *sql.DB      // Database connection
+	crypt fieldCrypter // Column encryption
 }
 
 // NewItemStorage creates a new ItemStorage instance
-func NewItemStorage(db *sql.DB) *ItemStorage {
-	return &ItemStorage{db: db}
+func NewItemStorage(db *sql.DB, crypt fieldCrypter) *ItemStorage {
+	return &ItemStorage{db: db, crypt: crypt}
+}
+
+// Open prepares storage for an unlocked user
+// Creates schema and encrypts rows left in plaintext by older versions
+func (s *ItemStorage) Open(ctx context.Context, uid models.UserID) error {
+	if err := InitDB(ctx, s.db); err != nil {
+		return err
+	}
+	return s.migratePlaintext(ctx, uid)
 }
 
 // Add stores a new item with its content and metadata
 func (s *ItemStorage) Add(ctx context.Context, info *models.ItemInfo, content []byte) error {
-	_, err := s.db.ExecContext(
+	row, err := s.sealRow(info.UserID, info.ItemType, info.Name, info.Metadata)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(
 		ctx,
 		sqlAddItem,
 		info.ID,
-		info.UserID,
-		info.ItemType,
-		info.Name,
+		row.userID,
+		row.itemType,
+		row.name,
 		content,
-		info.Metadata,
+		row.metadata,
+		info.CollectionID,
 	)
 	return err
 }
 
 // ListByUser retrieves all item metadata for a specific user
 func (s *ItemStorage) ListByUser(ctx context.Context, uid models.UserID) ([]models.ItemInfo, error) {
-	rows, err := s.db.QueryContext(ctx, sqlGetUserItemsInfo, uid)
+	blindUID, err := s.blind(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, sqlGetUserItemsInfo, blindUID)
 	if err != nil {
 		return nil, err
 	}
@@ -45,16 +77,22 @@ func (s *ItemStorage) ListByUser(ctx context.Context, uid models.UserID) ([]mode
 	var items []models.ItemInfo
 	for rows.Next() {
 		var info models.ItemInfo
+		var row sealedRow
 		if err := rows.Scan(
 			&info.ID,
-			&info.UserID,
-			&info.ItemType,
-			&info.Name,
-			&info.Metadata,
+			&row.userID,
+			&row.itemType,
+			&row.name,
+			&row.metadata,
 			&info.UpdatedAt,
+			&info.CollectionID,
 		); err != nil {
 			return nil, err
 		}
+		if err := s.openRow(&row, &info.ItemType, &info.Name, &info.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to decrypt item %s: %w", info.ID, err)
+		}
+		info.UserID = uid
 		items = append(items, info)
 	}
 
@@ -76,14 +114,19 @@ func (s *ItemStorage) DeleteItem(ctx context.Context, id models.ItemID) error {
 
 // UpdateItem modifies an existing item's data and content
 func (s *ItemStorage) UpdateItem(ctx context.Context, info *models.ItemInfo, content []byte) error {
-	_, err := s.db.ExecContext(
+	row, err := s.sealRow(info.UserID, info.ItemType, info.Name, info.Metadata)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(
 		ctx,
 		sqlUpdateItem,
-		info.Name,
-		info.Metadata,
+		row.name,
+		row.metadata,
 		info.UpdatedAt,
 		content,
-		info.UserID,
+		row.userID,
 		info.ID,
 	)
 	return err
@@ -91,7 +134,34 @@ func (s *ItemStorage) UpdateItem(ctx context.Context, info *models.ItemInfo, con
 
 // GetAllUserItems retrieves all items (including content and deleted status) for a specific user
 func (s *ItemStorage) GetAllUserItems(ctx context.Context, uid models.UserID) ([]models.Item, error) {
-	rows, err := s.db.QueryContext(ctx, sqlGetAllUserItems, uid)
+	blindUID, err := s.blind(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.queryItems(ctx, blindUID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		row := sealedRow{
+			itemType: string(items[i].ItemType),
+			name:     items[i].Name,
+			metadata: items[i].Metadata,
+		}
+		if err := s.openRow(&row, &items[i].ItemType, &items[i].Name, &items[i].Metadata); err != nil {
+			return nil, fmt.Errorf("failed to decrypt item %s: %w", items[i].ID, err)
+		}
+		items[i].UserID = uid
+	}
+
+	return items, nil
+}
+
+// queryItems returns raw rows stored under the given user_id column value
+func (s *ItemStorage) queryItems(ctx context.Context, userID string) ([]models.Item, error) {
+	rows, err := s.db.QueryContext(ctx, sqlGetAllUserItems, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +179,7 @@ func (s *ItemStorage) GetAllUserItems(ctx context.Context, uid models.UserID) ([
 			&item.Data,
 			&item.UpdatedAt,
 			&item.IsDeleted,
+			&item.CollectionID,
 		); err != nil {
 			return nil, err
 		}
@@ -121,6 +192,19 @@ func (s *ItemStorage) GetAllUserItems(ctx context.Context, uid models.UserID) ([
 // ReplaceAllUserItems completely replaces all items for a user in a single transaction
 // First deletes all existing items, then inserts the new ones
 func (s *ItemStorage) ReplaceAllUserItems(ctx context.Context, uid models.UserID, items []models.Item) (err error) {
+	blindUID, err := s.blind(uid)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]sealedRow, len(items))
+	for i, item := range items {
+		rows[i], err = s.sealRow(uid, item.ItemType, item.Name, item.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt item %s: %w", item.ID, err)
+		}
+	}
+
 	tx, err := s.db.Begin()
 	if err != nil {
 		return err
@@ -131,7 +215,7 @@ func (s *ItemStorage) ReplaceAllUserItems(ctx context.Context, uid models.UserID
 		}
 	}()
 
-	if _, err := tx.Exec(sqlDeleteUserItems, uid); err != nil {
+	if _, err := tx.Exec(sqlDeleteUserItems, blindUID); err != nil {
 		tx.Rollback()
 		return fmt.Errorf("failed to clear items table: %w", err)
 	}
@@ -143,16 +227,17 @@ func (s *ItemStorage) ReplaceAllUserItems(ctx context.Context, uid models.UserID
 	}
 	defer stmt.Close()
 
-	for _, item := range items {
+	for i, item := range items {
 		_, err := stmt.Exec(
 			item.ID,
-			item.UserID,
-			item.ItemType,
-			item.Name,
-			item.Metadata,
+			rows[i].userID,
+			rows[i].itemType,
+			rows[i].name,
+			rows[i].metadata,
 			item.Data,
 			item.UpdatedAt,
 			item.IsDeleted,
+			item.CollectionID,
 		)
 		if err != nil {
 			tx.Rollback()
@@ -166,3 +251,159 @@ func (s *ItemStorage) ReplaceAllUserItems(ctx context.Context, uid models.UserID
 
 	return nil
 }
+
+// WipeUser removes all local rows of the user in a single transaction
+// Covers items, cached collections and credentials cached for offline unlock
+func (s *ItemStorage) WipeUser(ctx context.Context, uid models.UserID) (err error) {
+	blindUID, err := s.blind(uid)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			err = fmt.Errorf("%v; rollback failed: %w", err, rollbackErr)
+		}
+	}()
+
+	// Rows written before column encryption keep the plain user ID
+	if _, err := tx.ExecContext(ctx, sqlWipeUserItems, blindUID, uid); err != nil {
+		return fmt.Errorf("failed to wipe items: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, sqlDeleteUserCollections, blindUID); err != nil {
+		return fmt.Errorf("failed to wipe collections: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, sqlDeleteUserAccount, uid); err != nil {
+		return fmt.Errorf("failed to wipe cached account: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// migratePlaintext encrypts rows of the user stored by versions without column encryption
+func (s *ItemStorage) migratePlaintext(ctx context.Context, uid models.UserID) (err error) {
+	items, err := s.queryItems(ctx, string(uid))
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			err = fmt.Errorf("%v; rollback failed: %w", err, rollbackErr)
+		}
+	}()
+
+	for _, item := range items {
+		row, err := s.sealRow(uid, item.ItemType, item.Name, item.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt item %s: %w", item.ID, err)
+		}
+
+		_, err = tx.ExecContext(ctx, sqlEncryptItem, row.userID, row.itemType, row.name, row.metadata, item.ID)
+		if err != nil {
+			return fmt.Errorf("failed to migrate item %s: %w", item.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// sealedRow holds column values as stored in the database
+type sealedRow struct {
+	userID   string
+	itemType string
+	name     string
+	metadata string
+}
+
+// sealRow encrypts item columns and blinds owner ID
+func (s *ItemStorage) sealRow(uid models.UserID, itemType models.ItemType, name, metadata string) (sealedRow, error) {
+	var (
+		row sealedRow
+		err error
+	)
+
+	if row.userID, err = s.blind(uid); err != nil {
+		return sealedRow{}, err
+	}
+	if row.itemType, err = s.seal(string(itemType)); err != nil {
+		return sealedRow{}, err
+	}
+	if row.name, err = s.seal(name); err != nil {
+		return sealedRow{}, err
+	}
+	if row.metadata, err = s.seal(metadata); err != nil {
+		return sealedRow{}, err
+	}
+
+	return row, nil
+}
+
+// openRow decrypts item columns into destination fields
+func (s *ItemStorage) openRow(row *sealedRow, itemType *models.ItemType, name, metadata *string) error {
+	t, err := s.open(row.itemType)
+	if err != nil {
+		return err
+	}
+	*itemType = models.ItemType(t)
+
+	if *name, err = s.open(row.name); err != nil {
+		return err
+	}
+	if *metadata, err = s.open(row.metadata); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// seal encrypts column value and encodes it for a TEXT column
+func (s *ItemStorage) seal(value string) (string, error) {
+	crypted, err := s.crypt.Encrypt([]byte(value))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(crypted), nil
+}
+
+// open decodes and decrypts column value produced by seal
+func (s *ItemStorage) open(value string) (string, error) {
+	crypted, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", err
+	}
+
+	plain, err := s.crypt.Decrypt(crypted)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// blind returns blind index of user ID used in the user_id column
+func (s *ItemStorage) blind(uid models.UserID) (string, error) {
+	digest, err := s.crypt.Blind([]byte(uid))
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(digest), nil
+}
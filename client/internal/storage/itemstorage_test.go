@@ -1,8 +1,11 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"regexp"
 	"testing"
@@ -14,15 +17,46 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// testCrypter is a deterministic stand-in for the vault crypter
+type testCrypter struct{}
+
+const testSealPrefix = "enc:"
+
+func (testCrypter) Encrypt(data []byte) ([]byte, error) {
+	return append([]byte(testSealPrefix), data...), nil
+}
+
+func (testCrypter) Decrypt(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte(testSealPrefix)) {
+		return nil, errors.New("not sealed")
+	}
+	return data[len(testSealPrefix):], nil
+}
+
+func (testCrypter) Blind(data []byte) ([]byte, error) {
+	return append([]byte("blind:"), data...), nil
+}
+
+// sealed returns column value as written by ItemStorage with testCrypter
+func sealed(value string) string {
+	return base64.StdEncoding.EncodeToString([]byte(testSealPrefix + value))
+}
+
+// blinded returns user_id column value as written by ItemStorage with testCrypter
+func blinded(uid models.UserID) string {
+	return hex.EncodeToString([]byte("blind:" + uid))
+}
+
 func TestNewItemStorage(t *testing.T) {
 	t.Run("should create new item storage", func(t *testing.T) {
 		db, _, err := sqlmock.New()
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 		assert.NotNil(t, storage)
 		assert.Equal(t, db, storage.db)
+		assert.Equal(t, testCrypter{}, storage.crypt)
 	})
 }
 
@@ -44,16 +78,17 @@ func TestItemStorage_Add(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		mock.ExpectExec(expectedQuery).
 			WithArgs(
 				testInfo.ID,
-				testInfo.UserID,
-				testInfo.ItemType,
-				testInfo.Name,
+				blinded(testInfo.UserID),
+				sealed(string(testInfo.ItemType)),
+				sealed(testInfo.Name),
 				testContent,
-				testInfo.Metadata,
+				sealed(testInfo.Metadata),
+				testInfo.CollectionID,
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -67,17 +102,18 @@ func TestItemStorage_Add(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		expectedErr := errors.New("database error")
 		mock.ExpectExec(expectedQuery).
 			WithArgs(
 				testInfo.ID,
-				testInfo.UserID,
-				testInfo.ItemType,
-				testInfo.Name,
+				blinded(testInfo.UserID),
+				sealed(string(testInfo.ItemType)),
+				sealed(testInfo.Name),
 				testContent,
-				testInfo.Metadata,
+				sealed(testInfo.Metadata),
+				testInfo.CollectionID,
 			).
 			WillReturnError(expectedErr)
 
@@ -99,7 +135,7 @@ func TestItemStorage_ListByUser(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		expectedItems := []models.ItemInfo{
 			{
@@ -111,37 +147,40 @@ func TestItemStorage_ListByUser(t *testing.T) {
 				UpdatedAt: time.Now(),
 			},
 			{
-				ID:        "item2",
-				UserID:    userID,
-				ItemType:  models.TypeCard,
-				Name:      "item 2",
-				Metadata:  "metadata2",
-				UpdatedAt: time.Now(),
+				ID:           "item2",
+				UserID:       userID,
+				CollectionID: "col1",
+				ItemType:     models.TypeCard,
+				Name:         "item 2",
+				Metadata:     "metadata2",
+				UpdatedAt:    time.Now(),
 			},
 		}
 
 		rows := sqlmock.NewRows([]string{
-			"id", "user_id", "type", "name", "metadata", "updated_at",
+			"id", "user_id", "type", "name", "metadata", "updated_at", "collection_id",
 		}).
 			AddRow(
 				expectedItems[0].ID,
-				expectedItems[0].UserID,
-				expectedItems[0].ItemType,
-				expectedItems[0].Name,
-				expectedItems[0].Metadata,
+				blinded(expectedItems[0].UserID),
+				sealed(string(expectedItems[0].ItemType)),
+				sealed(expectedItems[0].Name),
+				sealed(expectedItems[0].Metadata),
 				expectedItems[0].UpdatedAt,
+				expectedItems[0].CollectionID,
 			).
 			AddRow(
 				expectedItems[1].ID,
-				expectedItems[1].UserID,
-				expectedItems[1].ItemType,
-				expectedItems[1].Name,
-				expectedItems[1].Metadata,
+				blinded(expectedItems[1].UserID),
+				sealed(string(expectedItems[1].ItemType)),
+				sealed(expectedItems[1].Name),
+				sealed(expectedItems[1].Metadata),
 				expectedItems[1].UpdatedAt,
+				expectedItems[1].CollectionID,
 			)
 
 		mock.ExpectQuery(expectedQuery).
-			WithArgs(userID).
+			WithArgs(blinded(userID)).
 			WillReturnRows(rows)
 
 		items, err := storage.ListByUser(ctx, userID)
@@ -155,11 +194,11 @@ func TestItemStorage_ListByUser(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		expectedErr := errors.New("database error")
 		mock.ExpectQuery(expectedQuery).
-			WithArgs(userID).
+			WithArgs(blinded(userID)).
 			WillReturnError(expectedErr)
 
 		_, err = storage.ListByUser(ctx, userID)
@@ -173,15 +212,15 @@ func TestItemStorage_ListByUser(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		rows := sqlmock.NewRows([]string{
-			"id", "user_id", "type", "name", "metadata", "updated_at",
+			"id", "user_id", "type", "name", "metadata", "updated_at", "collection_id",
 		}).
-			AddRow("item1", "user123", "invalid_type", "item 1", "{}", "{}")
+			AddRow("item1", "user123", "invalid_type", "item 1", "{}", "{}", "")
 
 		mock.ExpectQuery(expectedQuery).
-			WithArgs(userID).
+			WithArgs(blinded(userID)).
 			WillReturnRows(rows)
 
 		_, err = storage.ListByUser(ctx, userID)
@@ -201,7 +240,7 @@ func TestItemStorage_GetContent(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		expectedContent := []byte("encrypted content")
 		mock.ExpectQuery(expectedQuery).
@@ -219,7 +258,7 @@ func TestItemStorage_GetContent(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		expectedErr := errors.New("database error")
 		mock.ExpectQuery(expectedQuery).
@@ -237,7 +276,7 @@ func TestItemStorage_GetContent(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		mock.ExpectQuery(expectedQuery).
 			WithArgs(itemID).
@@ -261,7 +300,7 @@ func TestItemStorage_DeleteItem(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		mock.ExpectExec(expectedQuery).
 			WithArgs(itemID).
@@ -277,7 +316,7 @@ func TestItemStorage_DeleteItem(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		expectedErr := errors.New("database error")
 		mock.ExpectExec(expectedQuery).
@@ -309,15 +348,15 @@ func TestItemStorage_UpdateItem(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		mock.ExpectExec(expectedQuery).
 			WithArgs(
-				testInfo.Name,
-				testInfo.Metadata,
+				sealed(testInfo.Name),
+				sealed(testInfo.Metadata),
 				testInfo.UpdatedAt,
 				testContent,
-				testInfo.UserID,
+				blinded(testInfo.UserID),
 				testInfo.ID,
 			).
 			WillReturnResult(sqlmock.NewResult(0, 1))
@@ -332,16 +371,16 @@ func TestItemStorage_UpdateItem(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		expectedErr := errors.New("database error")
 		mock.ExpectExec(expectedQuery).
 			WithArgs(
-				testInfo.Name,
-				testInfo.Metadata,
+				sealed(testInfo.Name),
+				sealed(testInfo.Metadata),
 				testInfo.UpdatedAt,
 				testContent,
-				testInfo.UserID,
+				blinded(testInfo.UserID),
 				testInfo.ID,
 			).
 			WillReturnError(expectedErr)
@@ -364,7 +403,7 @@ func TestItemStorage_GetAllUserItems(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		expectedItems := []models.Item{
 			{
@@ -378,43 +417,46 @@ func TestItemStorage_GetAllUserItems(t *testing.T) {
 				IsDeleted: false,
 			},
 			{
-				ID:        "item2",
-				UserID:    userID,
-				ItemType:  models.TypeCard,
-				Name:      "item 2",
-				Metadata:  "metadata2",
-				Data:      []byte("data2"),
-				UpdatedAt: time.Now(),
-				IsDeleted: true,
+				ID:           "item2",
+				UserID:       userID,
+				CollectionID: "col1",
+				ItemType:     models.TypeCard,
+				Name:         "item 2",
+				Metadata:     "metadata2",
+				Data:         []byte("data2"),
+				UpdatedAt:    time.Now(),
+				IsDeleted:    true,
 			},
 		}
 
 		rows := sqlmock.NewRows([]string{
-			"id", "user_id", "type", "name", "metadata", "content", "updated_at", "is_deleted",
+			"id", "user_id", "type", "name", "metadata", "content", "updated_at", "is_deleted", "collection_id",
 		}).
 			AddRow(
 				expectedItems[0].ID,
-				expectedItems[0].UserID,
-				expectedItems[0].ItemType,
-				expectedItems[0].Name,
-				expectedItems[0].Metadata,
+				blinded(expectedItems[0].UserID),
+				sealed(string(expectedItems[0].ItemType)),
+				sealed(expectedItems[0].Name),
+				sealed(expectedItems[0].Metadata),
 				expectedItems[0].Data,
 				expectedItems[0].UpdatedAt,
 				expectedItems[0].IsDeleted,
+				expectedItems[0].CollectionID,
 			).
 			AddRow(
 				expectedItems[1].ID,
-				expectedItems[1].UserID,
-				expectedItems[1].ItemType,
-				expectedItems[1].Name,
-				expectedItems[1].Metadata,
+				blinded(expectedItems[1].UserID),
+				sealed(string(expectedItems[1].ItemType)),
+				sealed(expectedItems[1].Name),
+				sealed(expectedItems[1].Metadata),
 				expectedItems[1].Data,
 				expectedItems[1].UpdatedAt,
 				expectedItems[1].IsDeleted,
+				expectedItems[1].CollectionID,
 			)
 
 		mock.ExpectQuery(expectedQuery).
-			WithArgs(userID).
+			WithArgs(blinded(userID)).
 			WillReturnRows(rows)
 
 		items, err := storage.GetAllUserItems(ctx, userID)
@@ -428,11 +470,11 @@ func TestItemStorage_GetAllUserItems(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		expectedErr := errors.New("database error")
 		mock.ExpectQuery(expectedQuery).
-			WithArgs(userID).
+			WithArgs(blinded(userID)).
 			WillReturnError(expectedErr)
 
 		_, err = storage.GetAllUserItems(ctx, userID)
@@ -446,15 +488,15 @@ func TestItemStorage_GetAllUserItems(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		rows := sqlmock.NewRows([]string{
-			"id", "user_id", "type", "name", "metadata", "content", "updated_at", "is_deleted",
+			"id", "user_id", "type", "name", "metadata", "content", "updated_at", "is_deleted", "collection_id",
 		}).
-			AddRow("item1", "user123", "invalid_type", "item 1", "{}", []byte("data"), time.Now(), "invalid_bool")
+			AddRow("item1", "user123", "invalid_type", "item 1", "{}", []byte("data"), time.Now(), "invalid_bool", "")
 
 		mock.ExpectQuery(expectedQuery).
-			WithArgs(userID).
+			WithArgs(blinded(userID)).
 			WillReturnRows(rows)
 
 		_, err = storage.GetAllUserItems(ctx, userID)
@@ -479,14 +521,15 @@ func TestItemStorage_ReplaceAllUserItems(t *testing.T) {
 			IsDeleted: false,
 		},
 		{
-			ID:        "item2",
-			UserID:    userID,
-			ItemType:  models.TypeCard,
-			Name:      "item 2",
-			Metadata:  "metadata2",
-			Data:      []byte("data2"),
-			UpdatedAt: time.Now(),
-			IsDeleted: true,
+			ID:           "item2",
+			UserID:       userID,
+			CollectionID: "col1",
+			ItemType:     models.TypeCard,
+			Name:         "item 2",
+			Metadata:     "metadata2",
+			Data:         []byte("data2"),
+			UpdatedAt:    time.Now(),
+			IsDeleted:    true,
 		},
 	}
 
@@ -495,14 +538,14 @@ func TestItemStorage_ReplaceAllUserItems(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		// Begin transaction
 		mock.ExpectBegin()
 
 		// Delete all items
 		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteUserItems)).
-			WithArgs(userID).
+			WithArgs(blinded(userID)).
 			WillReturnResult(sqlmock.NewResult(0, 2))
 
 		// Prepare statement
@@ -513,13 +556,14 @@ func TestItemStorage_ReplaceAllUserItems(t *testing.T) {
 			mock.ExpectExec(regexp.QuoteMeta(sqlAddUserItems)).
 				WithArgs(
 					item.ID,
-					item.UserID,
-					item.ItemType,
-					item.Name,
-					item.Metadata,
+					blinded(item.UserID),
+					sealed(string(item.ItemType)),
+					sealed(item.Name),
+					sealed(item.Metadata),
 					item.Data,
 					item.UpdatedAt,
 					item.IsDeleted,
+					item.CollectionID,
 				).
 				WillReturnResult(sqlmock.NewResult(1, 1))
 		}
@@ -537,7 +581,7 @@ func TestItemStorage_ReplaceAllUserItems(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		expectedErr := errors.New("begin error")
 		mock.ExpectBegin().WillReturnError(expectedErr)
@@ -553,7 +597,7 @@ func TestItemStorage_ReplaceAllUserItems(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		// Begin transaction
 		mock.ExpectBegin()
@@ -561,7 +605,7 @@ func TestItemStorage_ReplaceAllUserItems(t *testing.T) {
 		// Delete all items error
 		expectedErr := errors.New("delete error")
 		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteUserItems)).
-			WithArgs(userID).
+			WithArgs(blinded(userID)).
 			WillReturnError(expectedErr)
 
 		err = storage.ReplaceAllUserItems(ctx, userID, items)
@@ -575,14 +619,14 @@ func TestItemStorage_ReplaceAllUserItems(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		// Begin transaction
 		mock.ExpectBegin()
 
 		// Delete all items
 		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteUserItems)).
-			WithArgs(userID).
+			WithArgs(blinded(userID)).
 			WillReturnResult(sqlmock.NewResult(0, 2))
 
 		// Prepare statement error
@@ -601,14 +645,14 @@ func TestItemStorage_ReplaceAllUserItems(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		// Begin transaction
 		mock.ExpectBegin()
 
 		// Delete all items
 		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteUserItems)).
-			WithArgs(userID).
+			WithArgs(blinded(userID)).
 			WillReturnResult(sqlmock.NewResult(0, 2))
 
 		// Prepare statement
@@ -618,13 +662,14 @@ func TestItemStorage_ReplaceAllUserItems(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(sqlAddUserItems)).
 			WithArgs(
 				items[0].ID,
-				items[0].UserID,
-				items[0].ItemType,
-				items[0].Name,
-				items[0].Metadata,
+				blinded(items[0].UserID),
+				sealed(string(items[0].ItemType)),
+				sealed(items[0].Name),
+				sealed(items[0].Metadata),
 				items[0].Data,
 				items[0].UpdatedAt,
 				items[0].IsDeleted,
+				items[0].CollectionID,
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -633,13 +678,14 @@ func TestItemStorage_ReplaceAllUserItems(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(sqlAddUserItems)).
 			WithArgs(
 				items[1].ID,
-				items[1].UserID,
-				items[1].ItemType,
-				items[1].Name,
-				items[1].Metadata,
+				blinded(items[1].UserID),
+				sealed(string(items[1].ItemType)),
+				sealed(items[1].Name),
+				sealed(items[1].Metadata),
 				items[1].Data,
 				items[1].UpdatedAt,
 				items[1].IsDeleted,
+				items[1].CollectionID,
 			).
 			WillReturnError(expectedErr)
 
@@ -654,14 +700,14 @@ func TestItemStorage_ReplaceAllUserItems(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, testCrypter{})
 
 		// Begin transaction
 		mock.ExpectBegin()
 
 		// Delete all items
 		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteUserItems)).
-			WithArgs(userID).
+			WithArgs(blinded(userID)).
 			WillReturnResult(sqlmock.NewResult(0, 2))
 
 		// Prepare statement
@@ -672,13 +718,14 @@ func TestItemStorage_ReplaceAllUserItems(t *testing.T) {
 			mock.ExpectExec(regexp.QuoteMeta(sqlAddUserItems)).
 				WithArgs(
 					item.ID,
-					item.UserID,
-					item.ItemType,
-					item.Name,
-					item.Metadata,
+					blinded(item.UserID),
+					sealed(string(item.ItemType)),
+					sealed(item.Name),
+					sealed(item.Metadata),
 					item.Data,
 					item.UpdatedAt,
 					item.IsDeleted,
+					item.CollectionID,
 				).
 				WillReturnResult(sqlmock.NewResult(1, 1))
 		}
@@ -693,3 +740,141 @@ func TestItemStorage_ReplaceAllUserItems(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestItemStorage_Open(t *testing.T) {
+	ctx := context.Background()
+	userID := models.UserID("user123")
+	updatedAt := time.Now()
+
+	plainRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{
+			"id", "user_id", "type", "name", "metadata", "content", "updated_at", "is_deleted", "collection_id",
+		}).
+			AddRow("item1", userID, models.TypePassword, "item 1", "metadata1", []byte("data1"), updatedAt, false, "")
+	}
+
+	t.Run("should encrypt plaintext rows of the user", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		storage := NewItemStorage(db, testCrypter{})
+
+		mock.ExpectExec(regexp.QuoteMeta(sqlCreateItemsTable)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(sqlCreateAccountsTable)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		expectCollectionsSchema(mock)
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetAllUserItems)).
+			WithArgs(userID).
+			WillReturnRows(plainRows())
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlEncryptItem)).
+			WithArgs(
+				blinded(userID),
+				sealed(string(models.TypePassword)),
+				sealed("item 1"),
+				sealed("metadata1"),
+				"item1",
+			).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err = storage.Open(ctx, userID)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should skip migration without plaintext rows", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		storage := NewItemStorage(db, testCrypter{})
+
+		mock.ExpectExec(regexp.QuoteMeta(sqlCreateItemsTable)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(sqlCreateAccountsTable)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		expectCollectionsSchema(mock)
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetAllUserItems)).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "user_id", "type", "name", "metadata", "content", "updated_at", "is_deleted", "collection_id",
+			}))
+
+		err = storage.Open(ctx, userID)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should rollback on update error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		storage := NewItemStorage(db, testCrypter{})
+
+		expectedErr := errors.New("update error")
+		mock.ExpectExec(regexp.QuoteMeta(sqlCreateItemsTable)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(sqlCreateAccountsTable)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		expectCollectionsSchema(mock)
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetAllUserItems)).
+			WithArgs(userID).
+			WillReturnRows(plainRows())
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlEncryptItem)).
+			WillReturnError(expectedErr)
+		mock.ExpectRollback()
+
+		err = storage.Open(ctx, userID)
+		assert.ErrorIs(t, err, expectedErr)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should return schema error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		storage := NewItemStorage(db, testCrypter{})
+
+		expectedErr := errors.New("table creation failed")
+		mock.ExpectExec(regexp.QuoteMeta(sqlCreateItemsTable)).
+			WillReturnError(expectedErr)
+
+		err = storage.Open(ctx, userID)
+		assert.Equal(t, expectedErr, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestItemStorage_Sealing(t *testing.T) {
+	t.Run("should not store plaintext columns", func(t *testing.T) {
+		storage := NewItemStorage(nil, testCrypter{})
+
+		row, err := storage.sealRow("user123", models.TypeCard, "my card", "bank")
+		require.NoError(t, err)
+		assert.NotContains(t, row.userID, "user123")
+		assert.NotContains(t, row.name, "my card")
+		assert.NotContains(t, row.metadata, "bank")
+
+		var (
+			itemType       models.ItemType
+			name, metadata string
+		)
+		require.NoError(t, storage.openRow(&row, &itemType, &name, &metadata))
+		assert.Equal(t, models.TypeCard, itemType)
+		assert.Equal(t, "my card", name)
+		assert.Equal(t, "bank", metadata)
+	})
+
+	t.Run("should fail on plaintext column", func(t *testing.T) {
+		storage := NewItemStorage(nil, testCrypter{})
+
+		_, err := storage.open("plain name")
+		assert.Error(t, err)
+	})
+}
@@ -9,13 +9,48 @@ const sqlCreateItemsTable = `
 		encrypt_content BLOB NOT NULL,
 		metadata TEXT,
 		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		is_deleted BOOLEAN DEFAULT FALSE
+		is_deleted BOOLEAN DEFAULT FALSE,
+		collection_id TEXT NOT NULL DEFAULT ''
 	)
 `
+
+const sqlHasCollectionColumn = `
+	SELECT COUNT(*) 
+	FROM pragma_table_info('items') 
+	WHERE name = 'collection_id'
+`
+
+const sqlAddCollectionColumn = `
+	ALTER TABLE items 
+	ADD COLUMN collection_id TEXT NOT NULL DEFAULT ''
+`
+
+const sqlCreateCollectionsTable = `
+	CREATE TABLE IF NOT EXISTS collections (
+		id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		org_id TEXT NOT NULL,
+		org_name TEXT NOT NULL,
+		name TEXT NOT NULL,
+		role TEXT NOT NULL,
+		PRIMARY KEY (id, user_id)
+	)
+`
+const sqlCreateAccountsTable = `
+	CREATE TABLE IF NOT EXISTS accounts (
+		username_hash TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		salt TEXT NOT NULL,
+		kdf_iterations INTEGER NOT NULL,
+		encrypted_key TEXT NOT NULL,
+		key_check TEXT NOT NULL
+	)
+`
+
 const sqlAddItem = `
 	INSERT INTO items
-	(id, user_id, type, name, encrypt_content, metadata, is_deleted) 
-	VALUES ($1, $2, $3, $4, $5, $6, false)
+	(id, user_id, type, name, encrypt_content, metadata, is_deleted, collection_id) 
+	VALUES ($1, $2, $3, $4, $5, $6, false, $7)
 `
 
 const sqlGetItemByID = `
@@ -32,7 +67,8 @@ const sqlGetUserItemsInfo = `
 		type,
 		name, 
 		metadata,
-		updated_at 
+		updated_at,
+		collection_id 
 	FROM items
 	WHERE user_id = $1 AND is_deleted = FALSE
 `
@@ -61,7 +97,8 @@ const sqlGetAllUserItems = `
 		metadata,
 		encrypt_content,
 		updated_at, 
-		is_deleted 
+		is_deleted,
+		collection_id 
 	FROM items
 	WHERE user_id = $1
 `
@@ -80,6 +117,70 @@ const sqlAddUserItems = `
 		metadata, 
 		encrypt_content, 
 		updated_at, 
-		is_deleted
-	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		is_deleted,
+		collection_id
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+`
+
+const sqlEncryptItem = `
+	UPDATE items
+	SET user_id = $1,
+		type = $2,
+		name = $3,
+		metadata = $4
+	WHERE id = $5
+`
+
+const sqlDeleteUserCollections = `
+	DELETE FROM collections
+	WHERE user_id = $1
+`
+
+const sqlAddUserCollection = `
+	INSERT INTO collections (id, user_id, org_id, org_name, name, role)
+	VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+const sqlGetUserCollections = `
+	SELECT 
+		id,
+		org_id,
+		org_name,
+		name,
+		role
+	FROM collections
+	WHERE user_id = $1
+`
+
+const sqlSaveAccount = `
+	INSERT INTO accounts
+	(username_hash, user_id, salt, kdf_iterations, encrypted_key, key_check)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (username_hash) DO UPDATE SET
+		user_id = excluded.user_id,
+		salt = excluded.salt,
+		kdf_iterations = excluded.kdf_iterations,
+		encrypted_key = excluded.encrypted_key,
+		key_check = excluded.key_check
+`
+
+const sqlGetAccount = `
+	SELECT
+		user_id,
+		salt,
+		kdf_iterations,
+		encrypted_key,
+		key_check
+	FROM accounts
+	WHERE username_hash = $1
+`
+
+const sqlWipeUserItems = `
+	DELETE FROM items
+	WHERE user_id IN ($1, $2)
+`
+
+const sqlDeleteUserAccount = `
+	DELETE FROM accounts
+	WHERE user_id = $1
 `
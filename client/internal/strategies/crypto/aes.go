@@ -1,11 +1,14 @@
 // Package crypto provides AES-GCM encryption/decryption functionality.
-// Implements secure symmetric encryption for sensitive data storage.
+// Implements secure symmetric encryption for sensitive data storage
+// and X25519 sealed boxes for sharing data between accounts.
 package crypto
 
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
 	"errors"
 	"io"
 )
@@ -17,6 +20,9 @@ var (
 	errNoKey        = errors.New("no key")                              // Key not initialized
 )
 
+// blindLabel separates blind index key from the encryption key
+const blindLabel = "gophkeeper-blind-index"
+
 // AESCrypter implements AES-GCM encryption/decryption
 // Uses 256-bit keys and provides authenticated encryption
 type AESCrypter struct {
@@ -38,6 +44,15 @@ func (c *AESCrypter) SetKey(key []byte) error {
 	return nil
 }
 
+// Key returns a copy of the configured key
+// Used to wrap the vault key for trusted contacts
+func (c *AESCrypter) Key() ([]byte, error) {
+	if len(c.key) == 0 {
+		return nil, errNoKey
+	}
+	return append([]byte(nil), c.key...), nil
+}
+
 // Encrypt encrypts data using AES-GCM
 func (c *AESCrypter) Encrypt(content []byte) ([]byte, error) {
 	if len(c.key) == 0 {
@@ -86,3 +101,27 @@ func (c *AESCrypter) Decrypt(crypted []byte) ([]byte, error) {
 	nonce, ciphertext := crypted[:nonceSize], crypted[nonceSize:]
 	return gcm.Open(nil, nonce, ciphertext, nil)
 }
+
+// Blind returns deterministic keyed digest of data
+// Allows lookups by value without storing it in plaintext
+func (c *AESCrypter) Blind(data []byte) ([]byte, error) {
+	if len(c.key) == 0 {
+		return nil, errNoKey
+	}
+
+	sub := hmac.New(sha256.New, c.key)
+	sub.Write([]byte(blindLabel))
+
+	mac := hmac.New(sha256.New, sub.Sum(nil))
+	mac.Write(data)
+	return mac.Sum(nil), nil
+}
+
+// Wipe zeroes the key in memory and forgets it
+// Encrypt and Decrypt fail until a new key is set
+func (c *AESCrypter) Wipe() {
+	for i := range c.key {
+		c.key[i] = 0
+	}
+	c.key = nil
+}
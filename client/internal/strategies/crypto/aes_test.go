@@ -45,6 +45,27 @@ func TestAESCrypter_SetKey(t *testing.T) {
 	})
 }
 
+func TestAESCrypter_Key(t *testing.T) {
+	t.Run("should return copy of the key", func(t *testing.T) {
+		c := NewAESCrypter()
+		key := make([]byte, 32)
+		require.NoError(t, c.SetKey(key))
+
+		got, err := c.Key()
+		require.NoError(t, err)
+		assert.Equal(t, key, got)
+
+		got[0] = 1
+		assert.Equal(t, byte(0), c.key[0])
+	})
+
+	t.Run("should fail without key", func(t *testing.T) {
+		c := NewAESCrypter()
+		_, err := c.Key()
+		assert.Equal(t, errNoKey, err)
+	})
+}
+
 func TestAESCrypter_Encrypt(t *testing.T) {
 	t.Run("should encrypt data with valid key", func(t *testing.T) {
 		c := NewAESCrypter()
@@ -188,3 +209,57 @@ func TestAESCrypter_EncryptDecrypt(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestAESCrypter_Blind(t *testing.T) {
+	t.Run("should return deterministic digest for the same key", func(t *testing.T) {
+		c := NewAESCrypter()
+		require.NoError(t, c.SetKey(make([]byte, 32)))
+
+		first, err := c.Blind([]byte("user123"))
+		require.NoError(t, err)
+		second, err := c.Blind([]byte("user123"))
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		assert.NotContains(t, string(first), "user123")
+	})
+
+	t.Run("should depend on key", func(t *testing.T) {
+		c := NewAESCrypter()
+		require.NoError(t, c.SetKey(make([]byte, 32)))
+		first, err := c.Blind([]byte("user123"))
+		require.NoError(t, err)
+
+		key := make([]byte, 32)
+		key[0] = 1
+		require.NoError(t, c.SetKey(key))
+		second, err := c.Blind([]byte("user123"))
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("should fail without key", func(t *testing.T) {
+		c := NewAESCrypter()
+		_, err := c.Blind([]byte("user123"))
+		assert.Equal(t, errNoKey, err)
+	})
+}
+
+func TestAESCrypter_Wipe(t *testing.T) {
+	t.Run("should zero key and forget it", func(t *testing.T) {
+		c := NewAESCrypter()
+		key := make([]byte, 32)
+		_, err := rand.Read(key)
+		require.NoError(t, err)
+		require.NoError(t, c.SetKey(key))
+
+		c.Wipe()
+
+		assert.Nil(t, c.key)
+		assert.Equal(t, make([]byte, 32), key)
+
+		_, err = c.Encrypt([]byte("data"))
+		assert.Equal(t, errNoKey, err)
+	})
+}
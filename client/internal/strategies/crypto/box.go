@@ -0,0 +1,108 @@
+package crypto
+
+import (
+	"crypto/ecdh"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+)
+
+// sealLabel separates sealed box keys from other derived keys
+const sealLabel = "gophkeeper-sealed-box"
+
+// x25519KeySize is the length of X25519 public and private keys
+const x25519KeySize = 32
+
+var errShortSealed = errors.New("sealed content too short")
+
+// Box implements anonymous public key encryption over X25519
+// Every message is sealed with a fresh ephemeral key, so the sender stays unknown
+type Box struct{}
+
+// NewBox creates a new sealed box instance
+func NewBox() *Box {
+	return &Box{}
+}
+
+// GenerateKeyPair creates a new X25519 public and private key
+func (b *Box) GenerateKeyPair() ([]byte, []byte, error) {
+	priv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return priv.PublicKey().Bytes(), priv.Bytes(), nil
+}
+
+// Seal encrypts data so that only owner of the private key can open it
+// Output is ephemeral public key followed by AES-GCM ciphertext
+func (b *Box) Seal(publicKey, data []byte) ([]byte, error) {
+	recipient, err := ecdh.X25519().NewPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	eph, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := eph.ECDH(recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	ephPub := eph.PublicKey().Bytes()
+
+	c := NewAESCrypter()
+	if err := c.SetKey(sealKey(shared, ephPub, publicKey)); err != nil {
+		return nil, err
+	}
+
+	crypted, err := c.Encrypt(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(ephPub, crypted...), nil
+}
+
+// Open decrypts data sealed to the public key of privateKey
+func (b *Box) Open(privateKey, sealed []byte) ([]byte, error) {
+	if len(sealed) < x25519KeySize {
+		return nil, errShortSealed
+	}
+
+	priv, err := ecdh.X25519().NewPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ephPub, crypted := sealed[:x25519KeySize], sealed[x25519KeySize:]
+
+	eph, err := ecdh.X25519().NewPublicKey(ephPub)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := priv.ECDH(eph)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewAESCrypter()
+	if err := c.SetKey(sealKey(shared, ephPub, priv.PublicKey().Bytes())); err != nil {
+		return nil, err
+	}
+
+	return c.Decrypt(crypted)
+}
+
+// sealKey binds shared secret to both public keys of the exchange
+func sealKey(shared, ephPub, recipientPub []byte) []byte {
+	mac := hmac.New(sha256.New, shared)
+	mac.Write([]byte(sealLabel))
+	mac.Write(ephPub)
+	mac.Write(recipientPub)
+	return mac.Sum(nil)
+}
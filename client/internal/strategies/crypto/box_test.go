@@ -0,0 +1,77 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBox_GenerateKeyPair(t *testing.T) {
+	t.Run("should generate distinct 32-byte keys", func(t *testing.T) {
+		b := NewBox()
+
+		pub, priv, err := b.GenerateKeyPair()
+		require.NoError(t, err)
+		assert.Len(t, pub, x25519KeySize)
+		assert.Len(t, priv, x25519KeySize)
+		assert.NotEqual(t, pub, priv)
+	})
+}
+
+func TestBox_SealOpen(t *testing.T) {
+	b := NewBox()
+	data := []byte("item key")
+
+	pub, priv, err := b.GenerateKeyPair()
+	require.NoError(t, err)
+
+	t.Run("should open sealed data with matching private key", func(t *testing.T) {
+		sealed, err := b.Seal(pub, data)
+		require.NoError(t, err)
+		assert.NotContains(t, string(sealed), string(data))
+
+		opened, err := b.Open(priv, sealed)
+		require.NoError(t, err)
+		assert.Equal(t, data, opened)
+	})
+
+	t.Run("should produce different output for same data", func(t *testing.T) {
+		first, err := b.Seal(pub, data)
+		require.NoError(t, err)
+		second, err := b.Seal(pub, data)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("should fail with another private key", func(t *testing.T) {
+		_, otherPriv, err := b.GenerateKeyPair()
+		require.NoError(t, err)
+
+		sealed, err := b.Seal(pub, data)
+		require.NoError(t, err)
+
+		_, err = b.Open(otherPriv, sealed)
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail on tampered data", func(t *testing.T) {
+		sealed, err := b.Seal(pub, data)
+		require.NoError(t, err)
+		sealed[len(sealed)-1] ^= 0xff
+
+		_, err = b.Open(priv, sealed)
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail on short data", func(t *testing.T) {
+		_, err := b.Open(priv, []byte("short"))
+		assert.ErrorIs(t, err, errShortSealed)
+	})
+
+	t.Run("should reject invalid public key", func(t *testing.T) {
+		_, err := b.Seal([]byte("short"), data)
+		assert.Error(t, err)
+	})
+}
@@ -3,54 +3,109 @@
 package tui
 
 import (
+	"time"
+
+	"github.com/rycln/gokeep/client/internal/tui/screens/account"
 	"github.com/rycln/gokeep/client/internal/tui/screens/add"
 	"github.com/rycln/gokeep/client/internal/tui/screens/auth"
+	"github.com/rycln/gokeep/client/internal/tui/screens/emergency"
+	"github.com/rycln/gokeep/client/internal/tui/screens/lock"
 	"github.com/rycln/gokeep/client/internal/tui/screens/update"
 	"github.com/rycln/gokeep/client/internal/tui/screens/vault"
+	"github.com/rycln/gokeep/shared/models"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// idleCheckInterval defines how often inactivity is checked
+const idleCheckInterval = time.Second
+
+// IdleTickMsg triggers periodic inactivity check
+type IdleTickMsg struct{ Time time.Time }
+
 // model represents current active screen
 type model int
 
 // Screen type constants
 const (
-	AuthModel   model = iota // Authentication screen
-	VaultModel               // Main vault screen
-	AddModel                 // Add item screen
-	UpdateModel              // Update item screen
+	AuthModel      model = iota // Authentication screen
+	VaultModel                  // Main vault screen
+	AddModel                    // Add item screen
+	UpdateModel                 // Update item screen
+	LockModel                   // Vault lock screen
+	EmergencyModel              // Emergency access screen
+	AccountModel                // Account management screen
 )
 
 // rootModel manages all application screens and transitions
 type rootModel struct {
-	authModel   auth.Model   // Authentication screen model
-	vaultModel  vault.Model  // Main vault screen model
-	addModel    add.Model    // Add item screen model
-	updateModel update.Model // Update item screen model
-	current     model        // Currently active screen
+	authModel      auth.Model      // Authentication screen model
+	vaultModel     vault.Model     // Main vault screen model
+	addModel       add.Model       // Add item screen model
+	updateModel    update.Model    // Update item screen model
+	lockModel      lock.Model      // Vault lock screen model
+	emergencyModel emergency.Model // Emergency access screen model
+	accountModel   account.Model   // Account management screen model
+	current        model           // Currently active screen
+
+	user         *models.User  // Authenticated user
+	idleTimeout  time.Duration // Inactivity period before lock, zero disables locking
+	lastActivity time.Time     // Time of the last user input
 }
 
 // InitialRootModel creates root model with all screen dependencies
-func InitialRootModel(auth auth.Model, vault vault.Model, add add.Model, update update.Model) rootModel {
+func InitialRootModel(
+	auth auth.Model,
+	vault vault.Model,
+	add add.Model,
+	update update.Model,
+	lock lock.Model,
+	emergency emergency.Model,
+	account account.Model,
+	idleTimeout time.Duration,
+) rootModel {
 	return rootModel{
-		authModel:   auth,
-		vaultModel:  vault,
-		addModel:    add,
-		updateModel: update,
-		current:     AuthModel,
+		authModel:      auth,
+		vaultModel:     vault,
+		addModel:       add,
+		updateModel:    update,
+		lockModel:      lock,
+		emergencyModel: emergency,
+		accountModel:   account,
+		current:        AuthModel,
+		idleTimeout:    idleTimeout,
 	}
 }
 
-// Init initializes the root model
+// Init initializes the root model and starts inactivity checks
 func (m rootModel) Init() tea.Cmd {
-	return nil
+	if m.idleTimeout <= 0 {
+		return nil
+	}
+	return idleTick()
+}
+
+// idleTick schedules next inactivity check
+func idleTick() tea.Cmd {
+	return tea.Tick(idleCheckInterval, func(t time.Time) tea.Msg {
+		return IdleTickMsg{Time: t}
+	})
 }
 
 // Update handles messages and screen transitions
 func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
-	case add.CancelMsg, update.CancelMsg:
+	case IdleTickMsg:
+		if m.unlocked() && msg.Time.Sub(m.lastActivity) >= m.idleTimeout {
+			m.lock()
+		}
+		return m, idleTick()
+	case tea.KeyMsg, tea.MouseMsg:
+		m.lastActivity = time.Now()
+	}
+
+	switch msg := msg.(type) {
+	case add.CancelMsg, update.CancelMsg, emergency.CancelMsg, account.CancelMsg:
 		m.vaultModel.SetUpdateState()
 		m.current = VaultModel
 		return m, nil
@@ -64,16 +119,43 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return handleAddModel(m, msg)
 		case UpdateModel:
 			return handleUpdateModel(m, msg)
+		case LockModel:
+			return handleLockModel(m, msg)
+		case EmergencyModel:
+			return handleEmergencyModel(m, msg)
+		case AccountModel:
+			return handleAccountModel(m, msg)
 		default:
 			return m, nil
 		}
 	}
 }
 
+// unlocked reports whether a screen with decrypted data is active
+func (m rootModel) unlocked() bool {
+	return m.current == VaultModel || m.current == AddModel || m.current == UpdateModel ||
+		m.current == EmergencyModel || m.current == AccountModel
+}
+
+// lock wipes the vault key and decrypted data and shows lock screen
+func (m *rootModel) lock() {
+	m.lockModel.Lock(m.user)
+	m.authModel.Clear()
+	m.vaultModel.Clear()
+	m.addModel.Clear()
+	m.updateModel.Clear()
+	m.emergencyModel.Clear()
+	m.accountModel.Clear()
+	m.current = LockModel
+}
+
 // handleAuthModel processes auth screen
 func handleAuthModel(m rootModel, msg tea.Msg) (rootModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case auth.AuthSuccessMsg:
+		m.authModel.Clear()
+		m.user = msg.User
+		m.lastActivity = time.Now()
 		m.vaultModel.SetUser(msg.User)
 		m.current = VaultModel
 		return m, nil
@@ -97,6 +179,18 @@ func handleVaultModel(m rootModel, msg tea.Msg) (rootModel, tea.Cmd) {
 		m.updateModel.SetItem(msg.Info, msg.Content)
 		m.current = UpdateModel // Switch to update screen
 		return m, nil
+	case vault.ReauthReqMsg:
+		m.authModel.Reauth()
+		m.current = AuthModel // Log in online before sync
+		return m, nil
+	case vault.EmergencyReqMsg:
+		m.emergencyModel.SetUser(msg.User)
+		m.current = EmergencyModel // Switch to emergency access screen
+		return m, nil
+	case vault.AccountReqMsg:
+		m.accountModel.SetUser(msg.User)
+		m.current = AccountModel // Switch to account screen
+		return m, nil
 	default:
 		updated, cmd := m.vaultModel.Update(msg)
 		if vaultModel, ok := updated.(vault.Model); ok {
@@ -138,6 +232,61 @@ func handleUpdateModel(m rootModel, msg tea.Msg) (rootModel, tea.Cmd) {
 	}
 }
 
+// handleLockModel processes lock screen
+func handleLockModel(m rootModel, msg tea.Msg) (rootModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case lock.UnlockSuccessMsg:
+		m.lastActivity = time.Now()
+		m.vaultModel.SetUpdateState()
+		m.current = VaultModel
+		return m, nil
+	default:
+		updated, cmd := m.lockModel.Update(msg)
+		if lockModel, ok := updated.(lock.Model); ok {
+			m.lockModel = lockModel
+		}
+		return m, cmd
+	}
+}
+
+// handleEmergencyModel processes emergency access screen
+func handleEmergencyModel(m rootModel, msg tea.Msg) (rootModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case emergency.VaultMsg:
+		cmd := m.vaultModel.ShowShared(msg.Owner, msg.Items)
+		m.current = VaultModel // Show released vault read-only
+		return m, cmd
+	default:
+		updated, cmd := m.emergencyModel.Update(msg)
+		if emergencyModel, ok := updated.(emergency.Model); ok {
+			m.emergencyModel = emergencyModel
+		}
+		return m, cmd
+	}
+}
+
+// handleAccountModel processes account management screen
+func handleAccountModel(m rootModel, msg tea.Msg) (rootModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case account.DeletedMsg:
+		m.user = nil
+		m.vaultModel.Clear()
+		m.addModel.Clear()
+		m.updateModel.Clear()
+		m.emergencyModel.Clear()
+		m.accountModel.Clear()
+		m.authModel.Reset()
+		m.current = AuthModel // Account is gone, start over
+		return m, nil
+	default:
+		updated, cmd := m.accountModel.Update(msg)
+		if accountModel, ok := updated.(account.Model); ok {
+			m.accountModel = accountModel
+		}
+		return m, cmd
+	}
+}
+
 // View renders current active screen
 func (m rootModel) View() string {
 	switch m.current {
@@ -149,6 +298,12 @@ func (m rootModel) View() string {
 		return m.addModel.View()
 	case UpdateModel:
 		return m.updateModel.View()
+	case LockModel:
+		return m.lockModel.View()
+	case EmergencyModel:
+		return m.emergencyModel.View()
+	case AccountModel:
+		return m.accountModel.View()
 	default:
 		return ""
 	}
@@ -1,11 +1,18 @@
 package tui
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/client/internal/tui/screens/account"
 	"github.com/rycln/gokeep/client/internal/tui/screens/add"
 	"github.com/rycln/gokeep/client/internal/tui/screens/auth"
+	"github.com/rycln/gokeep/client/internal/tui/screens/emergency"
+	"github.com/rycln/gokeep/client/internal/tui/screens/lock"
+	"github.com/rycln/gokeep/client/internal/tui/screens/lock/mocks"
 	"github.com/rycln/gokeep/client/internal/tui/screens/update"
 	"github.com/rycln/gokeep/client/internal/tui/screens/vault"
 	"github.com/rycln/gokeep/shared/models"
@@ -20,13 +27,14 @@ func TestInitialRootModel(t *testing.T) {
 		addModel := add.Model{}
 		updateModel := update.Model{}
 
-		model := InitialRootModel(authModel, vaultModel, addModel, updateModel)
+		model := InitialRootModel(authModel, vaultModel, addModel, updateModel, lock.Model{}, emergency.Model{}, account.Model{}, 0)
 
 		assert.Equal(t, AuthModel, model.current)
 		assert.Equal(t, authModel, model.authModel)
 		assert.Equal(t, vaultModel, model.vaultModel)
 		assert.Equal(t, addModel, model.addModel)
 		assert.Equal(t, updateModel, model.updateModel)
+		assert.Equal(t, lock.Model{}, model.lockModel)
 	})
 }
 
@@ -35,7 +43,7 @@ func TestRootModel_Update(t *testing.T) {
 		user := &models.User{ID: "user123"}
 		authModel := auth.Model{}
 		vaultModel := vault.Model{}
-		model := InitialRootModel(authModel, vaultModel, add.Model{}, update.Model{})
+		model := InitialRootModel(authModel, vaultModel, add.Model{}, update.Model{}, lock.Model{}, emergency.Model{}, account.Model{}, 0)
 
 		updated, cmd := model.Update(auth.AuthSuccessMsg{User: user})
 		require.Nil(t, cmd)
@@ -45,10 +53,48 @@ func TestRootModel_Update(t *testing.T) {
 		assert.Equal(t, VaultModel, rootModel.current)
 	})
 
+	t.Run("should show emergency screen and released vault", func(t *testing.T) {
+		user := &models.User{ID: "user123"}
+		vaultModel := vault.InitialModel(nil, nil, nil, nil, time.Second)
+		model := InitialRootModel(auth.Model{}, vaultModel, add.Model{}, update.Model{}, lock.Model{}, emergency.Model{}, account.Model{}, 0)
+		model.current = VaultModel
+
+		updated, cmd := model.Update(vault.EmergencyReqMsg{User: user})
+		require.Nil(t, cmd)
+		model = updated.(rootModel)
+		assert.Equal(t, EmergencyModel, model.current)
+
+		updated, _ = model.Update(emergency.VaultMsg{
+			Owner: "alice",
+			Items: []models.SharedItem{{ID: "item1", Owner: "alice", Name: "bank"}},
+		})
+		model = updated.(rootModel)
+		assert.Equal(t, VaultModel, model.current)
+		assert.Contains(t, model.View(), "alice")
+	})
+
+	t.Run("should return to login after account deletion", func(t *testing.T) {
+		user := &models.User{ID: "user123"}
+		vaultModel := vault.InitialModel(nil, nil, nil, nil, time.Second)
+		model := InitialRootModel(auth.Model{}, vaultModel, add.Model{}, update.Model{}, lock.Model{}, emergency.Model{}, account.Model{}, 0)
+		model.current = VaultModel
+		model.user = user
+
+		updated, cmd := model.Update(vault.AccountReqMsg{User: user})
+		require.Nil(t, cmd)
+		model = updated.(rootModel)
+		assert.Equal(t, AccountModel, model.current)
+
+		updated, _ = model.Update(account.DeletedMsg{})
+		model = updated.(rootModel)
+		assert.Equal(t, AuthModel, model.current)
+		assert.Nil(t, model.user)
+	})
+
 	t.Run("should transition from vault to add on add request", func(t *testing.T) {
 		user := &models.User{ID: "user123"}
 		vaultModel := vault.Model{}
-		model := InitialRootModel(auth.Model{}, vaultModel, add.Model{}, update.Model{})
+		model := InitialRootModel(auth.Model{}, vaultModel, add.Model{}, update.Model{}, lock.Model{}, emergency.Model{}, account.Model{}, 0)
 		model.current = VaultModel
 
 		updated, cmd := model.Update(vault.AddItemReqMsg{User: user})
@@ -61,7 +107,7 @@ func TestRootModel_Update(t *testing.T) {
 
 	t.Run("should transition from vault to update on update request", func(t *testing.T) {
 		vaultModel := vault.Model{}
-		model := InitialRootModel(auth.Model{}, vaultModel, add.Model{}, update.Model{})
+		model := InitialRootModel(auth.Model{}, vaultModel, add.Model{}, update.Model{}, lock.Model{}, emergency.Model{}, account.Model{}, 0)
 		model.current = VaultModel
 
 		itemInfo := &models.ItemInfo{ID: "item123"}
@@ -76,7 +122,7 @@ func TestRootModel_Update(t *testing.T) {
 
 	t.Run("should return to vault from add on cancel", func(t *testing.T) {
 		vaultModel := vault.Model{}
-		model := InitialRootModel(auth.Model{}, vaultModel, add.Model{}, update.Model{})
+		model := InitialRootModel(auth.Model{}, vaultModel, add.Model{}, update.Model{}, lock.Model{}, emergency.Model{}, account.Model{}, 0)
 		model.current = AddModel
 
 		updated, cmd := model.Update(add.CancelMsg{})
@@ -89,7 +135,7 @@ func TestRootModel_Update(t *testing.T) {
 
 	t.Run("should return to vault from update on cancel", func(t *testing.T) {
 		vaultModel := vault.Model{}
-		model := InitialRootModel(auth.Model{}, vaultModel, add.Model{}, update.Model{})
+		model := InitialRootModel(auth.Model{}, vaultModel, add.Model{}, update.Model{}, lock.Model{}, emergency.Model{}, account.Model{}, 0)
 		model.current = UpdateModel
 
 		updated, cmd := model.Update(update.CancelMsg{})
@@ -102,17 +148,142 @@ func TestRootModel_Update(t *testing.T) {
 
 	t.Run("should delegate update to current screen", func(t *testing.T) {
 		authModel := auth.Model{}
-		model := InitialRootModel(authModel, vault.Model{}, add.Model{}, update.Model{})
+		model := InitialRootModel(authModel, vault.Model{}, add.Model{}, update.Model{}, lock.Model{}, emergency.Model{}, account.Model{}, 0)
 
 		_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
 		assert.NotNil(t, cmd)
 	})
 }
 
+func TestRootModel_IdleLock(t *testing.T) {
+	const idleTimeout = time.Minute
+
+	newModel := func(t *testing.T) (rootModel, *mocks.Mockcrypter) {
+		ctrl := gomock.NewController(t)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		lockModel := lock.InitialModel(mocks.NewMockkeyProvider(ctrl), mockCrypt)
+		vaultModel := vault.InitialModel(nil, nil, nil, nil, time.Second)
+
+		model := InitialRootModel(auth.Model{}, vaultModel, add.Model{}, update.Model{}, lockModel, emergency.Model{}, account.Model{}, idleTimeout)
+		updated, _ := model.Update(auth.AuthSuccessMsg{User: &models.User{ID: "user123"}})
+		return updated.(rootModel), mockCrypt
+	}
+
+	t.Run("should schedule checks only when enabled", func(t *testing.T) {
+		model := InitialRootModel(auth.Model{}, vault.Model{}, add.Model{}, update.Model{}, lock.Model{}, emergency.Model{}, account.Model{}, 0)
+		assert.Nil(t, model.Init())
+
+		model.idleTimeout = idleTimeout
+		assert.NotNil(t, model.Init())
+	})
+
+	t.Run("should keep vault open before timeout", func(t *testing.T) {
+		model, _ := newModel(t)
+
+		updated, cmd := model.Update(IdleTickMsg{Time: model.lastActivity.Add(idleTimeout / 2)})
+		assert.NotNil(t, cmd)
+		assert.Equal(t, VaultModel, updated.(rootModel).current)
+	})
+
+	t.Run("should lock vault after timeout", func(t *testing.T) {
+		model, mockCrypt := newModel(t)
+		model.current = UpdateModel
+
+		gomock.InOrder(
+			mockCrypt.EXPECT().Blind(gomock.Any()).Return([]byte("check"), nil),
+			mockCrypt.EXPECT().Wipe(),
+		)
+
+		updated, cmd := model.Update(IdleTickMsg{Time: model.lastActivity.Add(idleTimeout)})
+		assert.NotNil(t, cmd)
+		assert.Equal(t, LockModel, updated.(rootModel).current)
+		assert.Equal(t, updated.(rootModel).lockModel.View(), updated.(rootModel).View())
+	})
+
+	t.Run("should not lock on auth screen", func(t *testing.T) {
+		model := InitialRootModel(auth.Model{}, vault.Model{}, add.Model{}, update.Model{}, lock.Model{}, emergency.Model{}, account.Model{}, idleTimeout)
+
+		updated, _ := model.Update(IdleTickMsg{Time: time.Now().Add(time.Hour)})
+		assert.Equal(t, AuthModel, updated.(rootModel).current)
+	})
+
+	t.Run("should reset idle timer on key press", func(t *testing.T) {
+		model, _ := newModel(t)
+		model.lastActivity = time.Time{}
+
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyDown})
+		assert.WithinDuration(t, time.Now(), updated.(rootModel).lastActivity, time.Second)
+	})
+
+	t.Run("should return to vault on unlock", func(t *testing.T) {
+		model, _ := newModel(t)
+		model.current = LockModel
+
+		updated, cmd := model.Update(lock.UnlockSuccessMsg{})
+		require.Nil(t, cmd)
+		assert.Equal(t, VaultModel, updated.(rootModel).current)
+	})
+}
+
+func TestRootModel_ClearAuth(t *testing.T) {
+	const password = "secret"
+
+	// typed returns the model after the password was entered on the login form
+	typed := func(model rootModel) rootModel {
+		for _, msg := range []tea.Msg{
+			tea.KeyMsg{Type: tea.KeyDown},
+			tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(password)},
+		} {
+			updated, _ := model.Update(msg)
+			model = updated.(rootModel)
+		}
+		require.Contains(t, model.authModel.View(), strings.Repeat("•", len(password)))
+		return model
+	}
+
+	t.Run("should wipe password on successful login", func(t *testing.T) {
+		model := InitialRootModel(auth.InitialModel(nil, nil, nil, nil, time.Second), vault.Model{},
+			add.Model{}, update.Model{}, lock.Model{}, emergency.Model{}, account.Model{}, 0)
+		model = typed(model)
+
+		updated, _ := model.Update(auth.AuthSuccessMsg{User: &models.User{ID: "user123"}})
+		assert.NotContains(t, updated.(rootModel).authModel.View(), "•")
+	})
+
+	t.Run("should wipe password on lock", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		lockModel := lock.InitialModel(mocks.NewMockkeyProvider(ctrl), mockCrypt)
+		model := InitialRootModel(auth.InitialModel(nil, nil, nil, nil, time.Second), vault.InitialModel(nil, nil, nil, nil, time.Second),
+			add.Model{}, update.Model{}, lockModel, emergency.Model{}, account.Model{}, time.Minute)
+		model = typed(model)
+		model.current = VaultModel
+
+		mockCrypt.EXPECT().Blind(gomock.Any()).Return([]byte("check"), nil)
+		mockCrypt.EXPECT().Wipe()
+
+		model.lock()
+		assert.Equal(t, LockModel, model.current)
+		assert.NotContains(t, model.authModel.View(), "•")
+	})
+}
+
+func TestRootModel_Reauth(t *testing.T) {
+	t.Run("should switch to auth screen for sync login", func(t *testing.T) {
+		model := InitialRootModel(auth.Model{}, vault.Model{}, add.Model{}, update.Model{}, lock.Model{}, emergency.Model{}, account.Model{}, 0)
+		model.current = VaultModel
+
+		updated, cmd := model.Update(vault.ReauthReqMsg{})
+		require.Nil(t, cmd)
+		assert.Equal(t, AuthModel, updated.(rootModel).current)
+		assert.Equal(t, auth.LoginState, updated.(rootModel).authModel.GetState())
+	})
+}
+
 func TestRootModel_View(t *testing.T) {
 	t.Run("should render auth screen when active", func(t *testing.T) {
 		authModel := auth.Model{}
-		model := InitialRootModel(authModel, vault.Model{}, add.Model{}, update.Model{})
+		model := InitialRootModel(authModel, vault.Model{}, add.Model{}, update.Model{}, lock.Model{}, emergency.Model{}, account.Model{}, 0)
 		model.current = AuthModel
 
 		view := model.View()
@@ -121,7 +292,7 @@ func TestRootModel_View(t *testing.T) {
 
 	t.Run("should render vault screen when active", func(t *testing.T) {
 		vaultModel := vault.Model{}
-		model := InitialRootModel(auth.Model{}, vaultModel, add.Model{}, update.Model{})
+		model := InitialRootModel(auth.Model{}, vaultModel, add.Model{}, update.Model{}, lock.Model{}, emergency.Model{}, account.Model{}, 0)
 		model.current = VaultModel
 
 		view := model.View()
@@ -130,7 +301,7 @@ func TestRootModel_View(t *testing.T) {
 
 	t.Run("should render add screen when active", func(t *testing.T) {
 		addModel := add.Model{}
-		model := InitialRootModel(auth.Model{}, vault.Model{}, addModel, update.Model{})
+		model := InitialRootModel(auth.Model{}, vault.Model{}, addModel, update.Model{}, lock.Model{}, emergency.Model{}, account.Model{}, 0)
 		model.current = AddModel
 
 		view := model.View()
@@ -139,7 +310,7 @@ func TestRootModel_View(t *testing.T) {
 
 	t.Run("should render update screen when active", func(t *testing.T) {
 		updateModel := update.Model{}
-		model := InitialRootModel(auth.Model{}, vault.Model{}, add.Model{}, updateModel)
+		model := InitialRootModel(auth.Model{}, vault.Model{}, add.Model{}, updateModel, lock.Model{}, emergency.Model{}, account.Model{}, 0)
 		model.current = UpdateModel
 
 		view := model.View()
@@ -0,0 +1,211 @@
+package account
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/client/internal/tui/screens/account/mocks"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteAccount(t *testing.T) {
+	user := &models.User{ID: "user123", JWT: "token"}
+
+	t.Run("should delete account and wipe key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSvc := mocks.NewMockaccountService(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		model := InitialModel(mockSvc, mockCrypt, time.Second)
+		model.SetUser(user)
+		model.state = InputState
+
+		for _, r := range "pass" {
+			updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			model = updated.(Model)
+		}
+		assert.NotContains(t, model.View(), "pass")
+
+		gomock.InOrder(
+			mockSvc.EXPECT().UserDeleteAccount(gomock.Any(), "pass", user).Return(nil),
+			mockCrypt.EXPECT().Wipe(),
+		)
+
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		require.NotNil(t, cmd)
+		assert.Equal(t, ProcessingState, updated.(Model).state)
+		assert.Equal(t, DeletedMsg{}, cmd())
+	})
+
+	t.Run("should keep key on wrong password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSvc := mocks.NewMockaccountService(ctrl)
+		model := InitialModel(mockSvc, mocks.NewMockcrypter(ctrl), time.Second)
+		model.user = user
+		model.password = "wrong"
+		model.state = ProcessingState
+
+		mockSvc.EXPECT().UserDeleteAccount(gomock.Any(), "wrong", user).Return(errors.New("password mismatch"))
+
+		updated, _ := model.Update(model.deleteAccount()())
+		newModel := updated.(Model)
+		assert.Equal(t, ErrorState, newModel.state)
+		assert.Equal(t, "password mismatch", newModel.errMsg)
+		assert.Empty(t, newModel.password)
+	})
+
+	t.Run("should ignore empty password", func(t *testing.T) {
+		model := InitialModel(nil, nil, time.Second)
+		model.state = InputState
+
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Nil(t, cmd)
+		assert.Equal(t, InputState, updated.(Model).state)
+	})
+
+	t.Run("should erase whole character", func(t *testing.T) {
+		model := InitialModel(nil, nil, time.Second)
+		model.state = InputState
+		model.password = "парольй"
+
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		assert.Equal(t, "пароль", updated.(Model).password)
+	})
+
+	t.Run("should return to activity on escape", func(t *testing.T) {
+		model := InitialModel(nil, nil, time.Second)
+		model.state = InputState
+		model.password = "pa"
+
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		assert.Nil(t, cmd)
+		assert.Equal(t, ListState, updated.(Model).state)
+		assert.Empty(t, updated.(Model).password)
+	})
+
+	t.Run("should return to form after error", func(t *testing.T) {
+		model := InitialModel(nil, nil, time.Second)
+		model.state = ProcessingState
+
+		updated, _ := model.Update(DeleteErrorMsg{Err: errors.New("password mismatch")})
+		updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Equal(t, InputState, updated.(Model).state)
+	})
+}
+
+func TestActivity(t *testing.T) {
+	user := &models.User{ID: "user123", JWT: "token"}
+
+	t.Run("should load first page on open", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSvc := mocks.NewMockaccountService(ctrl)
+		model := InitialModel(mockSvc, nil, time.Second)
+		model.SetUser(user)
+
+		page := &models.AuditPage{
+			Events: []models.AuditEvent{
+				{ID: 2, Device: "laptop", IP: "192.0.2.1", Action: models.AuditLogin, Result: models.AuditFailure, CreatedAt: time.Now()},
+				{ID: 1, Action: models.AuditSync, Result: models.AuditSuccess, CreatedAt: time.Now()},
+			},
+			NextToken: "1",
+		}
+		mockSvc.EXPECT().UserActivity(gomock.Any(), "", activityPageSize, user).Return(page, nil)
+
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		require.NotNil(t, cmd)
+		updated, _ = updated.(Model).Update(cmd())
+		newModel := updated.(Model)
+
+		assert.Equal(t, ListState, newModel.state)
+		assert.Equal(t, []string{""}, newModel.tokens)
+		view := newModel.View()
+		assert.Contains(t, view, "laptop")
+		assert.Contains(t, view, "192.0.2.1")
+	})
+
+	t.Run("should page forward and back", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSvc := mocks.NewMockaccountService(ctrl)
+		model := InitialModel(mockSvc, nil, time.Second)
+		model.user = user
+		model.state = ListState
+		model.tokens = []string{""}
+		model.next = "21"
+
+		gomock.InOrder(
+			mockSvc.EXPECT().UserActivity(gomock.Any(), "21", activityPageSize, user).
+				Return(&models.AuditPage{Events: []models.AuditEvent{{ID: 20}}}, nil),
+			mockSvc.EXPECT().UserActivity(gomock.Any(), "", activityPageSize, user).
+				Return(&models.AuditPage{Events: []models.AuditEvent{{ID: 40}}, NextToken: "21"}, nil),
+		)
+
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+		updated, _ = updated.(Model).Update(cmd())
+		model = updated.(Model)
+		assert.Equal(t, []string{"", "21"}, model.tokens)
+		assert.Empty(t, model.next)
+
+		updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+		assert.Nil(t, cmd, "no page after the last one")
+
+		updated, cmd = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("з")})
+		updated, _ = updated.(Model).Update(cmd())
+		model = updated.(Model)
+		assert.Equal(t, []string{""}, model.tokens)
+		assert.Equal(t, int64(40), model.events[0].ID)
+	})
+
+	t.Run("should keep shown page on error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSvc := mocks.NewMockaccountService(ctrl)
+		model := InitialModel(mockSvc, nil, time.Second)
+		model.user = user
+		model.state = ListState
+		model.tokens = []string{""}
+		model.next = "21"
+
+		mockSvc.EXPECT().UserActivity(gomock.Any(), "21", activityPageSize, user).Return(nil, errors.New("unavailable"))
+
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+		updated, _ = updated.(Model).Update(cmd())
+		assert.Equal(t, ErrorState, updated.(Model).state)
+
+		updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Equal(t, ListState, updated.(Model).state)
+		assert.Equal(t, []string{""}, updated.(Model).tokens)
+	})
+
+	t.Run("should open deletion form", func(t *testing.T) {
+		model := InitialModel(nil, nil, time.Second)
+		model.state = ListState
+
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+		assert.Equal(t, InputState, updated.(Model).state)
+	})
+
+	t.Run("should return to vault on escape", func(t *testing.T) {
+		model := InitialModel(nil, nil, time.Second)
+		model.state = ListState
+		model.tokens = []string{""}
+
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		require.NotNil(t, cmd)
+		assert.Equal(t, CancelMsg{}, cmd())
+		assert.Equal(t, UpdateState, updated.(Model).state)
+		assert.Nil(t, updated.(Model).tokens)
+	})
+}
@@ -0,0 +1,166 @@
+package account
+
+import (
+	"context"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Init initializes the account model
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles all messages and state transitions
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch m.state {
+	case UpdateState:
+		m.state = ProcessingState
+		return m, m.loadActivity([]string{""})
+	case ListState:
+		return handleListState(m, msg)
+	case InputState:
+		return handleInputState(m, msg)
+	case ProcessingState:
+		return handleProcessingState(m, msg)
+	case ErrorState:
+		return handleErrorState(m, msg)
+	}
+
+	return m, nil
+}
+
+// handleListState manages account activity paging and actions
+func handleListState(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.Clear()
+		return m, func() tea.Msg { return CancelMsg{} }
+	case tea.KeyRunes:
+		switch keyMsg.String() {
+		case "n", "т":
+			if m.next != "" {
+				m.state = ProcessingState
+				return m, m.loadActivity(append(slices.Clone(m.tokens), m.next))
+			}
+		case "p", "з":
+			if len(m.tokens) > 1 {
+				m.state = ProcessingState
+				return m, m.loadActivity(m.tokens[:len(m.tokens)-1])
+			}
+		case "u", "г":
+			m.state = ProcessingState
+			return m, m.loadActivity([]string{""})
+		case "x", "ч":
+			m.password = ""
+			m.state = InputState
+		}
+	}
+
+	return m, nil
+}
+
+// handleInputState manages password confirmation input
+func handleInputState(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.password = ""
+		m.state = ListState
+	case tea.KeyEnter:
+		if m.password == "" {
+			return m, nil
+		}
+		m.state = ProcessingState
+		return m, m.deleteAccount()
+	case tea.KeyBackspace:
+		runes := []rune(m.password)
+		if len(runes) > 0 {
+			m.password = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes:
+		m.password += string(keyMsg.Runes)
+	}
+
+	return m, nil
+}
+
+// handleProcessingState processes activity loading and deletion results
+func handleProcessingState(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ActivityMsg:
+		m.tokens = msg.Tokens
+		m.events = msg.Page.Events
+		m.next = msg.Page.NextToken
+		m.state = ListState
+	case ActivityErrorMsg:
+		m.errMsg = msg.Err.Error()
+		m.back = ListState
+		if m.tokens == nil {
+			m.back = UpdateState // Nothing to show yet, retry loading
+		}
+		m.state = ErrorState
+	case DeleteErrorMsg:
+		m.errMsg = msg.Err.Error()
+		m.password = ""
+		m.back = InputState
+		m.state = ErrorState
+	}
+
+	return m, nil
+}
+
+// handleErrorState returns to the previous view after error acknowledgement
+func handleErrorState(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.Type == tea.KeyEnter {
+		m.state = m.back
+	}
+
+	return m, nil
+}
+
+// loadActivity fetches the page of account activity for the last of tokens
+// Tokens replace the shown ones only after the page is loaded
+func (m Model) loadActivity(tokens []string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		page, err := m.svc.UserActivity(ctx, tokens[len(tokens)-1], activityPageSize, m.user)
+		if err != nil {
+			return ActivityErrorMsg{Err: err}
+		}
+
+		return ActivityMsg{Page: page, Tokens: tokens}
+	}
+}
+
+// deleteAccount removes the account and wipes the vault key from memory
+func (m Model) deleteAccount() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		err := m.svc.UserDeleteAccount(ctx, m.password, m.user)
+		if err != nil {
+			return DeleteErrorMsg{Err: err}
+		}
+
+		m.crypt.Wipe()
+		return DeletedMsg{}
+	}
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: model.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/gokeep/shared/models"
+)
+
+// MockaccountService is a mock of accountService interface.
+type MockaccountService struct {
+	ctrl     *gomock.Controller
+	recorder *MockaccountServiceMockRecorder
+}
+
+// MockaccountServiceMockRecorder is the mock recorder for MockaccountService.
+type MockaccountServiceMockRecorder struct {
+	mock *MockaccountService
+}
+
+// NewMockaccountService creates a new mock instance.
+func NewMockaccountService(ctrl *gomock.Controller) *MockaccountService {
+	mock := &MockaccountService{ctrl: ctrl}
+	mock.recorder = &MockaccountServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockaccountService) EXPECT() *MockaccountServiceMockRecorder {
+	return m.recorder
+}
+
+// UserActivity mocks base method.
+func (m *MockaccountService) UserActivity(arg0 context.Context, arg1 string, arg2 int, arg3 *models.User) (*models.AuditPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserActivity", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.AuditPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserActivity indicates an expected call of UserActivity.
+func (mr *MockaccountServiceMockRecorder) UserActivity(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserActivity", reflect.TypeOf((*MockaccountService)(nil).UserActivity), arg0, arg1, arg2, arg3)
+}
+
+// UserDeleteAccount mocks base method.
+func (m *MockaccountService) UserDeleteAccount(arg0 context.Context, arg1 string, arg2 *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserDeleteAccount", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserDeleteAccount indicates an expected call of UserDeleteAccount.
+func (mr *MockaccountServiceMockRecorder) UserDeleteAccount(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDeleteAccount", reflect.TypeOf((*MockaccountService)(nil).UserDeleteAccount), arg0, arg1, arg2)
+}
+
+// Mockcrypter is a mock of crypter interface.
+type Mockcrypter struct {
+	ctrl     *gomock.Controller
+	recorder *MockcrypterMockRecorder
+}
+
+// MockcrypterMockRecorder is the mock recorder for Mockcrypter.
+type MockcrypterMockRecorder struct {
+	mock *Mockcrypter
+}
+
+// NewMockcrypter creates a new mock instance.
+func NewMockcrypter(ctrl *gomock.Controller) *Mockcrypter {
+	mock := &Mockcrypter{ctrl: ctrl}
+	mock.recorder = &MockcrypterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcrypter) EXPECT() *MockcrypterMockRecorder {
+	return m.recorder
+}
+
+// Wipe mocks base method.
+func (m *Mockcrypter) Wipe() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Wipe")
+}
+
+// Wipe indicates an expected call of Wipe.
+func (mr *MockcrypterMockRecorder) Wipe() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wipe", reflect.TypeOf((*Mockcrypter)(nil).Wipe))
+}
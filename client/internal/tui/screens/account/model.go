@@ -0,0 +1,102 @@
+// Package account implements the account management screen.
+// Shows recent account activity and deletes the account after
+// the master password is entered again.
+package account
+
+import (
+	"context"
+	"time"
+
+	"github.com/rycln/gokeep/shared/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// state represents current account screen state
+type state int
+
+// Account screen states
+const (
+	UpdateState     state = iota // Loading activity state
+	ListState                    // Account activity view
+	InputState                   // Master password confirmation
+	ProcessingState              // Background operation in progress
+	ErrorState                   // Error display state
+)
+
+// activityPageSize is the number of audit events shown at once
+const activityPageSize = 20
+
+// Message types for account screen events
+type (
+	// CancelMsg requests return to the vault screen
+	CancelMsg struct{}
+
+	// DeletedMsg indicates the account and its local data are removed
+	DeletedMsg struct{}
+
+	// DeleteErrorMsg contains deletion failure details
+	DeleteErrorMsg struct{ Err error }
+
+	// ActivityMsg delivers a page of account activity
+	ActivityMsg struct {
+		Page   *models.AuditPage
+		Tokens []string // Page tokens up to the delivered page
+	}
+
+	// ActivityErrorMsg contains activity loading failure details
+	ActivityErrorMsg struct{ Err error }
+)
+
+// accountService defines account activity and removal operations
+type accountService interface {
+	UserActivity(context.Context, string, int, *models.User) (*models.AuditPage, error)
+	UserDeleteAccount(context.Context, string, *models.User) error
+}
+
+// crypter defines vault key operations
+type crypter interface {
+	// Wipe removes the key from memory
+	Wipe()
+}
+
+// Model represents account screen state and its dependencies
+type Model struct {
+	state    state               // Current screen state
+	back     state               // State restored after error acknowledgement
+	events   []models.AuditEvent // Shown page of account activity
+	tokens   []string            // Page tokens of the shown page and the pages before it
+	next     string              // Token of the following page, empty on the last one
+	password string              // Master password input value
+	errMsg   string              // Last error message to display
+	user     *models.User        // Current authenticated user
+	svc      accountService      // Account service
+	crypt    crypter             // Vault key holder
+	timeout  time.Duration       // Operation timeout
+}
+
+// InitialModel creates new account screen model with dependencies
+func InitialModel(svc accountService, crypt crypter, timeout time.Duration) Model {
+	return Model{
+		state:   UpdateState,
+		svc:     svc,
+		crypt:   crypt,
+		timeout: timeout,
+	}
+}
+
+// SetUser updates current user and resets the form
+func (m *Model) SetUser(user *models.User) {
+	m.user = user
+	m.Clear()
+}
+
+// Clear drops loaded activity, entered password and errors
+func (m *Model) Clear() {
+	m.events = nil
+	m.tokens = nil
+	m.next = ""
+	m.password = ""
+	m.errMsg = ""
+	m.state = UpdateState
+}
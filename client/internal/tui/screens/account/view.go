@@ -0,0 +1,93 @@
+package account
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rycln/gokeep/client/internal/tui/shared/i18n"
+	"github.com/rycln/gokeep/client/internal/tui/shared/styles"
+	"github.com/rycln/gokeep/shared/models"
+)
+
+// View renders the account screen based on state
+func (m Model) View() string {
+	switch m.state {
+	case UpdateState:
+		return i18n.CommonPressAnyKey
+	case ProcessingState:
+		return i18n.CommonWait
+	case ErrorState:
+		return styles.ErrorStyle.Render(fmt.Sprintf(i18n.CommonError, m.errMsg))
+	case ListState:
+		return m.activityView()
+	default:
+		return fmt.Sprintf(
+			"%s\n\n%s\n\n%s\n\n%s",
+			styles.TitleStyle.Render(i18n.AccountDeleteTitle),
+			styles.ErrorStyle.Render(i18n.AccountDeleteWarning),
+			styles.FocusedStyle.Render("> "+fmt.Sprintf(i18n.AuthPasswordLabel, strings.Repeat("•", len(m.password)))),
+			i18n.AccountDeleteHint,
+		)
+	}
+}
+
+// activityView renders the shown page of account activity, newest first
+// Failed operations are highlighted so unexpected access stands out
+func (m Model) activityView() string {
+	var b strings.Builder
+	b.WriteString(styles.TitleStyle.Render(i18n.AccountActivityTitle) + "\n\n")
+
+	if len(m.events) == 0 {
+		b.WriteString(i18n.AccountActivityEmpty + "\n")
+	}
+	for _, event := range m.events {
+		device := event.Device
+		if device == "" {
+			device = i18n.AccountUnknownDevice
+		}
+		line := fmt.Sprintf(
+			i18n.AccountActivityEntry,
+			event.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			actionLabel(event.Action),
+			resultLabel(event.Result),
+			event.IP,
+			device,
+		)
+		if event.Result == models.AuditFailure {
+			line = styles.ErrorStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n" + fmt.Sprintf(i18n.AccountActivityPage, len(m.tokens)) + "\n")
+	b.WriteString("\n" + i18n.AccountActivityActions)
+	return b.String()
+}
+
+// actionLabel describes audited operation for display
+func actionLabel(action models.AuditAction) string {
+	switch action {
+	case models.AuditRegister:
+		return i18n.AccountActionRegister
+	case models.AuditLogin:
+		return i18n.AccountActionLogin
+	case models.AuditRecover:
+		return i18n.AccountActionRecover
+	case models.AuditChangePassword:
+		return i18n.AccountActionChangePassword
+	case models.AuditDeleteAccount:
+		return i18n.AccountActionDeleteAccount
+	case models.AuditSync:
+		return i18n.AccountActionSync
+	default:
+		return string(action)
+	}
+}
+
+// resultLabel describes audited operation outcome for display
+func resultLabel(result models.AuditResult) string {
+	if result == models.AuditFailure {
+		return i18n.AccountResultFailure
+	}
+	return i18n.AccountResultSuccess
+}
@@ -51,6 +51,21 @@ func TestSetUser(t *testing.T) {
 	})
 }
 
+func TestClear(t *testing.T) {
+	t.Run("should return to type selection", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockitemAdder(ctrl), time.Second)
+		model.state = AddText
+		model.cursor = 2
+
+		model.Clear()
+		assert.Equal(t, SelectState, model.state)
+		assert.Equal(t, 0, model.cursor)
+	})
+}
+
 func TestInit(t *testing.T) {
 	t.Run("should return nil command", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
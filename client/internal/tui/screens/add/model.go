@@ -81,3 +81,14 @@ func InitialModel(service itemAdder, timeout time.Duration) Model {
 func (m *Model) SetUser(user *models.User) {
 	m.user = user
 }
+
+// Clear drops entered form data and returns to type selection
+func (m *Model) Clear() {
+	m.state = SelectState
+	m.cursor = 0
+	m.errMsg = ""
+	m.logpassModel = logpass.InitialModel()
+	m.cardModel = card.InitialModel()
+	m.textModel = text.InitialModel()
+	m.binModel = bin.InitialModel()
+}
@@ -2,17 +2,26 @@ package auth
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/client/internal/services"
 	"github.com/rycln/gokeep/client/internal/tui/screens/auth/mocks"
 	"github.com/rycln/gokeep/client/internal/tui/shared/i18n"
 	"github.com/rycln/gokeep/shared/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// testKeyPair is a sharing keypair returned by the key provider mock
+var testKeyPair = models.KeyPair{
+	PublicKey:           []byte("publicKey"),
+	EncryptedPrivateKey: "encryptedPrivateKey",
+}
+
 func TestInitialModel(t *testing.T) {
 	t.Run("should initialize with default values", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -21,9 +30,10 @@ func TestInitialModel(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
 		timeout := 5 * time.Second
 
-		model := InitialModel(mockService, mockKey, mockCrypt, timeout)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, timeout)
 
 		assert.Equal(t, LoginState, model.state)
 		assert.Equal(t, UsernameField, model.activeField)
@@ -33,6 +43,7 @@ func TestInitialModel(t *testing.T) {
 		assert.Equal(t, mockService, model.service)
 		assert.Equal(t, mockKey, model.key)
 		assert.Equal(t, mockCrypt, model.crypt)
+		assert.Equal(t, mockVault, model.vault)
 		assert.Equal(t, timeout, model.timeout)
 	})
 }
@@ -45,7 +56,8 @@ func TestGetState(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.state = RegisterState
 
 		assert.Equal(t, RegisterState, model.GetState())
@@ -60,7 +72,8 @@ func TestInit(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 
 		cmd := model.Init()
 		assert.Nil(t, cmd)
@@ -75,7 +88,8 @@ func TestHandleAuthInput(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.state = LoginState
 
 		newModel, cmd := handleAuthInput(model, tea.KeyMsg{Type: tea.KeyEnter})
@@ -90,7 +104,8 @@ func TestHandleAuthInput(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.state = RegisterState
 
 		newModel, cmd := handleAuthInput(model, tea.KeyMsg{Type: tea.KeyEnter})
@@ -105,7 +120,8 @@ func TestHandleAuthInput(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 
 		newModel, _ := handleAuthInput(model, tea.KeyMsg{Type: tea.KeyTab})
 		assert.Equal(t, RegisterState, newModel.state)
@@ -121,7 +137,8 @@ func TestHandleAuthInput(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 
 		newModel, _ := handleAuthInput(model, tea.KeyMsg{Type: tea.KeyDown})
 		assert.Equal(t, PasswordField, newModel.activeField)
@@ -137,7 +154,8 @@ func TestHandleAuthInput(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.activeField = UsernameField
 
 		newModel, _ := handleAuthInput(model, tea.KeyMsg{
@@ -154,7 +172,8 @@ func TestHandleAuthInput(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.activeField = PasswordField
 
 		newModel, _ := handleAuthInput(model, tea.KeyMsg{
@@ -171,7 +190,8 @@ func TestHandleAuthInput(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.activeField = UsernameField
 
 		newModel, _ := handleAuthInput(model, tea.KeyMsg{
@@ -188,7 +208,8 @@ func TestHandleAuthInput(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.activeField = UsernameField
 		model.username = "test"
 
@@ -203,7 +224,8 @@ func TestHandleAuthInput(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.activeField = PasswordField
 		model.password = "pass"
 
@@ -220,11 +242,12 @@ func TestLogin(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.username = "testuser"
 		model.password = "testpass"
 
-		expectedUser := &models.User{ID: "user123", Salt: "encodedSalt"}
+		expectedUser := &models.User{ID: "user123", Salt: "encodedSalt", KeyPair: testKeyPair}
 		decodedSalt := []byte("decodedSalt")
 		derivedKey := []byte("derivedKey")
 
@@ -247,6 +270,28 @@ func TestLogin(t *testing.T) {
 			SetKey(derivedKey).
 			Return(nil)
 
+		mockVault.EXPECT().
+			Open(gomock.Any(), expectedUser.ID).
+			Return(nil)
+
+		mockKey.EXPECT().
+			KeyCheck(derivedKey).
+			Return("keyCheck", nil)
+
+		mockKey.EXPECT().
+			KDFIterations().
+			Return(600000)
+
+		mockService.EXPECT().
+			CacheAccount(gomock.Any(), &models.LocalAccount{
+				Username:      "testuser",
+				UserID:        expectedUser.ID,
+				Salt:          expectedUser.Salt,
+				KDFIterations: 600000,
+				KeyCheck:      "keyCheck",
+			}).
+			Return(nil)
+
 		cmd := model.login()
 		msg := cmd().(AuthSuccessMsg)
 
@@ -260,7 +305,8 @@ func TestLogin(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.username = "testuser"
 		model.password = "testpass"
 
@@ -288,7 +334,8 @@ func TestLogin(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.username = "testuser"
 		model.password = "testpass"
 
@@ -320,15 +367,293 @@ func TestLogin(t *testing.T) {
 	})
 }
 
+func TestLoginKeyPair(t *testing.T) {
+	setup := func(t *testing.T) (Model, *mocks.MockauthService, *mocks.MockkeyProvider, *models.User) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockKey := mocks.NewMockkeyProvider(ctrl)
+		mockService := mocks.NewMockauthService(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
+		model.username = "testuser"
+		model.password = "testpass"
+
+		legacyUser := &models.User{ID: "user123", JWT: "jwt", Salt: "encodedSalt"}
+
+		mockService.EXPECT().UserLogin(gomock.Any(), gomock.Any()).Return(legacyUser, nil)
+		mockKey.EXPECT().DecodeSalt("encodedSalt").Return([]byte("salt"), nil)
+		mockKey.EXPECT().DeriveKeyFromPasswordAndSalt("testpass", []byte("salt")).Return([]byte("key"))
+		mockCrypt.EXPECT().SetKey([]byte("key")).Return(nil)
+		mockVault.EXPECT().Open(gomock.Any(), legacyUser.ID).Return(nil)
+		mockKey.EXPECT().KeyCheck([]byte("key")).Return("keyCheck", nil)
+		mockKey.EXPECT().KDFIterations().Return(600000)
+		mockService.EXPECT().CacheAccount(gomock.Any(), gomock.Any()).Return(nil)
+
+		return model, mockService, mockKey, legacyUser
+	}
+
+	t.Run("should upload keypair for account without one", func(t *testing.T) {
+		model, mockService, mockKey, legacyUser := setup(t)
+
+		mockKey.EXPECT().NewKeyPair([]byte("key")).Return(&testKeyPair, nil)
+		mockService.EXPECT().UserSetKeyPair(gomock.Any(), &testKeyPair, legacyUser).Return(nil)
+
+		msg := model.login()().(AuthSuccessMsg)
+		assert.Equal(t, testKeyPair, msg.User.KeyPair)
+	})
+
+	t.Run("should return LoginErrorMsg when keypair upload fails", func(t *testing.T) {
+		model, mockService, mockKey, _ := setup(t)
+
+		testErr := errors.New("upload error")
+		mockKey.EXPECT().NewKeyPair([]byte("key")).Return(&testKeyPair, nil)
+		mockService.EXPECT().UserSetKeyPair(gomock.Any(), gomock.Any(), gomock.Any()).Return(testErr)
+
+		msg := model.login()().(LoginErrorMsg)
+		assert.Equal(t, testErr, msg.Err)
+	})
+}
+
+func TestLoginWithWrappedKey(t *testing.T) {
+	t.Run("should unwrap vault key with derived key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockKey := mocks.NewMockkeyProvider(ctrl)
+		mockService := mocks.NewMockauthService(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
+		model.username = "testuser"
+		model.password = "testpass"
+
+		expectedUser := &models.User{ID: "user123", Salt: "encodedSalt", EncryptedKey: "wrappedKey", KeyPair: testKeyPair}
+		decodedSalt := []byte("decodedSalt")
+		derivedKey := []byte("derivedKey")
+		vaultKey := []byte("vaultKey")
+
+		gomock.InOrder(
+			mockService.EXPECT().UserLogin(gomock.Any(), gomock.Any()).Return(expectedUser, nil),
+			mockKey.EXPECT().DecodeSalt(expectedUser.Salt).Return(decodedSalt, nil),
+			mockKey.EXPECT().DeriveKeyFromPasswordAndSalt("testpass", decodedSalt).Return(derivedKey),
+			mockKey.EXPECT().UnwrapKey(derivedKey, expectedUser.EncryptedKey).Return(vaultKey, nil),
+			mockCrypt.EXPECT().SetKey(vaultKey).Return(nil),
+			mockVault.EXPECT().Open(gomock.Any(), expectedUser.ID).Return(nil),
+			mockKey.EXPECT().KeyCheck(vaultKey).Return("keyCheck", nil),
+			mockKey.EXPECT().KDFIterations().Return(600000),
+			mockService.EXPECT().
+				CacheAccount(gomock.Any(), &models.LocalAccount{
+					Username:      "testuser",
+					UserID:        expectedUser.ID,
+					Salt:          expectedUser.Salt,
+					KDFIterations: 600000,
+					EncryptedKey:  expectedUser.EncryptedKey,
+					KeyCheck:      "keyCheck",
+				}).
+				Return(nil),
+		)
+
+		cmd := model.login()
+		msg := cmd().(AuthSuccessMsg)
+		assert.Equal(t, expectedUser, msg.User)
+	})
+
+	t.Run("should return LoginErrorMsg when key cannot be unwrapped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockKey := mocks.NewMockkeyProvider(ctrl)
+		mockService := mocks.NewMockauthService(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
+		model.password = "testpass"
+
+		expectedUser := &models.User{ID: "user123", Salt: "encodedSalt", EncryptedKey: "wrappedKey"}
+		testErr := errors.New("unwrap error")
+
+		gomock.InOrder(
+			mockService.EXPECT().UserLogin(gomock.Any(), gomock.Any()).Return(expectedUser, nil),
+			mockKey.EXPECT().DecodeSalt(gomock.Any()).Return([]byte("salt"), nil),
+			mockKey.EXPECT().DeriveKeyFromPasswordAndSalt(gomock.Any(), gomock.Any()).Return([]byte("key")),
+			mockKey.EXPECT().UnwrapKey(gomock.Any(), gomock.Any()).Return(nil, testErr),
+		)
+
+		cmd := model.login()
+		msg := cmd().(LoginErrorMsg)
+		assert.Equal(t, testErr, msg.Err)
+	})
+}
+
+func TestLoginVaultOpen(t *testing.T) {
+	t.Run("should return LoginErrorMsg when vault cannot be opened", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockKey := mocks.NewMockkeyProvider(ctrl)
+		mockService := mocks.NewMockauthService(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
+		model.password = "testpass"
+
+		expectedUser := &models.User{ID: "user123", Salt: "encodedSalt"}
+		testErr := errors.New("migration error")
+
+		gomock.InOrder(
+			mockService.EXPECT().UserLogin(gomock.Any(), gomock.Any()).Return(expectedUser, nil),
+			mockKey.EXPECT().DecodeSalt(gomock.Any()).Return([]byte("salt"), nil),
+			mockKey.EXPECT().DeriveKeyFromPasswordAndSalt(gomock.Any(), gomock.Any()).Return([]byte("key")),
+			mockCrypt.EXPECT().SetKey([]byte("key")).Return(nil),
+			mockVault.EXPECT().Open(gomock.Any(), expectedUser.ID).Return(testErr),
+		)
+
+		cmd := model.login()
+		msg := cmd().(LoginErrorMsg)
+		assert.Equal(t, testErr, msg.Err)
+	})
+}
+
+func TestOfflineLogin(t *testing.T) {
+	offlineErr := fmt.Errorf("%w: connection refused", services.ErrOffline)
+	account := &models.LocalAccount{
+		Username:      "testuser",
+		UserID:        "user123",
+		Salt:          "encodedSalt",
+		KDFIterations: 600000,
+		EncryptedKey:  "wrappedKey",
+		KeyCheck:      "keyCheck",
+	}
+
+	newModel := func(t *testing.T) (Model, *mocks.MockauthService, *mocks.MockkeyProvider, *mocks.Mockcrypter, *mocks.MockvaultOpener) {
+		ctrl := gomock.NewController(t)
+		mockService := mocks.NewMockauthService(ctrl)
+		mockKey := mocks.NewMockkeyProvider(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
+		model.username = "testuser"
+		model.password = "testpass"
+		return model, mockService, mockKey, mockCrypt, mockVault
+	}
+
+	t.Run("should unlock from cache when server is unreachable", func(t *testing.T) {
+		model, mockService, mockKey, mockCrypt, mockVault := newModel(t)
+
+		gomock.InOrder(
+			mockService.EXPECT().UserLogin(gomock.Any(), gomock.Any()).Return(nil, offlineErr),
+			mockService.EXPECT().CachedAccount(gomock.Any(), "testuser").Return(account, nil),
+			mockKey.EXPECT().KDFIterations().Return(600000),
+			mockKey.EXPECT().DecodeSalt("encodedSalt").Return([]byte("salt"), nil),
+			mockKey.EXPECT().DeriveKeyFromPasswordAndSalt("testpass", []byte("salt")).Return([]byte("derived")),
+			mockKey.EXPECT().UnwrapKey([]byte("derived"), "wrappedKey").Return([]byte("vaultKey"), nil),
+			mockKey.EXPECT().VerifyKeyCheck([]byte("vaultKey"), "keyCheck").Return(nil),
+			mockCrypt.EXPECT().SetKey([]byte("vaultKey")).Return(nil),
+			mockVault.EXPECT().Open(gomock.Any(), account.UserID).Return(nil),
+		)
+
+		msg := model.login()().(AuthSuccessMsg)
+		assert.Equal(t, &models.User{
+			ID:           account.UserID,
+			Salt:         account.Salt,
+			EncryptedKey: account.EncryptedKey,
+			Offline:      true,
+		}, msg.User)
+	})
+
+	t.Run("should return network error without cached account", func(t *testing.T) {
+		model, mockService, _, _, _ := newModel(t)
+
+		gomock.InOrder(
+			mockService.EXPECT().UserLogin(gomock.Any(), gomock.Any()).Return(nil, offlineErr),
+			mockService.EXPECT().CachedAccount(gomock.Any(), "testuser").Return(nil, errors.New("no cached account")),
+		)
+
+		msg := model.login()().(LoginErrorMsg)
+		assert.Equal(t, offlineErr, msg.Err)
+	})
+
+	t.Run("should reject wrong password", func(t *testing.T) {
+		model, mockService, mockKey, _, _ := newModel(t)
+		legacy := *account
+		legacy.EncryptedKey = ""
+
+		gomock.InOrder(
+			mockService.EXPECT().UserLogin(gomock.Any(), gomock.Any()).Return(nil, offlineErr),
+			mockService.EXPECT().CachedAccount(gomock.Any(), "testuser").Return(&legacy, nil),
+			mockKey.EXPECT().KDFIterations().Return(600000),
+			mockKey.EXPECT().DecodeSalt(gomock.Any()).Return([]byte("salt"), nil),
+			mockKey.EXPECT().DeriveKeyFromPasswordAndSalt(gomock.Any(), gomock.Any()).Return([]byte("derived")),
+			mockKey.EXPECT().VerifyKeyCheck([]byte("derived"), "keyCheck").Return(errors.New("auth failed")),
+		)
+
+		msg := model.login()().(LoginErrorMsg)
+		assert.Equal(t, errWrongPassword, msg.Err)
+	})
+
+	t.Run("should require online login after KDF change", func(t *testing.T) {
+		model, mockService, mockKey, _, _ := newModel(t)
+
+		gomock.InOrder(
+			mockService.EXPECT().UserLogin(gomock.Any(), gomock.Any()).Return(nil, offlineErr),
+			mockService.EXPECT().CachedAccount(gomock.Any(), "testuser").Return(account, nil),
+			mockKey.EXPECT().KDFIterations().Return(1000000),
+		)
+
+		msg := model.login()().(LoginErrorMsg)
+		assert.Equal(t, errKDFChanged, msg.Err)
+	})
+}
+
+func TestReauth(t *testing.T) {
+	t.Run("should return to login with password focused", func(t *testing.T) {
+		model := Model{state: ProcessingState, username: "testuser", password: "old", errMsg: "err"}
+
+		model.Reauth()
+		assert.Equal(t, LoginState, model.state)
+		assert.Equal(t, PasswordField, model.activeField)
+		assert.Equal(t, "testuser", model.username)
+		assert.Empty(t, model.password)
+		assert.Empty(t, model.errMsg)
+	})
+}
+
+func TestClear(t *testing.T) {
+	t.Run("should drop secrets and keep username", func(t *testing.T) {
+		model := Model{
+			username:    "testuser",
+			password:    "secret",
+			recoveryKey: "AAAA-BBBB",
+			user:        &models.User{ID: "user123"},
+			errMsg:      "err",
+		}
+
+		model.Clear()
+		assert.Equal(t, "testuser", model.username)
+		assert.Empty(t, model.password)
+		assert.Empty(t, model.recoveryKey)
+		assert.Nil(t, model.user)
+		assert.Empty(t, model.errMsg)
+	})
+}
+
 func TestRegister(t *testing.T) {
-	t.Run("should return AuthSuccessMsg on successful registration", func(t *testing.T) {
+	t.Run("should return RegisterSuccessMsg on successful registration", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.username = "newuser"
 		model.password = "newpass"
 
@@ -336,35 +661,52 @@ func TestRegister(t *testing.T) {
 		generatedSalt := []byte("generatedSalt")
 		encodedSalt := "encodedSalt"
 		derivedKey := []byte("derivedKey")
-
-		mockKey.EXPECT().
-			GenerateSalt().
-			Return(generatedSalt, nil)
-
-		mockKey.EXPECT().
-			EncodeSalt(generatedSalt).
-			Return(encodedSalt)
-
-		mockService.EXPECT().
-			UserRegister(gomock.Any(), &models.UserRegReq{
-				Username: "newuser",
-				Password: "newpass",
-				Salt:     encodedSalt,
-			}).
-			Return(expectedUser, nil)
-
-		mockKey.EXPECT().
-			DeriveKeyFromPasswordAndSalt("newpass", generatedSalt).
-			Return(derivedKey)
-
-		mockCrypt.EXPECT().
-			SetKey(derivedKey).
-			Return(nil)
+		vaultKey := []byte("vaultKey")
+		recoveryKey := "AAAA-BBBB"
+		recoveryKEK := []byte("recoveryKEK")
+
+		gomock.InOrder(
+			mockKey.EXPECT().GenerateSalt().Return(generatedSalt, nil),
+			mockKey.EXPECT().EncodeSalt(generatedSalt).Return(encodedSalt),
+			mockKey.EXPECT().GenerateVaultKey().Return(vaultKey, nil),
+			mockKey.EXPECT().DeriveKeyFromPasswordAndSalt("newpass", generatedSalt).Return(derivedKey),
+			mockKey.EXPECT().WrapKey(derivedKey, vaultKey).Return("wrappedKey", nil),
+			mockKey.EXPECT().GenerateRecoveryKey().Return(recoveryKey, nil),
+			mockKey.EXPECT().DeriveRecoveryKeys(recoveryKey).Return(recoveryKEK, "recoveryAuth", nil),
+			mockKey.EXPECT().WrapKey(recoveryKEK, vaultKey).Return("recoveryWrappedKey", nil),
+			mockKey.EXPECT().NewKeyPair(vaultKey).Return(&testKeyPair, nil),
+			mockService.EXPECT().
+				UserRegister(gomock.Any(), &models.UserRegReq{
+					Username:     "newuser",
+					Password:     "newpass",
+					Salt:         encodedSalt,
+					EncryptedKey: "wrappedKey",
+					RecoveryKey:  "recoveryWrappedKey",
+					RecoveryAuth: "recoveryAuth",
+					KeyPair:      testKeyPair,
+				}).
+				Return(expectedUser, nil),
+			mockCrypt.EXPECT().SetKey(vaultKey).Return(nil),
+			mockVault.EXPECT().Open(gomock.Any(), expectedUser.ID).Return(nil),
+			mockKey.EXPECT().KeyCheck(vaultKey).Return("keyCheck", nil),
+			mockKey.EXPECT().KDFIterations().Return(600000),
+			mockService.EXPECT().
+				CacheAccount(gomock.Any(), &models.LocalAccount{
+					Username:      "newuser",
+					UserID:        expectedUser.ID,
+					Salt:          encodedSalt,
+					KDFIterations: 600000,
+					EncryptedKey:  "wrappedKey",
+					KeyCheck:      "keyCheck",
+				}).
+				Return(nil),
+		)
 
 		cmd := model.register()
-		msg := cmd().(AuthSuccessMsg)
-
+		msg := cmd().(RegisterSuccessMsg)
 		assert.Equal(t, expectedUser, msg.User)
+		assert.Equal(t, testKeyPair, msg.User.KeyPair)
+		assert.Equal(t, recoveryKey, msg.RecoveryKey)
 	})
 
 	t.Run("should return RegisterErrorMsg on failed salt generation", func(t *testing.T) {
@@ -374,7 +716,9 @@ func TestRegister(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.username = "newuser"
 		model.password = "newpass"
 
@@ -386,7 +730,34 @@ func TestRegister(t *testing.T) {
 
 		cmd := model.register()
 		msg := cmd().(RegisterErrorMsg)
+		assert.Equal(t, testErr, msg.Err)
+	})
+
+	t.Run("should return RegisterErrorMsg on failed recovery key generation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockKey := mocks.NewMockkeyProvider(ctrl)
+		mockService := mocks.NewMockauthService(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
+		model.password = "newpass"
+
+		testErr := errors.New("rand failed")
 
+		gomock.InOrder(
+			mockKey.EXPECT().GenerateSalt().Return([]byte("salt"), nil),
+			mockKey.EXPECT().EncodeSalt(gomock.Any()).Return("salt"),
+			mockKey.EXPECT().GenerateVaultKey().Return([]byte("vaultKey"), nil),
+			mockKey.EXPECT().DeriveKeyFromPasswordAndSalt(gomock.Any(), gomock.Any()).Return([]byte("key")),
+			mockKey.EXPECT().WrapKey(gomock.Any(), gomock.Any()).Return("wrappedKey", nil),
+			mockKey.EXPECT().GenerateRecoveryKey().Return("", testErr),
+		)
+
+		cmd := model.register()
+		msg := cmd().(RegisterErrorMsg)
 		assert.Equal(t, testErr, msg.Err)
 	})
 
@@ -397,41 +768,202 @@ func TestRegister(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.username = "newuser"
 		model.password = "newpass"
 
-		expectedUser := &models.User{ID: "user456"}
-		generatedSalt := []byte("generatedSalt")
-		encodedSalt := "encodedSalt"
-		derivedKey := []byte("derivedKey")
 		testErr := errors.New("set key error")
 
-		mockKey.EXPECT().
-			GenerateSalt().
-			Return(generatedSalt, nil)
+		gomock.InOrder(
+			mockKey.EXPECT().GenerateSalt().Return([]byte("salt"), nil),
+			mockKey.EXPECT().EncodeSalt(gomock.Any()).Return("salt"),
+			mockKey.EXPECT().GenerateVaultKey().Return([]byte("vaultKey"), nil),
+			mockKey.EXPECT().DeriveKeyFromPasswordAndSalt(gomock.Any(), gomock.Any()).Return([]byte("key")),
+			mockKey.EXPECT().WrapKey(gomock.Any(), gomock.Any()).Return("wrappedKey", nil),
+			mockKey.EXPECT().GenerateRecoveryKey().Return("AAAA", nil),
+			mockKey.EXPECT().DeriveRecoveryKeys("AAAA").Return([]byte("kek"), "auth", nil),
+			mockKey.EXPECT().WrapKey(gomock.Any(), gomock.Any()).Return("recoveryWrappedKey", nil),
+			mockKey.EXPECT().NewKeyPair([]byte("vaultKey")).Return(&testKeyPair, nil),
+			mockService.EXPECT().UserRegister(gomock.Any(), gomock.Any()).Return(&models.User{ID: "user456"}, nil),
+			mockCrypt.EXPECT().SetKey([]byte("vaultKey")).Return(testErr),
+		)
 
-		mockKey.EXPECT().
-			EncodeSalt(generatedSalt).
-			Return(encodedSalt)
+		cmd := model.register()
+		msg := cmd().(RegisterErrorMsg)
+		assert.Equal(t, testErr, msg.Err)
+	})
+}
 
-		mockService.EXPECT().
-			UserRegister(gomock.Any(), gomock.Any()).
-			Return(expectedUser, nil)
+func TestRecover(t *testing.T) {
+	t.Run("should rewrap vault key with new password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
 
-		mockKey.EXPECT().
-			DeriveKeyFromPasswordAndSalt("newpass", generatedSalt).
-			Return(derivedKey)
+		mockKey := mocks.NewMockkeyProvider(ctrl)
+		mockService := mocks.NewMockauthService(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
 
-		mockCrypt.EXPECT().
-			SetKey(derivedKey).
-			Return(testErr)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
+		model.username = "testuser"
+		model.recoveryKey = "AAAA-BBBB"
+		model.password = "newpass"
 
-		cmd := model.register()
-		msg := cmd().(RegisterErrorMsg)
+		recoveredUser := &models.User{ID: "user123", JWT: "jwt", Salt: "oldSalt", RecoveryKey: "recoveryWrappedKey", KeyPair: testKeyPair}
+		recoveryKEK := []byte("recoveryKEK")
+		vaultKey := []byte("vaultKey")
+		newSalt := []byte("newSalt")
+		derivedKey := []byte("derivedKey")
 
+		gomock.InOrder(
+			mockKey.EXPECT().DeriveRecoveryKeys("AAAA-BBBB").Return(recoveryKEK, "recoveryAuth", nil),
+			mockService.EXPECT().
+				UserRecover(gomock.Any(), &models.UserRecoverReq{
+					Username:     "testuser",
+					RecoveryAuth: "recoveryAuth",
+				}).
+				Return(recoveredUser, nil),
+			mockKey.EXPECT().UnwrapKey(recoveryKEK, "recoveryWrappedKey").Return(vaultKey, nil),
+			mockKey.EXPECT().GenerateSalt().Return(newSalt, nil),
+			mockKey.EXPECT().DeriveKeyFromPasswordAndSalt("newpass", newSalt).Return(derivedKey),
+			mockKey.EXPECT().WrapKey(derivedKey, vaultKey).Return("wrappedKey", nil),
+			mockKey.EXPECT().EncodeSalt(newSalt).Return("encodedNewSalt"),
+			mockService.EXPECT().
+				UserChangePassword(gomock.Any(), &models.PasswordChangeReq{
+					Password:     "newpass",
+					Salt:         "encodedNewSalt",
+					EncryptedKey: "wrappedKey",
+					RecoveryAuth: "recoveryAuth",
+				}, recoveredUser).
+				Return(nil),
+			mockCrypt.EXPECT().SetKey(vaultKey).Return(nil),
+			mockVault.EXPECT().Open(gomock.Any(), recoveredUser.ID).Return(nil),
+			mockKey.EXPECT().KeyCheck(vaultKey).Return("keyCheck", nil),
+			mockKey.EXPECT().KDFIterations().Return(600000),
+			mockService.EXPECT().CacheAccount(gomock.Any(), gomock.Any()).Return(nil),
+		)
+
+		cmd := model.recover()
+		msg := cmd().(AuthSuccessMsg)
+		assert.Equal(t, "encodedNewSalt", msg.User.Salt)
+		assert.Equal(t, "wrappedKey", msg.User.EncryptedKey)
+		assert.Empty(t, msg.User.RecoveryKey)
+	})
+
+	t.Run("should return RecoverErrorMsg on malformed recovery key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockKey := mocks.NewMockkeyProvider(ctrl)
+		mockService := mocks.NewMockauthService(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
+		model.recoveryKey = "bad"
+
+		testErr := errors.New("invalid recovery key")
+		mockKey.EXPECT().DeriveRecoveryKeys("bad").Return(nil, "", testErr)
+
+		cmd := model.recover()
+		msg := cmd().(RecoverErrorMsg)
 		assert.Equal(t, testErr, msg.Err)
 	})
+
+	t.Run("should return RecoverErrorMsg when server rejects recovery", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockKey := mocks.NewMockkeyProvider(ctrl)
+		mockService := mocks.NewMockauthService(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
+		model.recoveryKey = "AAAA"
+
+		testErr := errors.New("wrong recovery key")
+
+		gomock.InOrder(
+			mockKey.EXPECT().DeriveRecoveryKeys("AAAA").Return([]byte("kek"), "auth", nil),
+			mockService.EXPECT().UserRecover(gomock.Any(), gomock.Any()).Return(nil, testErr),
+		)
+
+		cmd := model.recover()
+		msg := cmd().(RecoverErrorMsg)
+		assert.Equal(t, testErr, msg.Err)
+	})
+}
+
+func TestRecoveryInput(t *testing.T) {
+	t.Run("should switch to recovery state on Ctrl+R", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockauthService(ctrl), mocks.NewMockkeyProvider(ctrl), mocks.NewMockcrypter(ctrl), mocks.NewMockvaultOpener(ctrl), time.Second)
+
+		newModel, _ := handleAuthInput(model, tea.KeyMsg{Type: tea.KeyCtrlR})
+		assert.Equal(t, RecoveryState, newModel.state)
+
+		newModel, _ = handleAuthInput(newModel, tea.KeyMsg{Type: tea.KeyTab})
+		assert.Equal(t, LoginState, newModel.state)
+	})
+
+	t.Run("should cycle through three fields in recovery state", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockauthService(ctrl), mocks.NewMockkeyProvider(ctrl), mocks.NewMockcrypter(ctrl), mocks.NewMockvaultOpener(ctrl), time.Second)
+		model.state = RecoveryState
+
+		newModel, _ := handleAuthInput(model, tea.KeyMsg{Type: tea.KeyDown})
+		assert.Equal(t, RecoveryKeyField, newModel.activeField)
+
+		newModel, _ = handleAuthInput(newModel, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("AB-CD")})
+		assert.Equal(t, "AB-CD", newModel.recoveryKey)
+
+		newModel, _ = handleAuthInput(newModel, tea.KeyMsg{Type: tea.KeyDown})
+		assert.Equal(t, PasswordField, newModel.activeField)
+
+		newModel, _ = handleAuthInput(newModel, tea.KeyMsg{Type: tea.KeyDown})
+		assert.Equal(t, UsernameField, newModel.activeField)
+
+		newModel, _ = handleAuthInput(newModel, tea.KeyMsg{Type: tea.KeyUp})
+		assert.Equal(t, PasswordField, newModel.activeField)
+	})
+
+	t.Run("should start recovery on Enter in recovery state", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockauthService(ctrl), mocks.NewMockkeyProvider(ctrl), mocks.NewMockcrypter(ctrl), mocks.NewMockvaultOpener(ctrl), time.Second)
+		model.state = RecoveryState
+
+		newModel, cmd := handleAuthInput(model, tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Equal(t, ProcessingState, newModel.state)
+		assert.NotNil(t, cmd)
+	})
+}
+
+func TestHandleRecoveryKeyState(t *testing.T) {
+	t.Run("should emit AuthSuccessMsg and forget key on Enter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockauthService(ctrl), mocks.NewMockkeyProvider(ctrl), mocks.NewMockcrypter(ctrl), mocks.NewMockvaultOpener(ctrl), time.Second)
+		user := &models.User{ID: "user456"}
+
+		model, _ = handleProcessingState(model, RegisterSuccessMsg{User: user, RecoveryKey: "AAAA-BBBB"})
+		assert.Equal(t, RecoveryKeyState, model.state)
+		assert.Contains(t, model.View(), "AAAA-BBBB")
+
+		newModel, cmd := handleRecoveryKeyState(model, tea.KeyMsg{Type: tea.KeyEnter})
+		require.NotNil(t, cmd)
+		assert.Equal(t, AuthSuccessMsg{user}, cmd())
+		assert.Empty(t, newModel.recoveryKey)
+	})
 }
 
 func TestHandleProcessingState(t *testing.T) {
@@ -442,7 +974,8 @@ func TestHandleProcessingState(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.state = ProcessingState
 
 		testErr := errors.New("login error")
@@ -452,6 +985,27 @@ func TestHandleProcessingState(t *testing.T) {
 		assert.Equal(t, testErr.Error(), newModel.errMsg)
 	})
 
+	t.Run("should show localized message of rejected credentials", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockKey := mocks.NewMockkeyProvider(ctrl)
+		mockService := mocks.NewMockauthService(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
+		model.state = ProcessingState
+
+		testErr := &models.ServerError{
+			Reason: models.ReasonInvalidCredentials,
+			Err:    errors.New("rpc error: code = Unauthenticated desc = invalid username or password"),
+		}
+		newModel, _ := handleProcessingState(model, LoginErrorMsg{testErr})
+
+		assert.Equal(t, ErrorState, newModel.state)
+		assert.Equal(t, i18n.ErrInvalidCredentials, newModel.errMsg)
+	})
+
 	t.Run("should transition to ErrorState on RegisterErrorMsg", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -459,7 +1013,8 @@ func TestHandleProcessingState(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.state = ProcessingState
 
 		testErr := errors.New("register error")
@@ -469,6 +1024,64 @@ func TestHandleProcessingState(t *testing.T) {
 		assert.Equal(t, testErr.Error(), newModel.errMsg)
 	})
 
+	t.Run("should show field violations on registration form", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockKey := mocks.NewMockkeyProvider(ctrl)
+		mockService := mocks.NewMockauthService(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
+		model.state = ProcessingState
+		model.activeField = UsernameField
+
+		verr := &models.ValidationError{Violations: []models.FieldViolation{
+			{Field: models.FieldPassword, Reason: models.ReasonPasswordWeak},
+			{Field: models.FieldPassword, Reason: "UNKNOWN", Description: "unknown"},
+		}}
+		newModel, _ := handleProcessingState(model, RegisterErrorMsg{verr})
+
+		assert.Equal(t, RegisterState, newModel.state)
+		assert.Equal(t, PasswordField, newModel.activeField)
+		assert.Equal(t, map[field]string{PasswordField: i18n.AuthPasswordWeak}, newModel.fieldErrs)
+		assert.Contains(t, newModel.View(), i18n.AuthPasswordWeak)
+
+		updated, _ := newModel.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+		assert.Empty(t, updated.(Model).fieldErrs)
+	})
+
+	t.Run("should return to recovery form on rejected new password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockKey := mocks.NewMockkeyProvider(ctrl)
+		mockService := mocks.NewMockauthService(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
+		model.state = ProcessingState
+		model.activeField = UsernameField
+
+		verr := &models.ValidationError{Violations: []models.FieldViolation{
+			{Field: models.FieldPassword, Reason: models.ReasonPasswordLength},
+		}}
+		newModel, _ := handleProcessingState(model, RecoverErrorMsg{verr})
+
+		assert.Equal(t, RecoveryState, newModel.state)
+		assert.Equal(t, PasswordField, newModel.activeField)
+		assert.Contains(t, newModel.View(), i18n.AuthPasswordLength)
+	})
+
+	t.Run("should fall back to violation description", func(t *testing.T) {
+		msgs := violationMessages(&models.ValidationError{Violations: []models.FieldViolation{
+			{Field: models.FieldUsername, Reason: "UNKNOWN", Description: "unknown"},
+		}})
+
+		assert.Equal(t, map[field]string{UsernameField: "unknown"}, msgs)
+		assert.Equal(t, UsernameField, firstRejectedField(msgs))
+	})
+
 	t.Run("should return AuthSuccessMsg unchanged", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -476,7 +1089,8 @@ func TestHandleProcessingState(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.state = ProcessingState
 
 		user := &models.User{ID: "testuser"}
@@ -495,7 +1109,8 @@ func TestHandleErrorState(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.state = ErrorState
 
 		newModel, _ := handleErrorState(model, tea.KeyMsg{Type: tea.KeyEnter})
@@ -509,7 +1124,8 @@ func TestHandleErrorState(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.state = ErrorState
 
 		newModel, _ := handleErrorState(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
@@ -525,7 +1141,8 @@ func TestView(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.state = ProcessingState
 
 		view := model.View()
@@ -539,7 +1156,8 @@ func TestView(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.state = ErrorState
 		model.errMsg = "test error"
 
@@ -554,7 +1172,8 @@ func TestView(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.state = LoginState
 		model.username = "user"
 		model.password = "pass"
@@ -572,7 +1191,8 @@ func TestView(t *testing.T) {
 		mockKey := mocks.NewMockkeyProvider(ctrl)
 		mockService := mocks.NewMockauthService(ctrl)
 		mockCrypt := mocks.NewMockcrypter(ctrl)
-		model := InitialModel(mockService, mockKey, mockCrypt, time.Second)
+		mockVault := mocks.NewMockvaultOpener(ctrl)
+		model := InitialModel(mockService, mockKey, mockCrypt, mockVault, time.Second)
 		model.state = RegisterState
 		model.username = "newuser"
 		model.password = "newpass"
@@ -582,4 +1202,20 @@ func TestView(t *testing.T) {
 		assert.Contains(t, view, "newuser")
 		assert.Contains(t, view, "••••••")
 	})
+
+	t.Run("should render recovery form in RecoveryState", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockauthService(ctrl), mocks.NewMockkeyProvider(ctrl), mocks.NewMockcrypter(ctrl), mocks.NewMockvaultOpener(ctrl), time.Second)
+		model.state = RecoveryState
+		model.username = "user"
+		model.recoveryKey = "AAAA-BBBB"
+		model.password = "pass"
+
+		view := model.View()
+		assert.Contains(t, view, i18n.AuthRecoveryTitle)
+		assert.Contains(t, view, "AAAA-BBBB")
+		assert.Contains(t, view, "••••")
+	})
 }
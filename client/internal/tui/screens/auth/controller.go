@@ -2,8 +2,12 @@ package auth
 
 import (
 	"context"
+	"errors"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rycln/gokeep/client/internal/services"
+	"github.com/rycln/gokeep/client/internal/tui/shared/i18n"
+	"github.com/rycln/gokeep/client/internal/tui/shared/messages"
 	"github.com/rycln/gokeep/shared/models"
 )
 
@@ -15,17 +19,19 @@ func (m Model) Init() tea.Cmd {
 // Update handles all messages and state transitions for authentication
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m.state {
-	case LoginState, RegisterState:
+	case LoginState, RegisterState, RecoveryState:
 		return handleAuthInput(m, msg)
 	case ProcessingState:
 		return handleProcessingState(m, msg)
 	case ErrorState:
 		return handleErrorState(m, msg)
+	case RecoveryKeyState:
+		return handleRecoveryKeyState(m, msg)
 	}
 	return m, nil
 }
 
-// handleAuthInput processes user input in login/register states
+// handleAuthInput processes user input in login/register/recovery states
 func handleAuthInput(m Model, msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -33,10 +39,15 @@ func handleAuthInput(m Model, msg tea.Msg) (Model, tea.Cmd) {
 		case tea.KeyCtrlC:
 			return m, tea.Quit
 		case tea.KeyEnter:
-			if m.state == LoginState {
+			m.fieldErrs = nil
+			switch m.state {
+			case LoginState:
 				m.state = ProcessingState
 				return m, m.login()
-			} else {
+			case RecoveryState:
+				m.state = ProcessingState
+				return m, m.recover()
+			default:
 				m.state = ProcessingState
 				return m, m.register()
 			}
@@ -46,39 +57,68 @@ func handleAuthInput(m Model, msg tea.Msg) (Model, tea.Cmd) {
 			} else {
 				m.state = LoginState
 			}
+			m.activeField = UsernameField
+			m.fieldErrs = nil
 			return m, nil
-		case tea.KeyDown, tea.KeyUp:
-			if m.activeField == UsernameField {
-				m.activeField = PasswordField
-			} else {
-				m.activeField = UsernameField
-			}
+		case tea.KeyCtrlR:
+			m.state = RecoveryState
+			m.activeField = UsernameField
+			m.fieldErrs = nil
+			return m, nil
+		case tea.KeyDown:
+			m.activeField = m.nextField(1)
+		case tea.KeyUp:
+			m.activeField = m.nextField(-1)
 		case tea.KeyRunes:
 			if msg.String() == " " {
 				return m, nil
 			}
-			if m.activeField == UsernameField {
-				m.username += msg.String()
-			} else {
-				m.password += msg.String()
-			}
+			value := m.fieldValue()
+			*value += msg.String()
+			delete(m.fieldErrs, m.activeField)
 		case tea.KeyBackspace:
-			if m.activeField == UsernameField && len(m.username) > 0 {
-				runes := []rune(m.username)
-				if len(runes) > 0 {
-					m.username = string(runes[:len(runes)-1])
-				}
-			} else if len(m.password) > 0 {
-				runes := []rune(m.password)
-				if len(runes) > 0 {
-					m.password = string(runes[:len(runes)-1])
-				}
+			value := m.fieldValue()
+			runes := []rune(*value)
+			if len(runes) > 0 {
+				*value = string(runes[:len(runes)-1])
 			}
+			delete(m.fieldErrs, m.activeField)
 		}
 	}
 	return m, nil
 }
 
+// fields returns input fields available in the current state
+func (m Model) fields() []field {
+	if m.state == RecoveryState {
+		return []field{UsernameField, RecoveryKeyField, PasswordField}
+	}
+	return []field{UsernameField, PasswordField}
+}
+
+// nextField returns field shifted by step from the active one
+func (m Model) nextField(step int) field {
+	fields := m.fields()
+	for i, f := range fields {
+		if f == m.activeField {
+			return fields[(i+step+len(fields))%len(fields)]
+		}
+	}
+	return UsernameField
+}
+
+// fieldValue returns pointer to the value of the active field
+func (m *Model) fieldValue() *string {
+	switch m.activeField {
+	case PasswordField:
+		return &m.password
+	case RecoveryKeyField:
+		return &m.recoveryKey
+	default:
+		return &m.username
+	}
+}
+
 // login initiates user authentication
 func (m Model) login() tea.Cmd {
 	return func() tea.Msg {
@@ -89,6 +129,9 @@ func (m Model) login() tea.Cmd {
 			Username: m.username,
 			Password: m.password,
 		})
+		if errors.Is(err, services.ErrOffline) {
+			return m.offlineLogin(err)
+		}
 		if err != nil {
 			return LoginErrorMsg{err}
 		}
@@ -100,15 +143,127 @@ func (m Model) login() tea.Cmd {
 
 		key := m.key.DeriveKeyFromPasswordAndSalt(m.password, decSalt)
 
+		// Accounts registered before the key hierarchy use the derived key directly
+		if user.EncryptedKey != "" {
+			key, err = m.key.UnwrapKey(key, user.EncryptedKey)
+			if err != nil {
+				return LoginErrorMsg{err}
+			}
+		}
+
 		err = m.crypt.SetKey(key)
 		if err != nil {
 			return LoginErrorMsg{err}
 		}
 
+		err = m.vault.Open(ctx, user.ID)
+		if err != nil {
+			return LoginErrorMsg{err}
+		}
+
+		err = m.cacheAccount(ctx, user.ID, user.Salt, user.EncryptedKey, key)
+		if err != nil {
+			return LoginErrorMsg{err}
+		}
+
+		err = m.ensureKeyPair(ctx, user, key)
+		if err != nil {
+			return LoginErrorMsg{err}
+		}
+
 		return AuthSuccessMsg{user}
 	}
 }
 
+// offlineLogin unlocks local vault with cached credentials when server is unreachable
+// Returns loginErr if nothing is cached for the username
+func (m Model) offlineLogin(loginErr error) tea.Msg {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	account, err := m.service.CachedAccount(ctx, m.username)
+	if err != nil {
+		return LoginErrorMsg{loginErr}
+	}
+
+	if account.KDFIterations != m.key.KDFIterations() {
+		return LoginErrorMsg{errKDFChanged}
+	}
+
+	decSalt, err := m.key.DecodeSalt(account.Salt)
+	if err != nil {
+		return LoginErrorMsg{err}
+	}
+
+	key := m.key.DeriveKeyFromPasswordAndSalt(m.password, decSalt)
+
+	if account.EncryptedKey != "" {
+		key, err = m.key.UnwrapKey(key, account.EncryptedKey)
+		if err != nil {
+			return LoginErrorMsg{errWrongPassword}
+		}
+	}
+
+	err = m.key.VerifyKeyCheck(key, account.KeyCheck)
+	if err != nil {
+		return LoginErrorMsg{errWrongPassword}
+	}
+
+	err = m.crypt.SetKey(key)
+	if err != nil {
+		return LoginErrorMsg{err}
+	}
+
+	err = m.vault.Open(ctx, account.UserID)
+	if err != nil {
+		return LoginErrorMsg{err}
+	}
+
+	return AuthSuccessMsg{&models.User{
+		ID:           account.UserID,
+		Salt:         account.Salt,
+		EncryptedKey: account.EncryptedKey,
+		Offline:      true,
+	}}
+}
+
+// cacheAccount stores credentials needed for the next offline unlock
+func (m Model) cacheAccount(ctx context.Context, uid models.UserID, salt, encKey string, vaultKey []byte) error {
+	check, err := m.key.KeyCheck(vaultKey)
+	if err != nil {
+		return err
+	}
+
+	return m.service.CacheAccount(ctx, &models.LocalAccount{
+		Username:      m.username,
+		UserID:        uid,
+		Salt:          salt,
+		KDFIterations: m.key.KDFIterations(),
+		EncryptedKey:  encKey,
+		KeyCheck:      check,
+	})
+}
+
+// ensureKeyPair uploads sharing keypair for accounts created before sharing
+func (m Model) ensureKeyPair(ctx context.Context, user *models.User, vaultKey []byte) error {
+	if len(user.PublicKey) != 0 {
+		return nil
+	}
+
+	kp, err := m.key.NewKeyPair(vaultKey)
+	if err != nil {
+		return err
+	}
+
+	err = m.service.UserSetKeyPair(ctx, kp, user)
+	if err != nil {
+		return err
+	}
+
+	user.KeyPair = *kp
+	return nil
+}
+
 // register initiates new user registration
 func (m Model) register() tea.Cmd {
 	return func() tea.Msg {
@@ -122,22 +277,141 @@ func (m Model) register() tea.Cmd {
 
 		encSalt := m.key.EncodeSalt(salt)
 
+		vaultKey, err := m.key.GenerateVaultKey()
+		if err != nil {
+			return RegisterErrorMsg{err}
+		}
+
+		kek := m.key.DeriveKeyFromPasswordAndSalt(m.password, salt)
+		encKey, err := m.key.WrapKey(kek, vaultKey)
+		if err != nil {
+			return RegisterErrorMsg{err}
+		}
+
+		recoveryKey, err := m.key.GenerateRecoveryKey()
+		if err != nil {
+			return RegisterErrorMsg{err}
+		}
+
+		recoveryKEK, recoveryAuth, err := m.key.DeriveRecoveryKeys(recoveryKey)
+		if err != nil {
+			return RegisterErrorMsg{err}
+		}
+
+		recoveryEncKey, err := m.key.WrapKey(recoveryKEK, vaultKey)
+		if err != nil {
+			return RegisterErrorMsg{err}
+		}
+
+		kp, err := m.key.NewKeyPair(vaultKey)
+		if err != nil {
+			return RegisterErrorMsg{err}
+		}
+
 		user, err := m.service.UserRegister(ctx, &models.UserRegReq{
-			Username: m.username,
-			Password: m.password,
-			Salt:     encSalt,
+			Username:     m.username,
+			Password:     m.password,
+			Salt:         encSalt,
+			EncryptedKey: encKey,
+			RecoveryKey:  recoveryEncKey,
+			RecoveryAuth: recoveryAuth,
+			KeyPair:      *kp,
 		})
 		if err != nil {
 			return RegisterErrorMsg{err}
 		}
+		user.KeyPair = *kp
+
+		err = m.crypt.SetKey(vaultKey)
+		if err != nil {
+			return RegisterErrorMsg{err}
+		}
 
-		key := m.key.DeriveKeyFromPasswordAndSalt(m.password, salt)
+		err = m.vault.Open(ctx, user.ID)
+		if err != nil {
+			return RegisterErrorMsg{err}
+		}
 
-		err = m.crypt.SetKey(key)
+		err = m.cacheAccount(ctx, user.ID, encSalt, encKey, vaultKey)
 		if err != nil {
 			return RegisterErrorMsg{err}
 		}
 
+		return RegisterSuccessMsg{User: user, RecoveryKey: recoveryKey}
+	}
+}
+
+// recover restores access with the recovery key and sets a new password
+func (m Model) recover() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		recoveryKEK, recoveryAuth, err := m.key.DeriveRecoveryKeys(m.recoveryKey)
+		if err != nil {
+			return RecoverErrorMsg{err}
+		}
+
+		user, err := m.service.UserRecover(ctx, &models.UserRecoverReq{
+			Username:     m.username,
+			RecoveryAuth: recoveryAuth,
+		})
+		if err != nil {
+			return RecoverErrorMsg{err}
+		}
+
+		vaultKey, err := m.key.UnwrapKey(recoveryKEK, user.RecoveryKey)
+		if err != nil {
+			return RecoverErrorMsg{err}
+		}
+
+		salt, err := m.key.GenerateSalt()
+		if err != nil {
+			return RecoverErrorMsg{err}
+		}
+
+		kek := m.key.DeriveKeyFromPasswordAndSalt(m.password, salt)
+		encKey, err := m.key.WrapKey(kek, vaultKey)
+		if err != nil {
+			return RecoverErrorMsg{err}
+		}
+
+		req := &models.PasswordChangeReq{
+			Password:     m.password,
+			Salt:         m.key.EncodeSalt(salt),
+			EncryptedKey: encKey,
+			RecoveryAuth: recoveryAuth,
+		}
+
+		err = m.service.UserChangePassword(ctx, req, user)
+		if err != nil {
+			return RecoverErrorMsg{err}
+		}
+
+		err = m.crypt.SetKey(vaultKey)
+		if err != nil {
+			return RecoverErrorMsg{err}
+		}
+
+		err = m.vault.Open(ctx, user.ID)
+		if err != nil {
+			return RecoverErrorMsg{err}
+		}
+
+		err = m.cacheAccount(ctx, user.ID, req.Salt, req.EncryptedKey, vaultKey)
+		if err != nil {
+			return RecoverErrorMsg{err}
+		}
+
+		err = m.ensureKeyPair(ctx, user, vaultKey)
+		if err != nil {
+			return RecoverErrorMsg{err}
+		}
+
+		user.Salt = req.Salt
+		user.EncryptedKey = req.EncryptedKey
+		user.RecoveryKey = ""
+
 		return AuthSuccessMsg{user}
 	}
 }
@@ -146,17 +420,99 @@ func (m Model) register() tea.Cmd {
 func handleProcessingState(m Model, msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case LoginErrorMsg:
-		m.errMsg = msg.Err.Error()
+		m.errMsg = messages.ErrorText(msg.Err)
 		m.state = ErrorState
 	case RegisterErrorMsg:
-		m.errMsg = msg.Err.Error()
+		var verr *models.ValidationError
+		if errors.As(msg.Err, &verr) {
+			m.fieldErrs = violationMessages(verr)
+			m.activeField = firstRejectedField(m.fieldErrs)
+			m.state = RegisterState
+			return m, nil
+		}
+		m.errMsg = messages.ErrorText(msg.Err)
 		m.state = ErrorState
+	case RecoverErrorMsg:
+		// The new password is checked by the server after the recovery key
+		var verr *models.ValidationError
+		if errors.As(msg.Err, &verr) {
+			m.fieldErrs = violationMessages(verr)
+			m.activeField = firstRejectedField(m.fieldErrs)
+			m.state = RecoveryState
+			return m, nil
+		}
+		m.errMsg = messages.ErrorText(msg.Err)
+		m.state = ErrorState
+	case RegisterSuccessMsg:
+		m.user = msg.User
+		m.recoveryKey = msg.RecoveryKey
+		m.state = RecoveryKeyState
 	case AuthSuccessMsg:
 		return m, func() tea.Msg { return msg }
 	}
 	return m, nil
 }
 
+// violationMessages maps server field violations to localized form messages
+func violationMessages(verr *models.ValidationError) map[field]string {
+	msgs := make(map[field]string)
+	for _, v := range verr.Violations {
+		f := UsernameField
+		if v.Field == models.FieldPassword {
+			f = PasswordField
+		}
+		if _, ok := msgs[f]; ok {
+			continue
+		}
+		msgs[f] = violationMessage(v)
+	}
+	return msgs
+}
+
+// violationMessage returns localized text for the violation reason
+// Unknown reasons fall back to the server description
+func violationMessage(v models.FieldViolation) string {
+	switch v.Reason {
+	case models.ReasonUsernameLength:
+		return i18n.AuthUsernameLength
+	case models.ReasonUsernameCharset:
+		return i18n.AuthUsernameCharset
+	case models.ReasonUsernameTaken:
+		return i18n.AuthUsernameTaken
+	case models.ReasonPasswordLength:
+		return i18n.AuthPasswordLength
+	case models.ReasonPasswordWeak:
+		return i18n.AuthPasswordWeak
+	default:
+		return v.Description
+	}
+}
+
+// firstRejectedField returns the topmost form field with an error
+func firstRejectedField(msgs map[field]string) field {
+	if _, ok := msgs[UsernameField]; ok {
+		return UsernameField
+	}
+	return PasswordField
+}
+
+// handleRecoveryKeyState waits for the user to confirm the recovery key is saved
+func handleRecoveryKeyState(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+		case tea.KeyEnter:
+			user := m.user
+			m.user = nil
+			m.recoveryKey = ""
+			return m, func() tea.Msg { return AuthSuccessMsg{user} }
+		}
+	}
+	return m, nil
+}
+
 // handleErrorState manages error display and recovery
 func handleErrorState(m Model, msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -164,6 +520,7 @@ func handleErrorState(m Model, msg tea.Msg) (Model, tea.Cmd) {
 		switch msg.Type {
 		case tea.KeyEnter:
 			m.state = LoginState
+			m.activeField = UsernameField
 		}
 	}
 	return m, nil
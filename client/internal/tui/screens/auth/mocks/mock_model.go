@@ -35,6 +35,49 @@ func (m *MockauthService) EXPECT() *MockauthServiceMockRecorder {
 	return m.recorder
 }
 
+// CacheAccount mocks base method.
+func (m *MockauthService) CacheAccount(arg0 context.Context, arg1 *models.LocalAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CacheAccount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CacheAccount indicates an expected call of CacheAccount.
+func (mr *MockauthServiceMockRecorder) CacheAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheAccount", reflect.TypeOf((*MockauthService)(nil).CacheAccount), arg0, arg1)
+}
+
+// CachedAccount mocks base method.
+func (m *MockauthService) CachedAccount(arg0 context.Context, arg1 string) (*models.LocalAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CachedAccount", arg0, arg1)
+	ret0, _ := ret[0].(*models.LocalAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CachedAccount indicates an expected call of CachedAccount.
+func (mr *MockauthServiceMockRecorder) CachedAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CachedAccount", reflect.TypeOf((*MockauthService)(nil).CachedAccount), arg0, arg1)
+}
+
+// UserChangePassword mocks base method.
+func (m *MockauthService) UserChangePassword(arg0 context.Context, arg1 *models.PasswordChangeReq, arg2 *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserChangePassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserChangePassword indicates an expected call of UserChangePassword.
+func (mr *MockauthServiceMockRecorder) UserChangePassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserChangePassword", reflect.TypeOf((*MockauthService)(nil).UserChangePassword), arg0, arg1, arg2)
+}
+
 // UserLogin mocks base method.
 func (m *MockauthService) UserLogin(arg0 context.Context, arg1 *models.UserLoginReq) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +93,21 @@ func (mr *MockauthServiceMockRecorder) UserLogin(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserLogin", reflect.TypeOf((*MockauthService)(nil).UserLogin), arg0, arg1)
 }
 
+// UserRecover mocks base method.
+func (m *MockauthService) UserRecover(arg0 context.Context, arg1 *models.UserRecoverReq) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserRecover", arg0, arg1)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserRecover indicates an expected call of UserRecover.
+func (mr *MockauthServiceMockRecorder) UserRecover(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserRecover", reflect.TypeOf((*MockauthService)(nil).UserRecover), arg0, arg1)
+}
+
 // UserRegister mocks base method.
 func (m *MockauthService) UserRegister(arg0 context.Context, arg1 *models.UserRegReq) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +123,20 @@ func (mr *MockauthServiceMockRecorder) UserRegister(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserRegister", reflect.TypeOf((*MockauthService)(nil).UserRegister), arg0, arg1)
 }
 
+// UserSetKeyPair mocks base method.
+func (m *MockauthService) UserSetKeyPair(arg0 context.Context, arg1 *models.KeyPair, arg2 *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserSetKeyPair", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserSetKeyPair indicates an expected call of UserSetKeyPair.
+func (mr *MockauthServiceMockRecorder) UserSetKeyPair(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSetKeyPair", reflect.TypeOf((*MockauthService)(nil).UserSetKeyPair), arg0, arg1, arg2)
+}
+
 // MocksaltGenerator is a mock of saltGenerator interface.
 type MocksaltGenerator struct {
 	ctrl     *gomock.Controller
@@ -192,6 +264,232 @@ func (mr *MockkeyDeriverMockRecorder) DeriveKeyFromPasswordAndSalt(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeriveKeyFromPasswordAndSalt", reflect.TypeOf((*MockkeyDeriver)(nil).DeriveKeyFromPasswordAndSalt), arg0, arg1)
 }
 
+// MockkeyWrapper is a mock of keyWrapper interface.
+type MockkeyWrapper struct {
+	ctrl     *gomock.Controller
+	recorder *MockkeyWrapperMockRecorder
+}
+
+// MockkeyWrapperMockRecorder is the mock recorder for MockkeyWrapper.
+type MockkeyWrapperMockRecorder struct {
+	mock *MockkeyWrapper
+}
+
+// NewMockkeyWrapper creates a new mock instance.
+func NewMockkeyWrapper(ctrl *gomock.Controller) *MockkeyWrapper {
+	mock := &MockkeyWrapper{ctrl: ctrl}
+	mock.recorder = &MockkeyWrapperMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockkeyWrapper) EXPECT() *MockkeyWrapperMockRecorder {
+	return m.recorder
+}
+
+// GenerateVaultKey mocks base method.
+func (m *MockkeyWrapper) GenerateVaultKey() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateVaultKey")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateVaultKey indicates an expected call of GenerateVaultKey.
+func (mr *MockkeyWrapperMockRecorder) GenerateVaultKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateVaultKey", reflect.TypeOf((*MockkeyWrapper)(nil).GenerateVaultKey))
+}
+
+// UnwrapKey mocks base method.
+func (m *MockkeyWrapper) UnwrapKey(arg0 []byte, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnwrapKey", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnwrapKey indicates an expected call of UnwrapKey.
+func (mr *MockkeyWrapperMockRecorder) UnwrapKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnwrapKey", reflect.TypeOf((*MockkeyWrapper)(nil).UnwrapKey), arg0, arg1)
+}
+
+// WrapKey mocks base method.
+func (m *MockkeyWrapper) WrapKey(arg0, arg1 []byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WrapKey", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WrapKey indicates an expected call of WrapKey.
+func (mr *MockkeyWrapperMockRecorder) WrapKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WrapKey", reflect.TypeOf((*MockkeyWrapper)(nil).WrapKey), arg0, arg1)
+}
+
+// MockrecoveryKeyProvider is a mock of recoveryKeyProvider interface.
+type MockrecoveryKeyProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockrecoveryKeyProviderMockRecorder
+}
+
+// MockrecoveryKeyProviderMockRecorder is the mock recorder for MockrecoveryKeyProvider.
+type MockrecoveryKeyProviderMockRecorder struct {
+	mock *MockrecoveryKeyProvider
+}
+
+// NewMockrecoveryKeyProvider creates a new mock instance.
+func NewMockrecoveryKeyProvider(ctrl *gomock.Controller) *MockrecoveryKeyProvider {
+	mock := &MockrecoveryKeyProvider{ctrl: ctrl}
+	mock.recorder = &MockrecoveryKeyProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrecoveryKeyProvider) EXPECT() *MockrecoveryKeyProviderMockRecorder {
+	return m.recorder
+}
+
+// DeriveRecoveryKeys mocks base method.
+func (m *MockrecoveryKeyProvider) DeriveRecoveryKeys(arg0 string) ([]byte, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeriveRecoveryKeys", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeriveRecoveryKeys indicates an expected call of DeriveRecoveryKeys.
+func (mr *MockrecoveryKeyProviderMockRecorder) DeriveRecoveryKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeriveRecoveryKeys", reflect.TypeOf((*MockrecoveryKeyProvider)(nil).DeriveRecoveryKeys), arg0)
+}
+
+// GenerateRecoveryKey mocks base method.
+func (m *MockrecoveryKeyProvider) GenerateRecoveryKey() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateRecoveryKey")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateRecoveryKey indicates an expected call of GenerateRecoveryKey.
+func (mr *MockrecoveryKeyProviderMockRecorder) GenerateRecoveryKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRecoveryKey", reflect.TypeOf((*MockrecoveryKeyProvider)(nil).GenerateRecoveryKey))
+}
+
+// MockkeyChecker is a mock of keyChecker interface.
+type MockkeyChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockkeyCheckerMockRecorder
+}
+
+// MockkeyCheckerMockRecorder is the mock recorder for MockkeyChecker.
+type MockkeyCheckerMockRecorder struct {
+	mock *MockkeyChecker
+}
+
+// NewMockkeyChecker creates a new mock instance.
+func NewMockkeyChecker(ctrl *gomock.Controller) *MockkeyChecker {
+	mock := &MockkeyChecker{ctrl: ctrl}
+	mock.recorder = &MockkeyCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockkeyChecker) EXPECT() *MockkeyCheckerMockRecorder {
+	return m.recorder
+}
+
+// KDFIterations mocks base method.
+func (m *MockkeyChecker) KDFIterations() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KDFIterations")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// KDFIterations indicates an expected call of KDFIterations.
+func (mr *MockkeyCheckerMockRecorder) KDFIterations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KDFIterations", reflect.TypeOf((*MockkeyChecker)(nil).KDFIterations))
+}
+
+// KeyCheck mocks base method.
+func (m *MockkeyChecker) KeyCheck(arg0 []byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeyCheck", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KeyCheck indicates an expected call of KeyCheck.
+func (mr *MockkeyCheckerMockRecorder) KeyCheck(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeyCheck", reflect.TypeOf((*MockkeyChecker)(nil).KeyCheck), arg0)
+}
+
+// VerifyKeyCheck mocks base method.
+func (m *MockkeyChecker) VerifyKeyCheck(arg0 []byte, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyKeyCheck", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyKeyCheck indicates an expected call of VerifyKeyCheck.
+func (mr *MockkeyCheckerMockRecorder) VerifyKeyCheck(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyKeyCheck", reflect.TypeOf((*MockkeyChecker)(nil).VerifyKeyCheck), arg0, arg1)
+}
+
+// MockkeyPairGenerator is a mock of keyPairGenerator interface.
+type MockkeyPairGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockkeyPairGeneratorMockRecorder
+}
+
+// MockkeyPairGeneratorMockRecorder is the mock recorder for MockkeyPairGenerator.
+type MockkeyPairGeneratorMockRecorder struct {
+	mock *MockkeyPairGenerator
+}
+
+// NewMockkeyPairGenerator creates a new mock instance.
+func NewMockkeyPairGenerator(ctrl *gomock.Controller) *MockkeyPairGenerator {
+	mock := &MockkeyPairGenerator{ctrl: ctrl}
+	mock.recorder = &MockkeyPairGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockkeyPairGenerator) EXPECT() *MockkeyPairGeneratorMockRecorder {
+	return m.recorder
+}
+
+// NewKeyPair mocks base method.
+func (m *MockkeyPairGenerator) NewKeyPair(arg0 []byte) (*models.KeyPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewKeyPair", arg0)
+	ret0, _ := ret[0].(*models.KeyPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewKeyPair indicates an expected call of NewKeyPair.
+func (mr *MockkeyPairGeneratorMockRecorder) NewKeyPair(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewKeyPair", reflect.TypeOf((*MockkeyPairGenerator)(nil).NewKeyPair), arg0)
+}
+
 // MockkeyProvider is a mock of keyProvider interface.
 type MockkeyProvider struct {
 	ctrl     *gomock.Controller
@@ -244,6 +542,22 @@ func (mr *MockkeyProviderMockRecorder) DeriveKeyFromPasswordAndSalt(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeriveKeyFromPasswordAndSalt", reflect.TypeOf((*MockkeyProvider)(nil).DeriveKeyFromPasswordAndSalt), arg0, arg1)
 }
 
+// DeriveRecoveryKeys mocks base method.
+func (m *MockkeyProvider) DeriveRecoveryKeys(arg0 string) ([]byte, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeriveRecoveryKeys", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeriveRecoveryKeys indicates an expected call of DeriveRecoveryKeys.
+func (mr *MockkeyProviderMockRecorder) DeriveRecoveryKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeriveRecoveryKeys", reflect.TypeOf((*MockkeyProvider)(nil).DeriveRecoveryKeys), arg0)
+}
+
 // EncodeSalt mocks base method.
 func (m *MockkeyProvider) EncodeSalt(arg0 []byte) string {
 	m.ctrl.T.Helper()
@@ -258,6 +572,21 @@ func (mr *MockkeyProviderMockRecorder) EncodeSalt(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EncodeSalt", reflect.TypeOf((*MockkeyProvider)(nil).EncodeSalt), arg0)
 }
 
+// GenerateRecoveryKey mocks base method.
+func (m *MockkeyProvider) GenerateRecoveryKey() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateRecoveryKey")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateRecoveryKey indicates an expected call of GenerateRecoveryKey.
+func (mr *MockkeyProviderMockRecorder) GenerateRecoveryKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRecoveryKey", reflect.TypeOf((*MockkeyProvider)(nil).GenerateRecoveryKey))
+}
+
 // GenerateSalt mocks base method.
 func (m *MockkeyProvider) GenerateSalt() ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -273,6 +602,109 @@ func (mr *MockkeyProviderMockRecorder) GenerateSalt() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSalt", reflect.TypeOf((*MockkeyProvider)(nil).GenerateSalt))
 }
 
+// GenerateVaultKey mocks base method.
+func (m *MockkeyProvider) GenerateVaultKey() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateVaultKey")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateVaultKey indicates an expected call of GenerateVaultKey.
+func (mr *MockkeyProviderMockRecorder) GenerateVaultKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateVaultKey", reflect.TypeOf((*MockkeyProvider)(nil).GenerateVaultKey))
+}
+
+// KDFIterations mocks base method.
+func (m *MockkeyProvider) KDFIterations() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KDFIterations")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// KDFIterations indicates an expected call of KDFIterations.
+func (mr *MockkeyProviderMockRecorder) KDFIterations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KDFIterations", reflect.TypeOf((*MockkeyProvider)(nil).KDFIterations))
+}
+
+// KeyCheck mocks base method.
+func (m *MockkeyProvider) KeyCheck(arg0 []byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeyCheck", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KeyCheck indicates an expected call of KeyCheck.
+func (mr *MockkeyProviderMockRecorder) KeyCheck(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeyCheck", reflect.TypeOf((*MockkeyProvider)(nil).KeyCheck), arg0)
+}
+
+// NewKeyPair mocks base method.
+func (m *MockkeyProvider) NewKeyPair(arg0 []byte) (*models.KeyPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewKeyPair", arg0)
+	ret0, _ := ret[0].(*models.KeyPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewKeyPair indicates an expected call of NewKeyPair.
+func (mr *MockkeyProviderMockRecorder) NewKeyPair(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewKeyPair", reflect.TypeOf((*MockkeyProvider)(nil).NewKeyPair), arg0)
+}
+
+// UnwrapKey mocks base method.
+func (m *MockkeyProvider) UnwrapKey(arg0 []byte, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnwrapKey", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnwrapKey indicates an expected call of UnwrapKey.
+func (mr *MockkeyProviderMockRecorder) UnwrapKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnwrapKey", reflect.TypeOf((*MockkeyProvider)(nil).UnwrapKey), arg0, arg1)
+}
+
+// VerifyKeyCheck mocks base method.
+func (m *MockkeyProvider) VerifyKeyCheck(arg0 []byte, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyKeyCheck", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyKeyCheck indicates an expected call of VerifyKeyCheck.
+func (mr *MockkeyProviderMockRecorder) VerifyKeyCheck(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyKeyCheck", reflect.TypeOf((*MockkeyProvider)(nil).VerifyKeyCheck), arg0, arg1)
+}
+
+// WrapKey mocks base method.
+func (m *MockkeyProvider) WrapKey(arg0, arg1 []byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WrapKey", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WrapKey indicates an expected call of WrapKey.
+func (mr *MockkeyProviderMockRecorder) WrapKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WrapKey", reflect.TypeOf((*MockkeyProvider)(nil).WrapKey), arg0, arg1)
+}
+
 // Mockcrypter is a mock of crypter interface.
 type Mockcrypter struct {
 	ctrl     *gomock.Controller
@@ -309,3 +741,40 @@ func (mr *MockcrypterMockRecorder) SetKey(key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKey", reflect.TypeOf((*Mockcrypter)(nil).SetKey), key)
 }
+
+// MockvaultOpener is a mock of vaultOpener interface.
+type MockvaultOpener struct {
+	ctrl     *gomock.Controller
+	recorder *MockvaultOpenerMockRecorder
+}
+
+// MockvaultOpenerMockRecorder is the mock recorder for MockvaultOpener.
+type MockvaultOpenerMockRecorder struct {
+	mock *MockvaultOpener
+}
+
+// NewMockvaultOpener creates a new mock instance.
+func NewMockvaultOpener(ctrl *gomock.Controller) *MockvaultOpener {
+	mock := &MockvaultOpener{ctrl: ctrl}
+	mock.recorder = &MockvaultOpenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockvaultOpener) EXPECT() *MockvaultOpenerMockRecorder {
+	return m.recorder
+}
+
+// Open mocks base method.
+func (m *MockvaultOpener) Open(arg0 context.Context, arg1 models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockvaultOpenerMockRecorder) Open(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockvaultOpener)(nil).Open), arg0, arg1)
+}
@@ -3,6 +3,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/rycln/gokeep/shared/models"
@@ -15,10 +16,12 @@ type state int
 
 // Authentication screen states
 const (
-	LoginState      state = iota // User login form
-	RegisterState                // User registration form
-	ProcessingState              // Authentication in progress
-	ErrorState                   // Error display state
+	LoginState       state = iota // User login form
+	RegisterState                 // User registration form
+	RecoveryState                 // Recovery key form
+	ProcessingState               // Authentication in progress
+	ErrorState                    // Error display state
+	RecoveryKeyState              // New recovery key display
 )
 
 // field represents active form field
@@ -26,8 +29,15 @@ type field int
 
 // Form field constants
 const (
-	UsernameField field = iota // Username input field
-	PasswordField              // Password input field
+	UsernameField    field = iota // Username input field
+	PasswordField                 // Password input field
+	RecoveryKeyField              // Recovery key input field
+)
+
+// Error definitions for offline unlock
+var (
+	errWrongPassword = errors.New("wrong password")
+	errKDFChanged    = errors.New("key derivation parameters changed, online login required")
 )
 
 // Message types for authentication events
@@ -40,12 +50,24 @@ type (
 
 	// RegisterErrorMsg contains registration failure details
 	RegisterErrorMsg struct{ Err error }
+	// RegisterSuccessMsg carries registered user and recovery key to show once
+	RegisterSuccessMsg struct {
+		User        *models.User
+		RecoveryKey string
+	}
+	// RecoverErrorMsg contains recovery failure details
+	RecoverErrorMsg struct{ Err error }
 )
 
 // authService defines required authentication operations
 type authService interface {
 	UserRegister(context.Context, *models.UserRegReq) (*models.User, error)
 	UserLogin(context.Context, *models.UserLoginReq) (*models.User, error)
+	UserRecover(context.Context, *models.UserRecoverReq) (*models.User, error)
+	UserChangePassword(context.Context, *models.PasswordChangeReq, *models.User) error
+	CacheAccount(context.Context, *models.LocalAccount) error
+	CachedAccount(context.Context, string) (*models.LocalAccount, error)
+	UserSetKeyPair(context.Context, *models.KeyPair, *models.User) error
 }
 
 // saltGenerator defines operations for generating cryptographic salt
@@ -68,12 +90,50 @@ type keyDeriver interface {
 	DeriveKeyFromPasswordAndSalt(string, []byte) []byte
 }
 
+// keyWrapper defines operations for the vault key hierarchy
+type keyWrapper interface {
+	// GenerateVaultKey creates a new random vault key
+	GenerateVaultKey() ([]byte, error)
+	// WrapKey encrypts key with key encryption key
+	WrapKey([]byte, []byte) (string, error)
+	// UnwrapKey decrypts key wrapped by WrapKey
+	UnwrapKey([]byte, string) ([]byte, error)
+}
+
+// recoveryKeyProvider defines operations with printable recovery keys
+type recoveryKeyProvider interface {
+	// GenerateRecoveryKey creates a new printable recovery key
+	GenerateRecoveryKey() (string, error)
+	// DeriveRecoveryKeys returns key encryption key and server verifier
+	DeriveRecoveryKeys(string) ([]byte, string, error)
+}
+
+// keyChecker defines operations for offline key verification
+type keyChecker interface {
+	// KDFIterations returns current key derivation parameters
+	KDFIterations() int
+	// KeyCheck encrypts known value with key
+	KeyCheck([]byte) (string, error)
+	// VerifyKeyCheck verifies value produced by KeyCheck
+	VerifyKeyCheck([]byte, string) error
+}
+
+// keyPairGenerator defines operations with sharing keypairs
+type keyPairGenerator interface {
+	// NewKeyPair generates keypair with private key wrapped by vault key
+	NewKeyPair([]byte) (*models.KeyPair, error)
+}
+
 // keyProvider defines key handling for crypto operations, combining salt generation,
-// conversion and key derivation capabilities
+// conversion, key derivation, wrapping, recovery and sharing capabilities
 type keyProvider interface {
 	saltGenerator
 	saltConverter
 	keyDeriver
+	keyWrapper
+	recoveryKeyProvider
+	keyChecker
+	keyPairGenerator
 }
 
 // crypter defines interface for encryption and decryption operations
@@ -82,17 +142,27 @@ type crypter interface {
 	SetKey(key []byte) error
 }
 
+// vaultOpener defines access to the local vault after unlock
+type vaultOpener interface {
+	// Open prepares local storage of the user once the vault key is set
+	Open(context.Context, models.UserID) error
+}
+
 // Model represents authentication screen state and its dependencies
 type Model struct {
-	state       state         // Current screen state
-	activeField field         // Currently focused input field
-	username    string        // Username input value
-	password    string        // Password input value
-	errMsg      string        // Last error message to display
-	service     authService   // Authentication service implementation
-	key         keyProvider   // Key generation and handling provider
-	crypt       crypter       // Cryptographic operations handler
-	timeout     time.Duration // Maximum duration for authentication operations
+	state       state            // Current screen state
+	activeField field            // Currently focused input field
+	username    string           // Username input value
+	password    string           // Password input value
+	recoveryKey string           // Recovery key input or generated value
+	user        *models.User     // Registered user awaiting recovery key confirmation
+	errMsg      string           // Last error message to display
+	fieldErrs   map[field]string // Server rejections shown under form fields
+	service     authService      // Authentication service implementation
+	key         keyProvider      // Key generation and handling provider
+	crypt       crypter          // Cryptographic operations handler
+	vault       vaultOpener      // Local vault storage
+	timeout     time.Duration    // Maximum duration for authentication operations
 }
 
 // InitialModel creates new authentication model with dependencies
@@ -100,6 +170,7 @@ func InitialModel(
 	service authService,
 	key keyProvider,
 	crypt crypter,
+	vault vaultOpener,
 	timeout time.Duration,
 ) Model {
 	return Model{
@@ -109,6 +180,7 @@ func InitialModel(
 		timeout:     timeout,
 		key:         key,
 		crypt:       crypt,
+		vault:       vault,
 	}
 }
 
@@ -117,3 +189,32 @@ func InitialModel(
 func (m Model) GetState() state {
 	return m.state
 }
+
+// Reset clears the form after the account was removed
+func (m *Model) Reset() {
+	m.state = LoginState
+	m.activeField = UsernameField
+	m.username = ""
+	m.password = ""
+	m.recoveryKey = ""
+	m.errMsg = ""
+	m.fieldErrs = nil
+}
+
+// Clear drops entered secrets once the vault is opened or locked
+// The username is kept for the next login
+func (m *Model) Clear() {
+	m.password = ""
+	m.recoveryKey = ""
+	m.user = nil
+	m.errMsg = ""
+	m.fieldErrs = nil
+}
+
+// Reauth returns to login form to open a server session for the current user
+func (m *Model) Reauth() {
+	m.state = LoginState
+	m.activeField = PasswordField
+	m.password = ""
+	m.errMsg = ""
+}
@@ -16,6 +16,10 @@ func (m Model) View() string {
 		return i18n.CommonWait
 	case ErrorState:
 		return styles.ErrorStyle.Render(fmt.Sprintf(i18n.CommonError, m.errMsg))
+	case RecoveryKeyState:
+		return renderRecoveryKey(m)
+	case RecoveryState:
+		return renderRecoveryForm(m)
 	default:
 		return renderAuthForm(m)
 	}
@@ -51,13 +55,59 @@ func renderAuthForm(m Model) string {
 	}
 
 	return fmt.Sprintf(
-		"%s\n\n%s\n%s\n\n%s %s\n\n%s",
+		"%s\n\n%s\n%s\n\n%s %s\n\n%s\n%s",
 		styles.TitleStyle.Render(title),
-		usernameInput,
-		passwordInput,
+		usernameInput+renderFieldError(m, UsernameField),
+		passwordInput+renderFieldError(m, PasswordField),
 		loginBtn,
 		registerBtn,
 		i18n.AuthTabHint,
+		i18n.AuthRecoveryKeyHint,
+	)
+}
+
+// renderFieldError renders server rejection of the field on its own line
+// Returns empty string when the field has no error
+func renderFieldError(m Model, f field) string {
+	msg, ok := m.fieldErrs[f]
+	if !ok {
+		return ""
+	}
+	return "\n" + styles.ErrorStyle.Render("  "+msg)
+}
+
+// renderRecoveryForm builds the account recovery form UI
+// Includes username, recovery key and new password fields
+func renderRecoveryForm(m Model) string {
+	labels := map[field]string{
+		UsernameField:    fmt.Sprintf(i18n.AuthUsernameLabel, m.username),
+		RecoveryKeyField: fmt.Sprintf(i18n.AuthRecoveryKeyLabel, m.recoveryKey),
+		PasswordField:    fmt.Sprintf(i18n.AuthNewPasswordLabel, maskPassword(m.password)),
+	}
+
+	var b strings.Builder
+	b.WriteString(styles.TitleStyle.Render(i18n.AuthRecoveryTitle) + "\n\n")
+	for _, f := range m.fields() {
+		if f == m.activeField {
+			b.WriteString(styles.FocusedStyle.Render("> " + labels[f]))
+		} else {
+			b.WriteString(styles.InputStyle.Render(labels[f]))
+		}
+		b.WriteString(renderFieldError(m, f) + "\n")
+	}
+	b.WriteString("\n" + i18n.AuthRecoveryHint)
+
+	return b.String()
+}
+
+// renderRecoveryKey shows newly generated recovery key
+func renderRecoveryKey(m Model) string {
+	return fmt.Sprintf(
+		"%s\n\n%s\n\n%s\n\n%s",
+		styles.TitleStyle.Render(i18n.AuthRecoveryKeyTitle),
+		styles.FocusedStyle.Render(m.recoveryKey),
+		i18n.AuthRecoveryKeyWarning,
+		i18n.CommonPressEnter,
 	)
 }
 
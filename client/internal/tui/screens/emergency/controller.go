@@ -0,0 +1,235 @@
+package emergency
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rycln/gokeep/client/internal/tui/shared/i18n"
+	"github.com/rycln/gokeep/shared/models"
+)
+
+// Init initializes the emergency model
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles all messages and state transitions
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch m.state {
+	case UpdateState:
+		m.state = ProcessingState
+		return m, m.loadEntries()
+	case ListState:
+		return handleListState(m, msg)
+	case InputState:
+		return handleInputState(m, msg)
+	case ProcessingState:
+		return handleProcessingState(m, msg)
+	case ErrorState:
+		return handleErrorState(m, msg)
+	}
+
+	return m, nil
+}
+
+// handleListState manages contacts list interactions
+func handleListState(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		return m, func() tea.Msg { return CancelMsg{} }
+	case tea.KeyUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case tea.KeyDown:
+		if m.cursor < len(m.contacts)+len(m.grants)-1 {
+			m.cursor++
+		}
+	case tea.KeyEnter:
+		entry, grant := m.selected()
+		if grant && entry.Status == models.EmergencyApproved {
+			m.state = ProcessingState
+			return m, m.openVault(entry.Grantor)
+		}
+	case tea.KeyRunes:
+		switch keyMsg.String() {
+		case "a", "ф":
+			m.state = InputState
+			m.input = ""
+		case "u", "г":
+			m.state = ProcessingState
+			return m, m.loadEntries()
+		case "d", "в":
+			entry, grant := m.selected()
+			if entry != nil && !grant && entry.Status == models.EmergencyRequested {
+				m.state = ProcessingState
+				return m, m.denyAccess(entry.Grantee)
+			}
+		case "r", "к":
+			entry, grant := m.selected()
+			if grant && (entry.Status == models.EmergencyIdle || entry.Status == models.EmergencyDenied) {
+				m.state = ProcessingState
+				return m, m.requestAccess(entry.Grantor)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// handleInputState manages new contact input
+func handleInputState(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyEsc:
+		m.state = ListState
+		m.input = ""
+	case tea.KeyEnter:
+		m.state = ProcessingState
+		return m, m.addContact()
+	case tea.KeyBackspace:
+		runes := []rune(m.input)
+		if len(runes) > 0 {
+			m.input = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.input += keyMsg.String()
+	}
+
+	return m, nil
+}
+
+// handleProcessingState processes background operation results
+func handleProcessingState(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case EntriesMsg:
+		m.contacts = msg.Contacts
+		m.grants = msg.Grants
+		if m.cursor >= len(m.contacts)+len(m.grants) {
+			m.cursor = 0
+		}
+		m.state = ListState
+	case DoneMsg:
+		m.status = msg.Status
+		m.input = ""
+		m.state = ProcessingState
+		return m, m.loadEntries()
+	case ErrorMsg:
+		m.errMsg = msg.Err.Error()
+		m.state = ErrorState
+	}
+
+	return m, nil
+}
+
+// handleErrorState manages error display and recovery
+func handleErrorState(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.Type == tea.KeyEnter {
+		m.state = ListState
+	}
+
+	return m, nil
+}
+
+// loadEntries fetches trusted contacts and grants of current user
+func (m Model) loadEntries() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		contacts, err := m.svc.ListContacts(ctx, m.user)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		grants, err := m.svc.ListGrants(ctx, m.user)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		return EntriesMsg{Contacts: contacts, Grants: grants}
+	}
+}
+
+// addContact nominates the entered account with the entered waiting period
+func (m Model) addContact() tea.Cmd {
+	return func() tea.Msg {
+		fields := strings.Fields(m.input)
+		if len(fields) != 2 {
+			return ErrorMsg{Err: errInvalidInput}
+		}
+
+		wait, err := time.ParseDuration(fields[1])
+		if err != nil || wait < 0 {
+			return ErrorMsg{Err: errInvalidInput}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		err = m.svc.AddContact(ctx, m.user, fields[0], wait)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		return DoneMsg{Status: i18n.EmergencyContactAdded}
+	}
+}
+
+// requestAccess starts waiting period for the grantor vault
+func (m Model) requestAccess(grantor string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		err := m.svc.RequestAccess(ctx, m.user, grantor)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		return DoneMsg{Status: i18n.EmergencyRequested}
+	}
+}
+
+// denyAccess denies pending request of the trusted contact
+func (m Model) denyAccess(grantee string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		err := m.svc.DenyAccess(ctx, m.user, grantee)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		return DoneMsg{Status: i18n.EmergencyDenied}
+	}
+}
+
+// openVault fetches and decrypts the vault released by the grantor
+func (m Model) openVault(grantor string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		items, err := m.svc.OpenVault(ctx, m.user, grantor)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		return VaultMsg{Owner: grantor, Items: items}
+	}
+}
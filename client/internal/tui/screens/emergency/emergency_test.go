@@ -0,0 +1,186 @@
+package emergency
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/client/internal/tui/screens/emergency/mocks"
+	"github.com/rycln/gokeep/client/internal/tui/shared/i18n"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmergency_Load(t *testing.T) {
+	user := &models.User{ID: "user123"}
+
+	t.Run("should load contacts and grants", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSvc := mocks.NewMockemergencyService(ctrl)
+		model := InitialModel(mockSvc, time.Second)
+		model.SetUser(user)
+
+		contacts := []models.EmergencyContact{{Grantee: "bob", Status: models.EmergencyIdle}}
+		grants := []models.EmergencyContact{{Grantor: "alice", Status: models.EmergencyApproved}}
+		mockSvc.EXPECT().ListContacts(gomock.Any(), user).Return(contacts, nil)
+		mockSvc.EXPECT().ListGrants(gomock.Any(), user).Return(grants, nil)
+
+		updated, cmd := model.Update(nil)
+		require.NotNil(t, cmd)
+		assert.Equal(t, ProcessingState, updated.(Model).state)
+
+		updated, _ = updated.Update(cmd())
+		newModel := updated.(Model)
+		assert.Equal(t, ListState, newModel.state)
+		assert.Equal(t, contacts, newModel.contacts)
+		assert.Equal(t, grants, newModel.grants)
+		assert.Contains(t, newModel.View(), "bob")
+		assert.Contains(t, newModel.View(), "alice")
+	})
+
+	t.Run("should show load error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSvc := mocks.NewMockemergencyService(ctrl)
+		model := InitialModel(mockSvc, time.Second)
+		model.user = user
+		model.state = ProcessingState
+
+		mockSvc.EXPECT().ListContacts(gomock.Any(), user).Return(nil, errors.New("offline"))
+
+		updated, _ := model.Update(model.loadEntries()())
+		assert.Equal(t, ErrorState, updated.(Model).state)
+		assert.Equal(t, "offline", updated.(Model).errMsg)
+	})
+}
+
+func TestEmergency_AddContact(t *testing.T) {
+	user := &models.User{ID: "user123"}
+
+	t.Run("should add contact with waiting period", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSvc := mocks.NewMockemergencyService(ctrl)
+		model := InitialModel(mockSvc, time.Second)
+		model.user = user
+		model.state = ListState
+
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+		model = updated.(Model)
+		require.Equal(t, InputState, model.state)
+
+		for _, r := range "bob 72h" {
+			updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			model = updated.(Model)
+		}
+		assert.Equal(t, "bob 72h", model.input)
+
+		mockSvc.EXPECT().AddContact(gomock.Any(), user, "bob", 72*time.Hour).Return(nil)
+
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		require.NotNil(t, cmd)
+		assert.Equal(t, DoneMsg{Status: i18n.EmergencyContactAdded}, cmd())
+		assert.Equal(t, ProcessingState, updated.(Model).state)
+	})
+
+	t.Run("should erase whole character", func(t *testing.T) {
+		model := InitialModel(nil, time.Second)
+		model.state = InputState
+		model.input = "ёжик"
+
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		assert.Equal(t, "ёжи", updated.(Model).input)
+	})
+
+	t.Run("should reject malformed input", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockemergencyService(ctrl), time.Second)
+		model.user = user
+
+		for _, input := range []string{"bob", "bob soon", "bob -1h"} {
+			model.input = input
+			assert.Equal(t, ErrorMsg{Err: errInvalidInput}, model.addContact()(), input)
+		}
+	})
+}
+
+func TestEmergency_Actions(t *testing.T) {
+	user := &models.User{ID: "user123"}
+
+	newModel := func(t *testing.T) (Model, *mocks.MockemergencyService) {
+		ctrl := gomock.NewController(t)
+		mockSvc := mocks.NewMockemergencyService(ctrl)
+		model := InitialModel(mockSvc, time.Second)
+		model.user = user
+		model.state = ListState
+		model.contacts = []models.EmergencyContact{{Grantee: "bob", Status: models.EmergencyRequested}}
+		model.grants = []models.EmergencyContact{
+			{Grantor: "alice", Status: models.EmergencyIdle},
+			{Grantor: "carol", Status: models.EmergencyApproved},
+		}
+		return model, mockSvc
+	}
+
+	t.Run("should deny pending request of contact", func(t *testing.T) {
+		model, mockSvc := newModel(t)
+
+		mockSvc.EXPECT().DenyAccess(gomock.Any(), user, "bob").Return(nil)
+
+		_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+		require.NotNil(t, cmd)
+		assert.Equal(t, DoneMsg{Status: i18n.EmergencyDenied}, cmd())
+	})
+
+	t.Run("should request access to grant", func(t *testing.T) {
+		model, mockSvc := newModel(t)
+		model.cursor = 1
+
+		mockSvc.EXPECT().RequestAccess(gomock.Any(), user, "alice").Return(nil)
+
+		_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+		require.NotNil(t, cmd)
+		assert.Equal(t, DoneMsg{Status: i18n.EmergencyRequested}, cmd())
+	})
+
+	t.Run("should not open vault before approval", func(t *testing.T) {
+		model, _ := newModel(t)
+		model.cursor = 1
+
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Nil(t, cmd)
+		assert.Equal(t, ListState, updated.(Model).state)
+	})
+
+	t.Run("should open approved vault", func(t *testing.T) {
+		model, mockSvc := newModel(t)
+
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyDown})
+		updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyDown})
+		model = updated.(Model)
+		require.Equal(t, 2, model.cursor)
+
+		items := []models.SharedItem{{ID: "item1", Owner: "carol", Name: "bank"}}
+		mockSvc.EXPECT().OpenVault(gomock.Any(), user, "carol").Return(items, nil)
+
+		_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		require.NotNil(t, cmd)
+		assert.Equal(t, VaultMsg{Owner: "carol", Items: items}, cmd())
+	})
+
+	t.Run("should return to vault on escape", func(t *testing.T) {
+		model, _ := newModel(t)
+
+		_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		require.NotNil(t, cmd)
+		assert.Equal(t, CancelMsg{}, cmd())
+	})
+}
@@ -0,0 +1,115 @@
+// Package emergency implements the emergency access screen.
+// Lists trusted contacts of the user and vaults the user is trusted with.
+package emergency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rycln/gokeep/shared/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// state represents current view state of emergency screen
+type state int
+
+// Screen state constants
+const (
+	UpdateState     state = iota // Reloading contacts
+	ListState                    // Contacts and grants list
+	InputState                   // New contact input
+	ProcessingState              // Background operation in progress
+	ErrorState                   // Error display state
+)
+
+// errInvalidInput indicates malformed new contact input
+var errInvalidInput = errors.New(`expected "username wait", for example "bob 72h"`)
+
+// Message types for emergency screen communication
+type (
+	// CancelMsg requests return to the vault screen
+	CancelMsg struct{}
+
+	// VaultMsg delivers decrypted vault released by the grantor
+	VaultMsg struct {
+		Owner string
+		Items []models.SharedItem
+	}
+
+	// EntriesMsg delivers contacts and grants for display
+	EntriesMsg struct {
+		Contacts []models.EmergencyContact
+		Grants   []models.EmergencyContact
+	}
+
+	// DoneMsg confirms successful operation
+	DoneMsg struct{ Status string }
+
+	// ErrorMsg delivers error information
+	ErrorMsg struct{ Err error }
+)
+
+// emergencyService defines interface for emergency access operations
+type emergencyService interface {
+	AddContact(context.Context, *models.User, string, time.Duration) error
+	ListContacts(context.Context, *models.User) ([]models.EmergencyContact, error)
+	ListGrants(context.Context, *models.User) ([]models.EmergencyContact, error)
+	RequestAccess(context.Context, *models.User, string) error
+	DenyAccess(context.Context, *models.User, string) error
+	OpenVault(context.Context, *models.User, string) ([]models.SharedItem, error)
+}
+
+// Model represents emergency screen state and components
+type Model struct {
+	state    state                     // Current view state
+	input    string                    // New contact input buffer
+	status   string                    // Result of the last operation
+	errMsg   string                    // Last error message
+	contacts []models.EmergencyContact // Trusted contacts nominated by the user
+	grants   []models.EmergencyContact // Vaults the user is trusted with
+	cursor   int                       // Selected entry, contacts go before grants
+	user     *models.User              // Current authenticated user
+	svc      emergencyService          // Emergency access service
+	timeout  time.Duration             // Operation timeout
+}
+
+// InitialModel creates new emergency model with dependencies
+func InitialModel(svc emergencyService, timeout time.Duration) Model {
+	return Model{
+		state:   UpdateState,
+		svc:     svc,
+		timeout: timeout,
+	}
+}
+
+// SetUser updates current user and reloads entries on the next update
+func (m *Model) SetUser(user *models.User) {
+	m.user = user
+	m.status = ""
+	m.state = UpdateState
+}
+
+// Clear drops loaded entries and input
+func (m *Model) Clear() {
+	m.contacts = nil
+	m.grants = nil
+	m.cursor = 0
+	m.input = ""
+	m.status = ""
+	m.errMsg = ""
+	m.state = UpdateState
+}
+
+// selected returns the entry under cursor and whether it is a grant
+func (m Model) selected() (*models.EmergencyContact, bool) {
+	switch {
+	case m.cursor < len(m.contacts):
+		return &m.contacts[m.cursor], false
+	case m.cursor < len(m.contacts)+len(m.grants):
+		return &m.grants[m.cursor-len(m.contacts)], true
+	default:
+		return nil, false
+	}
+}
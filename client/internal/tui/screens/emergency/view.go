@@ -0,0 +1,78 @@
+package emergency
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rycln/gokeep/client/internal/tui/shared/i18n"
+	"github.com/rycln/gokeep/client/internal/tui/shared/styles"
+	"github.com/rycln/gokeep/shared/models"
+)
+
+// View renders the current state of the emergency access screen.
+func (m Model) View() string {
+	switch m.state {
+	case UpdateState:
+		return i18n.CommonPressAnyKey
+	case ProcessingState:
+		return i18n.CommonWait
+	case InputState:
+		return fmt.Sprintf(i18n.EmergencyAddPrompt, m.input)
+	case ErrorState:
+		return styles.ErrorStyle.Render(fmt.Sprintf(i18n.CommonError, m.errMsg))
+	default:
+		return m.listView()
+	}
+}
+
+// listView renders trusted contacts followed by vaults the user is trusted with.
+func (m Model) listView() string {
+	var b strings.Builder
+	b.WriteString(styles.TitleStyle.Render(i18n.EmergencyTitle) + "\n\n")
+
+	b.WriteString(i18n.EmergencyContactsTitle + "\n")
+	if len(m.contacts) == 0 {
+		b.WriteString(i18n.EmergencyEmpty + "\n")
+	}
+	for i, contact := range m.contacts {
+		b.WriteString(m.entryView(i, contact.Grantee, &contact) + "\n")
+	}
+
+	b.WriteString("\n" + i18n.EmergencyGrantsTitle + "\n")
+	if len(m.grants) == 0 {
+		b.WriteString(i18n.EmergencyEmpty + "\n")
+	}
+	for i, grant := range m.grants {
+		b.WriteString(m.entryView(len(m.contacts)+i, grant.Grantor, &grant) + "\n")
+	}
+
+	if m.status != "" {
+		b.WriteString("\n" + m.status + "\n")
+	}
+	b.WriteString("\n" + i18n.EmergencyActions)
+	return b.String()
+}
+
+// entryView renders a single contact line with its status.
+func (m Model) entryView(i int, username string, contact *models.EmergencyContact) string {
+	line := fmt.Sprintf(i18n.EmergencyEntry, username, contact.WaitPeriod, statusLabel(contact))
+	if i == m.cursor {
+		return styles.FocusedStyle.Render("> " + line)
+	}
+	return "  " + line
+}
+
+// statusLabel describes emergency access state for display.
+func statusLabel(contact *models.EmergencyContact) string {
+	switch contact.Status {
+	case models.EmergencyRequested:
+		deadline := contact.RequestedAt.Add(contact.WaitPeriod)
+		return fmt.Sprintf(i18n.EmergencyStatusRequested, deadline.Format("2006-01-02 15:04"))
+	case models.EmergencyDenied:
+		return i18n.EmergencyStatusDenied
+	case models.EmergencyApproved:
+		return i18n.EmergencyStatusApproved
+	default:
+		return i18n.EmergencyStatusIdle
+	}
+}
@@ -0,0 +1,113 @@
+package lock
+
+import (
+	"crypto/hmac"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Init initializes the lock model
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles all messages and state transitions for lock screen
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch m.state {
+	case InputState:
+		return handleInputState(m, msg)
+	case ProcessingState:
+		return handleProcessingState(m, msg)
+	case ErrorState:
+		return handleErrorState(m, msg)
+	}
+	return m, nil
+}
+
+// handleInputState processes master password input
+func handleInputState(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+		case tea.KeyEnter:
+			m.state = ProcessingState
+			return m, m.unlock()
+		case tea.KeyRunes:
+			m.password += msg.String()
+		case tea.KeyBackspace:
+			runes := []rune(m.password)
+			if len(runes) > 0 {
+				m.password = string(runes[:len(runes)-1])
+			}
+		}
+	}
+	return m, nil
+}
+
+// unlock re-derives vault key from the cached salt without contacting the server
+func (m Model) unlock() tea.Cmd {
+	return func() tea.Msg {
+		if m.user == nil || m.check == nil {
+			return UnlockErrorMsg{errNotLocked}
+		}
+
+		salt, err := m.key.DecodeSalt(m.user.Salt)
+		if err != nil {
+			return UnlockErrorMsg{err}
+		}
+
+		key := m.key.DeriveKeyFromPasswordAndSalt(m.password, salt)
+
+		if m.user.EncryptedKey != "" {
+			key, err = m.key.UnwrapKey(key, m.user.EncryptedKey)
+			if err != nil {
+				return UnlockErrorMsg{errWrongPassword}
+			}
+		}
+
+		err = m.crypt.SetKey(key)
+		if err != nil {
+			return UnlockErrorMsg{err}
+		}
+
+		check, err := m.crypt.Blind([]byte(checkLabel))
+		if err != nil || !hmac.Equal(check, m.check) {
+			m.crypt.Wipe()
+			return UnlockErrorMsg{errWrongPassword}
+		}
+
+		return UnlockSuccessMsg{}
+	}
+}
+
+// handleProcessingState manages unlock results
+func handleProcessingState(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case UnlockErrorMsg:
+		m.errMsg = msg.Err.Error()
+		m.password = ""
+		m.state = ErrorState
+	case UnlockSuccessMsg:
+		m.password = ""
+		m.check = nil
+		m.state = InputState
+		return m, func() tea.Msg { return msg }
+	}
+	return m, nil
+}
+
+// handleErrorState manages error display
+func handleErrorState(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+		case tea.KeyEnter:
+			m.state = InputState
+		}
+	}
+	return m, nil
+}
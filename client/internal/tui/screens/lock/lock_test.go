@@ -0,0 +1,189 @@
+package lock
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/client/internal/tui/screens/lock/mocks"
+	"github.com/rycln/gokeep/client/internal/tui/shared/i18n"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock(t *testing.T) {
+	t.Run("should remember key check and wipe key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+		model := InitialModel(mocks.NewMockkeyProvider(ctrl), mockCrypt)
+		model.password = "leftover"
+		model.state = ErrorState
+
+		user := &models.User{ID: "user123"}
+
+		gomock.InOrder(
+			mockCrypt.EXPECT().Blind([]byte(checkLabel)).Return([]byte("check"), nil),
+			mockCrypt.EXPECT().Wipe(),
+		)
+
+		model.Lock(user)
+
+		assert.Equal(t, user, model.user)
+		assert.Equal(t, []byte("check"), model.check)
+		assert.Empty(t, model.password)
+		assert.Equal(t, InputState, model.state)
+	})
+}
+
+func TestUnlock(t *testing.T) {
+	user := &models.User{ID: "user123", Salt: "encodedSalt", EncryptedKey: "wrappedKey"}
+
+	t.Run("should restore key without server round trip", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockKey := mocks.NewMockkeyProvider(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+
+		model := InitialModel(mockKey, mockCrypt)
+		model.user = user
+		model.check = []byte("check")
+		model.password = "pass"
+
+		gomock.InOrder(
+			mockKey.EXPECT().DecodeSalt("encodedSalt").Return([]byte("salt"), nil),
+			mockKey.EXPECT().DeriveKeyFromPasswordAndSalt("pass", []byte("salt")).Return([]byte("derived")),
+			mockKey.EXPECT().UnwrapKey([]byte("derived"), "wrappedKey").Return([]byte("vaultKey"), nil),
+			mockCrypt.EXPECT().SetKey([]byte("vaultKey")).Return(nil),
+			mockCrypt.EXPECT().Blind([]byte(checkLabel)).Return([]byte("check"), nil),
+		)
+
+		msg := model.unlock()()
+		assert.Equal(t, UnlockSuccessMsg{}, msg)
+	})
+
+	t.Run("should reject password that cannot unwrap key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockKey := mocks.NewMockkeyProvider(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+
+		model := InitialModel(mockKey, mockCrypt)
+		model.user = user
+		model.check = []byte("check")
+
+		gomock.InOrder(
+			mockKey.EXPECT().DecodeSalt(gomock.Any()).Return([]byte("salt"), nil),
+			mockKey.EXPECT().DeriveKeyFromPasswordAndSalt(gomock.Any(), gomock.Any()).Return([]byte("derived")),
+			mockKey.EXPECT().UnwrapKey(gomock.Any(), gomock.Any()).Return(nil, errors.New("auth failed")),
+		)
+
+		msg := model.unlock()()
+		assert.Equal(t, UnlockErrorMsg{errWrongPassword}, msg)
+	})
+
+	t.Run("should wipe key on check mismatch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockKey := mocks.NewMockkeyProvider(ctrl)
+		mockCrypt := mocks.NewMockcrypter(ctrl)
+
+		model := InitialModel(mockKey, mockCrypt)
+		model.user = &models.User{ID: "user123", Salt: "encodedSalt"}
+		model.check = []byte("check")
+
+		gomock.InOrder(
+			mockKey.EXPECT().DecodeSalt(gomock.Any()).Return([]byte("salt"), nil),
+			mockKey.EXPECT().DeriveKeyFromPasswordAndSalt(gomock.Any(), gomock.Any()).Return([]byte("derived")),
+			mockCrypt.EXPECT().SetKey([]byte("derived")).Return(nil),
+			mockCrypt.EXPECT().Blind([]byte(checkLabel)).Return([]byte("other"), nil),
+			mockCrypt.EXPECT().Wipe(),
+		)
+
+		msg := model.unlock()()
+		assert.Equal(t, UnlockErrorMsg{errWrongPassword}, msg)
+	})
+
+	t.Run("should fail when vault was not locked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockkeyProvider(ctrl), mocks.NewMockcrypter(ctrl))
+
+		msg := model.unlock()()
+		assert.Equal(t, UnlockErrorMsg{errNotLocked}, msg)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("should edit password and start unlock on Enter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockkeyProvider(ctrl), mocks.NewMockcrypter(ctrl))
+
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("pass")})
+		updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		model = updated.(Model)
+		assert.Equal(t, "pas", model.password)
+
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Equal(t, ProcessingState, updated.(Model).state)
+		assert.NotNil(t, cmd)
+	})
+
+	t.Run("should show error and return to input", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockkeyProvider(ctrl), mocks.NewMockcrypter(ctrl))
+		model.state = ProcessingState
+		model.password = "wrong"
+
+		updated, _ := model.Update(UnlockErrorMsg{errWrongPassword})
+		model = updated.(Model)
+		assert.Equal(t, ErrorState, model.state)
+		assert.Empty(t, model.password)
+		assert.Contains(t, model.View(), errWrongPassword.Error())
+
+		updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Equal(t, InputState, updated.(Model).state)
+	})
+
+	t.Run("should forward success message", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockkeyProvider(ctrl), mocks.NewMockcrypter(ctrl))
+		model.state = ProcessingState
+		model.check = []byte("check")
+
+		updated, cmd := model.Update(UnlockSuccessMsg{})
+		require.NotNil(t, cmd)
+		assert.Equal(t, UnlockSuccessMsg{}, cmd())
+		assert.Nil(t, updated.(Model).check)
+		assert.Equal(t, InputState, updated.(Model).state)
+	})
+}
+
+func TestView(t *testing.T) {
+	t.Run("should render masked password", func(t *testing.T) {
+		model := Model{state: InputState, password: "abc"}
+
+		view := model.View()
+		assert.Contains(t, view, i18n.LockTitle)
+		assert.Contains(t, view, "•••")
+		assert.NotContains(t, view, "abc")
+	})
+
+	t.Run("should render wait message while processing", func(t *testing.T) {
+		model := Model{state: ProcessingState}
+		assert.Equal(t, i18n.CommonWait, model.View())
+	})
+}
@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: model.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockkeyProvider is a mock of keyProvider interface.
+type MockkeyProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockkeyProviderMockRecorder
+}
+
+// MockkeyProviderMockRecorder is the mock recorder for MockkeyProvider.
+type MockkeyProviderMockRecorder struct {
+	mock *MockkeyProvider
+}
+
+// NewMockkeyProvider creates a new mock instance.
+func NewMockkeyProvider(ctrl *gomock.Controller) *MockkeyProvider {
+	mock := &MockkeyProvider{ctrl: ctrl}
+	mock.recorder = &MockkeyProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockkeyProvider) EXPECT() *MockkeyProviderMockRecorder {
+	return m.recorder
+}
+
+// DecodeSalt mocks base method.
+func (m *MockkeyProvider) DecodeSalt(arg0 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecodeSalt", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecodeSalt indicates an expected call of DecodeSalt.
+func (mr *MockkeyProviderMockRecorder) DecodeSalt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecodeSalt", reflect.TypeOf((*MockkeyProvider)(nil).DecodeSalt), arg0)
+}
+
+// DeriveKeyFromPasswordAndSalt mocks base method.
+func (m *MockkeyProvider) DeriveKeyFromPasswordAndSalt(arg0 string, arg1 []byte) []byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeriveKeyFromPasswordAndSalt", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	return ret0
+}
+
+// DeriveKeyFromPasswordAndSalt indicates an expected call of DeriveKeyFromPasswordAndSalt.
+func (mr *MockkeyProviderMockRecorder) DeriveKeyFromPasswordAndSalt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeriveKeyFromPasswordAndSalt", reflect.TypeOf((*MockkeyProvider)(nil).DeriveKeyFromPasswordAndSalt), arg0, arg1)
+}
+
+// UnwrapKey mocks base method.
+func (m *MockkeyProvider) UnwrapKey(arg0 []byte, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnwrapKey", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnwrapKey indicates an expected call of UnwrapKey.
+func (mr *MockkeyProviderMockRecorder) UnwrapKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnwrapKey", reflect.TypeOf((*MockkeyProvider)(nil).UnwrapKey), arg0, arg1)
+}
+
+// Mockcrypter is a mock of crypter interface.
+type Mockcrypter struct {
+	ctrl     *gomock.Controller
+	recorder *MockcrypterMockRecorder
+}
+
+// MockcrypterMockRecorder is the mock recorder for Mockcrypter.
+type MockcrypterMockRecorder struct {
+	mock *Mockcrypter
+}
+
+// NewMockcrypter creates a new mock instance.
+func NewMockcrypter(ctrl *gomock.Controller) *Mockcrypter {
+	mock := &Mockcrypter{ctrl: ctrl}
+	mock.recorder = &MockcrypterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcrypter) EXPECT() *MockcrypterMockRecorder {
+	return m.recorder
+}
+
+// Blind mocks base method.
+func (m *Mockcrypter) Blind(arg0 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Blind", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Blind indicates an expected call of Blind.
+func (mr *MockcrypterMockRecorder) Blind(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Blind", reflect.TypeOf((*Mockcrypter)(nil).Blind), arg0)
+}
+
+// SetKey mocks base method.
+func (m *Mockcrypter) SetKey(arg0 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKey", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKey indicates an expected call of SetKey.
+func (mr *MockcrypterMockRecorder) SetKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKey", reflect.TypeOf((*Mockcrypter)(nil).SetKey), arg0)
+}
+
+// Wipe mocks base method.
+func (m *Mockcrypter) Wipe() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Wipe")
+}
+
+// Wipe indicates an expected call of Wipe.
+func (mr *MockcrypterMockRecorder) Wipe() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wipe", reflect.TypeOf((*Mockcrypter)(nil).Wipe))
+}
@@ -0,0 +1,90 @@
+// Package lock implements the vault lock screen.
+// Unlocks the vault locally with the master password after idle timeout.
+package lock
+
+import (
+	"errors"
+
+	"github.com/rycln/gokeep/shared/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// state represents current lock screen state
+type state int
+
+// Lock screen states
+const (
+	InputState      state = iota // Master password input
+	ProcessingState              // Key derivation in progress
+	ErrorState                   // Error display state
+)
+
+// checkLabel is blinded with the vault key to verify the password on unlock
+const checkLabel = "gophkeeper-lock-check"
+
+// Error definitions for unlock
+var (
+	errWrongPassword = errors.New("wrong password")
+	errNotLocked     = errors.New("vault is not locked")
+)
+
+// Message types for lock screen events
+type (
+	// UnlockSuccessMsg indicates the vault key is restored
+	UnlockSuccessMsg struct{}
+
+	// UnlockErrorMsg contains unlock failure details
+	UnlockErrorMsg struct{ Err error }
+)
+
+// keyProvider defines local key derivation operations
+type keyProvider interface {
+	// DecodeSalt converts string salt back to binary representation
+	DecodeSalt(string) ([]byte, error)
+	// DeriveKeyFromPasswordAndSalt creates a cryptographic key from password and salt
+	DeriveKeyFromPasswordAndSalt(string, []byte) []byte
+	// UnwrapKey decrypts vault key wrapped with the password-derived key
+	UnwrapKey([]byte, string) ([]byte, error)
+}
+
+// crypter defines vault key operations
+type crypter interface {
+	// SetKey configures the encryption key
+	SetKey([]byte) error
+	// Blind returns keyed digest of data
+	Blind([]byte) ([]byte, error)
+	// Wipe removes the key from memory
+	Wipe()
+}
+
+// Model represents lock screen state and its dependencies
+type Model struct {
+	state    state        // Current screen state
+	password string       // Master password input value
+	errMsg   string       // Last error message to display
+	user     *models.User // Locked user with cached salt and wrapped key
+	check    []byte       // Key check value computed before wiping
+	key      keyProvider  // Key derivation provider
+	crypt    crypter      // Vault key holder
+}
+
+// InitialModel creates new lock screen model with dependencies
+func InitialModel(key keyProvider, crypt crypter) Model {
+	return Model{
+		state: InputState,
+		key:   key,
+		crypt: crypt,
+	}
+}
+
+// Lock remembers key check value and wipes the vault key from memory
+func (m *Model) Lock(user *models.User) {
+	m.check, _ = m.crypt.Blind([]byte(checkLabel))
+	m.crypt.Wipe()
+
+	m.user = user
+	m.password = ""
+	m.errMsg = ""
+	m.state = InputState
+}
@@ -0,0 +1,26 @@
+package lock
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rycln/gokeep/client/internal/tui/shared/i18n"
+	"github.com/rycln/gokeep/client/internal/tui/shared/styles"
+)
+
+// View renders the lock screen based on state
+func (m Model) View() string {
+	switch m.state {
+	case ProcessingState:
+		return i18n.CommonWait
+	case ErrorState:
+		return styles.ErrorStyle.Render(fmt.Sprintf(i18n.CommonError, m.errMsg))
+	default:
+		return fmt.Sprintf(
+			"%s\n\n%s\n\n%s",
+			styles.TitleStyle.Render(i18n.LockTitle),
+			styles.FocusedStyle.Render("> "+fmt.Sprintf(i18n.AuthPasswordLabel, strings.Repeat("•", len(m.password)))),
+			i18n.LockHint,
+		)
+	}
+}
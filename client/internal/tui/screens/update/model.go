@@ -79,3 +79,15 @@ func (m *Model) SetItem(info *models.ItemInfo, content []byte) {
 	m.info = info
 	m.content = content
 }
+
+// Clear drops decrypted item content and form data
+func (m *Model) Clear() {
+	m.state = LoadState
+	m.errMsg = ""
+	m.info = nil
+	m.content = nil
+	m.logpassModel = logpass.InitialModel()
+	m.cardModel = card.InitialModel()
+	m.textModel = text.InitialModel()
+	m.binModel = bin.InitialModel()
+}
@@ -55,6 +55,22 @@ func TestSetItem(t *testing.T) {
 	})
 }
 
+func TestClear(t *testing.T) {
+	t.Run("should drop item content", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockitemUpdater(ctrl), time.Second)
+		model.SetItem(&models.ItemInfo{ID: "test-id"}, []byte("test content"))
+		model.state = UpdateText
+
+		model.Clear()
+		assert.Equal(t, LoadState, model.state)
+		assert.Nil(t, model.info)
+		assert.Nil(t, model.content)
+	})
+}
+
 func TestInit(t *testing.T) {
 	t.Run("should return nil command", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -2,7 +2,10 @@ package vault
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -10,9 +13,17 @@ import (
 	"github.com/rycln/gokeep/client/internal/tui/items/card"
 	"github.com/rycln/gokeep/client/internal/tui/items/logpass"
 	"github.com/rycln/gokeep/client/internal/tui/items/text"
+	"github.com/rycln/gokeep/client/internal/tui/shared/i18n"
+	"github.com/rycln/gokeep/client/internal/tui/shared/messages"
 	"github.com/rycln/gokeep/shared/models"
 )
 
+// Errors of organization input
+var (
+	errInvalidRole       = errors.New("invalid role")
+	errUnknownCollection = errors.New("collection not found")
+)
+
 // Init initializes the vault model and checks for required user
 func (m Model) Init() tea.Cmd {
 	if m.user == nil {
@@ -39,6 +50,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return handleErrorState(m, msg)
 	case BinaryInputState:
 		return handleBinaryInputState(m, msg)
+	case ShareInputState:
+		return handleShareInputState(m, msg)
 	}
 
 	var cmd tea.Cmd
@@ -66,12 +79,36 @@ func handleListState(m Model, msg tea.Msg) (Model, tea.Cmd) {
 				return m, tea.Quit
 			case "u", "г":
 				m.state = ProcessingState
+				if m.shared {
+					return m, m.loadShared()
+				}
 				return m, m.loadItems()
 			case "s", "ы":
+				if m.user != nil && m.user.Offline {
+					return m, func() tea.Msg { return ReauthReqMsg{} }
+				}
 				m.state = ProcessingState
 				return m, m.syncItems()
 			case "n", "т":
 				return m, func() tea.Msg { return AddItemReqMsg{User: m.user} }
+			case "h", "р":
+				if m.user != nil && m.user.Offline {
+					return m, func() tea.Msg { return ReauthReqMsg{} }
+				}
+				m.state = ProcessingState
+				return m, m.loadShared()
+			case "o", "щ":
+				return startOrgInput(m, CreateOrgAction)
+			case "e", "у":
+				if m.user != nil && m.user.Offline {
+					return m, func() tea.Msg { return ReauthReqMsg{} }
+				}
+				return m, func() tea.Msg { return EmergencyReqMsg{User: m.user} }
+			case "x", "ч":
+				if m.user != nil && m.user.Offline {
+					return m, func() tea.Msg { return ReauthReqMsg{} }
+				}
+				return m, func() tea.Msg { return AccountReqMsg{User: m.user} }
 			}
 		}
 	}
@@ -92,18 +129,54 @@ func (m Model) loadItems() tea.Cmd {
 			return ErrorMsg{Err: err}
 		}
 
+		cols, err := m.itemService.Collections(ctx, m.user.ID)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		byID := make(map[models.CollectionID]models.Collection, len(cols))
+		for _, col := range cols {
+			byID[col.ID] = col
+		}
+
 		ritems := make([]itemRender, len(items))
 		for i, item := range items {
 			ritems[i] = itemRender{
-				ID:        item.ID,
-				ItemType:  item.ItemType,
-				Name:      item.Name,
-				Metadata:  item.Metadata,
-				UpdatedAt: item.UpdatedAt,
+				ID:           item.ID,
+				ItemType:     item.ItemType,
+				Name:         item.Name,
+				Metadata:     item.Metadata,
+				UpdatedAt:    item.UpdatedAt,
+				CollectionID: item.CollectionID,
+			}
+			if col, ok := byID[item.CollectionID]; ok {
+				ritems[i].OrgID = col.OrgID
+				ritems[i].Collection = fmt.Sprintf(i18n.VaultCollectionLabel, col.OrgName, col.Name)
+				ritems[i].readOnly = !col.Role.CanWrite()
 			}
 		}
 
-		return ItemsMsg{Items: ritems}
+		// Personal vault goes first, then items grouped by collection
+		sort.SliceStable(ritems, func(i, j int) bool {
+			return ritems[i].Collection < ritems[j].Collection
+		})
+
+		return ItemsMsg{Items: ritems, Collections: cols}
+	}
+}
+
+// loadShared fetches and decrypts items shared with current user
+func (m Model) loadShared() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		items, err := m.shareSvc.ListSharedWithMe(ctx, m.user)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		return SharedItemsMsg{Items: sharedRenders(items)}
 	}
 }
 
@@ -141,25 +214,87 @@ func handleDetailState(m Model, msg tea.Msg) (Model, tea.Cmd) {
 			m.state = ProcessingState
 			return m, m.getContent()
 		case tea.KeyDelete:
+			if m.selected.Owner != "" || m.selected.readOnly {
+				return m, nil
+			}
 			m.state = ProcessingState
 			return m, m.deleteItem()
 		case tea.KeyInsert:
+			if m.selected.Owner != "" || m.selected.readOnly {
+				return m, nil
+			}
 			return m, m.updateItem()
+		case tea.KeyRunes:
+			switch msg.String() {
+			case "p", "з":
+				return startShareInput(m, ShareAction)
+			case "r", "к":
+				return startShareInput(m, RevokeAction)
+			case "m", "ь":
+				if m.selected.OrgID == "" {
+					return m, nil
+				}
+				return startOrgInput(m, AddMemberAction)
+			case "c", "с":
+				if m.selected.Owner != "" || m.selected.CollectionID != "" || len(m.collections) == 0 {
+					return m, nil
+				}
+				m.state = ShareInputState
+				m.action = MoveAction
+				m.input = ""
+				return m, nil
+			}
 		}
 	}
 
 	return m, nil
 }
 
+// startShareInput asks for recipient username of a share action
+// Sharing needs a server session, so offline users are sent to login first
+func startShareInput(m Model, action shareAction) (Model, tea.Cmd) {
+	if !m.selected.shareable() {
+		return m, nil
+	}
+	if m.user != nil && m.user.Offline {
+		return m, func() tea.Msg { return ReauthReqMsg{} }
+	}
+
+	m.state = ShareInputState
+	m.action = action
+	m.input = ""
+	return m, nil
+}
+
+// startOrgInput asks for input of an organization action
+// Organizations are managed on the server, so offline users are sent to login first
+func startOrgInput(m Model, action shareAction) (Model, tea.Cmd) {
+	if m.orgSvc == nil {
+		return m, nil
+	}
+	if m.user != nil && m.user.Offline {
+		return m, func() tea.Msg { return ReauthReqMsg{} }
+	}
+
+	m.state = ShareInputState
+	m.action = action
+	m.input = ""
+	return m, nil
+}
+
 // getContent retrieves and formats item content based on type
 func (m Model) getContent() tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
 		defer cancel()
 
-		contentBytes, err := m.itemService.GetContent(ctx, m.selected.ID)
-		if err != nil {
-			return ErrorMsg{Err: err}
+		var err error
+		contentBytes := m.selected.raw
+		if m.selected.Owner == "" {
+			contentBytes, err = m.itemService.GetContent(ctx, m.selected.ID)
+			if err != nil {
+				return ErrorMsg{Err: err}
+			}
 		}
 
 		var content string
@@ -221,21 +356,162 @@ func (m Model) updateItem() tea.Cmd {
 	}
 }
 
+// shareItem shares selected item with the entered recipient
+// Items are synced first because the server only accepts shares of known items
+func (m Model) shareItem() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		err := m.syncService.SyncUserItems(ctx, m.user)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		contentBytes, err := m.itemService.GetContent(ctx, m.selected.ID)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		info := &models.ItemInfo{
+			ID:        m.selected.ID,
+			UserID:    m.user.ID,
+			ItemType:  m.selected.ItemType,
+			Name:      m.selected.Name,
+			Metadata:  m.selected.Metadata,
+			UpdatedAt: m.selected.UpdatedAt,
+		}
+
+		err = m.shareSvc.ShareItem(ctx, m.user, info, contentBytes, m.input)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		return ShareSuccessMsg{Status: i18n.VaultShareSuccess}
+	}
+}
+
+// revokeShare revokes access of the entered recipient to selected item
+func (m Model) revokeShare() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		err := m.shareSvc.RevokeShare(ctx, m.user, m.selected.ID, m.input)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		return ShareSuccessMsg{Status: i18n.VaultRevokeSuccess}
+	}
+}
+
+// createOrg creates organization with the entered name and syncs its collection
+func (m Model) createOrg() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		_, err := m.orgSvc.CreateOrganization(ctx, m.user, m.input, i18n.VaultDefaultCollection)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		err = m.syncService.SyncUserItems(ctx, m.user)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		return OrgSuccessMsg{Status: i18n.VaultOrgCreated}
+	}
+}
+
+// addMember adds the entered account to organization of selected item
+// Input has form "username [role]", role defaults to member
+func (m Model) addMember() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		fields := strings.Fields(m.input)
+		role := models.RoleMember
+		if len(fields) > 1 {
+			role = models.Role(fields[1])
+		}
+		if !role.Valid() {
+			return ErrorMsg{Err: errInvalidRole}
+		}
+
+		err := m.orgSvc.AddMember(ctx, m.user, m.selected.OrgID, fields[0], role)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		return ShareSuccessMsg{Status: i18n.VaultMemberAdded}
+	}
+}
+
+// moveItem moves selected personal item to the entered collection
+func (m Model) moveItem() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		defer cancel()
+
+		var target *models.Collection
+		for i, col := range m.collections {
+			label := fmt.Sprintf(i18n.VaultCollectionLabel, col.OrgName, col.Name)
+			if col.Role.CanWrite() && (m.input == col.Name || m.input == label) {
+				target = &m.collections[i]
+				break
+			}
+		}
+		if target == nil {
+			return ErrorMsg{Err: errUnknownCollection}
+		}
+
+		info := &models.ItemInfo{
+			ID:        m.selected.ID,
+			UserID:    m.user.ID,
+			ItemType:  m.selected.ItemType,
+			Name:      m.selected.Name,
+			Metadata:  m.selected.Metadata,
+			UpdatedAt: m.selected.UpdatedAt,
+		}
+
+		err := m.itemService.MoveToCollection(ctx, info, target.ID)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+
+		return OrgSuccessMsg{Status: i18n.VaultMoveSuccess}
+	}
+}
+
 // handleProcessingState processes background operation results
 func handleProcessingState(m Model, msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case ErrorMsg:
-		m.errMsg = msg.Err.Error()
+		m.errMsg = messages.ErrorText(msg.Err)
 		m.state = ErrorState
 	case ItemsMsg:
-		m.items = msg.Items
+		m.shared = false
+		m.collections = msg.Collections
+		m.resetTitle()
+		return m, m.showItems(msg.Items)
+	case SharedItemsMsg:
+		m.shared = true
+		m.list.Title = i18n.VaultSharedTitle
+		return m, m.showItems(msg.Items)
+	case ShareSuccessMsg:
 		m.state = ListState
-
-		items := make([]list.Item, len(msg.Items))
-		for i, item := range msg.Items {
-			items[i] = item
-		}
-		return m, m.list.SetItems(items)
+		m.selected = nil
+		m.input = ""
+		return m, m.list.NewStatusMessage(msg.Status)
+	case OrgSuccessMsg:
+		m.state = UpdateState
+		m.selected = nil
+		m.input = ""
+		return m, m.list.NewStatusMessage(msg.Status)
 	case ContentMsg:
 		m.selected.Content = msg.Content
 		m.state = DetailState
@@ -248,6 +524,18 @@ func handleProcessingState(m Model, msg tea.Msg) (Model, tea.Cmd) {
 	return m, nil
 }
 
+// showItems replaces displayed items and returns to the list
+func (m *Model) showItems(ritems []itemRender) tea.Cmd {
+	m.items = ritems
+	m.state = ListState
+
+	items := make([]list.Item, len(ritems))
+	for i, item := range ritems {
+		items[i] = item
+	}
+	return m.list.SetItems(items)
+}
+
 // handleBinaryInputState manages binary file path input
 func handleBinaryInputState(m Model, msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -270,6 +558,45 @@ func handleBinaryInputState(m Model, msg tea.Msg) (Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleShareInputState manages recipient username and organization input
+func handleShareInputState(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.state = DetailState
+			if m.action == CreateOrgAction {
+				m.state = ListState
+			}
+			m.input = ""
+		case tea.KeyEnter:
+			if strings.TrimSpace(m.input) == "" {
+				return m, nil
+			}
+			m.state = ProcessingState
+			switch m.action {
+			case RevokeAction:
+				return m, m.revokeShare()
+			case CreateOrgAction:
+				return m, m.createOrg()
+			case AddMemberAction:
+				return m, m.addMember()
+			case MoveAction:
+				return m, m.moveItem()
+			}
+			return m, m.shareItem()
+		case tea.KeyBackspace:
+			if len(m.input) > 0 {
+				m.input = m.input[:len(m.input)-1]
+			}
+		case tea.KeyRunes:
+			m.input += msg.String()
+		}
+	}
+
+	return m, nil
+}
+
 // handleErrorState manages error display and recovery
 func handleErrorState(m Model, msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -102,6 +102,58 @@ func (mr *MockitemDeleterMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockitemDeleter)(nil).Delete), arg0, arg1)
 }
 
+// MockcollectionManager is a mock of collectionManager interface.
+type MockcollectionManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockcollectionManagerMockRecorder
+}
+
+// MockcollectionManagerMockRecorder is the mock recorder for MockcollectionManager.
+type MockcollectionManagerMockRecorder struct {
+	mock *MockcollectionManager
+}
+
+// NewMockcollectionManager creates a new mock instance.
+func NewMockcollectionManager(ctrl *gomock.Controller) *MockcollectionManager {
+	mock := &MockcollectionManager{ctrl: ctrl}
+	mock.recorder = &MockcollectionManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcollectionManager) EXPECT() *MockcollectionManagerMockRecorder {
+	return m.recorder
+}
+
+// Collections mocks base method.
+func (m *MockcollectionManager) Collections(arg0 context.Context, arg1 models.UserID) ([]models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Collections", arg0, arg1)
+	ret0, _ := ret[0].([]models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Collections indicates an expected call of Collections.
+func (mr *MockcollectionManagerMockRecorder) Collections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collections", reflect.TypeOf((*MockcollectionManager)(nil).Collections), arg0, arg1)
+}
+
+// MoveToCollection mocks base method.
+func (m *MockcollectionManager) MoveToCollection(arg0 context.Context, arg1 *models.ItemInfo, arg2 models.CollectionID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveToCollection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveToCollection indicates an expected call of MoveToCollection.
+func (mr *MockcollectionManagerMockRecorder) MoveToCollection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveToCollection", reflect.TypeOf((*MockcollectionManager)(nil).MoveToCollection), arg0, arg1, arg2)
+}
+
 // MockitemService is a mock of itemService interface.
 type MockitemService struct {
 	ctrl     *gomock.Controller
@@ -125,6 +177,21 @@ func (m *MockitemService) EXPECT() *MockitemServiceMockRecorder {
 	return m.recorder
 }
 
+// Collections mocks base method.
+func (m *MockitemService) Collections(arg0 context.Context, arg1 models.UserID) ([]models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Collections", arg0, arg1)
+	ret0, _ := ret[0].([]models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Collections indicates an expected call of Collections.
+func (mr *MockitemServiceMockRecorder) Collections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collections", reflect.TypeOf((*MockitemService)(nil).Collections), arg0, arg1)
+}
+
 // Delete mocks base method.
 func (m *MockitemService) Delete(arg0 context.Context, arg1 models.ItemID) error {
 	m.ctrl.T.Helper()
@@ -169,6 +236,20 @@ func (mr *MockitemServiceMockRecorder) List(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockitemService)(nil).List), arg0, arg1)
 }
 
+// MoveToCollection mocks base method.
+func (m *MockitemService) MoveToCollection(arg0 context.Context, arg1 *models.ItemInfo, arg2 models.CollectionID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveToCollection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveToCollection indicates an expected call of MoveToCollection.
+func (mr *MockitemServiceMockRecorder) MoveToCollection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveToCollection", reflect.TypeOf((*MockitemService)(nil).MoveToCollection), arg0, arg1, arg2)
+}
+
 // MocksyncService is a mock of syncService interface.
 type MocksyncService struct {
 	ctrl     *gomock.Controller
@@ -205,3 +286,121 @@ func (mr *MocksyncServiceMockRecorder) SyncUserItems(arg0, arg1 interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncUserItems", reflect.TypeOf((*MocksyncService)(nil).SyncUserItems), arg0, arg1)
 }
+
+// MockshareService is a mock of shareService interface.
+type MockshareService struct {
+	ctrl     *gomock.Controller
+	recorder *MockshareServiceMockRecorder
+}
+
+// MockshareServiceMockRecorder is the mock recorder for MockshareService.
+type MockshareServiceMockRecorder struct {
+	mock *MockshareService
+}
+
+// NewMockshareService creates a new mock instance.
+func NewMockshareService(ctrl *gomock.Controller) *MockshareService {
+	mock := &MockshareService{ctrl: ctrl}
+	mock.recorder = &MockshareServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockshareService) EXPECT() *MockshareServiceMockRecorder {
+	return m.recorder
+}
+
+// ListSharedWithMe mocks base method.
+func (m *MockshareService) ListSharedWithMe(arg0 context.Context, arg1 *models.User) ([]models.SharedItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSharedWithMe", arg0, arg1)
+	ret0, _ := ret[0].([]models.SharedItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSharedWithMe indicates an expected call of ListSharedWithMe.
+func (mr *MockshareServiceMockRecorder) ListSharedWithMe(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSharedWithMe", reflect.TypeOf((*MockshareService)(nil).ListSharedWithMe), arg0, arg1)
+}
+
+// RevokeShare mocks base method.
+func (m *MockshareService) RevokeShare(arg0 context.Context, arg1 *models.User, arg2 models.ItemID, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShare", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockshareServiceMockRecorder) RevokeShare(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockshareService)(nil).RevokeShare), arg0, arg1, arg2, arg3)
+}
+
+// ShareItem mocks base method.
+func (m *MockshareService) ShareItem(arg0 context.Context, arg1 *models.User, arg2 *models.ItemInfo, arg3 []byte, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareItem", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShareItem indicates an expected call of ShareItem.
+func (mr *MockshareServiceMockRecorder) ShareItem(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareItem", reflect.TypeOf((*MockshareService)(nil).ShareItem), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MockorgService is a mock of orgService interface.
+type MockorgService struct {
+	ctrl     *gomock.Controller
+	recorder *MockorgServiceMockRecorder
+}
+
+// MockorgServiceMockRecorder is the mock recorder for MockorgService.
+type MockorgServiceMockRecorder struct {
+	mock *MockorgService
+}
+
+// NewMockorgService creates a new mock instance.
+func NewMockorgService(ctrl *gomock.Controller) *MockorgService {
+	mock := &MockorgService{ctrl: ctrl}
+	mock.recorder = &MockorgServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorgService) EXPECT() *MockorgServiceMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockorgService) AddMember(arg0 context.Context, arg1 *models.User, arg2 models.OrgID, arg3 string, arg4 models.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockorgServiceMockRecorder) AddMember(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockorgService)(nil).AddMember), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockorgService) CreateOrganization(arg0 context.Context, arg1 *models.User, arg2, arg3 string) (*models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockorgServiceMockRecorder) CreateOrganization(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockorgService)(nil).CreateOrganization), arg0, arg1, arg2, arg3)
+}
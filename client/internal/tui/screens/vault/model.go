@@ -9,6 +9,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/rycln/gokeep/client/internal/tui/shared/i18n"
 	"github.com/rycln/gokeep/client/internal/tui/shared/styles"
@@ -28,6 +29,19 @@ const (
 	BinaryInputState              // Binary input processing
 	ProcessingState               // Background operation in progress
 	ErrorState                    // Error display state
+	ShareInputState               // Recipient username or organization input
+)
+
+// shareAction represents operation applied to the entered text
+type shareAction int
+
+// Share actions
+const (
+	ShareAction     shareAction = iota // Share selected item with recipient
+	RevokeAction                       // Revoke recipient access to selected item
+	CreateOrgAction                    // Create organization with the entered name
+	AddMemberAction                    // Add member to organization of selected item
+	MoveAction                         // Move selected item to the entered collection
 )
 
 // Message types for vault screen communication
@@ -47,8 +61,29 @@ type (
 	// SyncSuccessMsg confirms successful item sync
 	SyncSuccessMsg struct{}
 
+	// ReauthReqMsg requests login to open a server session before sync
+	ReauthReqMsg struct{}
+
+	// EmergencyReqMsg requests showing emergency access screen
+	EmergencyReqMsg struct{ User *models.User }
+
+	// AccountReqMsg requests showing account management screen
+	AccountReqMsg struct{ User *models.User }
+
 	// ItemsMsg delivers list of items for display
-	ItemsMsg struct{ Items []itemRender }
+	ItemsMsg struct {
+		Items       []itemRender
+		Collections []models.Collection
+	}
+
+	// ShareSuccessMsg confirms successful share or revocation
+	ShareSuccessMsg struct{ Status string }
+
+	// OrgSuccessMsg confirms organization change that requires reloading items
+	OrgSuccessMsg struct{ Status string }
+
+	// SharedItemsMsg delivers items shared with the user
+	SharedItemsMsg struct{ Items []itemRender }
 
 	// ContentMsg delivers item content for detail view
 	ContentMsg struct{ Content string }
@@ -68,10 +103,17 @@ type itemDeleter interface {
 	Delete(context.Context, models.ItemID) error
 }
 
+// collectionManager defines interface for organization collections of items
+type collectionManager interface {
+	Collections(context.Context, models.UserID) ([]models.Collection, error)
+	MoveToCollection(context.Context, *models.ItemInfo, models.CollectionID) error
+}
+
 // itemService combines item management interfaces
 type itemService interface {
 	itemGetter
 	itemDeleter
+	collectionManager
 }
 
 // syncService defines interface for sync operation
@@ -79,6 +121,19 @@ type syncService interface {
 	SyncUserItems(context.Context, *models.User) error
 }
 
+// shareService defines interface for sharing items between accounts
+type shareService interface {
+	ShareItem(context.Context, *models.User, *models.ItemInfo, []byte, string) error
+	ListSharedWithMe(context.Context, *models.User) ([]models.SharedItem, error)
+	RevokeShare(context.Context, *models.User, models.ItemID, string) error
+}
+
+// orgService defines interface for managing organizations
+type orgService interface {
+	CreateOrganization(context.Context, *models.User, string, string) (*models.Collection, error)
+	AddMember(context.Context, *models.User, models.OrgID, string, models.Role) error
+}
+
 // itemRender represents formatted item for display
 type itemRender struct {
 	ID        models.ItemID   // Unique item identifier
@@ -87,13 +142,33 @@ type itemRender struct {
 	Metadata  string          // Additional description
 	UpdatedAt time.Time       // Last modification time
 	Content   string          // Formatted content
+	Owner     string          // Owner username of an item shared with the user
+	raw       []byte          // Decrypted content of a shared item
+
+	CollectionID models.CollectionID // Organization collection, empty for personal items
+	OrgID        models.OrgID        // Organization owning the collection
+	Collection   string              // Organization and collection names
+	readOnly     bool                // Collection role forbids changes
+}
+
+// shareable reports whether item can be shared with another account
+func (i itemRender) shareable() bool {
+	return i.Owner == "" && i.CollectionID == "" && (i.ItemType == models.TypePassword || i.ItemType == models.TypeCard)
 }
 
 // FilterValue implements list.Item interface for filtering
 func (i itemRender) FilterValue() string { return i.Name }
 
 // Title implements list.Item interface for display
-func (i itemRender) Title() string { return i.Name }
+func (i itemRender) Title() string {
+	if i.Owner != "" {
+		return fmt.Sprintf(i18n.VaultSharedItemTitle, i.Name, i.Owner)
+	}
+	if i.Collection != "" {
+		return fmt.Sprintf(i18n.VaultCollectionItemTitle, i.Collection, i.Name)
+	}
+	return i.Name
+}
 
 // Description implements list.Item interface for display
 func (i itemRender) Description() string {
@@ -110,12 +185,23 @@ type Model struct {
 	errMsg      string       // Last error message
 	itemService itemService  // Item service interface
 	syncService syncService
-	user        *models.User  // Current authenticated user
-	timeout     time.Duration // UI message timeout
+	shareSvc    shareService        // Item sharing service
+	orgSvc      orgService          // Organization management service
+	collections []models.Collection // Organization collections of the user
+	shared      bool                // List shows items shared with the user
+	action      shareAction         // Pending action for the entered recipient
+	user        *models.User        // Current authenticated user
+	timeout     time.Duration       // UI message timeout
 }
 
 // InitialModel creates new vault model with dependencies
-func InitialModel(itemService itemService, syncService syncService, timeout time.Duration) Model {
+func InitialModel(
+	itemService itemService,
+	syncService syncService,
+	shareSvc shareService,
+	orgSvc orgService,
+	timeout time.Duration,
+) Model {
 	delegate := list.NewDefaultDelegate()
 	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
 		Border(lipgloss.ThickBorder(), false, false, false, true).
@@ -137,6 +223,22 @@ func InitialModel(itemService itemService, syncService syncService, timeout time
 				key.WithKeys("s"),
 				key.WithHelp("s", i18n.VaultSyncHelp),
 			),
+			key.NewBinding(
+				key.WithKeys("h"),
+				key.WithHelp("h", i18n.VaultSharedHelp),
+			),
+			key.NewBinding(
+				key.WithKeys("o"),
+				key.WithHelp("o", i18n.VaultCreateOrgHelp),
+			),
+			key.NewBinding(
+				key.WithKeys("e"),
+				key.WithHelp("e", i18n.VaultEmergencyHelp),
+			),
+			key.NewBinding(
+				key.WithKeys("x"),
+				key.WithHelp("x", i18n.VaultAccountHelp),
+			),
 		}
 	}
 
@@ -153,6 +255,8 @@ func InitialModel(itemService itemService, syncService syncService, timeout time
 		list:        l,
 		itemService: itemService,
 		syncService: syncService,
+		shareSvc:    shareSvc,
+		orgSvc:      orgSvc,
 		timeout:     timeout,
 	}
 }
@@ -160,10 +264,59 @@ func InitialModel(itemService itemService, syncService syncService, timeout time
 // SetUser updates current authenticated user
 func (m *Model) SetUser(user *models.User) {
 	m.user = user
-
+	m.resetTitle()
 }
 
 // SetUpdateState resets view to update items list
 func (m *Model) SetUpdateState() {
 	m.state = UpdateState
 }
+
+// Clear drops loaded items and decrypted content
+// Items are reloaded on the next update
+func (m *Model) Clear() {
+	m.items = nil
+	m.collections = nil
+	m.selected = nil
+	m.input = ""
+	m.errMsg = ""
+	m.shared = false
+	m.resetTitle()
+	m.list.SetItems(nil)
+	m.list.ResetFilter()
+	m.state = UpdateState
+}
+
+// ShowShared displays read-only items of another account
+// Used for vaults released through emergency access
+func (m *Model) ShowShared(owner string, items []models.SharedItem) tea.Cmd {
+	m.shared = true
+	m.selected = nil
+	m.list.Title = fmt.Sprintf(i18n.VaultEmergencyTitle, owner)
+	return m.showItems(sharedRenders(items))
+}
+
+// sharedRenders converts decrypted items of another account for display
+func sharedRenders(items []models.SharedItem) []itemRender {
+	ritems := make([]itemRender, len(items))
+	for i, item := range items {
+		ritems[i] = itemRender{
+			ID:        item.ID,
+			ItemType:  item.ItemType,
+			Name:      item.Name,
+			Metadata:  item.Metadata,
+			UpdatedAt: item.SharedAt,
+			Owner:     item.Owner,
+			raw:       item.Content,
+		}
+	}
+	return ritems
+}
+
+// resetTitle restores list title of the personal vault
+func (m *Model) resetTitle() {
+	m.list.Title = i18n.VaultTitle
+	if m.user != nil && m.user.Offline {
+		m.list.Title = i18n.VaultOfflineTitle
+	}
+}
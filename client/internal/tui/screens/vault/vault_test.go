@@ -2,15 +2,18 @@ package vault
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/golang/mock/gomock"
 	"github.com/rycln/gokeep/client/internal/tui/screens/vault/mocks"
 	"github.com/rycln/gokeep/client/internal/tui/shared/i18n"
 	"github.com/rycln/gokeep/shared/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInitialModel(t *testing.T) {
@@ -22,7 +25,7 @@ func TestInitialModel(t *testing.T) {
 		mockSyncService := mocks.NewMocksyncService(ctrl)
 		timeout := 5 * time.Second
 
-		model := InitialModel(mockItemService, mockSyncService, timeout)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, timeout)
 
 		assert.Equal(t, UpdateState, model.state)
 		assert.NotNil(t, model.list)
@@ -39,7 +42,7 @@ func TestSetUser(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		user := &models.User{ID: "test-user"}
 
 		model.SetUser(user)
@@ -54,7 +57,7 @@ func TestSetUpdateState(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		model.state = ListState
 
 		model.SetUpdateState()
@@ -62,6 +65,29 @@ func TestSetUpdateState(t *testing.T) {
 	})
 }
 
+func TestClear(t *testing.T) {
+	t.Run("should drop items and decrypted content", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockitemService(ctrl), mocks.NewMocksyncService(ctrl), nil, nil, time.Second)
+		item := itemRender{ID: "item1", Name: "secret", Content: "password"}
+		model.items = []itemRender{item}
+		model.list.SetItems([]list.Item{item})
+		model.selected = &item
+		model.input = "/tmp/file"
+		model.state = DetailState
+
+		model.Clear()
+
+		assert.Nil(t, model.items)
+		assert.Nil(t, model.selected)
+		assert.Empty(t, model.input)
+		assert.Empty(t, model.list.Items())
+		assert.Equal(t, UpdateState, model.state)
+	})
+}
+
 func TestInit(t *testing.T) {
 	t.Run("should return error when user not set", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -69,7 +95,7 @@ func TestInit(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 
 		cmd := model.Init()
 		assert.NotNil(t, cmd)
@@ -86,7 +112,7 @@ func TestInit(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		model.SetUser(&models.User{ID: "test-user"})
 
 		cmd := model.Init()
@@ -101,7 +127,7 @@ func TestLoadItems(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		user := &models.User{ID: "test-user"}
 		model.SetUser(user)
 
@@ -113,6 +139,9 @@ func TestLoadItems(t *testing.T) {
 		mockItemService.EXPECT().
 			List(gomock.Any(), user.ID).
 			Return(expectedItems, nil)
+		mockItemService.EXPECT().
+			Collections(gomock.Any(), user.ID).
+			Return(nil, nil)
 
 		cmd := model.loadItems()
 		msg := cmd().(ItemsMsg)
@@ -127,7 +156,7 @@ func TestLoadItems(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		user := &models.User{ID: "test-user"}
 		model.SetUser(user)
 
@@ -150,7 +179,7 @@ func TestSyncItems(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		user := &models.User{ID: models.UserID("test-user")}
 		model.SetUser(user)
 
@@ -170,7 +199,7 @@ func TestSyncItems(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		user := &models.User{ID: models.UserID("test-user")}
 		model.SetUser(user)
 
@@ -193,7 +222,7 @@ func TestDeleteItem(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		model.selected = &itemRender{ID: models.ItemID("test-id")}
 
 		mockItemService.EXPECT().
@@ -214,7 +243,7 @@ func TestUpdate(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		model.state = UpdateState
 
 		newModel, cmd := model.Update(nil)
@@ -230,7 +259,7 @@ func TestHandleListState(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		model.state = ListState
 
 		newModel, cmd := handleListState(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
@@ -244,13 +273,28 @@ func TestHandleListState(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		model.state = ListState
 
 		newModel, cmd := handleListState(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
 		assert.Equal(t, ProcessingState, newModel.state)
 		assert.NotNil(t, cmd)
 	})
+
+	t.Run("should request login on 's' key when offline", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockitemService(ctrl), mocks.NewMocksyncService(ctrl), nil, nil, time.Second)
+		model.SetUser(&models.User{ID: "test-user", Offline: true})
+		model.state = ListState
+
+		newModel, cmd := handleListState(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+		assert.Equal(t, ListState, newModel.state)
+		require.NotNil(t, cmd)
+		assert.Equal(t, ReauthReqMsg{}, cmd())
+		assert.Equal(t, i18n.VaultOfflineTitle, newModel.list.Title)
+	})
 }
 
 func TestHandleDetailState(t *testing.T) {
@@ -260,7 +304,7 @@ func TestHandleDetailState(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		model.state = DetailState
 		model.selected = &itemRender{ID: "test-id"}
 
@@ -274,7 +318,7 @@ func TestHandleDetailState(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		model.state = DetailState
 		model.selected = &itemRender{ID: "test-id", ItemType: models.TypeText}
 
@@ -291,7 +335,7 @@ func TestHandleProcessingState(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		model.state = ProcessingState
 
 		testItems := []itemRender{
@@ -310,7 +354,7 @@ func TestHandleProcessingState(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		model.state = ProcessingState
 
 		testErr := errors.New("test error")
@@ -320,13 +364,32 @@ func TestHandleProcessingState(t *testing.T) {
 		assert.Equal(t, testErr.Error(), newModel.errMsg)
 	})
 
+	t.Run("should show localized message of server error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockItemService := mocks.NewMockitemService(ctrl)
+		mockSyncService := mocks.NewMocksyncService(ctrl)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
+		model.state = ProcessingState
+
+		testErr := &models.ServerError{
+			Reason: models.ReasonQuotaExceeded,
+			Err:    errors.New("rpc error: code = ResourceExhausted desc = storage quota exceeded"),
+		}
+		newModel, _ := handleProcessingState(model, ErrorMsg{Err: fmt.Errorf("sync: %w", testErr)})
+
+		assert.Equal(t, ErrorState, newModel.state)
+		assert.Equal(t, i18n.ErrQuotaExceeded, newModel.errMsg)
+	})
+
 	t.Run("should handle SyncSuccessMsg correctly", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		model.state = ProcessingState
 
 		newModel, _ := handleProcessingState(model, SyncSuccessMsg{})
@@ -341,7 +404,7 @@ func TestView(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		model.state = ProcessingState
 
 		view := model.View()
@@ -354,7 +417,7 @@ func TestView(t *testing.T) {
 
 		mockItemService := mocks.NewMockitemService(ctrl)
 		mockSyncService := mocks.NewMocksyncService(ctrl)
-		model := InitialModel(mockItemService, mockSyncService, time.Second)
+		model := InitialModel(mockItemService, mockSyncService, nil, nil, time.Second)
 		model.state = ErrorState
 		model.errMsg = "test error"
 
@@ -362,3 +425,315 @@ func TestView(t *testing.T) {
 		assert.Contains(t, view, "test error")
 	})
 }
+
+func TestSharing(t *testing.T) {
+	user := &models.User{ID: "test-user", JWT: "jwt"}
+	loginItem := itemRender{ID: "item1", ItemType: models.TypePassword, Name: "github", Metadata: "work"}
+
+	t.Run("should ask recipient on 'p' for login item", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockitemService(ctrl), mocks.NewMocksyncService(ctrl), mocks.NewMockshareService(ctrl), nil, time.Second)
+		model.SetUser(user)
+		model.state = DetailState
+		model.selected = &loginItem
+
+		newModel, cmd := handleDetailState(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+		assert.Equal(t, ShareInputState, newModel.state)
+		assert.Equal(t, ShareAction, newModel.action)
+		assert.Nil(t, cmd)
+	})
+
+	t.Run("should not share text items", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockitemService(ctrl), mocks.NewMocksyncService(ctrl), mocks.NewMockshareService(ctrl), nil, time.Second)
+		model.SetUser(user)
+		model.state = DetailState
+		model.selected = &itemRender{ID: "item2", ItemType: models.TypeText}
+
+		newModel, _ := handleDetailState(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+		assert.Equal(t, DetailState, newModel.state)
+	})
+
+	t.Run("should request login on 'p' when offline", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockitemService(ctrl), mocks.NewMocksyncService(ctrl), mocks.NewMockshareService(ctrl), nil, time.Second)
+		model.SetUser(&models.User{ID: "test-user", Offline: true})
+		model.state = DetailState
+		model.selected = &loginItem
+
+		newModel, cmd := handleDetailState(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+		assert.Equal(t, DetailState, newModel.state)
+		require.NotNil(t, cmd)
+		assert.Equal(t, ReauthReqMsg{}, cmd())
+	})
+
+	t.Run("should sync and share item with entered recipient", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockItemService := mocks.NewMockitemService(ctrl)
+		mockSyncService := mocks.NewMocksyncService(ctrl)
+		mockShareService := mocks.NewMockshareService(ctrl)
+		model := InitialModel(mockItemService, mockSyncService, mockShareService, nil, time.Second)
+		model.SetUser(user)
+		model.state = ShareInputState
+		model.selected = &loginItem
+
+		for _, r := range "bob" {
+			model, _ = handleShareInputState(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+
+		gomock.InOrder(
+			mockSyncService.EXPECT().SyncUserItems(gomock.Any(), user).Return(nil),
+			mockItemService.EXPECT().GetContent(gomock.Any(), loginItem.ID).Return([]byte("content"), nil),
+			mockShareService.EXPECT().
+				ShareItem(gomock.Any(), user, &models.ItemInfo{
+					ID:       loginItem.ID,
+					UserID:   user.ID,
+					ItemType: loginItem.ItemType,
+					Name:     loginItem.Name,
+					Metadata: loginItem.Metadata,
+				}, []byte("content"), "bob").
+				Return(nil),
+		)
+
+		newModel, cmd := handleShareInputState(model, tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Equal(t, ProcessingState, newModel.state)
+		require.NotNil(t, cmd)
+		assert.Equal(t, ShareSuccessMsg{Status: i18n.VaultShareSuccess}, cmd())
+	})
+
+	t.Run("should revoke access of entered recipient", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockShareService := mocks.NewMockshareService(ctrl)
+		model := InitialModel(mocks.NewMockitemService(ctrl), mocks.NewMocksyncService(ctrl), mockShareService, nil, time.Second)
+		model.SetUser(user)
+		model.state = ShareInputState
+		model.action = RevokeAction
+		model.selected = &loginItem
+		model.input = "bob"
+
+		mockShareService.EXPECT().
+			RevokeShare(gomock.Any(), user, loginItem.ID, "bob").
+			Return(nil)
+
+		_, cmd := handleShareInputState(model, tea.KeyMsg{Type: tea.KeyEnter})
+		require.NotNil(t, cmd)
+		assert.Equal(t, ShareSuccessMsg{Status: i18n.VaultRevokeSuccess}, cmd())
+	})
+
+	t.Run("should return to detail on Escape", func(t *testing.T) {
+		model := InitialModel(nil, nil, nil, nil, time.Second)
+		model.state = ShareInputState
+		model.selected = &loginItem
+		model.input = "bo"
+
+		newModel, _ := handleShareInputState(model, tea.KeyMsg{Type: tea.KeyEsc})
+		assert.Equal(t, DetailState, newModel.state)
+		assert.Empty(t, newModel.input)
+	})
+
+	t.Run("should load items shared with user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockShareService := mocks.NewMockshareService(ctrl)
+		model := InitialModel(mocks.NewMockitemService(ctrl), mocks.NewMocksyncService(ctrl), mockShareService, nil, time.Second)
+		model.SetUser(user)
+		model.state = ListState
+
+		sharedAt := time.Now()
+		mockShareService.EXPECT().
+			ListSharedWithMe(gomock.Any(), user).
+			Return([]models.SharedItem{{
+				ID:       "item1",
+				Owner:    "alice",
+				ItemType: models.TypeText,
+				Name:     "note",
+				Content:  []byte(`{"text":"hello"}`),
+				SharedAt: sharedAt,
+			}}, nil)
+
+		newModel, cmd := handleListState(model, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+		assert.Equal(t, ProcessingState, newModel.state)
+		require.NotNil(t, cmd)
+
+		msg := cmd().(SharedItemsMsg)
+		require.Len(t, msg.Items, 1)
+		assert.Equal(t, "alice", msg.Items[0].Owner)
+		assert.Equal(t, "note (от alice)", msg.Items[0].Title())
+
+		newModel, _ = handleProcessingState(newModel, msg)
+		assert.Equal(t, ListState, newModel.state)
+		assert.True(t, newModel.shared)
+		assert.Equal(t, i18n.VaultSharedTitle, newModel.list.Title)
+
+		newModel.selected = &msg.Items[0]
+		content := newModel.getContent()().(ContentMsg)
+		assert.Contains(t, content.Content, "hello")
+	})
+
+	t.Run("should not delete shared items", func(t *testing.T) {
+		model := InitialModel(nil, nil, nil, nil, time.Second)
+		model.state = DetailState
+		model.selected = &itemRender{ID: "item1", Owner: "alice"}
+
+		newModel, cmd := handleDetailState(model, tea.KeyMsg{Type: tea.KeyDelete})
+		assert.Equal(t, DetailState, newModel.state)
+		assert.Nil(t, cmd)
+	})
+
+	t.Run("should show status after share", func(t *testing.T) {
+		model := InitialModel(nil, nil, nil, nil, time.Second)
+		model.state = ProcessingState
+		model.selected = &loginItem
+
+		newModel, cmd := handleProcessingState(model, ShareSuccessMsg{Status: i18n.VaultShareSuccess})
+		assert.Equal(t, ListState, newModel.state)
+		assert.Nil(t, newModel.selected)
+		assert.NotNil(t, cmd)
+	})
+}
+
+func TestLoadItemsGrouping(t *testing.T) {
+	t.Run("personal items go first then collections", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockItemService := mocks.NewMockitemService(ctrl)
+		model := InitialModel(mockItemService, mocks.NewMocksyncService(ctrl), nil, nil, time.Second)
+		user := &models.User{ID: "test-user"}
+		model.SetUser(user)
+
+		mockItemService.EXPECT().
+			List(gomock.Any(), user.ID).
+			Return([]models.ItemInfo{
+				{ID: "item1", Name: "ops", CollectionID: "col2"},
+				{ID: "item2", Name: "shared", CollectionID: "col1"},
+				{ID: "item3", Name: "personal"},
+			}, nil)
+		mockItemService.EXPECT().
+			Collections(gomock.Any(), user.ID).
+			Return([]models.Collection{
+				{ID: "col1", OrgID: "org1", OrgName: "acme", Name: "common", Role: models.RoleMember},
+				{ID: "col2", OrgID: "org1", OrgName: "acme", Name: "prod", Role: models.RoleReadOnly},
+			}, nil)
+
+		msg := model.loadItems()().(ItemsMsg)
+
+		require.Len(t, msg.Items, 3)
+		assert.Equal(t, models.ItemID("item3"), msg.Items[0].ID)
+		assert.Equal(t, models.ItemID("item2"), msg.Items[1].ID)
+		assert.Equal(t, "acme / common", msg.Items[1].Collection)
+		assert.False(t, msg.Items[1].readOnly)
+		assert.Equal(t, models.ItemID("item1"), msg.Items[2].ID)
+		assert.True(t, msg.Items[2].readOnly)
+		assert.Equal(t, "[acme / prod] ops", msg.Items[2].Title())
+		assert.Len(t, msg.Collections, 2)
+	})
+}
+
+func TestReadOnlyCollectionItem(t *testing.T) {
+	t.Run("delete and update are ignored", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(mocks.NewMockitemService(ctrl), mocks.NewMocksyncService(ctrl), nil, nil, time.Second)
+		model.selected = &itemRender{ID: "item1", CollectionID: "col1", OrgID: "org1", readOnly: true}
+		model.state = DetailState
+
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyDelete})
+		assert.Nil(t, cmd)
+		assert.Equal(t, DetailState, updated.(Model).state)
+
+		updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyInsert})
+		assert.Nil(t, cmd)
+		assert.Equal(t, DetailState, updated.(Model).state)
+	})
+}
+
+func TestAddMember(t *testing.T) {
+	user := &models.User{ID: "test-user", JWT: "token"}
+
+	t.Run("member added with role", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrgService := mocks.NewMockorgService(ctrl)
+		model := InitialModel(mocks.NewMockitemService(ctrl), mocks.NewMocksyncService(ctrl), nil, mockOrgService, time.Second)
+		model.SetUser(user)
+		model.selected = &itemRender{ID: "item1", CollectionID: "col1", OrgID: "org1"}
+		model.state = DetailState
+
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+		model = updated.(Model)
+		require.Equal(t, ShareInputState, model.state)
+		require.Equal(t, AddMemberAction, model.action)
+
+		model.input = "bob readonly"
+		mockOrgService.EXPECT().
+			AddMember(gomock.Any(), user, models.OrgID("org1"), "bob", models.RoleReadOnly).
+			Return(nil)
+
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Equal(t, ProcessingState, updated.(Model).state)
+		assert.Equal(t, ShareSuccessMsg{Status: i18n.VaultMemberAdded}, cmd())
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		model := InitialModel(nil, nil, nil, mocks.NewMockorgService(ctrl), time.Second)
+		model.SetUser(user)
+		model.selected = &itemRender{ID: "item1", OrgID: "org1"}
+		model.input = "bob boss"
+
+		msg := model.addMember()()
+		assert.Equal(t, ErrorMsg{Err: errInvalidRole}, msg)
+	})
+}
+
+func TestMoveItem(t *testing.T) {
+	user := &models.User{ID: "test-user"}
+	cols := []models.Collection{
+		{ID: "col1", OrgName: "acme", Name: "common", Role: models.RoleMember},
+		{ID: "col2", OrgName: "acme", Name: "prod", Role: models.RoleReadOnly},
+	}
+
+	t.Run("moved to writable collection", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockItemService := mocks.NewMockitemService(ctrl)
+		model := InitialModel(mockItemService, nil, nil, nil, time.Second)
+		model.SetUser(user)
+		model.collections = cols
+		model.selected = &itemRender{ID: "item1", Name: "note"}
+		model.input = "acme / common"
+
+		mockItemService.EXPECT().
+			MoveToCollection(gomock.Any(), gomock.Any(), models.CollectionID("col1")).
+			Return(nil)
+
+		assert.Equal(t, OrgSuccessMsg{Status: i18n.VaultMoveSuccess}, model.moveItem()())
+	})
+
+	t.Run("read-only collection is rejected", func(t *testing.T) {
+		model := InitialModel(nil, nil, nil, nil, time.Second)
+		model.SetUser(user)
+		model.collections = cols
+		model.selected = &itemRender{ID: "item1", Name: "note"}
+		model.input = "prod"
+
+		assert.Equal(t, ErrorMsg{Err: errUnknownCollection}, model.moveItem()())
+	})
+}
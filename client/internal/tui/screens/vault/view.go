@@ -22,6 +22,18 @@ func (m Model) View() string {
 		return m.detailView()
 	case BinaryInputState:
 		return fmt.Sprintf(i18n.InputSavePathPrompt, m.input)
+	case ShareInputState:
+		switch m.action {
+		case RevokeAction:
+			return fmt.Sprintf(i18n.VaultRevokePrompt, m.input)
+		case CreateOrgAction:
+			return fmt.Sprintf(i18n.VaultCreateOrgPrompt, m.input)
+		case AddMemberAction:
+			return fmt.Sprintf(i18n.VaultAddMemberPrompt, m.input)
+		case MoveAction:
+			return fmt.Sprintf(i18n.VaultMovePrompt, m.collectionNames(), m.input)
+		}
+		return fmt.Sprintf(i18n.VaultSharePrompt, m.input)
 	case ErrorState:
 		return styles.ErrorStyle.Render(fmt.Sprintf(i18n.CommonError, m.errMsg))
 	default:
@@ -36,14 +48,48 @@ func (m Model) detailView() string {
 	b.WriteString(fmt.Sprintf(i18n.VaultObjectTitle, m.selected.Name) + "\n")
 	b.WriteString(fmt.Sprintf(i18n.VaultTypeTitle, m.selected.ItemType) + "\n")
 	b.WriteString(fmt.Sprintf(i18n.VaultDescTitle, m.selected.Metadata) + "\n")
+	if m.selected.Owner != "" {
+		b.WriteString(fmt.Sprintf(i18n.VaultOwnerTitle, m.selected.Owner) + "\n")
+	}
+	if m.selected.Collection != "" {
+		b.WriteString(fmt.Sprintf(i18n.VaultCollectionTitle, m.selected.Collection) + "\n")
+	}
 	b.WriteString(fmt.Sprintf(i18n.VaultUpdatedTitle, m.selected.UpdatedAt.String()))
 	if m.selected.Content != "" {
 		b.WriteString(m.selected.Content + "\n")
 	}
-	b.WriteString(i18n.VaultActions)
+	switch {
+	case m.selected.Owner != "", m.selected.readOnly:
+		b.WriteString(i18n.VaultSharedActions)
+	case m.selected.shareable():
+		b.WriteString(i18n.VaultShareActions)
+		if len(m.collections) > 0 {
+			b.WriteString(i18n.VaultMoveActions)
+		}
+		b.WriteString(i18n.VaultActions)
+	case m.selected.CollectionID != "":
+		b.WriteString(i18n.VaultCollectionActions)
+		b.WriteString(i18n.VaultActions)
+	default:
+		if len(m.collections) > 0 {
+			b.WriteString(i18n.VaultMoveActions)
+		}
+		b.WriteString(i18n.VaultActions)
+	}
 	return b.String()
 }
 
+// collectionNames lists collections available for moving items.
+func (m Model) collectionNames() string {
+	var names []string
+	for _, col := range m.collections {
+		if col.Role.CanWrite() {
+			names = append(names, fmt.Sprintf(i18n.VaultCollectionLabel, col.OrgName, col.Name))
+		}
+	}
+	return strings.Join(names, "\n")
+}
+
 // listView renders the main items list view.
 // Uses the bubbletea list component for consistent list rendering.
 func (m Model) listView() string {
@@ -8,10 +8,24 @@ const (
 	CommonPressESC    = "Нажмите ESC для отмены"
 	CommonPressAnyKey = "Нажмите любую клавишу..."
 
+	ErrInvalidCredentials = "неверный логин или пароль"
+	ErrAccountDisabled    = "аккаунт заблокирован администратором"
+	ErrTooManyAttempts    = "слишком много попыток, повторите позже"
+	ErrRetryAfter         = "слишком много попыток, повторите через %s"
+	ErrSessionExpired     = "сессия истекла, войдите заново"
+	ErrQuotaExceeded      = "превышена квота хранилища, удалите ненужные объекты"
+	ErrForbidden          = "недостаточно прав"
+	ErrNotFound           = "объект не найден"
+	ErrAlreadyExists      = "объект уже существует"
+	ErrTimeout            = "сервер не ответил вовремя, повторите попытку"
+	ErrUnavailable        = "сервер недоступен"
+	ErrInternal           = "внутренняя ошибка сервера"
+
 	InputDataPrompt     = "Введите данные:\n\n"
 	InputSavePathPrompt = "Введите путь сохранения файла:\n\n>%s\n\n" + CommonPressEnter + "\n\n" + CommonPressESC
 
 	VaultTitle                 = "GophKeeper"
+	VaultOfflineTitle          = "GophKeeper (офлайн)"
 	VaultListTitleNameSingular = "Объект"
 	VaultListTitleNamePlural   = "Объектов"
 	VaultObjectTitle           = "Объект: %s"
@@ -25,6 +39,58 @@ const (
 	VaultUpdateHelp  = "обновить"
 	VaultAddItemHelp = "добавить"
 	VaultSyncHelp    = "синхронизировать"
+	VaultSharedHelp  = "доступные мне"
+
+	VaultSharedTitle     = "GophKeeper (доступные мне)"
+	VaultSharedItemTitle = "%s (от %s)"
+	VaultOwnerTitle      = "Владелец: %s"
+	VaultShareActions    = "Нажмите P для передачи доступа...\n" +
+		"Нажмите R для отзыва доступа...\n"
+	VaultSharedActions = "Нажмите ENTER для загрузки данных...\n" +
+		"Нажмите ESC для возврата к списку..."
+	VaultSharePrompt   = "Введите логин получателя:\n\n>%s\n\n" + CommonPressEnter + "\n\n" + CommonPressESC
+	VaultRevokePrompt  = "Введите логин, у которого нужно отозвать доступ:\n\n>%s\n\n" + CommonPressEnter + "\n\n" + CommonPressESC
+	VaultShareSuccess  = "Доступ предоставлен"
+	VaultRevokeSuccess = "Доступ отозван"
+
+	VaultCreateOrgHelp       = "организация"
+	VaultCollectionLabel     = "%s / %s"
+	VaultCollectionItemTitle = "[%s] %s"
+	VaultCollectionTitle     = "Коллекция: %s"
+	VaultDefaultCollection   = "Общая"
+	VaultCollectionActions   = "Нажмите M для добавления участника организации...\n"
+	VaultMoveActions         = "Нажмите C для переноса в коллекцию организации...\n"
+	VaultCreateOrgPrompt     = "Введите название организации:\n\n>%s\n\n" + CommonPressEnter + "\n\n" + CommonPressESC
+	VaultAddMemberPrompt     = "Введите логин и роль (owner, admin, member, readonly) через пробел:\n\n>%s\n\n" +
+		CommonPressEnter + "\n\n" + CommonPressESC
+	VaultMovePrompt = "Доступные коллекции:\n%s\n\nВведите название коллекции:\n\n>%s\n\n" +
+		CommonPressEnter + "\n\n" + CommonPressESC
+	VaultOrgCreated  = "Организация создана"
+	VaultMemberAdded = "Участник добавлен"
+	VaultMoveSuccess = "Объект перенесён в коллекцию"
+
+	VaultEmergencyHelp  = "экстренный доступ"
+	VaultAccountHelp    = "аккаунт"
+	VaultEmergencyTitle = "GophKeeper (хранилище %s)"
+
+	EmergencyTitle         = "Экстренный доступ"
+	EmergencyContactsTitle = "Мои доверенные контакты:"
+	EmergencyGrantsTitle   = "Мне доверили доступ:"
+	EmergencyEmpty         = "  —"
+	EmergencyEntry         = "%s — ожидание %s — %s"
+	EmergencyActions       = "Нажмите A для добавления контакта...\n" +
+		"Нажмите R для запроса доступа, D для отказа в запросе...\n" +
+		"Нажмите ENTER для открытия доступного хранилища...\n" +
+		"Нажмите ESC для возврата к списку..."
+	EmergencyAddPrompt = "Введите логин контакта и срок ожидания через пробел (например, bob 72h):\n\n>%s\n\n" +
+		CommonPressEnter + "\n\n" + CommonPressESC
+	EmergencyStatusIdle      = "запросов нет"
+	EmergencyStatusRequested = "запрошен, доступ откроется %s"
+	EmergencyStatusDenied    = "отказано"
+	EmergencyStatusApproved  = "доступ открыт"
+	EmergencyContactAdded    = "Контакт добавлен"
+	EmergencyRequested       = "Доступ запрошен"
+	EmergencyDenied          = "В доступе отказано"
 
 	AuthLoginTitle     = "Вход в GophKeeper"
 	AuthRegisterTitle  = "Регистрация"
@@ -34,6 +100,44 @@ const (
 	AuthRegisterButton = "Регистрация"
 	AuthTabHint        = "Нажмите Enter для подтверждения, Tab для переключения"
 
+	AuthRecoveryTitle      = "Восстановление доступа"
+	AuthRecoveryKeyLabel   = "Ключ восстановления: %s"
+	AuthNewPasswordLabel   = "Новый пароль: %s"
+	AuthRecoveryHint       = "Нажмите Enter для подтверждения, Tab для возврата ко входу"
+	AuthRecoveryKeyHint    = "Нажмите Ctrl+R, если забыли пароль"
+	AuthRecoveryKeyTitle   = "Ваш ключ восстановления"
+	AuthRecoveryKeyWarning = "Сохраните ключ в надёжном месте: он показывается один раз\n" +
+		"и понадобится для доступа к данным, если вы забудете пароль."
+
+	AuthUsernameLength  = "Недопустимая длина логина"
+	AuthUsernameCharset = "Логин содержит недопустимые символы"
+	AuthUsernameTaken   = "Логин уже занят"
+	AuthPasswordLength  = "Пароль слишком короткий"
+	AuthPasswordWeak    = "Пароль слишком простой: используйте буквы разного регистра, цифры и символы"
+
+	LockTitle = "Хранилище заблокировано"
+	LockHint  = "Введите мастер-пароль и нажмите Enter для разблокировки"
+
+	AccountActivityTitle   = "Активность аккаунта"
+	AccountActivityEmpty   = "Событий нет"
+	AccountActivityEntry   = "%s  %-18s %-8s %-15s %s"
+	AccountActivityPage    = "Страница %d"
+	AccountActivityActions = "n/p - следующая/предыдущая страница, u - обновить, x - удалить аккаунт, ESC - назад"
+	AccountUnknownDevice   = "неизвестное устройство"
+
+	AccountActionRegister       = "регистрация"
+	AccountActionLogin          = "вход"
+	AccountActionRecover        = "восстановление"
+	AccountActionChangePassword = "смена пароля"
+	AccountActionDeleteAccount  = "удаление аккаунта"
+	AccountActionSync           = "синхронизация"
+	AccountResultSuccess        = "успешно"
+	AccountResultFailure        = "ошибка"
+
+	AccountDeleteTitle   = "Удаление аккаунта"
+	AccountDeleteWarning = "Аккаунт и все данные на сервере и на этом устройстве будут удалены без возможности восстановления."
+	AccountDeleteHint    = "Введите мастер-пароль и нажмите Enter для удаления, ESC для отмены"
+
 	AddSelectPrompt   = "Выберите тип хранимой информации:\n\n"
 	AddChoiceTemplate = "%s %s\n"
 
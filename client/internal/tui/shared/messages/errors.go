@@ -0,0 +1,42 @@
+package messages
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rycln/gokeep/client/internal/tui/shared/i18n"
+	"github.com/rycln/gokeep/shared/models"
+)
+
+// serverErrors maps reasons reported by the server to localized messages
+var serverErrors = map[string]string{
+	models.ReasonInvalidCredentials: i18n.ErrInvalidCredentials,
+	models.ReasonAccountDisabled:    i18n.ErrAccountDisabled,
+	models.ReasonTooManyAttempts:    i18n.ErrTooManyAttempts,
+	models.ReasonSessionExpired:     i18n.ErrSessionExpired,
+	models.ReasonQuotaExceeded:      i18n.ErrQuotaExceeded,
+	models.ReasonForbidden:          i18n.ErrForbidden,
+	models.ReasonNotFound:           i18n.ErrNotFound,
+	models.ReasonAlreadyExists:      i18n.ErrAlreadyExists,
+	models.ReasonTimeout:            i18n.ErrTimeout,
+	models.ReasonUnavailable:        i18n.ErrUnavailable,
+	models.ReasonInternal:           i18n.ErrInternal,
+}
+
+// ErrorText returns localized text of the error for display.
+// Errors without a known server reason are shown as is.
+func ErrorText(err error) string {
+	var serr *models.ServerError
+	if !errors.As(err, &serr) {
+		return err.Error()
+	}
+
+	if serr.Reason == models.ReasonTooManyAttempts && serr.RetryAfter > 0 {
+		return fmt.Sprintf(i18n.ErrRetryAfter, serr.RetryAfter.Round(time.Second))
+	}
+	if msg, ok := serverErrors[serr.Reason]; ok {
+		return msg
+	}
+	return err.Error()
+}
@@ -0,0 +1,31 @@
+package messages
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rycln/gokeep/client/internal/tui/shared/i18n"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorText(t *testing.T) {
+	testErr := errors.New("rpc error: code = Internal desc = internal error")
+
+	t.Run("should localize known reason", func(t *testing.T) {
+		err := &models.ServerError{Reason: models.ReasonInternal, Err: testErr}
+		assert.Equal(t, i18n.ErrInternal, ErrorText(fmt.Errorf("sync: %w", err)))
+	})
+
+	t.Run("should include retry delay", func(t *testing.T) {
+		err := &models.ServerError{Reason: models.ReasonTooManyAttempts, RetryAfter: 90 * time.Second, Err: testErr}
+		assert.Equal(t, fmt.Sprintf(i18n.ErrRetryAfter, "1m30s"), ErrorText(err))
+	})
+
+	t.Run("should fall back to error text", func(t *testing.T) {
+		assert.Equal(t, testErr.Error(), ErrorText(testErr))
+		assert.Equal(t, testErr.Error(), ErrorText(&models.ServerError{Reason: "UNKNOWN", Err: testErr}))
+	})
+}
@@ -19,9 +19,30 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GophKeeper_Register_FullMethodName = "/gophkeeper.GophKeeper/Register"
-	GophKeeper_Login_FullMethodName    = "/gophkeeper.GophKeeper/Login"
-	GophKeeper_Sync_FullMethodName     = "/gophkeeper.GophKeeper/Sync"
+	GophKeeper_Register_FullMethodName               = "/gophkeeper.GophKeeper/Register"
+	GophKeeper_Login_FullMethodName                  = "/gophkeeper.GophKeeper/Login"
+	GophKeeper_Sync_FullMethodName                   = "/gophkeeper.GophKeeper/Sync"
+	GophKeeper_Recover_FullMethodName                = "/gophkeeper.GophKeeper/Recover"
+	GophKeeper_ChangePassword_FullMethodName         = "/gophkeeper.GophKeeper/ChangePassword"
+	GophKeeper_DeleteAccount_FullMethodName          = "/gophkeeper.GophKeeper/DeleteAccount"
+	GophKeeper_SetKeyPair_FullMethodName             = "/gophkeeper.GophKeeper/SetKeyPair"
+	GophKeeper_GetPublicKey_FullMethodName           = "/gophkeeper.GophKeeper/GetPublicKey"
+	GophKeeper_ShareItem_FullMethodName              = "/gophkeeper.GophKeeper/ShareItem"
+	GophKeeper_ListSharedWithMe_FullMethodName       = "/gophkeeper.GophKeeper/ListSharedWithMe"
+	GophKeeper_RevokeShare_FullMethodName            = "/gophkeeper.GophKeeper/RevokeShare"
+	GophKeeper_CreateOrganization_FullMethodName     = "/gophkeeper.GophKeeper/CreateOrganization"
+	GophKeeper_CreateCollection_FullMethodName       = "/gophkeeper.GophKeeper/CreateCollection"
+	GophKeeper_AddMember_FullMethodName              = "/gophkeeper.GophKeeper/AddMember"
+	GophKeeper_ListMembers_FullMethodName            = "/gophkeeper.GophKeeper/ListMembers"
+	GophKeeper_ListCollections_FullMethodName        = "/gophkeeper.GophKeeper/ListCollections"
+	GophKeeper_AddEmergencyContact_FullMethodName    = "/gophkeeper.GophKeeper/AddEmergencyContact"
+	GophKeeper_ListEmergencyContacts_FullMethodName  = "/gophkeeper.GophKeeper/ListEmergencyContacts"
+	GophKeeper_ListEmergencyGrants_FullMethodName    = "/gophkeeper.GophKeeper/ListEmergencyGrants"
+	GophKeeper_RequestEmergencyAccess_FullMethodName = "/gophkeeper.GophKeeper/RequestEmergencyAccess"
+	GophKeeper_DenyEmergencyAccess_FullMethodName    = "/gophkeeper.GophKeeper/DenyEmergencyAccess"
+	GophKeeper_GetEmergencyVault_FullMethodName      = "/gophkeeper.GophKeeper/GetEmergencyVault"
+	GophKeeper_ListAuditEvents_FullMethodName        = "/gophkeeper.GophKeeper/ListAuditEvents"
+	GophKeeper_Watch_FullMethodName                  = "/gophkeeper.GophKeeper/Watch"
 )
 
 // GophKeeperClient is the client API for GophKeeper service.
@@ -31,6 +52,27 @@ type GophKeeperClient interface {
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*AuthResponse, error)
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*AuthResponse, error)
 	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error)
+	Recover(ctx context.Context, in *RecoverRequest, opts ...grpc.CallOption) (*AuthResponse, error)
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error)
+	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error)
+	SetKeyPair(ctx context.Context, in *KeyPairRequest, opts ...grpc.CallOption) (*KeyPairResponse, error)
+	GetPublicKey(ctx context.Context, in *PublicKeyRequest, opts ...grpc.CallOption) (*PublicKeyResponse, error)
+	ShareItem(ctx context.Context, in *ShareItemRequest, opts ...grpc.CallOption) (*ShareItemResponse, error)
+	ListSharedWithMe(ctx context.Context, in *ListSharedRequest, opts ...grpc.CallOption) (*ListSharedResponse, error)
+	RevokeShare(ctx context.Context, in *RevokeShareRequest, opts ...grpc.CallOption) (*RevokeShareResponse, error)
+	CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*CreateOrganizationResponse, error)
+	CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CreateCollectionResponse, error)
+	AddMember(ctx context.Context, in *AddMemberRequest, opts ...grpc.CallOption) (*AddMemberResponse, error)
+	ListMembers(ctx context.Context, in *ListMembersRequest, opts ...grpc.CallOption) (*ListMembersResponse, error)
+	ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error)
+	AddEmergencyContact(ctx context.Context, in *AddEmergencyContactRequest, opts ...grpc.CallOption) (*AddEmergencyContactResponse, error)
+	ListEmergencyContacts(ctx context.Context, in *ListEmergencyContactsRequest, opts ...grpc.CallOption) (*ListEmergencyContactsResponse, error)
+	ListEmergencyGrants(ctx context.Context, in *ListEmergencyGrantsRequest, opts ...grpc.CallOption) (*ListEmergencyGrantsResponse, error)
+	RequestEmergencyAccess(ctx context.Context, in *RequestEmergencyAccessRequest, opts ...grpc.CallOption) (*RequestEmergencyAccessResponse, error)
+	DenyEmergencyAccess(ctx context.Context, in *DenyEmergencyAccessRequest, opts ...grpc.CallOption) (*DenyEmergencyAccessResponse, error)
+	GetEmergencyVault(ctx context.Context, in *EmergencyVaultRequest, opts ...grpc.CallOption) (*EmergencyVaultResponse, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangeNotification], error)
 }
 
 type gophKeeperClient struct {
@@ -71,6 +113,225 @@ func (c *gophKeeperClient) Sync(ctx context.Context, in *SyncRequest, opts ...gr
 	return out, nil
 }
 
+func (c *gophKeeperClient) Recover(ctx context.Context, in *RecoverRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_Recover_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangePasswordResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ChangePassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAccountResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_DeleteAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) SetKeyPair(ctx context.Context, in *KeyPairRequest, opts ...grpc.CallOption) (*KeyPairResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeyPairResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_SetKeyPair_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) GetPublicKey(ctx context.Context, in *PublicKeyRequest, opts ...grpc.CallOption) (*PublicKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublicKeyResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_GetPublicKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ShareItem(ctx context.Context, in *ShareItemRequest, opts ...grpc.CallOption) (*ShareItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareItemResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ShareItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListSharedWithMe(ctx context.Context, in *ListSharedRequest, opts ...grpc.CallOption) (*ListSharedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSharedResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListSharedWithMe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) RevokeShare(ctx context.Context, in *RevokeShareRequest, opts ...grpc.CallOption) (*RevokeShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeShareResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_RevokeShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*CreateOrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOrganizationResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_CreateOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CreateCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCollectionResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_CreateCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) AddMember(ctx context.Context, in *AddMemberRequest, opts ...grpc.CallOption) (*AddMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddMemberResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_AddMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListMembers(ctx context.Context, in *ListMembersRequest, opts ...grpc.CallOption) (*ListMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMembersResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionsResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) AddEmergencyContact(ctx context.Context, in *AddEmergencyContactRequest, opts ...grpc.CallOption) (*AddEmergencyContactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddEmergencyContactResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_AddEmergencyContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListEmergencyContacts(ctx context.Context, in *ListEmergencyContactsRequest, opts ...grpc.CallOption) (*ListEmergencyContactsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEmergencyContactsResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListEmergencyContacts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListEmergencyGrants(ctx context.Context, in *ListEmergencyGrantsRequest, opts ...grpc.CallOption) (*ListEmergencyGrantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEmergencyGrantsResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListEmergencyGrants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) RequestEmergencyAccess(ctx context.Context, in *RequestEmergencyAccessRequest, opts ...grpc.CallOption) (*RequestEmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_RequestEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) DenyEmergencyAccess(ctx context.Context, in *DenyEmergencyAccessRequest, opts ...grpc.CallOption) (*DenyEmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DenyEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_DenyEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) GetEmergencyVault(ctx context.Context, in *EmergencyVaultRequest, opts ...grpc.CallOption) (*EmergencyVaultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmergencyVaultResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_GetEmergencyVault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangeNotification], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GophKeeper_ServiceDesc.Streams[0], GophKeeper_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, ChangeNotification]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GophKeeper_WatchClient = grpc.ServerStreamingClient[ChangeNotification]
+
 // GophKeeperServer is the server API for GophKeeper service.
 // All implementations must embed UnimplementedGophKeeperServer
 // for forward compatibility.
@@ -78,6 +339,27 @@ type GophKeeperServer interface {
 	Register(context.Context, *RegisterRequest) (*AuthResponse, error)
 	Login(context.Context, *LoginRequest) (*AuthResponse, error)
 	Sync(context.Context, *SyncRequest) (*SyncResponse, error)
+	Recover(context.Context, *RecoverRequest) (*AuthResponse, error)
+	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
+	DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error)
+	SetKeyPair(context.Context, *KeyPairRequest) (*KeyPairResponse, error)
+	GetPublicKey(context.Context, *PublicKeyRequest) (*PublicKeyResponse, error)
+	ShareItem(context.Context, *ShareItemRequest) (*ShareItemResponse, error)
+	ListSharedWithMe(context.Context, *ListSharedRequest) (*ListSharedResponse, error)
+	RevokeShare(context.Context, *RevokeShareRequest) (*RevokeShareResponse, error)
+	CreateOrganization(context.Context, *CreateOrganizationRequest) (*CreateOrganizationResponse, error)
+	CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error)
+	AddMember(context.Context, *AddMemberRequest) (*AddMemberResponse, error)
+	ListMembers(context.Context, *ListMembersRequest) (*ListMembersResponse, error)
+	ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error)
+	AddEmergencyContact(context.Context, *AddEmergencyContactRequest) (*AddEmergencyContactResponse, error)
+	ListEmergencyContacts(context.Context, *ListEmergencyContactsRequest) (*ListEmergencyContactsResponse, error)
+	ListEmergencyGrants(context.Context, *ListEmergencyGrantsRequest) (*ListEmergencyGrantsResponse, error)
+	RequestEmergencyAccess(context.Context, *RequestEmergencyAccessRequest) (*RequestEmergencyAccessResponse, error)
+	DenyEmergencyAccess(context.Context, *DenyEmergencyAccessRequest) (*DenyEmergencyAccessResponse, error)
+	GetEmergencyVault(context.Context, *EmergencyVaultRequest) (*EmergencyVaultResponse, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	Watch(*WatchRequest, grpc.ServerStreamingServer[ChangeNotification]) error
 	mustEmbedUnimplementedGophKeeperServer()
 }
 
@@ -97,6 +379,69 @@ func (UnimplementedGophKeeperServer) Login(context.Context, *LoginRequest) (*Aut
 func (UnimplementedGophKeeperServer) Sync(context.Context, *SyncRequest) (*SyncResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Sync not implemented")
 }
+func (UnimplementedGophKeeperServer) Recover(context.Context, *RecoverRequest) (*AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Recover not implemented")
+}
+func (UnimplementedGophKeeperServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedGophKeeperServer) DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
+func (UnimplementedGophKeeperServer) SetKeyPair(context.Context, *KeyPairRequest) (*KeyPairResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetKeyPair not implemented")
+}
+func (UnimplementedGophKeeperServer) GetPublicKey(context.Context, *PublicKeyRequest) (*PublicKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicKey not implemented")
+}
+func (UnimplementedGophKeeperServer) ShareItem(context.Context, *ShareItemRequest) (*ShareItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareItem not implemented")
+}
+func (UnimplementedGophKeeperServer) ListSharedWithMe(context.Context, *ListSharedRequest) (*ListSharedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSharedWithMe not implemented")
+}
+func (UnimplementedGophKeeperServer) RevokeShare(context.Context, *RevokeShareRequest) (*RevokeShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeShare not implemented")
+}
+func (UnimplementedGophKeeperServer) CreateOrganization(context.Context, *CreateOrganizationRequest) (*CreateOrganizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrganization not implemented")
+}
+func (UnimplementedGophKeeperServer) CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedGophKeeperServer) AddMember(context.Context, *AddMemberRequest) (*AddMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMember not implemented")
+}
+func (UnimplementedGophKeeperServer) ListMembers(context.Context, *ListMembersRequest) (*ListMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMembers not implemented")
+}
+func (UnimplementedGophKeeperServer) ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollections not implemented")
+}
+func (UnimplementedGophKeeperServer) AddEmergencyContact(context.Context, *AddEmergencyContactRequest) (*AddEmergencyContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddEmergencyContact not implemented")
+}
+func (UnimplementedGophKeeperServer) ListEmergencyContacts(context.Context, *ListEmergencyContactsRequest) (*ListEmergencyContactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEmergencyContacts not implemented")
+}
+func (UnimplementedGophKeeperServer) ListEmergencyGrants(context.Context, *ListEmergencyGrantsRequest) (*ListEmergencyGrantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEmergencyGrants not implemented")
+}
+func (UnimplementedGophKeeperServer) RequestEmergencyAccess(context.Context, *RequestEmergencyAccessRequest) (*RequestEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestEmergencyAccess not implemented")
+}
+func (UnimplementedGophKeeperServer) DenyEmergencyAccess(context.Context, *DenyEmergencyAccessRequest) (*DenyEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DenyEmergencyAccess not implemented")
+}
+func (UnimplementedGophKeeperServer) GetEmergencyVault(context.Context, *EmergencyVaultRequest) (*EmergencyVaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmergencyVault not implemented")
+}
+func (UnimplementedGophKeeperServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedGophKeeperServer) Watch(*WatchRequest, grpc.ServerStreamingServer[ChangeNotification]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
 func (UnimplementedGophKeeperServer) mustEmbedUnimplementedGophKeeperServer() {}
 func (UnimplementedGophKeeperServer) testEmbeddedByValue()                    {}
 
@@ -172,24 +517,773 @@ func _GophKeeper_Sync_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
-// GophKeeper_ServiceDesc is the grpc.ServiceDesc for GophKeeper service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var GophKeeper_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "gophkeeper.GophKeeper",
-	HandlerType: (*GophKeeperServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Register",
-			Handler:    _GophKeeper_Register_Handler,
-		},
-		{
-			MethodName: "Login",
-			Handler:    _GophKeeper_Login_Handler,
-		},
-		{
-			MethodName: "Sync",
-			Handler:    _GophKeeper_Sync_Handler,
+func _GophKeeper_Recover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).Recover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_Recover_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).Recover(ctx, req.(*RecoverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_DeleteAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_SetKeyPair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyPairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).SetKeyPair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_SetKeyPair_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).SetKeyPair(ctx, req.(*KeyPairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).GetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_GetPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).GetPublicKey(ctx, req.(*PublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ShareItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ShareItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ShareItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ShareItem(ctx, req.(*ShareItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListSharedWithMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSharedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListSharedWithMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListSharedWithMe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListSharedWithMe(ctx, req.(*ListSharedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_RevokeShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).RevokeShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_RevokeShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).RevokeShare(ctx, req.(*RevokeShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_CreateOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).CreateOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_CreateOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).CreateOrganization(ctx, req.(*CreateOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_CreateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).CreateCollection(ctx, req.(*CreateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_AddMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).AddMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_AddMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).AddMember(ctx, req.(*AddMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListMembers(ctx, req.(*ListMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListCollections(ctx, req.(*ListCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_AddEmergencyContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddEmergencyContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).AddEmergencyContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_AddEmergencyContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).AddEmergencyContact(ctx, req.(*AddEmergencyContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListEmergencyContacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEmergencyContactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListEmergencyContacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListEmergencyContacts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListEmergencyContacts(ctx, req.(*ListEmergencyContactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListEmergencyGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEmergencyGrantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListEmergencyGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListEmergencyGrants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListEmergencyGrants(ctx, req.(*ListEmergencyGrantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_RequestEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).RequestEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_RequestEmergencyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).RequestEmergencyAccess(ctx, req.(*RequestEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_DenyEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DenyEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).DenyEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_DenyEmergencyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).DenyEmergencyAccess(ctx, req.(*DenyEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_GetEmergencyVault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmergencyVaultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).GetEmergencyVault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_GetEmergencyVault_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).GetEmergencyVault(ctx, req.(*EmergencyVaultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GophKeeperServer).Watch(m, &grpc.GenericServerStream[WatchRequest, ChangeNotification]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GophKeeper_WatchServer = grpc.ServerStreamingServer[ChangeNotification]
+
+// GophKeeper_ServiceDesc is the grpc.ServiceDesc for GophKeeper service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GophKeeper_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gophkeeper.GophKeeper",
+	HandlerType: (*GophKeeperServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _GophKeeper_Register_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _GophKeeper_Login_Handler,
+		},
+		{
+			MethodName: "Sync",
+			Handler:    _GophKeeper_Sync_Handler,
+		},
+		{
+			MethodName: "Recover",
+			Handler:    _GophKeeper_Recover_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _GophKeeper_ChangePassword_Handler,
+		},
+		{
+			MethodName: "DeleteAccount",
+			Handler:    _GophKeeper_DeleteAccount_Handler,
+		},
+		{
+			MethodName: "SetKeyPair",
+			Handler:    _GophKeeper_SetKeyPair_Handler,
+		},
+		{
+			MethodName: "GetPublicKey",
+			Handler:    _GophKeeper_GetPublicKey_Handler,
+		},
+		{
+			MethodName: "ShareItem",
+			Handler:    _GophKeeper_ShareItem_Handler,
+		},
+		{
+			MethodName: "ListSharedWithMe",
+			Handler:    _GophKeeper_ListSharedWithMe_Handler,
+		},
+		{
+			MethodName: "RevokeShare",
+			Handler:    _GophKeeper_RevokeShare_Handler,
+		},
+		{
+			MethodName: "CreateOrganization",
+			Handler:    _GophKeeper_CreateOrganization_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _GophKeeper_CreateCollection_Handler,
+		},
+		{
+			MethodName: "AddMember",
+			Handler:    _GophKeeper_AddMember_Handler,
+		},
+		{
+			MethodName: "ListMembers",
+			Handler:    _GophKeeper_ListMembers_Handler,
+		},
+		{
+			MethodName: "ListCollections",
+			Handler:    _GophKeeper_ListCollections_Handler,
+		},
+		{
+			MethodName: "AddEmergencyContact",
+			Handler:    _GophKeeper_AddEmergencyContact_Handler,
+		},
+		{
+			MethodName: "ListEmergencyContacts",
+			Handler:    _GophKeeper_ListEmergencyContacts_Handler,
+		},
+		{
+			MethodName: "ListEmergencyGrants",
+			Handler:    _GophKeeper_ListEmergencyGrants_Handler,
+		},
+		{
+			MethodName: "RequestEmergencyAccess",
+			Handler:    _GophKeeper_RequestEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "DenyEmergencyAccess",
+			Handler:    _GophKeeper_DenyEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "GetEmergencyVault",
+			Handler:    _GophKeeper_GetEmergencyVault_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _GophKeeper_ListAuditEvents_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _GophKeeper_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gophkeeper.proto",
+}
+
+const (
+	GophKeeperAdmin_ListUsers_FullMethodName   = "/gophkeeper.GophKeeperAdmin/ListUsers"
+	GophKeeperAdmin_DisableUser_FullMethodName = "/gophkeeper.GophKeeperAdmin/DisableUser"
+	GophKeeperAdmin_EnableUser_FullMethodName  = "/gophkeeper.GophKeeperAdmin/EnableUser"
+	GophKeeperAdmin_LogoutUser_FullMethodName  = "/gophkeeper.GophKeeperAdmin/LogoutUser"
+	GophKeeperAdmin_GetUsage_FullMethodName    = "/gophkeeper.GophKeeperAdmin/GetUsage"
+	GophKeeperAdmin_PurgeUser_FullMethodName   = "/gophkeeper.GophKeeperAdmin/PurgeUser"
+)
+
+// GophKeeperAdminClient is the client API for GophKeeperAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GophKeeperAdminClient interface {
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	DisableUser(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*AdminUserResponse, error)
+	EnableUser(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*AdminUserResponse, error)
+	LogoutUser(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*AdminUserResponse, error)
+	GetUsage(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*UsageResponse, error)
+	PurgeUser(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*AdminUserResponse, error)
+}
+
+type gophKeeperAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGophKeeperAdminClient(cc grpc.ClientConnInterface) GophKeeperAdminClient {
+	return &gophKeeperAdminClient{cc}
+}
+
+func (c *gophKeeperAdminClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, GophKeeperAdmin_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperAdminClient) DisableUser(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*AdminUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminUserResponse)
+	err := c.cc.Invoke(ctx, GophKeeperAdmin_DisableUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperAdminClient) EnableUser(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*AdminUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminUserResponse)
+	err := c.cc.Invoke(ctx, GophKeeperAdmin_EnableUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperAdminClient) LogoutUser(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*AdminUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminUserResponse)
+	err := c.cc.Invoke(ctx, GophKeeperAdmin_LogoutUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperAdminClient) GetUsage(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*UsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsageResponse)
+	err := c.cc.Invoke(ctx, GophKeeperAdmin_GetUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperAdminClient) PurgeUser(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*AdminUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminUserResponse)
+	err := c.cc.Invoke(ctx, GophKeeperAdmin_PurgeUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GophKeeperAdminServer is the server API for GophKeeperAdmin service.
+// All implementations must embed UnimplementedGophKeeperAdminServer
+// for forward compatibility.
+type GophKeeperAdminServer interface {
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	DisableUser(context.Context, *AdminUserRequest) (*AdminUserResponse, error)
+	EnableUser(context.Context, *AdminUserRequest) (*AdminUserResponse, error)
+	LogoutUser(context.Context, *AdminUserRequest) (*AdminUserResponse, error)
+	GetUsage(context.Context, *AdminUserRequest) (*UsageResponse, error)
+	PurgeUser(context.Context, *AdminUserRequest) (*AdminUserResponse, error)
+	mustEmbedUnimplementedGophKeeperAdminServer()
+}
+
+// UnimplementedGophKeeperAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGophKeeperAdminServer struct{}
+
+func (UnimplementedGophKeeperAdminServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedGophKeeperAdminServer) DisableUser(context.Context, *AdminUserRequest) (*AdminUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableUser not implemented")
+}
+func (UnimplementedGophKeeperAdminServer) EnableUser(context.Context, *AdminUserRequest) (*AdminUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableUser not implemented")
+}
+func (UnimplementedGophKeeperAdminServer) LogoutUser(context.Context, *AdminUserRequest) (*AdminUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogoutUser not implemented")
+}
+func (UnimplementedGophKeeperAdminServer) GetUsage(context.Context, *AdminUserRequest) (*UsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedGophKeeperAdminServer) PurgeUser(context.Context, *AdminUserRequest) (*AdminUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeUser not implemented")
+}
+func (UnimplementedGophKeeperAdminServer) mustEmbedUnimplementedGophKeeperAdminServer() {}
+func (UnimplementedGophKeeperAdminServer) testEmbeddedByValue()                         {}
+
+// UnsafeGophKeeperAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GophKeeperAdminServer will
+// result in compilation errors.
+type UnsafeGophKeeperAdminServer interface {
+	mustEmbedUnimplementedGophKeeperAdminServer()
+}
+
+func RegisterGophKeeperAdminServer(s grpc.ServiceRegistrar, srv GophKeeperAdminServer) {
+	// If the following call pancis, it indicates UnimplementedGophKeeperAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GophKeeperAdmin_ServiceDesc, srv)
+}
+
+func _GophKeeperAdmin_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperAdminServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperAdmin_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperAdminServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperAdmin_DisableUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperAdminServer).DisableUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperAdmin_DisableUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperAdminServer).DisableUser(ctx, req.(*AdminUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperAdmin_EnableUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperAdminServer).EnableUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperAdmin_EnableUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperAdminServer).EnableUser(ctx, req.(*AdminUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperAdmin_LogoutUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperAdminServer).LogoutUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperAdmin_LogoutUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperAdminServer).LogoutUser(ctx, req.(*AdminUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperAdmin_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperAdminServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperAdmin_GetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperAdminServer).GetUsage(ctx, req.(*AdminUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperAdmin_PurgeUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperAdminServer).PurgeUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperAdmin_PurgeUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperAdminServer).PurgeUser(ctx, req.(*AdminUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GophKeeperAdmin_ServiceDesc is the grpc.ServiceDesc for GophKeeperAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GophKeeperAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gophkeeper.GophKeeperAdmin",
+	HandlerType: (*GophKeeperAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListUsers",
+			Handler:    _GophKeeperAdmin_ListUsers_Handler,
+		},
+		{
+			MethodName: "DisableUser",
+			Handler:    _GophKeeperAdmin_DisableUser_Handler,
+		},
+		{
+			MethodName: "EnableUser",
+			Handler:    _GophKeeperAdmin_EnableUser_Handler,
+		},
+		{
+			MethodName: "LogoutUser",
+			Handler:    _GophKeeperAdmin_LogoutUser_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _GophKeeperAdmin_GetUsage_Handler,
+		},
+		{
+			MethodName: "PurgeUser",
+			Handler:    _GophKeeperAdmin_PurgeUser_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
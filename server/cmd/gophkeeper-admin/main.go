@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/server/internal/admincli"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func main() {
+	addr := flag.String("s", envOr("SERVER_ADDRESS", ":50051"), "gRPC server address")
+	token := flag.String("token", os.Getenv("ADMIN_TOKEN"), "Admin token, ADMIN_TOKEN by default")
+	caFile := flag.String("ca", "", "PEM file with server CA, system roots by default")
+	timeout := flag.Duration("t", 10*time.Second, "Command timeout")
+	asJSON := flag.Bool("json", false, "Print JSON instead of tables")
+	yes := flag.Bool("yes", false, "Confirm irreversible commands")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <command> [args]\n\n%s\n\nFlags:\n", os.Args[0], admincli.Usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *token == "" {
+		log.Fatal("admin token required")
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if *caFile != "" {
+		pem, err := os.ReadFile(*caFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			log.Fatalf("no certificates in %s", *caFile)
+		}
+	}
+
+	conn, err := grpc.NewClient(
+		*addr,
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithPerRPCCredentials(admincli.TokenCredentials(*token)),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	cli := admincli.New(pb.NewGophKeeperAdminClient(conn), os.Stdout, *asJSON, *yes)
+	if err := cli.Run(ctx, flag.Args()); err != nil {
+		cancel()
+		log.Fatal(err)
+	}
+}
+
+// envOr returns the environment variable or def when it is not set
+func envOr(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return def
+}
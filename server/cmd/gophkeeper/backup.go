@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/rycln/gokeep/server/internal/backup"
+	"github.com/rycln/gokeep/server/internal/storage"
+)
+
+// passphraseEnv holds archive passphrase when no file is given
+const passphraseEnv = "BACKUP_PASSPHRASE"
+
+// runBackup writes a database snapshot to a file or stdout
+func runBackup(args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ExitOnError)
+	uri := fs.String("d", os.Getenv("DATABASE_DSN"), "Database connection address, sqlite:path for SQLite, DATABASE_DSN by default")
+	output := fs.String("o", "-", "Archive file, - for stdout")
+	compress := fs.Bool("z", false, "Compress the archive with gzip")
+	passFile := fs.String("passphrase-file", "", "File with the encryption passphrase, "+passphraseEnv+" by default, no encryption when empty")
+	fs.Usage = toolUsage(fs, "backup", "Write a consistent database snapshot to an archive.")
+	_ = fs.Parse(args)
+
+	if *uri == "" {
+		return errors.New("dsn required")
+	}
+	passphrase, err := readPassphrase(*passFile)
+	if err != nil {
+		return err
+	}
+
+	database, dialect, err := storage.NewDB(*uri)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	var out io.WriteCloser = os.Stdout
+	if *output != "-" {
+		// Incomplete archives are removed, so a failed run leaves no file behind
+		out, err = os.OpenFile(*output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	w := bufio.NewWriter(out)
+	summary, err := backup.Create(ctx, database, dialect, w, backup.Options{Compress: *compress, Passphrase: passphrase})
+	if err == nil {
+		err = w.Flush()
+	}
+	if *output != "-" {
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(*output)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	logSummary("Backup", summary)
+	return nil
+}
+
+// runRestore loads an archive into an empty database
+func runRestore(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	uri := fs.String("d", os.Getenv("DATABASE_DSN"), "Database connection address, sqlite:path for SQLite, DATABASE_DSN by default")
+	input := fs.String("i", "-", "Archive file, - for stdin")
+	passFile := fs.String("passphrase-file", "", "File with the encryption passphrase, "+passphraseEnv+" by default")
+	fs.Usage = toolUsage(fs, "restore", "Load an archive into an empty database, migrations are applied up to the archive version.")
+	_ = fs.Parse(args)
+
+	if *uri == "" {
+		return errors.New("dsn required")
+	}
+	passphrase, err := readPassphrase(*passFile)
+	if err != nil {
+		return err
+	}
+
+	var in io.ReadCloser = os.Stdin
+	if *input != "-" {
+		in, err = os.Open(*input)
+		if err != nil {
+			return err
+		}
+	}
+	defer in.Close()
+
+	database, dialect, err := storage.NewDB(*uri)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	summary, err := backup.Restore(ctx, database, dialect, in, passphrase)
+	if err != nil {
+		return err
+	}
+
+	logSummary("Restore", summary)
+	return nil
+}
+
+// readPassphrase reads the passphrase from the file or environment
+func readPassphrase(path string) ([]byte, error) {
+	if path == "" {
+		return []byte(os.Getenv(passphraseEnv)), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimRight(string(data), "\r\n")), nil
+}
+
+// toolUsage prints usage of a server subcommand
+func toolUsage(fs *flag.FlagSet, name, description string) func() {
+	return func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s %s [flags]\n\n%s\n\nFlags:\n", os.Args[0], name, description)
+		fs.PrintDefaults()
+	}
+}
+
+// logSummary reports archived tables to stderr, stdout may hold the archive
+func logSummary(action string, summary *backup.Summary) {
+	log.Printf("%s completed, schema version %d", action, summary.SchemaVersion)
+	for _, t := range summary.Tables {
+		log.Printf("  %s: %d rows", t.Name, t.Rows)
+	}
+}
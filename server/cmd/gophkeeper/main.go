@@ -2,11 +2,27 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/rycln/gokeep/server/internal/app"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "backup":
+			if err := runBackup(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "restore":
+			if err := runRestore(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
+
 	app, err := app.New()
 	if err != nil {
 		log.Fatal(err)
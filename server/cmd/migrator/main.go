@@ -1,39 +1,112 @@
 package main
 
 import (
-	"database/sql"
-	"errors"
+	"context"
 	"flag"
+	"fmt"
 	"log"
+	"os"
+	"text/tabwriter"
+	"time"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
-	"github.com/pressly/goose/v3"
 	"github.com/rycln/gokeep/server/internal/db"
+	"github.com/rycln/gokeep/server/internal/storage"
 )
 
+const usage = `Commands:
+  up        apply all pending migrations (default)
+  down      roll back the latest migration
+  redo      roll back the latest migration and apply it again
+  status    list migrations with their state
+  version   print database and expected schema versions`
+
 func main() {
-	uri := flag.String("d", "", "Database connection address")
+	uri := flag.String("d", os.Getenv("DATABASE_DSN"), "Database connection address, sqlite:path for SQLite, DATABASE_DSN by default")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [command]\n\n%s\n\nFlags:\n", os.Args[0], usage)
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
 	if *uri == "" {
 		log.Fatal("dsn required")
 	}
 
-	database, err := sql.Open("pgx", *uri)
+	command := "up"
+	switch flag.NArg() {
+	case 0:
+	case 1:
+		command = flag.Arg(0)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	database, dialect, err := storage.NewDB(*uri)
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer database.Close()
 
-	goose.SetBaseFS(db.MigrationsFS)
-
-	if err := goose.SetDialect("postgres"); err != nil {
+	m, err := db.NewMigrator(database, dialect)
+	if err != nil {
 		log.Fatal(err)
 	}
 
-	if err := goose.Up(database, "migrations"); err != nil && !errors.Is(err, goose.ErrNoNextVersion) {
+	if err := run(context.Background(), m, command); err != nil {
+		database.Close()
 		log.Fatal(err)
 	}
+}
+
+// run executes the migrator command
+func run(ctx context.Context, m *db.Migrator, command string) error {
+	switch command {
+	case "up":
+		results, err := m.Up(ctx)
+		for _, r := range results {
+			log.Print(r)
+		}
+		if err != nil {
+			return err
+		}
+		log.Print("Migrations applied successfully")
+	case "down":
+		r, err := m.Down(ctx)
+		if err != nil {
+			return err
+		}
+		log.Print(r)
+	case "redo":
+		results, err := m.Redo(ctx)
+		for _, r := range results {
+			log.Print(r)
+		}
+		return err
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "APPLIED AT\tMIGRATION")
+		for _, s := range statuses {
+			applied := "pending"
+			if !s.AppliedAt.IsZero() {
+				applied = s.AppliedAt.Format(time.DateTime)
+			}
+			fmt.Fprintf(w, "%s\t%s\n", applied, s.Source.Path)
+		}
+		return w.Flush()
+	case "version":
+		current, target, err := m.Version(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("database: %d\nexpected: %d\n", current, target)
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
 
-	log.Print("Migrations applied successfully")
+	return nil
 }
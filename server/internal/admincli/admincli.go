@@ -0,0 +1,202 @@
+package admincli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+)
+
+// Usage describes available commands
+const Usage = `Commands:
+  users [query]       list accounts, optionally filtered by username substring
+  usage <username>    show stored items, shares, memberships and quota
+  disable <username>  block logins and revoke issued tokens
+  enable <username>   allow logins again
+  logout <username>   revoke issued tokens
+  purge <username>    delete the account with all data, requires -yes`
+
+var (
+	errUsage        = errors.New("invalid command, see -help")
+	errPurgeConfirm = errors.New("purge deletes all user data irreversibly, pass -yes to confirm")
+)
+
+// pageSize defines users requested per call
+const pageSize = 200
+
+// CLI runs commands against the admin gRPC service and prints results.
+type CLI struct {
+	client pb.GophKeeperAdminClient
+	out    io.Writer
+	json   bool // Print JSON instead of tables
+	yes    bool // Confirms irreversible commands
+}
+
+// New creates a new CLI instance.
+func New(client pb.GophKeeperAdminClient, out io.Writer, asJSON, yes bool) *CLI {
+	return &CLI{
+		client: client,
+		out:    out,
+		json:   asJSON,
+		yes:    yes,
+	}
+}
+
+// user is the JSON representation of a listed account
+type user struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Disabled bool   `json:"disabled"`
+}
+
+// usage is the JSON representation of account usage
+type usage struct {
+	Username   string `json:"username"`
+	Items      int64  `json:"items"`
+	Bytes      int64  `json:"bytes"`
+	Shares     int64  `json:"shares"`
+	Orgs       int64  `json:"orgs"`
+	QuotaBytes int64  `json:"quota_bytes"`
+}
+
+// result is the JSON representation of a completed account action
+type result struct {
+	Username string `json:"username"`
+	Result   string `json:"result"`
+}
+
+// Run executes the command given by args.
+func (c *CLI) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	cmd, args := args[0], args[1:]
+	if cmd == "users" {
+		if len(args) > 1 {
+			return errUsage
+		}
+		var query string
+		if len(args) == 1 {
+			query = args[0]
+		}
+		return c.users(ctx, query)
+	}
+
+	if len(args) != 1 {
+		return errUsage
+	}
+	req := &pb.AdminUserRequest{Username: args[0]}
+
+	var err error
+	switch cmd {
+	case "usage":
+		return c.usage(ctx, req)
+	case "disable":
+		_, err = c.client.DisableUser(ctx, req)
+		return c.done(err, req.Username, "disabled")
+	case "enable":
+		_, err = c.client.EnableUser(ctx, req)
+		return c.done(err, req.Username, "enabled")
+	case "logout":
+		_, err = c.client.LogoutUser(ctx, req)
+		return c.done(err, req.Username, "logged out")
+	case "purge":
+		if !c.yes {
+			return errPurgeConfirm
+		}
+		_, err = c.client.PurgeUser(ctx, req)
+		return c.done(err, req.Username, "purged")
+	default:
+		return errUsage
+	}
+}
+
+// users lists all pages of matching accounts
+func (c *CLI) users(ctx context.Context, query string) error {
+	users := []user{}
+	req := &pb.ListUsersRequest{Query: query, PageSize: pageSize}
+	for {
+		resp, err := c.client.ListUsers(ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, u := range resp.Users {
+			users = append(users, user{ID: u.Id, Username: u.Username, Disabled: u.Disabled})
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+
+	if c.json {
+		return c.printJSON(users)
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "USERNAME\tSTATUS\tID")
+	for _, u := range users {
+		status := "active"
+		if u.Disabled {
+			status = "disabled"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", u.Username, status, u.ID)
+	}
+	return w.Flush()
+}
+
+// usage prints resources held by the account
+func (c *CLI) usage(ctx context.Context, req *pb.AdminUserRequest) error {
+	resp, err := c.client.GetUsage(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		return c.printJSON(usage{
+			Username:   req.Username,
+			Items:      resp.Items,
+			Bytes:      resp.Bytes,
+			Shares:     resp.Shares,
+			Orgs:       resp.Orgs,
+			QuotaBytes: resp.QuotaBytes,
+		})
+	}
+
+	quota := "unlimited"
+	if resp.QuotaBytes > 0 {
+		quota = strconv.FormatInt(resp.QuotaBytes, 10)
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "USERNAME\tITEMS\tBYTES\tQUOTA\tSHARES\tORGS")
+	fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%d\t%d\n", req.Username, resp.Items, resp.Bytes, quota, resp.Shares, resp.Orgs)
+	return w.Flush()
+}
+
+// done prints outcome of an account action
+func (c *CLI) done(err error, username, res string) error {
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		return c.printJSON(result{Username: username, Result: res})
+	}
+
+	_, err = fmt.Fprintf(c.out, "%s: %s\n", username, res)
+	return err
+}
+
+// printJSON writes v as indented JSON
+func (c *CLI) printJSON(v any) error {
+	enc := json.NewEncoder(c.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,154 @@
+package admincli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testServer serves two pages of users and records account actions
+type testServer struct {
+	pb.UnimplementedGophKeeperAdminServer
+	actions []string
+}
+
+func (s *testServer) ListUsers(_ context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	if req.PageToken == "" {
+		return &pb.ListUsersResponse{
+			Users:         []*pb.AdminUser{{Id: "u1", Username: "alice"}},
+			NextPageToken: "alice",
+		}, nil
+	}
+	return &pb.ListUsersResponse{
+		Users: []*pb.AdminUser{{Id: "u2", Username: "bob", Disabled: true}},
+	}, nil
+}
+
+func (s *testServer) GetUsage(_ context.Context, req *pb.AdminUserRequest) (*pb.UsageResponse, error) {
+	if req.Username != "alice" {
+		return nil, status.Error(codes.NotFound, "user does not exist")
+	}
+	return &pb.UsageResponse{Items: 3, Bytes: 512, Shares: 1, Orgs: 2}, nil
+}
+
+func (s *testServer) DisableUser(_ context.Context, req *pb.AdminUserRequest) (*pb.AdminUserResponse, error) {
+	s.actions = append(s.actions, "disable "+req.Username)
+	return &pb.AdminUserResponse{}, nil
+}
+
+func (s *testServer) PurgeUser(_ context.Context, req *pb.AdminUserRequest) (*pb.AdminUserResponse, error) {
+	s.actions = append(s.actions, "purge "+req.Username)
+	return &pb.AdminUserResponse{}, nil
+}
+
+// startServer serves srv in memory and returns a client connected to it
+func startServer(t *testing.T, srv *testServer) pb.GophKeeperAdminClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	g := grpc.NewServer()
+	pb.RegisterGophKeeperAdminServer(g, srv)
+	go func() { _ = g.Serve(lis) }()
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewGophKeeperAdminClient(conn)
+}
+
+func TestCLI_Run(t *testing.T) {
+	srv := &testServer{}
+	client := startServer(t, srv)
+
+	t.Run("users table over all pages", func(t *testing.T) {
+		var out bytes.Buffer
+		err := New(client, &out, false, false).Run(context.Background(), []string{"users"})
+		require.NoError(t, err)
+		assert.Equal(t, "USERNAME  STATUS    ID\nalice     active    u1\nbob       disabled  u2\n", out.String())
+	})
+
+	t.Run("users json", func(t *testing.T) {
+		var out bytes.Buffer
+		err := New(client, &out, true, false).Run(context.Background(), []string{"users", "a"})
+		require.NoError(t, err)
+
+		var users []user
+		require.NoError(t, json.Unmarshal(out.Bytes(), &users))
+		assert.Equal(t, []user{{ID: "u1", Username: "alice"}, {ID: "u2", Username: "bob", Disabled: true}}, users)
+	})
+
+	t.Run("usage with unlimited quota", func(t *testing.T) {
+		var out bytes.Buffer
+		err := New(client, &out, false, false).Run(context.Background(), []string{"usage", "alice"})
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "unlimited")
+	})
+
+	t.Run("usage json", func(t *testing.T) {
+		var out bytes.Buffer
+		err := New(client, &out, true, false).Run(context.Background(), []string{"usage", "alice"})
+		require.NoError(t, err)
+
+		var got usage
+		require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+		assert.Equal(t, usage{Username: "alice", Items: 3, Bytes: 512, Shares: 1, Orgs: 2}, got)
+	})
+
+	t.Run("server error returned", func(t *testing.T) {
+		var out bytes.Buffer
+		err := New(client, &out, false, false).Run(context.Background(), []string{"usage", "bob"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("disable prints result", func(t *testing.T) {
+		var out bytes.Buffer
+		err := New(client, &out, false, false).Run(context.Background(), []string{"disable", "alice"})
+		require.NoError(t, err)
+		assert.Equal(t, "alice: disabled\n", out.String())
+		assert.Contains(t, srv.actions, "disable alice")
+	})
+
+	t.Run("purge requires confirmation", func(t *testing.T) {
+		var out bytes.Buffer
+		err := New(client, &out, false, false).Run(context.Background(), []string{"purge", "alice"})
+		assert.ErrorIs(t, err, errPurgeConfirm)
+		assert.NotContains(t, srv.actions, "purge alice")
+
+		err = New(client, &out, false, true).Run(context.Background(), []string{"purge", "alice"})
+		require.NoError(t, err)
+		assert.Contains(t, srv.actions, "purge alice")
+	})
+
+	t.Run("invalid commands", func(t *testing.T) {
+		cli := New(client, &bytes.Buffer{}, false, false)
+		for _, args := range [][]string{nil, {"unknown", "alice"}, {"disable"}, {"users", "a", "b"}} {
+			assert.ErrorIs(t, cli.Run(context.Background(), args), errUsage)
+		}
+	})
+}
+
+func TestTokenCredentials(t *testing.T) {
+	t.Run("bearer header", func(t *testing.T) {
+		md, err := TokenCredentials("secret").GetRequestMetadata(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"authorization": "Bearer secret"}, md)
+		assert.True(t, TokenCredentials("secret").RequireTransportSecurity())
+	})
+}
@@ -0,0 +1,17 @@
+package admincli
+
+import "context"
+
+// TokenCredentials attaches the admin bearer token to every call.
+type TokenCredentials string
+
+// GetRequestMetadata implements credentials.PerRPCCredentials
+func (t TokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials.
+// The token must never be sent over a plaintext connection.
+func (t TokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
@@ -0,0 +1,3 @@
+// Package admincli implements commands of the gophkeeper-admin tool
+// on top of the admin gRPC service.
+package admincli
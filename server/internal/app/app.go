@@ -3,12 +3,14 @@
 package app
 
 import (
+	"context"
 	"crypto/tls"
 	"database/sql"
 	"errors"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
@@ -17,16 +19,30 @@ import (
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/selector"
 	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
 	"github.com/rycln/gokeep/server/internal/config"
+	schema "github.com/rycln/gokeep/server/internal/db"
+	"github.com/rycln/gokeep/server/internal/devcert"
+	"github.com/rycln/gokeep/server/internal/fanout"
+	"github.com/rycln/gokeep/server/internal/gateway"
 	server "github.com/rycln/gokeep/server/internal/grpc"
 	"github.com/rycln/gokeep/server/internal/grpc/interceptors"
+	"github.com/rycln/gokeep/server/internal/limiter"
 	"github.com/rycln/gokeep/server/internal/logger"
+	"github.com/rycln/gokeep/server/internal/metrics"
+	"github.com/rycln/gokeep/server/internal/mtls"
+	"github.com/rycln/gokeep/server/internal/reload"
 	"github.com/rycln/gokeep/server/internal/services"
 	"github.com/rycln/gokeep/server/internal/storage"
 	"github.com/rycln/gokeep/server/internal/strategies/password"
+	"github.com/rycln/gokeep/server/internal/tracing"
+	"github.com/rycln/gokeep/server/internal/validation"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 // buildInfo holds application build metadata that can be set during compilation.
@@ -41,12 +57,38 @@ const (
 	// jwtExpires sets the lifetime duration for JWT authentication tokens.
 	// Used in auth service when generating new tokens.
 	jwtExpires = time.Duration(2) * time.Hour
+
+	// emergencyInterval sets how often elapsed emergency access requests are approved.
+	emergencyInterval = time.Minute
+
+	// healthInterval sets how often database availability is checked for health status.
+	healthInterval = 5 * time.Second
+
+	// serviceName identifies the server in exported traces.
+	serviceName = "gophkeeper-server"
+
+	// reloadInterval sets how often config and certificate files are checked for changes.
+	reloadInterval = 10 * time.Second
+
+	// shutdownTimeout limits graceful shutdown of HTTP listeners and trace export.
+	shutdownTimeout = 5 * time.Second
 )
 
 // App represents the core application layer.
 type App struct {
 	grpcserver *grpc.Server
-	db         *sql.DB
+	httpserver *http.Server // Metrics listener, nil when disabled
+	gwserver   *http.Server // REST gateway listener, nil when disabled
+	gateway    *gateway.Gateway
+	tracing    func(context.Context) error
+	scheduler  *services.EmergencyScheduler
+	health     *health.Server
+	checker    *services.HealthChecker
+	certs      *reload.Certs
+	watcher    *reload.Watcher
+	bus        fanout.Bus
+	db         *sql.DB // Nil in development mode
+	devDir     string  // Generated development certificate, removed on exit
 	cfg        *config.Cfg
 }
 
@@ -62,58 +104,286 @@ func New() (*App, error) {
 		return nil, fmt.Errorf("can't initialize config: %v", err)
 	}
 
-	err = logger.LogInit(cfg.LogLevel)
+	err = logger.LogInit(cfg.LogLevel, cfg.LogFormat)
 	if err != nil {
 		return nil, fmt.Errorf("can't initialize logger: %v", err)
 	}
 
-	db, err := storage.NewDB(cfg.DatabaseDsn)
+	return newApp(cfg)
+}
+
+// newApp composes application components from the configuration.
+func newApp(cfg *config.Cfg) (*App, error) {
+	strg, db, err := openStorage(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("can't init DB: %v", err)
+		return nil, err
 	}
 
-	authstrg := storage.NewUserStorage(db)
-	itemstrg := storage.NewItemStorage(db)
+	return compose(cfg, strg, db, openBus(cfg, db))
+}
+
+// compose builds application components on top of the storage and the event bus.
+// Instances sharing both behave as replicas of one deployment.
+func compose(cfg *config.Cfg, strg services.Storage, db *sql.DB, bus fanout.Bus) (*App, error) {
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Endpoint:       cfg.TraceEndpoint,
+		Insecure:       cfg.TraceInsecure,
+		File:           cfg.TraceFile,
+		ServiceName:    serviceName,
+		ServiceVersion: buildVersion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't init tracing: %v", err)
+	}
+
+	var devDir string
+	if cfg.Dev && cfg.CertFileName == "" {
+		devDir, err = os.MkdirTemp("", "gophkeeper-dev-")
+		if err != nil {
+			return nil, fmt.Errorf("can't create dev cert dir: %v", err)
+		}
+		cfg.CertFileName, cfg.CertKeyFileName, err = devcert.Generate(devDir)
+		if err != nil {
+			return nil, fmt.Errorf("can't generate dev cert: %v", err)
+		}
+	}
+
+	policy, err := validation.NewPolicy(
+		cfg.UsernameMinLen,
+		cfg.UsernameMaxLen,
+		cfg.UsernameCharset,
+		cfg.PasswordMinLen,
+		cfg.PasswordMinKinds,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't init credentials policy: %v", err)
+	}
 
 	passwordStrategy := password.NewBCryptHasher()
 	jwtservice := services.NewJWTService(cfg.Key, jwtExpires)
-	authservice := services.NewUserService(authstrg, passwordStrategy, jwtservice)
-	syncservice := services.NewSyncService(itemstrg, authservice)
-
-	serverCert, err := tls.LoadX509KeyPair(cfg.CertFileName, cfg.CertKeyFileName)
+	auditservice := services.NewAuditService(strg, func(ctx context.Context, err error) {
+		logger.FromContext(ctx).Error(fmt.Sprintf("audit log error: %v", err))
+	})
+	lockout := limiter.NewLockout(cfg.LockoutThreshold, cfg.LockoutBase, cfg.LockoutMax)
+	authservice := services.NewUserService(strg, passwordStrategy, jwtservice, auditservice, lockout, policy, bus)
+	syncservice := services.NewSyncService(strg, strg, authservice, auditservice, bus, cfg.QuotaBytes)
+	shareservice := services.NewShareService(strg, strg, authservice)
+	orgservice := services.NewOrgService(strg, strg, authservice)
+	emergencyservice := services.NewEmergencyService(strg, strg, strg, authservice)
+	adminservice := services.NewAdminService(strg, strg, bus, cfg.QuotaBytes)
+	watchservice := services.NewWatchService(bus, strg, authservice)
+
+	sessions := services.NewSessionCache(authservice, cfg.SessionCacheTTL)
+	bus.Subscribe(sessions.HandleEvent)
+
+	certs, err := reload.NewCerts(cfg.CertFileName, cfg.CertKeyFileName)
 	if err != nil {
 		return nil, fmt.Errorf("can't load cert: %v", err)
 	}
 
 	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{serverCert},
-		ClientAuth:   tls.NoClientCert,
-		MinVersion:   tls.VersionTLS12,
+		GetCertificate: certs.GetCertificate,
+		ClientAuth:     tls.NoClientCert,
+		MinVersion:     tls.VersionTLS12,
 	}
 
-	authInterceptor := interceptors.NewAuthInterceptor(jwtservice)
+	unary := []grpc.UnaryServerInterceptor{
+		interceptors.TracingInterceptor,
+		interceptors.RequestIDInterceptor,
+		interceptors.ClientInfoInterceptor,
+	}
+	stream := []grpc.StreamServerInterceptor{
+		interceptors.RequestIDStreamInterceptor,
+	}
+
+	if cfg.ClientCAFileName != "" {
+		verifier, err := mtls.NewVerifier(cfg.ClientCAFileName, certs)
+		if err != nil {
+			return nil, fmt.Errorf("can't load client CA: %v", err)
+		}
+		tlsConfig.ClientAuth = tls.RequestClientCert
+		tlsConfig.VerifyPeerCertificate = verifier.VerifyPeerCertificate
+		clientCert := interceptors.NewClientCertInterceptor(verifier, cfg.ClientCertRequired)
+		unary = append(unary, clientCert.Unary)
+		stream = append(stream, clientCert.Stream)
+	}
+
+	m := metrics.New(db)
+
+	authInterceptor := interceptors.NewAuthInterceptor(jwtservice, sessions)
+	metricsInterceptor := interceptors.NewMetricsInterceptor(m)
+	rateInterceptor := interceptors.NewRateLimitInterceptor(limiter.NewWindow(cfg.RateLimit, cfg.RateWindow))
+
+	logOpts := []logging.Option{logging.WithFieldsFromContext(interceptors.RequestIDFields)}
+	if cfg.LogPayloads {
+		logOpts = append(logOpts, logging.WithLogOnEvents(
+			logging.StartCall, logging.FinishCall, logging.PayloadReceived, logging.PayloadSent,
+		))
+	}
 
 	g := grpc.NewServer(
 		grpc.Creds(credentials.NewTLS(tlsConfig)),
-		grpc.ChainUnaryInterceptor(
-			logging.UnaryServerInterceptor(interceptors.InterceptorLogger(logger.Log)),
-			auth.UnaryServerInterceptor(authInterceptor.AuthFunc),
-		),
+		grpc.ChainUnaryInterceptor(append(unary,
+			metricsInterceptor.Unary,
+			rateInterceptor.Unary,
+			logging.UnaryServerInterceptor(interceptors.InterceptorLogger(logger.Log), logOpts...),
+			selector.UnaryServerInterceptor(
+				auth.UnaryServerInterceptor(authInterceptor.AuthFunc),
+				selector.MatchFunc(interceptors.AuthRequired),
+			),
+		)...),
+		grpc.ChainStreamInterceptor(append(stream,
+			logging.StreamServerInterceptor(interceptors.InterceptorLogger(logger.Log), logOpts...),
+			selector.StreamServerInterceptor(
+				auth.StreamServerInterceptor(authInterceptor.AuthFunc),
+				selector.MatchFunc(interceptors.AuthRequired),
+			),
+		)...),
 	)
 
-	gs := server.NewGophKeeperServer(authservice, syncservice, authInterceptor, cfg.Timeout)
+	gs := server.NewGophKeeperServer(server.Services{
+		User:      authservice,
+		Sync:      syncservice,
+		Share:     shareservice,
+		Org:       orgservice,
+		Emergency: emergencyservice,
+		Audit:     auditservice,
+		Watch:     watchservice,
+		Auth:      authInterceptor,
+	}, cfg.Timeout)
 
 	pb.RegisterGophKeeperServer(g, gs)
 
+	if cfg.AdminToken != "" {
+		pb.RegisterGophKeeperAdminServer(g, server.NewAdminServer(adminservice, cfg.AdminToken, cfg.Timeout))
+	}
+
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(g, hs)
+
+	if cfg.Reflection {
+		reflection.Register(g)
+	}
+
+	var gw *gateway.Gateway
+	var rs *http.Server
+	if cfg.GatewayAddr != "" {
+		gw, err = gateway.New(cfg.GRPCPort, certs)
+		if err != nil {
+			return nil, fmt.Errorf("can't init gateway: %v", err)
+		}
+		rs = &http.Server{
+			Addr:              cfg.GatewayAddr,
+			Handler:           gw,
+			TLSConfig:         tlsConfig.Clone(),
+			ReadHeaderTimeout: cfg.Timeout,
+		}
+	}
+
+	var ms *http.Server
+	if cfg.MetricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", m.Handler())
+		ms = &http.Server{
+			Addr:              cfg.MetricsAddr,
+			Handler:           mux,
+			ReadHeaderTimeout: cfg.Timeout,
+		}
+	}
+
 	return &App{
 		grpcserver: g,
+		httpserver: ms,
+		gwserver:   rs,
+		gateway:    gw,
+		tracing:    shutdownTracing,
+		scheduler:  services.NewEmergencyScheduler(strg, emergencyInterval),
+		health:     hs,
+		checker:    services.NewHealthChecker(strg, hs, healthInterval, "", pb.GophKeeper_ServiceDesc.ServiceName),
+		certs:      certs,
+		watcher:    reload.NewWatcher(reloadInterval, cfg.CfgFileName, cfg.CertFileName, cfg.CertKeyFileName),
+		bus:        bus,
 		db:         db,
+		devDir:     devDir,
 		cfg:        cfg,
 	}, nil
 }
 
+// openStorage selects the storage backend: in-memory in development mode,
+// the database otherwise. The returned database is nil in development mode.
+func openStorage(cfg *config.Cfg) (services.Storage, *sql.DB, error) {
+	if cfg.Dev {
+		logger.Log.Warn("Development mode: data is kept in memory and lost on exit")
+		return storage.NewMemStorage(), nil, nil
+	}
+
+	db, dialect, err := storage.NewDB(cfg.DatabaseDsn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't init DB: %v", err)
+	}
+
+	err = migrate(context.Background(), db, dialect, cfg.Migrate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("database schema error: %v", err)
+	}
+
+	return storage.NewSQLStorage(db, dialect), db, nil
+}
+
+// openBus selects how change events reach other instances: through the database
+// when it is Postgres shared by replicas, within the process otherwise.
+func openBus(cfg *config.Cfg, db *sql.DB) fanout.Bus {
+	if db == nil {
+		return fanout.NewLocal()
+	}
+	if dialect, _ := schema.ParseDSN(cfg.DatabaseDsn); dialect != schema.Postgres {
+		return fanout.NewLocal()
+	}
+
+	return fanout.NewPostgres(db, cfg.DatabaseDsn, func(ctx context.Context, err error) {
+		logger.FromContext(ctx).Error(fmt.Sprintf("event fanout error: %v", err))
+	})
+}
+
+// migrate applies embedded migrations when enabled and checks the schema version.
+func migrate(ctx context.Context, db *sql.DB, dialect schema.Dialect, apply bool) error {
+	m, err := schema.NewMigrator(db, dialect)
+	if err != nil {
+		return err
+	}
+
+	if apply {
+		results, err := m.Up(ctx)
+		for _, r := range results {
+			logger.Log.Info(fmt.Sprintf("migration applied: %s", r))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return m.Check(ctx)
+}
+
 // Run starts the application services.
 func (app *App) Run() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go app.scheduler.Run(ctx, func(ctx context.Context, err error) {
+		logger.FromContext(ctx).Error(fmt.Sprintf("emergency scheduler error: %v", err))
+	})
+
+	go app.checker.Run(ctx, func(err error) {
+		logger.Log.Warn(fmt.Sprintf("database health check failed: %v", err))
+	})
+
+	go func() {
+		if err := app.bus.Run(ctx); err != nil {
+			logger.Log.Error(fmt.Sprintf("event fanout error: %v", err))
+		}
+	}()
+
 	go func() {
 		listen, err := net.Listen("tcp", app.cfg.GRPCPort)
 		if err != nil {
@@ -124,13 +394,58 @@ func (app *App) Run() error {
 		}
 	}()
 
+	if app.gwserver != nil {
+		go func() {
+			err := app.gwserver.ListenAndServeTLS("", "")
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatalf("gateway server error: %v", err)
+			}
+		}()
+		logger.Log.Info(fmt.Sprintf("REST gateway served at %s", app.cfg.GatewayAddr))
+	}
+
+	if app.httpserver != nil {
+		go func() {
+			err := app.httpserver.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatalf("metrics server error: %v", err)
+			}
+		}()
+		logger.Log.Info(fmt.Sprintf("Metrics served at %s/metrics", app.cfg.MetricsAddr))
+	}
+
 	logger.Log.Info(fmt.Sprintf("Server started successfully! Port: %s", app.cfg.GRPCPort))
 	printBuildInfo()
 
+	if app.devDir != "" {
+		fmt.Printf("Development CA: %s (client flag --tls-ca)\n", app.cfg.CertFileName)
+	}
+
+	changed := make(chan struct{}, 1)
+	go app.watcher.Run(ctx, func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT)
 
-	<-shutdown
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+wait:
+	for {
+		select {
+		case <-shutdown:
+			break wait
+		case <-hup:
+			app.reload()
+		case <-changed:
+			app.reload()
+		}
+	}
 
 	err := app.shutdown()
 	if err != nil {
@@ -147,17 +462,108 @@ func (app *App) Run() error {
 	return nil
 }
 
+// reload re-reads the config file and applies the log level and TLS certificate.
+// Other changed settings are reported and take effect after restart.
+// Runs only in the Run goroutine, so the applied values are not guarded.
+func (app *App) reload() {
+	cfg := app.cfg
+	if app.cfg.CfgFileName != "" {
+		var err error
+		cfg, err = config.NewConfigBuilderFrom(app.cfg).
+			WithConfigFile().
+			Build()
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("config reload failed, keeping current settings: %v", err))
+			return
+		}
+	}
+
+	if cfg.LogLevel != app.cfg.LogLevel {
+		if err := logger.SetLevel(cfg.LogLevel); err != nil {
+			logger.Log.Error(fmt.Sprintf("log level not changed: %v", err))
+		} else {
+			app.cfg.LogLevel = cfg.LogLevel
+			logger.Log.Info(fmt.Sprintf("Log level set to %s", cfg.LogLevel))
+		}
+	}
+
+	if err := app.certs.Load(cfg.CertFileName, cfg.CertKeyFileName); err != nil {
+		logger.Log.Error(fmt.Sprintf("TLS certificate not reloaded, keeping current one: %v", err))
+	} else {
+		app.cfg.CertFileName = cfg.CertFileName
+		app.cfg.CertKeyFileName = cfg.CertKeyFileName
+		logger.Log.Info("TLS certificate reloaded")
+	}
+	app.watcher.SetFiles(app.cfg.CfgFileName, cfg.CertFileName, cfg.CertKeyFileName)
+
+	for _, name := range config.RestartRequired(app.cfg, cfg) {
+		logger.Log.Warn(fmt.Sprintf("Setting %s changed, restart the server to apply it", name))
+	}
+}
+
 // shutdown gracefully shuts down the application components.
 func (app *App) shutdown() error {
-	app.grpcserver.GracefulStop()
+	// Orchestrator stops routing new requests before in-flight ones are drained
+	app.health.Shutdown()
+
+	if app.gwserver != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := app.gwserver.Shutdown(ctx); err != nil {
+			return fmt.Errorf("gateway server shutdown failed: %w", err)
+		}
+	}
+
+	// Watch streams last until clients disconnect, so they are cut after the timeout
+	stopped := make(chan struct{})
+	go func() {
+		app.grpcserver.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		app.grpcserver.Stop()
+	}
+
+	if app.httpserver != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := app.httpserver.Shutdown(ctx); err != nil {
+			return fmt.Errorf("metrics server shutdown failed: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := app.tracing(ctx); err != nil {
+		return fmt.Errorf("trace export shutdown failed: %w", err)
+	}
 
 	return nil
 }
 
 // cleanup performs resource cleanup operations for the application.
 func (app *App) cleanup() error {
-	if err := app.db.Close(); err != nil {
-		return fmt.Errorf("storage close failed: %w", err)
+	if app.gateway != nil {
+		if err := app.gateway.Close(); err != nil {
+			return fmt.Errorf("gateway close failed: %w", err)
+		}
+	}
+
+	if app.db != nil {
+		if err := app.db.Close(); err != nil {
+			return fmt.Errorf("storage close failed: %w", err)
+		}
+	}
+
+	if app.devDir != "" {
+		if err := os.RemoveAll(app.devDir); err != nil {
+			return fmt.Errorf("dev cert removal failed: %w", err)
+		}
 	}
 
 	if err := logger.Log.Sync(); err != nil && !errors.Is(err, syscall.EINVAL) {
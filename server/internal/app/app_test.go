@@ -0,0 +1,245 @@
+package app
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/server/internal/config"
+	"github.com/rycln/gokeep/server/internal/devcert"
+	"github.com/rycln/gokeep/server/internal/fanout"
+	"github.com/rycln/gokeep/server/internal/services"
+	"github.com/rycln/gokeep/server/internal/storage"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// startDevServer serves a development mode app on a random local port
+func startDevServer(t *testing.T) (*App, pb.GophKeeperClient) {
+	t.Helper()
+
+	cfg, err := config.NewConfigBuilder().
+		WithDefaultJWTKey().
+		Build()
+	require.NoError(t, err)
+	cfg.Dev = true
+
+	app, err := newApp(cfg)
+	require.NoError(t, err)
+
+	conn := serve(t, app)
+	return app, pb.NewGophKeeperClient(conn)
+}
+
+// serve starts the app gRPC server on a random local port and connects to it
+func serve(t *testing.T, app *App) *grpc.ClientConn {
+	t.Helper()
+
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.grpcserver.Serve(listen) }()
+
+	creds, err := credentials.NewClientTLSFromFile(app.cfg.CertFileName, "")
+	require.NoError(t, err)
+	conn, err := grpc.NewClient(listen.Addr().String(), grpc.WithTransportCredentials(creds))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		app.grpcserver.Stop()
+	})
+
+	return conn
+}
+
+// startReplica serves an app on the shared storage and event bus, as one more instance of a deployment
+func startReplica(t *testing.T, cfg config.Cfg, strg services.Storage, bus fanout.Bus) *grpc.ClientConn {
+	t.Helper()
+
+	app, err := compose(&cfg, strg, nil, bus)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() { _ = bus.Run(ctx) }()
+	t.Cleanup(cancel)
+
+	return serve(t, app)
+}
+
+// replicaCfg returns settings shared by instances of a test deployment
+func replicaCfg(t *testing.T) config.Cfg {
+	t.Helper()
+
+	cfg, err := config.NewConfigBuilder().
+		WithDefaultJWTKey().
+		Build()
+	require.NoError(t, err)
+	cfg.AdminToken = "admin_token"
+
+	cfg.CertFileName, cfg.CertKeyFileName, err = devcert.Generate(t.TempDir())
+	require.NoError(t, err)
+
+	return *cfg
+}
+
+func TestApp_Dev(t *testing.T) {
+	app, client := startDevServer(t)
+	ctx := context.Background()
+
+	register := &pb.RegisterRequest{
+		Username:     "alice",
+		Password:     "correct-horse-42",
+		Salt:         "salt",
+		EncryptedKey: "encrypted_key",
+		RecoveryKey:  "recovery_key",
+		RecoveryAuth: "recovery_auth",
+	}
+	item := &pb.Item{
+		Id:        uuid.NewString(),
+		Type:      string(models.TypePassword),
+		Name:      "mail",
+		Metadata:  "metadata",
+		Data:      []byte("encrypted"),
+		UpdatedAt: timestamppb.Now(),
+	}
+
+	t.Run("item synced after register and login", func(t *testing.T) {
+		reg, err := client.Register(ctx, register)
+		require.NoError(t, err)
+
+		authCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "bearer "+reg.Token)
+		_, err = client.Sync(authCtx, &pb.SyncRequest{Items: []*pb.Item{item}})
+		require.NoError(t, err)
+
+		login, err := client.Login(ctx, &pb.LoginRequest{Username: register.Username, Password: register.Password})
+		require.NoError(t, err)
+		assert.Equal(t, reg.UserId, login.UserId)
+		assert.Equal(t, register.EncryptedKey, login.EncryptedKey)
+
+		authCtx = metadata.AppendToOutgoingContext(ctx, "authorization", "bearer "+login.Token)
+		res, err := client.Sync(authCtx, &pb.SyncRequest{})
+		require.NoError(t, err)
+		require.Len(t, res.Items, 1)
+		assert.Equal(t, item.Id, res.Items[0].Id)
+		assert.Equal(t, item.Data, res.Items[0].Data)
+	})
+
+	t.Run("dev certificate removed on cleanup", func(t *testing.T) {
+		app.grpcserver.Stop()
+		require.NoError(t, app.cleanup())
+
+		_, err := os.Stat(app.devDir)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+// testReplicas checks that changes made on instance A reach instance B
+func testReplicas(t *testing.T, a, b *grpc.ClientConn) {
+	ctx := context.Background()
+	clientA, clientB := pb.NewGophKeeperClient(a), pb.NewGophKeeperClient(b)
+
+	username := "u" + uuid.NewString()[:8]
+	reg, err := clientA.Register(ctx, &pb.RegisterRequest{
+		Username:     username,
+		Password:     "correct-horse-42",
+		Salt:         "salt",
+		EncryptedKey: "encrypted_key",
+		RecoveryKey:  "recovery_key",
+		RecoveryAuth: "recovery_auth",
+	})
+	require.NoError(t, err)
+	authCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "bearer "+reg.Token)
+
+	t.Run("sync on one instance notifies watcher on another", func(t *testing.T) {
+		watchCtx, cancel := context.WithCancel(authCtx)
+		defer cancel()
+
+		stream, err := clientB.Watch(watchCtx, &pb.WatchRequest{})
+		require.NoError(t, err)
+
+		received := make(chan *pb.ChangeNotification, 1)
+		go func() {
+			n, err := stream.Recv()
+			if err == nil {
+				received <- n
+			}
+		}()
+
+		item := &pb.Item{
+			Id:        uuid.NewString(),
+			Type:      string(models.TypePassword),
+			Name:      "mail",
+			Metadata:  "metadata",
+			Data:      []byte("encrypted"),
+			UpdatedAt: timestamppb.Now(),
+		}
+
+		// The watcher may subscribe after the first sync, so changes are repeated until noticed
+		var n *pb.ChangeNotification
+		require.Eventually(t, func() bool {
+			_, err := clientA.Sync(authCtx, &pb.SyncRequest{Items: []*pb.Item{item}})
+			require.NoError(t, err)
+
+			select {
+			case n = <-received:
+				return true
+			case <-time.After(100 * time.Millisecond):
+				return false
+			}
+		}, 10*time.Second, 10*time.Millisecond)
+		assert.Empty(t, n.CollectionId)
+	})
+
+	t.Run("logout on one instance rejects cached session on another", func(t *testing.T) {
+		_, err := clientB.Sync(authCtx, &pb.SyncRequest{})
+		require.NoError(t, err)
+
+		adminCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "bearer admin_token")
+		_, err = pb.NewGophKeeperAdminClient(a).LogoutUser(adminCtx, &pb.AdminUserRequest{Username: username})
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			_, err := clientB.Sync(authCtx, &pb.SyncRequest{})
+			return status.Code(err) == codes.Unauthenticated
+		}, 10*time.Second, 50*time.Millisecond)
+	})
+}
+
+func TestApp_Replicas(t *testing.T) {
+	cfg := replicaCfg(t)
+	strg, bus := storage.NewMemStorage(), fanout.NewLocal()
+
+	testReplicas(t, startReplica(t, cfg, strg, bus), startReplica(t, cfg, strg, bus))
+}
+
+func TestApp_ReplicasPostgres(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN not set")
+	}
+
+	cfg := replicaCfg(t)
+	cfg.DatabaseDsn = dsn
+	cfg.Migrate = true
+
+	replica := func() *grpc.ClientConn {
+		strg, db, err := openStorage(&cfg)
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		return startReplica(t, cfg, strg, openBus(&cfg, db))
+	}
+
+	testReplicas(t, replica(), replica())
+}
@@ -0,0 +1,198 @@
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+	"time"
+
+	schema "github.com/rycln/gokeep/server/internal/db"
+)
+
+// Options configures a written archive.
+type Options struct {
+	// Compress enables gzip compression of the archive body
+	Compress bool
+	// Passphrase enables encryption of the archive body when not empty
+	Passphrase []byte
+}
+
+// rowRecord is a body line with a single table row
+type rowRecord struct {
+	Row []any `json:"row"`
+}
+
+// endRecord is the last body line
+type endRecord struct {
+	End trailer `json:"end"`
+}
+
+// lineWriter writes JSON lines and hashes them for the trailer
+type lineWriter struct {
+	w *bufio.Writer
+	h hash.Hash
+}
+
+// writeLine encodes the value as a single line
+func (l *lineWriter) writeLine(v any) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	l.h.Write(line)
+	_, err = l.w.Write(line)
+	return err
+}
+
+// Create writes a consistent snapshot of the database to w.
+// All tables are read in a single transaction, rows are streamed one at a time.
+// The database schema must be fully migrated, so archived columns match the server.
+func Create(ctx context.Context, database *sql.DB, dialect schema.Dialect, w io.Writer, opts Options) (*Summary, error) {
+	m, err := schema.NewMigrator(database, dialect)
+	if err != nil {
+		return nil, err
+	}
+	current, target, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if current != target {
+		return nil, fmt.Errorf("database schema version %d differs from server version %d", current, target)
+	}
+
+	// Postgres needs an explicit snapshot, SQLite transactions are serializable.
+	// Read-only SQLite transactions are deferred instead of taking the write lock,
+	// so the server keeps accepting writes while the backup runs
+	txOpts := &sql.TxOptions{ReadOnly: true}
+	if dialect == schema.Postgres {
+		txOpts.Isolation = sql.LevelRepeatableRead
+	}
+	tx, err := database.BeginTx(ctx, txOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	hdr := header{
+		Magic:         magic,
+		Format:        formatVersion,
+		SchemaVersion: current,
+		CreatedAt:     time.Now().UTC(),
+	}
+	if opts.Compress {
+		hdr.Compression = compressionGzip
+	}
+	if len(opts.Passphrase) > 0 {
+		hdr.Encryption, err = newEncryption()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	headerLine, err := json.Marshal(hdr)
+	if err != nil {
+		return nil, err
+	}
+	headerLine = append(headerLine, '\n')
+	if _, err := w.Write(headerLine); err != nil {
+		return nil, err
+	}
+
+	// Body pipeline: JSON lines, then gzip, then encryption
+	var (
+		body    = w
+		closers []io.Closer
+	)
+	if hdr.Encryption != nil {
+		aead, err := hdr.Encryption.aead(opts.Passphrase)
+		if err != nil {
+			return nil, err
+		}
+		enc := newEncryptWriter(body, aead, sha256.Sum256(headerLine), hdr.Encryption.ChunkSize)
+		body, closers = enc, append(closers, enc)
+	}
+	if opts.Compress {
+		gz := gzip.NewWriter(body)
+		body, closers = gz, append(closers, gz)
+	}
+
+	lw := &lineWriter{w: bufio.NewWriter(body), h: sha256.New()}
+	lw.h.Write(headerLine)
+
+	summary := &Summary{SchemaVersion: current}
+	for _, t := range tables {
+		rows, err := dumpTable(ctx, tx, t, lw)
+		if err != nil {
+			return nil, fmt.Errorf("backup %s: %w", t.name, err)
+		}
+		summary.Tables = append(summary.Tables, TableCount{Name: t.name, Rows: rows})
+	}
+
+	end := endRecord{End: trailer{Tables: summary.Tables, SHA256: fmt.Sprintf("%x", lw.h.Sum(nil))}}
+	if err := lw.writeLine(end); err != nil {
+		return nil, err
+	}
+	if err := lw.w.Flush(); err != nil {
+		return nil, err
+	}
+	for i := len(closers) - 1; i >= 0; i-- {
+		if err := closers[i].Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// dumpTable writes the table line followed by its rows
+func dumpTable(ctx context.Context, tx *sql.Tx, t table, lw *lineWriter) (int64, error) {
+	if err := lw.writeLine(record{Table: t.name, Columns: t.columns}); err != nil {
+		return 0, err
+	}
+
+	names := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		names[i] = c.Name
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s ORDER BY %s", strings.Join(names, ", "), t.name, t.order)
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	dests := make([]any, len(t.columns))
+	values := make([]any, len(t.columns))
+	var count int64
+	for rows.Next() {
+		for i, c := range t.columns {
+			dests[i] = c.Kind.dest()
+		}
+		if err := rows.Scan(dests...); err != nil {
+			return 0, err
+		}
+		for i, d := range dests {
+			values[i] = value(d)
+		}
+		if err := lw.writeLine(rowRecord{Row: values}); err != nil {
+			return 0, err
+		}
+		count++
+	}
+
+	return count, rows.Err()
+}
@@ -0,0 +1,354 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"database/sql"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	schema "github.com/rycln/gokeep/server/internal/db"
+	"github.com/rycln/gokeep/server/internal/storage"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openTestDB opens an empty SQLite database in a temporary file
+func openTestDB(t *testing.T) (*sql.DB, schema.Dialect) {
+	t.Helper()
+
+	db, dialect, err := storage.NewDB("sqlite:" + filepath.Join(t.TempDir(), "gophkeeper.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return db, dialect
+}
+
+// seedTestDB migrates the database and fills every backed up table
+func seedTestDB(t *testing.T, db *sql.DB, dialect schema.Dialect) *storage.SQLStorage {
+	t.Helper()
+	ctx := context.Background()
+
+	m, err := schema.NewMigrator(db, dialect)
+	require.NoError(t, err)
+	_, err = m.Up(ctx)
+	require.NoError(t, err)
+
+	strg := storage.NewSQLStorage(db, dialect)
+	users := make([]*models.UserDB, 2)
+	for i := range users {
+		id := uuid.NewString()
+		users[i] = &models.UserDB{
+			ID:           models.UserID(id),
+			Username:     "user-" + id[:8],
+			PassHash:     "hash",
+			Salt:         "salt",
+			EncryptedKey: "encrypted_key",
+			RecoveryKey:  "recovery_key",
+			RecoveryHash: "recovery_hash",
+			KeyPair:      models.KeyPair{PublicKey: []byte("public_key"), EncryptedPrivateKey: "encrypted_private_key"},
+		}
+		require.NoError(t, strg.AddUser(ctx, users[i]))
+	}
+	owner, other := users[0], users[1]
+	require.NoError(t, strg.SetUserDisabled(ctx, other.ID, true))
+
+	item := &models.Item{
+		ID:        models.ItemID(uuid.NewString()),
+		UserID:    owner.ID,
+		ItemType:  models.TypePassword,
+		Name:      "item",
+		Metadata:  "metadata",
+		Data:      []byte{0, 1, 2, 0xff},
+		UpdatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	require.NoError(t, strg.AddItem(ctx, item))
+
+	org := &models.Organization{ID: models.OrgID(uuid.NewString()), Name: "org"}
+	col := &models.Collection{ID: models.CollectionID(uuid.NewString()), Name: "col", WrappedKey: []byte("owner_key")}
+	require.NoError(t, strg.CreateOrganization(ctx, org, owner.ID, col))
+
+	require.NoError(t, strg.AddShare(ctx, &models.Share{
+		ItemID:      item.ID,
+		OwnerID:     owner.ID,
+		RecipientID: other.ID,
+		WrappedKey:  []byte("wrapped_key"),
+		Payload:     []byte("payload"),
+		SharedAt:    time.Now().UTC().Truncate(time.Millisecond),
+	}))
+	require.NoError(t, strg.AddContact(ctx, &models.EmergencyContact{
+		GrantorID:  owner.ID,
+		GranteeID:  other.ID,
+		WaitPeriod: time.Hour,
+		WrappedKey: []byte("wrapped_key"),
+	}))
+	require.NoError(t, strg.AddEvent(ctx, &models.AuditEvent{
+		UserID:    owner.ID,
+		Device:    "laptop",
+		IP:        "192.0.2.1",
+		Action:    models.AuditLogin,
+		Result:    models.AuditSuccess,
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}))
+	require.NoError(t, strg.AddEvent(ctx, &models.AuditEvent{Action: models.AuditLogin, Result: models.AuditFailure, CreatedAt: time.Now()}))
+
+	return strg
+}
+
+// archiveRows returns table and row lines of a plain archive.
+// Header and trailer differ between archives by creation time.
+func archiveRows(t *testing.T, archive []byte) []string {
+	t.Helper()
+
+	lines := strings.Split(strings.TrimSuffix(string(archive), "\n"), "\n")
+	require.Greater(t, len(lines), 2)
+	return lines[1 : len(lines)-1]
+}
+
+func TestCreateRestore(t *testing.T) {
+	ctx := context.Background()
+	src, dialect := openTestDB(t)
+	seedTestDB(t, src, dialect)
+
+	var plain bytes.Buffer
+	summary, err := Create(ctx, src, dialect, &plain, Options{})
+	require.NoError(t, err)
+	require.Len(t, summary.Tables, len(tables))
+	for _, tc := range summary.Tables {
+		assert.NotZero(t, tc.Rows, tc.Name)
+	}
+
+	passphrase := []byte("correct horse battery staple")
+	var sealed bytes.Buffer
+	_, err = Create(ctx, src, dialect, &sealed, Options{Compress: true, Passphrase: passphrase})
+	require.NoError(t, err)
+	assert.NotContains(t, sealed.String(), "laptop")
+
+	t.Run("plain round trip", func(t *testing.T) {
+		dst, _ := openTestDB(t)
+		restored, err := Restore(ctx, dst, dialect, bytes.NewReader(plain.Bytes()), nil)
+		require.NoError(t, err)
+		assert.Equal(t, summary, restored)
+
+		var again bytes.Buffer
+		_, err = Create(ctx, dst, dialect, &again, Options{})
+		require.NoError(t, err)
+		assert.Equal(t, archiveRows(t, plain.Bytes()), archiveRows(t, again.Bytes()))
+	})
+
+	t.Run("compressed and encrypted round trip", func(t *testing.T) {
+		dst, _ := openTestDB(t)
+		restored, err := Restore(ctx, dst, dialect, bytes.NewReader(sealed.Bytes()), passphrase)
+		require.NoError(t, err)
+		assert.Equal(t, summary, restored)
+
+		var again bytes.Buffer
+		_, err = Create(ctx, dst, dialect, &again, Options{})
+		require.NoError(t, err)
+		assert.Equal(t, archiveRows(t, plain.Bytes()), archiveRows(t, again.Bytes()))
+	})
+
+	t.Run("audit ids continue after restore", func(t *testing.T) {
+		dst, _ := openTestDB(t)
+		_, err := Restore(ctx, dst, dialect, bytes.NewReader(plain.Bytes()), nil)
+		require.NoError(t, err)
+
+		strg := storage.NewSQLStorage(dst, dialect)
+		require.NoError(t, strg.AddEvent(ctx, &models.AuditEvent{Action: models.AuditLogin, Result: models.AuditFailure, CreatedAt: time.Now()}))
+	})
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		dst, _ := openTestDB(t)
+		_, err := Restore(ctx, dst, dialect, bytes.NewReader(sealed.Bytes()), []byte("wrong"))
+		assert.ErrorIs(t, err, ErrPassphrase)
+	})
+
+	t.Run("missing passphrase", func(t *testing.T) {
+		dst, _ := openTestDB(t)
+		_, err := Restore(ctx, dst, dialect, bytes.NewReader(sealed.Bytes()), nil)
+		assert.ErrorIs(t, err, ErrPassphrase)
+	})
+
+	t.Run("tampered row rejected", func(t *testing.T) {
+		dst, _ := openTestDB(t)
+		tampered := bytes.Replace(plain.Bytes(), []byte("laptop"), []byte("laptoq"), 1)
+		_, err := Restore(ctx, dst, dialect, bytes.NewReader(tampered), nil)
+		assert.ErrorIs(t, err, ErrIntegrity)
+	})
+
+	t.Run("tampered header rejected", func(t *testing.T) {
+		dst, _ := openTestDB(t)
+		tampered := bytes.Replace(sealed.Bytes(), []byte(`"format":1`), []byte(`"format":1 `), 1)
+		_, err := Restore(ctx, dst, dialect, bytes.NewReader(tampered), passphrase)
+		assert.ErrorIs(t, err, ErrPassphrase)
+	})
+
+	t.Run("truncated archive rolled back", func(t *testing.T) {
+		dst, _ := openTestDB(t)
+		lastLine := bytes.LastIndexByte(plain.Bytes()[:plain.Len()-1], '\n')
+		_, err := Restore(ctx, dst, dialect, bytes.NewReader(plain.Bytes()[:lastLine+1]), nil)
+		assert.ErrorIs(t, err, ErrIntegrity)
+
+		var users int
+		require.NoError(t, dst.QueryRow("SELECT COUNT(*) FROM users").Scan(&users))
+		assert.Zero(t, users)
+	})
+
+	t.Run("truncated encrypted archive", func(t *testing.T) {
+		dst, _ := openTestDB(t)
+		_, err := Restore(ctx, dst, dialect, bytes.NewReader(sealed.Bytes()[:sealed.Len()-1]), passphrase)
+		assert.ErrorIs(t, err, ErrIntegrity)
+	})
+
+	t.Run("trailing data rejected", func(t *testing.T) {
+		dst, _ := openTestDB(t)
+		extended := append(bytes.Clone(plain.Bytes()), []byte(`{"row":["x"]}`+"\n")...)
+		_, err := Restore(ctx, dst, dialect, bytes.NewReader(extended), nil)
+		assert.ErrorIs(t, err, ErrIntegrity)
+	})
+
+	t.Run("non-empty target rejected", func(t *testing.T) {
+		_, err := Restore(ctx, src, dialect, bytes.NewReader(plain.Bytes()), nil)
+		assert.ErrorIs(t, err, ErrNotEmpty)
+	})
+
+	t.Run("not an archive", func(t *testing.T) {
+		dst, _ := openTestDB(t)
+		_, err := Restore(ctx, dst, dialect, bytes.NewReader([]byte("{}\n")), nil)
+		assert.ErrorIs(t, err, ErrFormat)
+	})
+}
+
+func TestCreate_OutdatedSchema(t *testing.T) {
+	db, dialect := openTestDB(t)
+
+	_, err := Create(context.Background(), db, dialect, io.Discard, Options{})
+	assert.Error(t, err)
+}
+
+// writeHook calls fn before the first write
+type writeHook struct {
+	io.Writer
+	fn func() error
+}
+
+func (w *writeHook) Write(p []byte) (int, error) {
+	if w.fn != nil {
+		fn := w.fn
+		w.fn = nil
+		if err := fn(); err != nil {
+			return 0, err
+		}
+	}
+	return w.Writer.Write(p)
+}
+
+func TestCreate_ConcurrentWrites(t *testing.T) {
+	db, dialect := openTestDB(t)
+	seedTestDB(t, db, dialect)
+
+	w := &writeHook{Writer: io.Discard, fn: func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, err := db.ExecContext(ctx, "UPDATE users SET salt = salt")
+		return err
+	}}
+	_, err := Create(context.Background(), db, dialect, w, Options{})
+	assert.NoError(t, err)
+}
+
+func TestEncryption_AEAD(t *testing.T) {
+	valid, err := newEncryption()
+	require.NoError(t, err)
+
+	_, err = valid.aead([]byte("passphrase"))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		modify func(e *encryption)
+	}{
+		{"unsupported cipher", func(e *encryption) { e.Cipher = "chacha20" }},
+		{"zero chunk size", func(e *encryption) { e.ChunkSize = 0 }},
+		{"zero threads", func(e *encryption) { e.Threads = 0 }},
+		{"zero passes", func(e *encryption) { e.Time = 0 }},
+		{"too many passes", func(e *encryption) { e.Time = maxArgonTime + 1 }},
+		{"too much memory", func(e *encryption) { e.Memory = maxArgonMemory + 1 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := *valid
+			tt.modify(&e)
+
+			_, err := e.aead([]byte("passphrase"))
+			assert.ErrorIs(t, err, ErrFormat)
+		})
+	}
+}
+
+func TestEncryptStream(t *testing.T) {
+	const size = 16
+
+	block, err := aes.NewCipher(make([]byte, keySize))
+	require.NoError(t, err)
+	aead, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	headerSum := sha256.Sum256([]byte("header"))
+
+	seal := func(t *testing.T, data []byte) []byte {
+		var buf bytes.Buffer
+		w := newEncryptWriter(&buf, aead, headerSum, size)
+		_, err := w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+	open := func(sealed []byte) ([]byte, error) {
+		return io.ReadAll(newDecryptReader(bufio.NewReader(bytes.NewReader(sealed)), aead, headerSum, size))
+	}
+
+	for _, n := range []int{0, 1, size - 1, size, size + 1, 3 * size} {
+		t.Run(fmt.Sprintf("round trip of %d bytes", n), func(t *testing.T) {
+			data := bytes.Repeat([]byte{'x'}, n)
+			got, err := open(seal(t, data))
+			require.NoError(t, err)
+			assert.Equal(t, data, got)
+		})
+	}
+
+	frame := 5 + size + aead.Overhead()
+	sealed := seal(t, bytes.Repeat([]byte{'x'}, 2*size))
+
+	t.Run("final chunk dropped", func(t *testing.T) {
+		_, err := open(sealed[:frame])
+		assert.ErrorIs(t, err, ErrIntegrity)
+	})
+
+	t.Run("chunks reordered", func(t *testing.T) {
+		reordered := append(append(bytes.Clone(sealed[frame:2*frame]), sealed[:frame]...), sealed[2*frame:]...)
+		_, err := open(reordered)
+		assert.Error(t, err)
+	})
+
+	t.Run("final flag forged", func(t *testing.T) {
+		forged := bytes.Clone(sealed[:frame])
+		forged[0] = frameFinal
+		_, err := open(forged)
+		assert.Error(t, err)
+	})
+
+	t.Run("data after final chunk", func(t *testing.T) {
+		_, err := open(append(bytes.Clone(sealed), 0))
+		assert.ErrorIs(t, err, ErrIntegrity)
+	})
+}
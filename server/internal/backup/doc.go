@@ -0,0 +1,12 @@
+// Package backup writes consistent snapshots of the server database to
+// versioned archives and restores them into an empty database.
+//
+// An archive starts with a plain JSON header line describing the format,
+// schema version, compression and encryption. The body is a stream of JSON
+// lines: a table line with column names and kinds followed by its rows,
+// and a final line with row counts and SHA-256 of all preceding lines.
+// The body is optionally gzip compressed and then encrypted with AES-256-GCM
+// in authenticated chunks, the key is derived from a passphrase with Argon2id.
+// Rows are written and read one at a time, so memory use does not depend
+// on the database size.
+package backup
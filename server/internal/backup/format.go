@@ -0,0 +1,327 @@
+package backup
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Archive format constants
+const (
+	magic         = "gophkeeper-backup"
+	formatVersion = 1
+
+	compressionGzip = "gzip"
+
+	maxHeaderSize = 4 << 10  // Header line limit
+	maxRecordSize = 64 << 20 // Single row line limit, items are far smaller
+)
+
+// Archive errors
+var (
+	// ErrFormat indicates that input is not a supported archive
+	ErrFormat = errors.New("not a supported backup archive")
+
+	// ErrIntegrity indicates that archive content does not match its checksum or counts
+	ErrIntegrity = errors.New("backup archive integrity check failed")
+
+	// ErrPassphrase indicates a missing or wrong passphrase of an encrypted archive
+	ErrPassphrase = errors.New("wrong passphrase or corrupted archive")
+
+	// ErrNotEmpty indicates that restore target already holds data
+	ErrNotEmpty = errors.New("restore target database is not empty")
+)
+
+// header describes the archive, written as the first plain line
+type header struct {
+	Magic         string      `json:"magic"`
+	Format        int         `json:"format"`
+	SchemaVersion int64       `json:"schema_version"`
+	CreatedAt     time.Time   `json:"created_at"`
+	Compression   string      `json:"compression,omitempty"`
+	Encryption    *encryption `json:"encryption,omitempty"`
+}
+
+// encryption holds parameters of passphrase based body encryption
+type encryption struct {
+	Cipher    string `json:"cipher"`
+	KDF       string `json:"kdf"`
+	Salt      []byte `json:"salt"`
+	Time      uint32 `json:"time"`
+	Memory    uint32 `json:"memory"` // KiB
+	Threads   uint8  `json:"threads"`
+	ChunkSize int    `json:"chunk_size"`
+}
+
+// record is a single body line, exactly one field is set
+type record struct {
+	Table   string            `json:"table,omitempty"`
+	Columns []column          `json:"columns,omitempty"`
+	Row     []json.RawMessage `json:"row,omitempty"`
+	End     *trailer          `json:"end,omitempty"`
+}
+
+// trailer closes the body with row counts and checksum of all preceding lines
+type trailer struct {
+	Tables []TableCount `json:"tables"`
+	SHA256 string       `json:"sha256"`
+}
+
+// TableCount reports rows of a table in the archive.
+type TableCount struct {
+	Name string `json:"name"`
+	Rows int64  `json:"rows"`
+}
+
+// Summary describes a written or restored archive.
+type Summary struct {
+	SchemaVersion int64
+	Tables        []TableCount
+}
+
+// kind defines how column values are scanned and encoded
+type kind string
+
+// Column kinds
+const (
+	kindText  kind = "text"
+	kindBytes kind = "bytes"
+	kindTime  kind = "time"
+	kindInt   kind = "int"
+	kindBool  kind = "bool"
+)
+
+// column describes a table column in the archive
+type column struct {
+	Name string `json:"name"`
+	Kind kind   `json:"kind"`
+}
+
+// table describes a backed up table
+type table struct {
+	name    string
+	columns []column
+	order   string // ORDER BY clause making snapshots reproducible
+}
+
+// tables lists backed up tables in foreign key order, parents first
+var tables = []table{
+	{
+		name: "users",
+		columns: []column{
+			{"id", kindText},
+			{"username", kindText},
+			{"username_key", kindText},
+			{"password_hash", kindText},
+			{"salt", kindText},
+			{"encrypted_key", kindText},
+			{"recovery_key", kindText},
+			{"recovery_hash", kindText},
+			{"public_key", kindBytes},
+			{"encrypted_private_key", kindText},
+			{"disabled_at", kindTime},
+			{"sessions_revoked_at", kindTime},
+		},
+		order: "id",
+	},
+	{
+		name: "organizations",
+		columns: []column{
+			{"id", kindText},
+			{"name", kindText},
+			{"created_at", kindTime},
+		},
+		order: "id",
+	},
+	{
+		name: "org_members",
+		columns: []column{
+			{"org_id", kindText},
+			{"user_id", kindText},
+			{"role", kindText},
+		},
+		order: "org_id, user_id",
+	},
+	{
+		name: "collections",
+		columns: []column{
+			{"id", kindText},
+			{"org_id", kindText},
+			{"name", kindText},
+		},
+		order: "id",
+	},
+	{
+		name: "collection_keys",
+		columns: []column{
+			{"collection_id", kindText},
+			{"user_id", kindText},
+			{"wrapped_key", kindBytes},
+		},
+		order: "collection_id, user_id",
+	},
+	{
+		name: "items",
+		columns: []column{
+			{"id", kindText},
+			{"user_id", kindText},
+			{"collection_id", kindText},
+			{"type", kindText},
+			{"name", kindText},
+			{"metadata", kindText},
+			{"data", kindBytes},
+			{"updated_at", kindTime},
+			{"is_deleted", kindBool},
+		},
+		order: "id",
+	},
+	{
+		name: "shares",
+		columns: []column{
+			{"item_id", kindText},
+			{"owner_id", kindText},
+			{"recipient_id", kindText},
+			{"wrapped_key", kindBytes},
+			{"payload", kindBytes},
+			{"shared_at", kindTime},
+		},
+		order: "item_id, recipient_id",
+	},
+	{
+		name: "emergency_contacts",
+		columns: []column{
+			{"grantor_id", kindText},
+			{"grantee_id", kindText},
+			{"wait_seconds", kindInt},
+			{"status", kindText},
+			{"requested_at", kindTime},
+			{"wrapped_key", kindBytes},
+		},
+		order: "grantor_id, grantee_id",
+	},
+	{
+		name: "audit_events",
+		columns: []column{
+			{"id", kindInt},
+			{"user_id", kindText},
+			{"device", kindText},
+			{"ip", kindText},
+			{"action", kindText},
+			{"result", kindText},
+			{"created_at", kindTime},
+		},
+		order: "id",
+	},
+}
+
+// dest returns a scan destination of the kind
+func (k kind) dest() any {
+	switch k {
+	case kindBytes:
+		return new([]byte)
+	case kindTime:
+		return new(sql.NullTime)
+	case kindInt:
+		return new(sql.NullInt64)
+	case kindBool:
+		return new(sql.NullBool)
+	default:
+		return new(sql.NullString)
+	}
+}
+
+// value converts a scanned destination to its JSON value, nil for NULL
+func value(dest any) any {
+	switch v := dest.(type) {
+	case *[]byte:
+		if *v == nil {
+			return nil
+		}
+		return *v
+	case *sql.NullTime:
+		if !v.Valid {
+			return nil
+		}
+		return v.Time.UTC()
+	case *sql.NullInt64:
+		if !v.Valid {
+			return nil
+		}
+		return v.Int64
+	case *sql.NullBool:
+		if !v.Valid {
+			return nil
+		}
+		return v.Bool
+	case *sql.NullString:
+		if !v.Valid {
+			return nil
+		}
+		return v.String
+	default:
+		return nil
+	}
+}
+
+// decode converts an archived JSON value of the kind to a query argument
+func (k kind) decode(raw json.RawMessage) (any, error) {
+	if string(raw) == "null" {
+		return nil, nil
+	}
+
+	var (
+		v   any
+		err error
+	)
+	switch k {
+	case kindText:
+		var s string
+		err = json.Unmarshal(raw, &s)
+		v = s
+	case kindBytes:
+		var b []byte
+		err = json.Unmarshal(raw, &b)
+		v = b
+	case kindTime:
+		var t time.Time
+		err = json.Unmarshal(raw, &t)
+		v = t
+	case kindInt:
+		var n int64
+		err = json.Unmarshal(raw, &n)
+		v = n
+	case kindBool:
+		var b bool
+		err = json.Unmarshal(raw, &b)
+		v = b
+	default:
+		return nil, fmt.Errorf("%w: unknown column kind %q", ErrFormat, k)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFormat, err)
+	}
+
+	return v, nil
+}
+
+// lookupTable returns the table of the current schema by name
+func lookupTable(name string) (int, *table) {
+	for i := range tables {
+		if tables[i].name == name {
+			return i, &tables[i]
+		}
+	}
+	return -1, nil
+}
+
+// hasColumn reports whether the table has the column of the kind
+func (t *table) hasColumn(c column) bool {
+	for _, own := range t.columns {
+		if own == c {
+			return true
+		}
+	}
+	return false
+}
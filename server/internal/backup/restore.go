@@ -0,0 +1,292 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"slices"
+	"strings"
+
+	schema "github.com/rycln/gokeep/server/internal/db"
+)
+
+// Restore loads an archive into an empty database.
+// Migrations are applied up to the archive schema version first, newer
+// migrations are left to the server startup. All rows are inserted in
+// a single transaction, so a failed restore leaves no data behind.
+func Restore(ctx context.Context, database *sql.DB, dialect schema.Dialect, r io.Reader, passphrase []byte) (*Summary, error) {
+	br := bufio.NewReaderSize(r, chunkSize)
+
+	headerLine, err := readLine(br, maxHeaderSize)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFormat, err)
+	}
+	var hdr header
+	if err := json.Unmarshal(headerLine, &hdr); err != nil || hdr.Magic != magic {
+		return nil, ErrFormat
+	}
+	if hdr.Format != formatVersion {
+		return nil, fmt.Errorf("%w: format version %d", ErrFormat, hdr.Format)
+	}
+	headerLine = append(headerLine, '\n') // Hashed and authenticated as written
+
+	m, err := schema.NewMigrator(database, dialect)
+	if err != nil {
+		return nil, err
+	}
+	if _, target, err := m.Version(ctx); err != nil {
+		return nil, err
+	} else if hdr.SchemaVersion > target {
+		return nil, fmt.Errorf("archive schema version %d is newer than server version %d", hdr.SchemaVersion, target)
+	}
+
+	// raw is buffered, so gzip does not read past its stream and trailing data is detected
+	raw := br
+	if hdr.Encryption != nil {
+		if len(passphrase) == 0 {
+			return nil, ErrPassphrase
+		}
+		aead, err := hdr.Encryption.aead(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		raw = bufio.NewReaderSize(newDecryptReader(br, aead, sha256.Sum256(headerLine), hdr.Encryption.ChunkSize), chunkSize)
+	}
+
+	var body io.Reader = raw
+	switch hdr.Compression {
+	case "":
+	case compressionGzip:
+		gz, err := gzip.NewReader(raw)
+		if err != nil {
+			return nil, bodyError(err)
+		}
+		gz.Multistream(false)
+		body = gz
+	default:
+		return nil, fmt.Errorf("%w: unsupported compression %q", ErrFormat, hdr.Compression)
+	}
+
+	if _, err := m.UpTo(ctx, hdr.SchemaVersion); err != nil {
+		return nil, err
+	}
+	if current, _, err := m.Version(ctx); err != nil {
+		return nil, err
+	} else if current != hdr.SchemaVersion {
+		return nil, fmt.Errorf("database schema version %d does not match archive version %d", current, hdr.SchemaVersion)
+	}
+
+	tx, err := database.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	h := sha256.New()
+	h.Write(headerLine)
+	ld := &loader{tx: tx, dialect: dialect, last: -1}
+	defer ld.close()
+
+	lines := bufio.NewReaderSize(body, chunkSize)
+	if err := ld.load(ctx, lines, h); err != nil {
+		return nil, err
+	}
+	if err := expectEOF(lines); err != nil {
+		return nil, err
+	}
+	if err := expectEOF(raw); err != nil {
+		return nil, err
+	}
+
+	if dialect == schema.Postgres && slices.ContainsFunc(ld.counts, func(c TableCount) bool { return c.Name == "audit_events" }) {
+		// Explicit ids do not advance the serial sequence
+		_, err := tx.ExecContext(ctx,
+			"SELECT setval(pg_get_serial_sequence('audit_events', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM audit_events")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ld.close()
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &Summary{SchemaVersion: hdr.SchemaVersion, Tables: ld.counts}, nil
+}
+
+// loader inserts archived rows table by table
+type loader struct {
+	tx      *sql.Tx
+	dialect schema.Dialect
+	last    int // Index of the current table, tables must follow foreign key order
+	columns []column
+	stmt    *sql.Stmt
+	counts  []TableCount
+}
+
+// load reads body lines up to and including the end record
+func (l *loader) load(ctx context.Context, lines *bufio.Reader, h hash.Hash) error {
+	for {
+		line, err := readLine(lines, maxRecordSize)
+		if err != nil {
+			return bodyError(err)
+		}
+
+		var rec record
+		dec := json.NewDecoder(bytes.NewReader(line))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&rec); err != nil {
+			return fmt.Errorf("%w: %v", ErrFormat, err)
+		}
+
+		switch {
+		case rec.End != nil:
+			if fmt.Sprintf("%x", h.Sum(nil)) != rec.End.SHA256 || !slices.Equal(rec.End.Tables, l.counts) {
+				return ErrIntegrity
+			}
+			return nil
+		case rec.Table != "":
+			err = l.startTable(ctx, rec.Table, rec.Columns)
+		case rec.Row != nil:
+			err = l.insert(ctx, rec.Row)
+		default:
+			err = fmt.Errorf("%w: unknown record", ErrFormat)
+		}
+		if err != nil {
+			return err
+		}
+
+		h.Write(line)
+		h.Write([]byte{'\n'})
+	}
+}
+
+// startTable validates the archived table and prepares its insert
+func (l *loader) startTable(ctx context.Context, name string, columns []column) error {
+	idx, t := lookupTable(name)
+	if t == nil || idx <= l.last {
+		return fmt.Errorf("%w: unexpected table %q", ErrFormat, name)
+	}
+	if len(columns) == 0 {
+		return fmt.Errorf("%w: table %q has no columns", ErrFormat, name)
+	}
+
+	names := make([]string, len(columns))
+	params := make([]string, len(columns))
+	for i, c := range columns {
+		if !t.hasColumn(c) || slices.Contains(names[:i], c.Name) {
+			return fmt.Errorf("%w: unexpected column %s.%s", ErrFormat, name, c.Name)
+		}
+		names[i] = c.Name
+		params[i] = fmt.Sprintf("$%d", i+1)
+	}
+
+	var one int
+	err := l.tx.QueryRowContext(ctx, fmt.Sprintf("SELECT 1 FROM %s LIMIT 1", name)).Scan(&one)
+	if err == nil {
+		return fmt.Errorf("%w: table %s has rows", ErrNotEmpty, name)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	l.close()
+	l.stmt, err = l.tx.PrepareContext(ctx, fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		name, strings.Join(names, ", "), strings.Join(params, ", ")))
+	if err != nil {
+		return err
+	}
+
+	l.last = idx
+	l.columns = columns
+	l.counts = append(l.counts, TableCount{Name: name})
+	return nil
+}
+
+// insert adds a row to the current table
+func (l *loader) insert(ctx context.Context, row []json.RawMessage) error {
+	if l.stmt == nil {
+		return fmt.Errorf("%w: row outside of a table", ErrFormat)
+	}
+	if len(row) != len(l.columns) {
+		return fmt.Errorf("%w: row of %d values, expected %d", ErrFormat, len(row), len(l.columns))
+	}
+
+	args := make([]any, len(row))
+	for i, raw := range row {
+		v, err := l.columns[i].Kind.decode(raw)
+		if err != nil {
+			return err
+		}
+		args[i] = v
+	}
+
+	if _, err := l.stmt.ExecContext(ctx, args...); err != nil {
+		return fmt.Errorf("restore %s: %w", l.counts[len(l.counts)-1].Name, err)
+	}
+	l.counts[len(l.counts)-1].Rows++
+	return nil
+}
+
+// close releases the prepared insert
+func (l *loader) close() {
+	if l.stmt != nil {
+		l.stmt.Close()
+		l.stmt = nil
+	}
+}
+
+// readLine returns the next line without the newline, longer lines are rejected
+func readLine(r *bufio.Reader, limit int) ([]byte, error) {
+	var line []byte
+	for {
+		chunk, err := r.ReadSlice('\n')
+		line = append(line, chunk...)
+		if len(line) > limit {
+			return nil, errors.New("line too long")
+		}
+		switch {
+		case err == nil:
+			return line[:len(line)-1], nil
+		case errors.Is(err, bufio.ErrBufferFull):
+			continue
+		case errors.Is(err, io.EOF):
+			return nil, io.ErrUnexpectedEOF
+		default:
+			return nil, err
+		}
+	}
+}
+
+// expectEOF fails when the reader has data left
+func expectEOF(r io.Reader) error {
+	n, err := r.Read(make([]byte, 1))
+	if n > 0 {
+		return fmt.Errorf("%w: data after the end record", ErrIntegrity)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return bodyError(err)
+	}
+	return nil
+}
+
+// bodyError maps read failures of a damaged body to archive errors
+func bodyError(err error) error {
+	switch {
+	case errors.Is(err, ErrIntegrity), errors.Is(err, ErrPassphrase), errors.Is(err, ErrFormat):
+		return err
+	case errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, gzip.ErrChecksum), errors.Is(err, gzip.ErrHeader):
+		return fmt.Errorf("%w: %v", ErrIntegrity, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,221 @@
+package backup
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Body encryption parameters
+const (
+	cipherAESGCM = "aes-256-gcm"
+	kdfArgon2id  = "argon2id"
+
+	keySize   = 32
+	saltSize  = 16
+	chunkSize = 64 << 10
+
+	argonTime    = 3
+	argonMemory  = 64 << 10 // KiB
+	argonThreads = 4
+
+	maxArgonTime   = 64      // Passes accepted from an archive header
+	maxArgonMemory = 4 << 20 // KiB accepted from an archive header
+
+	frameFinal = 1 // Flag of the last chunk, guards against truncation
+)
+
+// newEncryption returns parameters with a fresh random salt
+func newEncryption() (*encryption, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	return &encryption{
+		Cipher:    cipherAESGCM,
+		KDF:       kdfArgon2id,
+		Salt:      salt,
+		Time:      argonTime,
+		Memory:    argonMemory,
+		Threads:   argonThreads,
+		ChunkSize: chunkSize,
+	}, nil
+}
+
+// aead derives the key from the passphrase and creates the cipher
+func (e *encryption) aead(passphrase []byte) (cipher.AEAD, error) {
+	if e.Cipher != cipherAESGCM || e.KDF != kdfArgon2id {
+		return nil, fmt.Errorf("%w: unsupported encryption %s/%s", ErrFormat, e.Cipher, e.KDF)
+	}
+	if e.ChunkSize <= 0 || e.ChunkSize > maxRecordSize || e.Threads == 0 ||
+		e.Time == 0 || e.Time > maxArgonTime || e.Memory > maxArgonMemory {
+		return nil, fmt.Errorf("%w: invalid encryption parameters", ErrFormat)
+	}
+
+	block, err := aes.NewCipher(argon2.IDKey(passphrase, e.Salt, e.Time, e.Memory, e.Threads, keySize))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce builds a unique nonce from the chunk sequence number
+func chunkNonce(aead cipher.AEAD, seq uint64) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], seq)
+	return nonce
+}
+
+// chunkAAD binds a chunk to the archive header and its position flag
+func chunkAAD(headerSum [sha256.Size]byte, flag byte) []byte {
+	return append(headerSum[:], flag)
+}
+
+// encryptWriter seals written data in fixed size chunks.
+// Each frame is a flag byte, big endian ciphertext length and the ciphertext.
+type encryptWriter struct {
+	w         io.Writer
+	aead      cipher.AEAD
+	headerSum [sha256.Size]byte
+	buf       []byte
+	size      int
+	seq       uint64
+}
+
+// newEncryptWriter creates writer sealing chunks of the size
+func newEncryptWriter(w io.Writer, aead cipher.AEAD, headerSum [sha256.Size]byte, size int) *encryptWriter {
+	return &encryptWriter{
+		w:         w,
+		aead:      aead,
+		headerSum: headerSum,
+		buf:       make([]byte, 0, size),
+		size:      size,
+	}
+}
+
+// Write buffers data and seals every full chunk except the last one
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		if len(e.buf) == e.size {
+			if err := e.seal(0); err != nil {
+				return n - len(p), err
+			}
+		}
+		k := min(e.size-len(e.buf), len(p))
+		e.buf = append(e.buf, p[:k]...)
+		p = p[k:]
+	}
+	return n, nil
+}
+
+// Close seals the remaining data as the final chunk, it may be empty
+func (e *encryptWriter) Close() error {
+	return e.seal(frameFinal)
+}
+
+// seal writes buffered data as a single frame
+func (e *encryptWriter) seal(flag byte) error {
+	sealed := e.aead.Seal(nil, chunkNonce(e.aead, e.seq), e.buf, chunkAAD(e.headerSum, flag))
+	e.seq++
+	e.buf = e.buf[:0]
+
+	var prefix [5]byte
+	prefix[0] = flag
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(sealed)))
+	if _, err := e.w.Write(prefix[:]); err != nil {
+		return err
+	}
+	_, err := e.w.Write(sealed)
+	return err
+}
+
+// decryptReader opens frames written by encryptWriter
+type decryptReader struct {
+	r         *bufio.Reader
+	aead      cipher.AEAD
+	headerSum [sha256.Size]byte
+	size      int
+	buf       []byte
+	seq       uint64
+	final     bool
+}
+
+// newDecryptReader creates reader of chunks not larger than the size
+func newDecryptReader(r *bufio.Reader, aead cipher.AEAD, headerSum [sha256.Size]byte, size int) *decryptReader {
+	return &decryptReader{r: r, aead: aead, headerSum: headerSum, size: size}
+}
+
+// Read returns opened data, fails on truncated, reordered or modified frames
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if d.final {
+			return 0, d.checkEOF()
+		}
+		if err := d.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+// open reads and authenticates the next frame
+func (d *decryptReader) open() error {
+	var prefix [5]byte
+	if _, err := io.ReadFull(d.r, prefix[:]); err != nil {
+		return truncated(err)
+	}
+
+	flag, length := prefix[0], binary.BigEndian.Uint32(prefix[1:])
+	if flag&^frameFinal != 0 || int64(length) > int64(d.size+d.aead.Overhead()) {
+		return fmt.Errorf("%w: invalid chunk", ErrIntegrity)
+	}
+
+	sealed := make([]byte, length)
+	if _, err := io.ReadFull(d.r, sealed); err != nil {
+		return truncated(err)
+	}
+
+	plain, err := d.aead.Open(sealed[:0], chunkNonce(d.aead, d.seq), sealed, chunkAAD(d.headerSum, flag))
+	if err != nil {
+		if d.seq == 0 {
+			return ErrPassphrase
+		}
+		return fmt.Errorf("%w: chunk %d authentication failed", ErrIntegrity, d.seq)
+	}
+
+	d.seq++
+	d.buf = plain
+	d.final = flag == frameFinal
+	return nil
+}
+
+// checkEOF rejects data appended after the final chunk
+func (d *decryptReader) checkEOF() error {
+	if _, err := d.r.ReadByte(); err == nil {
+		return fmt.Errorf("%w: data after the final chunk", ErrIntegrity)
+	} else if !errors.Is(err, io.EOF) {
+		return err
+	}
+	return io.EOF
+}
+
+// truncated maps unexpected end of input to an integrity error
+func truncated(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: archive is truncated", ErrIntegrity)
+	}
+	return err
+}
@@ -21,9 +21,26 @@ const (
 	defaultTimeout   = time.Duration(2) * time.Minute
 	defaultKeyLength = 32
 	defaultLogLevel  = "debug"
+	defaultLogFormat = "console"
+
+	defaultRateLimit        = 20
+	defaultRateWindow       = time.Minute
+	defaultLockoutThreshold = 5
+	defaultLockoutBase      = 30 * time.Second
+	defaultLockoutMax       = time.Hour
+	defaultSessionCacheTTL  = 30 * time.Second
+
+	defaultUsernameMinLen   = 3
+	defaultUsernameMaxLen   = 32
+	defaultUsernameCharset  = `^[\p{L}\p{N}._-]+$`
+	defaultPasswordMinLen   = 8
+	defaultPasswordMinKinds = 2
 )
 
-var errEmptyCfgFilepath = errors.New("empty cfg file path")
+var (
+	errEmptyCfgFilepath = errors.New("empty cfg file path")
+	errNoClientCA       = errors.New("client certificates required without client CA")
+)
 
 // Cfg contains all application configuration parameters.
 //
@@ -35,15 +52,28 @@ var errEmptyCfgFilepath = errors.New("empty cfg file path")
 //
 // Tags specify the corresponding environment variable names.
 type Cfg struct {
-	// DatabaseDsn specifies database connection string
+	// DatabaseDsn specifies database connection string, sqlite:path selects embedded SQLite
 	DatabaseDsn string `json:"database_dsn" env:"DATABASE_DSN"`
 
+	// Migrate applies pending database migrations at startup
+	Migrate bool `json:"migrate" env:"MIGRATE"`
+
+	// Dev runs a self-contained development server: data is kept in memory
+	// and a self-signed certificate is generated unless one is set
+	Dev bool `json:"dev" env:"DEV"`
+
 	// Key contains JWT signing key (min 32 bytes recommended)
 	Key string `json:"jwt_key" env:"JWT_KEY"`
 
 	// LogLevel sets logging verbosity (debug|info|warn|error)
 	LogLevel string `json:"log_level" env:"LOG_LEVEL"`
 
+	// LogFormat selects log output: console for humans, json for log collectors
+	LogFormat string `json:"log_format" env:"LOG_FORMAT"`
+
+	// LogPayloads logs gRPC requests and responses with secrets masked
+	LogPayloads bool `json:"log_payloads" env:"LOG_PAYLOADS"`
+
 	// GRPCPort defines port for gRPC endpoints
 	GRPCPort string `json:"grpc_port" env:"GRPC_PORT"`
 
@@ -56,8 +86,74 @@ type Cfg struct {
 	// CertFileName specifies cert key file name
 	CertKeyFileName string `json:"cert_key" env:"CERT_KEY"`
 
+	// ClientCAFileName specifies CA bundle of device certificates, empty disables mTLS
+	ClientCAFileName string `json:"client_ca" env:"CLIENT_CA"`
+
+	// ClientCertRequired rejects device calls without a verified client certificate,
+	// JWT is still required on top of it
+	ClientCertRequired bool `json:"client_cert_required" env:"CLIENT_CERT_REQUIRED"`
+
+	// GatewayAddr defines HTTPS address of REST/JSON gateway, empty disables it
+	GatewayAddr string `json:"gateway_addr" env:"GATEWAY_ADDR"`
+
+	// MetricsAddr defines HTTP address of Prometheus metrics endpoint, empty disables it
+	MetricsAddr string `json:"metrics_addr" env:"METRICS_ADDR"`
+
+	// TraceEndpoint defines OTLP gRPC collector address, empty disables export
+	TraceEndpoint string `json:"trace_endpoint" env:"TRACE_ENDPOINT"`
+
+	// TraceInsecure disables TLS to the trace collector
+	TraceInsecure bool `json:"trace_insecure" env:"TRACE_INSECURE"`
+
+	// TraceFile specifies file to write spans as JSON for local debugging, "stdout" for console
+	TraceFile string `json:"trace_file" env:"TRACE_FILE"`
+
+	// Reflection enables gRPC server reflection for tools like grpcurl
+	Reflection bool `json:"reflection" env:"REFLECTION"`
+
+	// AdminToken is the bearer token of the admin gRPC service, empty disables the service
+	AdminToken string `json:"admin_token" env:"ADMIN_TOKEN"`
+
+	// QuotaBytes caps size of personal items per user, zero means unlimited
+	QuotaBytes int64 `json:"quota_bytes" env:"QUOTA_BYTES"`
+
 	// Timeout defines default network operation timeout
 	Timeout time.Duration `json:"timeout_dur" env:"TIMEOUT_DUR"`
+
+	// SessionCacheTTL defines how long successful session checks are reused
+	// without the database, zero disables caching
+	SessionCacheTTL time.Duration `json:"session_cache_ttl" env:"SESSION_CACHE_TTL"`
+
+	// RateLimit sets max Register, Login and Recover requests per client address
+	// and per username in RateWindow, zero disables limiting
+	RateLimit int `json:"rate_limit" env:"RATE_LIMIT"`
+
+	// RateWindow defines rate limiting period
+	RateWindow time.Duration `json:"rate_window" env:"RATE_WINDOW"`
+
+	// LockoutThreshold sets failed logins in a row before username lockout, zero disables lockout
+	LockoutThreshold int `json:"lockout_threshold" env:"LOCKOUT_THRESHOLD"`
+
+	// LockoutBase defines first lockout duration, doubled on each further failure
+	LockoutBase time.Duration `json:"lockout_base" env:"LOCKOUT_BASE"`
+
+	// LockoutMax caps lockout duration
+	LockoutMax time.Duration `json:"lockout_max" env:"LOCKOUT_MAX"`
+
+	// UsernameMinLen sets min username length in characters
+	UsernameMinLen int `json:"username_min_len" env:"USERNAME_MIN_LEN"`
+
+	// UsernameMaxLen sets max username length in characters
+	UsernameMaxLen int `json:"username_max_len" env:"USERNAME_MAX_LEN"`
+
+	// UsernameCharset is a regular expression the whole username must match
+	UsernameCharset string `json:"username_charset" env:"USERNAME_CHARSET"`
+
+	// PasswordMinLen sets min password length in characters
+	PasswordMinLen int `json:"password_min_len" env:"PASSWORD_MIN_LEN"`
+
+	// PasswordMinKinds sets required kinds of password characters: lower, upper, digits, other
+	PasswordMinKinds int `json:"password_min_kinds" env:"PASSWORD_MIN_KINDS"`
 }
 
 // ConfigBuilder implements builder pattern for Cfg.
@@ -70,9 +166,21 @@ type ConfigBuilder struct {
 func NewConfigBuilder() *ConfigBuilder {
 	return &ConfigBuilder{
 		cfg: &Cfg{
-			Timeout:  defaultTimeout,
-			LogLevel: defaultLogLevel,
-			GRPCPort: defaultGRPCPort,
+			Timeout:          defaultTimeout,
+			LogLevel:         defaultLogLevel,
+			LogFormat:        defaultLogFormat,
+			GRPCPort:         defaultGRPCPort,
+			RateLimit:        defaultRateLimit,
+			RateWindow:       defaultRateWindow,
+			LockoutThreshold: defaultLockoutThreshold,
+			LockoutBase:      defaultLockoutBase,
+			LockoutMax:       defaultLockoutMax,
+			SessionCacheTTL:  defaultSessionCacheTTL,
+			UsernameMinLen:   defaultUsernameMinLen,
+			UsernameMaxLen:   defaultUsernameMaxLen,
+			UsernameCharset:  defaultUsernameCharset,
+			PasswordMinLen:   defaultPasswordMinLen,
+			PasswordMinKinds: defaultPasswordMinKinds,
 		},
 		err: nil,
 	}
@@ -120,13 +228,38 @@ func (b *ConfigBuilder) WithFlagParsing() *ConfigBuilder {
 	}
 
 	flag.StringVarP(&b.cfg.DatabaseDsn, "d", "d", b.cfg.DatabaseDsn, "Database connection address")
+	flag.BoolVar(&b.cfg.Migrate, "migrate", b.cfg.Migrate, "Apply database migrations at startup")
+	flag.BoolVar(&b.cfg.Dev, "dev", b.cfg.Dev, "Run development server with in-memory storage")
 	flag.DurationVarP(&b.cfg.Timeout, "t", "t", b.cfg.Timeout, "Timeout duration in seconds")
 	flag.StringVarP(&b.cfg.Key, "k", "k", b.cfg.Key, "Key for jwt autorization")
 	flag.StringVarP(&b.cfg.LogLevel, "l", "l", b.cfg.LogLevel, "Logger level")
+	flag.StringVar(&b.cfg.LogFormat, "log-format", b.cfg.LogFormat, "Log format: console or json")
+	flag.BoolVar(&b.cfg.LogPayloads, "log-payloads", b.cfg.LogPayloads, "Log gRPC payloads with secrets masked")
 	flag.StringVarP(&b.cfg.GRPCPort, "g", "g", b.cfg.GRPCPort, "gRPC port")
 	flag.StringVarP(&b.cfg.CfgFileName, "config", "c", b.cfg.CfgFileName, "Path to config file")
 	flag.StringVar(&b.cfg.CertFileName, "tls-cert", b.cfg.CertFileName, "Path to cert file")
 	flag.StringVar(&b.cfg.CertKeyFileName, "tls-key", b.cfg.CertKeyFileName, "Path to cert key file")
+	flag.StringVar(&b.cfg.ClientCAFileName, "tls-client-ca", b.cfg.ClientCAFileName, "Path to CA bundle of client certificates")
+	flag.BoolVar(&b.cfg.ClientCertRequired, "tls-client-cert-required", b.cfg.ClientCertRequired, "Require client certificates")
+	flag.StringVar(&b.cfg.GatewayAddr, "gateway-addr", b.cfg.GatewayAddr, "REST gateway HTTPS address")
+	flag.StringVar(&b.cfg.MetricsAddr, "metrics-addr", b.cfg.MetricsAddr, "Metrics HTTP address")
+	flag.BoolVar(&b.cfg.Reflection, "reflection", b.cfg.Reflection, "Enable gRPC server reflection")
+	flag.StringVar(&b.cfg.AdminToken, "admin-token", b.cfg.AdminToken, "Admin service bearer token")
+	flag.Int64Var(&b.cfg.QuotaBytes, "quota-bytes", b.cfg.QuotaBytes, "Max size of personal items per user")
+	flag.StringVar(&b.cfg.TraceEndpoint, "trace-endpoint", b.cfg.TraceEndpoint, "OTLP gRPC trace collector address")
+	flag.BoolVar(&b.cfg.TraceInsecure, "trace-insecure", b.cfg.TraceInsecure, "Disable TLS to trace collector")
+	flag.StringVar(&b.cfg.TraceFile, "trace-file", b.cfg.TraceFile, "File to write traces to, stdout for console")
+	flag.DurationVar(&b.cfg.SessionCacheTTL, "session-cache-ttl", b.cfg.SessionCacheTTL, "Session check cache duration")
+	flag.IntVar(&b.cfg.RateLimit, "rate-limit", b.cfg.RateLimit, "Max auth requests per client and username in rate window")
+	flag.DurationVar(&b.cfg.RateWindow, "rate-window", b.cfg.RateWindow, "Rate limiting window")
+	flag.IntVar(&b.cfg.LockoutThreshold, "lockout-threshold", b.cfg.LockoutThreshold, "Failed logins before lockout")
+	flag.DurationVar(&b.cfg.LockoutBase, "lockout-base", b.cfg.LockoutBase, "First lockout duration")
+	flag.DurationVar(&b.cfg.LockoutMax, "lockout-max", b.cfg.LockoutMax, "Max lockout duration")
+	flag.IntVar(&b.cfg.UsernameMinLen, "username-min-len", b.cfg.UsernameMinLen, "Min username length")
+	flag.IntVar(&b.cfg.UsernameMaxLen, "username-max-len", b.cfg.UsernameMaxLen, "Max username length")
+	flag.StringVar(&b.cfg.UsernameCharset, "username-charset", b.cfg.UsernameCharset, "Username regular expression")
+	flag.IntVar(&b.cfg.PasswordMinLen, "password-min-len", b.cfg.PasswordMinLen, "Min password length")
+	flag.IntVar(&b.cfg.PasswordMinKinds, "password-min-kinds", b.cfg.PasswordMinKinds, "Required kinds of password characters")
 	flag.Parse()
 
 	return b
@@ -183,5 +316,9 @@ func (b *ConfigBuilder) Build() (*Cfg, error) {
 		return nil, b.err
 	}
 
+	if b.cfg.ClientCertRequired && b.cfg.ClientCAFileName == "" {
+		return nil, errNoClientCA
+	}
+
 	return b.cfg, nil
 }
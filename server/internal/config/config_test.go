@@ -18,24 +18,95 @@ const (
 	testLoggerLevel = "info"
 	testCfgFileName = "testcfg.json"
 	testGRPCPort    = ":50052"
+	testRateLimit   = 3
+	testRateWindow  = 10 * time.Second
+	testLockout     = 2
+	testLockoutBase = time.Second
+	testLockoutMax  = time.Minute
+	testCharset     = `^[a-z]+$`
+	testMetricsAddr = ":9090"
+	testGatewayAddr = ":8443"
+	testTraceAddr   = "localhost:4317"
+	testTraceFile   = "traces.json"
+	testAdminToken  = "admin_token"
+	testQuotaBytes  = 1 << 20
+	testClientCA    = "ca.pem"
+	testSessionTTL  = 5 * time.Second
 )
 
 var testCfg = &Cfg{
 	DatabaseDsn: testDatabaseDsn,
+	Migrate:     true,
+	Dev:         true,
 	Timeout:     testTimeout,
 	Key:         testKey,
 	LogLevel:    testLoggerLevel,
+	LogFormat:   "json",
+	LogPayloads: true,
 	GRPCPort:    testGRPCPort,
 	CfgFileName: testCfgFileName,
+	MetricsAddr: testMetricsAddr,
+	GatewayAddr: testGatewayAddr,
+
+	ClientCAFileName:   testClientCA,
+	ClientCertRequired: true,
+
+	TraceEndpoint: testTraceAddr,
+	TraceInsecure: true,
+	TraceFile:     testTraceFile,
+
+	Reflection: true,
+
+	AdminToken: testAdminToken,
+	QuotaBytes: testQuotaBytes,
+
+	SessionCacheTTL: testSessionTTL,
+
+	RateLimit:        testRateLimit,
+	RateWindow:       testRateWindow,
+	LockoutThreshold: testLockout,
+	LockoutBase:      testLockoutBase,
+	LockoutMax:       testLockoutMax,
+
+	UsernameMinLen:   2,
+	UsernameMaxLen:   10,
+	UsernameCharset:  testCharset,
+	PasswordMinLen:   12,
+	PasswordMinKinds: 3,
 }
 
 func TestConfigBuilder_WithEnvParsing(t *testing.T) {
 	t.Setenv("DATABASE_DSN", testCfg.DatabaseDsn)
+	t.Setenv("MIGRATE", "true")
+	t.Setenv("DEV", "true")
 	t.Setenv("TIMEOUT_DUR", testCfg.Timeout.String())
 	t.Setenv("JWT_KEY", testCfg.Key)
 	t.Setenv("LOG_LEVEL", testCfg.LogLevel)
+	t.Setenv("LOG_FORMAT", "json")
+	t.Setenv("LOG_PAYLOADS", "true")
 	t.Setenv("GRPC_PORT", testGRPCPort)
 	t.Setenv("CONFIG", testCfgFileName)
+	t.Setenv("METRICS_ADDR", testMetricsAddr)
+	t.Setenv("GATEWAY_ADDR", testGatewayAddr)
+	t.Setenv("CLIENT_CA", testClientCA)
+	t.Setenv("CLIENT_CERT_REQUIRED", "true")
+	t.Setenv("TRACE_ENDPOINT", testTraceAddr)
+	t.Setenv("TRACE_INSECURE", "true")
+	t.Setenv("TRACE_FILE", testTraceFile)
+	t.Setenv("REFLECTION", "true")
+	t.Setenv("ADMIN_TOKEN", testAdminToken)
+	t.Setenv("QUOTA_BYTES", "1048576")
+	t.Setenv("SESSION_CACHE_TTL", testSessionTTL.String())
+	t.Setenv("RATE_LIMIT", "3")
+	t.Setenv("RATE_WINDOW", testRateWindow.String())
+	t.Setenv("LOCKOUT_THRESHOLD", "2")
+	t.Setenv("LOCKOUT_BASE", testLockoutBase.String())
+	t.Setenv("LOCKOUT_MAX", testLockoutMax.String())
+	t.Setenv("USERNAME_MIN_LEN", "2")
+	t.Setenv("USERNAME_MAX_LEN", "10")
+	t.Setenv("USERNAME_CHARSET", testCharset)
+	t.Setenv("PASSWORD_MIN_LEN", "12")
+	t.Setenv("PASSWORD_MIN_KINDS", "3")
 
 	t.Run("valid test", func(t *testing.T) {
 		cfg, err := NewConfigBuilder().
@@ -56,6 +127,17 @@ func TestConfigBuilder_WithDefaultJWTKey(t *testing.T) {
 	})
 }
 
+func TestConfigBuilder_Build(t *testing.T) {
+	t.Run("client certificates required without ca", func(t *testing.T) {
+		t.Setenv("CLIENT_CERT_REQUIRED", "true")
+
+		_, err := NewConfigBuilder().
+			WithEnvParsing().
+			Build()
+		assert.ErrorIs(t, err, errNoClientCA)
+	})
+}
+
 func TestConfigBuilder_WithFlagParsing(t *testing.T) {
 	pflag.CommandLine = pflag.NewFlagSet(os.Args[0], pflag.ExitOnError)
 
@@ -68,11 +150,36 @@ func TestConfigBuilder_WithFlagParsing(t *testing.T) {
 		os.Args = []string{
 			"./server",
 			"-d=" + testCfg.DatabaseDsn,
+			"--migrate",
+			"--dev",
 			"-t=" + testCfg.Timeout.String(),
 			"-k=" + testCfg.Key,
 			"-l=" + testCfg.LogLevel,
+			"--log-format=json",
+			"--log-payloads",
 			"-g=" + testCfg.GRPCPort,
 			"-c=" + testCfg.CfgFileName,
+			"--metrics-addr=" + testMetricsAddr,
+			"--gateway-addr=" + testGatewayAddr,
+			"--tls-client-ca=" + testClientCA,
+			"--tls-client-cert-required",
+			"--trace-endpoint=" + testTraceAddr,
+			"--trace-insecure",
+			"--trace-file=" + testTraceFile,
+			"--reflection",
+			"--admin-token=" + testAdminToken,
+			"--quota-bytes=1048576",
+			"--session-cache-ttl=" + testSessionTTL.String(),
+			"--rate-limit=3",
+			"--rate-window=" + testRateWindow.String(),
+			"--lockout-threshold=2",
+			"--lockout-base=" + testLockoutBase.String(),
+			"--lockout-max=" + testLockoutMax.String(),
+			"--username-min-len=2",
+			"--username-max-len=10",
+			"--username-charset=" + testCharset,
+			"--password-min-len=12",
+			"--password-min-kinds=3",
 		}
 
 		cfg, err := NewConfigBuilder().
@@ -112,6 +219,8 @@ func TestConfigBuilder_WithConfigFile(t *testing.T) {
 			"-t=" + testCfg.Timeout.String(),
 			"-k=" + testCfg.Key,
 			"-l=" + testCfg.LogLevel,
+			"--log-format=json",
+			"--log-payloads",
 			"-g=" + testCfg.GRPCPort,
 			"-c=" + testCfg.CfgFileName,
 		}
@@ -166,6 +275,8 @@ func TestConfigBuilder_WithConfigFile(t *testing.T) {
 			"-t=" + testCfg.Timeout.String(),
 			"-k=" + testCfg.Key,
 			"-l=" + testCfg.LogLevel,
+			"--log-format=json",
+			"--log-payloads",
 			"-g=" + testCfg.GRPCPort,
 			"-c=" + testCfg.CfgFileName,
 		}
@@ -180,3 +291,51 @@ func TestConfigBuilder_WithConfigFile(t *testing.T) {
 		assert.ErrorIs(t, err, errEmptyCfgFilepath)
 	})
 }
+
+func TestConfigBuilder_Reload(t *testing.T) {
+	dir := t.TempDir()
+	fname := dir + "/cfg.json"
+
+	running := *testCfg
+	running.CfgFileName = fname
+
+	t.Run("file values override running config", func(t *testing.T) {
+		err := os.WriteFile(fname, []byte(`{"log_level":"warn","grpc_port":":50053"}`), 0o600)
+		require.NoError(t, err)
+
+		cfg, err := NewConfigBuilderFrom(&running).
+			WithConfigFile().
+			Build()
+		require.NoError(t, err)
+		assert.Equal(t, "warn", cfg.LogLevel)
+		assert.Equal(t, ":50053", cfg.GRPCPort)
+		assert.Equal(t, testLoggerLevel, running.LogLevel, "running config must stay unchanged")
+	})
+
+	t.Run("broken file", func(t *testing.T) {
+		err := os.WriteFile(fname, []byte(`{`), 0o600)
+		require.NoError(t, err)
+
+		_, err = NewConfigBuilderFrom(&running).
+			WithConfigFile().
+			Build()
+		assert.Error(t, err)
+	})
+}
+
+func TestRestartRequired(t *testing.T) {
+	t.Run("only static settings reported", func(t *testing.T) {
+		cur := *testCfg
+		cur.LogLevel = "error"
+		cur.CertFileName = "new.pem"
+		cur.GRPCPort = ":50053"
+		cur.Key = "another_secret_key"
+
+		assert.Equal(t, []string{"jwt_key", "grpc_port"}, RestartRequired(testCfg, &cur))
+	})
+
+	t.Run("nothing changed", func(t *testing.T) {
+		cur := *testCfg
+		assert.Empty(t, RestartRequired(testCfg, &cur))
+	})
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// reloadable lists JSON names of settings applied without restart
+var reloadable = map[string]bool{
+	"log_level": true,
+	"cert":      true,
+	"cert_key":  true,
+}
+
+// NewConfigBuilderFrom creates a builder starting from a copy of the running configuration.
+// Used on reload, when command-line flags are already parsed and cannot be parsed again.
+func NewConfigBuilderFrom(cfg *Cfg) *ConfigBuilder {
+	cp := *cfg
+	return &ConfigBuilder{
+		cfg: &cp,
+		err: nil,
+	}
+}
+
+// RestartRequired returns JSON names of changed settings that are applied only on restart.
+// Values are not reported because some of them are secrets.
+func RestartRequired(old, cur *Cfg) []string {
+	var names []string
+
+	ov, cv := reflect.ValueOf(old).Elem(), reflect.ValueOf(cur).Elem()
+	for i := range ov.NumField() {
+		name, _, _ := strings.Cut(ov.Type().Field(i).Tag.Get("json"), ",")
+		if reloadable[name] {
+			continue
+		}
+		if !ov.Field(i).Equal(cv.Field(i)) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
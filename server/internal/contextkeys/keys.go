@@ -1,11 +1,29 @@
 // Package contextkeys provides type-safe keys for storing values in request context.
 package contextkeys
 
-type contextKey struct{}
+type (
+	contextKey struct{}
+	deviceKey  struct{}
+	peerIPKey  struct{}
+	requestKey struct{}
+)
 
 // Package-level context keys for storing common request values.
 var (
 	// UserID is the context key for storing authenticated user ID.
 	// Populated by auth middleware after JWT verification.
 	UserID = contextKey{}
+
+	// Device is the context key for storing client device.
+	// Populated by client info interceptor from user agent and replaced
+	// by client cert interceptor with identity of a verified certificate.
+	Device = deviceKey{}
+
+	// PeerIP is the context key for storing client network address.
+	// Populated by client info interceptor from the gRPC peer.
+	PeerIP = peerIPKey{}
+
+	// RequestID is the context key for storing request ID attached to log lines.
+	// Populated by request ID interceptor from metadata or generated.
+	RequestID = requestKey{}
 )
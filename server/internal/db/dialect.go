@@ -0,0 +1,26 @@
+package db
+
+import "strings"
+
+// Dialect identifies the database backend.
+type Dialect string
+
+// Supported database backends
+const (
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+// sqliteScheme selects the embedded SQLite backend in DSN
+const sqliteScheme = "sqlite:"
+
+// ParseDSN selects backend by DSN scheme and returns its data source.
+// "sqlite:path" and "sqlite://path" open a SQLite file, other DSNs are passed to Postgres.
+func ParseDSN(dsn string) (Dialect, string) {
+	if !strings.HasPrefix(dsn, sqliteScheme) {
+		return Postgres, dsn
+	}
+
+	path := strings.TrimPrefix(dsn, sqliteScheme)
+	return SQLite, strings.TrimPrefix(path, "//")
+}
@@ -1,9 +1,12 @@
-// Package db contains database schema migration files.
+// Package db contains database schema migrations and selects the database backend.
 package db
 
 import (
 	"embed"
 )
 
-//go:embed migrations/*.sql
+// MigrationsFS holds Postgres migrations in migrations and
+// equivalent SQLite ones in migrations/sqlite.
+//
+//go:embed migrations/*.sql migrations/sqlite/*.sql
 var MigrationsFS embed.FS
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+// ErrSchemaOutdated is returned when the database lacks migrations the server expects.
+var ErrSchemaOutdated = errors.New("database schema is outdated")
+
+// Migrator applies embedded migrations of the database dialect.
+// On Postgres changes run under an advisory lock, so concurrently started
+// servers and migrator runs do not apply the same migration twice.
+type Migrator struct {
+	provider *goose.Provider
+}
+
+// NewMigrator creates migrator of the database.
+func NewMigrator(database *sql.DB, dialect Dialect) (*Migrator, error) {
+	var (
+		provider *goose.Provider
+		err      error
+	)
+
+	switch dialect {
+	case SQLite:
+		provider, err = newSQLiteProvider(database)
+	default:
+		provider, err = newPostgresProvider(database)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{provider: provider}, nil
+}
+
+// newPostgresProvider collects Postgres migrations guarded by a session advisory lock
+func newPostgresProvider(database *sql.DB) (*goose.Provider, error) {
+	fsys, err := fs.Sub(MigrationsFS, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, err
+	}
+
+	return goose.NewProvider(goose.DialectPostgres, database, fsys,
+		goose.WithSessionLocker(locker),
+		goose.WithGoMigrations(usernameKeyMigration()),
+	)
+}
+
+// newSQLiteProvider collects SQLite migrations, the file is locked by SQLite itself
+func newSQLiteProvider(database *sql.DB) (*goose.Provider, error) {
+	fsys, err := fs.Sub(MigrationsFS, "migrations/sqlite")
+	if err != nil {
+		return nil, err
+	}
+
+	return goose.NewProvider(goose.DialectSQLite3, database, fsys, goose.WithGoMigrations(usernameKeyMigration()))
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) ([]*goose.MigrationResult, error) {
+	return m.provider.Up(ctx)
+}
+
+// UpTo applies pending migrations up to and including the version.
+func (m *Migrator) UpTo(ctx context.Context, version int64) ([]*goose.MigrationResult, error) {
+	return m.provider.UpTo(ctx, version)
+}
+
+// Down rolls back the latest migration.
+func (m *Migrator) Down(ctx context.Context) (*goose.MigrationResult, error) {
+	return m.provider.Down(ctx)
+}
+
+// Redo rolls back the latest migration and applies it again.
+func (m *Migrator) Redo(ctx context.Context) ([]*goose.MigrationResult, error) {
+	down, err := m.provider.Down(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	up, err := m.provider.ApplyVersion(ctx, down.Source.Version, true)
+	if err != nil {
+		return []*goose.MigrationResult{down}, err
+	}
+
+	return []*goose.MigrationResult{down, up}, nil
+}
+
+// Status lists embedded migrations with their state in the database.
+func (m *Migrator) Status(ctx context.Context) ([]*goose.MigrationStatus, error) {
+	return m.provider.Status(ctx)
+}
+
+// Version returns the database schema version and the latest embedded one.
+func (m *Migrator) Version(ctx context.Context) (current, target int64, err error) {
+	return m.provider.GetVersions(ctx)
+}
+
+// Check fails with ErrSchemaOutdated when embedded migrations are not applied.
+// A newer schema is accepted, so servers can be rolled back after a migration.
+func (m *Migrator) Check(ctx context.Context) error {
+	current, target, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	return checkVersions(current, target)
+}
+
+// checkVersions compares database schema version with the expected one
+func checkVersions(current, target int64) error {
+	if current < target {
+		return fmt.Errorf("%w: version %d, expected %d", ErrSchemaOutdated, current, target)
+	}
+	return nil
+}
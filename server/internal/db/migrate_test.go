@@ -0,0 +1,46 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckVersions(t *testing.T) {
+	t.Run("schema up to date", func(t *testing.T) {
+		assert.NoError(t, checkVersions(20250910120000, 20250910120000))
+	})
+
+	t.Run("newer schema accepted", func(t *testing.T) {
+		assert.NoError(t, checkVersions(20250911120000, 20250910120000))
+	})
+
+	t.Run("older schema rejected", func(t *testing.T) {
+		assert.ErrorIs(t, checkVersions(20250905120000, 20250910120000), ErrSchemaOutdated)
+	})
+}
+
+func TestNewMigrator(t *testing.T) {
+	database, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer database.Close()
+
+	t.Run("postgres migrations collected", func(t *testing.T) {
+		m, err := NewMigrator(database, Postgres)
+		require.NoError(t, err)
+		assert.NotEmpty(t, m.provider.ListSources())
+	})
+
+	t.Run("sqlite schema matches postgres version", func(t *testing.T) {
+		pg, err := NewMigrator(database, Postgres)
+		require.NoError(t, err)
+		lite, err := NewMigrator(database, SQLite)
+		require.NoError(t, err)
+
+		pgSources, liteSources := pg.provider.ListSources(), lite.provider.ListSources()
+		require.NotEmpty(t, liteSources)
+		assert.Equal(t, pgSources[len(pgSources)-1].Version, liteSources[len(liteSources)-1].Version)
+	})
+}
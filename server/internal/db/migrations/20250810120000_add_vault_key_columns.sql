@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users 
+ADD COLUMN encrypted_key TEXT NOT NULL DEFAULT '',
+ADD COLUMN recovery_key TEXT NOT NULL DEFAULT '',
+ADD COLUMN recovery_hash VARCHAR(255) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users 
+DROP COLUMN encrypted_key,
+DROP COLUMN recovery_key,
+DROP COLUMN recovery_hash;
+-- +goose StatementEnd
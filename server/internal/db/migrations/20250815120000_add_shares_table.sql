@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users 
+ADD COLUMN public_key BYTEA,
+ADD COLUMN encrypted_private_key TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS shares (
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    recipient_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    wrapped_key BYTEA NOT NULL,
+    payload BYTEA NOT NULL,
+    shared_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (item_id, recipient_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_shares_recipient_id ON shares(recipient_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS shares;
+
+ALTER TABLE users 
+DROP COLUMN public_key,
+DROP COLUMN encrypted_private_key;
+-- +goose StatementEnd
@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS org_members (
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(16) NOT NULL CHECK (role IN ('owner', 'admin', 'member', 'readonly')),
+    PRIMARY KEY (org_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_members_user_id ON org_members(user_id);
+
+CREATE TABLE IF NOT EXISTS collections (
+    id UUID PRIMARY KEY,
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS collection_keys (
+    collection_id UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    wrapped_key BYTEA NOT NULL,
+    PRIMARY KEY (collection_id, user_id)
+);
+
+ALTER TABLE items 
+ALTER COLUMN user_id DROP NOT NULL,
+ADD COLUMN collection_id UUID REFERENCES collections(id) ON DELETE CASCADE,
+ADD CONSTRAINT items_owner_check CHECK ((user_id IS NULL) <> (collection_id IS NULL));
+
+CREATE INDEX IF NOT EXISTS idx_items_collection_id ON items(collection_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM items WHERE collection_id IS NOT NULL;
+
+ALTER TABLE items 
+DROP CONSTRAINT items_owner_check,
+DROP COLUMN collection_id,
+ALTER COLUMN user_id SET NOT NULL;
+
+DROP TABLE IF EXISTS collection_keys;
+DROP TABLE IF EXISTS collections;
+DROP TABLE IF EXISTS org_members;
+DROP TABLE IF EXISTS organizations;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS emergency_contacts (
+    grantor_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    grantee_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    wait_seconds BIGINT NOT NULL CHECK (wait_seconds >= 0),
+    status TEXT NOT NULL DEFAULT 'idle' CHECK (status IN ('idle', 'requested', 'denied', 'approved')),
+    requested_at TIMESTAMPTZ,
+    wrapped_key BYTEA NOT NULL,
+    PRIMARY KEY (grantor_id, grantee_id),
+    CHECK (grantor_id <> grantee_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_emergency_contacts_grantee_id ON emergency_contacts(grantee_id);
+CREATE INDEX IF NOT EXISTS idx_emergency_contacts_requested ON emergency_contacts(requested_at) WHERE status = 'requested';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS emergency_contacts;
+-- +goose StatementEnd
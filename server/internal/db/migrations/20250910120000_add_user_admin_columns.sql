@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS sessions_revoked_at TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+DROP COLUMN IF EXISTS sessions_revoked_at,
+DROP COLUMN IF EXISTS disabled_at;
+-- +goose StatementEnd
@@ -0,0 +1,123 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE users (
+    id TEXT PRIMARY KEY,
+    username VARCHAR(255) UNIQUE NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    salt VARCHAR(255),
+    encrypted_key TEXT NOT NULL DEFAULT '',
+    recovery_key TEXT NOT NULL DEFAULT '',
+    recovery_hash VARCHAR(255) NOT NULL DEFAULT '',
+    public_key BLOB,
+    encrypted_private_key TEXT NOT NULL DEFAULT '',
+    disabled_at TIMESTAMP,
+    sessions_revoked_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_users_username_lower ON users(LOWER(username));
+
+CREATE TABLE organizations (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE org_members (
+    org_id TEXT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(16) NOT NULL CHECK (role IN ('owner', 'admin', 'member', 'readonly')),
+    PRIMARY KEY (org_id, user_id)
+);
+
+CREATE INDEX idx_org_members_user_id ON org_members(user_id);
+
+CREATE TABLE collections (
+    id TEXT PRIMARY KEY,
+    org_id TEXT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name TEXT NOT NULL
+);
+
+CREATE TABLE collection_keys (
+    collection_id TEXT NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    wrapped_key BLOB NOT NULL,
+    PRIMARY KEY (collection_id, user_id)
+);
+
+CREATE TABLE items (
+    id TEXT PRIMARY KEY,
+    user_id TEXT REFERENCES users(id),
+    type VARCHAR(20) NOT NULL,
+    name TEXT NOT NULL,
+    metadata TEXT,
+    data BLOB NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    is_deleted BOOLEAN DEFAULT FALSE,
+    collection_id TEXT REFERENCES collections(id) ON DELETE CASCADE,
+    CONSTRAINT items_owner_check CHECK ((user_id IS NULL) <> (collection_id IS NULL))
+);
+
+CREATE INDEX idx_items_user_id ON items(user_id);
+CREATE INDEX idx_items_collection_id ON items(collection_id);
+
+CREATE TABLE shares (
+    item_id TEXT NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    owner_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    recipient_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    wrapped_key BLOB NOT NULL,
+    payload BLOB NOT NULL,
+    shared_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (item_id, recipient_id)
+);
+
+CREATE INDEX idx_shares_recipient_id ON shares(recipient_id);
+
+CREATE TABLE emergency_contacts (
+    grantor_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    grantee_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    wait_seconds BIGINT NOT NULL CHECK (wait_seconds >= 0),
+    status TEXT NOT NULL DEFAULT 'idle' CHECK (status IN ('idle', 'requested', 'denied', 'approved')),
+    requested_at TIMESTAMP,
+    wrapped_key BLOB NOT NULL,
+    PRIMARY KEY (grantor_id, grantee_id),
+    CHECK (grantor_id <> grantee_id)
+);
+
+CREATE INDEX idx_emergency_contacts_grantee_id ON emergency_contacts(grantee_id);
+CREATE INDEX idx_emergency_contacts_requested ON emergency_contacts(requested_at) WHERE status = 'requested';
+
+CREATE TABLE audit_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT,
+    device TEXT NOT NULL DEFAULT '',
+    ip TEXT NOT NULL DEFAULT '',
+    action VARCHAR(32) NOT NULL,
+    result VARCHAR(16) NOT NULL CHECK (result IN ('success', 'failure')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_events_user_id ON audit_events(user_id, id DESC);
+
+CREATE TRIGGER audit_events_no_update BEFORE UPDATE ON audit_events
+BEGIN
+    SELECT RAISE(IGNORE);
+END;
+
+CREATE TRIGGER audit_events_no_delete BEFORE DELETE ON audit_events
+BEGIN
+    SELECT RAISE(IGNORE);
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_events;
+DROP TABLE IF EXISTS emergency_contacts;
+DROP TABLE IF EXISTS shares;
+DROP TABLE IF EXISTS items;
+DROP TABLE IF EXISTS collection_keys;
+DROP TABLE IF EXISTS collections;
+DROP TABLE IF EXISTS org_members;
+DROP TABLE IF EXISTS organizations;
+DROP TABLE IF EXISTS users;
+-- +goose StatementEnd
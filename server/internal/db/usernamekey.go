@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pressly/goose/v3"
+	"github.com/rycln/gokeep/server/internal/validation"
+)
+
+// usernameKeyVersion is the version of the migration adding username keys
+const usernameKeyVersion = 20250915120000
+
+// usernameKeyMigration stores case-insensitive identity of every username
+// in its own column with a unique index. SQLite LOWER folds ASCII letters only,
+// as does Postgres under the C locale, so keys are computed by the server
+// the same way as on registration and login.
+func usernameKeyMigration() *goose.Migration {
+	return goose.NewGoMigration(
+		usernameKeyVersion,
+		&goose.GoFunc{RunTx: addUsernameKey},
+		&goose.GoFunc{RunTx: dropUsernameKey},
+	)
+}
+
+// addUsernameKey adds and fills username_key column
+func addUsernameKey(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, "ALTER TABLE users ADD COLUMN username_key VARCHAR(255)")
+	if err != nil {
+		return err
+	}
+
+	usernames, err := listUsernames(ctx, tx)
+	if err != nil {
+		return err
+	}
+	for id, username := range usernames {
+		_, err := tx.ExecContext(ctx, "UPDATE users SET username_key = $1 WHERE id = $2", validation.UsernameKey(username), id)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "CREATE UNIQUE INDEX idx_users_username_key ON users(username_key)")
+	if err != nil {
+		return fmt.Errorf("usernames differing only in case must be renamed first: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, "DROP INDEX IF EXISTS idx_users_username_lower")
+	return err
+}
+
+// dropUsernameKey restores index of lowercase usernames and drops username_key column
+func dropUsernameKey(ctx context.Context, tx *sql.Tx) error {
+	for _, stmt := range []string{
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username_lower ON users(LOWER(username))",
+		"DROP INDEX IF EXISTS idx_users_username_key",
+		"ALTER TABLE users DROP COLUMN username_key",
+	} {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listUsernames returns usernames by user ID
+func listUsernames(ctx context.Context, tx *sql.Tx) (usernames map[string]string, err error) {
+	rows, err := tx.QueryContext(ctx, "SELECT id, username FROM users")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rowsCloseErr := rows.Close(); rowsCloseErr != nil {
+			err = fmt.Errorf("%v; rows close failed: %w", err, rowsCloseErr)
+		}
+	}()
+
+	usernames = make(map[string]string)
+	for rows.Next() {
+		var id, username string
+		if err := rows.Scan(&id, &username); err != nil {
+			return nil, err
+		}
+		usernames[id] = username
+	}
+
+	return usernames, rows.Err()
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddUsernameKey(t *testing.T) {
+	database, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer database.Close()
+
+	const (
+		userID   = "550e8400-e29b-41d4-a716-446655440000"
+		username = "Ärger"
+	)
+
+	expectFill := func() {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE users ADD COLUMN username_key")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, username FROM users")).
+			WillReturnRows(mock.NewRows([]string{"id", "username"}).AddRow(userID, username))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET username_key")).
+			WithArgs("ärger", userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	t.Run("keys computed by server", func(t *testing.T) {
+		expectFill()
+		mock.ExpectExec(regexp.QuoteMeta("CREATE UNIQUE INDEX idx_users_username_key")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("DROP INDEX IF EXISTS idx_users_username_lower")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		tx, err := database.Begin()
+		require.NoError(t, err)
+		assert.NoError(t, addUsernameKey(context.Background(), tx))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("usernames differing only in case", func(t *testing.T) {
+		testErr := errors.New("UNIQUE constraint failed: users.username_key")
+		expectFill()
+		mock.ExpectExec(regexp.QuoteMeta("CREATE UNIQUE INDEX idx_users_username_key")).
+			WillReturnError(testErr)
+
+		tx, err := database.Begin()
+		require.NoError(t, err)
+		assert.ErrorIs(t, addUsernameKey(context.Background(), tx), testErr)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
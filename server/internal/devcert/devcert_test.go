@@ -0,0 +1,43 @@
+package devcert
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("certificate trusted as own CA for localhost", func(t *testing.T) {
+		dir := t.TempDir()
+
+		certFile, keyFile, err := Generate(dir)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, CertFileName), certFile)
+		assert.Equal(t, filepath.Join(dir, KeyFileName), keyFile)
+
+		pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+		require.NoError(t, err)
+		leaf, err := x509.ParseCertificate(pair.Certificate[0])
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(certFile)
+		require.NoError(t, err)
+		roots := x509.NewCertPool()
+		require.True(t, roots.AppendCertsFromPEM(data))
+
+		for _, host := range []string{"localhost", "127.0.0.1", "::1"} {
+			_, err = leaf.Verify(x509.VerifyOptions{DNSName: host, Roots: roots})
+			assert.NoError(t, err, host)
+		}
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		_, _, err := Generate(filepath.Join(t.TempDir(), "missing"))
+		assert.Error(t, err)
+	})
+}
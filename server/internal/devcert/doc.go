@@ -0,0 +1,2 @@
+// Package devcert generates a self-signed certificate for the development server mode.
+package devcert
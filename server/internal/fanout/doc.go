@@ -0,0 +1,8 @@
+// Package fanout broadcasts change events between server instances.
+//
+// Replicas behind a load balancer keep per-process state such as cached
+// session checks and live change subscriptions. Events published on any
+// instance are delivered to handlers of every instance, so this state
+// follows changes made elsewhere. Local serves a single process, Postgres
+// relays events through LISTEN/NOTIFY of the shared database.
+package fanout
@@ -0,0 +1,84 @@
+package fanout
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rycln/gokeep/shared/models"
+)
+
+// Kind identifies the event type.
+type Kind string
+
+// Event kinds
+const (
+	// KindItemsChanged reports changed personal items of a user or items of a collection
+	KindItemsChanged Kind = "items_changed"
+
+	// KindSessionRevoked reports disabled, logged out or deleted account
+	KindSessionRevoked Kind = "session_revoked"
+
+	// KindResync is delivered locally when events of other instances may have been missed,
+	// handlers drop all derived state
+	KindResync Kind = "resync"
+)
+
+// Event describes a change made on one of the instances.
+type Event struct {
+	Kind         Kind                `json:"kind"`
+	UserID       models.UserID       `json:"user_id,omitempty"`
+	CollectionID models.CollectionID `json:"collection_id,omitempty"`
+}
+
+// Handler processes a delivered event.
+// Handlers run in the delivering goroutine and must not block.
+type Handler func(Event)
+
+// Bus delivers events to handlers of every server instance.
+type Bus interface {
+	// Publish delivers the event to local handlers and other instances.
+	// Failures are reported to the error callback of the bus, the change itself is already stored.
+	Publish(context.Context, Event)
+
+	// Subscribe registers the handler, the returned function removes it.
+	Subscribe(Handler) func()
+
+	// Run receives events of other instances until the context is canceled.
+	Run(context.Context) error
+}
+
+// hub keeps local handlers
+type hub struct {
+	mu       sync.RWMutex
+	handlers map[int]Handler
+	next     int
+}
+
+// Subscribe registers the handler, the returned function removes it.
+func (h *hub) Subscribe(handler Handler) func() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.handlers == nil {
+		h.handlers = make(map[int]Handler)
+	}
+	id := h.next
+	h.next++
+	h.handlers[id] = handler
+
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.handlers, id)
+	}
+}
+
+// deliver calls every registered handler
+func (h *hub) deliver(e Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, handler := range h.handlers {
+		handler(e)
+	}
+}
@@ -0,0 +1,46 @@
+package fanout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocal(t *testing.T) {
+	ctx := context.Background()
+	event := Event{Kind: KindItemsChanged, UserID: "user"}
+
+	t.Run("event delivered to every handler", func(t *testing.T) {
+		bus := NewLocal()
+		var first, second []Event
+		bus.Subscribe(func(e Event) { first = append(first, e) })
+		bus.Subscribe(func(e Event) { second = append(second, e) })
+
+		bus.Publish(ctx, event)
+
+		assert.Equal(t, []Event{event}, first)
+		assert.Equal(t, []Event{event}, second)
+	})
+
+	t.Run("unsubscribed handler not called", func(t *testing.T) {
+		bus := NewLocal()
+		var got []Event
+		unsubscribe := bus.Subscribe(func(e Event) { got = append(got, e) })
+
+		bus.Publish(ctx, event)
+		unsubscribe()
+		bus.Publish(ctx, event)
+
+		assert.Len(t, got, 1)
+	})
+
+	t.Run("run stops with context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		require.NoError(t, NewLocal().Run(ctx))
+	})
+}
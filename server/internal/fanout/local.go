@@ -0,0 +1,25 @@
+package fanout
+
+import "context"
+
+// Local delivers events within a single process.
+// Used with SQLite and in-memory storage, which are not shared between instances.
+type Local struct {
+	hub
+}
+
+// NewLocal creates a new Local instance.
+func NewLocal() *Local {
+	return &Local{}
+}
+
+// Publish delivers the event to local handlers.
+func (l *Local) Publish(_ context.Context, e Event) {
+	l.deliver(e)
+}
+
+// Run waits for the context, there are no other instances to listen to.
+func (l *Local) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
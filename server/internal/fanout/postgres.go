@@ -0,0 +1,153 @@
+package fanout
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres relay parameters
+const (
+	// channel is the NOTIFY channel shared by all instances
+	channel = "gophkeeper_events"
+
+	// publishTimeout limits NOTIFY of a single event
+	publishTimeout = 5 * time.Second
+
+	// retryInterval sets delay before reconnecting the listener
+	retryInterval = 5 * time.Second
+)
+
+// message is a NOTIFY payload
+type message struct {
+	Origin string `json:"origin"` // Publishing instance, skipped by its own listener
+	Event
+}
+
+// notifier sends NOTIFY statements, implemented by *sql.DB
+type notifier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// listener receives notifications of a LISTEN connection, implemented by *pgx.Conn
+type listener interface {
+	WaitForNotification(ctx context.Context) (*pgconn.Notification, error)
+	Close(ctx context.Context) error
+}
+
+// Postgres relays events between instances sharing a Postgres database.
+// Events are delivered to local handlers at once and sent to other instances
+// with NOTIFY. Notifications are not persisted: after the listener reconnects
+// KindResync is delivered, so handlers drop state that may have missed events.
+type Postgres struct {
+	hub
+	db      notifier
+	connect func(context.Context) (listener, error)
+	origin  string
+	onError func(context.Context, error)
+}
+
+// NewPostgres creates a new Postgres instance.
+// Events are sent through the pool, the listener opens its own connection to the DSN.
+func NewPostgres(db *sql.DB, dsn string, onError func(context.Context, error)) *Postgres {
+	return newPostgres(db, func(ctx context.Context) (listener, error) {
+		return listen(ctx, dsn)
+	}, onError)
+}
+
+// newPostgres creates relay sending events through db and receiving them from connections
+func newPostgres(db notifier, connect func(context.Context) (listener, error), onError func(context.Context, error)) *Postgres {
+	return &Postgres{
+		db:      db,
+		connect: connect,
+		origin:  uuid.NewString(),
+		onError: onError,
+	}
+}
+
+// listen opens a connection listening to the channel
+func listen(ctx context.Context, dsn string) (listener, error) {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := conn.Exec(ctx, "LISTEN "+channel); err != nil {
+		conn.Close(context.Background())
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Publish delivers the event to local handlers and notifies other instances.
+// The request may be canceled right after the change, so NOTIFY runs on its own deadline.
+func (p *Postgres) Publish(ctx context.Context, e Event) {
+	p.deliver(e)
+
+	payload, err := json.Marshal(message{Origin: p.origin, Event: e})
+	if err != nil {
+		p.onError(ctx, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), publishTimeout)
+	defer cancel()
+
+	_, err = p.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", channel, string(payload))
+	if err != nil {
+		p.onError(ctx, fmt.Errorf("event not sent to other instances: %w", err))
+	}
+}
+
+// Run listens for events of other instances until the context is canceled.
+// The connection is reopened after failures.
+func (p *Postgres) Run(ctx context.Context) error {
+	for {
+		err := p.receive(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		p.onError(ctx, fmt.Errorf("event listener failed: %w", err))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// receive delivers notifications of a single connection
+func (p *Postgres) receive(ctx context.Context) error {
+	conn, err := p.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	// Events sent while no connection was listening are lost
+	p.deliver(Event{Kind: KindResync})
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var msg message
+		if err := json.Unmarshal([]byte(n.Payload), &msg); err != nil {
+			p.onError(ctx, fmt.Errorf("invalid event: %w", err))
+			continue
+		}
+		if msg.Origin == p.origin {
+			continue
+		}
+		p.deliver(msg.Event)
+	}
+}
@@ -0,0 +1,155 @@
+package fanout
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDatabaseDSNEnv names Postgres DSN the relay tests run against
+const testDatabaseDSNEnv = "TEST_DATABASE_DSN"
+
+// fakeServer imitates Postgres delivering NOTIFY payloads to every listening connection
+type fakeServer struct {
+	mu    sync.Mutex
+	conns []fakeConn
+}
+
+// ExecContext sends pg_notify payload to the listeners
+func (s *fakeServer) ExecContext(_ context.Context, _ string, args ...any) (sql.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := &pgconn.Notification{Channel: args[0].(string), Payload: args[1].(string)}
+	for _, conn := range s.conns {
+		conn <- n
+	}
+	return driver.RowsAffected(1), nil
+}
+
+// connect opens a listening connection
+func (s *fakeServer) connect(context.Context) (listener, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conn := make(fakeConn, 16)
+	s.conns = append(s.conns, conn)
+	return conn, nil
+}
+
+// fakeConn is a listening connection of fakeServer
+type fakeConn chan *pgconn.Notification
+
+func (c fakeConn) WaitForNotification(ctx context.Context) (*pgconn.Notification, error) {
+	select {
+	case n := <-c:
+		return n, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c fakeConn) Close(context.Context) error {
+	return nil
+}
+
+// startPostgres runs the relay and waits until it listens
+func startPostgres(t *testing.T, bus *Postgres) <-chan Event {
+	t.Helper()
+
+	events := make(chan Event, 16)
+	bus.Subscribe(func(e Event) { events <- e })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = bus.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	assert.Equal(t, Event{Kind: KindResync}, receive(t, events))
+	return events
+}
+
+// receive waits for the next delivered event
+func receive(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not delivered")
+		return Event{}
+	}
+}
+
+// testRelay checks delivery of events between two relays
+func testRelay(t *testing.T, first, second *Postgres) {
+	firstEvents, secondEvents := startPostgres(t, first), startPostgres(t, second)
+
+	t.Run("event delivered once to every instance", func(t *testing.T) {
+		event := Event{Kind: KindSessionRevoked, UserID: "user"}
+		first.Publish(context.Background(), event)
+
+		assert.Equal(t, event, receive(t, firstEvents))
+		assert.Equal(t, event, receive(t, secondEvents))
+
+		// Both instances got the notification by now, own one must have been skipped
+		second.Publish(context.Background(), Event{Kind: KindItemsChanged, CollectionID: "collection"})
+		assert.Equal(t, KindItemsChanged, receive(t, secondEvents).Kind)
+		assert.Equal(t, KindItemsChanged, receive(t, firstEvents).Kind)
+		assert.Empty(t, firstEvents)
+	})
+}
+
+func TestPostgres(t *testing.T) {
+	logError := func(_ context.Context, err error) { t.Log(err) }
+
+	t.Run("fake server", func(t *testing.T) {
+		srv := &fakeServer{}
+		testRelay(t, newPostgres(srv, srv.connect, logError), newPostgres(srv, srv.connect, logError))
+	})
+
+	t.Run("invalid payload skipped", func(t *testing.T) {
+		srv := &fakeServer{}
+		errs := make(chan error, 1)
+		events := startPostgres(t, newPostgres(srv, srv.connect, func(_ context.Context, err error) { errs <- err }))
+
+		_, err := srv.ExecContext(context.Background(), "", channel, "not json")
+		require.NoError(t, err)
+		select {
+		case err := <-errs:
+			assert.ErrorContains(t, err, "invalid event")
+		case <-time.After(5 * time.Second):
+			t.Fatal("error not reported")
+		}
+		assert.Empty(t, events)
+	})
+
+	t.Run("database", func(t *testing.T) {
+		dsn := os.Getenv(testDatabaseDSNEnv)
+		if dsn == "" {
+			t.Skipf("%s is not set", testDatabaseDSNEnv)
+		}
+
+		db, err := sql.Open("pgx", dsn)
+		require.NoError(t, err)
+		defer db.Close()
+
+		testRelay(t, NewPostgres(db, dsn, logError), NewPostgres(db, dsn, logError))
+	})
+}
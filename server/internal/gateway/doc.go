@@ -0,0 +1,2 @@
+// Package gateway provides REST/JSON access to the gRPC API for HTTP-only clients.
+package gateway
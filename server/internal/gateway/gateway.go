@@ -0,0 +1,135 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/server/internal/grpc/interceptors"
+	"github.com/rycln/gokeep/server/internal/mtls"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// errUnknownCert is returned when the gRPC server presents another certificate
+var errUnknownCert = errors.New("gRPC server certificate does not match")
+
+// certSource provides the current server certificate, which may be rotated at runtime
+type certSource interface {
+	Certificate() *tls.Certificate
+}
+
+// Gateway translates REST requests into calls of the gRPC server.
+// Requests pass through all server interceptors, the Authorization header
+// and device of a client certificate are forwarded as gRPC metadata.
+type Gateway struct {
+	mux  *runtime.ServeMux
+	conn *grpc.ClientConn
+}
+
+// New creates gateway to the gRPC server listening on addr.
+// The connection only accepts the server's own certificate and presents it
+// as a client one, so the server can trust forwarded devices.
+func New(addr string, cert certSource) (*Gateway, error) {
+	conn, err := grpc.NewClient(
+		dialAddr(addr),
+		grpc.WithTransportCredentials(credentials.NewTLS(pinnedTLS(cert))),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect gateway: %w", err)
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+		runtime.WithMetadata(deviceMetadata),
+	)
+	err = pb.RegisterGophKeeperHandler(context.Background(), mux, conn)
+	if err != nil {
+		return nil, fmt.Errorf("can't register gateway handlers: %w", err)
+	}
+
+	return &Gateway{
+		mux:  mux,
+		conn: conn,
+	}, nil
+}
+
+// ServeHTTP handles REST request.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// Close releases connection to the gRPC server.
+func (g *Gateway) Close() error {
+	return g.conn.Close()
+}
+
+// headerMatcher drops device header set by HTTP clients, only the gateway may set it.
+// Request ID of a proxy in front of the gateway is passed on, so log lines of both match.
+func headerMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, runtime.MetadataHeaderPrefix+mtls.DeviceMetadataKey) {
+		return "", false
+	}
+	if strings.EqualFold(key, interceptors.RequestIDMetadataKey) {
+		return interceptors.RequestIDMetadataKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher returns request ID assigned by the server in its usual header
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == interceptors.RequestIDMetadataKey {
+		return http.CanonicalHeaderKey(key), true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// deviceMetadata forwards device of the client certificate verified by the HTTPS listener
+func deviceMetadata(_ context.Context, r *http.Request) metadata.MD {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return nil
+	}
+	return metadata.Pairs(mtls.DeviceMetadataKey, mtls.DeviceName(r.TLS.PeerCertificates[0]))
+}
+
+// dialAddr points listen address without host to the loopback interface
+func dialAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || host != "" {
+		return addr
+	}
+	return net.JoinHostPort("localhost", port)
+}
+
+// pinnedTLS trusts exactly the current server certificate.
+// The loopback address may not match names of the certificate,
+// so chain and name verification is replaced by comparison with it.
+func pinnedTLS(certs certSource) *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: true, //nolint:gosec // certificate is pinned below
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			if cert := certs.Certificate(); cert != nil {
+				return cert, nil
+			}
+			return &tls.Certificate{}, nil
+		},
+		VerifyPeerCertificate: func(raw [][]byte, _ [][]*x509.Certificate) error {
+			cert := certs.Certificate()
+			if len(raw) == 0 || cert == nil || len(cert.Certificate) == 0 || !bytes.Equal(raw[0], cert.Certificate[0]) {
+				return errUnknownCert
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,235 @@
+package gateway
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/server/internal/mtls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testToken = "test.jwt.token"
+
+// testServer answers Login and checks bearer token of Sync
+type testServer struct {
+	pb.UnimplementedGophKeeperServer
+}
+
+func (testServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.AuthResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs("x-request-id", "req-"+strings.Join(md.Get("x-request-id"), "")))
+	return &pb.AuthResponse{UserId: req.Username, Token: testToken}, nil
+}
+
+func (testServer) Sync(ctx context.Context, req *pb.SyncRequest) (*pb.SyncResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if auth := md.Get("authorization"); len(auth) == 0 || auth[0] != "Bearer "+testToken {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return &pb.SyncResponse{Items: req.Items}, nil
+}
+
+// newTestCert creates self-signed certificate for localhost
+func newTestCert(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "gophkeeper.test"},
+		DNSNames:     []string{"gophkeeper.test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// startServer serves testServer over TLS with cert and returns its address
+func startServer(t *testing.T, cert tls.Certificate) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	g := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{cert}})))
+	pb.RegisterGophKeeperServer(g, testServer{})
+	go func() { _ = g.Serve(lis) }()
+	t.Cleanup(g.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestGateway(t *testing.T) {
+	cert := newTestCert(t)
+	addr := startServer(t, cert)
+
+	gw, err := New(addr, staticCert{cert})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, gw.Close()) }()
+
+	t.Run("login mapped to rest endpoint", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/login", strings.NewReader(`{"username":"testuser","password":"pass"}`))
+		gw.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "testuser", resp["userId"])
+		assert.Equal(t, testToken, resp["token"])
+	})
+
+	t.Run("request id passed both ways", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/login", strings.NewReader(`{"username":"testuser"}`))
+		req.Header.Set("X-Request-Id", "abc")
+		gw.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "req-abc", rec.Header().Get("X-Request-Id"))
+	})
+
+	t.Run("bearer token passed to server", func(t *testing.T) {
+		body := `{"items":[{"id":"item1","type":"text","data":"ZGF0YQ==","updatedAt":"2025-01-02T03:04:05Z"}]}`
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/sync", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+testToken)
+		gw.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp struct {
+			Items []map[string]any `json:"items"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Len(t, resp.Items, 1)
+		assert.Equal(t, "item1", resp.Items[0]["id"])
+		assert.Equal(t, "ZGF0YQ==", resp.Items[0]["data"])
+		assert.Equal(t, "2025-01-02T03:04:05Z", resp.Items[0]["updatedAt"])
+	})
+
+	t.Run("missing token rejected", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/sync", strings.NewReader(`{}`))
+		gw.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestGateway_PinnedCert(t *testing.T) {
+	t.Run("other certificate rejected", func(t *testing.T) {
+		addr := startServer(t, newTestCert(t))
+
+		gw, err := New(addr, staticCert{newTestCert(t)})
+		require.NoError(t, err)
+		defer func() { require.NoError(t, gw.Close()) }()
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/login", strings.NewReader(`{}`))
+		gw.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
+
+func TestPinnedTLS(t *testing.T) {
+	t.Run("rotated certificate accepted", func(t *testing.T) {
+		oldCert, newCert := newTestCert(t), newTestCert(t)
+		src := &rotatingCert{cert: oldCert}
+		cfg := pinnedTLS(src)
+
+		assert.NoError(t, cfg.VerifyPeerCertificate(oldCert.Certificate, nil))
+
+		src.cert = newCert
+		assert.NoError(t, cfg.VerifyPeerCertificate(newCert.Certificate, nil))
+		assert.ErrorIs(t, cfg.VerifyPeerCertificate(oldCert.Certificate, nil), errUnknownCert)
+	})
+
+	t.Run("server certificate presented as client one", func(t *testing.T) {
+		cert := newTestCert(t)
+		cfg := pinnedTLS(staticCert{cert})
+
+		got, err := cfg.GetClientCertificate(nil)
+		require.NoError(t, err)
+		assert.Equal(t, cert.Certificate, got.Certificate)
+	})
+}
+
+func TestHeaderMatcher(t *testing.T) {
+	t.Run("device header of client dropped", func(t *testing.T) {
+		_, ok := headerMatcher("Grpc-Metadata-X-Gophkeeper-Device")
+		assert.False(t, ok)
+	})
+
+	t.Run("other headers forwarded", func(t *testing.T) {
+		key, ok := headerMatcher("Authorization")
+		assert.True(t, ok)
+		assert.Equal(t, "grpcgateway-Authorization", key)
+	})
+
+	t.Run("request id forwarded as is", func(t *testing.T) {
+		key, ok := headerMatcher("X-Request-Id")
+		assert.True(t, ok)
+		assert.Equal(t, "x-request-id", key)
+	})
+}
+
+func TestDeviceMetadata(t *testing.T) {
+	t.Run("client certificate forwarded", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/sync", nil)
+		req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "laptop-1"}}}}
+
+		md := deviceMetadata(context.Background(), req)
+		assert.Equal(t, []string{"laptop-1"}, md.Get(mtls.DeviceMetadataKey))
+	})
+
+	t.Run("no client certificate", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/sync", nil)
+		assert.Nil(t, deviceMetadata(context.Background(), req))
+	})
+}
+
+// staticCert serves a fixed certificate
+type staticCert struct{ cert tls.Certificate }
+
+func (s staticCert) Certificate() *tls.Certificate { return &s.cert }
+
+// rotatingCert serves a certificate replaced by the test
+type rotatingCert struct{ cert tls.Certificate }
+
+func (r *rotatingCert) Certificate() *tls.Certificate { return &r.cert }
+
+func TestDialAddr(t *testing.T) {
+	t.Run("port only", func(t *testing.T) {
+		assert.Equal(t, "localhost:50051", dialAddr(":50051"))
+	})
+
+	t.Run("host and port", func(t *testing.T) {
+		assert.Equal(t, "127.0.0.1:50051", dialAddr("127.0.0.1:50051"))
+	})
+}
@@ -0,0 +1,176 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/server/internal/logger"
+	"github.com/rycln/gokeep/shared/models"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// Users page size limits
+const (
+	defaultUsersPageSize = 50
+	maxUsersPageSize     = 500
+)
+
+// adminService defines the required domain operations for account management
+type adminService interface {
+	ListUsers(context.Context, string, string, int) ([]models.AdminUser, error)
+	DisableUser(context.Context, string) error
+	EnableUser(context.Context, string) error
+	LogoutUser(context.Context, string) error
+	GetUsage(context.Context, string) (*models.Usage, error)
+	PurgeUser(context.Context, string) error
+}
+
+// AdminServer implements the operator gRPC service.
+// Calls are authorized by the admin token instead of user JWT.
+type AdminServer struct {
+	pb.UnimplementedGophKeeperAdminServer
+	admin   adminService
+	token   []byte
+	timeout time.Duration
+}
+
+// NewAdminServer constructs a new admin gRPC server instance.
+// The token must not be empty, otherwise every call is rejected.
+func NewAdminServer(admin adminService, token string, timeout time.Duration) *AdminServer {
+	return &AdminServer{
+		admin:   admin,
+		token:   []byte(token),
+		timeout: timeout,
+	}
+}
+
+// AuthFuncOverride checks the admin bearer token in constant time
+func (s *AdminServer) AuthFuncOverride(ctx context.Context, _ string) (context.Context, error) {
+	token, err := auth.AuthFromMD(ctx, "bearer")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.token) == 0 || subtle.ConstantTimeCompare([]byte(token), s.token) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "invalid admin token")
+	}
+
+	return ctx, nil
+}
+
+// ListUsers handles account search requests.
+// Page token is the username of the last account of the previous page.
+func (s *AdminServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	limit := int(req.PageSize)
+	if limit <= 0 {
+		limit = defaultUsersPageSize
+	}
+	limit = min(limit, maxUsersPageSize)
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	users, err := s.admin.ListUsers(ctx, req.Query, req.PageToken, limit)
+	if err != nil {
+		return nil, errStatus(ctx, err, codes.Internal)
+	}
+
+	resp := &pb.ListUsersResponse{
+		Users: make([]*pb.AdminUser, len(users)),
+	}
+	for i, user := range users {
+		resp.Users[i] = &pb.AdminUser{
+			Id:       string(user.ID),
+			Username: user.Username,
+			Disabled: user.Disabled,
+		}
+	}
+	if len(users) == limit {
+		resp.NextPageToken = users[len(users)-1].Username
+	}
+
+	return resp, nil
+}
+
+// DisableUser handles account blocking requests
+func (s *AdminServer) DisableUser(ctx context.Context, req *pb.AdminUserRequest) (*pb.AdminUserResponse, error) {
+	return s.userAction(ctx, req, "user disabled", s.admin.DisableUser)
+}
+
+// EnableUser handles account unblocking requests
+func (s *AdminServer) EnableUser(ctx context.Context, req *pb.AdminUserRequest) (*pb.AdminUserResponse, error) {
+	return s.userAction(ctx, req, "user enabled", s.admin.EnableUser)
+}
+
+// LogoutUser handles forced logout requests
+func (s *AdminServer) LogoutUser(ctx context.Context, req *pb.AdminUserRequest) (*pb.AdminUserResponse, error) {
+	return s.userAction(ctx, req, "user sessions revoked", s.admin.LogoutUser)
+}
+
+// PurgeUser handles account removal requests
+func (s *AdminServer) PurgeUser(ctx context.Context, req *pb.AdminUserRequest) (*pb.AdminUserResponse, error) {
+	return s.userAction(ctx, req, "user purged", s.admin.PurgeUser)
+}
+
+// GetUsage handles account usage requests
+func (s *AdminServer) GetUsage(ctx context.Context, req *pb.AdminUserRequest) (*pb.UsageResponse, error) {
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	usage, err := s.admin.GetUsage(ctx, req.Username)
+	if err != nil {
+		return nil, errStatus(ctx, err, adminErrCode(err))
+	}
+
+	return &pb.UsageResponse{
+		Items:      usage.Items,
+		Bytes:      usage.Bytes,
+		Shares:     usage.Shares,
+		Orgs:       usage.Orgs,
+		QuotaBytes: usage.QuotaBytes,
+	}, nil
+}
+
+// userAction runs an operation on a single account and logs it for operators
+func (s *AdminServer) userAction(
+	ctx context.Context,
+	req *pb.AdminUserRequest,
+	msg string,
+	action func(context.Context, string) error,
+) (*pb.AdminUserResponse, error) {
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	err := action(ctx, req.Username)
+	if err != nil {
+		return nil, errStatus(ctx, err, adminErrCode(err))
+	}
+	logger.FromContext(ctx).Info(msg, zap.String("username", req.Username))
+
+	return &pb.AdminUserResponse{}, nil
+}
+
+// adminErrCode maps account management errors to gRPC codes
+func adminErrCode(err error) codes.Code {
+	var noUser interface{ IsErrNoUser() bool }
+	if errors.As(err, &noUser) {
+		return codes.NotFound
+	}
+	return codes.Internal
+}
@@ -0,0 +1,194 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/server/internal/grpc/mocks"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testAdminToken = "admin-token"
+
+func TestAdminServer_AuthFuncOverride(t *testing.T) {
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "bearer "+token))
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		s := NewAdminServer(nil, testAdminToken, testTimeout)
+
+		_, err := s.AuthFuncOverride(withToken(testAdminToken), "/gophkeeper.GophKeeperAdmin/ListUsers")
+		assert.NoError(t, err)
+	})
+
+	t.Run("wrong token", func(t *testing.T) {
+		s := NewAdminServer(nil, testAdminToken, testTimeout)
+
+		_, err := s.AuthFuncOverride(withToken("user.jwt.token"), "/gophkeeper.GophKeeperAdmin/ListUsers")
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		s := NewAdminServer(nil, testAdminToken, testTimeout)
+
+		_, err := s.AuthFuncOverride(context.Background(), "/gophkeeper.GophKeeperAdmin/ListUsers")
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("empty configured token rejects all", func(t *testing.T) {
+		s := NewAdminServer(nil, "", testTimeout)
+
+		_, err := s.AuthFuncOverride(withToken(""), "/gophkeeper.GophKeeperAdmin/ListUsers")
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+func TestAdminServer_ListUsers(t *testing.T) {
+	t.Run("full page returns next token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAdmin := mocks.NewMockadminService(ctrl)
+		s := NewAdminServer(mockAdmin, testAdminToken, testTimeout)
+
+		mockAdmin.EXPECT().
+			ListUsers(gomock.Any(), "al", "", 2).
+			Return([]models.AdminUser{
+				{ID: "u1", Username: "alice"},
+				{ID: "u2", Username: "alfred", Disabled: true},
+			}, nil)
+
+		resp, err := s.ListUsers(context.Background(), &pb.ListUsersRequest{Query: "al", PageSize: 2})
+		require.NoError(t, err)
+		require.Len(t, resp.Users, 2)
+		assert.Equal(t, "alice", resp.Users[0].Username)
+		assert.True(t, resp.Users[1].Disabled)
+		assert.Equal(t, "alfred", resp.NextPageToken)
+	})
+
+	t.Run("last page with default size", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAdmin := mocks.NewMockadminService(ctrl)
+		s := NewAdminServer(mockAdmin, testAdminToken, testTimeout)
+
+		mockAdmin.EXPECT().
+			ListUsers(gomock.Any(), "", "alfred", defaultUsersPageSize).
+			Return([]models.AdminUser{{ID: "u3", Username: "bob"}}, nil)
+
+		resp, err := s.ListUsers(context.Background(), &pb.ListUsersRequest{PageToken: "alfred"})
+		require.NoError(t, err)
+		assert.Empty(t, resp.NextPageToken)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAdmin := mocks.NewMockadminService(ctrl)
+		s := NewAdminServer(mockAdmin, testAdminToken, testTimeout)
+
+		mockAdmin.EXPECT().
+			ListUsers(gomock.Any(), "", "", maxUsersPageSize).
+			Return(nil, errors.New("db down"))
+
+		_, err := s.ListUsers(context.Background(), &pb.ListUsersRequest{PageSize: 10000})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestAdminServer_UserActions(t *testing.T) {
+	req := &pb.AdminUserRequest{Username: "alice"}
+
+	t.Run("actions call service", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAdmin := mocks.NewMockadminService(ctrl)
+		s := NewAdminServer(mockAdmin, testAdminToken, testTimeout)
+
+		gomock.InOrder(
+			mockAdmin.EXPECT().DisableUser(gomock.Any(), "alice").Return(nil),
+			mockAdmin.EXPECT().EnableUser(gomock.Any(), "alice").Return(nil),
+			mockAdmin.EXPECT().LogoutUser(gomock.Any(), "alice").Return(nil),
+			mockAdmin.EXPECT().PurgeUser(gomock.Any(), "alice").Return(nil),
+		)
+
+		_, err := s.DisableUser(context.Background(), req)
+		assert.NoError(t, err)
+		_, err = s.EnableUser(context.Background(), req)
+		assert.NoError(t, err)
+		_, err = s.LogoutUser(context.Background(), req)
+		assert.NoError(t, err)
+		_, err = s.PurgeUser(context.Background(), req)
+		assert.NoError(t, err)
+	})
+
+	t.Run("empty username", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s := NewAdminServer(mocks.NewMockadminService(ctrl), testAdminToken, testTimeout)
+
+		_, err := s.PurgeUser(context.Background(), &pb.AdminUserRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAdmin := mocks.NewMockadminService(ctrl)
+		s := NewAdminServer(mockAdmin, testAdminToken, testTimeout)
+
+		mockAdmin.EXPECT().DisableUser(gomock.Any(), "alice").Return(testNoUserErr{})
+
+		_, err := s.DisableUser(context.Background(), req)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestAdminServer_GetUsage(t *testing.T) {
+	t.Run("usage with quota", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAdmin := mocks.NewMockadminService(ctrl)
+		s := NewAdminServer(mockAdmin, testAdminToken, testTimeout)
+
+		mockAdmin.EXPECT().
+			GetUsage(gomock.Any(), "alice").
+			Return(&models.Usage{Items: 3, Bytes: 512, Shares: 1, Orgs: 2, QuotaBytes: 1024}, nil)
+
+		resp, err := s.GetUsage(context.Background(), &pb.AdminUserRequest{Username: "alice"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), resp.Items)
+		assert.Equal(t, int64(512), resp.Bytes)
+		assert.Equal(t, int64(1), resp.Shares)
+		assert.Equal(t, int64(2), resp.Orgs)
+		assert.Equal(t, int64(1024), resp.QuotaBytes)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAdmin := mocks.NewMockadminService(ctrl)
+		s := NewAdminServer(mockAdmin, testAdminToken, testTimeout)
+
+		mockAdmin.EXPECT().GetUsage(gomock.Any(), "bob").Return(nil, testNoUserErr{})
+
+		_, err := s.GetUsage(context.Background(), &pb.AdminUserRequest{Username: "bob"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
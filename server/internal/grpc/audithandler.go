@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"context"
+	"strconv"
+
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/shared/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// Audit events page size limits
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 100
+)
+
+// auditService defines the required domain operations for security audit log
+type auditService interface {
+	ListEvents(context.Context, int64, int) ([]models.AuditEvent, error)
+}
+
+// ListAuditEvents handles account activity requests.
+// Page token is the ID of the last event of the previous page.
+func (h *GophKeeperServer) ListAuditEvents(
+	ctx context.Context,
+	req *pb.ListAuditEventsRequest,
+) (*pb.ListAuditEventsResponse, error) {
+	var before int64
+	if req.PageToken != "" {
+		var err error
+		before, err = strconv.ParseInt(req.PageToken, 10, 64)
+		if err != nil || before <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+
+	limit := int(req.PageSize)
+	if limit <= 0 {
+		limit = defaultAuditPageSize
+	}
+	limit = min(limit, maxAuditPageSize)
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	events, err := h.audit.ListEvents(ctx, before, limit)
+	if err != nil {
+		return nil, errStatus(ctx, err, codes.Internal)
+	}
+
+	resp := &pb.ListAuditEventsResponse{
+		Events: make([]*pb.AuditEvent, len(events)),
+	}
+	for i, event := range events {
+		resp.Events[i] = &pb.AuditEvent{
+			Id:        event.ID,
+			Device:    event.Device,
+			Ip:        event.IP,
+			Action:    string(event.Action),
+			Result:    string(event.Result),
+			CreatedAt: timestamppb.New(event.CreatedAt),
+		}
+	}
+	if len(events) == limit {
+		resp.NextPageToken = strconv.FormatInt(events[len(events)-1].ID, 10)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/server/internal/grpc/mocks"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGophKeeperServer_ListAuditEvents(t *testing.T) {
+	t.Run("full page returns next token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAudit := mocks.NewMockauditService(ctrl)
+		handler := NewGophKeeperServer(Services{Audit: mockAudit}, testTimeout)
+
+		createdAt := time.Now()
+		mockAudit.EXPECT().
+			ListEvents(gomock.Any(), int64(0), 2).
+			Return([]models.AuditEvent{
+				{ID: 9, Device: "laptop", IP: "192.0.2.1", Action: models.AuditLogin, Result: models.AuditFailure, CreatedAt: createdAt},
+				{ID: 7, Action: models.AuditSync, Result: models.AuditSuccess, CreatedAt: createdAt},
+			}, nil)
+
+		resp, err := handler.ListAuditEvents(context.Background(), &gophkeeper.ListAuditEventsRequest{PageSize: 2})
+		require.NoError(t, err)
+		require.Len(t, resp.Events, 2)
+		assert.Equal(t, "laptop", resp.Events[0].Device)
+		assert.Equal(t, "192.0.2.1", resp.Events[0].Ip)
+		assert.Equal(t, "login", resp.Events[0].Action)
+		assert.Equal(t, "failure", resp.Events[0].Result)
+		assert.True(t, createdAt.Equal(resp.Events[0].CreatedAt.AsTime()))
+		assert.Equal(t, "7", resp.NextPageToken)
+	})
+
+	t.Run("last page with default size", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAudit := mocks.NewMockauditService(ctrl)
+		handler := NewGophKeeperServer(Services{Audit: mockAudit}, testTimeout)
+
+		mockAudit.EXPECT().
+			ListEvents(gomock.Any(), int64(7), defaultAuditPageSize).
+			Return([]models.AuditEvent{{ID: 3}}, nil)
+
+		resp, err := handler.ListAuditEvents(context.Background(), &gophkeeper.ListAuditEventsRequest{PageToken: "7"})
+		require.NoError(t, err)
+		assert.Empty(t, resp.NextPageToken)
+	})
+
+	t.Run("page size capped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAudit := mocks.NewMockauditService(ctrl)
+		handler := NewGophKeeperServer(Services{Audit: mockAudit}, testTimeout)
+
+		mockAudit.EXPECT().
+			ListEvents(gomock.Any(), int64(0), maxAuditPageSize).
+			Return(nil, nil)
+
+		_, err := handler.ListAuditEvents(context.Background(), &gophkeeper.ListAuditEventsRequest{PageSize: 1000})
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid page token", func(t *testing.T) {
+		handler := NewGophKeeperServer(Services{}, testTimeout)
+
+		_, err := handler.ListAuditEvents(context.Background(), &gophkeeper.ListAuditEventsRequest{PageToken: "abc"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAudit := mocks.NewMockauditService(ctrl)
+		handler := NewGophKeeperServer(Services{Audit: mockAudit}, testTimeout)
+
+		mockAudit.EXPECT().
+			ListEvents(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("db error"))
+
+		_, err := handler.ListAuditEvents(context.Background(), &gophkeeper.ListAuditEventsRequest{})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
@@ -0,0 +1,195 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/shared/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// emergencyService defines the required domain operations for emergency access
+type emergencyService interface {
+	AddContact(context.Context, *models.EmergencyContact) error
+	ListContacts(context.Context) ([]models.EmergencyContact, error)
+	ListGrants(context.Context) ([]models.EmergencyContact, error)
+	RequestAccess(context.Context, string) error
+	DenyAccess(context.Context, string) error
+	GetVault(context.Context, string) (*models.EmergencyVault, error)
+}
+
+// AddEmergencyContact handles trusted contact nomination requests
+func (h *GophKeeperServer) AddEmergencyContact(
+	ctx context.Context,
+	req *pb.AddEmergencyContactRequest,
+) (*pb.AddEmergencyContactResponse, error) {
+	if req.Grantee == "" || len(req.WrappedKey) == 0 || req.WaitSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "grantee, wrapped key and non-negative wait period are required")
+	}
+
+	contact := &models.EmergencyContact{
+		Grantee:    req.Grantee,
+		WaitPeriod: time.Duration(req.WaitSeconds) * time.Second,
+		WrappedKey: req.WrappedKey,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	err := h.emergency.AddContact(ctx, contact)
+	if err != nil {
+		return nil, errStatus(ctx, err, emergencyErrCode(err))
+	}
+
+	return &pb.AddEmergencyContactResponse{}, nil
+}
+
+// ListEmergencyContacts handles requests for contacts nominated by the caller
+func (h *GophKeeperServer) ListEmergencyContacts(
+	ctx context.Context,
+	_ *pb.ListEmergencyContactsRequest,
+) (*pb.ListEmergencyContactsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	contacts, err := h.emergency.ListContacts(ctx)
+	if err != nil {
+		return nil, errStatus(ctx, err, codes.Internal)
+	}
+
+	return &pb.ListEmergencyContactsResponse{
+		Contacts: pbEmergencyContacts(contacts),
+	}, nil
+}
+
+// ListEmergencyGrants handles requests for vaults the caller is trusted with
+func (h *GophKeeperServer) ListEmergencyGrants(
+	ctx context.Context,
+	_ *pb.ListEmergencyGrantsRequest,
+) (*pb.ListEmergencyGrantsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	grants, err := h.emergency.ListGrants(ctx)
+	if err != nil {
+		return nil, errStatus(ctx, err, codes.Internal)
+	}
+
+	return &pb.ListEmergencyGrantsResponse{
+		Grants: pbEmergencyContacts(grants),
+	}, nil
+}
+
+// RequestEmergencyAccess handles emergency access requests of trusted contacts
+func (h *GophKeeperServer) RequestEmergencyAccess(
+	ctx context.Context,
+	req *pb.RequestEmergencyAccessRequest,
+) (*pb.RequestEmergencyAccessResponse, error) {
+	if req.Grantor == "" {
+		return nil, status.Error(codes.InvalidArgument, "grantor is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	err := h.emergency.RequestAccess(ctx, req.Grantor)
+	if err != nil {
+		return nil, errStatus(ctx, err, emergencyErrCode(err))
+	}
+
+	return &pb.RequestEmergencyAccessResponse{}, nil
+}
+
+// DenyEmergencyAccess handles denial of pending requests by the vault owner
+func (h *GophKeeperServer) DenyEmergencyAccess(
+	ctx context.Context,
+	req *pb.DenyEmergencyAccessRequest,
+) (*pb.DenyEmergencyAccessResponse, error) {
+	if req.Grantee == "" {
+		return nil, status.Error(codes.InvalidArgument, "grantee is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	err := h.emergency.DenyAccess(ctx, req.Grantee)
+	if err != nil {
+		return nil, errStatus(ctx, err, emergencyErrCode(err))
+	}
+
+	return &pb.DenyEmergencyAccessResponse{}, nil
+}
+
+// GetEmergencyVault handles requests for the vault released to a trusted contact
+func (h *GophKeeperServer) GetEmergencyVault(
+	ctx context.Context,
+	req *pb.EmergencyVaultRequest,
+) (*pb.EmergencyVaultResponse, error) {
+	if req.Grantor == "" {
+		return nil, status.Error(codes.InvalidArgument, "grantor is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	vault, err := h.emergency.GetVault(ctx, req.Grantor)
+	if err != nil {
+		return nil, errStatus(ctx, err, emergencyErrCode(err))
+	}
+
+	var resitems = make([]*pb.Item, len(vault.Items))
+	for i, item := range vault.Items {
+		resitems[i] = &pb.Item{
+			Id:        string(item.ID),
+			UserId:    string(item.UserID),
+			Type:      string(item.ItemType),
+			Name:      item.Name,
+			Metadata:  item.Metadata,
+			Data:      item.Data,
+			UpdatedAt: timestamppb.New(item.UpdatedAt),
+		}
+	}
+
+	return &pb.EmergencyVaultResponse{
+		WrappedKey: vault.WrappedKey,
+		Items:      resitems,
+	}, nil
+}
+
+// pbEmergencyContacts converts emergency contacts to protobuf format
+func pbEmergencyContacts(contacts []models.EmergencyContact) []*pb.EmergencyContact {
+	var res = make([]*pb.EmergencyContact, len(contacts))
+	for i, contact := range contacts {
+		res[i] = &pb.EmergencyContact{
+			Grantor:     contact.Grantor,
+			Grantee:     contact.Grantee,
+			WaitSeconds: int64(contact.WaitPeriod / time.Second),
+			Status:      string(contact.Status),
+		}
+		if !contact.RequestedAt.IsZero() {
+			res[i].RequestedAt = timestamppb.New(contact.RequestedAt)
+		}
+	}
+	return res
+}
+
+// emergencyErrCode maps emergency access errors to gRPC codes
+func emergencyErrCode(err error) codes.Code {
+	var noUser interface{ IsErrNoUser() bool }
+	var noAccess interface{ IsErrNoEmergencyAccess() bool }
+
+	switch {
+	case errors.As(err, &noUser):
+		return codes.NotFound
+	case errors.As(err, &noAccess):
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,165 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/server/internal/grpc/mocks"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testNoAccessErr mimics unavailable emergency access errors of storage
+type testNoAccessErr struct{}
+
+func (testNoAccessErr) Error() string                { return "no access" }
+func (testNoAccessErr) IsErrNoEmergencyAccess() bool { return true }
+
+func TestGophKeeperServer_AddEmergencyContact(t *testing.T) {
+	t.Run("successful nomination", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEmergency := mocks.NewMockemergencyService(ctrl)
+		handler := NewGophKeeperServer(Services{Emergency: mockEmergency}, testTimeout)
+
+		mockEmergency.EXPECT().
+			AddContact(gomock.Any(), &models.EmergencyContact{
+				Grantee:    "contact",
+				WaitPeriod: 2 * time.Hour,
+				WrappedKey: []byte("wrapped"),
+			}).
+			Return(nil)
+
+		_, err := handler.AddEmergencyContact(context.Background(), &gophkeeper.AddEmergencyContactRequest{
+			Grantee:     "contact",
+			WaitSeconds: 7200,
+			WrappedKey:  []byte("wrapped"),
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("negative wait period", func(t *testing.T) {
+		handler := NewGophKeeperServer(Services{}, testTimeout)
+
+		_, err := handler.AddEmergencyContact(context.Background(), &gophkeeper.AddEmergencyContactRequest{
+			Grantee:     "contact",
+			WaitSeconds: -1,
+			WrappedKey:  []byte("wrapped"),
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestGophKeeperServer_ListEmergencyGrants(t *testing.T) {
+	t.Run("grants converted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEmergency := mocks.NewMockemergencyService(ctrl)
+		handler := NewGophKeeperServer(Services{Emergency: mockEmergency}, testTimeout)
+
+		requestedAt := time.Now()
+		mockEmergency.EXPECT().
+			ListGrants(gomock.Any()).
+			Return([]models.EmergencyContact{
+				{Grantor: "owner", WaitPeriod: time.Hour, Status: models.EmergencyRequested, RequestedAt: requestedAt},
+				{Grantor: "other", WaitPeriod: time.Minute, Status: models.EmergencyIdle},
+			}, nil)
+
+		resp, err := handler.ListEmergencyGrants(context.Background(), &gophkeeper.ListEmergencyGrantsRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Grants, 2)
+		assert.Equal(t, "owner", resp.Grants[0].Grantor)
+		assert.Equal(t, int64(3600), resp.Grants[0].WaitSeconds)
+		assert.Equal(t, "requested", resp.Grants[0].Status)
+		assert.True(t, requestedAt.Equal(resp.Grants[0].RequestedAt.AsTime()))
+		assert.Nil(t, resp.Grants[1].RequestedAt)
+	})
+}
+
+func TestGophKeeperServer_RequestEmergencyAccess(t *testing.T) {
+	t.Run("request already pending", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEmergency := mocks.NewMockemergencyService(ctrl)
+		handler := NewGophKeeperServer(Services{Emergency: mockEmergency}, testTimeout)
+
+		mockEmergency.EXPECT().
+			RequestAccess(gomock.Any(), "owner").
+			Return(testNoAccessErr{})
+
+		_, err := handler.RequestEmergencyAccess(context.Background(), &gophkeeper.RequestEmergencyAccessRequest{Grantor: "owner"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("missing grantor", func(t *testing.T) {
+		handler := NewGophKeeperServer(Services{}, testTimeout)
+
+		_, err := handler.RequestEmergencyAccess(context.Background(), &gophkeeper.RequestEmergencyAccessRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestGophKeeperServer_DenyEmergencyAccess(t *testing.T) {
+	t.Run("successful denial", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEmergency := mocks.NewMockemergencyService(ctrl)
+		handler := NewGophKeeperServer(Services{Emergency: mockEmergency}, testTimeout)
+
+		mockEmergency.EXPECT().
+			DenyAccess(gomock.Any(), "contact").
+			Return(nil)
+
+		_, err := handler.DenyEmergencyAccess(context.Background(), &gophkeeper.DenyEmergencyAccessRequest{Grantee: "contact"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestGophKeeperServer_GetEmergencyVault(t *testing.T) {
+	t.Run("vault released", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEmergency := mocks.NewMockemergencyService(ctrl)
+		handler := NewGophKeeperServer(Services{Emergency: mockEmergency}, testTimeout)
+
+		mockEmergency.EXPECT().
+			GetVault(gomock.Any(), "owner").
+			Return(&models.EmergencyVault{
+				WrappedKey: []byte("wrapped"),
+				Items:      []models.Item{{ID: "item1", Name: "bank", Data: []byte("data")}},
+			}, nil)
+
+		resp, err := handler.GetEmergencyVault(context.Background(), &gophkeeper.EmergencyVaultRequest{Grantor: "owner"})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("wrapped"), resp.WrappedKey)
+		require.Len(t, resp.Items, 1)
+		assert.Equal(t, "item1", resp.Items[0].Id)
+		assert.Equal(t, []byte("data"), resp.Items[0].Data)
+	})
+
+	t.Run("unknown grantor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEmergency := mocks.NewMockemergencyService(ctrl)
+		handler := NewGophKeeperServer(Services{Emergency: mockEmergency}, testTimeout)
+
+		mockEmergency.EXPECT().
+			GetVault(gomock.Any(), "ghost").
+			Return(nil, testNoUserErr{})
+
+		_, err := handler.GetEmergencyVault(context.Background(), &gophkeeper.EmergencyVaultRequest{Grantor: "ghost"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
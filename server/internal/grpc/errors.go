@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rycln/gokeep/server/internal/logger"
+	"github.com/rycln/gokeep/shared/models"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Messages of errors not disclosed to clients
+const (
+	msgTimeout  = "request timed out"
+	msgCanceled = "request canceled"
+	msgInternal = "internal error"
+)
+
+// codeReasons maps status codes to reasons of errors without a more specific one
+var codeReasons = map[codes.Code]string{
+	codes.InvalidArgument:   models.ReasonInvalidRequest,
+	codes.NotFound:          models.ReasonNotFound,
+	codes.AlreadyExists:     models.ReasonAlreadyExists,
+	codes.PermissionDenied:  models.ReasonForbidden,
+	codes.ResourceExhausted: models.ReasonTooManyAttempts,
+	codes.Unauthenticated:   models.ReasonSessionExpired,
+}
+
+// errStatus maps service error to gRPC status with the code of a recognized domain error.
+// Timeouts become DeadlineExceeded whatever the code, an expired request only
+// explains errors without a domain code. Internal errors may carry storage details,
+// so they are logged with the request ID and hidden from the client.
+func errStatus(ctx context.Context, err error, code codes.Code) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded),
+		code == codes.Internal && errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return newStatus(codes.DeadlineExceeded, models.ReasonTimeout, msgTimeout)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, msgCanceled)
+	case code == codes.Internal:
+		logger.FromContext(ctx).Error("request failed", zap.Error(err))
+		return newStatus(codes.Internal, models.ReasonInternal, msgInternal)
+	}
+
+	reason, ok := codeReasons[code]
+	if !ok {
+		return status.Error(code, err.Error())
+	}
+	return newStatus(code, reason, err.Error())
+}
+
+// newStatus builds status error with ErrorInfo of the reason followed by other details
+func newStatus(code codes.Code, reason, msg string, details ...protoadapt.MessageV1) error {
+	info := &errdetails.ErrorInfo{Reason: reason, Domain: models.ErrorDomain}
+
+	st := status.New(code, msg)
+	withDetails, err := st.WithDetails(append([]protoadapt.MessageV1{info}, details...)...)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
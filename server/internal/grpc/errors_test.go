@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrStatus(t *testing.T) {
+	testErr := errors.New(`pq: relation "items" does not exist`)
+
+	t.Run("internal error is hidden", func(t *testing.T) {
+		err := errStatus(context.Background(), testErr, codes.Internal)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, msgInternal, status.Convert(err).Message())
+		assert.Equal(t, models.ReasonInternal, errorReason(t, err))
+	})
+
+	t.Run("domain error keeps message", func(t *testing.T) {
+		err := errStatus(context.Background(), testErr, codes.NotFound)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, testErr.Error(), status.Convert(err).Message())
+		assert.Equal(t, models.ReasonNotFound, errorReason(t, err))
+	})
+
+	t.Run("wrapped deadline becomes timeout", func(t *testing.T) {
+		err := errStatus(context.Background(), fmt.Errorf("query: %w", context.DeadlineExceeded), codes.Internal)
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Equal(t, models.ReasonTimeout, errorReason(t, err))
+	})
+
+	t.Run("expired context becomes timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 0)
+		defer cancel()
+
+		err := errStatus(ctx, testErr, codes.Internal)
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	})
+
+	t.Run("domain error after deadline keeps code", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 0)
+		defer cancel()
+
+		err := errStatus(ctx, testErr, codes.NotFound)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, models.ReasonNotFound, errorReason(t, err))
+	})
+
+	t.Run("canceled request", func(t *testing.T) {
+		err := errStatus(context.Background(), context.Canceled, codes.Internal)
+		assert.Equal(t, codes.Canceled, status.Code(err))
+	})
+
+	t.Run("code without reason", func(t *testing.T) {
+		err := errStatus(context.Background(), testErr, codes.FailedPrecondition)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Empty(t, status.Convert(err).Details())
+	})
+}
+
+func TestNewStatus(t *testing.T) {
+	err := newStatus(codes.ResourceExhausted, models.ReasonQuotaExceeded, "quota",
+		&errdetails.QuotaFailure{})
+
+	details := status.Convert(err).Details()
+	assert.Len(t, details, 2)
+	info, ok := details[0].(*errdetails.ErrorInfo)
+	assert.True(t, ok)
+	assert.Equal(t, models.ReasonQuotaExceeded, info.Reason)
+	assert.Equal(t, models.ErrorDomain, info.Domain)
+	assert.IsType(t, &errdetails.QuotaFailure{}, details[1])
+}
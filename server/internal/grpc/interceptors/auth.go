@@ -2,34 +2,52 @@ package interceptors
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/rycln/gokeep/server/internal/contextkeys"
 	"github.com/rycln/gokeep/server/internal/logger"
 	"github.com/rycln/gokeep/shared/models"
 	"go.uber.org/zap"
 
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 //go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
 
+// publicServices lists services available without JWT
+var publicServices = map[string]bool{
+	healthpb.Health_ServiceDesc.ServiceName: true,
+}
+
 // authServicer defines the interface for authentication operations.
 // Implementations should handle both JWT generation and parsing.
 type jwtServicer interface {
-	// ParseIDFromAuthHeader extracts user ID from a JWT authorization header.
-	ParseIDFromJWT(string) (models.UserID, error)
+	// ParseJWT extracts user ID and issue time from a JWT.
+	ParseJWT(string) (models.UserID, time.Time, error)
+}
+
+// sessionChecker defines validation of issued tokens against account state.
+type sessionChecker interface {
+	CheckSession(context.Context, models.UserID, time.Time) error
 }
 
 // AuthInterceptor implements gRPC unary server interceptor for authentication.
 // It handles both existing JWT validation and new user registration.
 type AuthInterceptor struct {
-	authService jwtServicer // Service handling JWT operations
+	authService jwtServicer    // Service handling JWT operations
+	sessions    sessionChecker // Rejects tokens of disabled or logged out accounts
 }
 
 // NewAuthInterceptor creates a new AuthInterceptor instance.
-func NewAuthInterceptor(authService jwtServicer) *AuthInterceptor {
+func NewAuthInterceptor(authService jwtServicer, sessions sessionChecker) *AuthInterceptor {
 	return &AuthInterceptor{
 		authService: authService,
+		sessions:    sessions,
 	}
 }
 
@@ -37,15 +55,42 @@ func NewAuthInterceptor(authService jwtServicer) *AuthInterceptor {
 func (i *AuthInterceptor) AuthFunc(ctx context.Context) (context.Context, error) {
 	token, err := auth.AuthFromMD(ctx, "bearer")
 	if err != nil {
-		logger.Log.Debug("auth interceptor", zap.Error(err))
+		logger.FromContext(ctx).Debug("auth interceptor", zap.Error(err))
 		return nil, err
 	}
 
-	uid, err := i.authService.ParseIDFromJWT(token)
+	uid, issuedAt, err := i.authService.ParseJWT(token)
 	if err != nil {
-		logger.Log.Debug("auth interceptor", zap.Error(err))
+		logger.FromContext(ctx).Debug("auth interceptor", zap.Error(err))
 		return nil, err
 	}
 
+	err = i.sessions.CheckSession(ctx, uid, issuedAt)
+	if err != nil {
+		logger.FromContext(ctx).Debug("auth interceptor", zap.Error(err))
+		return nil, status.Error(sessionErrCode(err), err.Error())
+	}
+
 	return context.WithValue(ctx, contextkeys.UserID, uid), nil
 }
+
+// sessionErrCode maps session check errors to gRPC codes
+// Tokens of disabled, logged out or deleted accounts are treated as invalid
+func sessionErrCode(err error) codes.Code {
+	var disabled interface{ IsErrDisabled() bool }
+	var revoked interface{ IsErrRevoked() bool }
+	var noUser interface{ IsErrNoUser() bool }
+
+	switch {
+	case errors.As(err, &disabled), errors.As(err, &revoked), errors.As(err, &noUser):
+		return codes.Unauthenticated
+	default:
+		return codes.Internal
+	}
+}
+
+// AuthRequired reports whether the call must carry a JWT.
+// Used with selector middleware to let orchestrators run health checks.
+func AuthRequired(_ context.Context, c middleware.CallMeta) bool {
+	return !publicServices[c.Service]
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
 	"github.com/rycln/gokeep/server/internal/contextkeys"
 	"github.com/rycln/gokeep/server/internal/grpc/interceptors/mocks"
 	"github.com/rycln/gokeep/server/internal/logger"
@@ -14,7 +16,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -22,16 +26,26 @@ const (
 	testToken  = "test.jwt.token"
 )
 
+var testIssuedAt = time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)
+
+// testSessionErr mimics account state errors of the user service
+type testSessionErr struct{}
+
+func (testSessionErr) Error() string      { return "session was revoked" }
+func (testSessionErr) IsErrRevoked() bool { return true }
+
 func TestNewAuthInterceptor(t *testing.T) {
 	t.Run("should create new interceptor", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
 		mockService := mocks.NewMockjwtServicer(ctrl)
-		interceptor := NewAuthInterceptor(mockService)
+		mockSessions := mocks.NewMocksessionChecker(ctrl)
+		interceptor := NewAuthInterceptor(mockService, mockSessions)
 
 		assert.NotNil(t, interceptor)
 		assert.Equal(t, mockService, interceptor.authService)
+		assert.Equal(t, mockSessions, interceptor.sessions)
 	})
 }
 
@@ -46,14 +60,18 @@ func TestAuthInterceptor_AuthFunc(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockService := mocks.NewMockjwtServicer(ctrl)
-		interceptor := NewAuthInterceptor(mockService)
+		mockSessions := mocks.NewMocksessionChecker(ctrl)
+		interceptor := NewAuthInterceptor(mockService, mockSessions)
 
 		md := metadata.Pairs("authorization", "bearer "+testToken)
 		ctx := metadata.NewIncomingContext(context.Background(), md)
 
 		mockService.EXPECT().
-			ParseIDFromJWT(testToken).
-			Return(testUserID, nil)
+			ParseJWT(testToken).
+			Return(testUserID, testIssuedAt, nil)
+		mockSessions.EXPECT().
+			CheckSession(ctx, testUserID, testIssuedAt).
+			Return(nil)
 
 		newCtx, err := interceptor.AuthFunc(ctx)
 		require.NoError(t, err)
@@ -66,7 +84,8 @@ func TestAuthInterceptor_AuthFunc(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockService := mocks.NewMockjwtServicer(ctrl)
-		interceptor := NewAuthInterceptor(mockService)
+		mockSessions := mocks.NewMocksessionChecker(ctrl)
+		interceptor := NewAuthInterceptor(mockService, mockSessions)
 
 		ctx := context.Background()
 
@@ -84,7 +103,8 @@ func TestAuthInterceptor_AuthFunc(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockService := mocks.NewMockjwtServicer(ctrl)
-		interceptor := NewAuthInterceptor(mockService)
+		mockSessions := mocks.NewMocksessionChecker(ctrl)
+		interceptor := NewAuthInterceptor(mockService, mockSessions)
 
 		md := metadata.Pairs("authorization", testToken)
 		ctx := metadata.NewIncomingContext(context.Background(), md)
@@ -103,15 +123,16 @@ func TestAuthInterceptor_AuthFunc(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockService := mocks.NewMockjwtServicer(ctrl)
-		interceptor := NewAuthInterceptor(mockService)
+		mockSessions := mocks.NewMocksessionChecker(ctrl)
+		interceptor := NewAuthInterceptor(mockService, mockSessions)
 
 		md := metadata.Pairs("authorization", "bearer "+testToken)
 		ctx := metadata.NewIncomingContext(context.Background(), md)
 
 		testErr := errors.New("invalid token")
 		mockService.EXPECT().
-			ParseIDFromJWT(testToken).
-			Return(models.UserID(""), testErr)
+			ParseJWT(testToken).
+			Return(models.UserID(""), time.Time{}, testErr)
 
 		_, err := interceptor.AuthFunc(ctx)
 		require.Error(t, err)
@@ -123,3 +144,48 @@ func TestAuthInterceptor_AuthFunc(t *testing.T) {
 		observedLogs.TakeAll()
 	})
 }
+
+func TestAuthInterceptor_AuthFuncSession(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{name: "revoked session is unauthenticated", err: testSessionErr{}, code: codes.Unauthenticated},
+		{name: "storage failure is internal", err: errors.New("connection refused"), code: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockjwtServicer(ctrl)
+			mockSessions := mocks.NewMocksessionChecker(ctrl)
+			interceptor := NewAuthInterceptor(mockService, mockSessions)
+
+			md := metadata.Pairs("authorization", "bearer "+testToken)
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+
+			mockService.EXPECT().
+				ParseJWT(testToken).
+				Return(testUserID, testIssuedAt, nil)
+			mockSessions.EXPECT().
+				CheckSession(ctx, testUserID, testIssuedAt).
+				Return(tt.err)
+
+			_, err := interceptor.AuthFunc(ctx)
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	t.Run("health check is public", func(t *testing.T) {
+		assert.False(t, AuthRequired(context.Background(), middleware.CallMeta{Service: "grpc.health.v1.Health", Method: "Check"}))
+	})
+
+	t.Run("gophkeeper methods require JWT", func(t *testing.T) {
+		assert.True(t, AuthRequired(context.Background(), middleware.CallMeta{Service: "gophkeeper.GophKeeper", Method: "Sync"}))
+	})
+}
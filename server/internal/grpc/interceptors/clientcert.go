@@ -0,0 +1,104 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/x509"
+	"path"
+	"strings"
+
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2"
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/server/internal/contextkeys"
+	"github.com/rycln/gokeep/server/internal/mtls"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// deviceServices lists services called from user devices, other ones have their own authentication
+var deviceServices = map[string]bool{
+	pb.GophKeeper_ServiceDesc.ServiceName: true,
+}
+
+// serverCertMatcher recognizes the server certificate presented by the REST gateway
+type serverCertMatcher interface {
+	IsServer(*x509.Certificate) bool
+}
+
+// ClientCertInterceptor maps verified client certificate to a device.
+// Must run after ClientInfoInterceptor because the certificate identity replaces user agent.
+type ClientCertInterceptor struct {
+	server   serverCertMatcher
+	required bool
+}
+
+// NewClientCertInterceptor creates a new ClientCertInterceptor instance.
+// With required set calls of device services without a certificate are rejected.
+func NewClientCertInterceptor(server serverCertMatcher, required bool) *ClientCertInterceptor {
+	return &ClientCertInterceptor{
+		server:   server,
+		required: required,
+	}
+}
+
+// Unary stores certificate device in context and enforces the requirement.
+func (i *ClientCertInterceptor) Unary(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	device := i.device(ctx)
+	if device != "" {
+		ctx = context.WithValue(ctx, contextkeys.Device, device)
+	} else if i.required && deviceServices[strings.TrimPrefix(path.Dir(info.FullMethod), "/")] {
+		return nil, status.Error(codes.Unauthenticated, "client certificate required")
+	}
+
+	return handler(ctx, req)
+}
+
+// Stream applies the same rules to streaming calls.
+func (i *ClientCertInterceptor) Stream(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	device := i.device(ss.Context())
+	if device != "" {
+		wrapped := middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = context.WithValue(ss.Context(), contextkeys.Device, device)
+		ss = wrapped
+	} else if i.required && deviceServices[strings.TrimPrefix(path.Dir(info.FullMethod), "/")] {
+		return status.Error(codes.Unauthenticated, "client certificate required")
+	}
+
+	return handler(srv, ss)
+}
+
+// device returns identity of the verified peer certificate.
+// For the REST gateway it is the device of the HTTP client forwarded in metadata.
+func (i *ClientCertInterceptor) device(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return ""
+	}
+
+	cert := tlsInfo.State.PeerCertificates[0]
+	if i.server.IsServer(cert) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		return firstValue(md, mtls.DeviceMetadataKey)
+	}
+
+	return mtls.DeviceName(cert)
+}
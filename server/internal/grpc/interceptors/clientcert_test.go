@@ -0,0 +1,177 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/server/internal/contextkeys"
+	"github.com/rycln/gokeep/server/internal/grpc/interceptors/mocks"
+	"github.com/rycln/gokeep/server/internal/mtls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// certContext returns context of a TLS peer presenting cert, nil for no certificate
+func certContext(cert *x509.Certificate) context.Context {
+	var state tls.ConnectionState
+	if cert != nil {
+		state.PeerCertificates = []*x509.Certificate{cert}
+	}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestClientCertInterceptor_Unary(t *testing.T) {
+	syncInfo := &grpc.UnaryServerInfo{FullMethod: "/gophkeeper.GophKeeper/Sync"}
+	deviceCert := &x509.Certificate{Subject: pkix.Name{CommonName: "laptop-1"}}
+	serverCert := &x509.Certificate{Subject: pkix.Name{CommonName: "gophkeeper.test"}}
+
+	var handlerCtx context.Context
+	handler := func(ctx context.Context, _ any) (any, error) {
+		handlerCtx = ctx
+		return "ok", nil
+	}
+
+	t.Run("certificate identity stored as device", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockServer := mocks.NewMockserverCertMatcher(ctrl)
+		i := NewClientCertInterceptor(mockServer, true)
+
+		mockServer.EXPECT().IsServer(deviceCert).Return(false)
+
+		ctx := context.WithValue(certContext(deviceCert), contextkeys.Device, "gophkeeper-client/1.0")
+		resp, err := i.Unary(ctx, nil, syncInfo, handler)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", resp)
+		assert.Equal(t, "laptop-1", handlerCtx.Value(contextkeys.Device))
+	})
+
+	t.Run("device forwarded by gateway", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockServer := mocks.NewMockserverCertMatcher(ctrl)
+		i := NewClientCertInterceptor(mockServer, true)
+
+		mockServer.EXPECT().IsServer(serverCert).Return(true)
+
+		ctx := metadata.NewIncomingContext(certContext(serverCert), metadata.Pairs(mtls.DeviceMetadataKey, "laptop-2"))
+		_, err := i.Unary(ctx, nil, syncInfo, handler)
+		require.NoError(t, err)
+		assert.Equal(t, "laptop-2", handlerCtx.Value(contextkeys.Device))
+	})
+
+	t.Run("gateway client without certificate rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockServer := mocks.NewMockserverCertMatcher(ctrl)
+		i := NewClientCertInterceptor(mockServer, true)
+
+		mockServer.EXPECT().IsServer(serverCert).Return(true)
+
+		_, err := i.Unary(certContext(serverCert), nil, syncInfo, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("missing certificate rejected when required", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		i := NewClientCertInterceptor(mocks.NewMockserverCertMatcher(ctrl), true)
+
+		_, err := i.Unary(certContext(nil), nil, syncInfo, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("missing certificate allowed when optional", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		i := NewClientCertInterceptor(mocks.NewMockserverCertMatcher(ctrl), false)
+
+		ctx := context.WithValue(certContext(nil), contextkeys.Device, "gophkeeper-client/1.0")
+		resp, err := i.Unary(ctx, nil, syncInfo, handler)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", resp)
+		assert.Equal(t, "gophkeeper-client/1.0", handlerCtx.Value(contextkeys.Device))
+	})
+
+	t.Run("health check allowed without certificate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		i := NewClientCertInterceptor(mocks.NewMockserverCertMatcher(ctrl), true)
+
+		healthInfo := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+		resp, err := i.Unary(context.Background(), nil, healthInfo, handler)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", resp)
+	})
+}
+
+// testStream is a server stream with a fixed context
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestClientCertInterceptor_Stream(t *testing.T) {
+	watchInfo := &grpc.StreamServerInfo{FullMethod: "/gophkeeper.GophKeeper/Watch", IsServerStream: true}
+	deviceCert := &x509.Certificate{Subject: pkix.Name{CommonName: "laptop-1"}}
+
+	var handlerCtx context.Context
+	handler := func(_ any, ss grpc.ServerStream) error {
+		handlerCtx = ss.Context()
+		return nil
+	}
+
+	t.Run("certificate identity stored as device", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockServer := mocks.NewMockserverCertMatcher(ctrl)
+		i := NewClientCertInterceptor(mockServer, true)
+
+		mockServer.EXPECT().IsServer(deviceCert).Return(false)
+
+		err := i.Stream(nil, &testStream{ctx: certContext(deviceCert)}, watchInfo, handler)
+		require.NoError(t, err)
+		assert.Equal(t, "laptop-1", handlerCtx.Value(contextkeys.Device))
+	})
+
+	t.Run("missing certificate rejected when required", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		i := NewClientCertInterceptor(mocks.NewMockserverCertMatcher(ctrl), true)
+
+		err := i.Stream(nil, &testStream{ctx: certContext(nil)}, watchInfo, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("missing certificate allowed when optional", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		i := NewClientCertInterceptor(mocks.NewMockserverCertMatcher(ctrl), false)
+
+		err := i.Stream(nil, &testStream{ctx: certContext(nil)}, watchInfo, handler)
+		require.NoError(t, err)
+	})
+}
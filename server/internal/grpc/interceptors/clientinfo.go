@@ -0,0 +1,82 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/rycln/gokeep/server/internal/contextkeys"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ClientInfoInterceptor stores client user agent and peer address in request context.
+// Values are used by the audit log and rate limiting.
+// Behind the REST gateway the peer is the gateway's loopback connection,
+// so the client is taken from headers the gateway forwards.
+// The gateway appends the remote address to X-Forwarded-For sent by the client,
+// only this last entry can be trusted.
+func ClientInfoInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var ip string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = peerIP(p.Addr)
+	}
+	device := firstValue(md, "user-agent")
+
+	if isLoopback(ip) {
+		if fwd := lastValue(md, "x-forwarded-for"); fwd != "" {
+			ip = strings.TrimSpace(fwd[strings.LastIndex(fwd, ",")+1:])
+		}
+		if ua := firstValue(md, "grpcgateway-user-agent"); ua != "" {
+			device = ua
+		}
+	}
+
+	if device != "" {
+		ctx = context.WithValue(ctx, contextkeys.Device, device)
+	}
+	if ip != "" {
+		ctx = context.WithValue(ctx, contextkeys.PeerIP, ip)
+	}
+
+	return handler(ctx, req)
+}
+
+// firstValue returns the first metadata value of the key
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// lastValue returns the last metadata value of the key
+func lastValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[len(values)-1]
+	}
+	return ""
+}
+
+// isLoopback reports whether the address belongs to this host
+func isLoopback(ip string) bool {
+	parsed := net.ParseIP(ip)
+	return parsed != nil && parsed.IsLoopback()
+}
+
+// peerIP strips port from the peer address
+func peerIP(addr net.Addr) string {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
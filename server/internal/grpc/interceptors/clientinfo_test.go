@@ -0,0 +1,87 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/rycln/gokeep/server/internal/contextkeys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientInfoInterceptor(t *testing.T) {
+	t.Run("user agent and peer address stored", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user-agent", "gophkeeper-client/1.0"))
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 51000}})
+
+		var handlerCtx context.Context
+		_, err := ClientInfoInterceptor(ctx, nil, nil, func(ctx context.Context, req any) (any, error) {
+			handlerCtx = ctx
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "gophkeeper-client/1.0", handlerCtx.Value(contextkeys.Device))
+		assert.Equal(t, "192.0.2.1", handlerCtx.Value(contextkeys.PeerIP))
+	})
+
+	t.Run("missing metadata and peer", func(t *testing.T) {
+		var handlerCtx context.Context
+		_, err := ClientInfoInterceptor(context.Background(), nil, nil, func(ctx context.Context, req any) (any, error) {
+			handlerCtx = ctx
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.Nil(t, handlerCtx.Value(contextkeys.Device))
+		assert.Nil(t, handlerCtx.Value(contextkeys.PeerIP))
+	})
+
+	t.Run("client forwarded by gateway", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			"user-agent", "grpc-go/1.74.2",
+			"grpcgateway-user-agent", "curl/8.0",
+			"x-forwarded-for", "198.51.100.7",
+		))
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 51000}})
+
+		var handlerCtx context.Context
+		_, err := ClientInfoInterceptor(ctx, nil, nil, func(ctx context.Context, req any) (any, error) {
+			handlerCtx = ctx
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "curl/8.0", handlerCtx.Value(contextkeys.Device))
+		assert.Equal(t, "198.51.100.7", handlerCtx.Value(contextkeys.PeerIP))
+	})
+
+	t.Run("entries sent by client ignored", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			"x-forwarded-for", "203.0.113.9, 10.0.0.1",
+			"x-forwarded-for", "10.0.0.2, 198.51.100.7",
+		))
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 51000}})
+
+		var handlerCtx context.Context
+		_, err := ClientInfoInterceptor(ctx, nil, nil, func(ctx context.Context, req any) (any, error) {
+			handlerCtx = ctx
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "198.51.100.7", handlerCtx.Value(contextkeys.PeerIP))
+	})
+
+	t.Run("forwarded address of remote peer ignored", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "198.51.100.7"))
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 51000}})
+
+		var handlerCtx context.Context
+		_, err := ClientInfoInterceptor(ctx, nil, nil, func(ctx context.Context, req any) (any, error) {
+			handlerCtx = ctx
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "192.0.2.1", handlerCtx.Value(contextkeys.PeerIP))
+	})
+}
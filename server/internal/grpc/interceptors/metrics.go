@@ -0,0 +1,92 @@
+package interceptors
+
+import (
+	"context"
+	"path"
+	"time"
+
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// Sync payload directions
+const (
+	syncPush = "push" // Items sent by client
+	syncPull = "pull" // Items returned to client
+)
+
+// credentialMethods lists methods verifying user credentials
+var credentialMethods = map[string]bool{
+	"Login":         true,
+	"Recover":       true,
+	"DeleteAccount": true,
+}
+
+// metricsRecorder defines server metrics collection
+type metricsRecorder interface {
+	// ObserveRequest records handled request status and latency
+	ObserveRequest(string, codes.Code, time.Duration)
+	// ObserveSync records sync payload size and item count
+	ObserveSync(string, int, int)
+	// AuthFailure records rejected authentication attempt
+	AuthFailure(string, codes.Code)
+}
+
+// MetricsInterceptor records request, sync and authentication metrics.
+// Must run before RateLimitInterceptor and auth to observe rejected requests.
+type MetricsInterceptor struct {
+	metrics metricsRecorder
+}
+
+// NewMetricsInterceptor creates a new MetricsInterceptor instance.
+func NewMetricsInterceptor(metrics metricsRecorder) *MetricsInterceptor {
+	return &MetricsInterceptor{
+		metrics: metrics,
+	}
+}
+
+// Unary observes every request after the handler returns.
+func (i *MetricsInterceptor) Unary(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	method := path.Base(info.FullMethod)
+	code := status.Code(err)
+	i.metrics.ObserveRequest(method, code, time.Since(start))
+
+	if isAuthFailure(method, code) {
+		i.metrics.AuthFailure(method, code)
+	}
+
+	if syncReq, ok := req.(*pb.SyncRequest); ok {
+		i.metrics.ObserveSync(syncPush, proto.Size(syncReq), len(syncReq.Items))
+	}
+	if syncResp, ok := resp.(*pb.SyncResponse); ok && err == nil {
+		i.metrics.ObserveSync(syncPull, proto.Size(syncResp), len(syncResp.Items))
+	}
+
+	return resp, err
+}
+
+// isAuthFailure reports whether status means rejected credentials or token
+// Server side errors of credential methods are not counted
+func isAuthFailure(method string, code codes.Code) bool {
+	switch code {
+	case codes.Unauthenticated:
+		return true
+	case codes.InvalidArgument, codes.NotFound, codes.ResourceExhausted:
+		return credentialMethods[method]
+	default:
+		return false
+	}
+}
@@ -0,0 +1,105 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/server/internal/grpc/interceptors/mocks"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMetricsInterceptor_Unary(t *testing.T) {
+	ctx := context.Background()
+	loginInfo := &grpc.UnaryServerInfo{FullMethod: "/gophkeeper.GophKeeper/Login"}
+	syncInfo := &grpc.UnaryServerInfo{FullMethod: "/gophkeeper.GophKeeper/Sync"}
+
+	t.Run("request observed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMetrics := mocks.NewMockmetricsRecorder(ctrl)
+		i := NewMetricsInterceptor(mockMetrics)
+
+		mockMetrics.EXPECT().ObserveRequest("Login", codes.OK, gomock.Any())
+
+		resp, err := i.Unary(ctx, &pb.LoginRequest{}, loginInfo, func(context.Context, any) (any, error) {
+			return "ok", nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", resp)
+	})
+
+	t.Run("failed login counted as auth failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMetrics := mocks.NewMockmetricsRecorder(ctrl)
+		i := NewMetricsInterceptor(mockMetrics)
+
+		mockMetrics.EXPECT().ObserveRequest("Login", codes.InvalidArgument, gomock.Any())
+		mockMetrics.EXPECT().AuthFailure("Login", codes.InvalidArgument)
+
+		_, err := i.Unary(ctx, &pb.LoginRequest{}, loginInfo, func(context.Context, any) (any, error) {
+			return nil, status.Error(codes.InvalidArgument, "wrong password")
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("internal login error not counted as auth failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMetrics := mocks.NewMockmetricsRecorder(ctrl)
+		i := NewMetricsInterceptor(mockMetrics)
+
+		mockMetrics.EXPECT().ObserveRequest("Login", codes.Internal, gomock.Any())
+
+		_, err := i.Unary(ctx, &pb.LoginRequest{}, loginInfo, func(context.Context, any) (any, error) {
+			return nil, status.Error(codes.Internal, "db down")
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("sync payload observed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMetrics := mocks.NewMockmetricsRecorder(ctrl)
+		i := NewMetricsInterceptor(mockMetrics)
+
+		req := &pb.SyncRequest{Items: []*pb.Item{{Id: "1", Data: []byte("data")}}}
+		resp := &pb.SyncResponse{Items: []*pb.Item{{Id: "1"}, {Id: "2"}}}
+
+		mockMetrics.EXPECT().ObserveRequest("Sync", codes.OK, gomock.Any())
+		mockMetrics.EXPECT().ObserveSync(syncPush, proto.Size(req), 1)
+		mockMetrics.EXPECT().ObserveSync(syncPull, proto.Size(resp), 2)
+
+		_, err := i.Unary(ctx, req, syncInfo, func(context.Context, any) (any, error) {
+			return resp, nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejected token counted as auth failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMetrics := mocks.NewMockmetricsRecorder(ctrl)
+		i := NewMetricsInterceptor(mockMetrics)
+
+		mockMetrics.EXPECT().ObserveRequest("Sync", codes.Unauthenticated, gomock.Any())
+		mockMetrics.EXPECT().AuthFailure("Sync", codes.Unauthenticated)
+		mockMetrics.EXPECT().ObserveSync(syncPush, gomock.Any(), 0)
+
+		_, err := i.Unary(ctx, &pb.SyncRequest{}, syncInfo, func(context.Context, any) (any, error) {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		})
+		assert.Error(t, err)
+	})
+}
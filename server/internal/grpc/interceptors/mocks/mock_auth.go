@@ -5,7 +5,9 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/rycln/gokeep/shared/models"
@@ -34,17 +36,55 @@ func (m *MockjwtServicer) EXPECT() *MockjwtServicerMockRecorder {
 	return m.recorder
 }
 
-// ParseIDFromJWT mocks base method.
-func (m *MockjwtServicer) ParseIDFromJWT(arg0 string) (models.UserID, error) {
+// ParseJWT mocks base method.
+func (m *MockjwtServicer) ParseJWT(arg0 string) (models.UserID, time.Time, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ParseIDFromJWT", arg0)
+	ret := m.ctrl.Call(m, "ParseJWT", arg0)
 	ret0, _ := ret[0].(models.UserID)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// ParseIDFromJWT indicates an expected call of ParseIDFromJWT.
-func (mr *MockjwtServicerMockRecorder) ParseIDFromJWT(arg0 interface{}) *gomock.Call {
+// ParseJWT indicates an expected call of ParseJWT.
+func (mr *MockjwtServicerMockRecorder) ParseJWT(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromJWT", reflect.TypeOf((*MockjwtServicer)(nil).ParseIDFromJWT), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseJWT", reflect.TypeOf((*MockjwtServicer)(nil).ParseJWT), arg0)
+}
+
+// MocksessionChecker is a mock of sessionChecker interface.
+type MocksessionChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionCheckerMockRecorder
+}
+
+// MocksessionCheckerMockRecorder is the mock recorder for MocksessionChecker.
+type MocksessionCheckerMockRecorder struct {
+	mock *MocksessionChecker
+}
+
+// NewMocksessionChecker creates a new mock instance.
+func NewMocksessionChecker(ctrl *gomock.Controller) *MocksessionChecker {
+	mock := &MocksessionChecker{ctrl: ctrl}
+	mock.recorder = &MocksessionCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionChecker) EXPECT() *MocksessionCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckSession mocks base method.
+func (m *MocksessionChecker) CheckSession(arg0 context.Context, arg1 models.UserID, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSession", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckSession indicates an expected call of CheckSession.
+func (mr *MocksessionCheckerMockRecorder) CheckSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSession", reflect.TypeOf((*MocksessionChecker)(nil).CheckSession), arg0, arg1, arg2)
 }
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: clientcert.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	x509 "crypto/x509"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockserverCertMatcher is a mock of serverCertMatcher interface.
+type MockserverCertMatcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockserverCertMatcherMockRecorder
+}
+
+// MockserverCertMatcherMockRecorder is the mock recorder for MockserverCertMatcher.
+type MockserverCertMatcherMockRecorder struct {
+	mock *MockserverCertMatcher
+}
+
+// NewMockserverCertMatcher creates a new mock instance.
+func NewMockserverCertMatcher(ctrl *gomock.Controller) *MockserverCertMatcher {
+	mock := &MockserverCertMatcher{ctrl: ctrl}
+	mock.recorder = &MockserverCertMatcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockserverCertMatcher) EXPECT() *MockserverCertMatcherMockRecorder {
+	return m.recorder
+}
+
+// IsServer mocks base method.
+func (m *MockserverCertMatcher) IsServer(arg0 *x509.Certificate) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsServer", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsServer indicates an expected call of IsServer.
+func (mr *MockserverCertMatcherMockRecorder) IsServer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsServer", reflect.TypeOf((*MockserverCertMatcher)(nil).IsServer), arg0)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: metrics.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	codes "google.golang.org/grpc/codes"
+)
+
+// MockmetricsRecorder is a mock of metricsRecorder interface.
+type MockmetricsRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockmetricsRecorderMockRecorder
+}
+
+// MockmetricsRecorderMockRecorder is the mock recorder for MockmetricsRecorder.
+type MockmetricsRecorderMockRecorder struct {
+	mock *MockmetricsRecorder
+}
+
+// NewMockmetricsRecorder creates a new mock instance.
+func NewMockmetricsRecorder(ctrl *gomock.Controller) *MockmetricsRecorder {
+	mock := &MockmetricsRecorder{ctrl: ctrl}
+	mock.recorder = &MockmetricsRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmetricsRecorder) EXPECT() *MockmetricsRecorderMockRecorder {
+	return m.recorder
+}
+
+// AuthFailure mocks base method.
+func (m *MockmetricsRecorder) AuthFailure(arg0 string, arg1 codes.Code) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AuthFailure", arg0, arg1)
+}
+
+// AuthFailure indicates an expected call of AuthFailure.
+func (mr *MockmetricsRecorderMockRecorder) AuthFailure(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthFailure", reflect.TypeOf((*MockmetricsRecorder)(nil).AuthFailure), arg0, arg1)
+}
+
+// ObserveRequest mocks base method.
+func (m *MockmetricsRecorder) ObserveRequest(arg0 string, arg1 codes.Code, arg2 time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveRequest", arg0, arg1, arg2)
+}
+
+// ObserveRequest indicates an expected call of ObserveRequest.
+func (mr *MockmetricsRecorderMockRecorder) ObserveRequest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveRequest", reflect.TypeOf((*MockmetricsRecorder)(nil).ObserveRequest), arg0, arg1, arg2)
+}
+
+// ObserveSync mocks base method.
+func (m *MockmetricsRecorder) ObserveSync(arg0 string, arg1, arg2 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveSync", arg0, arg1, arg2)
+}
+
+// ObserveSync indicates an expected call of ObserveSync.
+func (mr *MockmetricsRecorderMockRecorder) ObserveSync(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveSync", reflect.TypeOf((*MockmetricsRecorder)(nil).ObserveSync), arg0, arg1, arg2)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ratelimit.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockrateLimiter is a mock of rateLimiter interface.
+type MockrateLimiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockrateLimiterMockRecorder
+}
+
+// MockrateLimiterMockRecorder is the mock recorder for MockrateLimiter.
+type MockrateLimiterMockRecorder struct {
+	mock *MockrateLimiter
+}
+
+// NewMockrateLimiter creates a new mock instance.
+func NewMockrateLimiter(ctrl *gomock.Controller) *MockrateLimiter {
+	mock := &MockrateLimiter{ctrl: ctrl}
+	mock.recorder = &MockrateLimiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrateLimiter) EXPECT() *MockrateLimiterMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockrateLimiter) Allow(arg0 string) (bool, time.Duration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(time.Duration)
+	return ret0, ret1
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockrateLimiterMockRecorder) Allow(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockrateLimiter)(nil).Allow), arg0)
+}
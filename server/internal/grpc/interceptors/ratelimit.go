@@ -0,0 +1,84 @@
+package interceptors
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/rycln/gokeep/server/internal/contextkeys"
+	"github.com/rycln/gokeep/server/internal/validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// limitedMethods lists unauthenticated methods open to password guessing and spam
+var limitedMethods = map[string]bool{
+	"Register": true,
+	"Login":    true,
+	"Recover":  true,
+}
+
+// rateLimiter defines request counting per key
+type rateLimiter interface {
+	// Allow reports whether request is allowed and otherwise when to retry
+	Allow(string) (bool, time.Duration)
+}
+
+// RateLimitInterceptor limits account endpoints per client address and per username.
+// Usernames are counted by the same key as login lockout, so case variants share a limit.
+// Must run after ClientInfoInterceptor which provides the client address.
+type RateLimitInterceptor struct {
+	limiter rateLimiter
+}
+
+// NewRateLimitInterceptor creates a new RateLimitInterceptor instance.
+func NewRateLimitInterceptor(limiter rateLimiter) *RateLimitInterceptor {
+	return &RateLimitInterceptor{
+		limiter: limiter,
+	}
+}
+
+// Unary rejects limited requests with ResourceExhausted and retry delay detail.
+func (i *RateLimitInterceptor) Unary(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if !limitedMethods[path.Base(info.FullMethod)] {
+		return handler(ctx, req)
+	}
+
+	var keys []string
+	if ip, ok := ctx.Value(contextkeys.PeerIP).(string); ok && ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+	if r, ok := req.(interface{ GetUsername() string }); ok && r.GetUsername() != "" {
+		keys = append(keys, "user:"+validation.UsernameKey(r.GetUsername()))
+	}
+
+	for _, key := range keys {
+		if ok, retry := i.limiter.Allow(key); !ok {
+			return nil, exhausted(retry)
+		}
+	}
+
+	return handler(ctx, req)
+}
+
+// exhausted builds rate limit status with retry delay detail
+func exhausted(retry time.Duration) error {
+	const msg = "too many requests"
+	st, err := status.New(codes.ResourceExhausted, msg).WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retry),
+	})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, msg)
+	}
+	return st.Err()
+}
@@ -0,0 +1,89 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/server/internal/contextkeys"
+	"github.com/rycln/gokeep/server/internal/grpc/interceptors/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimitInterceptor_Unary(t *testing.T) {
+	loginInfo := &grpc.UnaryServerInfo{FullMethod: "/gophkeeper.GophKeeper/Login"}
+	loginReq := &pb.LoginRequest{Username: "testuser", Password: "pass"}
+	ctx := context.WithValue(context.Background(), contextkeys.PeerIP, "192.0.2.1")
+
+	okHandler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	t.Run("request counted by address and username", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockLimiter := mocks.NewMockrateLimiter(ctrl)
+		i := NewRateLimitInterceptor(mockLimiter)
+
+		gomock.InOrder(
+			mockLimiter.EXPECT().Allow("ip:192.0.2.1").Return(true, time.Duration(0)),
+			mockLimiter.EXPECT().Allow("user:testuser").Return(true, time.Duration(0)),
+		)
+
+		resp, err := i.Unary(ctx, loginReq, loginInfo, okHandler)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", resp)
+	})
+
+	t.Run("username variants share a limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockLimiter := mocks.NewMockrateLimiter(ctrl)
+		i := NewRateLimitInterceptor(mockLimiter)
+
+		mockLimiter.EXPECT().Allow("user:testuser").Return(true, time.Duration(0)).Times(2)
+
+		for _, username := range []string{"TestUser", "  testuser "} {
+			_, err := i.Unary(context.Background(), &pb.LoginRequest{Username: username}, loginInfo, okHandler)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("limited request rejected with retry delay", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockLimiter := mocks.NewMockrateLimiter(ctrl)
+		i := NewRateLimitInterceptor(mockLimiter)
+
+		mockLimiter.EXPECT().Allow("ip:192.0.2.1").Return(false, 40*time.Second)
+
+		_, err := i.Unary(ctx, loginReq, loginInfo, func(context.Context, any) (any, error) {
+			t.Error("handler must not be called")
+			return nil, nil
+		})
+		st := status.Convert(err)
+		assert.Equal(t, codes.ResourceExhausted, st.Code())
+		require.Len(t, st.Details(), 1)
+		retry, ok := st.Details()[0].(*errdetails.RetryInfo)
+		require.True(t, ok)
+		assert.Equal(t, 40*time.Second, retry.RetryDelay.AsDuration())
+	})
+
+	t.Run("other methods not limited", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		i := NewRateLimitInterceptor(mocks.NewMockrateLimiter(ctrl))
+
+		_, err := i.Unary(ctx, &pb.SyncRequest{}, &grpc.UnaryServerInfo{FullMethod: "/gophkeeper.GophKeeper/Sync"}, okHandler)
+		assert.NoError(t, err)
+	})
+}
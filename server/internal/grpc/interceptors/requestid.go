@@ -0,0 +1,68 @@
+package interceptors
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/google/uuid"
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/rycln/gokeep/server/internal/contextkeys"
+	"github.com/rycln/gokeep/server/internal/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDMetadataKey is the metadata key of the request ID, in both directions.
+const RequestIDMetadataKey = "x-request-id"
+
+// validRequestID limits request IDs taken from clients, so they can't forge log lines
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,64}$`)
+
+// RequestIDInterceptor stores request ID in request context and returns it in response headers.
+// ID sent by the client or a proxy in front of the server is kept, otherwise a new one is generated.
+func RequestIDInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	id := requestID(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, id))
+
+	return handler(context.WithValue(ctx, contextkeys.RequestID, id), req)
+}
+
+// RequestIDStreamInterceptor applies RequestIDInterceptor rules to streaming calls.
+func RequestIDStreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	id := requestID(ss.Context())
+	_ = ss.SetHeader(metadata.Pairs(RequestIDMetadataKey, id))
+
+	wrapped := middleware.WrapServerStream(ss)
+	wrapped.WrappedContext = context.WithValue(ss.Context(), contextkeys.RequestID, id)
+
+	return handler(srv, wrapped)
+}
+
+// RequestIDFields returns log fields of the request ID stored in context.
+// Used with the logging interceptor, so every call line carries the ID.
+func RequestIDFields(ctx context.Context) logging.Fields {
+	if id, ok := ctx.Value(contextkeys.RequestID).(string); ok {
+		return logging.Fields{logger.RequestIDField, id}
+	}
+	return nil
+}
+
+// requestID returns valid ID from metadata or a new one
+func requestID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if id := firstValue(md, RequestIDMetadataKey); validRequestID.MatchString(id) {
+		return id
+	}
+	return uuid.NewString()
+}
@@ -0,0 +1,82 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/rycln/gokeep/server/internal/contextkeys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// headerStream records headers set by the interceptor
+type headerStream struct {
+	testStream
+	header metadata.MD
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestRequestIDInterceptor(t *testing.T) {
+	handle := func(ctx context.Context) string {
+		var handlerCtx context.Context
+		_, err := RequestIDInterceptor(ctx, nil, nil, func(ctx context.Context, req any) (any, error) {
+			handlerCtx = ctx
+			return nil, nil
+		})
+		require.NoError(t, err)
+		return handlerCtx.Value(contextkeys.RequestID).(string)
+	}
+
+	t.Run("id of client kept", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDMetadataKey, "abc-123"))
+		assert.Equal(t, "abc-123", handle(ctx))
+	})
+
+	t.Run("id generated when missing", func(t *testing.T) {
+		_, err := uuid.Parse(handle(context.Background()))
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid id replaced", func(t *testing.T) {
+		for _, id := range []string{"line\nbreak", strings.Repeat("a", 65)} {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDMetadataKey, id))
+			assert.NotEqual(t, id, handle(ctx))
+		}
+	})
+}
+
+func TestRequestIDStreamInterceptor(t *testing.T) {
+	t.Run("id stored and returned in header", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDMetadataKey, "abc-123"))
+		ss := &headerStream{testStream: testStream{ctx: ctx}}
+
+		var handlerCtx context.Context
+		err := RequestIDStreamInterceptor(nil, ss, nil, func(_ any, ss grpc.ServerStream) error {
+			handlerCtx = ss.Context()
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "abc-123", handlerCtx.Value(contextkeys.RequestID))
+		assert.Equal(t, []string{"abc-123"}, ss.header.Get(RequestIDMetadataKey))
+	})
+}
+
+func TestRequestIDFields(t *testing.T) {
+	t.Run("id of context", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), contextkeys.RequestID, "abc-123")
+		assert.Equal(t, logging.Fields{"request_id", "abc-123"}, RequestIDFields(ctx))
+	})
+
+	t.Run("no id", func(t *testing.T) {
+		assert.Nil(t, RequestIDFields(context.Background()))
+	})
+}
@@ -0,0 +1,76 @@
+package interceptors
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/rycln/gokeep/server/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TracingInterceptor starts a server span for each request.
+// Trace context sent by the client becomes the parent of the span.
+func TracingInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+	service, method := path.Split(info.FullMethod)
+	ctx, span := tracing.Tracer().Start(ctx, strings.TrimPrefix(info.FullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(strings.Trim(service, "/")),
+			semconv.RPCMethod(method),
+		),
+	)
+	defer span.End()
+
+	resp, err := handler(ctx, req)
+
+	st := status.Convert(err)
+	span.SetAttributes(attribute.Int64(string(semconv.RPCGRPCStatusCodeKey), int64(st.Code())))
+	if err != nil {
+		span.SetStatus(otelcodes.Error, st.Message())
+	}
+
+	return resp, err
+}
+
+// metadataCarrier adapts gRPC metadata to trace context propagation
+type metadataCarrier metadata.MD
+
+// Get returns the first value of the key
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set replaces values of the key
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys lists metadata keys
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,66 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTracingInterceptor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	oldProvider, oldPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(oldProvider)
+		otel.SetTextMapPropagator(oldPropagator)
+	}()
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/gophkeeper.GophKeeper/Sync"}
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	t.Run("span continues client trace", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", traceparent))
+
+		var handlerSpan trace.SpanContext
+		_, err := TracingInterceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+			handlerSpan = trace.SpanContextFromContext(ctx)
+			return nil, nil
+		})
+		require.NoError(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		span := spans[0]
+		assert.Equal(t, "gophkeeper.GophKeeper/Sync", span.Name())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+		assert.Equal(t, span.SpanContext(), handlerSpan)
+		assert.Equal(t, otelcodes.Unset, span.Status().Code)
+	})
+
+	t.Run("failed request marks span", func(t *testing.T) {
+		_, err := TracingInterceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			return nil, status.Error(codes.Internal, "db down")
+		})
+		require.Error(t, err)
+
+		spans := recorder.Ended()
+		span := spans[len(spans)-1]
+		assert.Equal(t, otelcodes.Error, span.Status().Code)
+		assert.Equal(t, "db down", span.Status().Description)
+		assert.False(t, span.Parent().IsValid())
+	})
+}
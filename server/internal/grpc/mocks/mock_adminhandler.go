@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adminhandler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/gokeep/shared/models"
+)
+
+// MockadminService is a mock of adminService interface.
+type MockadminService struct {
+	ctrl     *gomock.Controller
+	recorder *MockadminServiceMockRecorder
+}
+
+// MockadminServiceMockRecorder is the mock recorder for MockadminService.
+type MockadminServiceMockRecorder struct {
+	mock *MockadminService
+}
+
+// NewMockadminService creates a new mock instance.
+func NewMockadminService(ctrl *gomock.Controller) *MockadminService {
+	mock := &MockadminService{ctrl: ctrl}
+	mock.recorder = &MockadminServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockadminService) EXPECT() *MockadminServiceMockRecorder {
+	return m.recorder
+}
+
+// DisableUser mocks base method.
+func (m *MockadminService) DisableUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableUser indicates an expected call of DisableUser.
+func (mr *MockadminServiceMockRecorder) DisableUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableUser", reflect.TypeOf((*MockadminService)(nil).DisableUser), arg0, arg1)
+}
+
+// EnableUser mocks base method.
+func (m *MockadminService) EnableUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableUser indicates an expected call of EnableUser.
+func (mr *MockadminServiceMockRecorder) EnableUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableUser", reflect.TypeOf((*MockadminService)(nil).EnableUser), arg0, arg1)
+}
+
+// GetUsage mocks base method.
+func (m *MockadminService) GetUsage(arg0 context.Context, arg1 string) (*models.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", arg0, arg1)
+	ret0, _ := ret[0].(*models.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockadminServiceMockRecorder) GetUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockadminService)(nil).GetUsage), arg0, arg1)
+}
+
+// ListUsers mocks base method.
+func (m *MockadminService) ListUsers(arg0 context.Context, arg1, arg2 string, arg3 int) ([]models.AdminUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.AdminUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockadminServiceMockRecorder) ListUsers(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockadminService)(nil).ListUsers), arg0, arg1, arg2, arg3)
+}
+
+// LogoutUser mocks base method.
+func (m *MockadminService) LogoutUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutUser indicates an expected call of LogoutUser.
+func (mr *MockadminServiceMockRecorder) LogoutUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutUser", reflect.TypeOf((*MockadminService)(nil).LogoutUser), arg0, arg1)
+}
+
+// PurgeUser mocks base method.
+func (m *MockadminService) PurgeUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeUser indicates an expected call of PurgeUser.
+func (mr *MockadminServiceMockRecorder) PurgeUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeUser", reflect.TypeOf((*MockadminService)(nil).PurgeUser), arg0, arg1)
+}
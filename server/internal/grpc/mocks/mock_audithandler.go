@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: audithandler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/gokeep/shared/models"
+)
+
+// MockauditService is a mock of auditService interface.
+type MockauditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockauditServiceMockRecorder
+}
+
+// MockauditServiceMockRecorder is the mock recorder for MockauditService.
+type MockauditServiceMockRecorder struct {
+	mock *MockauditService
+}
+
+// NewMockauditService creates a new mock instance.
+func NewMockauditService(ctrl *gomock.Controller) *MockauditService {
+	mock := &MockauditService{ctrl: ctrl}
+	mock.recorder = &MockauditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauditService) EXPECT() *MockauditServiceMockRecorder {
+	return m.recorder
+}
+
+// ListEvents mocks base method.
+func (m *MockauditService) ListEvents(arg0 context.Context, arg1 int64, arg2 int) ([]models.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockauditServiceMockRecorder) ListEvents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockauditService)(nil).ListEvents), arg0, arg1, arg2)
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: emergencyhandler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/gokeep/shared/models"
+)
+
+// MockemergencyService is a mock of emergencyService interface.
+type MockemergencyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockemergencyServiceMockRecorder
+}
+
+// MockemergencyServiceMockRecorder is the mock recorder for MockemergencyService.
+type MockemergencyServiceMockRecorder struct {
+	mock *MockemergencyService
+}
+
+// NewMockemergencyService creates a new mock instance.
+func NewMockemergencyService(ctrl *gomock.Controller) *MockemergencyService {
+	mock := &MockemergencyService{ctrl: ctrl}
+	mock.recorder = &MockemergencyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockemergencyService) EXPECT() *MockemergencyServiceMockRecorder {
+	return m.recorder
+}
+
+// AddContact mocks base method.
+func (m *MockemergencyService) AddContact(arg0 context.Context, arg1 *models.EmergencyContact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddContact", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddContact indicates an expected call of AddContact.
+func (mr *MockemergencyServiceMockRecorder) AddContact(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContact", reflect.TypeOf((*MockemergencyService)(nil).AddContact), arg0, arg1)
+}
+
+// DenyAccess mocks base method.
+func (m *MockemergencyService) DenyAccess(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DenyAccess", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DenyAccess indicates an expected call of DenyAccess.
+func (mr *MockemergencyServiceMockRecorder) DenyAccess(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DenyAccess", reflect.TypeOf((*MockemergencyService)(nil).DenyAccess), arg0, arg1)
+}
+
+// GetVault mocks base method.
+func (m *MockemergencyService) GetVault(arg0 context.Context, arg1 string) (*models.EmergencyVault, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVault", arg0, arg1)
+	ret0, _ := ret[0].(*models.EmergencyVault)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVault indicates an expected call of GetVault.
+func (mr *MockemergencyServiceMockRecorder) GetVault(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVault", reflect.TypeOf((*MockemergencyService)(nil).GetVault), arg0, arg1)
+}
+
+// ListContacts mocks base method.
+func (m *MockemergencyService) ListContacts(arg0 context.Context) ([]models.EmergencyContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListContacts", arg0)
+	ret0, _ := ret[0].([]models.EmergencyContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListContacts indicates an expected call of ListContacts.
+func (mr *MockemergencyServiceMockRecorder) ListContacts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContacts", reflect.TypeOf((*MockemergencyService)(nil).ListContacts), arg0)
+}
+
+// ListGrants mocks base method.
+func (m *MockemergencyService) ListGrants(arg0 context.Context) ([]models.EmergencyContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGrants", arg0)
+	ret0, _ := ret[0].([]models.EmergencyContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGrants indicates an expected call of ListGrants.
+func (mr *MockemergencyServiceMockRecorder) ListGrants(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGrants", reflect.TypeOf((*MockemergencyService)(nil).ListGrants), arg0)
+}
+
+// RequestAccess mocks base method.
+func (m *MockemergencyService) RequestAccess(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestAccess", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestAccess indicates an expected call of RequestAccess.
+func (mr *MockemergencyServiceMockRecorder) RequestAccess(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestAccess", reflect.TypeOf((*MockemergencyService)(nil).RequestAccess), arg0, arg1)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: orghandler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/gokeep/shared/models"
+)
+
+// MockorgService is a mock of orgService interface.
+type MockorgService struct {
+	ctrl     *gomock.Controller
+	recorder *MockorgServiceMockRecorder
+}
+
+// MockorgServiceMockRecorder is the mock recorder for MockorgService.
+type MockorgServiceMockRecorder struct {
+	mock *MockorgService
+}
+
+// NewMockorgService creates a new mock instance.
+func NewMockorgService(ctrl *gomock.Controller) *MockorgService {
+	mock := &MockorgService{ctrl: ctrl}
+	mock.recorder = &MockorgServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorgService) EXPECT() *MockorgServiceMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockorgService) AddMember(arg0 context.Context, arg1 models.OrgID, arg2 string, arg3 models.Role, arg4 []models.CollectionKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockorgServiceMockRecorder) AddMember(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockorgService)(nil).AddMember), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CreateCollection mocks base method.
+func (m *MockorgService) CreateCollection(arg0 context.Context, arg1 *models.Collection, arg2 []models.CollectionKey) (*models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockorgServiceMockRecorder) CreateCollection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockorgService)(nil).CreateCollection), arg0, arg1, arg2)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockorgService) CreateOrganization(arg0 context.Context, arg1 string, arg2 *models.Collection) (*models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockorgServiceMockRecorder) CreateOrganization(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockorgService)(nil).CreateOrganization), arg0, arg1, arg2)
+}
+
+// ListCollections mocks base method.
+func (m *MockorgService) ListCollections(arg0 context.Context) ([]models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollections", arg0)
+	ret0, _ := ret[0].([]models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollections indicates an expected call of ListCollections.
+func (mr *MockorgServiceMockRecorder) ListCollections(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollections", reflect.TypeOf((*MockorgService)(nil).ListCollections), arg0)
+}
+
+// ListMembers mocks base method.
+func (m *MockorgService) ListMembers(arg0 context.Context, arg1 models.OrgID) ([]models.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", arg0, arg1)
+	ret0, _ := ret[0].([]models.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockorgServiceMockRecorder) ListMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockorgService)(nil).ListMembers), arg0, arg1)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sharehandler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/gokeep/shared/models"
+)
+
+// MockshareService is a mock of shareService interface.
+type MockshareService struct {
+	ctrl     *gomock.Controller
+	recorder *MockshareServiceMockRecorder
+}
+
+// MockshareServiceMockRecorder is the mock recorder for MockshareService.
+type MockshareServiceMockRecorder struct {
+	mock *MockshareService
+}
+
+// NewMockshareService creates a new mock instance.
+func NewMockshareService(ctrl *gomock.Controller) *MockshareService {
+	mock := &MockshareService{ctrl: ctrl}
+	mock.recorder = &MockshareServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockshareService) EXPECT() *MockshareServiceMockRecorder {
+	return m.recorder
+}
+
+// ListSharedWithMe mocks base method.
+func (m *MockshareService) ListSharedWithMe(arg0 context.Context) ([]models.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSharedWithMe", arg0)
+	ret0, _ := ret[0].([]models.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSharedWithMe indicates an expected call of ListSharedWithMe.
+func (mr *MockshareServiceMockRecorder) ListSharedWithMe(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSharedWithMe", reflect.TypeOf((*MockshareService)(nil).ListSharedWithMe), arg0)
+}
+
+// RevokeShare mocks base method.
+func (m *MockshareService) RevokeShare(arg0 context.Context, arg1 models.ItemID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShare", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockshareServiceMockRecorder) RevokeShare(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockshareService)(nil).RevokeShare), arg0, arg1, arg2)
+}
+
+// ShareItem mocks base method.
+func (m *MockshareService) ShareItem(arg0 context.Context, arg1 *models.Share) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareItem", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShareItem indicates an expected call of ShareItem.
+func (mr *MockshareServiceMockRecorder) ShareItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareItem", reflect.TypeOf((*MockshareService)(nil).ShareItem), arg0, arg1)
+}
@@ -50,6 +50,20 @@ func (mr *MockuserServiceMockRecorder) AuthUser(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthUser", reflect.TypeOf((*MockuserService)(nil).AuthUser), arg0, arg1)
 }
 
+// ChangePassword mocks base method.
+func (m *MockuserService) ChangePassword(arg0 context.Context, arg1 *models.PasswordChangeReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockuserServiceMockRecorder) ChangePassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockuserService)(nil).ChangePassword), arg0, arg1)
+}
+
 // CreateUser mocks base method.
 func (m *MockuserService) CreateUser(arg0 context.Context, arg1 *models.UserRegReq) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +79,64 @@ func (mr *MockuserServiceMockRecorder) CreateUser(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockuserService)(nil).CreateUser), arg0, arg1)
 }
 
+// DeleteAccount mocks base method.
+func (m *MockuserService) DeleteAccount(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockuserServiceMockRecorder) DeleteAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockuserService)(nil).DeleteAccount), arg0, arg1)
+}
+
+// GetPublicKey mocks base method.
+func (m *MockuserService) GetPublicKey(arg0 context.Context, arg1 string) (*models.PublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicKey", arg0, arg1)
+	ret0, _ := ret[0].(*models.PublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicKey indicates an expected call of GetPublicKey.
+func (mr *MockuserServiceMockRecorder) GetPublicKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKey", reflect.TypeOf((*MockuserService)(nil).GetPublicKey), arg0, arg1)
+}
+
+// RecoverUser mocks base method.
+func (m *MockuserService) RecoverUser(arg0 context.Context, arg1 *models.UserRecoverReq) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoverUser", arg0, arg1)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecoverUser indicates an expected call of RecoverUser.
+func (mr *MockuserServiceMockRecorder) RecoverUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverUser", reflect.TypeOf((*MockuserService)(nil).RecoverUser), arg0, arg1)
+}
+
+// SetKeyPair mocks base method.
+func (m *MockuserService) SetKeyPair(arg0 context.Context, arg1 *models.KeyPair) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKeyPair", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKeyPair indicates an expected call of SetKeyPair.
+func (mr *MockuserServiceMockRecorder) SetKeyPair(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeyPair", reflect.TypeOf((*MockuserService)(nil).SetKeyPair), arg0, arg1)
+}
+
 // MockauthProvider is a mock of authProvider interface.
 type MockauthProvider struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: watchhandler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/gokeep/shared/models"
+)
+
+// MockwatchService is a mock of watchService interface.
+type MockwatchService struct {
+	ctrl     *gomock.Controller
+	recorder *MockwatchServiceMockRecorder
+}
+
+// MockwatchServiceMockRecorder is the mock recorder for MockwatchService.
+type MockwatchServiceMockRecorder struct {
+	mock *MockwatchService
+}
+
+// NewMockwatchService creates a new mock instance.
+func NewMockwatchService(ctrl *gomock.Controller) *MockwatchService {
+	mock := &MockwatchService{ctrl: ctrl}
+	mock.recorder = &MockwatchServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwatchService) EXPECT() *MockwatchServiceMockRecorder {
+	return m.recorder
+}
+
+// Watch mocks base method.
+func (m *MockwatchService) Watch(arg0 context.Context, arg1 func(models.CollectionID) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockwatchServiceMockRecorder) Watch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockwatchService)(nil).Watch), arg0, arg1)
+}
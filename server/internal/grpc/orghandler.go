@@ -0,0 +1,182 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/shared/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// orgService defines the required domain operations for organizations
+type orgService interface {
+	CreateOrganization(context.Context, string, *models.Collection) (*models.Collection, error)
+	CreateCollection(context.Context, *models.Collection, []models.CollectionKey) (*models.Collection, error)
+	AddMember(context.Context, models.OrgID, string, models.Role, []models.CollectionKey) error
+	ListMembers(context.Context, models.OrgID) ([]models.Member, error)
+	ListCollections(context.Context) ([]models.Collection, error)
+}
+
+// CreateOrganization handles organization creation requests
+func (h *GophKeeperServer) CreateOrganization(
+	ctx context.Context,
+	req *pb.CreateOrganizationRequest,
+) (*pb.CreateOrganizationResponse, error) {
+	if req.Name == "" || req.CollectionName == "" || len(req.WrappedKey) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "name, collection name and wrapped key are required")
+	}
+
+	col := &models.Collection{
+		Name:       req.CollectionName,
+		WrappedKey: req.WrappedKey,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	col, err := h.org.CreateOrganization(ctx, req.Name, col)
+	if err != nil {
+		return nil, errStatus(ctx, err, orgErrCode(err))
+	}
+
+	return &pb.CreateOrganizationResponse{
+		OrgId:        string(col.OrgID),
+		CollectionId: string(col.ID),
+	}, nil
+}
+
+// CreateCollection handles collection creation requests
+func (h *GophKeeperServer) CreateCollection(
+	ctx context.Context,
+	req *pb.CreateCollectionRequest,
+) (*pb.CreateCollectionResponse, error) {
+	if req.OrgId == "" || req.Name == "" || len(req.Keys) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "organization id, name and keys are required")
+	}
+
+	col := &models.Collection{
+		OrgID: models.OrgID(req.OrgId),
+		Name:  req.Name,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	col, err := h.org.CreateCollection(ctx, col, collectionKeys(req.Keys))
+	if err != nil {
+		return nil, errStatus(ctx, err, orgErrCode(err))
+	}
+
+	return &pb.CreateCollectionResponse{
+		CollectionId: string(col.ID),
+	}, nil
+}
+
+// AddMember handles organization membership requests
+func (h *GophKeeperServer) AddMember(ctx context.Context, req *pb.AddMemberRequest) (*pb.AddMemberResponse, error) {
+	role := models.Role(req.Role)
+	if req.OrgId == "" || req.Username == "" || !role.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "organization id, username and valid role are required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	err := h.org.AddMember(ctx, models.OrgID(req.OrgId), req.Username, role, collectionKeys(req.Keys))
+	if err != nil {
+		return nil, errStatus(ctx, err, orgErrCode(err))
+	}
+
+	return &pb.AddMemberResponse{}, nil
+}
+
+// ListMembers handles organization members requests
+func (h *GophKeeperServer) ListMembers(ctx context.Context, req *pb.ListMembersRequest) (*pb.ListMembersResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	members, err := h.org.ListMembers(ctx, models.OrgID(req.OrgId))
+	if err != nil {
+		return nil, errStatus(ctx, err, orgErrCode(err))
+	}
+
+	var resmembers = make([]*pb.Member, len(members))
+	for i, member := range members {
+		resmembers[i] = &pb.Member{
+			UserId:    string(member.UserID),
+			Username:  member.Username,
+			Role:      string(member.Role),
+			PublicKey: member.PublicKey,
+		}
+	}
+
+	return &pb.ListMembersResponse{
+		Members: resmembers,
+	}, nil
+}
+
+// ListCollections handles requests for collections available to the caller
+func (h *GophKeeperServer) ListCollections(
+	ctx context.Context,
+	_ *pb.ListCollectionsRequest,
+) (*pb.ListCollectionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	cols, err := h.org.ListCollections(ctx)
+	if err != nil {
+		return nil, errStatus(ctx, err, codes.Internal)
+	}
+
+	var rescols = make([]*pb.Collection, len(cols))
+	for i, col := range cols {
+		rescols[i] = &pb.Collection{
+			Id:         string(col.ID),
+			OrgId:      string(col.OrgID),
+			OrgName:    col.OrgName,
+			Name:       col.Name,
+			Role:       string(col.Role),
+			WrappedKey: col.WrappedKey,
+		}
+	}
+
+	return &pb.ListCollectionsResponse{
+		Collections: rescols,
+	}, nil
+}
+
+// collectionKeys converts collection keys from request
+func collectionKeys(reqkeys []*pb.CollectionKey) []models.CollectionKey {
+	var keys = make([]models.CollectionKey, len(reqkeys))
+	for i, reqkey := range reqkeys {
+		keys[i] = models.CollectionKey{
+			CollectionID: models.CollectionID(reqkey.CollectionId),
+			UserID:       models.UserID(reqkey.UserId),
+			WrappedKey:   reqkey.WrappedKey,
+		}
+	}
+	return keys
+}
+
+// orgErrCode maps role violations to PermissionDenied and missing users to NotFound
+func orgErrCode(err error) codes.Code {
+	var forbidden interface{ IsErrForbidden() bool }
+	var noMember interface{ IsErrNoMember() bool }
+	var noUser interface{ IsErrNoUser() bool }
+	var conflict interface{ IsErrMemberConflict() bool }
+
+	switch {
+	case errors.As(err, &forbidden), errors.As(err, &noMember):
+		return codes.PermissionDenied
+	case errors.As(err, &noUser):
+		return codes.NotFound
+	case errors.As(err, &conflict):
+		return codes.AlreadyExists
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,225 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/server/internal/grpc/mocks"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testOrgID        = "550e8400-e29b-41d4-a716-446655440004"
+	testCollectionID = "550e8400-e29b-41d4-a716-446655440003"
+)
+
+// testForbiddenErr mimics role violation errors of services
+type testForbiddenErr struct{}
+
+func (testForbiddenErr) Error() string        { return "forbidden" }
+func (testForbiddenErr) IsErrForbidden() bool { return true }
+
+// testConflictErr mimics membership conflict errors of storage
+type testConflictErr struct{}
+
+func (testConflictErr) Error() string             { return "conflict" }
+func (testConflictErr) IsErrMemberConflict() bool { return true }
+
+func TestGophKeeperServer_CreateOrganization(t *testing.T) {
+	t.Run("successful creation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrg := mocks.NewMockorgService(ctrl)
+		handler := NewGophKeeperServer(Services{Org: mockOrg}, testTimeout)
+
+		mockOrg.EXPECT().
+			CreateOrganization(gomock.Any(), "team", &models.Collection{Name: "shared", WrappedKey: []byte("wrapped")}).
+			Return(&models.Collection{ID: testCollectionID, OrgID: testOrgID}, nil)
+
+		resp, err := handler.CreateOrganization(context.Background(), &gophkeeper.CreateOrganizationRequest{
+			Name:           "team",
+			CollectionName: "shared",
+			WrappedKey:     []byte("wrapped"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, testOrgID, resp.OrgId)
+		assert.Equal(t, testCollectionID, resp.CollectionId)
+	})
+
+	t.Run("missing fields", func(t *testing.T) {
+		handler := NewGophKeeperServer(Services{}, testTimeout)
+
+		_, err := handler.CreateOrganization(context.Background(), &gophkeeper.CreateOrganizationRequest{Name: "team"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestGophKeeperServer_CreateCollection(t *testing.T) {
+	req := &gophkeeper.CreateCollectionRequest{
+		OrgId: testOrgID,
+		Name:  "ops",
+		Keys:  []*gophkeeper.CollectionKey{{UserId: "user1", WrappedKey: []byte("wrapped")}},
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrg := mocks.NewMockorgService(ctrl)
+		handler := NewGophKeeperServer(Services{Org: mockOrg}, testTimeout)
+
+		mockOrg.EXPECT().
+			CreateCollection(
+				gomock.Any(),
+				&models.Collection{OrgID: testOrgID, Name: "ops"},
+				[]models.CollectionKey{{UserID: "user1", WrappedKey: []byte("wrapped")}},
+			).
+			Return(&models.Collection{ID: testCollectionID}, nil)
+
+		resp, err := handler.CreateCollection(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, testCollectionID, resp.CollectionId)
+	})
+
+	t.Run("role violation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrg := mocks.NewMockorgService(ctrl)
+		handler := NewGophKeeperServer(Services{Org: mockOrg}, testTimeout)
+
+		mockOrg.EXPECT().
+			CreateCollection(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, testForbiddenErr{})
+
+		_, err := handler.CreateCollection(context.Background(), req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestGophKeeperServer_AddMember(t *testing.T) {
+	req := &gophkeeper.AddMemberRequest{
+		OrgId:    testOrgID,
+		Username: "bob",
+		Role:     "member",
+		Keys:     []*gophkeeper.CollectionKey{{CollectionId: testCollectionID, WrappedKey: []byte("wrapped")}},
+	}
+
+	t.Run("successful addition", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrg := mocks.NewMockorgService(ctrl)
+		handler := NewGophKeeperServer(Services{Org: mockOrg}, testTimeout)
+
+		mockOrg.EXPECT().
+			AddMember(
+				gomock.Any(),
+				models.OrgID(testOrgID),
+				"bob",
+				models.RoleMember,
+				[]models.CollectionKey{{CollectionID: testCollectionID, WrappedKey: []byte("wrapped")}},
+			).
+			Return(nil)
+
+		_, err := handler.AddMember(context.Background(), req)
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		handler := NewGophKeeperServer(Services{}, testTimeout)
+
+		_, err := handler.AddMember(context.Background(), &gophkeeper.AddMemberRequest{
+			OrgId:    testOrgID,
+			Username: "bob",
+			Role:     "guest",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("already a member", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrg := mocks.NewMockorgService(ctrl)
+		handler := NewGophKeeperServer(Services{Org: mockOrg}, testTimeout)
+
+		mockOrg.EXPECT().
+			AddMember(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(testConflictErr{})
+
+		_, err := handler.AddMember(context.Background(), req)
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+}
+
+func TestGophKeeperServer_ListMembers(t *testing.T) {
+	t.Run("successful listing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrg := mocks.NewMockorgService(ctrl)
+		handler := NewGophKeeperServer(Services{Org: mockOrg}, testTimeout)
+
+		mockOrg.EXPECT().
+			ListMembers(gomock.Any(), models.OrgID(testOrgID)).
+			Return([]models.Member{{UserID: "user1", Username: "alice", Role: models.RoleOwner, PublicKey: []byte("pub")}}, nil)
+
+		resp, err := handler.ListMembers(context.Background(), &gophkeeper.ListMembersRequest{OrgId: testOrgID})
+		require.NoError(t, err)
+		require.Len(t, resp.Members, 1)
+		assert.Equal(t, "alice", resp.Members[0].Username)
+		assert.Equal(t, "owner", resp.Members[0].Role)
+		assert.Equal(t, []byte("pub"), resp.Members[0].PublicKey)
+	})
+}
+
+func TestGophKeeperServer_ListCollections(t *testing.T) {
+	t.Run("successful listing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrg := mocks.NewMockorgService(ctrl)
+		handler := NewGophKeeperServer(Services{Org: mockOrg}, testTimeout)
+
+		mockOrg.EXPECT().
+			ListCollections(gomock.Any()).
+			Return([]models.Collection{{
+				ID:         testCollectionID,
+				OrgID:      testOrgID,
+				OrgName:    "team",
+				Name:       "shared",
+				Role:       models.RoleReadOnly,
+				WrappedKey: []byte("wrapped"),
+			}}, nil)
+
+		resp, err := handler.ListCollections(context.Background(), &gophkeeper.ListCollectionsRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Collections, 1)
+		assert.Equal(t, "team", resp.Collections[0].OrgName)
+		assert.Equal(t, "readonly", resp.Collections[0].Role)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrg := mocks.NewMockorgService(ctrl)
+		handler := NewGophKeeperServer(Services{Org: mockOrg}, testTimeout)
+
+		mockOrg.EXPECT().
+			ListCollections(gomock.Any()).
+			Return(nil, errors.New("db down"))
+
+		_, err := handler.ListCollections(context.Background(), &gophkeeper.ListCollectionsRequest{})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
@@ -10,24 +10,42 @@ import (
 // GophKeeperServer implements the gRPC server interface.
 type GophKeeperServer struct {
 	pb.UnimplementedGophKeeperServer
-	user    userService
-	sync    syncService
-	auth    authProvider
-	timeout time.Duration
+	user      userService
+	sync      syncService
+	share     shareService
+	org       orgService
+	emergency emergencyService
+	audit     auditService
+	watch     watchService
+	auth      authProvider
+	timeout   time.Duration
+}
+
+// Services holds business logic used by the server.
+// Handlers of a service left nil must not be called.
+type Services struct {
+	User      userService
+	Sync      syncService
+	Share     shareService
+	Org       orgService
+	Emergency emergencyService
+	Audit     auditService
+	Watch     watchService
+	Auth      authProvider
 }
 
 // NewGophKeeperServer constructs a new gRPC server instance with required dependencies.
 // Returns configured server ready for registration with gRPC
-func NewGophKeeperServer(
-	user userService,
-	sync syncService,
-	auth authProvider,
-	timeout time.Duration,
-) *GophKeeperServer {
+func NewGophKeeperServer(svc Services, timeout time.Duration) *GophKeeperServer {
 	return &GophKeeperServer{
-		user:    user,
-		sync:    sync,
-		auth:    auth,
-		timeout: timeout,
+		user:      svc.User,
+		sync:      svc.Sync,
+		share:     svc.Share,
+		org:       svc.Org,
+		emergency: svc.Emergency,
+		audit:     svc.Audit,
+		watch:     svc.Watch,
+		auth:      svc.Auth,
+		timeout:   timeout,
 	}
 }
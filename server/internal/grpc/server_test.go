@@ -15,13 +15,17 @@ func TestNewGophKeeperServer(t *testing.T) {
 
 	mockUser := mocks.NewMockuserService(ctrl)
 	mockSync := mocks.NewMocksyncService(ctrl)
+	mockShare := mocks.NewMockshareService(ctrl)
+	mockOrg := mocks.NewMockorgService(ctrl)
 	mockAuth := mocks.NewMockauthProvider(ctrl)
 
 	t.Run("should create new server instance", func(t *testing.T) {
-		server := NewGophKeeperServer(mockUser, mockSync, mockAuth, testTimeout)
+		server := NewGophKeeperServer(Services{User: mockUser, Sync: mockSync, Share: mockShare, Org: mockOrg, Auth: mockAuth}, testTimeout)
 		assert.NotNil(t, server)
 		assert.Equal(t, mockUser, server.user)
 		assert.Equal(t, mockSync, server.sync)
+		assert.Equal(t, mockShare, server.share)
+		assert.Equal(t, mockOrg, server.org)
 		assert.Equal(t, testTimeout, server.timeout)
 	})
 }
@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/shared/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// shareService defines the required domain operations for item sharing
+type shareService interface {
+	ShareItem(context.Context, *models.Share) error
+	ListSharedWithMe(context.Context) ([]models.Share, error)
+	RevokeShare(context.Context, models.ItemID, string) error
+}
+
+// ShareItem handles item sharing requests
+func (h *GophKeeperServer) ShareItem(ctx context.Context, req *pb.ShareItemRequest) (*pb.ShareItemResponse, error) {
+	if req.ItemId == "" || req.Recipient == "" || len(req.WrappedKey) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "item id, recipient and wrapped key are required")
+	}
+
+	share := &models.Share{
+		ItemID:     models.ItemID(req.ItemId),
+		Recipient:  req.Recipient,
+		WrappedKey: req.WrappedKey,
+		Payload:    req.Payload,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	err := h.share.ShareItem(ctx, share)
+	if err != nil {
+		return nil, errStatus(ctx, err, shareErrCode(err))
+	}
+
+	return &pb.ShareItemResponse{}, nil
+}
+
+// ListSharedWithMe handles requests for items shared with the caller
+func (h *GophKeeperServer) ListSharedWithMe(ctx context.Context, _ *pb.ListSharedRequest) (*pb.ListSharedResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	shares, err := h.share.ListSharedWithMe(ctx)
+	if err != nil {
+		return nil, errStatus(ctx, err, codes.Internal)
+	}
+
+	var resitems = make([]*pb.SharedItem, len(shares))
+	for i, share := range shares {
+		resitems[i] = &pb.SharedItem{
+			ItemId:     string(share.ItemID),
+			Owner:      share.Owner,
+			WrappedKey: share.WrappedKey,
+			Payload:    share.Payload,
+			SharedAt:   timestamppb.New(share.SharedAt),
+		}
+	}
+
+	return &pb.ListSharedResponse{
+		Items: resitems,
+	}, nil
+}
+
+// RevokeShare handles share revocation requests
+func (h *GophKeeperServer) RevokeShare(ctx context.Context, req *pb.RevokeShareRequest) (*pb.RevokeShareResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	err := h.share.RevokeShare(ctx, models.ItemID(req.ItemId), req.Recipient)
+	if err != nil {
+		return nil, errStatus(ctx, err, shareErrCode(err))
+	}
+
+	return &pb.RevokeShareResponse{}, nil
+}
+
+// shareErrCode maps missing users, items and shares to NotFound
+func shareErrCode(err error) codes.Code {
+	var noUser interface{ IsErrNoUser() bool }
+	var noItem interface{ IsErrNoItem() bool }
+	var noShare interface{ IsErrNoShare() bool }
+
+	switch {
+	case errors.As(err, &noUser), errors.As(err, &noItem), errors.As(err, &noShare):
+		return codes.NotFound
+	default:
+		return codes.Internal
+	}
+}
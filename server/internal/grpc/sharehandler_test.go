@@ -0,0 +1,185 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/server/internal/grpc/mocks"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testItemID = "550e8400-e29b-41d4-a716-446655440001"
+
+// testNotFoundErr mimics structured storage errors
+type testNotFoundErr struct{}
+
+func (testNotFoundErr) Error() string     { return "not found" }
+func (testNotFoundErr) IsErrNoItem() bool { return true }
+
+func TestGophKeeperServer_ShareItem(t *testing.T) {
+	testReq := &gophkeeper.ShareItemRequest{
+		ItemId:     testItemID,
+		Recipient:  "recipient",
+		WrappedKey: []byte("wrapped"),
+		Payload:    []byte("payload"),
+	}
+
+	expectedShare := &models.Share{
+		ItemID:     testItemID,
+		Recipient:  "recipient",
+		WrappedKey: []byte("wrapped"),
+		Payload:    []byte("payload"),
+	}
+
+	t.Run("successful share", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockShare := mocks.NewMockshareService(ctrl)
+		handler := NewGophKeeperServer(Services{Share: mockShare}, testTimeout)
+
+		mockShare.EXPECT().
+			ShareItem(gomock.Any(), expectedShare).
+			DoAndReturn(func(ctx context.Context, _ *models.Share) error {
+				_, ok := ctx.Deadline()
+				assert.True(t, ok, "context should have deadline")
+				return nil
+			})
+
+		resp, err := handler.ShareItem(context.Background(), testReq)
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+	})
+
+	t.Run("missing fields", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockShare := mocks.NewMockshareService(ctrl)
+		handler := NewGophKeeperServer(Services{Share: mockShare}, testTimeout)
+
+		_, err := handler.ShareItem(context.Background(), &gophkeeper.ShareItemRequest{ItemId: testItemID})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("item not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockShare := mocks.NewMockshareService(ctrl)
+		handler := NewGophKeeperServer(Services{Share: mockShare}, testTimeout)
+
+		mockShare.EXPECT().
+			ShareItem(gomock.Any(), expectedShare).
+			Return(testNotFoundErr{})
+
+		_, err := handler.ShareItem(context.Background(), testReq)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockShare := mocks.NewMockshareService(ctrl)
+		handler := NewGophKeeperServer(Services{Share: mockShare}, testTimeout)
+
+		mockShare.EXPECT().
+			ShareItem(gomock.Any(), expectedShare).
+			Return(errors.New("test error"))
+
+		_, err := handler.ShareItem(context.Background(), testReq)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestGophKeeperServer_ListSharedWithMe(t *testing.T) {
+	t.Run("successful listing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockShare := mocks.NewMockshareService(ctrl)
+		handler := NewGophKeeperServer(Services{Share: mockShare}, testTimeout)
+
+		sharedAt := time.Now().UTC()
+		mockShare.EXPECT().
+			ListSharedWithMe(gomock.Any()).
+			Return([]models.Share{{
+				ItemID:     testItemID,
+				Owner:      "owner",
+				WrappedKey: []byte("wrapped"),
+				Payload:    []byte("payload"),
+				SharedAt:   sharedAt,
+			}}, nil)
+
+		resp, err := handler.ListSharedWithMe(context.Background(), &gophkeeper.ListSharedRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 1)
+		assert.Equal(t, testItemID, resp.Items[0].ItemId)
+		assert.Equal(t, "owner", resp.Items[0].Owner)
+		assert.Equal(t, []byte("wrapped"), resp.Items[0].WrappedKey)
+		assert.Equal(t, []byte("payload"), resp.Items[0].Payload)
+		assert.True(t, sharedAt.Equal(resp.Items[0].SharedAt.AsTime()))
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockShare := mocks.NewMockshareService(ctrl)
+		handler := NewGophKeeperServer(Services{Share: mockShare}, testTimeout)
+
+		mockShare.EXPECT().
+			ListSharedWithMe(gomock.Any()).
+			Return(nil, errors.New("test error"))
+
+		_, err := handler.ListSharedWithMe(context.Background(), &gophkeeper.ListSharedRequest{})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestGophKeeperServer_RevokeShare(t *testing.T) {
+	testReq := &gophkeeper.RevokeShareRequest{
+		ItemId:    testItemID,
+		Recipient: "recipient",
+	}
+
+	t.Run("successful revoke", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockShare := mocks.NewMockshareService(ctrl)
+		handler := NewGophKeeperServer(Services{Share: mockShare}, testTimeout)
+
+		mockShare.EXPECT().
+			RevokeShare(gomock.Any(), models.ItemID(testItemID), "recipient").
+			Return(nil)
+
+		resp, err := handler.RevokeShare(context.Background(), testReq)
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+	})
+
+	t.Run("share not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockShare := mocks.NewMockshareService(ctrl)
+		handler := NewGophKeeperServer(Services{Share: mockShare}, testTimeout)
+
+		mockShare.EXPECT().
+			RevokeShare(gomock.Any(), models.ItemID(testItemID), "recipient").
+			Return(testNotFoundErr{})
+
+		_, err := handler.RevokeShare(context.Background(), testReq)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
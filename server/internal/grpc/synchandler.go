@@ -2,11 +2,11 @@ package grpc
 
 import (
 	"context"
+	"errors"
 
 	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
 	"github.com/rycln/gokeep/shared/models"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -23,6 +23,7 @@ func (h *GophKeeperServer) Sync(ctx context.Context, req *pb.SyncRequest) (*pb.S
 	for i, reqitem := range req.Items {
 		clientitems[i].ID = models.ItemID(reqitem.Id)
 		clientitems[i].UserID = models.UserID(reqitem.UserId)
+		clientitems[i].CollectionID = models.CollectionID(reqitem.CollectionId)
 		clientitems[i].ItemType = models.ItemType(reqitem.Type)
 		clientitems[i].Name = reqitem.Name
 		clientitems[i].Metadata = reqitem.Metadata
@@ -36,7 +37,7 @@ func (h *GophKeeperServer) Sync(ctx context.Context, req *pb.SyncRequest) (*pb.S
 
 	serveritems, err := h.sync.SyncItems(ctx, clientitems)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, syncErrStatus(ctx, err)
 	}
 
 	var resitems = make([]*pb.Item, len(serveritems))
@@ -44,6 +45,7 @@ func (h *GophKeeperServer) Sync(ctx context.Context, req *pb.SyncRequest) (*pb.S
 		var resitem = &pb.Item{}
 		resitem.Id = string(serveritem.ID)
 		resitem.UserId = string(serveritem.UserID)
+		resitem.CollectionId = string(serveritem.CollectionID)
 		resitem.Type = string(serveritem.ItemType)
 		resitem.Name = serveritem.Name
 		resitem.Metadata = serveritem.Metadata
@@ -58,3 +60,13 @@ func (h *GophKeeperServer) Sync(ctx context.Context, req *pb.SyncRequest) (*pb.S
 		Items: resitems,
 	}, nil
 }
+
+// syncErrStatus maps sync errors to gRPC status
+// Collection errors are mapped like organization ones
+func syncErrStatus(ctx context.Context, err error) error {
+	var quota interface{ IsErrQuota() bool }
+	if errors.As(err, &quota) {
+		return newStatus(codes.ResourceExhausted, models.ReasonQuotaExceeded, err.Error())
+	}
+	return errStatus(ctx, err, orgErrCode(err))
+}
@@ -25,10 +25,8 @@ func TestGophKeeperServer_Sync(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		mockUser := mocks.NewMockuserService(ctrl)
 		mockSync := mocks.NewMocksyncService(ctrl)
-		mockAuth := mocks.NewMockauthProvider(ctrl)
-		handler := NewGophKeeperServer(mockUser, mockSync, mockAuth, testTimeout)
+		handler := NewGophKeeperServer(Services{Sync: mockSync}, testTimeout)
 
 		req := &pb.SyncRequest{
 			Items: []*pb.Item{
@@ -94,10 +92,8 @@ func TestGophKeeperServer_Sync(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		mockUser := mocks.NewMockuserService(ctrl)
 		mockSync := mocks.NewMocksyncService(ctrl)
-		mockAuth := mocks.NewMockauthProvider(ctrl)
-		handler := NewGophKeeperServer(mockUser, mockSync, mockAuth, testTimeout)
+		handler := NewGophKeeperServer(Services{Sync: mockSync}, testTimeout)
 
 		req := &pb.SyncRequest{Items: []*pb.Item{}}
 
@@ -115,10 +111,8 @@ func TestGophKeeperServer_Sync(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		mockUser := mocks.NewMockuserService(ctrl)
 		mockSync := mocks.NewMocksyncService(ctrl)
-		mockAuth := mocks.NewMockauthProvider(ctrl)
-		handler := NewGophKeeperServer(mockUser, mockSync, mockAuth, testTimeout)
+		handler := NewGophKeeperServer(Services{Sync: mockSync}, testTimeout)
 
 		req := &pb.SyncRequest{
 			Items: []*pb.Item{{Id: "item1"}},
@@ -133,6 +127,46 @@ func TestGophKeeperServer_Sync(t *testing.T) {
 		require.Error(t, err)
 		assert.Nil(t, resp)
 		assert.Equal(t, codes.Internal, status.Code(err))
-		assert.Contains(t, err.Error(), expectedErr.Error())
+		assert.NotContains(t, err.Error(), expectedErr.Error())
+	})
+
+	t.Run("role violation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSync := mocks.NewMocksyncService(ctrl)
+		handler := NewGophKeeperServer(Services{Sync: mockSync}, testTimeout)
+
+		req := &pb.SyncRequest{
+			Items: []*pb.Item{{Id: "item1", CollectionId: "col1", UpdatedAt: timestamppb.New(now)}},
+		}
+
+		mockSync.EXPECT().
+			SyncItems(gomock.Any(), []models.Item{{ID: "item1", CollectionID: "col1", UpdatedAt: now}}).
+			Return(nil, testForbiddenErr{})
+
+		_, err := handler.Sync(context.Background(), req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+	t.Run("quota exceeded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSync := mocks.NewMocksyncService(ctrl)
+		handler := NewGophKeeperServer(Services{Sync: mockSync}, testTimeout)
+
+		mockSync.EXPECT().
+			SyncItems(gomock.Any(), gomock.Any()).
+			Return(nil, testQuotaErr{})
+
+		_, err := handler.Sync(context.Background(), &pb.SyncRequest{Items: []*pb.Item{{Id: "item1"}}})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, models.ReasonQuotaExceeded, errorReason(t, err))
 	})
 }
+
+// testQuotaErr mimics the quota error of the sync service
+type testQuotaErr struct{}
+
+func (testQuotaErr) Error() string    { return "storage quota exceeded" }
+func (testQuotaErr) IsErrQuota() bool { return true }
@@ -2,20 +2,28 @@ package grpc
 
 import (
 	"context"
-	"strings"
+	"errors"
+	"time"
 
 	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
 	"github.com/rycln/gokeep/shared/models"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 //go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
 
 // userService defines the required domain operations for user management
 type userService interface {
-	CreateUser(context.Context, *models.UserRegReq) (*models.User, error) // User registration
-	AuthUser(context.Context, *models.UserLoginReq) (*models.User, error) // User authentication
+	CreateUser(context.Context, *models.UserRegReq) (*models.User, error)      // User registration
+	AuthUser(context.Context, *models.UserLoginReq) (*models.User, error)      // User authentication
+	RecoverUser(context.Context, *models.UserRecoverReq) (*models.User, error) // Recovery key authentication
+	ChangePassword(context.Context, *models.PasswordChangeReq) error           // Credentials rotation
+	DeleteAccount(context.Context, string) error                               // Account removal
+	SetKeyPair(context.Context, *models.KeyPair) error                         // Sharing keypair upload
+	GetPublicKey(context.Context, string) (*models.PublicKey, error)           // Recipient key lookup
 }
 
 // authProvider defines authentication middleware function
@@ -29,9 +37,16 @@ func (h *GophKeeperServer) Register(
 	req *pb.RegisterRequest,
 ) (*pb.AuthResponse, error) {
 	authReq := &models.UserRegReq{
-		Username: req.Username,
-		Password: req.Password,
-		Salt:     req.Salt,
+		Username:     req.Username,
+		Password:     req.Password,
+		Salt:         req.Salt,
+		EncryptedKey: req.EncryptedKey,
+		RecoveryKey:  req.RecoveryKey,
+		RecoveryAuth: req.RecoveryAuth,
+		KeyPair: models.KeyPair{
+			PublicKey:           req.PublicKey,
+			EncryptedPrivateKey: req.EncryptedPrivateKey,
+		},
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, h.timeout)
@@ -39,16 +54,53 @@ func (h *GophKeeperServer) Register(
 
 	user, err := h.user.CreateUser(ctx, authReq)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, registerErrStatus(ctx, err)
 	}
 
 	return &pb.AuthResponse{
-		UserId: string(user.ID),
-		Token:  user.JWT,
-		Salt:   req.Salt,
+		UserId:              string(user.ID),
+		Token:               user.JWT,
+		Salt:                req.Salt,
+		EncryptedKey:        user.EncryptedKey,
+		PublicKey:           user.PublicKey,
+		EncryptedPrivateKey: user.EncryptedPrivateKey,
 	}, nil
 }
 
+// registerErrStatus maps registration errors to gRPC status
+// Rejected fields are reported as BadRequest violations so clients can point at them
+func registerErrStatus(ctx context.Context, err error) error {
+	var validationErr *models.ValidationError
+	var conflictErr interface{ IsErrUsernameConflict() bool }
+	switch {
+	case errors.As(err, &validationErr):
+		return newStatus(codes.InvalidArgument, models.ReasonInvalidRequest, err.Error(),
+			badRequest(validationErr.Violations))
+	case errors.As(err, &conflictErr) && conflictErr.IsErrUsernameConflict():
+		return newStatus(codes.AlreadyExists, models.ReasonUsernameTaken, err.Error(),
+			badRequest([]models.FieldViolation{{
+				Field:       models.FieldUsername,
+				Reason:      models.ReasonUsernameTaken,
+				Description: err.Error(),
+			}}))
+	default:
+		return errStatus(ctx, err, codes.Internal)
+	}
+}
+
+// badRequest converts field violations to BadRequest detail
+func badRequest(violations []models.FieldViolation) *errdetails.BadRequest {
+	badReq := &errdetails.BadRequest{}
+	for _, v := range violations {
+		badReq.FieldViolations = append(badReq.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Reason:      v.Reason,
+			Description: v.Description,
+		})
+	}
+	return badReq
+}
+
 // Login handles user authentication requests
 func (h *GophKeeperServer) Login(
 	ctx context.Context,
@@ -64,22 +116,217 @@ func (h *GophKeeperServer) Login(
 
 	user, err := h.user.AuthUser(ctx, authReq)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, loginErrStatus(ctx, err)
 	}
 
 	return &pb.AuthResponse{
-		UserId: string(user.ID),
-		Token:  user.JWT,
-		Salt:   user.Salt,
+		UserId:              string(user.ID),
+		Token:               user.JWT,
+		Salt:                user.Salt,
+		EncryptedKey:        user.EncryptedKey,
+		PublicKey:           user.PublicKey,
+		EncryptedPrivateKey: user.EncryptedPrivateKey,
 	}, nil
 }
 
+// loginErrStatus maps authentication errors to gRPC status
+// Unknown usernames and wrong secrets get the same Unauthenticated status,
+// so clients can't tell which accounts exist. Locked out usernames get
+// ResourceExhausted with retry delay detail, disabled accounts get PermissionDenied
+func loginErrStatus(ctx context.Context, err error) error {
+	var disabledErr interface{ IsErrDisabled() bool }
+	var lockedErr interface{ RetryAfter() time.Duration }
+	switch {
+	case isInvalidCredentials(err):
+		return newStatus(codes.Unauthenticated, models.ReasonInvalidCredentials, msgInvalidCredentials)
+	case errors.As(err, &disabledErr):
+		return newStatus(codes.PermissionDenied, models.ReasonAccountDisabled, err.Error())
+	case errors.As(err, &lockedErr):
+		return newStatus(codes.ResourceExhausted, models.ReasonTooManyAttempts, err.Error(), &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(lockedErr.RetryAfter()),
+		})
+	default:
+		return errStatus(ctx, err, codes.Internal)
+	}
+}
+
+// msgInvalidCredentials is the uniform message of rejected credentials
+const msgInvalidCredentials = "invalid username or password"
+
+// isInvalidCredentials reports whether err means unknown username or wrong password or recovery key
+func isInvalidCredentials(err error) bool {
+	var noUserErr interface{ IsErrNoUser() bool }
+	var wrongErr interface{ IsErrWrongPassword() bool }
+	var noRecoveryErr interface{ IsErrNoRecovery() bool }
+	return errors.As(err, &noUserErr) || errors.As(err, &wrongErr) || errors.As(err, &noRecoveryErr)
+}
+
+// Recover handles account recovery requests
+func (h *GophKeeperServer) Recover(
+	ctx context.Context,
+	req *pb.RecoverRequest,
+) (*pb.AuthResponse, error) {
+	recoverReq := &models.UserRecoverReq{
+		Username:     req.Username,
+		RecoveryAuth: req.RecoveryAuth,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	user, err := h.user.RecoverUser(ctx, recoverReq)
+	if err != nil {
+		return nil, loginErrStatus(ctx, err)
+	}
+
+	return &pb.AuthResponse{
+		UserId:              string(user.ID),
+		Token:               user.JWT,
+		Salt:                user.Salt,
+		RecoveryKey:         user.RecoveryKey,
+		PublicKey:           user.PublicKey,
+		EncryptedPrivateKey: user.EncryptedPrivateKey,
+	}, nil
+}
+
+// ChangePassword handles credentials rotation requests
+func (h *GophKeeperServer) ChangePassword(
+	ctx context.Context,
+	req *pb.ChangePasswordRequest,
+) (*pb.ChangePasswordResponse, error) {
+	if req.CurrentPassword == "" && req.RecoveryAuth == "" {
+		return nil, status.Error(codes.InvalidArgument, "current password or recovery key is required")
+	}
+
+	changeReq := &models.PasswordChangeReq{
+		Password:        req.Password,
+		Salt:            req.Salt,
+		EncryptedKey:    req.EncryptedKey,
+		CurrentPassword: req.CurrentPassword,
+		RecoveryAuth:    req.RecoveryAuth,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	err := h.user.ChangePassword(ctx, changeReq)
+	if err != nil {
+		return nil, reauthErrStatus(ctx, err)
+	}
+
+	return &pb.ChangePasswordResponse{}, nil
+}
+
+// DeleteAccount handles account removal requests
+func (h *GophKeeperServer) DeleteAccount(
+	ctx context.Context,
+	req *pb.DeleteAccountRequest,
+) (*pb.DeleteAccountResponse, error) {
+	if req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "password is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	err := h.user.DeleteAccount(ctx, req.Password)
+	if err != nil {
+		return nil, reauthErrStatus(ctx, err)
+	}
+
+	return &pb.DeleteAccountResponse{}, nil
+}
+
+// reauthErrStatus maps errors of operations confirmed with a secret to gRPC status
+// Wrong password or recovery key is reported with the reason of a failed login,
+// the code stays InvalidArgument because the session itself is valid.
+// A new password rejected by the policy is reported like on registration
+func reauthErrStatus(ctx context.Context, err error) error {
+	var validationErr *models.ValidationError
+	var noUserErr interface{ IsErrNoUser() bool }
+	var wrongErr interface{ IsErrWrongPassword() bool }
+	var noRecoveryErr interface{ IsErrNoRecovery() bool }
+	switch {
+	case errors.As(err, &validationErr):
+		return newStatus(codes.InvalidArgument, models.ReasonInvalidRequest, err.Error(),
+			badRequest(validationErr.Violations))
+	case errors.As(err, &noUserErr) && noUserErr.IsErrNoUser():
+		return errStatus(ctx, err, codes.NotFound)
+	case errors.As(err, &wrongErr), errors.As(err, &noRecoveryErr):
+		return newStatus(codes.InvalidArgument, models.ReasonInvalidCredentials, msgInvalidCredentials)
+	default:
+		return errStatus(ctx, err, codes.Internal)
+	}
+}
+
+// SetKeyPair handles sharing keypair upload requests
+func (h *GophKeeperServer) SetKeyPair(
+	ctx context.Context,
+	req *pb.KeyPairRequest,
+) (*pb.KeyPairResponse, error) {
+	kp := &models.KeyPair{
+		PublicKey:           req.PublicKey,
+		EncryptedPrivateKey: req.EncryptedPrivateKey,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	err := h.user.SetKeyPair(ctx, kp)
+	if err != nil {
+		return nil, errStatus(ctx, err, keyPairErrCode(err))
+	}
+
+	return &pb.KeyPairResponse{}, nil
+}
+
+// keyPairErrCode maps keypair upload errors to gRPC codes
+// An existing keypair is never replaced, uploads after the first one are rejected
+func keyPairErrCode(err error) codes.Code {
+	var emptyErr interface{ IsErrEmptyKeyPair() bool }
+	var existsErr interface{ IsErrKeyPairExists() bool }
+	switch {
+	case errors.As(err, &emptyErr):
+		return codes.InvalidArgument
+	case errors.As(err, &existsErr):
+		return codes.AlreadyExists
+	default:
+		return codes.Internal
+	}
+}
+
+// GetPublicKey handles recipient public key requests
+func (h *GophKeeperServer) GetPublicKey(
+	ctx context.Context,
+	req *pb.PublicKeyRequest,
+) (*pb.PublicKeyResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	pk, err := h.user.GetPublicKey(ctx, req.Username)
+	if err != nil {
+		return nil, errStatus(ctx, err, shareErrCode(err))
+	}
+
+	return &pb.PublicKeyResponse{
+		UserId:    string(pk.UserID),
+		PublicKey: pk.Key,
+	}, nil
+}
+
+// publicMethods lists methods called before the user has a token
+var publicMethods = map[string]bool{
+	pb.GophKeeper_Register_FullMethodName: true,
+	pb.GophKeeper_Login_FullMethodName:    true,
+	pb.GophKeeper_Recover_FullMethodName:  true,
+}
+
 // AuthFuncOverride provides authentication middleware hook
 func (s *GophKeeperServer) AuthFuncOverride(
 	ctx context.Context,
 	fullMethodName string,
 ) (context.Context, error) {
-	if strings.Contains(fullMethodName, "Register") || strings.Contains(fullMethodName, "Login") {
+	if publicMethods[fullMethodName] {
 		return ctx, nil
 	}
 
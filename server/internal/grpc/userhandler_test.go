@@ -12,6 +12,7 @@ import (
 	"github.com/rycln/gokeep/shared/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -41,9 +42,7 @@ func TestGophKeeperServer_Register(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockUser := mocks.NewMockuserService(ctrl)
-		mockSync := mocks.NewMocksyncService(ctrl)
-		mockAuth := mocks.NewMockauthProvider(ctrl)
-		handler := NewGophKeeperServer(mockUser, mockSync, mockAuth, testTimeout)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
 
 		expectedUser := &models.User{
 			ID:   models.UserID(testUserID),
@@ -72,9 +71,7 @@ func TestGophKeeperServer_Register(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockUser := mocks.NewMockuserService(ctrl)
-		mockSync := mocks.NewMocksyncService(ctrl)
-		mockAuth := mocks.NewMockauthProvider(ctrl)
-		handler := NewGophKeeperServer(mockUser, mockSync, mockAuth, testTimeout)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
 
 		testErr := errors.New("test error")
 		mockUser.EXPECT().
@@ -84,11 +81,80 @@ func TestGophKeeperServer_Register(t *testing.T) {
 		resp, err := handler.Register(context.Background(), testReq)
 		require.Error(t, err)
 		assert.Nil(t, resp)
-		assert.Equal(t, codes.InvalidArgument, status.Code(err))
-		assert.Contains(t, err.Error(), testErr.Error())
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.NotContains(t, err.Error(), testErr.Error())
+	})
+
+	t.Run("policy violations returned as field violations", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		mockUser.EXPECT().
+			CreateUser(gomock.Any(), expectedAuthReq).
+			Return(nil, &models.ValidationError{Violations: []models.FieldViolation{
+				{Field: models.FieldPassword, Reason: models.ReasonPasswordLength, Description: "too short"},
+			}})
+
+		_, err := handler.Register(context.Background(), testReq)
+		violations := badRequestViolations(t, err, codes.InvalidArgument)
+		require.Len(t, violations, 1)
+		assert.Equal(t, models.FieldPassword, violations[0].Field)
+		assert.Equal(t, models.ReasonPasswordLength, violations[0].Reason)
+	})
+
+	t.Run("taken username returned as conflict with field violation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		mockUser.EXPECT().
+			CreateUser(gomock.Any(), expectedAuthReq).
+			Return(nil, testUsernameTakenErr{})
+
+		_, err := handler.Register(context.Background(), testReq)
+		violations := badRequestViolations(t, err, codes.AlreadyExists)
+		require.Len(t, violations, 1)
+		assert.Equal(t, models.FieldUsername, violations[0].Field)
+		assert.Equal(t, models.ReasonUsernameTaken, violations[0].Reason)
 	})
 }
 
+// testUsernameTakenErr mimics username conflict errors of storage
+type testUsernameTakenErr struct{}
+
+func (testUsernameTakenErr) Error() string               { return "username already registered" }
+func (testUsernameTakenErr) IsErrUsernameConflict() bool { return true }
+
+// badRequestViolations extracts field violations of status with the given code
+func badRequestViolations(t *testing.T, err error, code codes.Code) []*errdetails.BadRequest_FieldViolation {
+	t.Helper()
+
+	st := status.Convert(err)
+	require.Equal(t, code, st.Code())
+	require.Len(t, st.Details(), 2)
+	badReq, ok := st.Details()[1].(*errdetails.BadRequest)
+	require.True(t, ok)
+	return badReq.FieldViolations
+}
+
+// errorReason extracts reason of ErrorInfo detail
+func errorReason(t *testing.T, err error) string {
+	t.Helper()
+
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	t.Fatal("status has no ErrorInfo")
+	return ""
+}
+
 func TestGophKeeperServer_Login(t *testing.T) {
 	testReq := &gophkeeper.LoginRequest{
 		Username: "testuser",
@@ -105,9 +171,7 @@ func TestGophKeeperServer_Login(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockUser := mocks.NewMockuserService(ctrl)
-		mockSync := mocks.NewMocksyncService(ctrl)
-		mockAuth := mocks.NewMockauthProvider(ctrl)
-		handler := NewGophKeeperServer(mockUser, mockSync, mockAuth, testTimeout)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
 
 		expectedUser := &models.User{
 			ID:   models.UserID(testUserID),
@@ -136,9 +200,7 @@ func TestGophKeeperServer_Login(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockUser := mocks.NewMockuserService(ctrl)
-		mockSync := mocks.NewMocksyncService(ctrl)
-		mockAuth := mocks.NewMockauthProvider(ctrl)
-		handler := NewGophKeeperServer(mockUser, mockSync, mockAuth, testTimeout)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
 
 		testErr := errors.New("test error")
 		mockUser.EXPECT().
@@ -148,8 +210,297 @@ func TestGophKeeperServer_Login(t *testing.T) {
 		resp, err := handler.Login(context.Background(), testReq)
 		require.Error(t, err)
 		assert.Nil(t, resp)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.NotContains(t, err.Error(), testErr.Error())
+	})
+
+	t.Run("unknown username and wrong password are indistinguishable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		mockUser.EXPECT().
+			AuthUser(gomock.Any(), expectedAuthReq).
+			Return(nil, testNoUserErr{})
+		mockUser.EXPECT().
+			AuthUser(gomock.Any(), expectedAuthReq).
+			Return(nil, testWrongPasswordErr{})
+
+		_, noUserErr := handler.Login(context.Background(), testReq)
+		_, wrongErr := handler.Login(context.Background(), testReq)
+		assert.Equal(t, codes.Unauthenticated, status.Code(noUserErr))
+		assert.Equal(t, models.ReasonInvalidCredentials, errorReason(t, noUserErr))
+		assert.Equal(t, noUserErr.Error(), wrongErr.Error())
+	})
+
+	t.Run("timeout returns deadline exceeded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		mockUser.EXPECT().
+			AuthUser(gomock.Any(), expectedAuthReq).
+			Return(nil, context.DeadlineExceeded)
+
+		_, err := handler.Login(context.Background(), testReq)
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Equal(t, models.ReasonTimeout, errorReason(t, err))
+	})
+
+	t.Run("locked out username", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		mockUser.EXPECT().
+			AuthUser(gomock.Any(), expectedAuthReq).
+			Return(nil, testLockedErr{retry: 90 * time.Second})
+
+		_, err := handler.Login(context.Background(), testReq)
+		st := status.Convert(err)
+		assert.Equal(t, codes.ResourceExhausted, st.Code())
+		assert.Equal(t, models.ReasonTooManyAttempts, errorReason(t, err))
+		require.Len(t, st.Details(), 2)
+		retry, ok := st.Details()[1].(*errdetails.RetryInfo)
+		require.True(t, ok)
+		assert.Equal(t, 90*time.Second, retry.RetryDelay.AsDuration())
+	})
+
+	t.Run("disabled account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		mockUser.EXPECT().
+			AuthUser(gomock.Any(), expectedAuthReq).
+			Return(nil, testDisabledErr{})
+
+		_, err := handler.Login(context.Background(), testReq)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+// testLockedErr mimics login lockout errors of user service
+type testLockedErr struct{ retry time.Duration }
+
+func (testLockedErr) Error() string                 { return "locked" }
+func (err testLockedErr) RetryAfter() time.Duration { return err.retry }
+
+// testDisabledErr mimics disabled account errors of user service
+type testDisabledErr struct{}
+
+func (testDisabledErr) Error() string       { return "account is disabled" }
+func (testDisabledErr) IsErrDisabled() bool { return true }
+
+func TestGophKeeperServer_Recover(t *testing.T) {
+	testReq := &gophkeeper.RecoverRequest{
+		Username:     "testuser",
+		RecoveryAuth: "recovery_auth",
+	}
+
+	expectedRecoverReq := &models.UserRecoverReq{
+		Username:     testReq.Username,
+		RecoveryAuth: testReq.RecoveryAuth,
+	}
+
+	t.Run("successful recovery", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		expectedUser := &models.User{
+			ID:          models.UserID(testUserID),
+			JWT:         testJWT,
+			Salt:        testSalt,
+			RecoveryKey: "recovery_key",
+		}
+
+		mockUser.EXPECT().
+			RecoverUser(gomock.Any(), expectedRecoverReq).
+			Return(expectedUser, nil)
+
+		resp, err := handler.Recover(context.Background(), testReq)
+		require.NoError(t, err)
+		assert.Equal(t, testUserID, resp.UserId)
+		assert.Equal(t, testJWT, resp.Token)
+		assert.Equal(t, expectedUser.RecoveryKey, resp.RecoveryKey)
+		assert.Empty(t, resp.EncryptedKey)
+	})
+
+	t.Run("service error returns grpc error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		mockUser.EXPECT().
+			RecoverUser(gomock.Any(), expectedRecoverReq).
+			Return(nil, errors.New("test error"))
+
+		resp, err := handler.Recover(context.Background(), testReq)
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestGophKeeperServer_ChangePassword(t *testing.T) {
+	testReq := &gophkeeper.ChangePasswordRequest{
+		Password:        "newpass",
+		Salt:            testSalt,
+		EncryptedKey:    "encrypted_key",
+		CurrentPassword: "oldpass",
+	}
+
+	expectedChangeReq := &models.PasswordChangeReq{
+		Password:        testReq.Password,
+		Salt:            testReq.Salt,
+		EncryptedKey:    testReq.EncryptedKey,
+		CurrentPassword: testReq.CurrentPassword,
+	}
+
+	t.Run("proof of ownership required", func(t *testing.T) {
+		handler := NewGophKeeperServer(Services{}, testTimeout)
+
+		_, err := handler.ChangePassword(context.Background(), &gophkeeper.ChangePasswordRequest{
+			Password:     "newpass",
+			Salt:         testSalt,
+			EncryptedKey: "encrypted_key",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("wrong current password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		mockUser.EXPECT().
+			ChangePassword(gomock.Any(), expectedChangeReq).
+			Return(testWrongPasswordErr{})
+
+		_, err := handler.ChangePassword(context.Background(), testReq)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, models.ReasonInvalidCredentials, errorReason(t, err))
+	})
+
+	t.Run("weak new password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		mockUser.EXPECT().
+			ChangePassword(gomock.Any(), expectedChangeReq).
+			Return(&models.ValidationError{Violations: []models.FieldViolation{
+				{Field: models.FieldPassword, Reason: models.ReasonPasswordWeak, Description: "too weak"},
+			}})
+
+		_, err := handler.ChangePassword(context.Background(), testReq)
+		violations := badRequestViolations(t, err, codes.InvalidArgument)
+		require.Len(t, violations, 1)
+		assert.Equal(t, models.FieldPassword, violations[0].Field)
+		assert.Equal(t, models.ReasonPasswordWeak, violations[0].Reason)
+	})
+
+	t.Run("successful change", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		mockUser.EXPECT().
+			ChangePassword(gomock.Any(), expectedChangeReq).
+			Return(nil)
+
+		resp, err := handler.ChangePassword(context.Background(), testReq)
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+	})
+
+	t.Run("service error returns grpc error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		mockUser.EXPECT().
+			ChangePassword(gomock.Any(), expectedChangeReq).
+			Return(errors.New("test error"))
+
+		resp, err := handler.ChangePassword(context.Background(), testReq)
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestGophKeeperServer_DeleteAccount(t *testing.T) {
+	testReq := &gophkeeper.DeleteAccountRequest{Password: "pass"}
+
+	newHandler := func(t *testing.T) (*GophKeeperServer, *mocks.MockuserService) {
+		ctrl := gomock.NewController(t)
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser, Auth: mocks.NewMockauthProvider(ctrl)}, testTimeout)
+		return handler, mockUser
+	}
+
+	t.Run("successful deletion", func(t *testing.T) {
+		handler, mockUser := newHandler(t)
+
+		mockUser.EXPECT().
+			DeleteAccount(gomock.Any(), "pass").
+			Return(nil)
+
+		resp, err := handler.DeleteAccount(context.Background(), testReq)
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+	})
+
+	t.Run("empty password", func(t *testing.T) {
+		handler, _ := newHandler(t)
+
+		_, err := handler.DeleteAccount(context.Background(), &gophkeeper.DeleteAccountRequest{})
 		assert.Equal(t, codes.InvalidArgument, status.Code(err))
-		assert.Contains(t, err.Error(), testErr.Error())
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		handler, mockUser := newHandler(t)
+
+		mockUser.EXPECT().
+			DeleteAccount(gomock.Any(), "pass").
+			Return(testWrongPasswordErr{})
+
+		_, err := handler.DeleteAccount(context.Background(), testReq)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, models.ReasonInvalidCredentials, errorReason(t, err))
+	})
+
+	t.Run("user already deleted", func(t *testing.T) {
+		handler, mockUser := newHandler(t)
+
+		mockUser.EXPECT().
+			DeleteAccount(gomock.Any(), "pass").
+			Return(testNoUserErr{})
+
+		_, err := handler.DeleteAccount(context.Background(), testReq)
+		assert.Equal(t, codes.NotFound, status.Code(err))
 	})
 }
 
@@ -157,10 +508,8 @@ func TestGophKeeperServer_AuthFuncOverride(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockUser := mocks.NewMockuserService(ctrl)
-	mockSync := mocks.NewMocksyncService(ctrl)
 	mockAuth := mocks.NewMockauthProvider(ctrl)
-	server := NewGophKeeperServer(mockUser, mockSync, mockAuth, testTimeout)
+	server := NewGophKeeperServer(Services{Auth: mockAuth}, testTimeout)
 
 	t.Run("should bypass auth for Register method", func(t *testing.T) {
 		ctx := context.Background()
@@ -182,6 +531,16 @@ func TestGophKeeperServer_AuthFuncOverride(t *testing.T) {
 		assert.Equal(t, ctx, resultCtx)
 	})
 
+	t.Run("should bypass auth for Recover method", func(t *testing.T) {
+		ctx := context.Background()
+		fullMethodName := "/gophkeeper.GophKeeper/Recover"
+
+		resultCtx, err := server.AuthFuncOverride(ctx, fullMethodName)
+
+		assert.NoError(t, err)
+		assert.Equal(t, ctx, resultCtx)
+	})
+
 	t.Run("should handle case-insensitive method names", func(t *testing.T) {
 		testCases := []struct {
 			name       string
@@ -189,6 +548,8 @@ func TestGophKeeperServer_AuthFuncOverride(t *testing.T) {
 			shouldAuth bool
 		}{
 			{"other method", "/service/Other", true},
+			{"method containing public name", "/gophkeeper.GophKeeper/LoginHistory", true},
+			{"public name of other service", "/other.Service/Login", true},
 		}
 
 		for _, tc := range testCases {
@@ -208,3 +569,116 @@ func TestGophKeeperServer_AuthFuncOverride(t *testing.T) {
 		}
 	})
 }
+
+func TestGophKeeperServer_SetKeyPair(t *testing.T) {
+	testReq := &gophkeeper.KeyPairRequest{
+		PublicKey:           []byte("public_key"),
+		EncryptedPrivateKey: "encrypted_private_key",
+	}
+
+	expectedKeyPair := &models.KeyPair{
+		PublicKey:           testReq.PublicKey,
+		EncryptedPrivateKey: testReq.EncryptedPrivateKey,
+	}
+
+	t.Run("successful upload", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		mockUser.EXPECT().
+			SetKeyPair(gomock.Any(), expectedKeyPair).
+			Return(nil)
+
+		resp, err := handler.SetKeyPair(context.Background(), testReq)
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+	})
+
+	t.Run("service error returns grpc error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		mockUser.EXPECT().
+			SetKeyPair(gomock.Any(), expectedKeyPair).
+			Return(errors.New("test error"))
+
+		_, err := handler.SetKeyPair(context.Background(), testReq)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("existing keypair not replaced", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		mockUser.EXPECT().
+			SetKeyPair(gomock.Any(), expectedKeyPair).
+			Return(testKeyPairExistsErr{})
+
+		_, err := handler.SetKeyPair(context.Background(), testReq)
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		assert.Equal(t, models.ReasonAlreadyExists, errorReason(t, err))
+	})
+}
+
+// testKeyPairExistsErr mimics keypair conflict of the storage
+type testKeyPairExistsErr struct{}
+
+func (testKeyPairExistsErr) Error() string            { return "keypair is already set" }
+func (testKeyPairExistsErr) IsErrKeyPairExists() bool { return true }
+
+func TestGophKeeperServer_GetPublicKey(t *testing.T) {
+	testReq := &gophkeeper.PublicKeyRequest{Username: "recipient"}
+
+	t.Run("successful lookup", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		mockUser.EXPECT().
+			GetPublicKey(gomock.Any(), "recipient").
+			Return(&models.PublicKey{UserID: testUserID, Key: []byte("public_key")}, nil)
+
+		resp, err := handler.GetPublicKey(context.Background(), testReq)
+		require.NoError(t, err)
+		assert.Equal(t, testUserID, resp.UserId)
+		assert.Equal(t, []byte("public_key"), resp.PublicKey)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUser := mocks.NewMockuserService(ctrl)
+		handler := NewGophKeeperServer(Services{User: mockUser}, testTimeout)
+
+		mockUser.EXPECT().
+			GetPublicKey(gomock.Any(), "recipient").
+			Return(nil, testNoUserErr{})
+
+		_, err := handler.GetPublicKey(context.Background(), testReq)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+// testNoUserErr mimics structured storage errors
+type testNoUserErr struct{}
+
+func (testNoUserErr) Error() string     { return "user does not exist" }
+func (testNoUserErr) IsErrNoUser() bool { return true }
+
+// testWrongPasswordErr mimics password verification errors
+type testWrongPasswordErr struct{}
+
+func (testWrongPasswordErr) Error() string            { return "wrong password" }
+func (testWrongPasswordErr) IsErrWrongPassword() bool { return true }
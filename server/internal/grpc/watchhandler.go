@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/shared/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// watchService defines the required domain operations for live change notifications
+type watchService interface {
+	Watch(context.Context, func(models.CollectionID) error) error
+}
+
+// Watch streams change notifications until the client disconnects.
+// Changes made on any server instance are delivered.
+func (h *GophKeeperServer) Watch(_ *pb.WatchRequest, stream grpc.ServerStreamingServer[pb.ChangeNotification]) error {
+	err := h.watch.Watch(stream.Context(), func(cid models.CollectionID) error {
+		return stream.Send(&pb.ChangeNotification{CollectionId: string(cid)})
+	})
+
+	var revoked interface{ IsErrRevoked() bool }
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &revoked):
+		return errStatus(stream.Context(), err, codes.Unauthenticated)
+	default:
+		return errStatus(stream.Context(), err, codes.Internal)
+	}
+}
@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/server/internal/grpc/mocks"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testWatchStream records notifications sent to the client
+type testWatchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*gophkeeper.ChangeNotification
+}
+
+func (s *testWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testWatchStream) Send(n *gophkeeper.ChangeNotification) error {
+	s.sent = append(s.sent, n)
+	return nil
+}
+
+// testRevokedErr mimics revoked session error of the service
+type testRevokedErr struct{}
+
+func (testRevokedErr) Error() string      { return "session was revoked" }
+func (testRevokedErr) IsErrRevoked() bool { return true }
+
+func TestGophKeeperServer_Watch(t *testing.T) {
+	t.Run("notifications sent until client leaves", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockWatch := mocks.NewMockwatchService(ctrl)
+		handler := NewGophKeeperServer(Services{Watch: mockWatch}, testTimeout)
+		stream := &testWatchStream{ctx: context.Background()}
+
+		mockWatch.EXPECT().
+			Watch(stream.ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, notify func(models.CollectionID) error) error {
+				_ = notify("")
+				_ = notify("collection")
+				return context.Canceled
+			})
+
+		err := handler.Watch(&gophkeeper.WatchRequest{}, stream)
+		assert.Equal(t, codes.Canceled, status.Code(err))
+		assert.Equal(t, []*gophkeeper.ChangeNotification{{}, {CollectionId: "collection"}}, stream.sent)
+	})
+
+	t.Run("revoked session", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockWatch := mocks.NewMockwatchService(ctrl)
+		handler := NewGophKeeperServer(Services{Watch: mockWatch}, testTimeout)
+
+		mockWatch.EXPECT().Watch(gomock.Any(), gomock.Any()).Return(testRevokedErr{})
+
+		err := handler.Watch(&gophkeeper.WatchRequest{}, &testWatchStream{ctx: context.Background()})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockWatch := mocks.NewMockwatchService(ctrl)
+		handler := NewGophKeeperServer(Services{Watch: mockWatch}, testTimeout)
+
+		mockWatch.EXPECT().Watch(gomock.Any(), gomock.Any()).Return(errors.New("subscribe failed"))
+
+		err := handler.Watch(&gophkeeper.WatchRequest{}, &testWatchStream{ctx: context.Background()})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
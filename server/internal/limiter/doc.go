@@ -0,0 +1,2 @@
+// Package limiter provides in-memory request limiting for authentication endpoints.
+package limiter
@@ -0,0 +1,97 @@
+package limiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testClock is a manually advanced clock
+type testClock struct{ t time.Time }
+
+func (c *testClock) now() time.Time { return c.t }
+
+func TestWindow_Allow(t *testing.T) {
+	t.Run("limit per key and window", func(t *testing.T) {
+		clock := &testClock{t: time.Now()}
+		l := NewWindow(2, time.Minute)
+		l.now = clock.now
+
+		for range 2 {
+			ok, _ := l.Allow("ip:192.0.2.1")
+			assert.True(t, ok)
+		}
+
+		clock.t = clock.t.Add(10 * time.Second)
+		ok, retry := l.Allow("ip:192.0.2.1")
+		assert.False(t, ok)
+		assert.Equal(t, 50*time.Second, retry)
+
+		ok, _ = l.Allow("ip:192.0.2.2")
+		assert.True(t, ok, "other keys are not affected")
+
+		clock.t = clock.t.Add(time.Minute)
+		ok, _ = l.Allow("ip:192.0.2.1")
+		assert.True(t, ok, "new window started")
+	})
+
+	t.Run("expired windows removed", func(t *testing.T) {
+		clock := &testClock{t: time.Now()}
+		l := NewWindow(1, time.Minute)
+		l.now = clock.now
+
+		l.Allow("a")
+		clock.t = clock.t.Add(2 * time.Minute)
+		l.Allow("b")
+
+		assert.NotContains(t, l.windows, "a")
+	})
+
+	t.Run("zero limit disables limiting", func(t *testing.T) {
+		l := NewWindow(0, time.Minute)
+		for range 100 {
+			ok, _ := l.Allow("key")
+			assert.True(t, ok)
+		}
+	})
+}
+
+func TestLockout(t *testing.T) {
+	t.Run("exponential lockout after threshold", func(t *testing.T) {
+		clock := &testClock{t: time.Now()}
+		l := NewLockout(3, time.Minute, 5*time.Minute)
+		l.now = clock.now
+
+		assert.Zero(t, l.Fail("user"))
+		assert.Zero(t, l.Fail("user"))
+		assert.Zero(t, l.Locked("user"))
+
+		assert.Equal(t, time.Minute, l.Fail("user"))
+		assert.Equal(t, time.Minute, l.Locked("user"))
+
+		clock.t = clock.t.Add(time.Minute)
+		assert.Zero(t, l.Locked("user"))
+
+		assert.Equal(t, 2*time.Minute, l.Fail("user"))
+		assert.Equal(t, 4*time.Minute, l.Fail("user"))
+		assert.Equal(t, 5*time.Minute, l.Fail("user"), "capped by max")
+		assert.Zero(t, l.Locked("other"))
+	})
+
+	t.Run("success resets failures", func(t *testing.T) {
+		l := NewLockout(2, time.Minute, time.Hour)
+
+		l.Fail("user")
+		l.Reset("user")
+		assert.Zero(t, l.Fail("user"))
+	})
+
+	t.Run("zero threshold disables lockout", func(t *testing.T) {
+		l := NewLockout(0, time.Minute, time.Hour)
+		for range 10 {
+			assert.Zero(t, l.Fail("user"))
+		}
+		assert.Zero(t, l.Locked("user"))
+	})
+}
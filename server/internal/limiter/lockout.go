@@ -0,0 +1,105 @@
+package limiter
+
+import (
+	"sync"
+	"time"
+)
+
+// failures holds failed attempts of a key
+type failures struct {
+	count int
+	until time.Time // End of the current lockout
+	last  time.Time // Time of the last failure
+}
+
+// Lockout tracks failed attempts and locks keys out with exponential backoff.
+// The first lockout starts when threshold failures in a row are reached,
+// each further failure doubles it up to max. Zero threshold disables lockouts.
+type Lockout struct {
+	mu        sync.Mutex
+	threshold int
+	base      time.Duration
+	max       time.Duration
+	keys      map[string]*failures
+	sweep     time.Time // Next removal of forgotten keys
+	now       func() time.Time
+}
+
+// NewLockout creates lockout tracker with given backoff parameters.
+func NewLockout(threshold int, base, max time.Duration) *Lockout {
+	return &Lockout{
+		threshold: threshold,
+		base:      base,
+		max:       max,
+		keys:      make(map[string]*failures),
+		now:       time.Now,
+	}
+}
+
+// Locked returns remaining lockout of the key, zero when attempts are allowed.
+func (l *Lockout) Locked(key string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, ok := l.keys[key]
+	if !ok {
+		return 0
+	}
+	return max(f.until.Sub(l.now()), 0)
+}
+
+// Fail records failed attempt of the key.
+// Returns lockout started by this failure, zero if attempts are still allowed.
+func (l *Lockout) Fail(key string) time.Duration {
+	if l.threshold <= 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.removeForgotten(now)
+
+	f, ok := l.keys[key]
+	if !ok {
+		f = &failures{}
+		l.keys[key] = f
+	}
+	f.count++
+	f.last = now
+
+	if f.count < l.threshold {
+		return 0
+	}
+
+	d := l.base
+	for i := l.threshold; i < f.count && d < l.max; i++ {
+		d *= 2
+	}
+	d = min(d, l.max)
+	f.until = now.Add(d)
+
+	return d
+}
+
+// Reset forgets failures of the key after successful attempt.
+func (l *Lockout) Reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.keys, key)
+}
+
+// removeForgotten drops keys without failures for the max lockout period
+func (l *Lockout) removeForgotten(now time.Time) {
+	if now.Before(l.sweep) {
+		return
+	}
+	for key, f := range l.keys {
+		if now.Sub(f.last) > l.max && !now.Before(f.until) {
+			delete(l.keys, key)
+		}
+	}
+	l.sweep = now.Add(l.max)
+}
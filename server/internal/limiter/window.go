@@ -0,0 +1,73 @@
+package limiter
+
+import (
+	"sync"
+	"time"
+)
+
+// window holds request count of a key in the current period
+type window struct {
+	count int
+	reset time.Time
+}
+
+// Window implements fixed window rate limiting per key.
+// Zero limit disables limiting.
+type Window struct {
+	mu      sync.Mutex
+	limit   int
+	period  time.Duration
+	windows map[string]*window
+	sweep   time.Time // Next removal of expired windows
+	now     func() time.Time
+}
+
+// NewWindow creates limiter allowing limit requests per key in each period.
+func NewWindow(limit int, period time.Duration) *Window {
+	return &Window{
+		limit:   limit,
+		period:  period,
+		windows: make(map[string]*window),
+		now:     time.Now,
+	}
+}
+
+// Allow counts request of the key.
+// Returns false with time until the window resets when the limit is exceeded.
+func (l *Window) Allow(key string) (bool, time.Duration) {
+	if l.limit <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.removeExpired(now)
+
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.reset) {
+		w = &window{reset: now.Add(l.period)}
+		l.windows[key] = w
+	}
+
+	if w.count >= l.limit {
+		return false, w.reset.Sub(now)
+	}
+	w.count++
+
+	return true, 0
+}
+
+// removeExpired drops finished windows once per period to bound memory usage
+func (l *Window) removeExpired(now time.Time) {
+	if now.Before(l.sweep) {
+		return
+	}
+	for key, w := range l.windows {
+		if !now.Before(w.reset) {
+			delete(l.windows, key)
+		}
+	}
+	l.sweep = now.Add(l.period)
+}
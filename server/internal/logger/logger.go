@@ -3,26 +3,58 @@
 package logger
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/rycln/gokeep/server/internal/contextkeys"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Log formats
+const (
+	// FormatConsole is the human-readable development format
+	FormatConsole = "console"
+
+	// FormatJSON writes one JSON object per line for log collectors
+	FormatJSON = "json"
 )
 
+// RequestIDField is the log field of the request ID.
+const RequestIDField = "request_id"
+
 // Log is the global logger instance implementing the Logger interface.
 var Log *zap.Logger = zap.NewNop()
 
+// level is shared by all loggers built by LogInit and can be changed at runtime.
+var level = zap.NewAtomicLevel()
+
 // LogInit configures the global Log instance.
-func LogInit(level string) error {
-	lvl, err := zap.ParseAtomicLevel(level)
+// Every built logger masks secrets, see Redact.
+func LogInit(lvl, format string) error {
+	err := SetLevel(lvl)
 	if err != nil {
 		return err
 	}
 
-	cfg := zap.NewDevelopmentConfig()
-	cfg.Level = lvl
-	if cfg.Level.Level() != zap.DebugLevel {
-		cfg.DisableCaller = true
+	var cfg zap.Config
+	switch format {
+	case FormatConsole, "":
+		cfg = zap.NewDevelopmentConfig()
+		if level.Level() != zap.DebugLevel {
+			cfg.DisableCaller = true
+		}
+	case FormatJSON:
+		cfg = zap.NewProductionConfig()
+		cfg.Sampling = nil
+		cfg.EncoderConfig.TimeKey = "time"
+		cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	default:
+		return fmt.Errorf("unknown log format %q", format)
 	}
+	cfg.Level = level
 
-	zl, err := cfg.Build()
+	zl, err := cfg.Build(zap.WrapCore(Redact))
 	if err != nil {
 		return err
 	}
@@ -30,3 +62,22 @@ func LogInit(level string) error {
 	Log = zl
 	return nil
 }
+
+// SetLevel changes level of the global Log without rebuilding it.
+func SetLevel(lvl string) error {
+	l, err := zapcore.ParseLevel(lvl)
+	if err != nil {
+		return err
+	}
+
+	level.SetLevel(l)
+	return nil
+}
+
+// FromContext returns the global Log with the request ID of the context attached.
+func FromContext(ctx context.Context) *zap.Logger {
+	if id, ok := ctx.Value(contextkeys.RequestID).(string); ok {
+		return Log.With(zap.String(RequestIDField, id))
+	}
+	return Log
+}
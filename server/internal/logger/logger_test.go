@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/rycln/gokeep/pkg/gen/grpc/gophkeeper"
+	"github.com/rycln/gokeep/server/internal/contextkeys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogInit(t *testing.T) {
+	defer func() { Log = zap.NewNop() }()
+
+	t.Run("json format", func(t *testing.T) {
+		require.NoError(t, LogInit("info", FormatJSON))
+	})
+
+	t.Run("console format", func(t *testing.T) {
+		require.NoError(t, LogInit("info", FormatConsole))
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		assert.Error(t, LogInit("info", "xml"))
+	})
+}
+
+func TestRedact(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	log := zap.New(Redact(core))
+
+	t.Run("sensitive fields masked", func(t *testing.T) {
+		log.With(zap.String("token", "jwt")).Info("msg", zap.String("password", "secret"), zap.String("username", "alice"))
+
+		fields := logs.TakeAll()[0].ContextMap()
+		assert.Equal(t, redacted, fields["token"])
+		assert.Equal(t, redacted, fields["password"])
+		assert.Equal(t, "alice", fields["username"])
+	})
+
+	t.Run("secrets of messages masked at any depth", func(t *testing.T) {
+		req := &pb.SyncRequest{Items: []*pb.Item{{Id: "item1", Data: []byte("encrypted"), Metadata: "meta"}}}
+		log.Info("msg", zap.Any("grpc.request.content", &pb.LoginRequest{Username: "alice", Password: "secret"}))
+		log.Info("msg", zap.Any("grpc.request.content", req))
+
+		entries := logs.TakeAll()
+		assert.Equal(t, map[string]any{"username": "alice", "password": redacted}, entries[0].ContextMap()["grpc.request.content"])
+
+		items := entries[1].ContextMap()["grpc.request.content"].(map[string]any)["items"].([]any)
+		assert.Equal(t, map[string]any{"id": "item1", "data": redacted, "metadata": redacted}, items[0])
+		assert.Equal(t, []byte("encrypted"), req.Items[0].Data, "logged message unchanged")
+	})
+
+	t.Run("secrets matched by name suffix", func(t *testing.T) {
+		log.Info("msg", zap.Any("grpc.request.content", &pb.ChangePasswordRequest{
+			Password:        "new",
+			Salt:            "salt",
+			EncryptedKey:    "key",
+			CurrentPassword: "current",
+			RecoveryAuth:    "auth",
+		}))
+
+		assert.Equal(t, map[string]any{
+			"password":         redacted,
+			"salt":             redacted,
+			"encrypted_key":    redacted,
+			"current_password": redacted,
+			"recovery_auth":    redacted,
+		}, logs.TakeAll()[0].ContextMap()["grpc.request.content"])
+	})
+}
+
+func TestFromContext(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	Log = zap.New(core)
+	defer func() { Log = zap.NewNop() }()
+
+	t.Run("request id attached", func(t *testing.T) {
+		FromContext(context.WithValue(context.Background(), contextkeys.RequestID, "req-1")).Info("msg")
+		assert.Equal(t, "req-1", logs.TakeAll()[0].ContextMap()[RequestIDField])
+	})
+
+	t.Run("no request id", func(t *testing.T) {
+		FromContext(context.Background()).Info("msg")
+		assert.NotContains(t, logs.TakeAll()[0].ContextMap(), RequestIDField)
+	})
+}
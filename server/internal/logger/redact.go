@@ -0,0 +1,126 @@
+package logger
+
+import (
+	"encoding/json"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// redacted replaces masked values
+const redacted = "[REDACTED]"
+
+// sensitive lists field names of secrets and encrypted user data,
+// both of log fields and of protobuf message fields
+var sensitive = map[string]bool{
+	"token":         true,
+	"authorization": true,
+	"salt":          true,
+	"data":          true,
+	"metadata":      true,
+	"payload":       true,
+}
+
+// sensitiveSuffixes mask fields by the ending of their name,
+// so new passwords, keys and verifiers are masked without listing them
+var sensitiveSuffixes = []string{"password", "_key", "_auth", "_token"}
+
+// isSensitive reports whether values of the field name must be masked
+func isSensitive(name string) bool {
+	if sensitive[name] {
+		return true
+	}
+	for _, suffix := range sensitiveSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactCore masks sensitive fields before they reach the wrapped core
+type redactCore struct {
+	zapcore.Core
+}
+
+// Redact wraps the core, so sensitive fields are masked and protobuf
+// messages are logged as JSON objects with sensitive fields masked.
+func Redact(core zapcore.Core) zapcore.Core {
+	return &redactCore{Core: core}
+}
+
+// With adds masked fields to the core
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(redactFields(fields))}
+}
+
+// Check adds the core itself, so Write is called on the masking core
+func (c *redactCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+// Write passes the entry with masked fields to the wrapped core
+func (c *redactCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, redactFields(fields))
+}
+
+// redactFields returns fields with sensitive values masked
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		switch {
+		case isSensitive(f.Key):
+			out[i] = zap.String(f.Key, redacted)
+		case isMessage(f):
+			out[i] = zap.Any(f.Key, redactMessage(f.Interface.(proto.Message)))
+		default:
+			out[i] = f
+		}
+	}
+	return out
+}
+
+// isMessage reports whether the field holds a protobuf message
+func isMessage(f zapcore.Field) bool {
+	_, ok := f.Interface.(proto.Message)
+	return ok
+}
+
+// redactMessage converts the message to JSON values with sensitive fields masked
+func redactMessage(m proto.Message) any {
+	raw, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return redacted
+	}
+
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return redacted
+	}
+	return redactValue(v)
+}
+
+// redactValue masks sensitive keys of decoded JSON objects at any depth
+func redactValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, x := range t {
+			if isSensitive(k) {
+				t[k] = redacted
+			} else {
+				t[k] = redactValue(x)
+			}
+		}
+	case []any:
+		for i, x := range t {
+			t[i] = redactValue(x)
+		}
+	}
+	return v
+}
@@ -0,0 +1,2 @@
+// Package metrics provides Prometheus instrumentation of the server.
+package metrics
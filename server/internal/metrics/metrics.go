@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+)
+
+// namespace prefixes all exported metric names
+const namespace = "gophkeeper"
+
+// Metrics holds server collectors and the registry they are exposed from.
+type Metrics struct {
+	registry     *prometheus.Registry
+	requests     *prometheus.CounterVec
+	latency      *prometheus.HistogramVec
+	syncBytes    *prometheus.HistogramVec
+	syncItems    *prometheus.HistogramVec
+	authFailures *prometheus.CounterVec
+}
+
+// New creates server metrics with Go runtime, process and DB pool collectors.
+// DB pool collector is skipped when db is nil.
+func New(db *sql.DB) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_requests_total",
+			Help:      "Handled gRPC requests by method and status code.",
+		}, []string{"method", "code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_request_duration_seconds",
+			Help:      "gRPC request handling latency by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		syncBytes: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "sync_payload_bytes",
+			Help:      "Size of sync payloads by direction.",
+			Buckets:   prometheus.ExponentialBuckets(256, 4, 10),
+		}, []string{"direction"}),
+		syncItems: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "sync_items",
+			Help:      "Number of items in sync payloads by direction.",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+		}, []string{"direction"}),
+		authFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "auth_failures_total",
+			Help:      "Failed authentication attempts by method and status code.",
+		}, []string{"method", "code"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.latency,
+		m.syncBytes,
+		m.syncItems,
+		m.authFailures,
+	)
+
+	if db != nil {
+		m.registry.MustRegister(collectors.NewDBStatsCollector(db, namespace))
+	}
+
+	return m
+}
+
+// ObserveRequest records a handled gRPC request.
+func (m *Metrics) ObserveRequest(method string, code codes.Code, d time.Duration) {
+	m.requests.WithLabelValues(method, code.String()).Inc()
+	m.latency.WithLabelValues(method).Observe(d.Seconds())
+}
+
+// ObserveSync records size and item count of a sync payload.
+func (m *Metrics) ObserveSync(direction string, bytes, items int) {
+	m.syncBytes.WithLabelValues(direction).Observe(float64(bytes))
+	m.syncItems.WithLabelValues(direction).Observe(float64(items))
+}
+
+// AuthFailure records a rejected authentication attempt.
+func (m *Metrics) AuthFailure(method string, code codes.Code) {
+	m.authFailures.WithLabelValues(method, code.String()).Inc()
+}
+
+// Handler returns HTTP handler exposing metrics in Prometheus format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestMetrics(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	m := New(db)
+
+	t.Run("request observed", func(t *testing.T) {
+		m.ObserveRequest("Login", codes.OK, 10*time.Millisecond)
+		m.ObserveRequest("Login", codes.OK, 20*time.Millisecond)
+
+		assert.Equal(t, 2.0, testutil.ToFloat64(m.requests.WithLabelValues("Login", "OK")))
+		assert.Equal(t, 1, testutil.CollectAndCount(m.latency))
+	})
+
+	t.Run("auth failure counted", func(t *testing.T) {
+		m.AuthFailure("Login", codes.InvalidArgument)
+
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.authFailures.WithLabelValues("Login", "InvalidArgument")))
+	})
+
+	t.Run("sync payload observed", func(t *testing.T) {
+		m.ObserveSync("push", 1024, 3)
+
+		assert.Equal(t, 1, testutil.CollectAndCount(m.syncBytes))
+		assert.Equal(t, 1, testutil.CollectAndCount(m.syncItems))
+	})
+
+	t.Run("metrics exposed over http", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		body := rec.Body.String()
+		assert.Contains(t, body, "gophkeeper_grpc_requests_total")
+		assert.Contains(t, body, "go_sql_open_connections")
+		assert.Contains(t, body, "go_goroutines")
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("no pool metrics without db", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		New(nil).Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "go_sql_open_connections")
+	})
+}
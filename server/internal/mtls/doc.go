@@ -0,0 +1,2 @@
+// Package mtls verifies client certificates of managed devices.
+package mtls
@@ -0,0 +1,106 @@
+package mtls
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// DeviceMetadataKey carries device of an HTTP client from the REST gateway to the gRPC server.
+// The gateway connects with the server's own certificate, so only it can set the key.
+const DeviceMetadataKey = "x-gophkeeper-device"
+
+// errNoCACerts is returned when the CA bundle contains no certificates
+var errNoCACerts = errors.New("no certificates in client CA bundle")
+
+// certSource provides the current server certificate, which may be rotated at runtime
+type certSource interface {
+	Certificate() *tls.Certificate
+}
+
+// Verifier checks client certificates against the configured CA bundle.
+type Verifier struct {
+	roots  *x509.CertPool
+	server certSource
+}
+
+// NewVerifier loads the CA bundle from caFile.
+// Certificate of the server itself is accepted as a client one for the REST gateway.
+func NewVerifier(caFile string, server certSource) (*Verifier, error) {
+	data, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(data) {
+		return nil, errNoCACerts
+	}
+
+	return &Verifier{
+		roots:  roots,
+		server: server,
+	}, nil
+}
+
+// VerifyPeerCertificate implements tls.Config.VerifyPeerCertificate.
+// Connections without a certificate are let through, the requirement is enforced per call.
+func (v *Verifier) VerifyPeerCertificate(raw [][]byte, _ [][]*x509.Certificate) error {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	certs := make([]*x509.Certificate, 0, len(raw))
+	for _, der := range raw {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return fmt.Errorf("bad client certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+
+	if v.IsServer(certs[0]) {
+		return nil
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return fmt.Errorf("untrusted client certificate: %w", err)
+	}
+
+	return nil
+}
+
+// IsServer reports whether cert is the current server certificate.
+func (v *Verifier) IsServer(cert *x509.Certificate) bool {
+	current := v.server.Certificate()
+	return cert != nil && current != nil && len(current.Certificate) > 0 &&
+		bytes.Equal(cert.Raw, current.Certificate[0])
+}
+
+// DeviceName maps certificate identity to a device name:
+// common name, then first DNS or URI name, then serial number.
+func DeviceName(cert *x509.Certificate) string {
+	switch {
+	case cert.Subject.CommonName != "":
+		return cert.Subject.CommonName
+	case len(cert.DNSNames) > 0:
+		return cert.DNSNames[0]
+	case len(cert.URIs) > 0:
+		return cert.URIs[0].String()
+	default:
+		return "serial:" + cert.SerialNumber.Text(16)
+	}
+}
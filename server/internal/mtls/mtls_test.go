@@ -0,0 +1,152 @@
+package mtls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCA issues client certificates
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key}
+}
+
+// issue signs a leaf certificate with the usage
+func (ca *testCA) issue(t *testing.T, name string, usage x509.ExtKeyUsage) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	return der
+}
+
+// writeBundle stores CA certificate as PEM file
+func (ca *testCA) writeBundle(t *testing.T) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0o600))
+	return file
+}
+
+// staticCert serves a fixed server certificate
+type staticCert struct{ cert tls.Certificate }
+
+func (s staticCert) Certificate() *tls.Certificate { return &s.cert }
+
+func TestNewVerifier(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewVerifier(filepath.Join(t.TempDir(), "none.pem"), staticCert{})
+		assert.Error(t, err)
+	})
+
+	t.Run("no certificates in file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(file, []byte("garbage"), 0o600))
+
+		_, err := NewVerifier(file, staticCert{})
+		assert.ErrorIs(t, err, errNoCACerts)
+	})
+}
+
+func TestVerifier_VerifyPeerCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	serverDER := newTestCA(t).cert.Raw
+
+	v, err := NewVerifier(ca.writeBundle(t), staticCert{tls.Certificate{Certificate: [][]byte{serverDER}}})
+	require.NoError(t, err)
+
+	t.Run("certificate signed by ca accepted", func(t *testing.T) {
+		assert.NoError(t, v.VerifyPeerCertificate([][]byte{ca.issue(t, "laptop-1", x509.ExtKeyUsageClientAuth)}, nil))
+	})
+
+	t.Run("no certificate let through", func(t *testing.T) {
+		assert.NoError(t, v.VerifyPeerCertificate(nil, nil))
+	})
+
+	t.Run("server certificate accepted", func(t *testing.T) {
+		assert.NoError(t, v.VerifyPeerCertificate([][]byte{serverDER}, nil))
+	})
+
+	t.Run("certificate of other ca rejected", func(t *testing.T) {
+		other := newTestCA(t)
+		assert.Error(t, v.VerifyPeerCertificate([][]byte{other.issue(t, "laptop-1", x509.ExtKeyUsageClientAuth)}, nil))
+	})
+
+	t.Run("certificate without client usage rejected", func(t *testing.T) {
+		assert.Error(t, v.VerifyPeerCertificate([][]byte{ca.issue(t, "laptop-1", x509.ExtKeyUsageServerAuth)}, nil))
+	})
+
+	t.Run("malformed certificate rejected", func(t *testing.T) {
+		assert.Error(t, v.VerifyPeerCertificate([][]byte{[]byte("bad")}, nil))
+	})
+}
+
+func TestDeviceName(t *testing.T) {
+	uri, err := url.Parse("spiffe://corp/laptop-3")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		cert *x509.Certificate
+		want string
+	}{
+		{"common name", &x509.Certificate{Subject: pkix.Name{CommonName: "laptop-1"}, DNSNames: []string{"other"}}, "laptop-1"},
+		{"dns name", &x509.Certificate{DNSNames: []string{"laptop-2.corp"}}, "laptop-2.corp"},
+		{"uri", &x509.Certificate{URIs: []*url.URL{uri}}, "spiffe://corp/laptop-3"},
+		{"serial", &x509.Certificate{SerialNumber: big.NewInt(255)}, "serial:ff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DeviceName(tt.cert))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package reload
+
+import (
+	"crypto/tls"
+	"sync/atomic"
+)
+
+// Certs holds the server certificate and swaps it on reload.
+// Established connections keep the certificate they were accepted with.
+type Certs struct {
+	cert atomic.Pointer[tls.Certificate]
+}
+
+// NewCerts loads the initial certificate.
+func NewCerts(certFile, keyFile string) (*Certs, error) {
+	c := &Certs{}
+	if err := c.Load(certFile, keyFile); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Load replaces the certificate with one read from files.
+// The current certificate is kept when files cannot be loaded.
+func (c *Certs) Load(certFile, keyFile string) error {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+
+	c.cert.Store(&cert)
+	return nil
+}
+
+// Certificate returns the current certificate.
+func (c *Certs) Certificate() *tls.Certificate {
+	return c.cert.Load()
+}
+
+// GetCertificate implements tls.Config.GetCertificate.
+func (c *Certs) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return c.cert.Load(), nil
+}
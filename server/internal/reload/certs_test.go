@@ -0,0 +1,85 @@
+package reload
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCert writes a self-signed certificate and key with the serial number
+func writeTestCert(t *testing.T, dir string, serial int64) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+// serialOf returns serial number of the leaf certificate
+func serialOf(t *testing.T, c *Certs) int64 {
+	t.Helper()
+
+	cert, err := c.GetCertificate(nil)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	return leaf.SerialNumber.Int64()
+}
+
+func TestCerts(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCert(t, dir, 1)
+
+	c, err := NewCerts(certFile, keyFile)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), serialOf(t, c))
+
+	t.Run("rotated certificate served", func(t *testing.T) {
+		certFile, keyFile := writeTestCert(t, dir, 2)
+
+		require.NoError(t, c.Load(certFile, keyFile))
+		assert.Equal(t, int64(2), serialOf(t, c))
+		assert.Same(t, c.Certificate(), c.cert.Load())
+	})
+
+	t.Run("broken files keep current certificate", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(keyFile, []byte("broken"), 0o600))
+
+		assert.Error(t, c.Load(certFile, keyFile))
+		assert.Equal(t, int64(2), serialOf(t, c))
+	})
+
+	t.Run("missing files on start", func(t *testing.T) {
+		_, err := NewCerts(filepath.Join(dir, "none.pem"), keyFile)
+		assert.Error(t, err)
+	})
+}
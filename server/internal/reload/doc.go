@@ -0,0 +1,2 @@
+// Package reload applies changed certificates and configuration to the running server.
+package reload
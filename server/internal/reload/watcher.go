@@ -0,0 +1,86 @@
+package reload
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+)
+
+// fileState identifies a version of a watched file
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher polls files and reports when any of them changes.
+// Polling is used because certificates are often replaced by renaming
+// or mounted from volumes where change notifications are unreliable.
+type Watcher struct {
+	mu       sync.Mutex
+	interval time.Duration
+	states   map[string]fileState
+}
+
+// NewWatcher creates a watcher for files, empty names are ignored.
+func NewWatcher(interval time.Duration, files ...string) *Watcher {
+	w := &Watcher{interval: interval}
+	w.SetFiles(files...)
+	return w
+}
+
+// SetFiles replaces the watched files with their current state.
+func (w *Watcher) SetFiles(files ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.states = make(map[string]fileState, len(files))
+	for _, f := range files {
+		if f != "" {
+			w.states[f] = stat(f)
+		}
+	}
+}
+
+// Run checks files every interval until context is canceled.
+// onChange is called once per check that found changes.
+func (w *Watcher) Run(ctx context.Context, onChange func()) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if w.Tick() {
+				onChange()
+			}
+		}
+	}
+}
+
+// Tick checks files once and reports whether any of them changed since the last check.
+func (w *Watcher) Tick() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	changed := false
+	for f, old := range w.states {
+		cur := stat(f)
+		if cur != old {
+			w.states[f] = cur
+			changed = true
+		}
+	}
+	return changed
+}
+
+// stat returns file state, missing files have zero state
+func stat(fname string) fileState {
+	info, err := os.Stat(fname)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{modTime: info.ModTime(), size: info.Size()}
+}
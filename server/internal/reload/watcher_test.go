@@ -0,0 +1,75 @@
+package reload
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher_Tick(t *testing.T) {
+	dir := t.TempDir()
+	fname := filepath.Join(dir, "cfg.json")
+	require.NoError(t, os.WriteFile(fname, []byte(`{}`), 0o600))
+
+	w := NewWatcher(time.Hour, fname, "")
+
+	t.Run("unchanged file", func(t *testing.T) {
+		assert.False(t, w.Tick())
+	})
+
+	t.Run("changed file reported once", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(fname, []byte(`{"log_level":"info"}`), 0o600))
+
+		assert.True(t, w.Tick())
+		assert.False(t, w.Tick())
+	})
+
+	t.Run("removed file", func(t *testing.T) {
+		require.NoError(t, os.Remove(fname))
+
+		assert.True(t, w.Tick())
+	})
+
+	t.Run("replaced files", func(t *testing.T) {
+		other := filepath.Join(dir, "other.json")
+		require.NoError(t, os.WriteFile(other, []byte(`{}`), 0o600))
+
+		w.SetFiles(other)
+		assert.False(t, w.Tick())
+	})
+}
+
+func TestWatcher_Run(t *testing.T) {
+	dir := t.TempDir()
+	fname := filepath.Join(dir, "cert.pem")
+	require.NoError(t, os.WriteFile(fname, []byte("old"), 0o600))
+
+	t.Run("change reported until canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		w := NewWatcher(time.Millisecond, fname)
+		changed := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			w.Run(ctx, func() { changed <- struct{}{} })
+			close(done)
+		}()
+
+		require.NoError(t, os.WriteFile(fname, []byte("new certificate"), 0o600))
+
+		select {
+		case <-changed:
+		case <-time.After(time.Second):
+			t.Fatal("change not reported")
+		}
+
+		cancel()
+		<-done
+	})
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+
+	"github.com/rycln/gokeep/server/internal/fanout"
+	"github.com/rycln/gokeep/server/internal/validation"
+	"github.com/rycln/gokeep/shared/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// adminStorage defines persistence operations of account management
+type adminStorage interface {
+	ListUsers(context.Context, string, string, int) ([]models.AdminUser, error)
+	SetUserDisabled(context.Context, models.UserID, bool) error
+	RevokeSessions(context.Context, models.UserID) error
+	GetUsage(context.Context, models.UserID) (*models.Usage, error)
+}
+
+// adminUserStorage defines operations on accounts looked up by username
+type adminUserStorage interface {
+	GetUserByUsername(context.Context, string) (*models.UserDB, error)
+	DeleteUser(context.Context, models.UserID) error
+}
+
+// AdminService implements account management by operators.
+// Accounts are addressed by username, as operators see them.
+// Revocations are announced, so other instances drop cached sessions.
+type AdminService struct {
+	strg   adminStorage
+	users  adminUserStorage
+	events eventPublisher
+	quota  int64 // Bytes of personal items per user, zero means unlimited
+}
+
+// NewAdminService creates a new AdminService instance.
+func NewAdminService(strg adminStorage, users adminUserStorage, events eventPublisher, quota int64) *AdminService {
+	return &AdminService{
+		strg:   strg,
+		users:  users,
+		events: events,
+		quota:  quota,
+	}
+}
+
+// ListUsers returns accounts whose username contains the query, ordered by username.
+// Only accounts after the given username are returned, empty starts from the first one.
+func (s *AdminService) ListUsers(ctx context.Context, query, after string, limit int) ([]models.AdminUser, error) {
+	return s.strg.ListUsers(ctx, query, after, limit)
+}
+
+// DisableUser blocks logins of the account and revokes its tokens.
+func (s *AdminService) DisableUser(ctx context.Context, username string) error {
+	uid, err := s.lookup(ctx, username)
+	if err != nil {
+		return err
+	}
+	return s.revoke(ctx, uid, s.strg.SetUserDisabled(ctx, uid, true))
+}
+
+// EnableUser allows logins of a disabled account.
+// Tokens revoked while the account was disabled stay invalid.
+func (s *AdminService) EnableUser(ctx context.Context, username string) error {
+	uid, err := s.lookup(ctx, username)
+	if err != nil {
+		return err
+	}
+	return s.strg.SetUserDisabled(ctx, uid, false)
+}
+
+// LogoutUser revokes all tokens issued to the account so far.
+func (s *AdminService) LogoutUser(ctx context.Context, username string) error {
+	uid, err := s.lookup(ctx, username)
+	if err != nil {
+		return err
+	}
+	return s.revoke(ctx, uid, s.strg.RevokeSessions(ctx, uid))
+}
+
+// GetUsage returns resources held by the account and the configured quota.
+func (s *AdminService) GetUsage(ctx context.Context, username string) (*models.Usage, error) {
+	uid, err := s.lookup(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := s.strg.GetUsage(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	usage.QuotaBytes = s.quota
+
+	return usage, nil
+}
+
+// PurgeUser removes the account with all personal data,
+// the same way as the owner deletes it.
+func (s *AdminService) PurgeUser(ctx context.Context, username string) error {
+	uid, err := s.lookup(ctx, username)
+	if err != nil {
+		return err
+	}
+	return s.revoke(ctx, uid, s.users.DeleteUser(ctx, uid))
+}
+
+// revoke announces invalidated sessions of the account unless the change failed
+func (s *AdminService) revoke(ctx context.Context, uid models.UserID, err error) error {
+	if err != nil {
+		return err
+	}
+	s.events.Publish(ctx, fanout.Event{Kind: fanout.KindSessionRevoked, UserID: uid})
+	return nil
+}
+
+// lookup resolves username to user ID
+func (s *AdminService) lookup(ctx context.Context, username string) (models.UserID, error) {
+	userDB, err := s.users.GetUserByUsername(ctx, validation.NormalizeUsername(username))
+	if err != nil {
+		return "", err
+	}
+	return userDB.ID, nil
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/server/internal/fanout"
+	"github.com/rycln/gokeep/server/internal/services/mocks"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminService_ListUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockadminStorage(ctrl)
+	s := NewAdminService(mStrg, mocks.NewMockadminUserStorage(ctrl), mocks.NewMockeventPublisher(ctrl), 0)
+
+	t.Run("storage page returned", func(t *testing.T) {
+		users := []models.AdminUser{{ID: testUserID, Username: "alice"}}
+		mStrg.EXPECT().ListUsers(gomock.Any(), "ali", "", 10).Return(users, nil)
+
+		got, err := s.ListUsers(context.Background(), "ali", "", 10)
+		require.NoError(t, err)
+		assert.Equal(t, users, got)
+	})
+}
+
+func TestAdminService_UserActions(t *testing.T) {
+	userDB := &models.UserDB{ID: testUserID, Username: "alice"}
+
+	t.Run("username normalized before lookup", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mStrg := mocks.NewMockadminStorage(ctrl)
+		mUsers := mocks.NewMockadminUserStorage(ctrl)
+		mEvents := mocks.NewMockeventPublisher(ctrl)
+		s := NewAdminService(mStrg, mUsers, mEvents, 0)
+
+		gomock.InOrder(
+			mUsers.EXPECT().GetUserByUsername(gomock.Any(), "alice").Return(userDB, nil),
+			mStrg.EXPECT().SetUserDisabled(gomock.Any(), userDB.ID, true).Return(nil),
+			mEvents.EXPECT().Publish(gomock.Any(), fanout.Event{Kind: fanout.KindSessionRevoked, UserID: userDB.ID}),
+		)
+
+		assert.NoError(t, s.DisableUser(context.Background(), "  alice "))
+	})
+
+	t.Run("enable keeps account sessions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mStrg := mocks.NewMockadminStorage(ctrl)
+		mUsers := mocks.NewMockadminUserStorage(ctrl)
+		s := NewAdminService(mStrg, mUsers, mocks.NewMockeventPublisher(ctrl), 0)
+
+		gomock.InOrder(
+			mUsers.EXPECT().GetUserByUsername(gomock.Any(), "alice").Return(userDB, nil),
+			mStrg.EXPECT().SetUserDisabled(gomock.Any(), userDB.ID, false).Return(nil),
+		)
+
+		assert.NoError(t, s.EnableUser(context.Background(), "alice"))
+	})
+
+	t.Run("logout revokes sessions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mStrg := mocks.NewMockadminStorage(ctrl)
+		mUsers := mocks.NewMockadminUserStorage(ctrl)
+		mEvents := mocks.NewMockeventPublisher(ctrl)
+		s := NewAdminService(mStrg, mUsers, mEvents, 0)
+
+		gomock.InOrder(
+			mUsers.EXPECT().GetUserByUsername(gomock.Any(), "alice").Return(userDB, nil),
+			mStrg.EXPECT().RevokeSessions(gomock.Any(), userDB.ID).Return(nil),
+			mEvents.EXPECT().Publish(gomock.Any(), fanout.Event{Kind: fanout.KindSessionRevoked, UserID: userDB.ID}),
+		)
+
+		assert.NoError(t, s.LogoutUser(context.Background(), "alice"))
+	})
+
+	t.Run("purge deletes account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mUsers := mocks.NewMockadminUserStorage(ctrl)
+		mEvents := mocks.NewMockeventPublisher(ctrl)
+		s := NewAdminService(mocks.NewMockadminStorage(ctrl), mUsers, mEvents, 0)
+
+		gomock.InOrder(
+			mUsers.EXPECT().GetUserByUsername(gomock.Any(), "alice").Return(userDB, nil),
+			mUsers.EXPECT().DeleteUser(gomock.Any(), userDB.ID).Return(nil),
+			mEvents.EXPECT().Publish(gomock.Any(), fanout.Event{Kind: fanout.KindSessionRevoked, UserID: userDB.ID}),
+		)
+
+		assert.NoError(t, s.PurgeUser(context.Background(), "alice"))
+	})
+
+	t.Run("failed logout not announced", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mStrg := mocks.NewMockadminStorage(ctrl)
+		mUsers := mocks.NewMockadminUserStorage(ctrl)
+		s := NewAdminService(mStrg, mUsers, mocks.NewMockeventPublisher(ctrl), 0)
+
+		gomock.InOrder(
+			mUsers.EXPECT().GetUserByUsername(gomock.Any(), "alice").Return(userDB, nil),
+			mStrg.EXPECT().RevokeSessions(gomock.Any(), userDB.ID).Return(errTest),
+		)
+
+		assert.ErrorIs(t, s.LogoutUser(context.Background(), "alice"), errTest)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mUsers := mocks.NewMockadminUserStorage(ctrl)
+		s := NewAdminService(mocks.NewMockadminStorage(ctrl), mUsers, mocks.NewMockeventPublisher(ctrl), 0)
+
+		mUsers.EXPECT().GetUserByUsername(gomock.Any(), "bob").Return(nil, errTest)
+
+		assert.ErrorIs(t, s.PurgeUser(context.Background(), "bob"), errTest)
+	})
+}
+
+func TestAdminService_GetUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockadminStorage(ctrl)
+	mUsers := mocks.NewMockadminUserStorage(ctrl)
+	s := NewAdminService(mStrg, mUsers, mocks.NewMockeventPublisher(ctrl), 1<<20)
+
+	t.Run("quota added to usage", func(t *testing.T) {
+		gomock.InOrder(
+			mUsers.EXPECT().GetUserByUsername(gomock.Any(), "alice").Return(&models.UserDB{ID: testUserID}, nil),
+			mStrg.EXPECT().GetUsage(gomock.Any(), models.UserID(testUserID)).Return(&models.Usage{Items: 3, Bytes: 512}, nil),
+		)
+
+		usage, err := s.GetUsage(context.Background(), "alice")
+		require.NoError(t, err)
+		assert.Equal(t, &models.Usage{Items: 3, Bytes: 512, QuotaBytes: 1 << 20}, usage)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		gomock.InOrder(
+			mUsers.EXPECT().GetUserByUsername(gomock.Any(), "alice").Return(&models.UserDB{ID: testUserID}, nil),
+			mStrg.EXPECT().GetUsage(gomock.Any(), models.UserID(testUserID)).Return(nil, errTest),
+		)
+
+		_, err := s.GetUsage(context.Background(), "alice")
+		assert.ErrorIs(t, err, errTest)
+	})
+}
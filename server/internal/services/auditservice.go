@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/rycln/gokeep/server/internal/contextkeys"
+	"github.com/rycln/gokeep/shared/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// auditStorage defines persistence operations for the audit log
+type auditStorage interface {
+	AddEvent(context.Context, *models.AuditEvent) error
+	GetEvents(context.Context, models.UserID, int64, int) ([]models.AuditEvent, error)
+}
+
+// auditRecorder defines recording of audited operations
+type auditRecorder interface {
+	Record(context.Context, models.UserID, models.AuditAction, error)
+}
+
+// AuditService records security relevant operations and lists them for the account owner.
+type AuditService struct {
+	strg  auditStorage
+	onErr func(context.Context, error) // Reports events that could not be stored
+}
+
+// NewAuditService creates a new AuditService instance.
+// Recording never fails the audited operation, storage errors are passed to onErr.
+func NewAuditService(strg auditStorage, onErr func(context.Context, error)) *AuditService {
+	return &AuditService{
+		strg:  strg,
+		onErr: onErr,
+	}
+}
+
+// Record appends operation outcome with device and address of the client.
+// The operation is recorded as failed when opErr is not nil.
+func (s *AuditService) Record(ctx context.Context, uid models.UserID, action models.AuditAction, opErr error) {
+	event := &models.AuditEvent{
+		UserID:    uid,
+		Action:    action,
+		Result:    models.AuditSuccess,
+		CreatedAt: time.Now(),
+	}
+	if opErr != nil {
+		event.Result = models.AuditFailure
+	}
+	event.Device, _ = ctx.Value(contextkeys.Device).(string)
+	event.IP, _ = ctx.Value(contextkeys.PeerIP).(string)
+
+	// Operation context may already be cancelled, the event must still be stored
+	err := s.strg.AddEvent(context.WithoutCancel(ctx), event)
+	if err != nil {
+		s.onErr(ctx, err)
+	}
+}
+
+// ListEvents returns audit events of the user taken from context, newest first.
+// Only events older than the before ID are returned, zero starts from the newest one.
+// User ID is read from context directly because UserService itself records events.
+func (s *AuditService) ListEvents(ctx context.Context, before int64, limit int) ([]models.AuditEvent, error) {
+	uid, ok := ctx.Value(contextkeys.UserID).(models.UserID)
+	if !ok {
+		return nil, errNoUserID
+	}
+
+	return s.strg.GetEvents(ctx, uid, before, limit)
+}
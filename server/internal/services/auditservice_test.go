@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/server/internal/contextkeys"
+	"github.com/rycln/gokeep/server/internal/services/mocks"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noAudit returns recorder accepting any event
+func noAudit(ctrl *gomock.Controller) *mocks.MockauditRecorder {
+	audit := mocks.NewMockauditRecorder(ctrl)
+	audit.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	return audit
+}
+
+func TestAuditService_Record(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextkeys.Device, "gophkeeper-client/1.0")
+	ctx = context.WithValue(ctx, contextkeys.PeerIP, "192.0.2.1")
+
+	t.Run("client info stored with result", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mStrg := mocks.NewMockauditStorage(ctrl)
+		s := NewAuditService(mStrg, func(_ context.Context, err error) { t.Errorf("unexpected error: %v", err) })
+
+		mStrg.EXPECT().
+			AddEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *models.AuditEvent) error {
+				assert.Equal(t, testUserID, event.UserID)
+				assert.Equal(t, "gophkeeper-client/1.0", event.Device)
+				assert.Equal(t, "192.0.2.1", event.IP)
+				assert.Equal(t, models.AuditLogin, event.Action)
+				assert.Equal(t, models.AuditFailure, event.Result)
+				assert.False(t, event.CreatedAt.IsZero())
+				return nil
+			})
+
+		s.Record(ctx, testUserID, models.AuditLogin, errTest)
+	})
+
+	t.Run("event stored after request is cancelled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mStrg := mocks.NewMockauditStorage(ctrl)
+		s := NewAuditService(mStrg, func(_ context.Context, err error) { t.Errorf("unexpected error: %v", err) })
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		mStrg.EXPECT().
+			AddEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, event *models.AuditEvent) error {
+				assert.NoError(t, ctx.Err())
+				assert.Equal(t, models.AuditSuccess, event.Result)
+				return nil
+			})
+
+		s.Record(cancelled, testUserID, models.AuditSync, nil)
+	})
+
+	t.Run("storage error reported", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mStrg := mocks.NewMockauditStorage(ctrl)
+		var (
+			reported    error
+			reportedCtx context.Context
+		)
+		s := NewAuditService(mStrg, func(ctx context.Context, err error) { reportedCtx, reported = ctx, err })
+
+		mStrg.EXPECT().AddEvent(gomock.Any(), gomock.Any()).Return(errTest)
+
+		s.Record(ctx, testUserID, models.AuditSync, nil)
+		assert.ErrorIs(t, reported, errTest)
+		assert.Equal(t, ctx, reportedCtx)
+	})
+}
+
+func TestAuditService_ListEvents(t *testing.T) {
+	t.Run("events of current user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mStrg := mocks.NewMockauditStorage(ctrl)
+		s := NewAuditService(mStrg, nil)
+
+		events := []models.AuditEvent{{ID: 3, Action: models.AuditLogin}}
+		mStrg.EXPECT().GetEvents(gomock.Any(), testUserID, int64(10), 20).Return(events, nil)
+
+		ctx := context.WithValue(context.Background(), contextkeys.UserID, testUserID)
+		got, err := s.ListEvents(ctx, 10, 20)
+		require.NoError(t, err)
+		assert.Equal(t, events, got)
+	})
+
+	t.Run("no user in context", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s := NewAuditService(mocks.NewMockauditStorage(ctrl), nil)
+
+		_, err := s.ListEvents(context.Background(), 0, 20)
+		assert.ErrorIs(t, err, errNoUserID)
+	})
+}
+
+func TestUserService_Audit(t *testing.T) {
+	t.Run("failed login recorded for account owner", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mStrg := mocks.NewMockuserStorage(ctrl)
+		mHasher := mocks.NewMockpassHasher(ctrl)
+		mAudit := mocks.NewMockauditRecorder(ctrl)
+		s := NewUserService(mStrg, mHasher, mocks.NewMockjwtCreator(ctrl), mAudit, noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+
+		gomock.InOrder(
+			mStrg.EXPECT().GetUserByUsername(gomock.Any(), "testuser").
+				Return(&models.UserDB{ID: testUserID, PassHash: testPasswordHash}, nil),
+			mHasher.EXPECT().Compare(testPasswordHash, "wrong").Return(errTest),
+			mAudit.EXPECT().Record(gomock.Any(), testUserID, models.AuditLogin, errTest),
+		)
+
+		_, err := s.AuthUser(context.Background(), &models.UserLoginReq{Username: "testuser", Password: "wrong"})
+		assert.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("failed registration recorded without account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mStrg := mocks.NewMockuserStorage(ctrl)
+		mHasher := mocks.NewMockpassHasher(ctrl)
+		mAudit := mocks.NewMockauditRecorder(ctrl)
+		s := NewUserService(mStrg, mHasher, mocks.NewMockjwtCreator(ctrl), mAudit, noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+
+		gomock.InOrder(
+			mHasher.EXPECT().Hash(testPassword).Return(testPasswordHash, nil),
+			mStrg.EXPECT().AddUser(gomock.Any(), gomock.Any()).Return(errTest),
+			mAudit.EXPECT().Record(gomock.Any(), models.UserID(""), models.AuditRegister, errTest),
+		)
+
+		_, err := s.CreateUser(context.Background(), &models.UserRegReq{Username: "taken", Password: testPassword})
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestSyncService_Audit(t *testing.T) {
+	t.Run("sync recorded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mStrg := mocks.NewMockitemStorage(ctrl)
+		mAuth := mocks.NewMockuidFetcher(ctrl)
+		mAudit := mocks.NewMockauditRecorder(ctrl)
+		s := NewSyncService(mStrg, mocks.NewMockroleFetcher(ctrl), mAuth, mAudit, noEvents(ctrl), 0)
+
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil),
+			mStrg.EXPECT().GetUserItems(gomock.Any(), testUserID).Return(nil, nil),
+			mAudit.EXPECT().Record(gomock.Any(), testUserID, models.AuditSync, nil),
+		)
+
+		_, err := s.SyncItems(context.Background(), nil)
+		assert.NoError(t, err)
+	})
+}
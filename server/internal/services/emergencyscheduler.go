@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// emergencyApprover defines interface for approving elapsed emergency requests.
+type emergencyApprover interface {
+	ApproveExpiredRequests(context.Context, time.Time) (int64, error)
+}
+
+// EmergencyScheduler enforces waiting periods of emergency access requests.
+// Requests are approved on the first tick after their waiting period elapsed.
+type EmergencyScheduler struct {
+	strg     emergencyApprover
+	interval time.Duration
+}
+
+// NewEmergencyScheduler creates a new EmergencyScheduler instance.
+func NewEmergencyScheduler(strg emergencyApprover, interval time.Duration) *EmergencyScheduler {
+	return &EmergencyScheduler{
+		strg:     strg,
+		interval: interval,
+	}
+}
+
+// Run approves elapsed requests every interval until context is canceled.
+// Errors are passed to onErr and do not stop the scheduler.
+func (s *EmergencyScheduler) Run(ctx context.Context, onErr func(context.Context, error)) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Tick(ctx); err != nil && onErr != nil {
+			onErr(ctx, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick approves requests whose waiting period has elapsed by now.
+func (s *EmergencyScheduler) Tick(ctx context.Context) error {
+	_, err := s.strg.ApproveExpiredRequests(ctx, time.Now())
+	return err
+}
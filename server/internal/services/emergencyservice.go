@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rycln/gokeep/shared/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// errSelfEmergency indicates an attempt to nominate the account itself
+var errSelfEmergency = errors.New("account can not be its own emergency contact")
+
+// emergencyStorage defines persistence operations for emergency access.
+type emergencyStorage interface {
+	AddContact(context.Context, *models.EmergencyContact) error
+	GetContacts(context.Context, models.UserID) ([]models.EmergencyContact, error)
+	GetGrants(context.Context, models.UserID) ([]models.EmergencyContact, error)
+	RequestAccess(context.Context, models.UserID, models.UserID, time.Time) error
+	DenyAccess(context.Context, models.UserID, models.UserID) error
+	GetApprovedKey(context.Context, models.UserID, models.UserID) ([]byte, error)
+}
+
+// EmergencyService handles emergency access through trusted contacts.
+// The vault key is wrapped by the grantor client, the service only
+// releases it after the waiting period enforced by EmergencyScheduler.
+type EmergencyService struct {
+	strg  emergencyStorage
+	items itemFetcher
+	users recipientFetcher
+	auth  uidFetcher
+}
+
+// NewEmergencyService creates a new EmergencyService instance.
+func NewEmergencyService(
+	strg emergencyStorage,
+	items itemFetcher,
+	users recipientFetcher,
+	auth uidFetcher,
+) *EmergencyService {
+	return &EmergencyService{
+		strg:  strg,
+		items: items,
+		users: users,
+		auth:  auth,
+	}
+}
+
+// AddContact nominates the grantee as trusted contact of the current user.
+func (s *EmergencyService) AddContact(ctx context.Context, contact *models.EmergencyContact) error {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	grantee, err := s.users.GetPublicKey(ctx, contact.Grantee)
+	if err != nil {
+		return err
+	}
+
+	if grantee.UserID == uid {
+		return errSelfEmergency
+	}
+
+	contact.GrantorID = uid
+	contact.GranteeID = grantee.UserID
+
+	return s.strg.AddContact(ctx, contact)
+}
+
+// ListContacts returns trusted contacts nominated by the current user.
+func (s *EmergencyService) ListContacts(ctx context.Context) ([]models.EmergencyContact, error) {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.strg.GetContacts(ctx, uid)
+}
+
+// ListGrants returns accounts that nominated the current user as trusted contact.
+func (s *EmergencyService) ListGrants(ctx context.Context) ([]models.EmergencyContact, error) {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.strg.GetGrants(ctx, uid)
+}
+
+// RequestAccess starts the waiting period for the vault of the grantor.
+func (s *EmergencyService) RequestAccess(ctx context.Context, grantor string) error {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner, err := s.users.GetPublicKey(ctx, grantor)
+	if err != nil {
+		return err
+	}
+
+	return s.strg.RequestAccess(ctx, owner.UserID, uid, time.Now())
+}
+
+// DenyAccess denies pending request of the grantee to the current user vault.
+func (s *EmergencyService) DenyAccess(ctx context.Context, grantee string) error {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	contact, err := s.users.GetPublicKey(ctx, grantee)
+	if err != nil {
+		return err
+	}
+
+	return s.strg.DenyAccess(ctx, uid, contact.UserID)
+}
+
+// GetVault returns the wrapped vault key and personal items of the grantor.
+// Only available after the request of the current user was approved.
+func (s *EmergencyService) GetVault(ctx context.Context, grantor string) (*models.EmergencyVault, error) {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.users.GetPublicKey(ctx, grantor)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.strg.GetApprovedKey(ctx, owner.UserID, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.items.GetUserItems(ctx, owner.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collection items are encrypted with collection keys, not with the vault key
+	vault := &models.EmergencyVault{WrappedKey: key}
+	for _, item := range items {
+		if item.CollectionID != "" || item.IsDeleted {
+			continue
+		}
+		vault.Items = append(vault.Items, item)
+	}
+
+	return vault, nil
+}
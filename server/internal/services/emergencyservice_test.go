@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/server/internal/services/mocks"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmergencyService_AddContact(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockemergencyStorage(ctrl)
+	mUsers := mocks.NewMockrecipientFetcher(ctrl)
+	mAuth := mocks.NewMockuidFetcher(ctrl)
+
+	ctx := context.Background()
+
+	t.Run("contact nominated", func(t *testing.T) {
+		contact := &models.EmergencyContact{
+			Grantee:    "contact",
+			WaitPeriod: time.Hour,
+			WrappedKey: []byte("wrapped"),
+		}
+
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testUserID, nil),
+			mUsers.EXPECT().GetPublicKey(ctx, "contact").Return(&models.PublicKey{UserID: testRecipientID}, nil),
+			mStrg.EXPECT().AddContact(ctx, contact).Return(nil),
+		)
+
+		s := NewEmergencyService(mStrg, nil, mUsers, mAuth)
+		err := s.AddContact(ctx, contact)
+		require.NoError(t, err)
+		assert.Equal(t, testUserID, contact.GrantorID)
+		assert.Equal(t, testRecipientID, contact.GranteeID)
+	})
+
+	t.Run("nominate self", func(t *testing.T) {
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testUserID, nil),
+			mUsers.EXPECT().GetPublicKey(ctx, "me").Return(&models.PublicKey{UserID: testUserID}, nil),
+		)
+
+		s := NewEmergencyService(mStrg, nil, mUsers, mAuth)
+		err := s.AddContact(ctx, &models.EmergencyContact{Grantee: "me"})
+		assert.ErrorIs(t, err, errSelfEmergency)
+	})
+}
+
+func TestEmergencyService_RequestAndDeny(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockemergencyStorage(ctrl)
+	mUsers := mocks.NewMockrecipientFetcher(ctrl)
+	mAuth := mocks.NewMockuidFetcher(ctrl)
+
+	ctx := context.Background()
+
+	t.Run("grantee requests access", func(t *testing.T) {
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testRecipientID, nil),
+			mUsers.EXPECT().GetPublicKey(ctx, "owner").Return(&models.PublicKey{UserID: testUserID}, nil),
+			mStrg.EXPECT().RequestAccess(ctx, testUserID, testRecipientID, gomock.Any()).Return(nil),
+		)
+
+		s := NewEmergencyService(mStrg, nil, mUsers, mAuth)
+		assert.NoError(t, s.RequestAccess(ctx, "owner"))
+	})
+
+	t.Run("owner denies access", func(t *testing.T) {
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testUserID, nil),
+			mUsers.EXPECT().GetPublicKey(ctx, "contact").Return(&models.PublicKey{UserID: testRecipientID}, nil),
+			mStrg.EXPECT().DenyAccess(ctx, testUserID, testRecipientID).Return(errTest),
+		)
+
+		s := NewEmergencyService(mStrg, nil, mUsers, mAuth)
+		assert.ErrorIs(t, s.DenyAccess(ctx, "contact"), errTest)
+	})
+}
+
+func TestEmergencyService_GetVault(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockemergencyStorage(ctrl)
+	mItems := mocks.NewMockitemFetcher(ctrl)
+	mUsers := mocks.NewMockrecipientFetcher(ctrl)
+	mAuth := mocks.NewMockuidFetcher(ctrl)
+
+	ctx := context.Background()
+
+	t.Run("personal items released", func(t *testing.T) {
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testRecipientID, nil),
+			mUsers.EXPECT().GetPublicKey(ctx, "owner").Return(&models.PublicKey{UserID: testUserID}, nil),
+			mStrg.EXPECT().GetApprovedKey(ctx, testUserID, testRecipientID).Return([]byte("wrapped"), nil),
+			mItems.EXPECT().GetUserItems(ctx, testUserID).Return([]models.Item{
+				{ID: "item1", UserID: testUserID},
+				{ID: "item2", CollectionID: "col1"},
+				{ID: "item3", UserID: testUserID, IsDeleted: true},
+			}, nil),
+		)
+
+		s := NewEmergencyService(mStrg, mItems, mUsers, mAuth)
+		vault, err := s.GetVault(ctx, "owner")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("wrapped"), vault.WrappedKey)
+		require.Len(t, vault.Items, 1)
+		assert.Equal(t, models.ItemID("item1"), vault.Items[0].ID)
+	})
+
+	t.Run("access not approved", func(t *testing.T) {
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testRecipientID, nil),
+			mUsers.EXPECT().GetPublicKey(ctx, "owner").Return(&models.PublicKey{UserID: testUserID}, nil),
+			mStrg.EXPECT().GetApprovedKey(ctx, testUserID, testRecipientID).Return(nil, errTest),
+		)
+
+		s := NewEmergencyService(mStrg, mItems, mUsers, mAuth)
+		_, err := s.GetVault(ctx, "owner")
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestEmergencyScheduler_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockemergencyApprover(ctrl)
+
+	t.Run("errors do not stop scheduler", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		calls := 0
+		mStrg.EXPECT().
+			ApproveExpiredRequests(ctx, gomock.Any()).
+			DoAndReturn(func(context.Context, time.Time) (int64, error) {
+				calls++
+				if calls == 2 {
+					cancel()
+				}
+				return 0, errTest
+			}).
+			Times(2)
+
+		var errs []error
+		s := NewEmergencyScheduler(mStrg, time.Millisecond)
+		s.Run(ctx, func(_ context.Context, err error) { errs = append(errs, err) })
+
+		assert.Len(t, errs, 2)
+	})
+}
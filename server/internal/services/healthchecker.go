@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// dbPinger defines interface for checking database availability.
+type dbPinger interface {
+	PingContext(context.Context) error
+}
+
+// servingStatusSetter defines interface for publishing health status of services.
+type servingStatusSetter interface {
+	SetServingStatus(string, healthpb.HealthCheckResponse_ServingStatus)
+}
+
+// HealthChecker reports services serving only while the database is reachable.
+type HealthChecker struct {
+	db       dbPinger
+	status   servingStatusSetter
+	services []string
+	interval time.Duration
+}
+
+// NewHealthChecker creates a new HealthChecker instance.
+// Services are the names whose status follows the database, "" is the whole server.
+func NewHealthChecker(db dbPinger, status servingStatusSetter, interval time.Duration, services ...string) *HealthChecker {
+	return &HealthChecker{
+		db:       db,
+		status:   status,
+		services: services,
+		interval: interval,
+	}
+}
+
+// Run pings the database every interval until context is canceled.
+// Ping errors are passed to onErr and do not stop the checker.
+func (c *HealthChecker) Run(ctx context.Context, onErr func(error)) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Tick(ctx); err != nil && onErr != nil {
+			onErr(err)
+		}
+
+		// Both cases may be ready at once, cancellation wins
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}
+}
+
+// Tick pings the database once and updates status of all services.
+// A ping must finish within the check interval.
+func (c *HealthChecker) Tick(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.interval)
+	defer cancel()
+
+	st := healthpb.HealthCheckResponse_SERVING
+	err := c.db.PingContext(ctx)
+	if err != nil {
+		st = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	for _, service := range c.services {
+		c.status.SetServingStatus(service, st)
+	}
+
+	return err
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/server/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthChecker_Tick(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mDB := mocks.NewMockdbPinger(ctrl)
+	mStatus := mocks.NewMockservingStatusSetter(ctrl)
+	c := NewHealthChecker(mDB, mStatus, time.Second, "", "gophkeeper.GophKeeper")
+
+	t.Run("serving while database is reachable", func(t *testing.T) {
+		mDB.EXPECT().PingContext(gomock.Any()).Return(nil)
+		mStatus.EXPECT().SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+		mStatus.EXPECT().SetServingStatus("gophkeeper.GophKeeper", healthpb.HealthCheckResponse_SERVING)
+
+		assert.NoError(t, c.Tick(context.Background()))
+	})
+
+	t.Run("not serving when ping fails", func(t *testing.T) {
+		mDB.EXPECT().PingContext(gomock.Any()).Return(errTest)
+		mStatus.EXPECT().SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		mStatus.EXPECT().SetServingStatus("gophkeeper.GophKeeper", healthpb.HealthCheckResponse_NOT_SERVING)
+
+		assert.ErrorIs(t, c.Tick(context.Background()), errTest)
+	})
+}
+
+func TestHealthChecker_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mDB := mocks.NewMockdbPinger(ctrl)
+	mStatus := mocks.NewMockservingStatusSetter(ctrl)
+
+	t.Run("errors do not stop checker", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		calls := 0
+		mDB.EXPECT().
+			PingContext(gomock.Any()).
+			DoAndReturn(func(context.Context) error {
+				calls++
+				if calls == 2 {
+					cancel()
+				}
+				return errTest
+			}).
+			Times(2)
+		mStatus.EXPECT().SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING).Times(2)
+
+		var errs []error
+		c := NewHealthChecker(mDB, mStatus, time.Millisecond, "")
+		c.Run(ctx, func(err error) { errs = append(errs, err) })
+
+		assert.Len(t, errs, 2)
+	})
+}
@@ -11,6 +11,15 @@ import (
 // Error definitions
 var errNoUserID = errors.New("does not contain user id")
 
+// tokenTimePrecision is precision of issue time in tokens.
+// Whole seconds would not tell a token issued right after a forced logout
+// from the ones it revoked.
+const tokenTimePrecision = time.Microsecond
+
+func init() {
+	jwt.TimePrecision = tokenTimePrecision
+}
+
 // JWTService handles JWT token operations
 type JWTService struct {
 	jwtKey string
@@ -45,9 +54,11 @@ func (s *JWTService) NewJWTString(userID models.UserID) (string, error) {
 		return "", errNoUserID
 	}
 
+	now := time.Now()
 	claims := jwtClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.jwtExp)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.jwtExp)),
 		},
 		UserID: userID,
 	}
@@ -63,13 +74,25 @@ func (s *JWTService) NewJWTString(userID models.UserID) (string, error) {
 
 // ParseIDFromJWT extracts user ID from JWT token
 func (s *JWTService) ParseIDFromJWT(token string) (models.UserID, error) {
+	uid, _, err := s.ParseJWT(token)
+	return uid, err
+}
+
+// ParseJWT extracts user ID and issue time from JWT token.
+// Tokens issued before the claim was introduced have zero issue time.
+func (s *JWTService) ParseJWT(token string) (models.UserID, time.Time, error) {
 	claims := &jwtClaims{}
 	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
 		return []byte(s.jwtKey), nil
 	})
 	if err != nil {
-		return "", err
+		return "", time.Time{}, err
+	}
+
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
 	}
 
-	return claims.UserID, nil
+	return claims.UserID, issuedAt, nil
 }
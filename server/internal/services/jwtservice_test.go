@@ -97,6 +97,37 @@ func TestJWTService_ParseIDFromJWT(t *testing.T) {
 	})
 }
 
+func TestJWTService_ParseJWT(t *testing.T) {
+	service := NewJWTService(testKey, testExp)
+
+	t.Run("issue time is returned", func(t *testing.T) {
+		before := time.Now().Truncate(time.Second)
+		token, err := service.NewJWTString(testUserID)
+		require.NoError(t, err)
+
+		userID, issuedAt, err := service.ParseJWT(token)
+		assert.NoError(t, err)
+		assert.Equal(t, testUserID, userID)
+		assert.False(t, issuedAt.Before(before))
+	})
+
+	t.Run("token without issue time", func(t *testing.T) {
+		claims := jwtClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(testExp)),
+			},
+			UserID: testUserID,
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testKey))
+		require.NoError(t, err)
+
+		userID, issuedAt, err := service.ParseJWT(token)
+		assert.NoError(t, err)
+		assert.Equal(t, testUserID, userID)
+		assert.True(t, issuedAt.IsZero())
+	})
+}
+
 func TestJWTClaims_Validate(t *testing.T) {
 	t.Run("valid claims", func(t *testing.T) {
 		claims := jwtClaims{
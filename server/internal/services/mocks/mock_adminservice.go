@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adminservice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/gokeep/shared/models"
+)
+
+// MockadminStorage is a mock of adminStorage interface.
+type MockadminStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockadminStorageMockRecorder
+}
+
+// MockadminStorageMockRecorder is the mock recorder for MockadminStorage.
+type MockadminStorageMockRecorder struct {
+	mock *MockadminStorage
+}
+
+// NewMockadminStorage creates a new mock instance.
+func NewMockadminStorage(ctrl *gomock.Controller) *MockadminStorage {
+	mock := &MockadminStorage{ctrl: ctrl}
+	mock.recorder = &MockadminStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockadminStorage) EXPECT() *MockadminStorageMockRecorder {
+	return m.recorder
+}
+
+// GetUsage mocks base method.
+func (m *MockadminStorage) GetUsage(arg0 context.Context, arg1 models.UserID) (*models.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", arg0, arg1)
+	ret0, _ := ret[0].(*models.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockadminStorageMockRecorder) GetUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockadminStorage)(nil).GetUsage), arg0, arg1)
+}
+
+// ListUsers mocks base method.
+func (m *MockadminStorage) ListUsers(arg0 context.Context, arg1, arg2 string, arg3 int) ([]models.AdminUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.AdminUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockadminStorageMockRecorder) ListUsers(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockadminStorage)(nil).ListUsers), arg0, arg1, arg2, arg3)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockadminStorage) RevokeSessions(arg0 context.Context, arg1 models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockadminStorageMockRecorder) RevokeSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockadminStorage)(nil).RevokeSessions), arg0, arg1)
+}
+
+// SetUserDisabled mocks base method.
+func (m *MockadminStorage) SetUserDisabled(arg0 context.Context, arg1 models.UserID, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserDisabled", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserDisabled indicates an expected call of SetUserDisabled.
+func (mr *MockadminStorageMockRecorder) SetUserDisabled(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserDisabled", reflect.TypeOf((*MockadminStorage)(nil).SetUserDisabled), arg0, arg1, arg2)
+}
+
+// MockadminUserStorage is a mock of adminUserStorage interface.
+type MockadminUserStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockadminUserStorageMockRecorder
+}
+
+// MockadminUserStorageMockRecorder is the mock recorder for MockadminUserStorage.
+type MockadminUserStorageMockRecorder struct {
+	mock *MockadminUserStorage
+}
+
+// NewMockadminUserStorage creates a new mock instance.
+func NewMockadminUserStorage(ctrl *gomock.Controller) *MockadminUserStorage {
+	mock := &MockadminUserStorage{ctrl: ctrl}
+	mock.recorder = &MockadminUserStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockadminUserStorage) EXPECT() *MockadminUserStorageMockRecorder {
+	return m.recorder
+}
+
+// DeleteUser mocks base method.
+func (m *MockadminUserStorage) DeleteUser(arg0 context.Context, arg1 models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockadminUserStorageMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockadminUserStorage)(nil).DeleteUser), arg0, arg1)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockadminUserStorage) GetUserByUsername(arg0 context.Context, arg1 string) (*models.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", arg0, arg1)
+	ret0, _ := ret[0].(*models.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockadminUserStorageMockRecorder) GetUserByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockadminUserStorage)(nil).GetUserByUsername), arg0, arg1)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: auditservice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/gokeep/shared/models"
+)
+
+// MockauditStorage is a mock of auditStorage interface.
+type MockauditStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockauditStorageMockRecorder
+}
+
+// MockauditStorageMockRecorder is the mock recorder for MockauditStorage.
+type MockauditStorageMockRecorder struct {
+	mock *MockauditStorage
+}
+
+// NewMockauditStorage creates a new mock instance.
+func NewMockauditStorage(ctrl *gomock.Controller) *MockauditStorage {
+	mock := &MockauditStorage{ctrl: ctrl}
+	mock.recorder = &MockauditStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauditStorage) EXPECT() *MockauditStorageMockRecorder {
+	return m.recorder
+}
+
+// AddEvent mocks base method.
+func (m *MockauditStorage) AddEvent(arg0 context.Context, arg1 *models.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEvent indicates an expected call of AddEvent.
+func (mr *MockauditStorageMockRecorder) AddEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvent", reflect.TypeOf((*MockauditStorage)(nil).AddEvent), arg0, arg1)
+}
+
+// GetEvents mocks base method.
+func (m *MockauditStorage) GetEvents(arg0 context.Context, arg1 models.UserID, arg2 int64, arg3 int) ([]models.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockauditStorageMockRecorder) GetEvents(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockauditStorage)(nil).GetEvents), arg0, arg1, arg2, arg3)
+}
+
+// MockauditRecorder is a mock of auditRecorder interface.
+type MockauditRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockauditRecorderMockRecorder
+}
+
+// MockauditRecorderMockRecorder is the mock recorder for MockauditRecorder.
+type MockauditRecorderMockRecorder struct {
+	mock *MockauditRecorder
+}
+
+// NewMockauditRecorder creates a new mock instance.
+func NewMockauditRecorder(ctrl *gomock.Controller) *MockauditRecorder {
+	mock := &MockauditRecorder{ctrl: ctrl}
+	mock.recorder = &MockauditRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauditRecorder) EXPECT() *MockauditRecorderMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockauditRecorder) Record(arg0 context.Context, arg1 models.UserID, arg2 models.AuditAction, arg3 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", arg0, arg1, arg2, arg3)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockauditRecorderMockRecorder) Record(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockauditRecorder)(nil).Record), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: emergencyscheduler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockemergencyApprover is a mock of emergencyApprover interface.
+type MockemergencyApprover struct {
+	ctrl     *gomock.Controller
+	recorder *MockemergencyApproverMockRecorder
+}
+
+// MockemergencyApproverMockRecorder is the mock recorder for MockemergencyApprover.
+type MockemergencyApproverMockRecorder struct {
+	mock *MockemergencyApprover
+}
+
+// NewMockemergencyApprover creates a new mock instance.
+func NewMockemergencyApprover(ctrl *gomock.Controller) *MockemergencyApprover {
+	mock := &MockemergencyApprover{ctrl: ctrl}
+	mock.recorder = &MockemergencyApproverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockemergencyApprover) EXPECT() *MockemergencyApproverMockRecorder {
+	return m.recorder
+}
+
+// ApproveExpiredRequests mocks base method.
+func (m *MockemergencyApprover) ApproveExpiredRequests(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveExpiredRequests", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveExpiredRequests indicates an expected call of ApproveExpiredRequests.
+func (mr *MockemergencyApproverMockRecorder) ApproveExpiredRequests(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveExpiredRequests", reflect.TypeOf((*MockemergencyApprover)(nil).ApproveExpiredRequests), arg0, arg1)
+}
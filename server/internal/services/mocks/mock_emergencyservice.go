@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: emergencyservice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/gokeep/shared/models"
+)
+
+// MockemergencyStorage is a mock of emergencyStorage interface.
+type MockemergencyStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockemergencyStorageMockRecorder
+}
+
+// MockemergencyStorageMockRecorder is the mock recorder for MockemergencyStorage.
+type MockemergencyStorageMockRecorder struct {
+	mock *MockemergencyStorage
+}
+
+// NewMockemergencyStorage creates a new mock instance.
+func NewMockemergencyStorage(ctrl *gomock.Controller) *MockemergencyStorage {
+	mock := &MockemergencyStorage{ctrl: ctrl}
+	mock.recorder = &MockemergencyStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockemergencyStorage) EXPECT() *MockemergencyStorageMockRecorder {
+	return m.recorder
+}
+
+// AddContact mocks base method.
+func (m *MockemergencyStorage) AddContact(arg0 context.Context, arg1 *models.EmergencyContact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddContact", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddContact indicates an expected call of AddContact.
+func (mr *MockemergencyStorageMockRecorder) AddContact(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContact", reflect.TypeOf((*MockemergencyStorage)(nil).AddContact), arg0, arg1)
+}
+
+// DenyAccess mocks base method.
+func (m *MockemergencyStorage) DenyAccess(arg0 context.Context, arg1, arg2 models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DenyAccess", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DenyAccess indicates an expected call of DenyAccess.
+func (mr *MockemergencyStorageMockRecorder) DenyAccess(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DenyAccess", reflect.TypeOf((*MockemergencyStorage)(nil).DenyAccess), arg0, arg1, arg2)
+}
+
+// GetApprovedKey mocks base method.
+func (m *MockemergencyStorage) GetApprovedKey(arg0 context.Context, arg1, arg2 models.UserID) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApprovedKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovedKey indicates an expected call of GetApprovedKey.
+func (mr *MockemergencyStorageMockRecorder) GetApprovedKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovedKey", reflect.TypeOf((*MockemergencyStorage)(nil).GetApprovedKey), arg0, arg1, arg2)
+}
+
+// GetContacts mocks base method.
+func (m *MockemergencyStorage) GetContacts(arg0 context.Context, arg1 models.UserID) ([]models.EmergencyContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContacts", arg0, arg1)
+	ret0, _ := ret[0].([]models.EmergencyContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContacts indicates an expected call of GetContacts.
+func (mr *MockemergencyStorageMockRecorder) GetContacts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockemergencyStorage)(nil).GetContacts), arg0, arg1)
+}
+
+// GetGrants mocks base method.
+func (m *MockemergencyStorage) GetGrants(arg0 context.Context, arg1 models.UserID) ([]models.EmergencyContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrants", arg0, arg1)
+	ret0, _ := ret[0].([]models.EmergencyContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrants indicates an expected call of GetGrants.
+func (mr *MockemergencyStorageMockRecorder) GetGrants(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrants", reflect.TypeOf((*MockemergencyStorage)(nil).GetGrants), arg0, arg1)
+}
+
+// RequestAccess mocks base method.
+func (m *MockemergencyStorage) RequestAccess(arg0 context.Context, arg1, arg2 models.UserID, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestAccess", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestAccess indicates an expected call of RequestAccess.
+func (mr *MockemergencyStorageMockRecorder) RequestAccess(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestAccess", reflect.TypeOf((*MockemergencyStorage)(nil).RequestAccess), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: healthchecker.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	grpc_health_v1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// MockdbPinger is a mock of dbPinger interface.
+type MockdbPinger struct {
+	ctrl     *gomock.Controller
+	recorder *MockdbPingerMockRecorder
+}
+
+// MockdbPingerMockRecorder is the mock recorder for MockdbPinger.
+type MockdbPingerMockRecorder struct {
+	mock *MockdbPinger
+}
+
+// NewMockdbPinger creates a new mock instance.
+func NewMockdbPinger(ctrl *gomock.Controller) *MockdbPinger {
+	mock := &MockdbPinger{ctrl: ctrl}
+	mock.recorder = &MockdbPingerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdbPinger) EXPECT() *MockdbPingerMockRecorder {
+	return m.recorder
+}
+
+// PingContext mocks base method.
+func (m *MockdbPinger) PingContext(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingContext", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PingContext indicates an expected call of PingContext.
+func (mr *MockdbPingerMockRecorder) PingContext(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingContext", reflect.TypeOf((*MockdbPinger)(nil).PingContext), arg0)
+}
+
+// MockservingStatusSetter is a mock of servingStatusSetter interface.
+type MockservingStatusSetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockservingStatusSetterMockRecorder
+}
+
+// MockservingStatusSetterMockRecorder is the mock recorder for MockservingStatusSetter.
+type MockservingStatusSetterMockRecorder struct {
+	mock *MockservingStatusSetter
+}
+
+// NewMockservingStatusSetter creates a new mock instance.
+func NewMockservingStatusSetter(ctrl *gomock.Controller) *MockservingStatusSetter {
+	mock := &MockservingStatusSetter{ctrl: ctrl}
+	mock.recorder = &MockservingStatusSetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockservingStatusSetter) EXPECT() *MockservingStatusSetterMockRecorder {
+	return m.recorder
+}
+
+// SetServingStatus mocks base method.
+func (m *MockservingStatusSetter) SetServingStatus(arg0 string, arg1 grpc_health_v1.HealthCheckResponse_ServingStatus) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetServingStatus", arg0, arg1)
+}
+
+// SetServingStatus indicates an expected call of SetServingStatus.
+func (mr *MockservingStatusSetterMockRecorder) SetServingStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetServingStatus", reflect.TypeOf((*MockservingStatusSetter)(nil).SetServingStatus), arg0, arg1)
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: orgservice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/gokeep/shared/models"
+)
+
+// MockorgStorage is a mock of orgStorage interface.
+type MockorgStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockorgStorageMockRecorder
+}
+
+// MockorgStorageMockRecorder is the mock recorder for MockorgStorage.
+type MockorgStorageMockRecorder struct {
+	mock *MockorgStorage
+}
+
+// NewMockorgStorage creates a new mock instance.
+func NewMockorgStorage(ctrl *gomock.Controller) *MockorgStorage {
+	mock := &MockorgStorage{ctrl: ctrl}
+	mock.recorder = &MockorgStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorgStorage) EXPECT() *MockorgStorageMockRecorder {
+	return m.recorder
+}
+
+// AddCollection mocks base method.
+func (m *MockorgStorage) AddCollection(arg0 context.Context, arg1 *models.Collection, arg2 []models.CollectionKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCollection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCollection indicates an expected call of AddCollection.
+func (mr *MockorgStorageMockRecorder) AddCollection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCollection", reflect.TypeOf((*MockorgStorage)(nil).AddCollection), arg0, arg1, arg2)
+}
+
+// AddMember mocks base method.
+func (m *MockorgStorage) AddMember(arg0 context.Context, arg1 models.OrgID, arg2 *models.Member, arg3 []models.CollectionKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockorgStorageMockRecorder) AddMember(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockorgStorage)(nil).AddMember), arg0, arg1, arg2, arg3)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockorgStorage) CreateOrganization(arg0 context.Context, arg1 *models.Organization, arg2 models.UserID, arg3 *models.Collection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockorgStorageMockRecorder) CreateOrganization(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockorgStorage)(nil).CreateOrganization), arg0, arg1, arg2, arg3)
+}
+
+// GetCollections mocks base method.
+func (m *MockorgStorage) GetCollections(arg0 context.Context, arg1 models.UserID) ([]models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollections", arg0, arg1)
+	ret0, _ := ret[0].([]models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollections indicates an expected call of GetCollections.
+func (mr *MockorgStorageMockRecorder) GetCollections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollections", reflect.TypeOf((*MockorgStorage)(nil).GetCollections), arg0, arg1)
+}
+
+// GetMembers mocks base method.
+func (m *MockorgStorage) GetMembers(arg0 context.Context, arg1 models.OrgID) ([]models.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", arg0, arg1)
+	ret0, _ := ret[0].([]models.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockorgStorageMockRecorder) GetMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockorgStorage)(nil).GetMembers), arg0, arg1)
+}
+
+// GetOrgRole mocks base method.
+func (m *MockorgStorage) GetOrgRole(arg0 context.Context, arg1 models.OrgID, arg2 models.UserID) (models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgRole", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgRole indicates an expected call of GetOrgRole.
+func (mr *MockorgStorageMockRecorder) GetOrgRole(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgRole", reflect.TypeOf((*MockorgStorage)(nil).GetOrgRole), arg0, arg1, arg2)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sessioncache.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/gokeep/shared/models"
+)
+
+// MocksessionChecker is a mock of sessionChecker interface.
+type MocksessionChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionCheckerMockRecorder
+}
+
+// MocksessionCheckerMockRecorder is the mock recorder for MocksessionChecker.
+type MocksessionCheckerMockRecorder struct {
+	mock *MocksessionChecker
+}
+
+// NewMocksessionChecker creates a new mock instance.
+func NewMocksessionChecker(ctrl *gomock.Controller) *MocksessionChecker {
+	mock := &MocksessionChecker{ctrl: ctrl}
+	mock.recorder = &MocksessionCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionChecker) EXPECT() *MocksessionCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckSession mocks base method.
+func (m *MocksessionChecker) CheckSession(arg0 context.Context, arg1 models.UserID, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSession", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckSession indicates an expected call of CheckSession.
+func (mr *MocksessionCheckerMockRecorder) CheckSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSession", reflect.TypeOf((*MocksessionChecker)(nil).CheckSession), arg0, arg1, arg2)
+}
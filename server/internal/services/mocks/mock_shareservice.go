@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: shareservice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/gokeep/shared/models"
+)
+
+// MockshareStorage is a mock of shareStorage interface.
+type MockshareStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockshareStorageMockRecorder
+}
+
+// MockshareStorageMockRecorder is the mock recorder for MockshareStorage.
+type MockshareStorageMockRecorder struct {
+	mock *MockshareStorage
+}
+
+// NewMockshareStorage creates a new mock instance.
+func NewMockshareStorage(ctrl *gomock.Controller) *MockshareStorage {
+	mock := &MockshareStorage{ctrl: ctrl}
+	mock.recorder = &MockshareStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockshareStorage) EXPECT() *MockshareStorageMockRecorder {
+	return m.recorder
+}
+
+// AddShare mocks base method.
+func (m *MockshareStorage) AddShare(arg0 context.Context, arg1 *models.Share) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddShare", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddShare indicates an expected call of AddShare.
+func (mr *MockshareStorageMockRecorder) AddShare(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddShare", reflect.TypeOf((*MockshareStorage)(nil).AddShare), arg0, arg1)
+}
+
+// DeleteShare mocks base method.
+func (m *MockshareStorage) DeleteShare(arg0 context.Context, arg1 models.ItemID, arg2 models.UserID, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShare", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShare indicates an expected call of DeleteShare.
+func (mr *MockshareStorageMockRecorder) DeleteShare(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShare", reflect.TypeOf((*MockshareStorage)(nil).DeleteShare), arg0, arg1, arg2, arg3)
+}
+
+// GetSharedWith mocks base method.
+func (m *MockshareStorage) GetSharedWith(arg0 context.Context, arg1 models.UserID) ([]models.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedWith", arg0, arg1)
+	ret0, _ := ret[0].([]models.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedWith indicates an expected call of GetSharedWith.
+func (mr *MockshareStorageMockRecorder) GetSharedWith(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedWith", reflect.TypeOf((*MockshareStorage)(nil).GetSharedWith), arg0, arg1)
+}
+
+// MockrecipientFetcher is a mock of recipientFetcher interface.
+type MockrecipientFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockrecipientFetcherMockRecorder
+}
+
+// MockrecipientFetcherMockRecorder is the mock recorder for MockrecipientFetcher.
+type MockrecipientFetcherMockRecorder struct {
+	mock *MockrecipientFetcher
+}
+
+// NewMockrecipientFetcher creates a new mock instance.
+func NewMockrecipientFetcher(ctrl *gomock.Controller) *MockrecipientFetcher {
+	mock := &MockrecipientFetcher{ctrl: ctrl}
+	mock.recorder = &MockrecipientFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrecipientFetcher) EXPECT() *MockrecipientFetcherMockRecorder {
+	return m.recorder
+}
+
+// GetPublicKey mocks base method.
+func (m *MockrecipientFetcher) GetPublicKey(arg0 context.Context, arg1 string) (*models.PublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicKey", arg0, arg1)
+	ret0, _ := ret[0].(*models.PublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicKey indicates an expected call of GetPublicKey.
+func (mr *MockrecipientFetcherMockRecorder) GetPublicKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKey", reflect.TypeOf((*MockrecipientFetcher)(nil).GetPublicKey), arg0, arg1)
+}
@@ -110,6 +110,20 @@ func (m *MockitemDeleter) EXPECT() *MockitemDeleterMockRecorder {
 	return m.recorder
 }
 
+// DeleteCollectionItem mocks base method.
+func (m *MockitemDeleter) DeleteCollectionItem(arg0 context.Context, arg1 models.ItemID, arg2 models.CollectionID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectionItem", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollectionItem indicates an expected call of DeleteCollectionItem.
+func (mr *MockitemDeleterMockRecorder) DeleteCollectionItem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectionItem", reflect.TypeOf((*MockitemDeleter)(nil).DeleteCollectionItem), arg0, arg1, arg2)
+}
+
 // DeleteItem mocks base method.
 func (m *MockitemDeleter) DeleteItem(arg0 context.Context, arg1 models.ItemID, arg2 models.UserID) error {
 	m.ctrl.T.Helper()
@@ -124,6 +138,44 @@ func (mr *MockitemDeleterMockRecorder) DeleteItem(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockitemDeleter)(nil).DeleteItem), arg0, arg1, arg2)
 }
 
+// MockitemMeter is a mock of itemMeter interface.
+type MockitemMeter struct {
+	ctrl     *gomock.Controller
+	recorder *MockitemMeterMockRecorder
+}
+
+// MockitemMeterMockRecorder is the mock recorder for MockitemMeter.
+type MockitemMeterMockRecorder struct {
+	mock *MockitemMeter
+}
+
+// NewMockitemMeter creates a new mock instance.
+func NewMockitemMeter(ctrl *gomock.Controller) *MockitemMeter {
+	mock := &MockitemMeter{ctrl: ctrl}
+	mock.recorder = &MockitemMeterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockitemMeter) EXPECT() *MockitemMeterMockRecorder {
+	return m.recorder
+}
+
+// GetUserDataSize mocks base method.
+func (m *MockitemMeter) GetUserDataSize(arg0 context.Context, arg1 models.UserID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDataSize", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserDataSize indicates an expected call of GetUserDataSize.
+func (mr *MockitemMeterMockRecorder) GetUserDataSize(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDataSize", reflect.TypeOf((*MockitemMeter)(nil).GetUserDataSize), arg0, arg1)
+}
+
 // MockitemStorage is a mock of itemStorage interface.
 type MockitemStorage struct {
 	ctrl     *gomock.Controller
@@ -161,6 +213,20 @@ func (mr *MockitemStorageMockRecorder) AddItem(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockitemStorage)(nil).AddItem), arg0, arg1)
 }
 
+// DeleteCollectionItem mocks base method.
+func (m *MockitemStorage) DeleteCollectionItem(arg0 context.Context, arg1 models.ItemID, arg2 models.CollectionID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectionItem", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollectionItem indicates an expected call of DeleteCollectionItem.
+func (mr *MockitemStorageMockRecorder) DeleteCollectionItem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectionItem", reflect.TypeOf((*MockitemStorage)(nil).DeleteCollectionItem), arg0, arg1, arg2)
+}
+
 // DeleteItem mocks base method.
 func (m *MockitemStorage) DeleteItem(arg0 context.Context, arg1 models.ItemID, arg2 models.UserID) error {
 	m.ctrl.T.Helper()
@@ -175,6 +241,21 @@ func (mr *MockitemStorageMockRecorder) DeleteItem(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockitemStorage)(nil).DeleteItem), arg0, arg1, arg2)
 }
 
+// GetUserDataSize mocks base method.
+func (m *MockitemStorage) GetUserDataSize(arg0 context.Context, arg1 models.UserID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDataSize", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserDataSize indicates an expected call of GetUserDataSize.
+func (mr *MockitemStorageMockRecorder) GetUserDataSize(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDataSize", reflect.TypeOf((*MockitemStorage)(nil).GetUserDataSize), arg0, arg1)
+}
+
 // GetUserItems mocks base method.
 func (m *MockitemStorage) GetUserItems(arg0 context.Context, arg1 models.UserID) ([]models.Item, error) {
 	m.ctrl.T.Helper()
@@ -190,6 +271,44 @@ func (mr *MockitemStorageMockRecorder) GetUserItems(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserItems", reflect.TypeOf((*MockitemStorage)(nil).GetUserItems), arg0, arg1)
 }
 
+// MockroleFetcher is a mock of roleFetcher interface.
+type MockroleFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockroleFetcherMockRecorder
+}
+
+// MockroleFetcherMockRecorder is the mock recorder for MockroleFetcher.
+type MockroleFetcherMockRecorder struct {
+	mock *MockroleFetcher
+}
+
+// NewMockroleFetcher creates a new mock instance.
+func NewMockroleFetcher(ctrl *gomock.Controller) *MockroleFetcher {
+	mock := &MockroleFetcher{ctrl: ctrl}
+	mock.recorder = &MockroleFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockroleFetcher) EXPECT() *MockroleFetcherMockRecorder {
+	return m.recorder
+}
+
+// GetCollectionRole mocks base method.
+func (m *MockroleFetcher) GetCollectionRole(arg0 context.Context, arg1 models.CollectionID, arg2 models.UserID) (models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectionRole", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectionRole indicates an expected call of GetCollectionRole.
+func (mr *MockroleFetcherMockRecorder) GetCollectionRole(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionRole", reflect.TypeOf((*MockroleFetcher)(nil).GetCollectionRole), arg0, arg1, arg2)
+}
+
 // MockuidFetcher is a mock of uidFetcher interface.
 type MockuidFetcher struct {
 	ctrl     *gomock.Controller
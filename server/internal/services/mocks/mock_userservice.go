@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/rycln/gokeep/shared/models"
@@ -49,6 +50,65 @@ func (mr *MockuserStorageMockRecorder) AddUser(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockuserStorage)(nil).AddUser), arg0, arg1)
 }
 
+// DeleteUser mocks base method.
+func (m *MockuserStorage) DeleteUser(arg0 context.Context, arg1 models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockuserStorageMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockuserStorage)(nil).DeleteUser), arg0, arg1)
+}
+
+// GetPublicKey mocks base method.
+func (m *MockuserStorage) GetPublicKey(arg0 context.Context, arg1 string) (*models.PublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicKey", arg0, arg1)
+	ret0, _ := ret[0].(*models.PublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicKey indicates an expected call of GetPublicKey.
+func (mr *MockuserStorageMockRecorder) GetPublicKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKey", reflect.TypeOf((*MockuserStorage)(nil).GetPublicKey), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockuserStorage) GetSession(arg0 context.Context, arg1 models.UserID) (*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockuserStorageMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockuserStorage)(nil).GetSession), arg0, arg1)
+}
+
+// GetUserByID mocks base method.
+func (m *MockuserStorage) GetUserByID(arg0 context.Context, arg1 models.UserID) (*models.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", arg0, arg1)
+	ret0, _ := ret[0].(*models.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockuserStorageMockRecorder) GetUserByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockuserStorage)(nil).GetUserByID), arg0, arg1)
+}
+
 // GetUserByUsername mocks base method.
 func (m *MockuserStorage) GetUserByUsername(arg0 context.Context, arg1 string) (*models.UserDB, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +124,34 @@ func (mr *MockuserStorageMockRecorder) GetUserByUsername(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockuserStorage)(nil).GetUserByUsername), arg0, arg1)
 }
 
+// SetKeyPair mocks base method.
+func (m *MockuserStorage) SetKeyPair(arg0 context.Context, arg1 models.UserID, arg2 *models.KeyPair) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKeyPair", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKeyPair indicates an expected call of SetKeyPair.
+func (mr *MockuserStorageMockRecorder) SetKeyPair(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeyPair", reflect.TypeOf((*MockuserStorage)(nil).SetKeyPair), arg0, arg1, arg2)
+}
+
+// UpdateUserCredentials mocks base method.
+func (m *MockuserStorage) UpdateUserCredentials(arg0 context.Context, arg1 *models.UserDB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserCredentials", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserCredentials indicates an expected call of UpdateUserCredentials.
+func (mr *MockuserStorageMockRecorder) UpdateUserCredentials(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserCredentials", reflect.TypeOf((*MockuserStorage)(nil).UpdateUserCredentials), arg0, arg1)
+}
+
 // MockpassHasher is a mock of passHasher interface.
 type MockpassHasher struct {
 	ctrl     *gomock.Controller
@@ -116,6 +204,120 @@ func (mr *MockpassHasherMockRecorder) Hash(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockpassHasher)(nil).Hash), arg0)
 }
 
+// MockloginLockout is a mock of loginLockout interface.
+type MockloginLockout struct {
+	ctrl     *gomock.Controller
+	recorder *MockloginLockoutMockRecorder
+}
+
+// MockloginLockoutMockRecorder is the mock recorder for MockloginLockout.
+type MockloginLockoutMockRecorder struct {
+	mock *MockloginLockout
+}
+
+// NewMockloginLockout creates a new mock instance.
+func NewMockloginLockout(ctrl *gomock.Controller) *MockloginLockout {
+	mock := &MockloginLockout{ctrl: ctrl}
+	mock.recorder = &MockloginLockoutMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockloginLockout) EXPECT() *MockloginLockoutMockRecorder {
+	return m.recorder
+}
+
+// Fail mocks base method.
+func (m *MockloginLockout) Fail(arg0 string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", arg0)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockloginLockoutMockRecorder) Fail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockloginLockout)(nil).Fail), arg0)
+}
+
+// Locked mocks base method.
+func (m *MockloginLockout) Locked(arg0 string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Locked", arg0)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// Locked indicates an expected call of Locked.
+func (mr *MockloginLockoutMockRecorder) Locked(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Locked", reflect.TypeOf((*MockloginLockout)(nil).Locked), arg0)
+}
+
+// Reset mocks base method.
+func (m *MockloginLockout) Reset(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Reset", arg0)
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockloginLockoutMockRecorder) Reset(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockloginLockout)(nil).Reset), arg0)
+}
+
+// MockcredentialsPolicy is a mock of credentialsPolicy interface.
+type MockcredentialsPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockcredentialsPolicyMockRecorder
+}
+
+// MockcredentialsPolicyMockRecorder is the mock recorder for MockcredentialsPolicy.
+type MockcredentialsPolicyMockRecorder struct {
+	mock *MockcredentialsPolicy
+}
+
+// NewMockcredentialsPolicy creates a new mock instance.
+func NewMockcredentialsPolicy(ctrl *gomock.Controller) *MockcredentialsPolicy {
+	mock := &MockcredentialsPolicy{ctrl: ctrl}
+	mock.recorder = &MockcredentialsPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcredentialsPolicy) EXPECT() *MockcredentialsPolicyMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockcredentialsPolicy) Validate(username, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", username, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockcredentialsPolicyMockRecorder) Validate(username, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockcredentialsPolicy)(nil).Validate), username, password)
+}
+
+// ValidatePassword mocks base method.
+func (m *MockcredentialsPolicy) ValidatePassword(username, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatePassword", username, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidatePassword indicates an expected call of ValidatePassword.
+func (mr *MockcredentialsPolicyMockRecorder) ValidatePassword(username, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatePassword", reflect.TypeOf((*MockcredentialsPolicy)(nil).ValidatePassword), username, password)
+}
+
 // MockjwtCreator is a mock of jwtCreator interface.
 type MockjwtCreator struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: watchservice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	fanout "github.com/rycln/gokeep/server/internal/fanout"
+)
+
+// MockeventPublisher is a mock of eventPublisher interface.
+type MockeventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockeventPublisherMockRecorder
+}
+
+// MockeventPublisherMockRecorder is the mock recorder for MockeventPublisher.
+type MockeventPublisherMockRecorder struct {
+	mock *MockeventPublisher
+}
+
+// NewMockeventPublisher creates a new mock instance.
+func NewMockeventPublisher(ctrl *gomock.Controller) *MockeventPublisher {
+	mock := &MockeventPublisher{ctrl: ctrl}
+	mock.recorder = &MockeventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockeventPublisher) EXPECT() *MockeventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockeventPublisher) Publish(arg0 context.Context, arg1 fanout.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", arg0, arg1)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockeventPublisherMockRecorder) Publish(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockeventPublisher)(nil).Publish), arg0, arg1)
+}
+
+// MockeventSubscriber is a mock of eventSubscriber interface.
+type MockeventSubscriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockeventSubscriberMockRecorder
+}
+
+// MockeventSubscriberMockRecorder is the mock recorder for MockeventSubscriber.
+type MockeventSubscriberMockRecorder struct {
+	mock *MockeventSubscriber
+}
+
+// NewMockeventSubscriber creates a new mock instance.
+func NewMockeventSubscriber(ctrl *gomock.Controller) *MockeventSubscriber {
+	mock := &MockeventSubscriber{ctrl: ctrl}
+	mock.recorder = &MockeventSubscriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockeventSubscriber) EXPECT() *MockeventSubscriberMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockeventSubscriber) Subscribe(arg0 fanout.Handler) func() {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", arg0)
+	ret0, _ := ret[0].(func())
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockeventSubscriberMockRecorder) Subscribe(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockeventSubscriber)(nil).Subscribe), arg0)
+}
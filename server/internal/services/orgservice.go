@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rycln/gokeep/shared/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// ErrForbidden indicates that member role does not permit the operation
+var ErrForbidden = errors.New("operation is not permitted for member role")
+
+var (
+	errInvalidRole = errors.New("invalid member role")
+	errEmptyOrg    = errors.New("organization name, collection name and key are required")
+)
+
+// errForbidden implements a structured permission error
+type errForbidden struct {
+	err error // Underlying error
+}
+
+// Error implements the error interface
+func (err *errForbidden) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap supports error inspection with errors.Is()/errors.As()
+func (err *errForbidden) Unwrap() error {
+	return err.err
+}
+
+// IsErrForbidden provides type checking method
+func (err *errForbidden) IsErrForbidden() bool {
+	return true
+}
+
+// newErrForbidden constructs a new permission error
+func newErrForbidden(err error) error {
+	return &errForbidden{
+		err: err,
+	}
+}
+
+// orgStorage defines persistence operations for organizations.
+type orgStorage interface {
+	CreateOrganization(context.Context, *models.Organization, models.UserID, *models.Collection) error
+	AddCollection(context.Context, *models.Collection, []models.CollectionKey) error
+	AddMember(context.Context, models.OrgID, *models.Member, []models.CollectionKey) error
+	GetOrgRole(context.Context, models.OrgID, models.UserID) (models.Role, error)
+	GetMembers(context.Context, models.OrgID) ([]models.Member, error)
+	GetCollections(context.Context, models.UserID) ([]models.Collection, error)
+}
+
+// OrgService handles organizations, memberships and collections.
+// Collection keys are wrapped by clients, the service only checks roles.
+type OrgService struct {
+	strg  orgStorage
+	users recipientFetcher
+	auth  uidFetcher
+}
+
+// NewOrgService creates a new OrgService instance.
+func NewOrgService(strg orgStorage, users recipientFetcher, auth uidFetcher) *OrgService {
+	return &OrgService{
+		strg:  strg,
+		users: users,
+		auth:  auth,
+	}
+}
+
+// CreateOrganization creates an organization owned by the current user with its first collection.
+func (s *OrgService) CreateOrganization(ctx context.Context, name string, col *models.Collection) (*models.Collection, error) {
+	if name == "" || col.Name == "" || len(col.WrappedKey) == 0 {
+		return nil, errEmptyOrg
+	}
+
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	org := &models.Organization{
+		ID:   models.OrgID(uuid.NewString()),
+		Name: name,
+	}
+
+	col.ID = models.CollectionID(uuid.NewString())
+	col.OrgID = org.ID
+	col.OrgName = org.Name
+	col.Role = models.RoleOwner
+
+	err = s.strg.CreateOrganization(ctx, org, uid, col)
+	if err != nil {
+		return nil, err
+	}
+
+	return col, nil
+}
+
+// CreateCollection adds a collection to the organization.
+// Keys must be wrapped for every member who should access the collection.
+func (s *OrgService) CreateCollection(
+	ctx context.Context,
+	col *models.Collection,
+	keys []models.CollectionKey,
+) (*models.Collection, error) {
+	if _, err := s.managerRole(ctx, col.OrgID); err != nil {
+		return nil, err
+	}
+
+	col.ID = models.CollectionID(uuid.NewString())
+
+	err := s.strg.AddCollection(ctx, col, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return col, nil
+}
+
+// AddMember adds an account to the organization by username.
+// Only owners may grant the owner role.
+func (s *OrgService) AddMember(
+	ctx context.Context,
+	org models.OrgID,
+	username string,
+	role models.Role,
+	keys []models.CollectionKey,
+) error {
+	if !role.Valid() {
+		return errInvalidRole
+	}
+
+	callerRole, err := s.managerRole(ctx, org)
+	if err != nil {
+		return err
+	}
+	if role == models.RoleOwner && callerRole != models.RoleOwner {
+		return newErrForbidden(ErrForbidden)
+	}
+
+	recipient, err := s.users.GetPublicKey(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	member := &models.Member{
+		UserID:    recipient.UserID,
+		Username:  username,
+		Role:      role,
+		PublicKey: recipient.Key,
+	}
+
+	return s.strg.AddMember(ctx, org, member, keys)
+}
+
+// ListMembers returns members of an organization of the current user.
+func (s *OrgService) ListMembers(ctx context.Context, org models.OrgID) ([]models.Member, error) {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.strg.GetOrgRole(ctx, org, uid); err != nil {
+		return nil, err
+	}
+
+	return s.strg.GetMembers(ctx, org)
+}
+
+// ListCollections returns collections available to the current user.
+func (s *OrgService) ListCollections(ctx context.Context) ([]models.Collection, error) {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.strg.GetCollections(ctx, uid)
+}
+
+// managerRole returns role of the current user if it permits managing the organization
+func (s *OrgService) managerRole(ctx context.Context, org models.OrgID) (models.Role, error) {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	role, err := s.strg.GetOrgRole(ctx, org, uid)
+	if err != nil {
+		return "", err
+	}
+	if !role.CanManage() {
+		return "", newErrForbidden(ErrForbidden)
+	}
+
+	return role, nil
+}
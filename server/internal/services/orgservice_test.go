@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/server/internal/services/mocks"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testOrgID        = models.OrgID("550e8400-e29b-41d4-a716-446655440004")
+	testCollectionID = models.CollectionID("550e8400-e29b-41d4-a716-446655440003")
+)
+
+func TestOrgService_CreateOrganization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockorgStorage(ctrl)
+	mUsers := mocks.NewMockrecipientFetcher(ctrl)
+	mAuth := mocks.NewMockuidFetcher(ctrl)
+
+	ctx := context.Background()
+
+	t.Run("successful creation", func(t *testing.T) {
+		col := &models.Collection{Name: "shared", WrappedKey: []byte("wrapped")}
+
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testUserID, nil),
+			mStrg.EXPECT().CreateOrganization(ctx, gomock.Any(), testUserID, col).DoAndReturn(
+				func(_ context.Context, org *models.Organization, _ models.UserID, c *models.Collection) error {
+					assert.NotEmpty(t, org.ID)
+					assert.Equal(t, "team", org.Name)
+					assert.Equal(t, org.ID, c.OrgID)
+					return nil
+				}),
+		)
+
+		s := NewOrgService(mStrg, mUsers, mAuth)
+		res, err := s.CreateOrganization(ctx, "team", col)
+		require.NoError(t, err)
+		assert.NotEmpty(t, res.ID)
+		assert.Equal(t, "team", res.OrgName)
+		assert.Equal(t, models.RoleOwner, res.Role)
+	})
+
+	t.Run("missing collection key", func(t *testing.T) {
+		s := NewOrgService(mStrg, mUsers, mAuth)
+		_, err := s.CreateOrganization(ctx, "team", &models.Collection{Name: "shared"})
+		assert.ErrorIs(t, err, errEmptyOrg)
+	})
+}
+
+func TestOrgService_CreateCollection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockorgStorage(ctrl)
+	mUsers := mocks.NewMockrecipientFetcher(ctrl)
+	mAuth := mocks.NewMockuidFetcher(ctrl)
+
+	ctx := context.Background()
+	keys := []models.CollectionKey{{UserID: testUserID, WrappedKey: []byte("wrapped")}}
+
+	t.Run("admin creates collection", func(t *testing.T) {
+		col := &models.Collection{OrgID: testOrgID, Name: "ops"}
+
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testUserID, nil),
+			mStrg.EXPECT().GetOrgRole(ctx, testOrgID, testUserID).Return(models.RoleAdmin, nil),
+			mStrg.EXPECT().AddCollection(ctx, col, keys).Return(nil),
+		)
+
+		s := NewOrgService(mStrg, mUsers, mAuth)
+		res, err := s.CreateCollection(ctx, col, keys)
+		require.NoError(t, err)
+		assert.NotEmpty(t, res.ID)
+	})
+
+	t.Run("member is not allowed", func(t *testing.T) {
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testUserID, nil),
+			mStrg.EXPECT().GetOrgRole(ctx, testOrgID, testUserID).Return(models.RoleMember, nil),
+		)
+
+		s := NewOrgService(mStrg, mUsers, mAuth)
+		_, err := s.CreateCollection(ctx, &models.Collection{OrgID: testOrgID, Name: "ops"}, keys)
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+}
+
+func TestOrgService_AddMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockorgStorage(ctrl)
+	mUsers := mocks.NewMockrecipientFetcher(ctrl)
+	mAuth := mocks.NewMockuidFetcher(ctrl)
+
+	ctx := context.Background()
+	keys := []models.CollectionKey{{CollectionID: testCollectionID, WrappedKey: []byte("wrapped")}}
+
+	t.Run("owner adds member", func(t *testing.T) {
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testUserID, nil),
+			mStrg.EXPECT().GetOrgRole(ctx, testOrgID, testUserID).Return(models.RoleOwner, nil),
+			mUsers.EXPECT().GetPublicKey(ctx, "bob").Return(&models.PublicKey{UserID: testRecipientID, Key: []byte("pub")}, nil),
+			mStrg.EXPECT().AddMember(ctx, testOrgID, &models.Member{
+				UserID:    testRecipientID,
+				Username:  "bob",
+				Role:      models.RoleReadOnly,
+				PublicKey: []byte("pub"),
+			}, keys).Return(nil),
+		)
+
+		s := NewOrgService(mStrg, mUsers, mAuth)
+		err := s.AddMember(ctx, testOrgID, "bob", models.RoleReadOnly, keys)
+		assert.NoError(t, err)
+	})
+
+	t.Run("admin can not grant owner role", func(t *testing.T) {
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testUserID, nil),
+			mStrg.EXPECT().GetOrgRole(ctx, testOrgID, testUserID).Return(models.RoleAdmin, nil),
+		)
+
+		s := NewOrgService(mStrg, mUsers, mAuth)
+		err := s.AddMember(ctx, testOrgID, "bob", models.RoleOwner, keys)
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		s := NewOrgService(mStrg, mUsers, mAuth)
+		err := s.AddMember(ctx, testOrgID, "bob", models.Role("guest"), keys)
+		assert.ErrorIs(t, err, errInvalidRole)
+	})
+
+	t.Run("recipient not found", func(t *testing.T) {
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testUserID, nil),
+			mStrg.EXPECT().GetOrgRole(ctx, testOrgID, testUserID).Return(models.RoleAdmin, nil),
+			mUsers.EXPECT().GetPublicKey(ctx, "ghost").Return(nil, errTest),
+		)
+
+		s := NewOrgService(mStrg, mUsers, mAuth)
+		err := s.AddMember(ctx, testOrgID, "ghost", models.RoleMember, keys)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestOrgService_ListMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockorgStorage(ctrl)
+	mUsers := mocks.NewMockrecipientFetcher(ctrl)
+	mAuth := mocks.NewMockuidFetcher(ctrl)
+
+	ctx := context.Background()
+
+	t.Run("read-only member lists members", func(t *testing.T) {
+		members := []models.Member{{UserID: testUserID, Username: "alice", Role: models.RoleOwner}}
+
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testRecipientID, nil),
+			mStrg.EXPECT().GetOrgRole(ctx, testOrgID, testRecipientID).Return(models.RoleReadOnly, nil),
+			mStrg.EXPECT().GetMembers(ctx, testOrgID).Return(members, nil),
+		)
+
+		s := NewOrgService(mStrg, mUsers, mAuth)
+		res, err := s.ListMembers(ctx, testOrgID)
+		require.NoError(t, err)
+		assert.Equal(t, members, res)
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testRecipientID, nil),
+			mStrg.EXPECT().GetOrgRole(ctx, testOrgID, testRecipientID).Return(models.Role(""), errTest),
+		)
+
+		s := NewOrgService(mStrg, mUsers, mAuth)
+		_, err := s.ListMembers(ctx, testOrgID)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestOrgService_ListCollections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockorgStorage(ctrl)
+	mUsers := mocks.NewMockrecipientFetcher(ctrl)
+	mAuth := mocks.NewMockuidFetcher(ctrl)
+
+	ctx := context.Background()
+
+	t.Run("successful listing", func(t *testing.T) {
+		cols := []models.Collection{{ID: testCollectionID, OrgID: testOrgID, Name: "shared"}}
+
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testUserID, nil),
+			mStrg.EXPECT().GetCollections(ctx, testUserID).Return(cols, nil),
+		)
+
+		s := NewOrgService(mStrg, mUsers, mAuth)
+		res, err := s.ListCollections(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, cols, res)
+	})
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rycln/gokeep/server/internal/fanout"
+	"github.com/rycln/gokeep/shared/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// sessionChecker defines verification of token validity
+type sessionChecker interface {
+	CheckSession(context.Context, models.UserID, time.Time) error
+}
+
+// sessionEntry remembers a successful session check
+type sessionEntry struct {
+	issuedAt time.Time // Earliest token issue time accepted
+	expires  time.Time
+}
+
+// SessionCache remembers successful session checks for a short time,
+// so authenticated calls do not query storage on every request.
+// Entries are dropped on revocation events published by any instance,
+// failed checks are never cached.
+type SessionCache struct {
+	next    sessionChecker
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[models.UserID]sessionEntry
+	gen     uint64 // Incremented on invalidation, checks started before it are not cached
+	swept   time.Time
+	now     func() time.Time
+}
+
+// NewSessionCache creates a new SessionCache instance.
+// Zero TTL disables caching.
+func NewSessionCache(next sessionChecker, ttl time.Duration) *SessionCache {
+	return &SessionCache{
+		next:    next,
+		ttl:     ttl,
+		entries: make(map[models.UserID]sessionEntry),
+		now:     time.Now,
+	}
+}
+
+// CheckSession accepts tokens issued not before a cached successful check,
+// other tokens are verified by the underlying checker.
+func (c *SessionCache) CheckSession(ctx context.Context, uid models.UserID, issuedAt time.Time) error {
+	if c.ttl <= 0 {
+		return c.next.CheckSession(ctx, uid, issuedAt)
+	}
+
+	c.mu.Lock()
+	now := c.now()
+	entry, ok := c.entries[uid]
+	gen := c.gen
+	c.mu.Unlock()
+
+	if ok && now.Before(entry.expires) && !issuedAt.Before(entry.issuedAt) {
+		return nil
+	}
+
+	err := c.next.CheckSession(ctx, uid, issuedAt)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.gen != gen {
+		return nil
+	}
+	// A valid entry is only replaced by an earlier token, which covers it
+	c.entries[uid] = sessionEntry{issuedAt: issuedAt, expires: now.Add(c.ttl)}
+	c.sweep(now)
+
+	return nil
+}
+
+// HandleEvent drops entries invalidated by the event.
+func (c *SessionCache) HandleEvent(e fanout.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch e.Kind {
+	case fanout.KindSessionRevoked:
+		delete(c.entries, e.UserID)
+	case fanout.KindResync:
+		clear(c.entries)
+	default:
+		return
+	}
+	c.gen++
+}
+
+// sweep removes expired entries at most once per TTL
+func (c *SessionCache) sweep(now time.Time) {
+	if now.Sub(c.swept) < c.ttl {
+		return
+	}
+	c.swept = now
+
+	for uid, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, uid)
+		}
+	}
+}
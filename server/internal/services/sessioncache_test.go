@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/server/internal/fanout"
+	"github.com/rycln/gokeep/server/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionCache_CheckSession(t *testing.T) {
+	ctx := context.Background()
+	issued := time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)
+
+	newCache := func(t *testing.T) (*SessionCache, *mocks.MocksessionChecker, *time.Time) {
+		ctrl := gomock.NewController(t)
+		mNext := mocks.NewMocksessionChecker(ctrl)
+		cache := NewSessionCache(mNext, time.Minute)
+		now := issued
+		cache.now = func() time.Time { return now }
+		return cache, mNext, &now
+	}
+
+	t.Run("successful check cached", func(t *testing.T) {
+		cache, mNext, _ := newCache(t)
+		mNext.EXPECT().CheckSession(ctx, testUserID, issued).Return(nil).Times(1)
+
+		assert.NoError(t, cache.CheckSession(ctx, testUserID, issued))
+		assert.NoError(t, cache.CheckSession(ctx, testUserID, issued))
+		assert.NoError(t, cache.CheckSession(ctx, testUserID, issued.Add(time.Second)), "later token is covered")
+	})
+
+	t.Run("earlier token checked again", func(t *testing.T) {
+		cache, mNext, _ := newCache(t)
+		earlier := issued.Add(-time.Hour)
+		gomock.InOrder(
+			mNext.EXPECT().CheckSession(ctx, testUserID, issued).Return(nil),
+			mNext.EXPECT().CheckSession(ctx, testUserID, earlier).Return(newErrRevoked(ErrSessionRevoked)),
+		)
+
+		assert.NoError(t, cache.CheckSession(ctx, testUserID, issued))
+		assert.ErrorIs(t, cache.CheckSession(ctx, testUserID, earlier), ErrSessionRevoked)
+	})
+
+	t.Run("failed check not cached", func(t *testing.T) {
+		cache, mNext, _ := newCache(t)
+		mNext.EXPECT().CheckSession(ctx, testUserID, issued).Return(newErrDisabled(ErrUserDisabled)).Times(2)
+
+		assert.ErrorIs(t, cache.CheckSession(ctx, testUserID, issued), ErrUserDisabled)
+		assert.ErrorIs(t, cache.CheckSession(ctx, testUserID, issued), ErrUserDisabled)
+	})
+
+	t.Run("entry expires", func(t *testing.T) {
+		cache, mNext, now := newCache(t)
+		mNext.EXPECT().CheckSession(ctx, testUserID, issued).Return(nil).Times(2)
+
+		assert.NoError(t, cache.CheckSession(ctx, testUserID, issued))
+		*now = now.Add(time.Minute)
+		assert.NoError(t, cache.CheckSession(ctx, testUserID, issued))
+	})
+
+	t.Run("revocation drops entry", func(t *testing.T) {
+		cache, mNext, _ := newCache(t)
+		gomock.InOrder(
+			mNext.EXPECT().CheckSession(ctx, testUserID, issued).Return(nil),
+			mNext.EXPECT().CheckSession(ctx, testUserID, issued).Return(newErrRevoked(ErrSessionRevoked)),
+		)
+
+		assert.NoError(t, cache.CheckSession(ctx, testUserID, issued))
+		cache.HandleEvent(fanout.Event{Kind: fanout.KindItemsChanged, UserID: testUserID})
+		cache.HandleEvent(fanout.Event{Kind: fanout.KindSessionRevoked, UserID: "other"})
+		assert.NoError(t, cache.CheckSession(ctx, testUserID, issued), "unrelated events keep entry")
+
+		cache.HandleEvent(fanout.Event{Kind: fanout.KindSessionRevoked, UserID: testUserID})
+		assert.ErrorIs(t, cache.CheckSession(ctx, testUserID, issued), ErrSessionRevoked)
+	})
+
+	t.Run("resync drops all entries", func(t *testing.T) {
+		cache, mNext, _ := newCache(t)
+		mNext.EXPECT().CheckSession(ctx, testUserID, issued).Return(nil).Times(2)
+
+		assert.NoError(t, cache.CheckSession(ctx, testUserID, issued))
+		cache.HandleEvent(fanout.Event{Kind: fanout.KindResync})
+		assert.NoError(t, cache.CheckSession(ctx, testUserID, issued))
+	})
+
+	t.Run("revocation during check not cached", func(t *testing.T) {
+		cache, mNext, _ := newCache(t)
+		gomock.InOrder(
+			mNext.EXPECT().CheckSession(ctx, testUserID, issued).DoAndReturn(
+				func(context.Context, any, time.Time) error {
+					cache.HandleEvent(fanout.Event{Kind: fanout.KindSessionRevoked, UserID: testUserID})
+					return nil
+				}),
+			mNext.EXPECT().CheckSession(ctx, testUserID, issued).Return(newErrRevoked(ErrSessionRevoked)),
+		)
+
+		assert.NoError(t, cache.CheckSession(ctx, testUserID, issued))
+		assert.ErrorIs(t, cache.CheckSession(ctx, testUserID, issued), ErrSessionRevoked)
+	})
+
+	t.Run("zero ttl disables cache", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mNext := mocks.NewMocksessionChecker(ctrl)
+		cache := NewSessionCache(mNext, 0)
+		mNext.EXPECT().CheckSession(ctx, testUserID, issued).Return(nil).Times(2)
+
+		assert.NoError(t, cache.CheckSession(ctx, testUserID, issued))
+		assert.NoError(t, cache.CheckSession(ctx, testUserID, issued))
+	})
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rycln/gokeep/shared/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// errSelfShare indicates an attempt to share an item with its owner
+var errSelfShare = errors.New("item can not be shared with its owner")
+
+// shareStorage defines persistence operations for shared items.
+type shareStorage interface {
+	AddShare(context.Context, *models.Share) error
+	GetSharedWith(context.Context, models.UserID) ([]models.Share, error)
+	DeleteShare(context.Context, models.ItemID, models.UserID, string) error
+}
+
+// recipientFetcher defines interface for resolving sharing recipients.
+type recipientFetcher interface {
+	GetPublicKey(context.Context, string) (*models.PublicKey, error)
+}
+
+// ShareService handles item sharing between accounts.
+// Items are encrypted by clients, so the service only routes opaque payloads.
+type ShareService struct {
+	strg  shareStorage
+	users recipientFetcher
+	auth  uidFetcher
+}
+
+// NewShareService creates a new ShareService instance.
+func NewShareService(strg shareStorage, users recipientFetcher, auth uidFetcher) *ShareService {
+	return &ShareService{
+		strg:  strg,
+		users: users,
+		auth:  auth,
+	}
+}
+
+// ShareItem shares an item of the current user with the recipient by username.
+func (s *ShareService) ShareItem(ctx context.Context, share *models.Share) error {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	recipient, err := s.users.GetPublicKey(ctx, share.Recipient)
+	if err != nil {
+		return err
+	}
+
+	if recipient.UserID == uid {
+		return errSelfShare
+	}
+
+	share.OwnerID = uid
+	share.RecipientID = recipient.UserID
+	share.SharedAt = time.Now()
+
+	return s.strg.AddShare(ctx, share)
+}
+
+// ListSharedWithMe returns items shared with the current user.
+func (s *ShareService) ListSharedWithMe(ctx context.Context) ([]models.Share, error) {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.strg.GetSharedWith(ctx, uid)
+}
+
+// RevokeShare removes access of the recipient to an item of the current user.
+func (s *ShareService) RevokeShare(ctx context.Context, id models.ItemID, recipient string) error {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.strg.DeleteShare(ctx, id, uid, recipient)
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/server/internal/services/mocks"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRecipientID = models.UserID("550e8400-e29b-41d4-a716-446655440002")
+	testItemID      = models.ItemID("550e8400-e29b-41d4-a716-446655440001")
+)
+
+func TestShareService_ShareItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockshareStorage(ctrl)
+	mUsers := mocks.NewMockrecipientFetcher(ctrl)
+	mAuth := mocks.NewMockuidFetcher(ctrl)
+
+	ctx := context.Background()
+
+	t.Run("successful share", func(t *testing.T) {
+		share := &models.Share{
+			ItemID:     testItemID,
+			Recipient:  "recipient",
+			WrappedKey: []byte("wrapped"),
+			Payload:    []byte("payload"),
+		}
+
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testUserID, nil),
+			mUsers.EXPECT().GetPublicKey(ctx, "recipient").Return(&models.PublicKey{UserID: testRecipientID}, nil),
+			mStrg.EXPECT().AddShare(ctx, gomock.Any()).DoAndReturn(
+				func(_ context.Context, s *models.Share) error {
+					assert.Equal(t, testUserID, s.OwnerID)
+					assert.Equal(t, testRecipientID, s.RecipientID)
+					assert.False(t, s.SharedAt.IsZero())
+					return nil
+				}),
+		)
+
+		s := NewShareService(mStrg, mUsers, mAuth)
+		err := s.ShareItem(ctx, share)
+		assert.NoError(t, err)
+	})
+
+	t.Run("share with self", func(t *testing.T) {
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testUserID, nil),
+			mUsers.EXPECT().GetPublicKey(ctx, "me").Return(&models.PublicKey{UserID: testUserID}, nil),
+		)
+
+		s := NewShareService(mStrg, mUsers, mAuth)
+		err := s.ShareItem(ctx, &models.Share{Recipient: "me"})
+		assert.ErrorIs(t, err, errSelfShare)
+	})
+
+	t.Run("recipient not found", func(t *testing.T) {
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testUserID, nil),
+			mUsers.EXPECT().GetPublicKey(ctx, "ghost").Return(nil, errTest),
+		)
+
+		s := NewShareService(mStrg, mUsers, mAuth)
+		err := s.ShareItem(ctx, &models.Share{Recipient: "ghost"})
+		assert.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("no user in context", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(models.UserID(""), errTest)
+
+		s := NewShareService(mStrg, mUsers, mAuth)
+		err := s.ShareItem(ctx, &models.Share{})
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestShareService_ListSharedWithMe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockshareStorage(ctrl)
+	mUsers := mocks.NewMockrecipientFetcher(ctrl)
+	mAuth := mocks.NewMockuidFetcher(ctrl)
+
+	ctx := context.Background()
+
+	t.Run("successful listing", func(t *testing.T) {
+		shares := []models.Share{{ItemID: testItemID, Owner: "owner"}}
+
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testRecipientID, nil),
+			mStrg.EXPECT().GetSharedWith(ctx, testRecipientID).Return(shares, nil),
+		)
+
+		s := NewShareService(mStrg, mUsers, mAuth)
+		res, err := s.ListSharedWithMe(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, shares, res)
+	})
+
+	t.Run("no user in context", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(models.UserID(""), errTest)
+
+		s := NewShareService(mStrg, mUsers, mAuth)
+		_, err := s.ListSharedWithMe(ctx)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestShareService_RevokeShare(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockshareStorage(ctrl)
+	mUsers := mocks.NewMockrecipientFetcher(ctrl)
+	mAuth := mocks.NewMockuidFetcher(ctrl)
+
+	ctx := context.Background()
+
+	t.Run("successful revoke", func(t *testing.T) {
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testUserID, nil),
+			mStrg.EXPECT().DeleteShare(ctx, testItemID, testUserID, "recipient").Return(nil),
+		)
+
+		s := NewShareService(mStrg, mUsers, mAuth)
+		err := s.RevokeShare(ctx, testItemID, "recipient")
+		assert.NoError(t, err)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		gomock.InOrder(
+			mAuth.EXPECT().GetUserIDFromCtx(ctx).Return(testUserID, nil),
+			mStrg.EXPECT().DeleteShare(ctx, testItemID, testUserID, "recipient").Return(errTest),
+		)
+
+		s := NewShareService(mStrg, mUsers, mAuth)
+		err := s.RevokeShare(ctx, testItemID, "recipient")
+		assert.ErrorIs(t, err, errTest)
+	})
+}
@@ -0,0 +1,17 @@
+package services
+
+// Storage combines storage operations of all services.
+// Every backend implements it as a whole, so the server is wired
+// to a single value whichever backend is selected.
+type Storage interface {
+	dbPinger
+	userStorage
+	itemStorage
+	roleFetcher
+	shareStorage
+	orgStorage
+	emergencyStorage
+	emergencyApprover
+	auditStorage
+	adminStorage
+}
@@ -2,12 +2,47 @@ package services
 
 import (
 	"context"
+	"errors"
 
+	"github.com/rycln/gokeep/server/internal/fanout"
+	"github.com/rycln/gokeep/server/internal/tracing"
 	"github.com/rycln/gokeep/shared/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
 
+// ErrQuotaExceeded indicates that personal items of the user reached the storage quota
+var ErrQuotaExceeded = errors.New("storage quota exceeded")
+
+// errQuota implements a structured quota error
+type errQuota struct {
+	err error // Underlying error
+}
+
+// Error implements the error interface
+func (err *errQuota) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap supports error inspection with errors.Is()/errors.As()
+func (err *errQuota) Unwrap() error {
+	return err.err
+}
+
+// IsErrQuota provides type checking method
+func (err *errQuota) IsErrQuota() bool {
+	return true
+}
+
+// newErrQuota constructs a new quota error
+func newErrQuota(err error) error {
+	return &errQuota{
+		err: err,
+	}
+}
+
 // itemFetcher defines interface for fetching user items.
 type itemFetcher interface {
 	GetUserItems(context.Context, models.UserID) ([]models.Item, error)
@@ -21,6 +56,12 @@ type itemAdder interface {
 // itemDeleter defines interface for deleting items.
 type itemDeleter interface {
 	DeleteItem(context.Context, models.ItemID, models.UserID) error
+	DeleteCollectionItem(context.Context, models.ItemID, models.CollectionID) error
+}
+
+// itemMeter defines interface for measuring stored personal items.
+type itemMeter interface {
+	GetUserDataSize(context.Context, models.UserID) (int64, error)
 }
 
 // itemStorage combines all item-related storage operations.
@@ -28,6 +69,12 @@ type itemStorage interface {
 	itemFetcher
 	itemAdder
 	itemDeleter
+	itemMeter
+}
+
+// roleFetcher defines interface for checking member role in collection organization.
+type roleFetcher interface {
+	GetCollectionRole(context.Context, models.CollectionID, models.UserID) (models.Role, error)
 }
 
 // uidFetcher defines interface for getting user ID from context.
@@ -37,26 +84,77 @@ type uidFetcher interface {
 
 // SyncService handles item synchronization operations.
 type SyncService struct {
-	strg itemStorage
-	auth uidFetcher
+	strg   itemStorage
+	roles  roleFetcher
+	auth   uidFetcher
+	audit  auditRecorder
+	events eventPublisher
+	quota  int64 // Bytes of personal items per user, zero means unlimited
 }
 
 // NewSyncService creates a new SyncService instance.
-func NewSyncService(strg itemStorage, auth uidFetcher) *SyncService {
+func NewSyncService(
+	strg itemStorage,
+	roles roleFetcher,
+	auth uidFetcher,
+	audit auditRecorder,
+	events eventPublisher,
+	quota int64,
+) *SyncService {
 	return &SyncService{
-		strg: strg,
-		auth: auth,
+		strg:   strg,
+		roles:  roles,
+		auth:   auth,
+		audit:  audit,
+		events: events,
+		quota:  quota,
 	}
 }
 
 // SyncItems synchronizes user items between client and server.
-func (s *SyncService) SyncItems(ctx context.Context, reqitems []models.Item) ([]models.Item, error) {
+// Personal items are always stored for the current user,
+// collection items require a role with write permission.
+// Once the quota is reached personal items can only be deleted.
+// Written changes are announced to watchers on every instance.
+func (s *SyncService) SyncItems(ctx context.Context, reqitems []models.Item) (items []models.Item, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "SyncService.SyncItems",
+		trace.WithAttributes(attribute.Int("sync.request_items", len(reqitems))))
+	defer func() { tracing.End(span, err) }()
+
 	uid, err := s.auth.GetUserIDFromCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer func() { s.audit.Record(ctx, uid, models.AuditSync, err) }()
+
+	err = s.checkQuota(ctx, reqitems, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	writable := make(map[models.CollectionID]bool)
+	defer s.announce(ctx, reqitems, uid, writable)
 
 	for _, item := range reqitems {
+		if item.CollectionID != "" {
+			err := s.checkWrite(ctx, writable, item.CollectionID, uid)
+			if err != nil {
+				return nil, err
+			}
+
+			item.UserID = ""
+			if item.IsDeleted {
+				err = s.strg.DeleteCollectionItem(ctx, item.ID, item.CollectionID)
+			} else {
+				err = s.strg.AddItem(ctx, &item)
+			}
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		item.UserID = uid
 		if item.IsDeleted {
 			err := s.strg.DeleteItem(ctx, item.ID, uid)
 			if err != nil {
@@ -74,6 +172,85 @@ func (s *SyncService) SyncItems(ctx context.Context, reqitems []models.Item) ([]
 	if err != nil {
 		return nil, err
 	}
+	span.SetAttributes(attribute.Int("sync.response_items", len(resitems)))
 
 	return resitems, nil
 }
+
+// announce publishes changes of written items, partially applied syncs included.
+// Collections are announced once write permission was confirmed.
+func (s *SyncService) announce(
+	ctx context.Context,
+	reqitems []models.Item,
+	uid models.UserID,
+	writable map[models.CollectionID]bool,
+) {
+	personal := false
+	for _, item := range reqitems {
+		if item.CollectionID == "" {
+			personal = true
+			break
+		}
+	}
+
+	if personal {
+		s.events.Publish(ctx, fanout.Event{Kind: fanout.KindItemsChanged, UserID: uid})
+	}
+	for cid, ok := range writable {
+		if ok {
+			s.events.Publish(ctx, fanout.Event{Kind: fanout.KindItemsChanged, CollectionID: cid})
+		}
+	}
+}
+
+// checkWrite verifies that user role permits writing to the collection
+// Results are cached for the duration of a single sync
+func (s *SyncService) checkWrite(
+	ctx context.Context,
+	writable map[models.CollectionID]bool,
+	cid models.CollectionID,
+	uid models.UserID,
+) error {
+	ok, checked := writable[cid]
+	if !checked {
+		role, err := s.roles.GetCollectionRole(ctx, cid, uid)
+		if err != nil {
+			return err
+		}
+		ok = role.CanWrite()
+		writable[cid] = ok
+	}
+
+	if !ok {
+		return newErrForbidden(ErrForbidden)
+	}
+	return nil
+}
+
+// checkQuota rejects writes of personal items when the user reached the quota
+// The check is soft: a single sync may exceed the quota, the next one is rejected
+func (s *SyncService) checkQuota(ctx context.Context, reqitems []models.Item, uid models.UserID) error {
+	if s.quota <= 0 {
+		return nil
+	}
+
+	writes := false
+	for _, item := range reqitems {
+		if item.CollectionID == "" && !item.IsDeleted {
+			writes = true
+			break
+		}
+	}
+	if !writes {
+		return nil
+	}
+
+	size, err := s.strg.GetUserDataSize(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if size >= s.quota {
+		return newErrQuota(ErrQuotaExceeded)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/server/internal/fanout"
 	"github.com/rycln/gokeep/server/internal/services/mocks"
 	"github.com/rycln/gokeep/shared/models"
 	"github.com/stretchr/testify/assert"
@@ -17,9 +18,10 @@ func TestNewSyncService(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockStorage := mocks.NewMockitemStorage(ctrl)
+		mockRoles := mocks.NewMockroleFetcher(ctrl)
 		mockAuth := mocks.NewMockuidFetcher(ctrl)
 
-		service := NewSyncService(mockStorage, mockAuth)
+		service := NewSyncService(mockStorage, mockRoles, mockAuth, noAudit(ctrl), noEvents(ctrl), 0)
 		assert.NotNil(t, service)
 	})
 }
@@ -30,12 +32,13 @@ func TestSyncItems(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockStorage := mocks.NewMockitemStorage(ctrl)
+		mockRoles := mocks.NewMockroleFetcher(ctrl)
 		mockAuth := mocks.NewMockuidFetcher(ctrl)
 
 		userID := models.UserID("user123")
 		ctx := context.Background()
 		reqItems := []models.Item{
-			{ID: models.ItemID("item1"), IsDeleted: false},
+			{ID: models.ItemID("item1"), UserID: userID, IsDeleted: false},
 			{ID: models.ItemID("item2"), IsDeleted: true},
 		}
 		resItems := []models.Item{
@@ -43,22 +46,22 @@ func TestSyncItems(t *testing.T) {
 		}
 
 		mockAuth.EXPECT().
-			GetUserIDFromCtx(ctx).
+			GetUserIDFromCtx(gomock.Any()).
 			Return(userID, nil)
 
 		mockStorage.EXPECT().
-			AddItem(ctx, &reqItems[0]).
+			AddItem(gomock.Any(), &reqItems[0]).
 			Return(nil)
 
 		mockStorage.EXPECT().
-			DeleteItem(ctx, models.ItemID("item2"), userID).
+			DeleteItem(gomock.Any(), models.ItemID("item2"), userID).
 			Return(nil)
 
 		mockStorage.EXPECT().
-			GetUserItems(ctx, userID).
+			GetUserItems(gomock.Any(), userID).
 			Return(resItems, nil)
 
-		service := NewSyncService(mockStorage, mockAuth)
+		service := NewSyncService(mockStorage, mockRoles, mockAuth, noAudit(ctrl), noEvents(ctrl), 0)
 		result, err := service.SyncItems(ctx, reqItems)
 
 		assert.NoError(t, err)
@@ -70,6 +73,7 @@ func TestSyncItems(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockStorage := mocks.NewMockitemStorage(ctrl)
+		mockRoles := mocks.NewMockroleFetcher(ctrl)
 		mockAuth := mocks.NewMockuidFetcher(ctrl)
 
 		testErr := errors.New("auth error")
@@ -77,7 +81,7 @@ func TestSyncItems(t *testing.T) {
 			GetUserIDFromCtx(gomock.Any()).
 			Return(models.UserID(""), testErr)
 
-		service := NewSyncService(mockStorage, mockAuth)
+		service := NewSyncService(mockStorage, mockRoles, mockAuth, noAudit(ctrl), noEvents(ctrl), 0)
 		_, err := service.SyncItems(context.Background(), []models.Item{})
 
 		assert.Equal(t, testErr, err)
@@ -88,11 +92,12 @@ func TestSyncItems(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockStorage := mocks.NewMockitemStorage(ctrl)
+		mockRoles := mocks.NewMockroleFetcher(ctrl)
 		mockAuth := mocks.NewMockuidFetcher(ctrl)
 
 		userID := models.UserID("user123")
 		testErr := errors.New("add error")
-		item := models.Item{ID: "item1", IsDeleted: false}
+		item := models.Item{ID: "item1", UserID: userID, IsDeleted: false}
 
 		mockAuth.EXPECT().
 			GetUserIDFromCtx(gomock.Any()).
@@ -102,7 +107,7 @@ func TestSyncItems(t *testing.T) {
 			AddItem(gomock.Any(), &item).
 			Return(testErr)
 
-		service := NewSyncService(mockStorage, mockAuth)
+		service := NewSyncService(mockStorage, mockRoles, mockAuth, noAudit(ctrl), noEvents(ctrl), 0)
 		_, err := service.SyncItems(context.Background(), []models.Item{item})
 
 		assert.Equal(t, testErr, err)
@@ -113,6 +118,7 @@ func TestSyncItems(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockStorage := mocks.NewMockitemStorage(ctrl)
+		mockRoles := mocks.NewMockroleFetcher(ctrl)
 		mockAuth := mocks.NewMockuidFetcher(ctrl)
 
 		userID := models.UserID("user123")
@@ -127,7 +133,7 @@ func TestSyncItems(t *testing.T) {
 			DeleteItem(gomock.Any(), models.ItemID("item1"), userID).
 			Return(testErr)
 
-		service := NewSyncService(mockStorage, mockAuth)
+		service := NewSyncService(mockStorage, mockRoles, mockAuth, noAudit(ctrl), noEvents(ctrl), 0)
 		_, err := service.SyncItems(context.Background(), []models.Item{item})
 
 		assert.Equal(t, testErr, err)
@@ -138,11 +144,12 @@ func TestSyncItems(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockStorage := mocks.NewMockitemStorage(ctrl)
+		mockRoles := mocks.NewMockroleFetcher(ctrl)
 		mockAuth := mocks.NewMockuidFetcher(ctrl)
 
 		userID := models.UserID("user123")
 		testErr := errors.New("fetch error")
-		item := models.Item{ID: "item1", IsDeleted: false}
+		item := models.Item{ID: "item1", UserID: userID, IsDeleted: false}
 
 		mockAuth.EXPECT().
 			GetUserIDFromCtx(gomock.Any()).
@@ -156,7 +163,7 @@ func TestSyncItems(t *testing.T) {
 			GetUserItems(gomock.Any(), userID).
 			Return(nil, testErr)
 
-		service := NewSyncService(mockStorage, mockAuth)
+		service := NewSyncService(mockStorage, mockRoles, mockAuth, noAudit(ctrl), noEvents(ctrl), 0)
 		_, err := service.SyncItems(context.Background(), []models.Item{item})
 
 		assert.Equal(t, testErr, err)
@@ -167,6 +174,7 @@ func TestSyncItems(t *testing.T) {
 		defer ctrl.Finish()
 
 		mockStorage := mocks.NewMockitemStorage(ctrl)
+		mockRoles := mocks.NewMockroleFetcher(ctrl)
 		mockAuth := mocks.NewMockuidFetcher(ctrl)
 
 		userID := models.UserID("user123")
@@ -180,10 +188,272 @@ func TestSyncItems(t *testing.T) {
 			GetUserItems(gomock.Any(), userID).
 			Return(resItems, nil)
 
-		service := NewSyncService(mockStorage, mockAuth)
+		service := NewSyncService(mockStorage, mockRoles, mockAuth, noAudit(ctrl), noEvents(ctrl), 0)
 		result, err := service.SyncItems(context.Background(), []models.Item{})
 
 		assert.NoError(t, err)
 		assert.Equal(t, resItems, result)
 	})
+
+	t.Run("should store personal items for current user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockitemStorage(ctrl)
+		mockRoles := mocks.NewMockroleFetcher(ctrl)
+		mockAuth := mocks.NewMockuidFetcher(ctrl)
+
+		userID := models.UserID("user123")
+		item := models.Item{ID: "item1", UserID: "someone else"}
+
+		mockAuth.EXPECT().
+			GetUserIDFromCtx(gomock.Any()).
+			Return(userID, nil)
+
+		mockStorage.EXPECT().
+			AddItem(gomock.Any(), &models.Item{ID: "item1", UserID: userID}).
+			Return(nil)
+
+		mockStorage.EXPECT().
+			GetUserItems(gomock.Any(), userID).
+			Return(nil, nil)
+
+		service := NewSyncService(mockStorage, mockRoles, mockAuth, noAudit(ctrl), noEvents(ctrl), 0)
+		_, err := service.SyncItems(context.Background(), []models.Item{item})
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestSyncCollectionItems(t *testing.T) {
+	userID := models.UserID("user123")
+	colID := models.CollectionID("col1")
+
+	t.Run("should write collection items for member", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockitemStorage(ctrl)
+		mockRoles := mocks.NewMockroleFetcher(ctrl)
+		mockAuth := mocks.NewMockuidFetcher(ctrl)
+
+		reqItems := []models.Item{
+			{ID: "item1", UserID: userID, CollectionID: colID},
+			{ID: "item2", CollectionID: colID, IsDeleted: true},
+		}
+
+		mockAuth.EXPECT().
+			GetUserIDFromCtx(gomock.Any()).
+			Return(userID, nil)
+
+		mockRoles.EXPECT().
+			GetCollectionRole(gomock.Any(), colID, userID).
+			Return(models.RoleMember, nil).
+			Times(1)
+
+		mockStorage.EXPECT().
+			AddItem(gomock.Any(), &models.Item{ID: "item1", CollectionID: colID}).
+			Return(nil)
+
+		mockStorage.EXPECT().
+			DeleteCollectionItem(gomock.Any(), models.ItemID("item2"), colID).
+			Return(nil)
+
+		mockStorage.EXPECT().
+			GetUserItems(gomock.Any(), userID).
+			Return(nil, nil)
+
+		service := NewSyncService(mockStorage, mockRoles, mockAuth, noAudit(ctrl), noEvents(ctrl), 0)
+		_, err := service.SyncItems(context.Background(), reqItems)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject writes of read-only member", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockitemStorage(ctrl)
+		mockRoles := mocks.NewMockroleFetcher(ctrl)
+		mockAuth := mocks.NewMockuidFetcher(ctrl)
+
+		mockAuth.EXPECT().
+			GetUserIDFromCtx(gomock.Any()).
+			Return(userID, nil)
+
+		mockRoles.EXPECT().
+			GetCollectionRole(gomock.Any(), colID, userID).
+			Return(models.RoleReadOnly, nil)
+
+		service := NewSyncService(mockStorage, mockRoles, mockAuth, noAudit(ctrl), noEvents(ctrl), 0)
+		_, err := service.SyncItems(context.Background(), []models.Item{{ID: "item1", CollectionID: colID}})
+
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("should return role lookup error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockitemStorage(ctrl)
+		mockRoles := mocks.NewMockroleFetcher(ctrl)
+		mockAuth := mocks.NewMockuidFetcher(ctrl)
+
+		testErr := errors.New("not a member")
+
+		mockAuth.EXPECT().
+			GetUserIDFromCtx(gomock.Any()).
+			Return(userID, nil)
+
+		mockRoles.EXPECT().
+			GetCollectionRole(gomock.Any(), colID, userID).
+			Return(models.Role(""), testErr)
+
+		service := NewSyncService(mockStorage, mockRoles, mockAuth, noAudit(ctrl), noEvents(ctrl), 0)
+		_, err := service.SyncItems(context.Background(), []models.Item{{ID: "item1", CollectionID: colID}})
+
+		assert.Equal(t, testErr, err)
+	})
+}
+
+func TestSyncItemsQuota(t *testing.T) {
+	userID := models.UserID("user123")
+	const quota = 1024
+
+	t.Run("should reject writes over quota", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockitemStorage(ctrl)
+		mockAuth := mocks.NewMockuidFetcher(ctrl)
+
+		mockAuth.EXPECT().
+			GetUserIDFromCtx(gomock.Any()).
+			Return(userID, nil)
+
+		mockStorage.EXPECT().
+			GetUserDataSize(gomock.Any(), userID).
+			Return(int64(quota), nil)
+
+		service := NewSyncService(mockStorage, mocks.NewMockroleFetcher(ctrl), mockAuth, noAudit(ctrl), noEvents(ctrl), quota)
+		_, err := service.SyncItems(context.Background(), []models.Item{{ID: "item1"}})
+
+		assert.ErrorIs(t, err, ErrQuotaExceeded)
+	})
+
+	t.Run("should allow deletions over quota", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockitemStorage(ctrl)
+		mockAuth := mocks.NewMockuidFetcher(ctrl)
+
+		mockAuth.EXPECT().
+			GetUserIDFromCtx(gomock.Any()).
+			Return(userID, nil)
+
+		mockStorage.EXPECT().
+			DeleteItem(gomock.Any(), models.ItemID("item1"), userID).
+			Return(nil)
+
+		mockStorage.EXPECT().
+			GetUserItems(gomock.Any(), userID).
+			Return(nil, nil)
+
+		service := NewSyncService(mockStorage, mocks.NewMockroleFetcher(ctrl), mockAuth, noAudit(ctrl), noEvents(ctrl), quota)
+		_, err := service.SyncItems(context.Background(), []models.Item{{ID: "item1", IsDeleted: true}})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should allow writes under quota", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockitemStorage(ctrl)
+		mockAuth := mocks.NewMockuidFetcher(ctrl)
+
+		mockAuth.EXPECT().
+			GetUserIDFromCtx(gomock.Any()).
+			Return(userID, nil)
+
+		mockStorage.EXPECT().
+			GetUserDataSize(gomock.Any(), userID).
+			Return(int64(quota-1), nil)
+
+		mockStorage.EXPECT().
+			AddItem(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		mockStorage.EXPECT().
+			GetUserItems(gomock.Any(), userID).
+			Return(nil, nil)
+
+		service := NewSyncService(mockStorage, mocks.NewMockroleFetcher(ctrl), mockAuth, noAudit(ctrl), noEvents(ctrl), quota)
+		_, err := service.SyncItems(context.Background(), []models.Item{{ID: "item1"}})
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestSyncItems_Announce(t *testing.T) {
+	userID := models.UserID("user123")
+	collectionID := models.CollectionID("collection")
+
+	t.Run("written personal and collection items announced", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockitemStorage(ctrl)
+		mockRoles := mocks.NewMockroleFetcher(ctrl)
+		mockAuth := mocks.NewMockuidFetcher(ctrl)
+		mockEvents := mocks.NewMockeventPublisher(ctrl)
+
+		mockAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(userID, nil)
+		mockRoles.EXPECT().GetCollectionRole(gomock.Any(), collectionID, userID).Return(models.RoleMember, nil)
+		mockStorage.EXPECT().AddItem(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		mockStorage.EXPECT().GetUserItems(gomock.Any(), userID).Return(nil, nil)
+		mockEvents.EXPECT().Publish(gomock.Any(), fanout.Event{Kind: fanout.KindItemsChanged, UserID: userID})
+		mockEvents.EXPECT().Publish(gomock.Any(), fanout.Event{Kind: fanout.KindItemsChanged, CollectionID: collectionID})
+
+		service := NewSyncService(mockStorage, mockRoles, mockAuth, noAudit(ctrl), mockEvents, 0)
+		_, err := service.SyncItems(context.Background(), []models.Item{
+			{ID: "item1"},
+			{ID: "item2", CollectionID: collectionID},
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("pull without changes not announced", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockitemStorage(ctrl)
+		mockAuth := mocks.NewMockuidFetcher(ctrl)
+
+		mockAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(userID, nil)
+		mockStorage.EXPECT().GetUserItems(gomock.Any(), userID).Return(nil, nil)
+
+		service := NewSyncService(mockStorage, mocks.NewMockroleFetcher(ctrl), mockAuth, noAudit(ctrl), mocks.NewMockeventPublisher(ctrl), 0)
+		_, err := service.SyncItems(context.Background(), nil)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("forbidden collection not announced", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRoles := mocks.NewMockroleFetcher(ctrl)
+		mockAuth := mocks.NewMockuidFetcher(ctrl)
+
+		mockAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(userID, nil)
+		mockRoles.EXPECT().GetCollectionRole(gomock.Any(), collectionID, userID).Return(models.RoleReadOnly, nil)
+
+		service := NewSyncService(mocks.NewMockitemStorage(ctrl), mockRoles, mockAuth, noAudit(ctrl), mocks.NewMockeventPublisher(ctrl), 0)
+		_, err := service.SyncItems(context.Background(), []models.Item{{ID: "item1", CollectionID: collectionID}})
+
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
 }
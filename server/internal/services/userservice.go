@@ -2,18 +2,156 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rycln/gokeep/server/internal/contextkeys"
+	"github.com/rycln/gokeep/server/internal/fanout"
+	"github.com/rycln/gokeep/server/internal/validation"
 	"github.com/rycln/gokeep/shared/models"
 )
 
 //go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
 
+// Request errors
+var (
+	// ErrNoRecovery indicates that account was registered without a recovery key
+	ErrNoRecovery = errors.New("recovery key is not set for user")
+
+	// ErrEmptyKeyPair indicates that client sent an incomplete sharing keypair
+	ErrEmptyKeyPair = errors.New("keypair is empty")
+)
+
+// errNoRecovery implements a structured missing recovery key error
+type errNoRecovery struct {
+	err error // Underlying error
+}
+
+// Error implements the error interface
+func (err *errNoRecovery) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap supports error inspection with errors.Is()/errors.As()
+func (err *errNoRecovery) Unwrap() error {
+	return err.err
+}
+
+// IsErrNoRecovery provides type checking method
+func (err *errNoRecovery) IsErrNoRecovery() bool {
+	return true
+}
+
+// newErrNoRecovery constructs a new missing recovery key error
+func newErrNoRecovery(err error) error {
+	return &errNoRecovery{
+		err: err,
+	}
+}
+
+// errEmptyKeyPair implements a structured incomplete keypair error
+type errEmptyKeyPair struct {
+	err error // Underlying error
+}
+
+// Error implements the error interface
+func (err *errEmptyKeyPair) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap supports error inspection with errors.Is()/errors.As()
+func (err *errEmptyKeyPair) Unwrap() error {
+	return err.err
+}
+
+// IsErrEmptyKeyPair provides type checking method
+func (err *errEmptyKeyPair) IsErrEmptyKeyPair() bool {
+	return true
+}
+
+// newErrEmptyKeyPair constructs a new incomplete keypair error
+func newErrEmptyKeyPair(err error) error {
+	return &errEmptyKeyPair{
+		err: err,
+	}
+}
+
+// Account state errors
+var (
+	// ErrUserDisabled indicates that the account was disabled by an operator
+	ErrUserDisabled = errors.New("account is disabled")
+
+	// ErrSessionRevoked indicates that the token was issued before a forced logout
+	ErrSessionRevoked = errors.New("session was revoked, log in again")
+)
+
+// errDisabled implements a structured disabled account error
+type errDisabled struct {
+	err error // Underlying error
+}
+
+// Error implements the error interface
+func (err *errDisabled) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap supports error inspection with errors.Is()/errors.As()
+func (err *errDisabled) Unwrap() error {
+	return err.err
+}
+
+// IsErrDisabled provides type checking method
+func (err *errDisabled) IsErrDisabled() bool {
+	return true
+}
+
+// newErrDisabled constructs a new disabled account error
+func newErrDisabled(err error) error {
+	return &errDisabled{
+		err: err,
+	}
+}
+
+// errRevoked implements a structured revoked session error
+type errRevoked struct {
+	err error // Underlying error
+}
+
+// Error implements the error interface
+func (err *errRevoked) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap supports error inspection with errors.Is()/errors.As()
+func (err *errRevoked) Unwrap() error {
+	return err.err
+}
+
+// IsErrRevoked provides type checking method
+func (err *errRevoked) IsErrRevoked() bool {
+	return true
+}
+
+// newErrRevoked constructs a new revoked session error
+func newErrRevoked(err error) error {
+	return &errRevoked{
+		err: err,
+	}
+}
+
 // userStorager defines persistence operations for user data
 type userStorage interface {
 	AddUser(context.Context, *models.UserDB) error
 	GetUserByUsername(context.Context, string) (*models.UserDB, error)
+	GetUserByID(context.Context, models.UserID) (*models.UserDB, error)
+	UpdateUserCredentials(context.Context, *models.UserDB) error
+	SetKeyPair(context.Context, models.UserID, *models.KeyPair) error
+	GetPublicKey(context.Context, string) (*models.PublicKey, error)
+	DeleteUser(context.Context, models.UserID) error
+	GetSession(context.Context, models.UserID) (*models.Session, error)
 }
 
 // passHasher defines password security operations
@@ -22,6 +160,34 @@ type passHasher interface {
 	Compare(string, string) error
 }
 
+// loginLockout defines tracking of failed logins per username
+type loginLockout interface {
+	Locked(string) time.Duration
+	Fail(string) time.Duration
+	Reset(string)
+}
+
+// errLocked indicates that logins are suspended after repeated failures
+type errLocked struct {
+	retry time.Duration // Remaining lockout
+}
+
+// Error implements the error interface
+func (err *errLocked) Error() string {
+	return fmt.Sprintf("too many failed login attempts, retry in %s", err.retry.Round(time.Second))
+}
+
+// RetryAfter returns time until logins are allowed again
+func (err *errLocked) RetryAfter() time.Duration {
+	return err.retry
+}
+
+// credentialsPolicy defines requirements for new account credentials
+type credentialsPolicy interface {
+	Validate(username, password string) error
+	ValidatePassword(username, password string) error
+}
+
 // jwtService defines JWT token operations
 type jwtCreator interface {
 	NewJWTString(models.UserID) (string, error)
@@ -29,40 +195,82 @@ type jwtCreator interface {
 
 // UserService implements user authentication business logic
 type UserService struct {
-	strg   userStorage
-	hasher passHasher
-	jwt    jwtCreator
+	strg    userStorage
+	hasher  passHasher
+	jwt     jwtCreator
+	audit   auditRecorder
+	lockout loginLockout
+	policy  credentialsPolicy
+	events  eventPublisher
+
+	unknownOnce sync.Once
+	unknownHash string // Compared for unknown usernames, created on first use
 }
 
 // NewUserService constructs a new UserService with required dependencies
-func NewUserService(strg userStorage, hasher passHasher, jwt jwtCreator) *UserService {
+func NewUserService(
+	strg userStorage,
+	hasher passHasher,
+	jwt jwtCreator,
+	audit auditRecorder,
+	lockout loginLockout,
+	policy credentialsPolicy,
+	events eventPublisher,
+) *UserService {
 	return &UserService{
-		strg:   strg,
-		hasher: hasher,
-		jwt:    jwt,
+		strg:    strg,
+		hasher:  hasher,
+		jwt:     jwt,
+		audit:   audit,
+		lockout: lockout,
+		policy:  policy,
+		events:  events,
 	}
 }
 
 // CreateUser handles new user registration:
-func (s *UserService) CreateUser(ctx context.Context, req *models.UserRegReq) (*models.User, error) {
+// Username is stored normalized, credentials violating the policy are rejected.
+func (s *UserService) CreateUser(ctx context.Context, req *models.UserRegReq) (user *models.User, err error) {
+	var created models.UserID // Stays empty until the account is stored
+	defer func() { s.audit.Record(ctx, created, models.AuditRegister, err) }()
+
+	username := validation.NormalizeUsername(req.Username)
+	err = s.policy.Validate(username, req.Password)
+	if err != nil {
+		return nil, err
+	}
+
 	hash, err := s.hasher.Hash(req.Password)
 	if err != nil {
 		return nil, err
 	}
 
+	var recoveryHash string
+	if req.RecoveryAuth != "" {
+		recoveryHash, err = s.hasher.Hash(req.RecoveryAuth)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	uid := models.UserID(uuid.NewString())
 
 	userDB := &models.UserDB{
-		ID:       uid,
-		Username: req.Username,
-		PassHash: hash,
-		Salt:     req.Salt,
+		ID:           uid,
+		Username:     username,
+		PassHash:     hash,
+		Salt:         req.Salt,
+		EncryptedKey: req.EncryptedKey,
+		RecoveryKey:  req.RecoveryKey,
+		RecoveryHash: recoveryHash,
+		KeyPair:      req.KeyPair,
 	}
 
 	err = s.strg.AddUser(ctx, userDB)
 	if err != nil {
 		return nil, err
 	}
+	created = uid
 
 	jwt, err := s.jwt.NewJWTString(uid)
 	if err != nil {
@@ -70,23 +278,55 @@ func (s *UserService) CreateUser(ctx context.Context, req *models.UserRegReq) (*
 	}
 
 	return &models.User{
-		ID:   uid,
-		JWT:  jwt,
-		Salt: req.Salt,
+		ID:           uid,
+		JWT:          jwt,
+		Salt:         req.Salt,
+		EncryptedKey: req.EncryptedKey,
+		KeyPair:      req.KeyPair,
 	}, nil
 }
 
 // AuthUser handles user authentication:
-func (s *UserService) AuthUser(ctx context.Context, req *models.UserLoginReq) (*models.User, error) {
-	userDB, err := s.strg.GetUserByUsername(ctx, req.Username)
+// Failed attempts against an existing account are recorded for its owner.
+// Repeated password mismatches lock the username out with growing delays.
+// Disabled accounts are reported only after the password matched.
+// Unknown usernames take the same hashing time and count towards lockout as wrong passwords,
+// so neither tells which accounts exist.
+func (s *UserService) AuthUser(ctx context.Context, req *models.UserLoginReq) (user *models.User, err error) {
+	var uid models.UserID
+	defer func() { s.audit.Record(ctx, uid, models.AuditLogin, err) }()
+
+	key := validation.UsernameKey(req.Username)
+	if retry := s.lockout.Locked(key); retry > 0 {
+		return nil, &errLocked{retry: retry}
+	}
+
+	userDB, err := s.strg.GetUserByUsername(ctx, validation.NormalizeUsername(req.Username))
+	var noUserErr interface{ IsErrNoUser() bool }
+	if errors.As(err, &noUserErr) {
+		_ = s.hasher.Compare(s.unknownUserHash(), req.Password)
+		if retry := s.lockout.Fail(key); retry > 0 {
+			return nil, &errLocked{retry: retry}
+		}
+		return nil, err
+	}
 	if err != nil {
 		return nil, err
 	}
+	uid = userDB.ID
 
 	err = s.hasher.Compare(userDB.PassHash, req.Password)
 	if err != nil {
+		if retry := s.lockout.Fail(key); retry > 0 {
+			return nil, &errLocked{retry: retry}
+		}
 		return nil, err
 	}
+	s.lockout.Reset(key)
+
+	if userDB.Disabled {
+		return nil, newErrDisabled(ErrUserDisabled)
+	}
 
 	jwt, err := s.jwt.NewJWTString(userDB.ID)
 	if err != nil {
@@ -94,12 +334,178 @@ func (s *UserService) AuthUser(ctx context.Context, req *models.UserLoginReq) (*
 	}
 
 	return &models.User{
-		ID:   userDB.ID,
-		JWT:  jwt,
-		Salt: userDB.Salt,
+		ID:           userDB.ID,
+		JWT:          jwt,
+		Salt:         userDB.Salt,
+		EncryptedKey: userDB.EncryptedKey,
+		KeyPair:      userDB.KeyPair,
 	}, nil
 }
 
+// unknownUserHash returns password hash standing in for a missing account.
+// A failed hashing leaves it empty, which only makes the comparison fail early.
+func (s *UserService) unknownUserHash() string {
+	s.unknownOnce.Do(func() {
+		s.unknownHash, _ = s.hasher.Hash(uuid.NewString())
+	})
+	return s.unknownHash
+}
+
+// RecoverUser authenticates user by recovery key verifier.
+// Returns the vault key wrapped with the recovery key instead of the password-wrapped one.
+func (s *UserService) RecoverUser(ctx context.Context, req *models.UserRecoverReq) (user *models.User, err error) {
+	var uid models.UserID
+	defer func() { s.audit.Record(ctx, uid, models.AuditRecover, err) }()
+
+	userDB, err := s.strg.GetUserByUsername(ctx, validation.NormalizeUsername(req.Username))
+	if err != nil {
+		return nil, err
+	}
+	uid = userDB.ID
+
+	if userDB.RecoveryHash == "" {
+		return nil, newErrNoRecovery(ErrNoRecovery)
+	}
+
+	err = s.hasher.Compare(userDB.RecoveryHash, req.RecoveryAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	if userDB.Disabled {
+		return nil, newErrDisabled(ErrUserDisabled)
+	}
+
+	jwt, err := s.jwt.NewJWTString(userDB.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.User{
+		ID:          userDB.ID,
+		JWT:         jwt,
+		Salt:        userDB.Salt,
+		RecoveryKey: userDB.RecoveryKey,
+		KeyPair:     userDB.KeyPair,
+	}, nil
+}
+
+// ChangePassword replaces credentials of the user taken from context.
+// The current password or recovery key is checked, so a leaked token alone cannot take over an account.
+// The new password must satisfy the same policy as on registration, recovery included.
+// Stored items are not affected because the vault key is only rewrapped.
+func (s *UserService) ChangePassword(ctx context.Context, req *models.PasswordChangeReq) (err error) {
+	uid, err := s.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { s.audit.Record(ctx, uid, models.AuditChangePassword, err) }()
+
+	userDB, err := s.strg.GetUserByID(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case req.CurrentPassword != "":
+		err = s.hasher.Compare(userDB.PassHash, req.CurrentPassword)
+	case userDB.RecoveryHash == "":
+		err = newErrNoRecovery(ErrNoRecovery)
+	default:
+		err = s.hasher.Compare(userDB.RecoveryHash, req.RecoveryAuth)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = s.policy.ValidatePassword(userDB.Username, req.Password)
+	if err != nil {
+		return err
+	}
+
+	hash, err := s.hasher.Hash(req.Password)
+	if err != nil {
+		return err
+	}
+
+	return s.strg.UpdateUserCredentials(ctx, &models.UserDB{
+		ID:           uid,
+		PassHash:     hash,
+		Salt:         req.Salt,
+		EncryptedKey: req.EncryptedKey,
+	})
+}
+
+// DeleteAccount removes the user taken from context with all personal data.
+// The password is checked again so a leaked token alone cannot delete an account.
+func (s *UserService) DeleteAccount(ctx context.Context, password string) (err error) {
+	uid, err := s.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { s.audit.Record(ctx, uid, models.AuditDeleteAccount, err) }()
+
+	userDB, err := s.strg.GetUserByID(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	err = s.hasher.Compare(userDB.PassHash, password)
+	if err != nil {
+		return err
+	}
+
+	err = s.strg.DeleteUser(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	// Other instances must stop accepting tokens of the deleted account
+	s.events.Publish(ctx, fanout.Event{Kind: fanout.KindSessionRevoked, UserID: uid})
+	return nil
+}
+
+// SetKeyPair stores sharing keypair of the user taken from context.
+// Used by accounts registered before sharing was introduced,
+// a keypair that is already set is never replaced.
+func (s *UserService) SetKeyPair(ctx context.Context, kp *models.KeyPair) error {
+	uid, err := s.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(kp.PublicKey) == 0 || kp.EncryptedPrivateKey == "" {
+		return newErrEmptyKeyPair(ErrEmptyKeyPair)
+	}
+
+	return s.strg.SetKeyPair(ctx, uid, kp)
+}
+
+// GetPublicKey returns public key of a user by username.
+func (s *UserService) GetPublicKey(ctx context.Context, username string) (*models.PublicKey, error) {
+	return s.strg.GetPublicKey(ctx, username)
+}
+
+// CheckSession verifies that a token issued at the given time is still valid:
+// The account must exist and be enabled, tokens issued before a forced logout are rejected.
+func (s *UserService) CheckSession(ctx context.Context, uid models.UserID, issuedAt time.Time) error {
+	session, err := s.strg.GetSession(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	if session.Disabled {
+		return newErrDisabled(ErrUserDisabled)
+	}
+	// Issue time may lose a unit of precision in the token encoding
+	revokedAt := session.RevokedAt.Truncate(tokenTimePrecision)
+	if !session.RevokedAt.IsZero() && issuedAt.Add(tokenTimePrecision).Before(revokedAt) {
+		return newErrRevoked(ErrSessionRevoked)
+	}
+
+	return nil
+}
+
 // GetUserIDFromCtx extracts user ID from context set by Auth middleware.
 func (s *UserService) GetUserIDFromCtx(ctx context.Context) (models.UserID, error) {
 	uid, ok := ctx.Value(contextkeys.UserID).(models.UserID)
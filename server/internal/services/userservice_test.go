@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/rycln/gokeep/server/internal/contextkeys"
+	"github.com/rycln/gokeep/server/internal/fanout"
 	"github.com/rycln/gokeep/server/internal/services/mocks"
 	"github.com/rycln/gokeep/shared/models"
 	"github.com/stretchr/testify/assert"
@@ -20,12 +22,33 @@ const (
 	testPassword     = "secret"
 	testPasswordHash = "hashed_secret"
 	testSalt         = "salt"
+	testEncryptedKey = "encrypted_key"
+	testRecoveryKey  = "recovery_key"
+	testRecoveryAuth = "recovery_auth"
+	testRecoveryHash = "hashed_recovery_auth"
 )
 
 var (
 	errTest = errors.New("test error")
 )
 
+// noPolicy returns credentials policy accepting anything
+func noPolicy(ctrl *gomock.Controller) *mocks.MockcredentialsPolicy {
+	policy := mocks.NewMockcredentialsPolicy(ctrl)
+	policy.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	policy.EXPECT().ValidatePassword(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return policy
+}
+
+// noLockout returns lockout tracker that never locks
+func noLockout(ctrl *gomock.Controller) *mocks.MockloginLockout {
+	lockout := mocks.NewMockloginLockout(ctrl)
+	lockout.EXPECT().Locked(gomock.Any()).Return(time.Duration(0)).AnyTimes()
+	lockout.EXPECT().Fail(gomock.Any()).Return(time.Duration(0)).AnyTimes()
+	lockout.EXPECT().Reset(gomock.Any()).AnyTimes()
+	return lockout
+}
+
 func TestUserService_CreateUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -59,7 +82,7 @@ func TestUserService_CreateUser(t *testing.T) {
 				}),
 		)
 
-		s := NewUserService(mStrg, mHasher, mJWT)
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
 		user, err := s.CreateUser(context.Background(), req)
 		assert.NoError(t, err)
 
@@ -77,7 +100,7 @@ func TestUserService_CreateUser(t *testing.T) {
 
 		mHasher.EXPECT().Hash(req.Password).Return("", errTest)
 
-		s := NewUserService(mStrg, mHasher, mJWT)
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
 		_, err := s.CreateUser(context.Background(), req)
 		assert.Error(t, err)
 	})
@@ -94,7 +117,7 @@ func TestUserService_CreateUser(t *testing.T) {
 			mStrg.EXPECT().AddUser(gomock.Any(), gomock.Any()).Return(errTest),
 		)
 
-		s := NewUserService(mStrg, mHasher, mJWT)
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
 		_, err := s.CreateUser(context.Background(), req)
 		assert.Error(t, err)
 	})
@@ -112,12 +135,54 @@ func TestUserService_CreateUser(t *testing.T) {
 			mJWT.EXPECT().NewJWTString(gomock.Any()).Return("", errTest),
 		)
 
-		s := NewUserService(mStrg, mHasher, mJWT)
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
 		_, err := s.CreateUser(context.Background(), req)
 		assert.Error(t, err)
 	})
 }
 
+func TestUserService_CreateUserPolicy(t *testing.T) {
+	t.Run("normalized username validated and stored", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mStrg := mocks.NewMockuserStorage(ctrl)
+		mHasher := mocks.NewMockpassHasher(ctrl)
+		mJWT := mocks.NewMockjwtCreator(ctrl)
+		mPolicy := mocks.NewMockcredentialsPolicy(ctrl)
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), mPolicy, noEvents(ctrl))
+
+		gomock.InOrder(
+			mPolicy.EXPECT().Validate("alice", testPassword).Return(nil),
+			mHasher.EXPECT().Hash(testPassword).Return(testPasswordHash, nil),
+			mStrg.EXPECT().AddUser(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, user *models.UserDB) error {
+					assert.Equal(t, "alice", user.Username)
+					return nil
+				}),
+			mJWT.EXPECT().NewJWTString(gomock.Any()).Return(testJWTToken, nil),
+		)
+
+		_, err := s.CreateUser(context.Background(), &models.UserRegReq{Username: " ａｌｉｃｅ", Password: testPassword})
+		assert.NoError(t, err)
+	})
+
+	t.Run("policy violation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mPolicy := mocks.NewMockcredentialsPolicy(ctrl)
+		s := NewUserService(mocks.NewMockuserStorage(ctrl), mocks.NewMockpassHasher(ctrl), mocks.NewMockjwtCreator(ctrl),
+			noAudit(ctrl), noLockout(ctrl), mPolicy, noEvents(ctrl))
+
+		verr := &models.ValidationError{Violations: []models.FieldViolation{{Field: models.FieldUsername}}}
+		mPolicy.EXPECT().Validate("", testPassword).Return(verr)
+
+		_, err := s.CreateUser(context.Background(), &models.UserRegReq{Password: testPassword})
+		assert.ErrorIs(t, err, verr)
+	})
+}
+
 func TestUserService_AuthUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -151,7 +216,7 @@ func TestUserService_AuthUser(t *testing.T) {
 			mJWT.EXPECT().NewJWTString(userDB.ID).Return(testJWTToken, nil),
 		)
 
-		s := NewUserService(mStrg, mHasher, mJWT)
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
 		user, err := s.AuthUser(context.Background(), req)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedUser, user)
@@ -165,7 +230,7 @@ func TestUserService_AuthUser(t *testing.T) {
 
 		mStrg.EXPECT().GetUserByUsername(gomock.Any(), req.Username).Return(nil, errTest)
 
-		s := NewUserService(mStrg, mHasher, mJWT)
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
 		_, err := s.AuthUser(context.Background(), req)
 		assert.Error(t, err)
 	})
@@ -188,7 +253,7 @@ func TestUserService_AuthUser(t *testing.T) {
 			mHasher.EXPECT().Compare(userDB.PassHash, req.Password).Return(errTest),
 		)
 
-		s := NewUserService(mStrg, mHasher, mJWT)
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
 		_, err := s.AuthUser(context.Background(), req)
 		assert.Error(t, err)
 	})
@@ -212,12 +277,489 @@ func TestUserService_AuthUser(t *testing.T) {
 			mJWT.EXPECT().NewJWTString(userDB.ID).Return("", errTest),
 		)
 
-		s := NewUserService(mStrg, mHasher, mJWT)
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		_, err := s.AuthUser(context.Background(), req)
+		assert.Error(t, err)
+	})
+
+	t.Run("disabled account", func(t *testing.T) {
+		req := &models.UserLoginReq{
+			Username: "testuser",
+			Password: testPassword,
+		}
+
+		userDB := &models.UserDB{
+			ID:       models.UserID(testUserID),
+			Username: req.Username,
+			PassHash: testPasswordHash,
+			Disabled: true,
+		}
+
+		gomock.InOrder(
+			mStrg.EXPECT().GetUserByUsername(gomock.Any(), req.Username).Return(userDB, nil),
+			mHasher.EXPECT().Compare(userDB.PassHash, req.Password).Return(nil),
+		)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		_, err := s.AuthUser(context.Background(), req)
+		assert.ErrorIs(t, err, ErrUserDisabled)
+	})
+}
+
+func TestUserService_CreateUserWithRecovery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockuserStorage(ctrl)
+	mHasher := mocks.NewMockpassHasher(ctrl)
+	mJWT := mocks.NewMockjwtCreator(ctrl)
+
+	req := &models.UserRegReq{
+		Username:     "testuser",
+		Password:     testPassword,
+		Salt:         testSalt,
+		EncryptedKey: testEncryptedKey,
+		RecoveryKey:  testRecoveryKey,
+		RecoveryAuth: testRecoveryAuth,
+	}
+
+	t.Run("stores wrapped keys and recovery hash", func(t *testing.T) {
+		gomock.InOrder(
+			mHasher.EXPECT().Hash(req.Password).Return(testPasswordHash, nil),
+			mHasher.EXPECT().Hash(req.RecoveryAuth).Return(testRecoveryHash, nil),
+			mStrg.EXPECT().AddUser(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, userDB *models.UserDB) error {
+					assert.Equal(t, testEncryptedKey, userDB.EncryptedKey)
+					assert.Equal(t, testRecoveryKey, userDB.RecoveryKey)
+					assert.Equal(t, testRecoveryHash, userDB.RecoveryHash)
+					return nil
+				}),
+			mJWT.EXPECT().NewJWTString(gomock.Any()).Return(testJWTToken, nil),
+		)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		user, err := s.CreateUser(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, testEncryptedKey, user.EncryptedKey)
+	})
+
+	t.Run("recovery hashing failed", func(t *testing.T) {
+		gomock.InOrder(
+			mHasher.EXPECT().Hash(req.Password).Return(testPasswordHash, nil),
+			mHasher.EXPECT().Hash(req.RecoveryAuth).Return("", errTest),
+		)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		_, err := s.CreateUser(context.Background(), req)
+		assert.Error(t, err)
+	})
+}
+
+// errTestNoUser mimics missing account error of the storage
+type errTestNoUser struct{}
+
+func (errTestNoUser) Error() string     { return "user not found" }
+func (errTestNoUser) IsErrNoUser() bool { return true }
+
+func TestUserService_AuthUserLockout(t *testing.T) {
+	req := &models.UserLoginReq{Username: "testuser", Password: "wrong_password"}
+	userDB := &models.UserDB{ID: testUserID, Username: req.Username, PassHash: testPasswordHash}
+
+	t.Run("unknown username hashed and counted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mStrg := mocks.NewMockuserStorage(ctrl)
+		mHasher := mocks.NewMockpassHasher(ctrl)
+		mLockout := mocks.NewMockloginLockout(ctrl)
+		s := NewUserService(mStrg, mHasher, mocks.NewMockjwtCreator(ctrl), noAudit(ctrl), mLockout, noPolicy(ctrl), noEvents(ctrl))
+
+		mLockout.EXPECT().Locked(req.Username).Return(time.Duration(0)).Times(2)
+		mStrg.EXPECT().GetUserByUsername(gomock.Any(), req.Username).Return(nil, errTestNoUser{}).Times(2)
+		mHasher.EXPECT().Hash(gomock.Any()).Return(testPasswordHash, nil)
+		mHasher.EXPECT().Compare(testPasswordHash, req.Password).Return(errTest).Times(2)
+		gomock.InOrder(
+			mLockout.EXPECT().Fail(req.Username).Return(time.Duration(0)),
+			mLockout.EXPECT().Fail(req.Username).Return(30*time.Second),
+		)
+
+		_, err := s.AuthUser(context.Background(), req)
+		assert.ErrorIs(t, err, errTestNoUser{})
+
+		_, err = s.AuthUser(context.Background(), req)
+		var locked interface{ RetryAfter() time.Duration }
+		require.ErrorAs(t, err, &locked)
+		assert.Equal(t, 30*time.Second, locked.RetryAfter())
+	})
+
+	t.Run("locked username not checked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mLockout := mocks.NewMockloginLockout(ctrl)
+		s := NewUserService(mocks.NewMockuserStorage(ctrl), mocks.NewMockpassHasher(ctrl), mocks.NewMockjwtCreator(ctrl), noAudit(ctrl), mLockout, noPolicy(ctrl), noEvents(ctrl))
+
+		mLockout.EXPECT().Locked(req.Username).Return(time.Minute)
+
+		_, err := s.AuthUser(context.Background(), req)
+		var locked interface{ RetryAfter() time.Duration }
+		require.ErrorAs(t, err, &locked)
+		assert.Equal(t, time.Minute, locked.RetryAfter())
+	})
+
+	t.Run("failure starting lockout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mStrg := mocks.NewMockuserStorage(ctrl)
+		mHasher := mocks.NewMockpassHasher(ctrl)
+		mLockout := mocks.NewMockloginLockout(ctrl)
+		s := NewUserService(mStrg, mHasher, mocks.NewMockjwtCreator(ctrl), noAudit(ctrl), mLockout, noPolicy(ctrl), noEvents(ctrl))
+
+		gomock.InOrder(
+			mLockout.EXPECT().Locked(req.Username).Return(time.Duration(0)),
+			mStrg.EXPECT().GetUserByUsername(gomock.Any(), req.Username).Return(userDB, nil),
+			mHasher.EXPECT().Compare(testPasswordHash, req.Password).Return(errTest),
+			mLockout.EXPECT().Fail(req.Username).Return(30*time.Second),
+		)
+
+		_, err := s.AuthUser(context.Background(), req)
+		var locked interface{ RetryAfter() time.Duration }
+		require.ErrorAs(t, err, &locked)
+		assert.Equal(t, 30*time.Second, locked.RetryAfter())
+	})
+
+	t.Run("success resets failures", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mStrg := mocks.NewMockuserStorage(ctrl)
+		mHasher := mocks.NewMockpassHasher(ctrl)
+		mJWT := mocks.NewMockjwtCreator(ctrl)
+		mLockout := mocks.NewMockloginLockout(ctrl)
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), mLockout, noPolicy(ctrl), noEvents(ctrl))
+
+		gomock.InOrder(
+			mLockout.EXPECT().Locked(req.Username).Return(time.Duration(0)),
+			mStrg.EXPECT().GetUserByUsername(gomock.Any(), req.Username).Return(userDB, nil),
+			mHasher.EXPECT().Compare(testPasswordHash, req.Password).Return(nil),
+			mLockout.EXPECT().Reset(req.Username),
+			mJWT.EXPECT().NewJWTString(testUserID).Return(testJWTToken, nil),
+		)
+
 		_, err := s.AuthUser(context.Background(), req)
+		assert.NoError(t, err)
+	})
+}
+
+func TestUserService_RecoverUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockuserStorage(ctrl)
+	mHasher := mocks.NewMockpassHasher(ctrl)
+	mJWT := mocks.NewMockjwtCreator(ctrl)
+
+	req := &models.UserRecoverReq{
+		Username:     "testuser",
+		RecoveryAuth: testRecoveryAuth,
+	}
+
+	userDB := &models.UserDB{
+		ID:           models.UserID(testUserID),
+		Username:     req.Username,
+		PassHash:     testPasswordHash,
+		Salt:         testSalt,
+		EncryptedKey: testEncryptedKey,
+		RecoveryKey:  testRecoveryKey,
+		RecoveryHash: testRecoveryHash,
+	}
+
+	t.Run("successful recovery", func(t *testing.T) {
+		gomock.InOrder(
+			mStrg.EXPECT().GetUserByUsername(gomock.Any(), req.Username).Return(userDB, nil),
+			mHasher.EXPECT().Compare(userDB.RecoveryHash, req.RecoveryAuth).Return(nil),
+			mJWT.EXPECT().NewJWTString(userDB.ID).Return(testJWTToken, nil),
+		)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		user, err := s.RecoverUser(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, &models.User{
+			ID:          userDB.ID,
+			JWT:         testJWTToken,
+			Salt:        testSalt,
+			RecoveryKey: testRecoveryKey,
+		}, user)
+	})
+
+	t.Run("recovery not configured", func(t *testing.T) {
+		legacy := *userDB
+		legacy.RecoveryHash = ""
+
+		mStrg.EXPECT().GetUserByUsername(gomock.Any(), req.Username).Return(&legacy, nil)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		_, err := s.RecoverUser(context.Background(), req)
+		assert.ErrorIs(t, err, ErrNoRecovery)
+	})
+
+	t.Run("wrong recovery key", func(t *testing.T) {
+		gomock.InOrder(
+			mStrg.EXPECT().GetUserByUsername(gomock.Any(), req.Username).Return(userDB, nil),
+			mHasher.EXPECT().Compare(userDB.RecoveryHash, req.RecoveryAuth).Return(errTest),
+		)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		_, err := s.RecoverUser(context.Background(), req)
+		assert.Error(t, err)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mStrg.EXPECT().GetUserByUsername(gomock.Any(), req.Username).Return(nil, errTest)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		_, err := s.RecoverUser(context.Background(), req)
+		assert.Error(t, err)
+	})
+
+	t.Run("disabled account", func(t *testing.T) {
+		disabled := *userDB
+		disabled.Disabled = true
+
+		gomock.InOrder(
+			mStrg.EXPECT().GetUserByUsername(gomock.Any(), req.Username).Return(&disabled, nil),
+			mHasher.EXPECT().Compare(userDB.RecoveryHash, req.RecoveryAuth).Return(nil),
+		)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		_, err := s.RecoverUser(context.Background(), req)
+		assert.ErrorIs(t, err, ErrUserDisabled)
+	})
+}
+
+func TestUserService_CheckSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockuserStorage(ctrl)
+	s := NewUserService(mStrg, mocks.NewMockpassHasher(ctrl), mocks.NewMockjwtCreator(ctrl), noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+
+	revokedAt := time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("never revoked", func(t *testing.T) {
+		mStrg.EXPECT().GetSession(gomock.Any(), models.UserID(testUserID)).Return(&models.Session{}, nil)
+
+		err := s.CheckSession(context.Background(), testUserID, time.Time{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("issued after forced logout", func(t *testing.T) {
+		mStrg.EXPECT().GetSession(gomock.Any(), models.UserID(testUserID)).Return(&models.Session{RevokedAt: revokedAt}, nil)
+
+		err := s.CheckSession(context.Background(), testUserID, revokedAt.Add(time.Second))
+		assert.NoError(t, err)
+	})
+
+	t.Run("issued before forced logout", func(t *testing.T) {
+		mStrg.EXPECT().GetSession(gomock.Any(), models.UserID(testUserID)).Return(&models.Session{RevokedAt: revokedAt}, nil)
+
+		err := s.CheckSession(context.Background(), testUserID, revokedAt.Add(-time.Second))
+		assert.ErrorIs(t, err, ErrSessionRevoked)
+	})
+
+	t.Run("issued before forced logout within the same second", func(t *testing.T) {
+		mStrg.EXPECT().GetSession(gomock.Any(), models.UserID(testUserID)).Return(&models.Session{RevokedAt: revokedAt.Add(300 * time.Millisecond)}, nil)
+
+		err := s.CheckSession(context.Background(), testUserID, revokedAt)
+		assert.ErrorIs(t, err, ErrSessionRevoked)
+	})
+
+	t.Run("token issued right after forced logout", func(t *testing.T) {
+		jwtService := NewJWTService(testKey, testExp)
+		mStrg.EXPECT().GetSession(gomock.Any(), models.UserID(testUserID)).Return(&models.Session{RevokedAt: time.Now()}, nil)
+
+		token, err := jwtService.NewJWTString(testUserID)
+		require.NoError(t, err)
+		_, issuedAt, err := jwtService.ParseJWT(token)
+		require.NoError(t, err)
+
+		err = s.CheckSession(context.Background(), testUserID, issuedAt)
+		assert.NoError(t, err)
+	})
+
+	t.Run("disabled account", func(t *testing.T) {
+		mStrg.EXPECT().GetSession(gomock.Any(), models.UserID(testUserID)).Return(&models.Session{Disabled: true}, nil)
+
+		err := s.CheckSession(context.Background(), testUserID, revokedAt)
+		assert.ErrorIs(t, err, ErrUserDisabled)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		mStrg.EXPECT().GetSession(gomock.Any(), models.UserID(testUserID)).Return(nil, errTest)
+
+		err := s.CheckSession(context.Background(), testUserID, revokedAt)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestUserService_ChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockuserStorage(ctrl)
+	mHasher := mocks.NewMockpassHasher(ctrl)
+	mJWT := mocks.NewMockjwtCreator(ctrl)
+
+	req := &models.PasswordChangeReq{
+		Password:        testPassword,
+		Salt:            testSalt,
+		EncryptedKey:    testEncryptedKey,
+		CurrentPassword: "old_secret",
+	}
+	recoverReq := &models.PasswordChangeReq{
+		Password:     testPassword,
+		Salt:         testSalt,
+		EncryptedKey: testEncryptedKey,
+		RecoveryAuth: testRecoveryAuth,
+	}
+	userDB := &models.UserDB{
+		ID:           models.UserID(testUserID),
+		PassHash:     "hashed_old_secret",
+		RecoveryHash: testRecoveryHash,
+	}
+
+	ctx := context.WithValue(context.Background(), contextkeys.UserID, models.UserID(testUserID))
+
+	t.Run("successful change", func(t *testing.T) {
+		gomock.InOrder(
+			mStrg.EXPECT().GetUserByID(gomock.Any(), models.UserID(testUserID)).Return(userDB, nil),
+			mHasher.EXPECT().Compare(userDB.PassHash, req.CurrentPassword).Return(nil),
+			mHasher.EXPECT().Hash(req.Password).Return(testPasswordHash, nil),
+			mStrg.EXPECT().UpdateUserCredentials(gomock.Any(), &models.UserDB{
+				ID:           models.UserID(testUserID),
+				PassHash:     testPasswordHash,
+				Salt:         testSalt,
+				EncryptedKey: testEncryptedKey,
+			}).Return(nil),
+		)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		err := s.ChangePassword(ctx, req)
+		assert.NoError(t, err)
+	})
+
+	t.Run("successful change with recovery key", func(t *testing.T) {
+		gomock.InOrder(
+			mStrg.EXPECT().GetUserByID(gomock.Any(), models.UserID(testUserID)).Return(userDB, nil),
+			mHasher.EXPECT().Compare(testRecoveryHash, testRecoveryAuth).Return(nil),
+			mHasher.EXPECT().Hash(recoverReq.Password).Return(testPasswordHash, nil),
+			mStrg.EXPECT().UpdateUserCredentials(gomock.Any(), gomock.Any()).Return(nil),
+		)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		err := s.ChangePassword(ctx, recoverReq)
+		assert.NoError(t, err)
+	})
+
+	t.Run("wrong current password", func(t *testing.T) {
+		gomock.InOrder(
+			mStrg.EXPECT().GetUserByID(gomock.Any(), models.UserID(testUserID)).Return(userDB, nil),
+			mHasher.EXPECT().Compare(userDB.PassHash, req.CurrentPassword).Return(errTest),
+		)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		err := s.ChangePassword(ctx, req)
+		assert.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("recovery key is not set", func(t *testing.T) {
+		mStrg.EXPECT().GetUserByID(gomock.Any(), models.UserID(testUserID)).
+			Return(&models.UserDB{ID: models.UserID(testUserID)}, nil)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		err := s.ChangePassword(ctx, recoverReq)
+		assert.ErrorIs(t, err, ErrNoRecovery)
+	})
+
+	t.Run("weak recovered password", func(t *testing.T) {
+		mPolicy := mocks.NewMockcredentialsPolicy(ctrl)
+		verr := &models.ValidationError{Violations: []models.FieldViolation{{Field: models.FieldPassword}}}
+		gomock.InOrder(
+			mStrg.EXPECT().GetUserByID(gomock.Any(), models.UserID(testUserID)).
+				Return(&models.UserDB{ID: models.UserID(testUserID), Username: "testuser", RecoveryHash: testRecoveryHash}, nil),
+			mHasher.EXPECT().Compare(testRecoveryHash, testRecoveryAuth).Return(nil),
+			mPolicy.EXPECT().ValidatePassword("testuser", recoverReq.Password).Return(verr),
+		)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), mPolicy, noEvents(ctrl))
+		err := s.ChangePassword(ctx, recoverReq)
+		assert.ErrorIs(t, err, verr)
+	})
+
+	t.Run("no user in context", func(t *testing.T) {
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		err := s.ChangePassword(context.Background(), req)
+		assert.ErrorIs(t, err, errNoUserID)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		gomock.InOrder(
+			mStrg.EXPECT().GetUserByID(gomock.Any(), models.UserID(testUserID)).Return(userDB, nil),
+			mHasher.EXPECT().Compare(userDB.PassHash, req.CurrentPassword).Return(nil),
+			mHasher.EXPECT().Hash(req.Password).Return(testPasswordHash, nil),
+			mStrg.EXPECT().UpdateUserCredentials(gomock.Any(), gomock.Any()).Return(errTest),
+		)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		err := s.ChangePassword(ctx, req)
 		assert.Error(t, err)
 	})
 }
 
+func TestUserService_DeleteAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockuserStorage(ctrl)
+	mHasher := mocks.NewMockpassHasher(ctrl)
+	mJWT := mocks.NewMockjwtCreator(ctrl)
+
+	ctx := context.WithValue(context.Background(), contextkeys.UserID, models.UserID(testUserID))
+	userDB := &models.UserDB{ID: models.UserID(testUserID), PassHash: testPasswordHash}
+
+	t.Run("successful deletion", func(t *testing.T) {
+		mEvents := mocks.NewMockeventPublisher(ctrl)
+		gomock.InOrder(
+			mStrg.EXPECT().GetUserByID(gomock.Any(), models.UserID(testUserID)).Return(userDB, nil),
+			mHasher.EXPECT().Compare(testPasswordHash, testPassword).Return(nil),
+			mStrg.EXPECT().DeleteUser(gomock.Any(), models.UserID(testUserID)).Return(nil),
+			mEvents.EXPECT().Publish(gomock.Any(), fanout.Event{Kind: fanout.KindSessionRevoked, UserID: models.UserID(testUserID)}),
+		)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), mEvents)
+		err := s.DeleteAccount(ctx, testPassword)
+		assert.NoError(t, err)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		gomock.InOrder(
+			mStrg.EXPECT().GetUserByID(gomock.Any(), models.UserID(testUserID)).Return(userDB, nil),
+			mHasher.EXPECT().Compare(testPasswordHash, "wrong").Return(errTest),
+		)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), mocks.NewMockeventPublisher(ctrl))
+		err := s.DeleteAccount(ctx, "wrong")
+		assert.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("no user in context", func(t *testing.T) {
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		err := s.DeleteAccount(context.Background(), testPassword)
+		assert.ErrorIs(t, err, errNoUserID)
+	})
+}
+
 func TestUserService_GetUserIDFromCtx(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -255,3 +797,74 @@ func TestUserService_GetUserIDFromCtx(t *testing.T) {
 		assert.Equal(t, errNoUserID, err)
 	})
 }
+
+func TestUserService_SetKeyPair(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockuserStorage(ctrl)
+	mHasher := mocks.NewMockpassHasher(ctrl)
+	mJWT := mocks.NewMockjwtCreator(ctrl)
+
+	kp := &models.KeyPair{
+		PublicKey:           []byte("public_key"),
+		EncryptedPrivateKey: "encrypted_private_key",
+	}
+
+	ctx := context.WithValue(context.Background(), contextkeys.UserID, testUserID)
+
+	t.Run("successful update", func(t *testing.T) {
+		mStrg.EXPECT().SetKeyPair(gomock.Any(), testUserID, kp).Return(nil)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		err := s.SetKeyPair(ctx, kp)
+		assert.NoError(t, err)
+	})
+
+	t.Run("no user in context", func(t *testing.T) {
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		err := s.SetKeyPair(context.Background(), kp)
+		assert.ErrorIs(t, err, errNoUserID)
+	})
+
+	t.Run("empty keypair", func(t *testing.T) {
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		err := s.SetKeyPair(ctx, &models.KeyPair{})
+		assert.ErrorIs(t, err, ErrEmptyKeyPair)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		mStrg.EXPECT().SetKeyPair(gomock.Any(), testUserID, kp).Return(errTest)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		err := s.SetKeyPair(ctx, kp)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestUserService_GetPublicKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockuserStorage(ctrl)
+	mHasher := mocks.NewMockpassHasher(ctrl)
+	mJWT := mocks.NewMockjwtCreator(ctrl)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		pk := &models.PublicKey{UserID: testUserID, Key: []byte("public_key")}
+		mStrg.EXPECT().GetPublicKey(gomock.Any(), "testuser").Return(pk, nil)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		res, err := s.GetPublicKey(context.Background(), "testuser")
+		require.NoError(t, err)
+		assert.Equal(t, pk, res)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		mStrg.EXPECT().GetPublicKey(gomock.Any(), "testuser").Return(nil, errTest)
+
+		s := NewUserService(mStrg, mHasher, mJWT, noAudit(ctrl), noLockout(ctrl), noPolicy(ctrl), noEvents(ctrl))
+		_, err := s.GetPublicKey(context.Background(), "testuser")
+		assert.ErrorIs(t, err, errTest)
+	})
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/rycln/gokeep/server/internal/fanout"
+	"github.com/rycln/gokeep/shared/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// eventPublisher defines broadcasting of changes to every server instance
+type eventPublisher interface {
+	Publish(context.Context, fanout.Event)
+}
+
+// eventSubscriber defines receiving of changes made on any server instance
+type eventSubscriber interface {
+	Subscribe(fanout.Handler) func()
+}
+
+// pendingChanges collects changes not yet sent to a watcher.
+// Publishers never block, the watcher is woken through ready.
+type pendingChanges struct {
+	mu          sync.Mutex
+	all         bool // Personal items or everything after missed events
+	collections map[models.CollectionID]bool
+	revoked     bool
+	ready       chan struct{}
+}
+
+// newPendingChanges creates an empty change set
+func newPendingChanges() *pendingChanges {
+	return &pendingChanges{
+		collections: make(map[models.CollectionID]bool),
+		ready:       make(chan struct{}, 1),
+	}
+}
+
+// add records the change and wakes the watcher
+func (p *pendingChanges) add(update func(*pendingChanges)) {
+	p.mu.Lock()
+	update(p)
+	p.mu.Unlock()
+
+	select {
+	case p.ready <- struct{}{}:
+	default:
+	}
+}
+
+// take returns collected changes and resets the set
+func (p *pendingChanges) take() (all bool, collections []models.CollectionID, revoked bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for cid := range p.collections {
+		collections = append(collections, cid)
+	}
+	all, revoked = p.all, p.revoked
+	p.all = false
+	clear(p.collections)
+
+	return all, collections, revoked
+}
+
+// WatchService notifies connected clients about item changes made on any instance.
+type WatchService struct {
+	events eventSubscriber
+	roles  roleFetcher
+	auth   uidFetcher
+}
+
+// NewWatchService creates a new WatchService instance.
+func NewWatchService(events eventSubscriber, roles roleFetcher, auth uidFetcher) *WatchService {
+	return &WatchService{
+		events: events,
+		roles:  roles,
+		auth:   auth,
+	}
+}
+
+// Watch calls notify for changes visible to the user taken from context until
+// the context is canceled. Empty collection ID means personal items or any items
+// after events were missed. Changes made while notify runs are coalesced.
+// Revoked sessions end the watch with an error.
+func (s *WatchService) Watch(ctx context.Context, notify func(models.CollectionID) error) error {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := newPendingChanges()
+	unsubscribe := s.events.Subscribe(func(e fanout.Event) {
+		switch {
+		case e.Kind == fanout.KindSessionRevoked && e.UserID == uid:
+			pending.add(func(p *pendingChanges) { p.revoked = true })
+		case e.Kind == fanout.KindResync:
+			pending.add(func(p *pendingChanges) { p.all = true })
+		case e.Kind == fanout.KindItemsChanged && e.UserID == uid:
+			pending.add(func(p *pendingChanges) { p.all = true })
+		case e.Kind == fanout.KindItemsChanged && e.CollectionID != "":
+			pending.add(func(p *pendingChanges) { p.collections[e.CollectionID] = true })
+		}
+	})
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-pending.ready:
+		}
+
+		all, collections, revoked := pending.take()
+		if revoked {
+			return newErrRevoked(ErrSessionRevoked)
+		}
+		err := s.send(ctx, uid, all, collections, notify)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// send notifies about collected changes, collections the user has no access to are skipped
+func (s *WatchService) send(
+	ctx context.Context,
+	uid models.UserID,
+	all bool,
+	collections []models.CollectionID,
+	notify func(models.CollectionID) error,
+) error {
+	if all {
+		return notify("")
+	}
+
+	for _, cid := range collections {
+		_, err := s.roles.GetCollectionRole(ctx, cid, uid)
+		var noMember interface{ IsErrNoMember() bool }
+		if errors.As(err, &noMember) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		err = notify(cid)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
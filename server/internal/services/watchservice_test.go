@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/gokeep/server/internal/fanout"
+	"github.com/rycln/gokeep/server/internal/services/mocks"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noEvents returns publisher accepting any events
+func noEvents(ctrl *gomock.Controller) *mocks.MockeventPublisher {
+	events := mocks.NewMockeventPublisher(ctrl)
+	events.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
+	return events
+}
+
+// errTestNoMember mimics storage error of a missing membership
+type errTestNoMember struct{}
+
+func (errTestNoMember) Error() string       { return "not a member" }
+func (errTestNoMember) IsErrNoMember() bool { return true }
+
+// watchRun is a watch running in background
+type watchRun struct {
+	handler fanout.Handler
+	notes   chan models.CollectionID
+	done    chan error
+	cancel  context.CancelFunc
+}
+
+// startWatch runs the watch of the test user and returns its event handler
+func startWatch(t *testing.T, ctrl *gomock.Controller, roles roleFetcher) *watchRun {
+	t.Helper()
+
+	mSub := mocks.NewMockeventSubscriber(ctrl)
+	mAuth := mocks.NewMockuidFetcher(ctrl)
+	mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+
+	handlers := make(chan fanout.Handler, 1)
+	unsubscribed := make(chan struct{})
+	mSub.EXPECT().Subscribe(gomock.Any()).DoAndReturn(func(h fanout.Handler) func() {
+		handlers <- h
+		return func() { close(unsubscribed) }
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &watchRun{
+		notes:  make(chan models.CollectionID, 16),
+		done:   make(chan error, 1),
+		cancel: cancel,
+	}
+	go func() {
+		run.done <- NewWatchService(mSub, roles, mAuth).Watch(ctx, func(cid models.CollectionID) error {
+			run.notes <- cid
+			return nil
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-unsubscribed
+	})
+
+	run.handler = <-handlers
+	return run
+}
+
+// note waits for the next notification
+func (r *watchRun) note(t *testing.T) models.CollectionID {
+	t.Helper()
+
+	select {
+	case cid := <-r.notes:
+		return cid
+	case <-time.After(time.Second):
+		t.Fatal("no notification")
+		return ""
+	}
+}
+
+func TestWatchService_Watch(t *testing.T) {
+	t.Run("personal changes of the user notified", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		run := startWatch(t, ctrl, mocks.NewMockroleFetcher(ctrl))
+
+		run.handler(fanout.Event{Kind: fanout.KindItemsChanged, UserID: "other"})
+		run.handler(fanout.Event{Kind: fanout.KindItemsChanged, UserID: testUserID})
+
+		assert.Equal(t, models.CollectionID(""), run.note(t))
+		assert.Empty(t, run.notes)
+	})
+
+	t.Run("collection changes notified to members only", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mRoles := mocks.NewMockroleFetcher(ctrl)
+		mRoles.EXPECT().GetCollectionRole(gomock.Any(), models.CollectionID("foreign"), testUserID).
+			Return(models.Role(""), errTestNoMember{})
+		mRoles.EXPECT().GetCollectionRole(gomock.Any(), models.CollectionID("team"), testUserID).
+			Return(models.RoleReadOnly, nil)
+		run := startWatch(t, ctrl, mRoles)
+
+		run.handler(fanout.Event{Kind: fanout.KindItemsChanged, CollectionID: "foreign"})
+		run.handler(fanout.Event{Kind: fanout.KindItemsChanged, CollectionID: "team"})
+
+		assert.Equal(t, models.CollectionID("team"), run.note(t))
+	})
+
+	t.Run("missed events notified as full change", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		run := startWatch(t, ctrl, mocks.NewMockroleFetcher(ctrl))
+
+		run.handler(fanout.Event{Kind: fanout.KindResync})
+
+		assert.Equal(t, models.CollectionID(""), run.note(t))
+	})
+
+	t.Run("revoked session ends watch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		run := startWatch(t, ctrl, mocks.NewMockroleFetcher(ctrl))
+
+		run.handler(fanout.Event{Kind: fanout.KindSessionRevoked, UserID: "other"})
+		run.handler(fanout.Event{Kind: fanout.KindSessionRevoked, UserID: testUserID})
+
+		err := <-run.done
+		var revoked interface{ IsErrRevoked() bool }
+		assert.ErrorAs(t, err, &revoked)
+	})
+
+	t.Run("canceled watch unsubscribed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		run := startWatch(t, ctrl, mocks.NewMockroleFetcher(ctrl))
+
+		run.cancel()
+		assert.ErrorIs(t, <-run.done, context.Canceled)
+	})
+
+	t.Run("no user in context", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mAuth := mocks.NewMockuidFetcher(ctrl)
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(models.UserID(""), errNoUserID)
+
+		s := NewWatchService(mocks.NewMockeventSubscriber(ctrl), mocks.NewMockroleFetcher(ctrl), mAuth)
+		err := s.Watch(context.Background(), func(models.CollectionID) error { return nil })
+		require.ErrorIs(t, err, errNoUserID)
+	})
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rycln/gokeep/server/internal/validation"
+	"github.com/rycln/gokeep/shared/models"
+)
+
+// AdminStorage handles database operations of account management by operators.
+type AdminStorage struct {
+	db *sql.DB
+}
+
+// NewAdminStorage creates a new AdminStorage instance.
+func NewAdminStorage(db *sql.DB) *AdminStorage {
+	return &AdminStorage{db: db}
+}
+
+// ListUsers retrieves accounts whose username contains the query, ordered by username.
+// Only accounts after the given username are returned, empty starts from the first one.
+func (s *AdminStorage) ListUsers(
+	ctx context.Context,
+	query string,
+	after string,
+	limit int,
+) (users []models.AdminUser, err error) {
+	rows, err := s.db.QueryContext(ctx, sqlListUsers,
+		escapeLike(validation.UsernameKey(query)), validation.UsernameKey(after), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rowsCloseErr := rows.Close(); rowsCloseErr != nil {
+			err = fmt.Errorf("%v; rows close failed: %w", err, rowsCloseErr)
+		}
+	}()
+
+	for rows.Next() {
+		var user models.AdminUser
+		err = rows.Scan(&user.ID, &user.Username, &user.Disabled)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// likeEscaper escapes wildcards of LIKE patterns, so queries match literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike returns the string as a literal part of LIKE pattern
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// SetUserDisabled disables or enables an account.
+// Disabling also revokes all tokens issued so far.
+func (s *AdminStorage) SetUserDisabled(ctx context.Context, uid models.UserID, disabled bool) error {
+	res, err := s.db.ExecContext(ctx, sqlSetUserDisabled, disabled, time.Now(), uid)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return newErrNoUser(ErrNoUser)
+	}
+
+	return nil
+}
+
+// RevokeSessions invalidates all tokens issued to the user so far.
+func (s *AdminStorage) RevokeSessions(ctx context.Context, uid models.UserID) error {
+	res, err := s.db.ExecContext(ctx, sqlRevokeSessions, time.Now(), uid)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return newErrNoUser(ErrNoUser)
+	}
+
+	return nil
+}
+
+// GetUsage counts personal items, their size, shares and memberships of the user.
+func (s *AdminStorage) GetUsage(ctx context.Context, uid models.UserID) (*models.Usage, error) {
+	var usage models.Usage
+	err := s.db.QueryRowContext(ctx, sqlGetUsage, uid).
+		Scan(&usage.Items, &usage.Bytes, &usage.Shares, &usage.Orgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &usage, nil
+}
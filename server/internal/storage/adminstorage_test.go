@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminStorage_ListUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewAdminStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlListUsers)
+
+	t.Run("page of matching users", func(t *testing.T) {
+		rows := mock.NewRows([]string{"id", "username", "disabled"}).
+			AddRow(testUserID, "alice", false).
+			AddRow("660e8400-e29b-41d4-a716-446655440000", "malice", true)
+
+		mock.ExpectQuery(expectedQuery).
+			WithArgs("lic", "", 2).
+			WillReturnRows(rows)
+
+		users, err := strg.ListUsers(context.Background(), "lic", "", 2)
+		require.NoError(t, err)
+		assert.Equal(t, []models.AdminUser{
+			{ID: testUserID, Username: "alice"},
+			{ID: "660e8400-e29b-41d4-a716-446655440000", Username: "malice", Disabled: true},
+		}, users)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("wildcards escaped", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(`50\%\_off\\`, "", 2).
+			WillReturnRows(mock.NewRows([]string{"id", "username", "disabled"}))
+
+		users, err := strg.ListUsers(context.Background(), `50%_off\`, "", 2)
+		require.NoError(t, err)
+		assert.Empty(t, users)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs("", "alice", 2).
+			WillReturnError(errTest)
+
+		_, err := strg.ListUsers(context.Background(), "", "alice", 2)
+		assert.ErrorIs(t, err, errTest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAdminStorage_SetUserDisabled(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewAdminStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlSetUserDisabled)
+
+	t.Run("user disabled", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(true, sqlmock.AnyArg(), testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.SetUserDisabled(context.Background(), testUserID, true)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user not found error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(false, sqlmock.AnyArg(), testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := strg.SetUserDisabled(context.Background(), testUserID, false)
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAdminStorage_RevokeSessions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewAdminStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlRevokeSessions)
+
+	t.Run("sessions revoked", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(sqlmock.AnyArg(), testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.RevokeSessions(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user not found error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(sqlmock.AnyArg(), testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := strg.RevokeSessions(context.Background(), testUserID)
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAdminStorage_GetUsage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewAdminStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlGetUsage)
+
+	t.Run("usage counted", func(t *testing.T) {
+		rows := mock.NewRows([]string{"items", "bytes", "shares", "orgs"}).
+			AddRow(12, 4096, 2, 1)
+
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testUserID).
+			WillReturnRows(rows)
+
+		usage, err := strg.GetUsage(context.Background(), testUserID)
+		require.NoError(t, err)
+		assert.Equal(t, &models.Usage{Items: 12, Bytes: 4096, Shares: 2, Orgs: 1}, usage)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testUserID).
+			WillReturnError(sql.ErrConnDone)
+
+		_, err := strg.GetUsage(context.Background(), testUserID)
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/rycln/gokeep/shared/models"
+)
+
+// AuditStorage handles database operations for the append-only audit log.
+type AuditStorage struct {
+	db *sql.DB
+}
+
+// NewAuditStorage creates a new AuditStorage instance.
+func NewAuditStorage(db *sql.DB) *AuditStorage {
+	return &AuditStorage{db: db}
+}
+
+// AddEvent appends an event to the audit log.
+func (s *AuditStorage) AddEvent(ctx context.Context, event *models.AuditEvent) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		sqlAddAuditEvent,
+		nullable(string(event.UserID)),
+		event.Device,
+		event.IP,
+		event.Action,
+		event.Result,
+		event.CreatedAt,
+	)
+
+	return err
+}
+
+// GetEvents retrieves events of the user, newest first.
+// Only events older than the before ID are returned, zero starts from the newest one.
+func (s *AuditStorage) GetEvents(
+	ctx context.Context,
+	uid models.UserID,
+	before int64,
+	limit int,
+) (events []models.AuditEvent, err error) {
+	rows, err := s.db.QueryContext(ctx, sqlGetAuditEvents, uid, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rowsCloseErr := rows.Close(); rowsCloseErr != nil {
+			err = fmt.Errorf("%v; rows close failed: %w", err, rowsCloseErr)
+		}
+	}()
+
+	for rows.Next() {
+		event := models.AuditEvent{UserID: uid}
+		err = rows.Scan(&event.ID, &event.Device, &event.IP, &event.Action, &event.Result, &event.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
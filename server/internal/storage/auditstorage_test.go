@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditStorage_AddEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewAuditStorage(db)
+
+	event := &models.AuditEvent{
+		UserID:    testUserID,
+		Device:    "gophkeeper-client/1.0 (laptop)",
+		IP:        "192.0.2.1",
+		Action:    models.AuditLogin,
+		Result:    models.AuditFailure,
+		CreatedAt: time.Now(),
+	}
+
+	expectedQuery := regexp.QuoteMeta(sqlAddAuditEvent)
+
+	t.Run("event appended", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(event.UserID, event.Device, event.IP, event.Action, event.Result, event.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := strg.AddEvent(context.Background(), event)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("general database error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WillReturnError(errTest)
+
+		err := strg.AddEvent(context.Background(), event)
+		assert.Equal(t, errTest, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAuditStorage_GetEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewAuditStorage(db)
+	createdAt := time.Now()
+
+	expectedQuery := regexp.QuoteMeta(sqlGetAuditEvents)
+
+	t.Run("page of events", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "device", "ip", "action", "result", "created_at"}).
+			AddRow(int64(7), "cli", "192.0.2.1", "sync", "success", createdAt).
+			AddRow(int64(5), "cli", "192.0.2.1", "login", "success", createdAt)
+
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testUserID, int64(10), 2).
+			WillReturnRows(rows)
+
+		events, err := strg.GetEvents(context.Background(), testUserID, 10, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []models.AuditEvent{
+			{ID: 7, UserID: testUserID, Device: "cli", IP: "192.0.2.1", Action: models.AuditSync, Result: models.AuditSuccess, CreatedAt: createdAt},
+			{ID: 5, UserID: testUserID, Device: "cli", IP: "192.0.2.1", Action: models.AuditLogin, Result: models.AuditSuccess, CreatedAt: createdAt},
+		}, events)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("general database error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testUserID, int64(0), 50).
+			WillReturnError(errTest)
+
+		_, err := strg.GetEvents(context.Background(), testUserID, 0, 50)
+		assert.Equal(t, errTest, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,496 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	schema "github.com/rycln/gokeep/server/internal/db"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDatabaseDSNEnv names Postgres DSN the backend suite also runs against
+const testDatabaseDSNEnv = "TEST_DATABASE_DSN"
+
+// backendStorage combines storage operations of a single backend
+type backendStorage interface {
+	AddUser(context.Context, *models.UserDB) error
+	GetUserByUsername(context.Context, string) (*models.UserDB, error)
+	GetUserByID(context.Context, models.UserID) (*models.UserDB, error)
+	UpdateUserCredentials(context.Context, *models.UserDB) error
+	SetKeyPair(context.Context, models.UserID, *models.KeyPair) error
+	GetPublicKey(context.Context, string) (*models.PublicKey, error)
+	DeleteUser(context.Context, models.UserID) error
+	GetSession(context.Context, models.UserID) (*models.Session, error)
+
+	AddItem(context.Context, *models.Item) error
+	GetUserItems(context.Context, models.UserID) ([]models.Item, error)
+	DeleteItem(context.Context, models.ItemID, models.UserID) error
+	GetUserDataSize(context.Context, models.UserID) (int64, error)
+
+	AddShare(context.Context, *models.Share) error
+	GetSharedWith(context.Context, models.UserID) ([]models.Share, error)
+	DeleteShare(context.Context, models.ItemID, models.UserID, string) error
+
+	CreateOrganization(context.Context, *models.Organization, models.UserID, *models.Collection) error
+	AddCollection(context.Context, *models.Collection, []models.CollectionKey) error
+	AddMember(context.Context, models.OrgID, *models.Member, []models.CollectionKey) error
+	GetOrgRole(context.Context, models.OrgID, models.UserID) (models.Role, error)
+	GetCollectionRole(context.Context, models.CollectionID, models.UserID) (models.Role, error)
+
+	AddContact(context.Context, *models.EmergencyContact) error
+	RequestAccess(context.Context, models.UserID, models.UserID, time.Time) error
+	ApproveExpiredRequests(context.Context, time.Time) (int64, error)
+	GetApprovedKey(context.Context, models.UserID, models.UserID) ([]byte, error)
+	GetGrants(context.Context, models.UserID) ([]models.EmergencyContact, error)
+
+	AddEvent(context.Context, *models.AuditEvent) error
+	GetEvents(context.Context, models.UserID, int64, int) ([]models.AuditEvent, error)
+	ListUsers(context.Context, string, string, int) ([]models.AdminUser, error)
+	SetUserDisabled(context.Context, models.UserID, bool) error
+	GetUsage(context.Context, models.UserID) (*models.Usage, error)
+}
+
+// TestBackends runs the same storage suite against every supported backend.
+// SQLite always runs in a temporary file, Postgres only when TEST_DATABASE_DSN is set.
+func TestBackends(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		runBackendSuite(t, NewMemStorage())
+	})
+
+	t.Run("sqlite", func(t *testing.T) {
+		runBackendSuite(t, openBackend(t, "sqlite:"+filepath.Join(t.TempDir(), "gophkeeper.db")))
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		dsn := os.Getenv(testDatabaseDSNEnv)
+		if dsn == "" {
+			t.Skipf("%s is not set", testDatabaseDSNEnv)
+		}
+		runBackendSuite(t, openBackend(t, dsn))
+	})
+}
+
+// openBackend migrates the database and creates SQL storages on it
+func openBackend(t *testing.T, dsn string) *SQLStorage {
+	db, dialect, err := NewDB(dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := schema.NewMigrator(db, dialect)
+	require.NoError(t, err)
+	_, err = m.Up(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, m.Check(context.Background()))
+
+	return NewSQLStorage(db, dialect)
+}
+
+// runBackendSuite checks storage behavior on the backend
+func runBackendSuite(t *testing.T, strg backendStorage) {
+	t.Run("users", func(t *testing.T) { testBackendUsers(t, strg) })
+	t.Run("items", func(t *testing.T) { testBackendItems(t, strg) })
+	t.Run("shares", func(t *testing.T) { testBackendShares(t, strg) })
+	t.Run("organizations", func(t *testing.T) { testBackendOrgs(t, strg) })
+	t.Run("emergency access", func(t *testing.T) { testBackendEmergency(t, strg) })
+	t.Run("audit and admin", func(t *testing.T) { testBackendAdmin(t, strg) })
+}
+
+// addBackendUser stores a user with a unique name and keypair
+func addBackendUser(t *testing.T, strg backendStorage) *models.UserDB {
+	t.Helper()
+
+	id := uuid.NewString()
+	user := &models.UserDB{
+		ID:           models.UserID(id),
+		Username:     "User-" + id[:8],
+		PassHash:     "hash",
+		Salt:         "salt",
+		EncryptedKey: "encrypted_key",
+		RecoveryKey:  "recovery_key",
+		RecoveryHash: "recovery_hash",
+		KeyPair: models.KeyPair{
+			PublicKey:           []byte("public_key"),
+			EncryptedPrivateKey: "encrypted_private_key",
+		},
+	}
+	require.NoError(t, strg.AddUser(context.Background(), user))
+	return user
+}
+
+// addBackendItem stores a personal item of the user
+func addBackendItem(t *testing.T, strg backendStorage, uid models.UserID, data string) *models.Item {
+	t.Helper()
+
+	item := &models.Item{
+		ID:        models.ItemID(uuid.NewString()),
+		UserID:    uid,
+		ItemType:  models.TypePassword,
+		Name:      "item",
+		Metadata:  "metadata",
+		Data:      []byte(data),
+		UpdatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	require.NoError(t, strg.AddItem(context.Background(), item))
+	return item
+}
+
+func testBackendUsers(t *testing.T, strg backendStorage) {
+	ctx := context.Background()
+	user := addBackendUser(t, strg)
+
+	t.Run("username conflict ignores case", func(t *testing.T) {
+		dup := *user
+		dup.ID = models.UserID(uuid.NewString())
+		dup.Username = "user-" + string(user.ID)[:8]
+
+		err := strg.AddUser(ctx, &dup)
+		var conflict interface{ IsErrUsernameConflict() bool }
+		assert.ErrorAs(t, err, &conflict)
+	})
+
+	t.Run("user found by username in any case", func(t *testing.T) {
+		got, err := strg.GetUserByUsername(ctx, "USER-"+string(user.ID)[:8])
+		require.NoError(t, err)
+		assert.Equal(t, user, got)
+	})
+
+	t.Run("non-ASCII username in any case", func(t *testing.T) {
+		other := *user
+		other.ID = models.UserID(uuid.NewString())
+		other.Username = "Ärger-" + string(other.ID)[:8]
+		require.NoError(t, strg.AddUser(ctx, &other))
+
+		got, err := strg.GetUserByUsername(ctx, "äRGER-"+string(other.ID)[:8])
+		require.NoError(t, err)
+		assert.Equal(t, other.ID, got.ID)
+
+		dup := other
+		dup.ID = models.UserID(uuid.NewString())
+		dup.Username = "ärger-" + string(other.ID)[:8]
+		var conflict interface{ IsErrUsernameConflict() bool }
+		assert.ErrorAs(t, strg.AddUser(ctx, &dup), &conflict)
+	})
+
+	t.Run("missing user", func(t *testing.T) {
+		_, err := strg.GetUserByID(ctx, models.UserID(uuid.NewString()))
+		var noUser interface{ IsErrNoUser() bool }
+		assert.ErrorAs(t, err, &noUser)
+	})
+
+	t.Run("credentials updated", func(t *testing.T) {
+		upd := *user
+		upd.PassHash, upd.Salt, upd.EncryptedKey = "new_hash", "new_salt", "new_key"
+		require.NoError(t, strg.UpdateUserCredentials(ctx, &upd))
+
+		got, err := strg.GetUserByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &upd, got)
+	})
+
+	t.Run("public key", func(t *testing.T) {
+		pk, err := strg.GetPublicKey(ctx, user.Username)
+		require.NoError(t, err)
+		assert.Equal(t, &models.PublicKey{UserID: user.ID, Key: user.PublicKey}, pk)
+	})
+
+	t.Run("keypair set once", func(t *testing.T) {
+		legacy := *user
+		legacy.ID = models.UserID(uuid.NewString())
+		legacy.Username = "legacy-" + string(legacy.ID)[:8]
+		legacy.KeyPair = models.KeyPair{}
+		require.NoError(t, strg.AddUser(ctx, &legacy))
+
+		kp := &models.KeyPair{PublicKey: []byte("public_key"), EncryptedPrivateKey: "encrypted_private_key"}
+		require.NoError(t, strg.SetKeyPair(ctx, legacy.ID, kp))
+
+		err := strg.SetKeyPair(ctx, legacy.ID, &models.KeyPair{PublicKey: []byte("other_key"), EncryptedPrivateKey: "other"})
+		var exists interface{ IsErrKeyPairExists() bool }
+		assert.ErrorAs(t, err, &exists)
+
+		pk, err := strg.GetPublicKey(ctx, legacy.Username)
+		require.NoError(t, err)
+		assert.Equal(t, kp.PublicKey, pk.Key)
+	})
+
+	t.Run("fresh session", func(t *testing.T) {
+		session, err := strg.GetSession(ctx, user.ID)
+		require.NoError(t, err)
+		assert.False(t, session.Disabled)
+		assert.True(t, session.RevokedAt.IsZero())
+	})
+
+	t.Run("user deleted with items", func(t *testing.T) {
+		other := addBackendUser(t, strg)
+		addBackendItem(t, strg, other.ID, "data")
+
+		require.NoError(t, strg.DeleteUser(ctx, other.ID))
+
+		_, err := strg.GetUserByID(ctx, other.ID)
+		var noUser interface{ IsErrNoUser() bool }
+		assert.ErrorAs(t, err, &noUser)
+	})
+}
+
+func testBackendItems(t *testing.T, strg backendStorage) {
+	ctx := context.Background()
+	user := addBackendUser(t, strg)
+	item := addBackendItem(t, strg, user.ID, "data")
+
+	t.Run("items of user", func(t *testing.T) {
+		items, err := strg.GetUserItems(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.True(t, item.UpdatedAt.Equal(items[0].UpdatedAt))
+		items[0].UpdatedAt = item.UpdatedAt
+		assert.Equal(t, *item, items[0])
+	})
+
+	t.Run("item of another user left unchanged", func(t *testing.T) {
+		other := addBackendUser(t, strg)
+		stolen := *item
+		stolen.UserID = other.ID
+		stolen.Data = []byte("stolen")
+		require.NoError(t, strg.AddItem(ctx, &stolen))
+
+		items, err := strg.GetUserItems(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, []byte("data"), items[0].Data)
+	})
+
+	t.Run("data size of live items", func(t *testing.T) {
+		deleted := addBackendItem(t, strg, user.ID, "deleted")
+		require.NoError(t, strg.DeleteItem(ctx, deleted.ID, user.ID))
+
+		size, err := strg.GetUserDataSize(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len("data")), size)
+	})
+
+	t.Run("deleted item kept as tombstone", func(t *testing.T) {
+		require.NoError(t, strg.DeleteItem(ctx, item.ID, user.ID))
+
+		items, err := strg.GetUserItems(ctx, user.ID)
+		require.NoError(t, err)
+		for _, got := range items {
+			if got.ID == item.ID {
+				assert.True(t, got.IsDeleted)
+			}
+		}
+	})
+}
+
+func testBackendShares(t *testing.T, strg backendStorage) {
+	ctx := context.Background()
+	owner, recipient := addBackendUser(t, strg), addBackendUser(t, strg)
+	item := addBackendItem(t, strg, owner.ID, "data")
+
+	share := &models.Share{
+		ItemID:      item.ID,
+		OwnerID:     owner.ID,
+		RecipientID: recipient.ID,
+		WrappedKey:  []byte("wrapped_key"),
+		Payload:     []byte("payload"),
+		SharedAt:    time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	t.Run("share received", func(t *testing.T) {
+		require.NoError(t, strg.AddShare(ctx, share))
+
+		shares, err := strg.GetSharedWith(ctx, recipient.ID)
+		require.NoError(t, err)
+		require.Len(t, shares, 1)
+		assert.Equal(t, owner.Username, shares[0].Owner)
+		assert.Equal(t, share.Payload, shares[0].Payload)
+	})
+
+	t.Run("share revoked by recipient name in any case", func(t *testing.T) {
+		require.NoError(t, strg.DeleteShare(ctx, item.ID, owner.ID, "USER-"+string(recipient.ID)[:8]))
+
+		shares, err := strg.GetSharedWith(ctx, recipient.ID)
+		require.NoError(t, err)
+		assert.Empty(t, shares)
+	})
+}
+
+func testBackendOrgs(t *testing.T, strg backendStorage) {
+	ctx := context.Background()
+	owner, member := addBackendUser(t, strg), addBackendUser(t, strg)
+
+	org := &models.Organization{ID: models.OrgID(uuid.NewString()), Name: "org"}
+	col := &models.Collection{ID: models.CollectionID(uuid.NewString()), Name: "col", WrappedKey: []byte("owner_key")}
+	require.NoError(t, strg.CreateOrganization(ctx, org, owner.ID, col))
+
+	newMember := &models.Member{UserID: member.ID, Role: models.RoleMember}
+	keys := []models.CollectionKey{{CollectionID: col.ID, UserID: member.ID, WrappedKey: []byte("member_key")}}
+
+	t.Run("member added", func(t *testing.T) {
+		require.NoError(t, strg.AddMember(ctx, org.ID, newMember, keys))
+
+		role, err := strg.GetCollectionRole(ctx, col.ID, member.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.RoleMember, role)
+	})
+
+	t.Run("member conflict", func(t *testing.T) {
+		err := strg.AddMember(ctx, org.ID, newMember, keys)
+		var conflict interface{ IsErrMemberConflict() bool }
+		assert.ErrorAs(t, err, &conflict)
+	})
+
+	t.Run("collection items visible to members", func(t *testing.T) {
+		item := &models.Item{
+			ID:           models.ItemID(uuid.NewString()),
+			CollectionID: col.ID,
+			ItemType:     models.TypePassword,
+			Name:         "shared",
+			Data:         []byte("data"),
+			UpdatedAt:    time.Now().UTC().Truncate(time.Millisecond),
+		}
+		require.NoError(t, strg.AddItem(ctx, item))
+
+		got, err := strg.GetUserItems(ctx, member.ID)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, col.ID, got[0].CollectionID)
+		assert.Empty(t, got[0].UserID)
+	})
+
+	t.Run("ownership passed on when sole owner is deleted", func(t *testing.T) {
+		soleOwner, admin, reader := addBackendUser(t, strg), addBackendUser(t, strg), addBackendUser(t, strg)
+		team := &models.Organization{ID: models.OrgID(uuid.NewString()), Name: "team"}
+		teamCol := &models.Collection{ID: models.CollectionID(uuid.NewString()), Name: "col", WrappedKey: []byte("owner_key")}
+		require.NoError(t, strg.CreateOrganization(ctx, team, soleOwner.ID, teamCol))
+		require.NoError(t, strg.AddMember(ctx, team.ID, &models.Member{UserID: reader.ID, Role: models.RoleReadOnly}, nil))
+		require.NoError(t, strg.AddMember(ctx, team.ID, &models.Member{UserID: admin.ID, Role: models.RoleAdmin}, nil))
+
+		require.NoError(t, strg.DeleteUser(ctx, soleOwner.ID))
+
+		role, err := strg.GetOrgRole(ctx, team.ID, admin.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.RoleOwner, role)
+		role, err = strg.GetOrgRole(ctx, team.ID, reader.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.RoleReadOnly, role)
+	})
+
+	t.Run("organization removed with last member", func(t *testing.T) {
+		require.NoError(t, strg.DeleteUser(ctx, member.ID))
+		require.NoError(t, strg.DeleteUser(ctx, owner.ID))
+
+		_, err := strg.GetOrgRole(ctx, org.ID, owner.ID)
+		var noMember interface{ IsErrNoMember() bool }
+		assert.ErrorAs(t, err, &noMember)
+
+		late := &models.Collection{ID: models.CollectionID(uuid.NewString()), OrgID: org.ID, Name: "late"}
+		assert.Error(t, strg.AddCollection(ctx, late, nil), "collection must not be added to removed organization")
+	})
+}
+
+func testBackendEmergency(t *testing.T, strg backendStorage) {
+	ctx := context.Background()
+	grantor, grantee := addBackendUser(t, strg), addBackendUser(t, strg)
+
+	contact := &models.EmergencyContact{
+		GrantorID:  grantor.ID,
+		GranteeID:  grantee.ID,
+		WaitPeriod: time.Hour,
+		WrappedKey: []byte("wrapped_key"),
+	}
+	require.NoError(t, strg.AddContact(ctx, contact))
+
+	requestedAt := time.Now()
+	require.NoError(t, strg.RequestAccess(ctx, grantor.ID, grantee.ID, requestedAt))
+
+	t.Run("request pending during waiting period", func(t *testing.T) {
+		n, err := strg.ApproveExpiredRequests(ctx, requestedAt.Add(59*time.Minute))
+		require.NoError(t, err)
+		assert.Zero(t, n)
+
+		_, err = strg.GetApprovedKey(ctx, grantor.ID, grantee.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("request approved after waiting period", func(t *testing.T) {
+		n, err := strg.ApproveExpiredRequests(ctx, requestedAt.Add(time.Hour+time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+
+		key, err := strg.GetApprovedKey(ctx, grantor.ID, grantee.ID)
+		require.NoError(t, err)
+		assert.Equal(t, contact.WrappedKey, key)
+	})
+
+	t.Run("grants of grantee", func(t *testing.T) {
+		grants, err := strg.GetGrants(ctx, grantee.ID)
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		assert.Equal(t, models.EmergencyApproved, grants[0].Status)
+		assert.True(t, requestedAt.Equal(grants[0].RequestedAt))
+	})
+}
+
+func testBackendAdmin(t *testing.T, strg backendStorage) {
+	ctx := context.Background()
+	user := addBackendUser(t, strg)
+
+	t.Run("audit events of user", func(t *testing.T) {
+		anonymous := &models.AuditEvent{Action: models.AuditLogin, Result: models.AuditFailure, CreatedAt: time.Now()}
+		require.NoError(t, strg.AddEvent(ctx, anonymous))
+
+		event := &models.AuditEvent{
+			UserID:    user.ID,
+			Device:    "laptop",
+			IP:        "192.0.2.1",
+			Action:    models.AuditLogin,
+			Result:    models.AuditSuccess,
+			CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+		}
+		require.NoError(t, strg.AddEvent(ctx, event))
+
+		events, err := strg.GetEvents(ctx, user.ID, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, event.Device, events[0].Device)
+		assert.True(t, event.CreatedAt.Equal(events[0].CreatedAt))
+	})
+
+	t.Run("users found by part of name in any case", func(t *testing.T) {
+		users, err := strg.ListUsers(ctx, "SER-"+string(user.ID)[:8], "", 10)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, user.ID, users[0].ID)
+	})
+
+	t.Run("wildcards in query matched literally", func(t *testing.T) {
+		for _, query := range []string{"%", "_", `\`} {
+			users, err := strg.ListUsers(ctx, query, "", 10)
+			require.NoError(t, err)
+			assert.Empty(t, users, query)
+		}
+	})
+
+	t.Run("disabled user", func(t *testing.T) {
+		require.NoError(t, strg.SetUserDisabled(ctx, user.ID, true))
+
+		session, err := strg.GetSession(ctx, user.ID)
+		require.NoError(t, err)
+		assert.True(t, session.Disabled)
+		assert.False(t, session.RevokedAt.IsZero())
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		addBackendItem(t, strg, user.ID, "12345")
+
+		usage, err := strg.GetUsage(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), usage.Items)
+		assert.Equal(t, int64(5), usage.Bytes)
+	})
+}
@@ -1,10 +1,21 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"strings"
 	"time"
 
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
 	_ "github.com/jackc/pgx/v5/stdlib"
+	schema "github.com/rycln/gokeep/server/internal/db"
+	"github.com/rycln/gokeep/server/internal/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
 // Connection pool configuration constants
@@ -15,11 +26,26 @@ const (
 	maxConnLifetime = 0               // Maximum connection lifetime (0 means no limit)
 )
 
-// NewDB creates and configures a new PostgreSQL database connection pool
-func NewDB(uri string) (*sql.DB, error) {
-	database, err := sql.Open("pgx", uri)
+// sqliteParams enables foreign keys and concurrent readers, waits for locks
+// instead of failing, starts transactions as writers to avoid upgrade deadlocks
+// and stores time in a format SQLite date functions understand
+const sqliteParams = "_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)" +
+	"&_txlock=immediate&_time_format=sqlite"
+
+// NewDB creates and configures a database connection pool.
+// The backend is selected by DSN scheme, see schema.ParseDSN.
+func NewDB(dsn string) (*sql.DB, schema.Dialect, error) {
+	dialect, source := schema.ParseDSN(dsn)
+
+	driver := "pgx"
+	if dialect == schema.SQLite {
+		driver = "sqlite"
+		source = sqliteSource(source)
+	}
+
+	database, err := sql.Open(driver, source)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	database.SetMaxOpenConns(maxOpenConns)
@@ -28,8 +54,64 @@ func NewDB(uri string) (*sql.DB, error) {
 	database.SetConnMaxLifetime(maxConnLifetime)
 
 	if err := database.Ping(); err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	return database, dialect, nil
+}
+
+// sqliteSource appends connection parameters to the SQLite file path
+func sqliteSource(path string) string {
+	if strings.Contains(path, "?") {
+		return path + "&" + sqliteParams
+	}
+	return path + "?" + sqliteParams
+}
+
+// isConstraintViolation reports integrity constraint errors of any backend
+func isConstraintViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgerrcode.IsIntegrityConstraintViolation(pgErr.Code)
+	}
+
+	var liteErr *sqlite.Error
+	if errors.As(err, &liteErr) {
+		return liteErr.Code()&0xff == sqlite3.SQLITE_CONSTRAINT
+	}
+
+	return false
+}
+
+// isUniqueViolation reports duplicate keys of any backend
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgerrcode.UniqueViolation
+	}
+
+	var liteErr *sqlite.Error
+	if errors.As(err, &liteErr) {
+		return liteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE ||
+			liteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+	}
+
+	return false
+}
+
+// startSpan starts a client span of a single database query
+func startSpan(ctx context.Context, dialect schema.Dialect, name, query string) (context.Context, trace.Span) {
+	system := semconv.DBSystemPostgreSQL
+	if dialect == schema.SQLite {
+		system = semconv.DBSystemSqlite
 	}
 
-	return database, nil
+	return tracing.Tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			system,
+			semconv.DBOperationName(name),
+			semconv.DBQueryText(query),
+		),
+	)
 }
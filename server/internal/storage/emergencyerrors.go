@@ -0,0 +1,33 @@
+package storage
+
+import "errors"
+
+// ErrNoEmergencyAccess indicates that emergency access is missing or in another state
+var ErrNoEmergencyAccess = errors.New("emergency access is not available")
+
+// errNoEmergencyAccess implements a structured emergency access error
+type errNoEmergencyAccess struct {
+	err error // Underlying error
+}
+
+// Error implements the error interface
+func (err *errNoEmergencyAccess) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap supports error inspection with errors.Is()/errors.As()
+func (err *errNoEmergencyAccess) Unwrap() error {
+	return err.err
+}
+
+// IsErrNoEmergencyAccess provides type checking method
+func (err *errNoEmergencyAccess) IsErrNoEmergencyAccess() bool {
+	return true
+}
+
+// newErrNoEmergencyAccess constructs a new emergency access error
+func newErrNoEmergencyAccess(err error) error {
+	return &errNoEmergencyAccess{
+		err: err,
+	}
+}
@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	schema "github.com/rycln/gokeep/server/internal/db"
+	"github.com/rycln/gokeep/shared/models"
+)
+
+// EmergencyStorage handles database operations for emergency access.
+type EmergencyStorage struct {
+	db      *sql.DB
+	dialect schema.Dialect
+}
+
+// NewEmergencyStorage creates a new EmergencyStorage instance.
+func NewEmergencyStorage(db *sql.DB, dialect schema.Dialect) *EmergencyStorage {
+	return &EmergencyStorage{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+// AddContact nominates a trusted contact of the grantor.
+// Nominating the same contact again resets any pending request.
+func (s *EmergencyStorage) AddContact(ctx context.Context, contact *models.EmergencyContact) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		sqlAddEmergencyContact,
+		contact.GrantorID,
+		contact.GranteeID,
+		int64(contact.WaitPeriod/time.Second),
+		contact.WrappedKey,
+	)
+
+	return err
+}
+
+// GetContacts retrieves trusted contacts nominated by the grantor.
+func (s *EmergencyStorage) GetContacts(ctx context.Context, grantor models.UserID) ([]models.EmergencyContact, error) {
+	return s.queryContacts(ctx, sqlGetEmergencyContacts, grantor, func(c *models.EmergencyContact) (*models.UserID, *string) {
+		c.GrantorID = grantor
+		return &c.GranteeID, &c.Grantee
+	})
+}
+
+// GetGrants retrieves accounts that nominated the grantee as trusted contact.
+func (s *EmergencyStorage) GetGrants(ctx context.Context, grantee models.UserID) ([]models.EmergencyContact, error) {
+	return s.queryContacts(ctx, sqlGetEmergencyGrants, grantee, func(c *models.EmergencyContact) (*models.UserID, *string) {
+		c.GranteeID = grantee
+		return &c.GrantorID, &c.Grantor
+	})
+}
+
+// RequestAccess starts the waiting period of a trusted contact.
+func (s *EmergencyStorage) RequestAccess(ctx context.Context, grantor, grantee models.UserID, at time.Time) error {
+	return s.execContact(ctx, sqlRequestEmergencyAccess, grantor, grantee, at)
+}
+
+// DenyAccess denies a pending request of a trusted contact.
+func (s *EmergencyStorage) DenyAccess(ctx context.Context, grantor, grantee models.UserID) error {
+	return s.execContact(ctx, sqlDenyEmergencyAccess, grantor, grantee)
+}
+
+// ApproveExpiredRequests approves requests whose waiting period has elapsed.
+// Returns the number of approved requests.
+func (s *EmergencyStorage) ApproveExpiredRequests(ctx context.Context, now time.Time) (int64, error) {
+	query := sqlApproveEmergencyRequests
+	if s.dialect == schema.SQLite {
+		query = sqliteApproveEmergencyRequests
+	}
+
+	res, err := s.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// GetApprovedKey retrieves grantor vault key wrapped for the grantee.
+// The key is only available after the request was approved.
+func (s *EmergencyStorage) GetApprovedKey(ctx context.Context, grantor, grantee models.UserID) ([]byte, error) {
+	var key []byte
+	err := s.db.QueryRowContext(ctx, sqlGetEmergencyKey, grantor, grantee).Scan(&key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, newErrNoEmergencyAccess(ErrNoEmergencyAccess)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// execContact updates a single contact and fails when its state does not match.
+func (s *EmergencyStorage) execContact(ctx context.Context, query string, args ...any) error {
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return newErrNoEmergencyAccess(ErrNoEmergencyAccess)
+	}
+
+	return nil
+}
+
+// queryContacts scans contacts, other returns fields of the counterpart account.
+func (s *EmergencyStorage) queryContacts(
+	ctx context.Context,
+	query string,
+	uid models.UserID,
+	other func(*models.EmergencyContact) (*models.UserID, *string),
+) (contacts []models.EmergencyContact, err error) {
+	rows, err := s.db.QueryContext(ctx, query, uid)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rowsCloseErr := rows.Close(); rowsCloseErr != nil {
+			err = fmt.Errorf("%v; rows close failed: %w", err, rowsCloseErr)
+		}
+	}()
+
+	for rows.Next() {
+		var (
+			contact     models.EmergencyContact
+			waitSeconds int64
+			requestedAt sql.NullTime
+		)
+		id, username := other(&contact)
+
+		err = rows.Scan(id, username, &waitSeconds, &contact.Status, &requestedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		contact.WaitPeriod = time.Duration(waitSeconds) * time.Second
+		contact.RequestedAt = requestedAt.Time
+		contacts = append(contacts, contact)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
@@ -0,0 +1,233 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	schema "github.com/rycln/gokeep/server/internal/db"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testGranteeID = "550e8400-e29b-41d4-a716-446655440005"
+)
+
+func TestEmergencyStorage_AddContact(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewEmergencyStorage(db, schema.Postgres)
+
+	contact := &models.EmergencyContact{
+		GrantorID:  testUserID,
+		GranteeID:  testGranteeID,
+		WaitPeriod: 48 * time.Hour,
+		WrappedKey: []byte("wrapped"),
+	}
+
+	expectedQuery := regexp.QuoteMeta(sqlAddEmergencyContact)
+
+	t.Run("wait period stored in seconds", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(testUserID, testGranteeID, int64(172800), []byte("wrapped")).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.AddContact(context.Background(), contact)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("general database error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(testUserID, testGranteeID, int64(172800), []byte("wrapped")).
+			WillReturnError(errTest)
+
+		err := strg.AddContact(context.Background(), contact)
+		assert.Equal(t, errTest, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestEmergencyStorage_GetContacts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewEmergencyStorage(db, schema.Postgres)
+	requestedAt := time.Now()
+
+	t.Run("contacts of grantor", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"grantee_id", "username", "wait_seconds", "status", "requested_at"}).
+			AddRow(testGranteeID, "bob", int64(3600), "requested", requestedAt).
+			AddRow(testRecipientID, "eve", int64(60), "idle", nil)
+
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetEmergencyContacts)).
+			WithArgs(testUserID).
+			WillReturnRows(rows)
+
+		contacts, err := strg.GetContacts(context.Background(), testUserID)
+		require.NoError(t, err)
+		assert.Equal(t, []models.EmergencyContact{
+			{
+				GrantorID:   testUserID,
+				GranteeID:   testGranteeID,
+				Grantee:     "bob",
+				WaitPeriod:  time.Hour,
+				Status:      models.EmergencyRequested,
+				RequestedAt: requestedAt,
+			},
+			{
+				GrantorID:  testUserID,
+				GranteeID:  testRecipientID,
+				Grantee:    "eve",
+				WaitPeriod: time.Minute,
+				Status:     models.EmergencyIdle,
+			},
+		}, contacts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestEmergencyStorage_GetGrants(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewEmergencyStorage(db, schema.Postgres)
+
+	t.Run("grants of grantee", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"grantor_id", "username", "wait_seconds", "status", "requested_at"}).
+			AddRow(testUserID, "alice", int64(3600), "approved", time.Time{})
+
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetEmergencyGrants)).
+			WithArgs(testGranteeID).
+			WillReturnRows(rows)
+
+		grants, err := strg.GetGrants(context.Background(), testGranteeID)
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		assert.Equal(t, models.UserID(testUserID), grants[0].GrantorID)
+		assert.Equal(t, "alice", grants[0].Grantor)
+		assert.Equal(t, models.UserID(testGranteeID), grants[0].GranteeID)
+		assert.Equal(t, models.EmergencyApproved, grants[0].Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestEmergencyStorage_RequestAccess(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewEmergencyStorage(db, schema.Postgres)
+	now := time.Now()
+
+	expectedQuery := regexp.QuoteMeta(sqlRequestEmergencyAccess)
+
+	t.Run("waiting period started", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(testUserID, testGranteeID, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.RequestAccess(context.Background(), testUserID, testGranteeID, now)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("request already pending", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(testUserID, testGranteeID, now).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := strg.RequestAccess(context.Background(), testUserID, testGranteeID, now)
+		assert.ErrorIs(t, err, ErrNoEmergencyAccess)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestEmergencyStorage_DenyAccess(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewEmergencyStorage(db, schema.Postgres)
+
+	expectedQuery := regexp.QuoteMeta(sqlDenyEmergencyAccess)
+
+	t.Run("pending request denied", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(testUserID, testGranteeID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.DenyAccess(context.Background(), testUserID, testGranteeID)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no pending request", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(testUserID, testGranteeID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := strg.DenyAccess(context.Background(), testUserID, testGranteeID)
+		assert.ErrorIs(t, err, ErrNoEmergencyAccess)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestEmergencyStorage_ApproveExpiredRequests(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewEmergencyStorage(db, schema.Postgres)
+	now := time.Now()
+
+	t.Run("expired requests approved", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(sqlApproveEmergencyRequests)).
+			WithArgs(now).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		n, err := strg.ApproveExpiredRequests(context.Background(), now)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), n)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestEmergencyStorage_GetApprovedKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewEmergencyStorage(db, schema.Postgres)
+
+	expectedQuery := regexp.QuoteMeta(sqlGetEmergencyKey)
+
+	t.Run("approved key", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testUserID, testGranteeID).
+			WillReturnRows(sqlmock.NewRows([]string{"wrapped_key"}).AddRow([]byte("wrapped")))
+
+		key, err := strg.GetApprovedKey(context.Background(), testUserID, testGranteeID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("wrapped"), key)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not approved yet", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testUserID, testGranteeID).
+			WillReturnRows(sqlmock.NewRows([]string{"wrapped_key"}))
+
+		_, err := strg.GetApprovedKey(context.Background(), testUserID, testGranteeID)
+		assert.ErrorIs(t, err, ErrNoEmergencyAccess)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -6,23 +6,43 @@ import (
 	"fmt"
 	"time"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+	schema "github.com/rycln/gokeep/server/internal/db"
+	"github.com/rycln/gokeep/server/internal/tracing"
 	"github.com/rycln/gokeep/shared/models"
 )
 
 // ItemStorage handles database operations for items.
 type ItemStorage struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect schema.Dialect
 }
 
 // NewItemStorage creates a new ItemStorage instance.
-func NewItemStorage(db *sql.DB) *ItemStorage {
-	return &ItemStorage{db: db}
+func NewItemStorage(db *sql.DB, dialect schema.Dialect) *ItemStorage {
+	return &ItemStorage{
+		db:      db,
+		dialect: dialect,
+	}
 }
 
 // DeleteItem removes an item from storage by ID and user ID.
-func (s *ItemStorage) DeleteItem(ctx context.Context, id models.ItemID, uid models.UserID) error {
-	_, err := s.db.ExecContext(ctx, sqlDeleteItem, time.Now(), id, uid)
+func (s *ItemStorage) DeleteItem(ctx context.Context, id models.ItemID, uid models.UserID) (err error) {
+	ctx, span := startSpan(ctx, s.dialect, "ItemStorage.DeleteItem", sqlDeleteItem)
+	defer func() { tracing.End(span, err) }()
+
+	_, err = s.db.ExecContext(ctx, sqlDeleteItem, time.Now(), id, uid)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteCollectionItem removes an organization item from storage by ID and collection ID.
+func (s *ItemStorage) DeleteCollectionItem(ctx context.Context, id models.ItemID, cid models.CollectionID) (err error) {
+	ctx, span := startSpan(ctx, s.dialect, "ItemStorage.DeleteCollectionItem", sqlDeleteCollectionItem)
+	defer func() { tracing.End(span, err) }()
+
+	_, err = s.db.ExecContext(ctx, sqlDeleteCollectionItem, time.Now(), id, cid)
 	if err != nil {
 		return err
 	}
@@ -30,17 +50,23 @@ func (s *ItemStorage) DeleteItem(ctx context.Context, id models.ItemID, uid mode
 }
 
 // AddItem stores a new item in the database.
-func (s *ItemStorage) AddItem(ctx context.Context, item *models.Item) error {
-	_, err := s.db.ExecContext(
+// Collection items are owned by the organization and have no user ID.
+// Existing items of another owner are left unchanged.
+func (s *ItemStorage) AddItem(ctx context.Context, item *models.Item) (err error) {
+	ctx, span := startSpan(ctx, s.dialect, "ItemStorage.AddItem", sqlAddItem)
+	defer func() { tracing.End(span, err) }()
+
+	_, err = s.db.ExecContext(
 		ctx,
 		sqlAddItem,
 		item.ID,
-		item.UserID,
+		nullable(string(item.UserID)),
 		item.ItemType,
 		item.Name,
 		item.Metadata,
 		item.Data,
 		item.UpdatedAt,
+		nullable(string(item.CollectionID)),
 	)
 	if err != nil {
 		return err
@@ -48,8 +74,12 @@ func (s *ItemStorage) AddItem(ctx context.Context, item *models.Item) error {
 	return nil
 }
 
-// GetUserItems retrieves all items belonging to a user.
+// GetUserItems retrieves all items belonging to a user
+// and items of collections of the user's organizations.
 func (s *ItemStorage) GetUserItems(ctx context.Context, uid models.UserID) (items []models.Item, err error) {
+	ctx, span := startSpan(ctx, s.dialect, "ItemStorage.GetUserItems", sqlGetUserItems)
+	defer func() { tracing.End(span, err) }()
+
 	rows, err := s.db.QueryContext(ctx, sqlGetUserItems, uid)
 	if err != nil {
 		return nil, err
@@ -61,12 +91,11 @@ func (s *ItemStorage) GetUserItems(ctx context.Context, uid models.UserID) (item
 	}()
 
 	for rows.Next() {
-		var item = models.Item{
-			UserID: uid,
-		}
+		var item models.Item
 
 		err = rows.Scan(
 			&item.ID,
+			&item.CollectionID,
 			&item.ItemType,
 			&item.Name,
 			&item.Metadata,
@@ -78,6 +107,10 @@ func (s *ItemStorage) GetUserItems(ctx context.Context, uid models.UserID) (item
 			return nil, err
 		}
 
+		if item.CollectionID == "" {
+			item.UserID = uid
+		}
+
 		items = append(items, item)
 	}
 
@@ -88,3 +121,24 @@ func (s *ItemStorage) GetUserItems(ctx context.Context, uid models.UserID) (item
 
 	return items, nil
 }
+
+// GetUserDataSize returns encrypted payload size of personal items of a user.
+// Deleted items are not counted.
+func (s *ItemStorage) GetUserDataSize(ctx context.Context, uid models.UserID) (size int64, err error) {
+	ctx, span := startSpan(ctx, s.dialect, "ItemStorage.GetUserDataSize", sqlGetUserDataSize)
+	defer func() { tracing.End(span, err) }()
+
+	err = s.db.QueryRowContext(ctx, sqlGetUserDataSize, uid).Scan(&size)
+	if err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// nullable converts empty identifiers to SQL NULL
+func nullable(id string) any {
+	if id == "" {
+		return nil
+	}
+	return id
+}
@@ -7,13 +7,15 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	schema "github.com/rycln/gokeep/server/internal/db"
 	"github.com/rycln/gokeep/shared/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 const (
-	testItemID = "550e8400-e29b-41d4-a716-446655440001"
+	testItemID       = "550e8400-e29b-41d4-a716-446655440001"
+	testCollectionID = "550e8400-e29b-41d4-a716-446655440003"
 )
 
 func TestNewItemStorage(t *testing.T) {
@@ -22,7 +24,7 @@ func TestNewItemStorage(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		storage := NewItemStorage(db)
+		storage := NewItemStorage(db, schema.Postgres)
 		assert.NotNil(t, storage)
 		assert.Equal(t, db, storage.db)
 	})
@@ -33,7 +35,7 @@ func TestItemStorage_AddItem(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	strg := NewItemStorage(db)
+	strg := NewItemStorage(db, schema.Postgres)
 
 	testItem := &models.Item{
 		ID:        testItemID,
@@ -57,6 +59,7 @@ func TestItemStorage_AddItem(t *testing.T) {
 				testItem.Metadata,
 				testItem.Data,
 				testItem.UpdatedAt,
+				nil,
 			).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -75,6 +78,7 @@ func TestItemStorage_AddItem(t *testing.T) {
 				testItem.Metadata,
 				testItem.Data,
 				testItem.UpdatedAt,
+				nil,
 			).
 			WillReturnError(errTest)
 
@@ -83,6 +87,29 @@ func TestItemStorage_AddItem(t *testing.T) {
 		assert.Equal(t, errTest, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+
+	t.Run("collection item has no user id", func(t *testing.T) {
+		orgItem := *testItem
+		orgItem.UserID = ""
+		orgItem.CollectionID = testCollectionID
+
+		mock.ExpectExec(expectedQuery).
+			WithArgs(
+				orgItem.ID,
+				nil,
+				orgItem.ItemType,
+				orgItem.Name,
+				orgItem.Metadata,
+				orgItem.Data,
+				orgItem.UpdatedAt,
+				testCollectionID,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.AddItem(context.Background(), &orgItem)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 }
 
 func TestItemStorage_DeleteItem(t *testing.T) {
@@ -90,7 +117,7 @@ func TestItemStorage_DeleteItem(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	strg := NewItemStorage(db)
+	strg := NewItemStorage(db, schema.Postgres)
 
 	expectedQuery := regexp.QuoteMeta(sqlDeleteItem)
 
@@ -116,12 +143,43 @@ func TestItemStorage_DeleteItem(t *testing.T) {
 	})
 }
 
+func TestItemStorage_DeleteCollectionItem(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewItemStorage(db, schema.Postgres)
+
+	expectedQuery := regexp.QuoteMeta(sqlDeleteCollectionItem)
+
+	t.Run("successful item deletion", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(sqlmock.AnyArg(), testItemID, testCollectionID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.DeleteCollectionItem(context.Background(), testItemID, testCollectionID)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("general database error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(sqlmock.AnyArg(), testItemID, testCollectionID).
+			WillReturnError(errTest)
+
+		err := strg.DeleteCollectionItem(context.Background(), testItemID, testCollectionID)
+		assert.Error(t, err)
+		assert.Equal(t, errTest, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestItemStorage_GetUserItems(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
 	defer db.Close()
 
-	strg := NewItemStorage(db)
+	strg := NewItemStorage(db, schema.Postgres)
 
 	testTime := time.Now()
 	testItems := []models.Item{
@@ -139,11 +197,48 @@ func TestItemStorage_GetUserItems(t *testing.T) {
 
 	expectedQuery := regexp.QuoteMeta(sqlGetUserItems)
 
+	t.Run("personal and collection items", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{
+			"id", "collection_id", "item_type", "name", "metadata", "data", "updated_at", "is_deleted",
+		}).AddRow(
+			testItems[0].ID,
+			"",
+			testItems[0].ItemType,
+			testItems[0].Name,
+			testItems[0].Metadata,
+			testItems[0].Data,
+			testItems[0].UpdatedAt,
+			testItems[0].IsDeleted,
+		).AddRow(
+			testItemID,
+			testCollectionID,
+			"note",
+			"org item",
+			"{}",
+			[]byte("org data"),
+			testTime,
+			false,
+		)
+
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testUserID).
+			WillReturnRows(rows)
+
+		items, err := strg.GetUserItems(context.Background(), testUserID)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, testItems[0], items[0])
+		assert.Equal(t, models.UserID(""), items[1].UserID)
+		assert.Equal(t, models.CollectionID(testCollectionID), items[1].CollectionID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("rows close error", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
-			"id", "item_type", "name", "metadata", "data", "updated_at", "is_deleted",
+			"id", "collection_id", "item_type", "name", "metadata", "data", "updated_at", "is_deleted",
 		}).AddRow(
 			testItems[0].ID,
+			"",
 			testItems[0].ItemType,
 			testItems[0].Name,
 			testItems[0].Metadata,
@@ -164,3 +259,34 @@ func TestItemStorage_GetUserItems(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestItemStorage_GetUserDataSize(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewItemStorage(db, schema.Postgres)
+
+	expectedQuery := regexp.QuoteMeta(sqlGetUserDataSize)
+
+	t.Run("size of personal items", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"size"}).AddRow(2048))
+
+		size, err := strg.GetUserDataSize(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2048), size)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testUserID).
+			WillReturnError(errTest)
+
+		_, err := strg.GetUserDataSize(context.Background(), testUserID)
+		assert.ErrorIs(t, err, errTest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,887 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rycln/gokeep/server/internal/validation"
+	"github.com/rycln/gokeep/shared/models"
+)
+
+// Errors of constraints the SQL schema enforces and MemStorage checks itself
+var (
+	errMemDuplicate   = errors.New("record already exists")
+	errMemNoRef       = errors.New("referenced record does not exist")
+	errMemItemOwner   = errors.New("item must belong to either a user or a collection")
+	errMemSelfContact = errors.New("user can't be own emergency contact")
+)
+
+// memUser holds an account with its session state
+type memUser struct {
+	user       models.UserDB
+	disabledAt time.Time // Zero when enabled
+	revokedAt  time.Time // Zero when sessions were never revoked
+}
+
+// shareKey identifies a share, an item is shared with a recipient once
+type shareKey struct {
+	item      models.ItemID
+	recipient models.UserID
+}
+
+// contactKey identifies an emergency contact
+type contactKey struct {
+	grantor models.UserID
+	grantee models.UserID
+}
+
+// MemStorage keeps all server data in process memory.
+// It implements the same storage interfaces as the SQL storages
+// with the same errors and is meant for development and tests:
+// data is lost on restart.
+type MemStorage struct {
+	mu          sync.RWMutex
+	users       map[models.UserID]*memUser
+	items       map[models.ItemID]*models.Item
+	shares      map[shareKey]*models.Share
+	orgs        map[models.OrgID]*models.Organization
+	members     map[models.OrgID]map[models.UserID]models.Role
+	collections map[models.CollectionID]*models.Collection
+	colKeys     map[models.CollectionID]map[models.UserID][]byte
+	contacts    map[contactKey]*models.EmergencyContact
+	events      []models.AuditEvent
+}
+
+// NewMemStorage creates an empty MemStorage instance.
+func NewMemStorage() *MemStorage {
+	return &MemStorage{
+		users:       make(map[models.UserID]*memUser),
+		items:       make(map[models.ItemID]*models.Item),
+		shares:      make(map[shareKey]*models.Share),
+		orgs:        make(map[models.OrgID]*models.Organization),
+		members:     make(map[models.OrgID]map[models.UserID]models.Role),
+		collections: make(map[models.CollectionID]*models.Collection),
+		colKeys:     make(map[models.CollectionID]map[models.UserID][]byte),
+		contacts:    make(map[contactKey]*models.EmergencyContact),
+	}
+}
+
+// PingContext reports the storage available, it never fails.
+func (s *MemStorage) PingContext(_ context.Context) error {
+	return nil
+}
+
+// AddUser stores a new user.
+func (s *MemStorage) AddUser(_ context.Context, user *models.UserDB) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[user.ID]; ok {
+		return newErrUsernameConflict(ErrUsernameConflict)
+	}
+	if s.userByName(user.Username) != nil {
+		return newErrUsernameConflict(ErrUsernameConflict)
+	}
+
+	stored := *user
+	stored.Disabled = false
+	stored.PublicKey = bytes.Clone(user.PublicKey)
+	s.users[user.ID] = &memUser{user: stored}
+
+	return nil
+}
+
+// GetUserByUsername retrieves a user by their username in any case.
+func (s *MemStorage) GetUserByUsername(_ context.Context, username string) (*models.UserDB, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u := s.userByName(username)
+	if u == nil {
+		return nil, newErrNoUser(ErrNoUser)
+	}
+	return u.get(), nil
+}
+
+// GetUserByID retrieves a user by their ID.
+func (s *MemStorage) GetUserByID(_ context.Context, uid models.UserID) (*models.UserDB, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[uid]
+	if !ok {
+		return nil, newErrNoUser(ErrNoUser)
+	}
+	return u.get(), nil
+}
+
+// UpdateUserCredentials replaces password hash, salt and wrapped vault key of a user.
+func (s *MemStorage) UpdateUserCredentials(_ context.Context, user *models.UserDB) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[user.ID]
+	if !ok {
+		return newErrNoUser(ErrNoUser)
+	}
+	u.user.PassHash = user.PassHash
+	u.user.Salt = user.Salt
+	u.user.EncryptedKey = user.EncryptedKey
+
+	return nil
+}
+
+// SetKeyPair stores sharing keypair of a user who has none yet.
+func (s *MemStorage) SetKeyPair(_ context.Context, uid models.UserID, kp *models.KeyPair) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[uid]
+	if !ok {
+		return newErrNoUser(ErrNoUser)
+	}
+	if len(u.user.PublicKey) != 0 {
+		return newErrKeyPairExists(ErrKeyPairExists)
+	}
+	u.user.PublicKey = bytes.Clone(kp.PublicKey)
+	u.user.EncryptedPrivateKey = kp.EncryptedPrivateKey
+
+	return nil
+}
+
+// GetPublicKey retrieves public key of a user by username.
+// Users without a keypair are reported as missing because nothing can be shared with them.
+func (s *MemStorage) GetPublicKey(_ context.Context, username string) (*models.PublicKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u := s.userByName(username)
+	if u == nil || u.user.PublicKey == nil {
+		return nil, newErrNoUser(ErrNoUser)
+	}
+	return &models.PublicKey{UserID: u.user.ID, Key: u.user.PublicKey}, nil
+}
+
+// DeleteUser removes a user with all personal items, shares, memberships,
+// collection keys and emergency contacts. Organizations of a sole owner pass
+// to the member with the highest role, organizations left without members
+// are removed with their collections.
+func (s *MemStorage) DeleteUser(_ context.Context, uid models.UserID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[uid]; !ok {
+		return newErrNoUser(ErrNoUser)
+	}
+	delete(s.users, uid)
+
+	for id, item := range s.items {
+		if item.UserID == uid {
+			s.deleteItem(id)
+		}
+	}
+	for key, share := range s.shares {
+		if share.OwnerID == uid || key.recipient == uid {
+			delete(s.shares, key)
+		}
+	}
+	for key := range s.contacts {
+		if key.grantor == uid || key.grantee == uid {
+			delete(s.contacts, key)
+		}
+	}
+	for _, keys := range s.colKeys {
+		delete(keys, uid)
+	}
+	for org, members := range s.members {
+		role, ok := members[uid]
+		if !ok {
+			continue
+		}
+		delete(members, uid)
+		if len(members) == 0 {
+			s.deleteOrg(org)
+			continue
+		}
+		if role == models.RoleOwner {
+			transferOwnership(members)
+		}
+	}
+
+	return nil
+}
+
+// GetSession retrieves token validity state of a user.
+func (s *MemStorage) GetSession(_ context.Context, uid models.UserID) (*models.Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[uid]
+	if !ok {
+		return nil, newErrNoUser(ErrNoUser)
+	}
+	return &models.Session{
+		Disabled:  !u.disabledAt.IsZero(),
+		RevokedAt: u.revokedAt,
+	}, nil
+}
+
+// AddItem stores a new item or replaces an existing one.
+// Collection items are owned by the organization and have no user ID.
+// Existing items of another owner are left unchanged.
+func (s *MemStorage) AddItem(_ context.Context, item *models.Item) error {
+	if (item.UserID == "") == (item.CollectionID == "") {
+		return errMemItemOwner
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[item.UserID]; !ok && item.UserID != "" {
+		return errMemNoRef
+	}
+	if _, ok := s.collections[item.CollectionID]; !ok && item.CollectionID != "" {
+		return errMemNoRef
+	}
+
+	if cur, ok := s.items[item.ID]; ok {
+		if cur.UserID != item.UserID || cur.CollectionID != item.CollectionID {
+			return nil
+		}
+	}
+
+	stored := *item
+	stored.Data = bytes.Clone(item.Data)
+	stored.IsDeleted = false
+	s.items[item.ID] = &stored
+
+	return nil
+}
+
+// GetUserItems retrieves all items belonging to a user
+// and items of collections of the user's organizations.
+func (s *MemStorage) GetUserItems(_ context.Context, uid models.UserID) ([]models.Item, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []models.Item
+	for _, item := range s.items {
+		switch {
+		case item.UserID == uid:
+		case item.CollectionID != "" && s.isMember(s.collections[item.CollectionID].OrgID, uid):
+		default:
+			continue
+		}
+		items = append(items, *item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	return items, nil
+}
+
+// DeleteItem marks an item of the user as deleted.
+func (s *MemStorage) DeleteItem(_ context.Context, id models.ItemID, uid models.UserID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if item, ok := s.items[id]; ok && item.UserID == uid {
+		item.IsDeleted = true
+		item.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// DeleteCollectionItem marks an organization item of the collection as deleted.
+func (s *MemStorage) DeleteCollectionItem(_ context.Context, id models.ItemID, cid models.CollectionID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if item, ok := s.items[id]; ok && item.CollectionID == cid {
+		item.IsDeleted = true
+		item.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// GetUserDataSize returns encrypted payload size of personal items of a user.
+// Deleted items are not counted.
+func (s *MemStorage) GetUserDataSize(_ context.Context, uid models.UserID) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, size := s.usage(uid)
+	return size, nil
+}
+
+// AddShare stores an item shared with another user.
+// Sharing the same item again replaces the previous payload.
+func (s *MemStorage) AddShare(_ context.Context, share *models.Share) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.items[share.ItemID]
+	if !ok || item.UserID != share.OwnerID || item.IsDeleted {
+		return newErrNoItem(ErrNoItem)
+	}
+	if _, ok := s.users[share.RecipientID]; !ok {
+		return errMemNoRef
+	}
+
+	s.shares[shareKey{item: share.ItemID, recipient: share.RecipientID}] = &models.Share{
+		ItemID:      share.ItemID,
+		OwnerID:     share.OwnerID,
+		RecipientID: share.RecipientID,
+		WrappedKey:  bytes.Clone(share.WrappedKey),
+		Payload:     bytes.Clone(share.Payload),
+		SharedAt:    share.SharedAt,
+	}
+
+	return nil
+}
+
+// GetSharedWith retrieves all items shared with a user.
+func (s *MemStorage) GetSharedWith(_ context.Context, uid models.UserID) ([]models.Share, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var shares []models.Share
+	for key, share := range s.shares {
+		if item, ok := s.items[key.item]; key.recipient != uid || !ok || item.IsDeleted {
+			continue
+		}
+		got := *share
+		got.Owner = s.users[share.OwnerID].user.Username
+		shares = append(shares, got)
+	}
+	sort.Slice(shares, func(i, j int) bool { return shares[i].ItemID < shares[j].ItemID })
+
+	return shares, nil
+}
+
+// DeleteShare revokes access of the recipient to the owner's item.
+func (s *MemStorage) DeleteShare(_ context.Context, id models.ItemID, owner models.UserID, recipient string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := s.userByName(recipient)
+	if u == nil {
+		return newErrNoShare(ErrNoShare)
+	}
+
+	key := shareKey{item: id, recipient: u.user.ID}
+	share, ok := s.shares[key]
+	if !ok || share.OwnerID != owner {
+		return newErrNoShare(ErrNoShare)
+	}
+	delete(s.shares, key)
+
+	return nil
+}
+
+// CreateOrganization stores a new organization with its owner and first collection.
+func (s *MemStorage) CreateOrganization(
+	_ context.Context,
+	org *models.Organization,
+	owner models.UserID,
+	col *models.Collection,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[owner]; !ok {
+		return errMemNoRef
+	}
+	if _, ok := s.orgs[org.ID]; ok {
+		return errMemDuplicate
+	}
+	if _, ok := s.collections[col.ID]; ok {
+		return errMemDuplicate
+	}
+
+	s.orgs[org.ID] = &models.Organization{ID: org.ID, Name: org.Name}
+	s.members[org.ID] = map[models.UserID]models.Role{owner: models.RoleOwner}
+	s.addCollection(col.ID, org.ID, col.Name)
+	s.colKeys[col.ID][owner] = bytes.Clone(col.WrappedKey)
+
+	return nil
+}
+
+// AddCollection stores a new collection with its keys wrapped for members.
+func (s *MemStorage) AddCollection(_ context.Context, col *models.Collection, keys []models.CollectionKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.orgs[col.OrgID]; !ok {
+		return errMemNoRef
+	}
+	if _, ok := s.collections[col.ID]; ok {
+		return errMemDuplicate
+	}
+	for _, key := range keys {
+		if !s.isMember(col.OrgID, key.UserID) {
+			return newErrNoMember(ErrNoMember)
+		}
+	}
+
+	s.addCollection(col.ID, col.OrgID, col.Name)
+	for _, key := range keys {
+		s.colKeys[col.ID][key.UserID] = bytes.Clone(key.WrappedKey)
+	}
+
+	return nil
+}
+
+// AddMember stores a new membership with collection keys wrapped for the member.
+func (s *MemStorage) AddMember(
+	_ context.Context,
+	org models.OrgID,
+	member *models.Member,
+	keys []models.CollectionKey,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	members, ok := s.members[org]
+	if !ok {
+		return errMemNoRef
+	}
+	if _, ok := s.users[member.UserID]; !ok {
+		return errMemNoRef
+	}
+	if _, ok := members[member.UserID]; ok {
+		return newErrMemberConflict(ErrMemberConflict)
+	}
+	for _, key := range keys {
+		if col, ok := s.collections[key.CollectionID]; !ok || col.OrgID != org {
+			return newErrNoMember(ErrNoMember)
+		}
+	}
+
+	members[member.UserID] = member.Role
+	for _, key := range keys {
+		s.colKeys[key.CollectionID][member.UserID] = bytes.Clone(key.WrappedKey)
+	}
+
+	return nil
+}
+
+// GetOrgRole returns role of the user in the organization.
+func (s *MemStorage) GetOrgRole(_ context.Context, org models.OrgID, uid models.UserID) (models.Role, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	role, ok := s.members[org][uid]
+	if !ok {
+		return "", newErrNoMember(ErrNoMember)
+	}
+	return role, nil
+}
+
+// GetCollectionRole returns role of the user in the organization owning the collection.
+func (s *MemStorage) GetCollectionRole(_ context.Context, cid models.CollectionID, uid models.UserID) (models.Role, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	col, ok := s.collections[cid]
+	if !ok {
+		return "", newErrNoMember(ErrNoMember)
+	}
+	role, ok := s.members[col.OrgID][uid]
+	if !ok {
+		return "", newErrNoMember(ErrNoMember)
+	}
+	return role, nil
+}
+
+// GetMembers retrieves all members of the organization with their public keys.
+func (s *MemStorage) GetMembers(_ context.Context, org models.OrgID) ([]models.Member, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var members []models.Member
+	for uid, role := range s.members[org] {
+		u := s.users[uid].user
+		members = append(members, models.Member{
+			UserID:    uid,
+			Username:  u.Username,
+			Role:      role,
+			PublicKey: u.PublicKey,
+		})
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Username < members[j].Username })
+
+	return members, nil
+}
+
+// GetCollections retrieves collections available to the user with keys wrapped for them.
+func (s *MemStorage) GetCollections(_ context.Context, uid models.UserID) ([]models.Collection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var cols []models.Collection
+	for cid, keys := range s.colKeys {
+		key, ok := keys[uid]
+		col := s.collections[cid]
+		if !ok || !s.isMember(col.OrgID, uid) {
+			continue
+		}
+		cols = append(cols, models.Collection{
+			ID:         cid,
+			OrgID:      col.OrgID,
+			OrgName:    s.orgs[col.OrgID].Name,
+			Name:       col.Name,
+			Role:       s.members[col.OrgID][uid],
+			WrappedKey: key,
+		})
+	}
+	sort.Slice(cols, func(i, j int) bool {
+		if cols[i].OrgName != cols[j].OrgName {
+			return cols[i].OrgName < cols[j].OrgName
+		}
+		return cols[i].Name < cols[j].Name
+	})
+
+	return cols, nil
+}
+
+// AddContact nominates a trusted contact of the grantor.
+// Nominating the same contact again resets any pending request.
+func (s *MemStorage) AddContact(_ context.Context, contact *models.EmergencyContact) error {
+	if contact.GrantorID == contact.GranteeID {
+		return errMemSelfContact
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.users[contact.GrantorID] == nil || s.users[contact.GranteeID] == nil {
+		return errMemNoRef
+	}
+
+	s.contacts[contactKey{grantor: contact.GrantorID, grantee: contact.GranteeID}] = &models.EmergencyContact{
+		GrantorID:  contact.GrantorID,
+		GranteeID:  contact.GranteeID,
+		WaitPeriod: contact.WaitPeriod.Truncate(time.Second),
+		Status:     models.EmergencyIdle,
+		WrappedKey: bytes.Clone(contact.WrappedKey),
+	}
+
+	return nil
+}
+
+// GetContacts retrieves trusted contacts nominated by the grantor.
+func (s *MemStorage) GetContacts(_ context.Context, grantor models.UserID) ([]models.EmergencyContact, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.listContacts(func(key contactKey) bool { return key.grantor == grantor }), nil
+}
+
+// GetGrants retrieves accounts that nominated the grantee as trusted contact.
+func (s *MemStorage) GetGrants(_ context.Context, grantee models.UserID) ([]models.EmergencyContact, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.listContacts(func(key contactKey) bool { return key.grantee == grantee }), nil
+}
+
+// RequestAccess starts the waiting period of a trusted contact.
+func (s *MemStorage) RequestAccess(_ context.Context, grantor, grantee models.UserID, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.contacts[contactKey{grantor: grantor, grantee: grantee}]
+	if !ok || (c.Status != models.EmergencyIdle && c.Status != models.EmergencyDenied) {
+		return newErrNoEmergencyAccess(ErrNoEmergencyAccess)
+	}
+	c.Status = models.EmergencyRequested
+	c.RequestedAt = at
+
+	return nil
+}
+
+// DenyAccess denies a pending request of a trusted contact.
+func (s *MemStorage) DenyAccess(_ context.Context, grantor, grantee models.UserID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.contacts[contactKey{grantor: grantor, grantee: grantee}]
+	if !ok || c.Status != models.EmergencyRequested {
+		return newErrNoEmergencyAccess(ErrNoEmergencyAccess)
+	}
+	c.Status = models.EmergencyDenied
+
+	return nil
+}
+
+// ApproveExpiredRequests approves requests whose waiting period has elapsed.
+// Returns the number of approved requests.
+func (s *MemStorage) ApproveExpiredRequests(_ context.Context, now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int64
+	for _, c := range s.contacts {
+		if c.Status == models.EmergencyRequested && !c.RequestedAt.Add(c.WaitPeriod).After(now) {
+			c.Status = models.EmergencyApproved
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// GetApprovedKey retrieves grantor vault key wrapped for the grantee.
+// The key is only available after the request was approved.
+func (s *MemStorage) GetApprovedKey(_ context.Context, grantor, grantee models.UserID) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.contacts[contactKey{grantor: grantor, grantee: grantee}]
+	if !ok || c.Status != models.EmergencyApproved {
+		return nil, newErrNoEmergencyAccess(ErrNoEmergencyAccess)
+	}
+	return c.WrappedKey, nil
+}
+
+// AddEvent appends an event to the audit log.
+func (s *MemStorage) AddEvent(_ context.Context, event *models.AuditEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *event
+	stored.ID = int64(len(s.events)) + 1
+	s.events = append(s.events, stored)
+
+	return nil
+}
+
+// GetEvents retrieves events of the user, newest first.
+// Only events older than the before ID are returned, zero starts from the newest one.
+func (s *MemStorage) GetEvents(_ context.Context, uid models.UserID, before int64, limit int) ([]models.AuditEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var events []models.AuditEvent
+	for i := len(s.events) - 1; i >= 0 && len(events) < limit; i-- {
+		event := s.events[i]
+		if event.UserID == uid && (before == 0 || event.ID < before) {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+// ListUsers retrieves accounts whose username contains the query, ordered by username.
+// Only accounts after the given username are returned, empty starts from the first one.
+func (s *MemStorage) ListUsers(_ context.Context, query string, after string, limit int) ([]models.AdminUser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	query, after = validation.UsernameKey(query), validation.UsernameKey(after)
+
+	var users []models.AdminUser
+	for _, u := range s.users {
+		name := validation.UsernameKey(u.user.Username)
+		if strings.Contains(name, query) && name > after {
+			users = append(users, models.AdminUser{
+				ID:       u.user.ID,
+				Username: u.user.Username,
+				Disabled: !u.disabledAt.IsZero(),
+			})
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return validation.UsernameKey(users[i].Username) < validation.UsernameKey(users[j].Username)
+	})
+	if len(users) > limit {
+		users = users[:limit]
+	}
+
+	return users, nil
+}
+
+// SetUserDisabled disables or enables an account.
+// Disabling also revokes all tokens issued so far.
+func (s *MemStorage) SetUserDisabled(_ context.Context, uid models.UserID, disabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[uid]
+	if !ok {
+		return newErrNoUser(ErrNoUser)
+	}
+
+	if !disabled {
+		u.disabledAt = time.Time{}
+		return nil
+	}
+
+	now := time.Now()
+	if u.disabledAt.IsZero() {
+		u.disabledAt = now
+	}
+	u.revokedAt = now
+
+	return nil
+}
+
+// RevokeSessions invalidates all tokens issued to the user so far.
+func (s *MemStorage) RevokeSessions(_ context.Context, uid models.UserID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[uid]
+	if !ok {
+		return newErrNoUser(ErrNoUser)
+	}
+	u.revokedAt = time.Now()
+
+	return nil
+}
+
+// GetUsage counts personal items, their size, shares and memberships of the user.
+func (s *MemStorage) GetUsage(_ context.Context, uid models.UserID) (*models.Usage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var usage models.Usage
+	usage.Items, usage.Bytes = s.usage(uid)
+	for _, share := range s.shares {
+		if share.OwnerID == uid {
+			usage.Shares++
+		}
+	}
+	for _, members := range s.members {
+		if _, ok := members[uid]; ok {
+			usage.Orgs++
+		}
+	}
+
+	return &usage, nil
+}
+
+// get returns a copy of the account with its disabled flag
+func (u *memUser) get() *models.UserDB {
+	user := u.user
+	user.Disabled = !u.disabledAt.IsZero()
+	return &user
+}
+
+// userByName finds a user by username in any case, nil when missing
+func (s *MemStorage) userByName(username string) *memUser {
+	key := validation.UsernameKey(username)
+	for _, u := range s.users {
+		if validation.UsernameKey(u.user.Username) == key {
+			return u
+		}
+	}
+	return nil
+}
+
+// isMember reports membership of the user in the organization
+func (s *MemStorage) isMember(org models.OrgID, uid models.UserID) bool {
+	_, ok := s.members[org][uid]
+	return ok
+}
+
+// usage counts live personal items of the user and their payload size
+func (s *MemStorage) usage(uid models.UserID) (items, size int64) {
+	for _, item := range s.items {
+		if item.UserID == uid && !item.IsDeleted {
+			items++
+			size += int64(len(item.Data))
+		}
+	}
+	return items, size
+}
+
+// addCollection stores an empty collection of the organization
+func (s *MemStorage) addCollection(id models.CollectionID, org models.OrgID, name string) {
+	s.collections[id] = &models.Collection{ID: id, OrgID: org, Name: name}
+	s.colKeys[id] = make(map[models.UserID][]byte)
+}
+
+// deleteItem removes an item with its shares
+func (s *MemStorage) deleteItem(id models.ItemID) {
+	delete(s.items, id)
+	for key := range s.shares {
+		if key.item == id {
+			delete(s.shares, key)
+		}
+	}
+}
+
+// transferOwnership promotes the member with the highest role when no owner is left
+// Members with the same role are ordered by ID, like in SQL storage
+func transferOwnership(members map[models.UserID]models.Role) {
+	var successor models.UserID
+	for uid, role := range members {
+		if role == models.RoleOwner {
+			return
+		}
+		if successor == "" || roleRank[role] < roleRank[members[successor]] ||
+			roleRank[role] == roleRank[members[successor]] && uid < successor {
+			successor = uid
+		}
+	}
+	members[successor] = models.RoleOwner
+}
+
+// roleRank orders roles from the most privileged one
+var roleRank = map[models.Role]int{
+	models.RoleOwner:    0,
+	models.RoleAdmin:    1,
+	models.RoleMember:   2,
+	models.RoleReadOnly: 3,
+}
+
+// deleteOrg removes an organization with its collections and their items
+func (s *MemStorage) deleteOrg(org models.OrgID) {
+	for cid, col := range s.collections {
+		if col.OrgID != org {
+			continue
+		}
+		for id, item := range s.items {
+			if item.CollectionID == cid {
+				s.deleteItem(id)
+			}
+		}
+		delete(s.colKeys, cid)
+		delete(s.collections, cid)
+	}
+	delete(s.members, org)
+	delete(s.orgs, org)
+}
+
+// listContacts returns contacts matching the filter with counterpart usernames
+func (s *MemStorage) listContacts(match func(contactKey) bool) []models.EmergencyContact {
+	var contacts []models.EmergencyContact
+	for key, c := range s.contacts {
+		if !match(key) {
+			continue
+		}
+		contacts = append(contacts, models.EmergencyContact{
+			GrantorID:   c.GrantorID,
+			Grantor:     s.users[c.GrantorID].user.Username,
+			GranteeID:   c.GranteeID,
+			Grantee:     s.users[c.GranteeID].user.Username,
+			WaitPeriod:  c.WaitPeriod,
+			Status:      c.Status,
+			RequestedAt: c.RequestedAt,
+		})
+	}
+	sort.Slice(contacts, func(i, j int) bool {
+		if contacts[i].GrantorID != contacts[j].GrantorID {
+			return contacts[i].GrantorID < contacts[j].GrantorID
+		}
+		return contacts[i].GranteeID < contacts[j].GranteeID
+	})
+	return contacts
+}
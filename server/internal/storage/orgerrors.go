@@ -0,0 +1,66 @@
+package storage
+
+import "errors"
+
+// Base error definitions for organization operations
+var (
+	// ErrNoMember indicates that user is not a member of the organization
+	ErrNoMember = errors.New("user is not a member of organization")
+
+	// ErrMemberConflict indicates a duplicate membership
+	ErrMemberConflict = errors.New("user is already a member of organization")
+)
+
+// errNoMember implements a structured "member not found" error
+type errNoMember struct {
+	err error // Underlying error
+}
+
+// Error implements the error interface
+func (err *errNoMember) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap supports error inspection with errors.Is()/errors.As()
+func (err *errNoMember) Unwrap() error {
+	return err.err
+}
+
+// IsErrNoMember provides type checking method
+func (err *errNoMember) IsErrNoMember() bool {
+	return true
+}
+
+// newErrNoMember constructs a new member not found error
+func newErrNoMember(err error) error {
+	return &errNoMember{
+		err: err,
+	}
+}
+
+// errMemberConflict implements a structured membership conflict error
+type errMemberConflict struct {
+	err error // Underlying error
+}
+
+// Error implements the error interface
+func (err *errMemberConflict) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap supports error inspection with errors.Is()/errors.As()
+func (err *errMemberConflict) Unwrap() error {
+	return err.err
+}
+
+// IsErrMemberConflict provides type checking method
+func (err *errMemberConflict) IsErrMemberConflict() bool {
+	return true
+}
+
+// newErrMemberConflict constructs a new membership conflict error
+func newErrMemberConflict(err error) error {
+	return &errMemberConflict{
+		err: err,
+	}
+}
@@ -0,0 +1,238 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rycln/gokeep/shared/models"
+)
+
+// OrgStorage handles database operations for organizations and collections.
+type OrgStorage struct {
+	db *sql.DB
+}
+
+// NewOrgStorage creates a new OrgStorage instance.
+func NewOrgStorage(db *sql.DB) *OrgStorage {
+	return &OrgStorage{db: db}
+}
+
+// CreateOrganization stores a new organization with its owner and first collection.
+func (s *OrgStorage) CreateOrganization(
+	ctx context.Context,
+	org *models.Organization,
+	owner models.UserID,
+	col *models.Collection,
+) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			err = fmt.Errorf("%v; rollback failed: %w", err, rollbackErr)
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx, sqlAddOrganization, org.ID, org.Name); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, sqlAddMember, org.ID, owner, models.RoleOwner); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, sqlAddCollection, col.ID, org.ID, col.Name); err != nil {
+		return err
+	}
+
+	key := models.CollectionKey{
+		CollectionID: col.ID,
+		UserID:       owner,
+		WrappedKey:   col.WrappedKey,
+	}
+	if err := addCollectionKey(ctx, tx, org.ID, key); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// AddCollection stores a new collection with its keys wrapped for members.
+func (s *OrgStorage) AddCollection(ctx context.Context, col *models.Collection, keys []models.CollectionKey) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			err = fmt.Errorf("%v; rollback failed: %w", err, rollbackErr)
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx, sqlAddCollection, col.ID, col.OrgID, col.Name); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		key.CollectionID = col.ID
+		if err := addCollectionKey(ctx, tx, col.OrgID, key); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// AddMember stores a new membership with collection keys wrapped for the member.
+func (s *OrgStorage) AddMember(
+	ctx context.Context,
+	org models.OrgID,
+	member *models.Member,
+	keys []models.CollectionKey,
+) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			err = fmt.Errorf("%v; rollback failed: %w", err, rollbackErr)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, sqlAddMember, org, member.UserID, member.Role)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return newErrMemberConflict(ErrMemberConflict)
+		}
+		return err
+	}
+
+	for _, key := range keys {
+		key.UserID = member.UserID
+		if err := addCollectionKey(ctx, tx, org, key); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetOrgRole returns role of the user in the organization.
+func (s *OrgStorage) GetOrgRole(ctx context.Context, org models.OrgID, uid models.UserID) (models.Role, error) {
+	return s.getRole(ctx, sqlGetOrgRole, string(org), uid)
+}
+
+// GetCollectionRole returns role of the user in the organization owning the collection.
+func (s *OrgStorage) GetCollectionRole(ctx context.Context, cid models.CollectionID, uid models.UserID) (models.Role, error) {
+	return s.getRole(ctx, sqlGetCollectionRole, string(cid), uid)
+}
+
+// GetMembers retrieves all members of the organization with their public keys.
+func (s *OrgStorage) GetMembers(ctx context.Context, org models.OrgID) (members []models.Member, err error) {
+	rows, err := s.db.QueryContext(ctx, sqlGetMembers, org)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rowsCloseErr := rows.Close(); rowsCloseErr != nil {
+			err = fmt.Errorf("%v; rows close failed: %w", err, rowsCloseErr)
+		}
+	}()
+
+	for rows.Next() {
+		var member models.Member
+
+		err = rows.Scan(
+			&member.UserID,
+			&member.Username,
+			&member.Role,
+			&member.PublicKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, member)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// GetCollections retrieves collections available to the user with keys wrapped for them.
+func (s *OrgStorage) GetCollections(ctx context.Context, uid models.UserID) (cols []models.Collection, err error) {
+	rows, err := s.db.QueryContext(ctx, sqlGetCollections, uid)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rowsCloseErr := rows.Close(); rowsCloseErr != nil {
+			err = fmt.Errorf("%v; rows close failed: %w", err, rowsCloseErr)
+		}
+	}()
+
+	for rows.Next() {
+		var col models.Collection
+
+		err = rows.Scan(
+			&col.ID,
+			&col.OrgID,
+			&col.OrgName,
+			&col.Name,
+			&col.Role,
+			&col.WrappedKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		cols = append(cols, col)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return cols, nil
+}
+
+// getRole runs role lookup query for the user
+func (s *OrgStorage) getRole(ctx context.Context, query, id string, uid models.UserID) (models.Role, error) {
+	var role models.Role
+
+	err := s.db.QueryRowContext(ctx, query, id, uid).Scan(&role)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return "", newErrNoMember(ErrNoMember)
+	case err != nil:
+		return "", err
+	default:
+		return role, nil
+	}
+}
+
+// addCollectionKey stores collection key of an organization member
+func addCollectionKey(ctx context.Context, tx *sql.Tx, org models.OrgID, key models.CollectionKey) error {
+	res, err := tx.ExecContext(ctx, sqlAddCollectionKey, key.CollectionID, key.UserID, key.WrappedKey, org)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return newErrNoMember(ErrNoMember)
+	}
+
+	return nil
+}
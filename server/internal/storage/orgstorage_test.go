@@ -0,0 +1,268 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testOrgID = "550e8400-e29b-41d4-a716-446655440004"
+)
+
+func TestOrgStorage_CreateOrganization(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewOrgStorage(db)
+
+	org := &models.Organization{ID: testOrgID, Name: "team"}
+	col := &models.Collection{ID: testCollectionID, Name: "shared", WrappedKey: []byte("wrapped")}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddOrganization)).
+			WithArgs(org.ID, org.Name).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddMember)).
+			WithArgs(org.ID, testUserID, models.RoleOwner).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddCollection)).
+			WithArgs(col.ID, org.ID, col.Name).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddCollectionKey)).
+			WithArgs(col.ID, testUserID, col.WrappedKey, org.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := strg.CreateOrganization(context.Background(), org, testUserID, col)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddOrganization)).
+			WithArgs(org.ID, org.Name).
+			WillReturnError(errTest)
+		mock.ExpectRollback()
+
+		err := strg.CreateOrganization(context.Background(), org, testUserID, col)
+		assert.ErrorIs(t, err, errTest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOrgStorage_AddCollection(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewOrgStorage(db)
+
+	col := &models.Collection{ID: testCollectionID, OrgID: testOrgID, Name: "ops"}
+	keys := []models.CollectionKey{
+		{UserID: testUserID, WrappedKey: []byte("owner key")},
+		{UserID: testRecipientID, WrappedKey: []byte("member key")},
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddCollection)).
+			WithArgs(col.ID, col.OrgID, col.Name).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		for _, key := range keys {
+			mock.ExpectExec(regexp.QuoteMeta(sqlAddCollectionKey)).
+				WithArgs(col.ID, key.UserID, key.WrappedKey, col.OrgID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectCommit()
+
+		err := strg.AddCollection(context.Background(), col, keys)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("key for non member", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddCollection)).
+			WithArgs(col.ID, col.OrgID, col.Name).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddCollectionKey)).
+			WithArgs(col.ID, keys[0].UserID, keys[0].WrappedKey, col.OrgID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := strg.AddCollection(context.Background(), col, keys)
+		assert.ErrorIs(t, err, ErrNoMember)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOrgStorage_AddMember(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewOrgStorage(db)
+
+	member := &models.Member{UserID: testRecipientID, Role: models.RoleMember}
+	keys := []models.CollectionKey{
+		{CollectionID: testCollectionID, WrappedKey: []byte("member key")},
+	}
+
+	t.Run("successful addition", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddMember)).
+			WithArgs(testOrgID, member.UserID, member.Role).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddCollectionKey)).
+			WithArgs(testCollectionID, member.UserID, keys[0].WrappedKey, testOrgID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := strg.AddMember(context.Background(), testOrgID, member, keys)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already a member", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddMember)).
+			WithArgs(testOrgID, member.UserID, member.Role).
+			WillReturnError(&pgconn.PgError{Code: pgerrcode.UniqueViolation})
+		mock.ExpectRollback()
+
+		err := strg.AddMember(context.Background(), testOrgID, member, keys)
+		assert.ErrorIs(t, err, ErrMemberConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOrgStorage_GetCollectionRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewOrgStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlGetCollectionRole)
+
+	t.Run("member role", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testCollectionID, testUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow("readonly"))
+
+		role, err := strg.GetCollectionRole(context.Background(), testCollectionID, testUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, models.RoleReadOnly, role)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testCollectionID, testUserID).
+			WillReturnRows(sqlmock.NewRows([]string{"role"}))
+
+		_, err := strg.GetCollectionRole(context.Background(), testCollectionID, testUserID)
+		assert.ErrorIs(t, err, ErrNoMember)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOrgStorage_GetOrgRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewOrgStorage(db)
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetOrgRole)).
+			WithArgs(testOrgID, testUserID).
+			WillReturnError(errTest)
+
+		_, err := strg.GetOrgRole(context.Background(), testOrgID, testUserID)
+		assert.Equal(t, errTest, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOrgStorage_GetMembers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewOrgStorage(db)
+
+	t.Run("members of organization", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"user_id", "username", "role", "public_key"}).
+			AddRow(testUserID, "alice", "owner", []byte("alice key")).
+			AddRow(testRecipientID, "bob", "member", []byte("bob key"))
+
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetMembers)).
+			WithArgs(testOrgID).
+			WillReturnRows(rows)
+
+		members, err := strg.GetMembers(context.Background(), testOrgID)
+		require.NoError(t, err)
+		assert.Equal(t, []models.Member{
+			{UserID: testUserID, Username: "alice", Role: models.RoleOwner, PublicKey: []byte("alice key")},
+			{UserID: testRecipientID, Username: "bob", Role: models.RoleMember, PublicKey: []byte("bob key")},
+		}, members)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOrgStorage_GetCollections(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewOrgStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlGetCollections)
+
+	t.Run("collections of user", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "org_id", "org_name", "name", "role", "wrapped_key"}).
+			AddRow(testCollectionID, testOrgID, "team", "shared", "admin", []byte("wrapped"))
+
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testUserID).
+			WillReturnRows(rows)
+
+		cols, err := strg.GetCollections(context.Background(), testUserID)
+		require.NoError(t, err)
+		assert.Equal(t, []models.Collection{{
+			ID:         testCollectionID,
+			OrgID:      testOrgID,
+			OrgName:    "team",
+			Name:       "shared",
+			Role:       models.RoleAdmin,
+			WrappedKey: []byte("wrapped"),
+		}}, cols)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rows close error", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "org_id", "org_name", "name", "role", "wrapped_key"}).
+			AddRow(testCollectionID, testOrgID, "team", "shared", "admin", []byte("wrapped")).
+			CloseError(errTest)
+
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testUserID).
+			WillReturnRows(rows)
+
+		_, err := strg.GetCollections(context.Background(), testUserID)
+		assert.ErrorContains(t, err, errTest.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
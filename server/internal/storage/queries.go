@@ -1,8 +1,8 @@
 package storage
 
 const sqlAddUser = `
-	INSERT INTO users (id, username, password_hash, salt) 
-	VALUES ($1, $2, $3, $4) 
+	INSERT INTO users (id, username, username_key, password_hash, salt, encrypted_key, recovery_key, recovery_hash, public_key, encrypted_private_key) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) 
 `
 
 const sqlGetUserByUsername = `
@@ -10,9 +10,95 @@ const sqlGetUserByUsername = `
 		id, 
 		username, 
 		password_hash,
-		salt 
+		salt,
+		encrypted_key,
+		recovery_key,
+		recovery_hash,
+		public_key,
+		encrypted_private_key,
+		disabled_at IS NOT NULL 
 	FROM users 
-	WHERE username = $1
+	WHERE username_key = $1
+`
+
+const sqlGetUserByID = `
+	SELECT 
+		id, 
+		username, 
+		password_hash,
+		salt,
+		encrypted_key,
+		recovery_key,
+		recovery_hash,
+		public_key,
+		encrypted_private_key,
+		disabled_at IS NOT NULL 
+	FROM users 
+	WHERE id = $1
+`
+
+const sqlUpdateUserCredentials = `
+	UPDATE users 
+	SET password_hash = $1, 
+		salt = $2, 
+		encrypted_key = $3 
+	WHERE id = $4
+`
+
+const sqlSetKeyPair = `
+	UPDATE users 
+	SET public_key = $1, 
+		encrypted_private_key = $2 
+	WHERE id = $3 AND public_key IS NULL
+`
+
+const sqlGetPublicKey = `
+	SELECT 
+		id, 
+		public_key 
+	FROM users 
+	WHERE username_key = $1 AND public_key IS NOT NULL
+`
+
+const sqlDeleteUserItems = `
+	DELETE FROM items 
+	WHERE user_id = $1
+`
+
+const sqlDeleteUser = `
+	DELETE FROM users 
+	WHERE id = $1
+`
+
+const sqlTransferOwnership = `
+	UPDATE org_members 
+	SET role = 'owner' 
+	WHERE user_id = (
+		SELECT s.user_id 
+		FROM org_members s 
+		WHERE s.org_id = org_members.org_id AND s.user_id <> $1 
+		ORDER BY CASE s.role WHEN 'owner' THEN 0 WHEN 'admin' THEN 1 WHEN 'member' THEN 2 ELSE 3 END, s.user_id 
+		LIMIT 1
+	) 
+	AND org_id IN (
+		SELECT o.org_id 
+		FROM org_members o 
+		WHERE o.user_id = $1 AND o.role = 'owner' 
+		AND NOT EXISTS (
+			SELECT 1 
+			FROM org_members x 
+			WHERE x.org_id = o.org_id AND x.role = 'owner' AND x.user_id <> $1
+		)
+	)
+`
+
+const sqlDeleteEmptyOrganizations = `
+	DELETE FROM organizations 
+	WHERE NOT EXISTS (
+		SELECT 1 
+		FROM org_members m 
+		WHERE m.org_id = organizations.id
+	)
 `
 
 const sqlDeleteItem = `
@@ -22,9 +108,16 @@ const sqlDeleteItem = `
 	WHERE id = $2 AND user_id = $3
 `
 
+const sqlDeleteCollectionItem = `
+	UPDATE items 
+	SET is_deleted = true, 
+		updated_at = $1 
+	WHERE id = $2 AND collection_id = $3
+`
+
 const sqlAddItem = `
-	INSERT INTO items (id, user_id, type, name, metadata, data, updated_at, is_deleted)
-    VALUES ($1, $2, $3, $4, $5, $6, $7, false)
+	INSERT INTO items (id, user_id, type, name, metadata, data, updated_at, is_deleted, collection_id)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, false, $8)
 	ON CONFLICT (id) DO 
 		UPDATE 
         SET type = $3, 
@@ -32,11 +125,14 @@ const sqlAddItem = `
 			metadata = $5, 
 			data = $6, 
 			updated_at = $7, 
-			is_deleted = false`
+			is_deleted = false 
+		WHERE items.user_id IS NOT DISTINCT FROM $2 
+			AND items.collection_id IS NOT DISTINCT FROM $8`
 
 const sqlGetUserItems = `
 	SELECT 
 		id, 
+		COALESCE(CAST(collection_id AS TEXT), ''), 
 		type, 
 		name, 
 		metadata, 
@@ -44,5 +140,252 @@ const sqlGetUserItems = `
 		updated_at, 
 		is_deleted 
 	FROM items 
-	WHERE user_id = $1
+	WHERE user_id = $1 
+		OR collection_id IN (
+			SELECT c.id 
+			FROM collections c 
+			JOIN org_members m ON m.org_id = c.org_id 
+			WHERE m.user_id = $1
+		)
+`
+
+const sqlAddShare = `
+	INSERT INTO shares (item_id, owner_id, recipient_id, wrapped_key, payload, shared_at)
+	SELECT id, user_id, $3, $4, $5, $6 
+	FROM items 
+	WHERE id = $1 AND user_id = $2 AND is_deleted = false
+	ON CONFLICT (item_id, recipient_id) DO 
+		UPDATE 
+		SET wrapped_key = $4, 
+			payload = $5, 
+			shared_at = $6`
+
+const sqlGetSharedWith = `
+	SELECT 
+		s.item_id, 
+		s.owner_id, 
+		u.username, 
+		s.wrapped_key, 
+		s.payload, 
+		s.shared_at 
+	FROM shares s 
+	JOIN users u ON u.id = s.owner_id 
+	JOIN items i ON i.id = s.item_id 
+	WHERE s.recipient_id = $1 AND i.is_deleted = false
+`
+
+const sqlDeleteShare = `
+	DELETE FROM shares 
+	WHERE item_id = $1 
+		AND owner_id = $2 
+		AND recipient_id IN (
+			SELECT id 
+			FROM users 
+			WHERE username_key = $3
+		)
+`
+
+const sqlAddOrganization = `
+	INSERT INTO organizations (id, name) 
+	VALUES ($1, $2)
+`
+
+const sqlAddMember = `
+	INSERT INTO org_members (org_id, user_id, role) 
+	VALUES ($1, $2, $3)
+`
+
+const sqlAddCollection = `
+	INSERT INTO collections (id, org_id, name) 
+	VALUES ($1, $2, $3)
+`
+
+const sqlAddCollectionKey = `
+	INSERT INTO collection_keys (collection_id, user_id, wrapped_key)
+	SELECT c.id, m.user_id, $3 
+	FROM collections c 
+	JOIN org_members m ON m.org_id = c.org_id 
+	WHERE c.id = $1 AND m.user_id = $2 AND c.org_id = $4
+	ON CONFLICT (collection_id, user_id) DO 
+		UPDATE 
+		SET wrapped_key = $3`
+
+const sqlGetOrgRole = `
+	SELECT role 
+	FROM org_members 
+	WHERE org_id = $1 AND user_id = $2
+`
+
+const sqlGetCollectionRole = `
+	SELECT m.role 
+	FROM collections c 
+	JOIN org_members m ON m.org_id = c.org_id 
+	WHERE c.id = $1 AND m.user_id = $2
+`
+
+const sqlGetMembers = `
+	SELECT 
+		m.user_id, 
+		u.username, 
+		m.role, 
+		u.public_key 
+	FROM org_members m 
+	JOIN users u ON u.id = m.user_id 
+	WHERE m.org_id = $1 
+	ORDER BY u.username
+`
+
+const sqlGetCollections = `
+	SELECT 
+		c.id, 
+		c.org_id, 
+		o.name, 
+		c.name, 
+		m.role, 
+		k.wrapped_key 
+	FROM collection_keys k 
+	JOIN collections c ON c.id = k.collection_id 
+	JOIN organizations o ON o.id = c.org_id 
+	JOIN org_members m ON m.org_id = c.org_id AND m.user_id = k.user_id 
+	WHERE k.user_id = $1 
+	ORDER BY o.name, c.name
+`
+
+const sqlAddEmergencyContact = `
+	INSERT INTO emergency_contacts (grantor_id, grantee_id, wait_seconds, wrapped_key) 
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (grantor_id, grantee_id) DO 
+		UPDATE 
+		SET wait_seconds = $3, 
+			wrapped_key = $4, 
+			status = 'idle', 
+			requested_at = NULL`
+
+const sqlGetEmergencyContacts = `
+	SELECT 
+		e.grantee_id, 
+		u.username, 
+		e.wait_seconds, 
+		e.status, 
+		e.requested_at 
+	FROM emergency_contacts e 
+	JOIN users u ON u.id = e.grantee_id 
+	WHERE e.grantor_id = $1
+`
+
+const sqlGetEmergencyGrants = `
+	SELECT 
+		e.grantor_id, 
+		u.username, 
+		e.wait_seconds, 
+		e.status, 
+		e.requested_at 
+	FROM emergency_contacts e 
+	JOIN users u ON u.id = e.grantor_id 
+	WHERE e.grantee_id = $1
+`
+
+const sqlRequestEmergencyAccess = `
+	UPDATE emergency_contacts 
+	SET status = 'requested', 
+		requested_at = $3 
+	WHERE grantor_id = $1 
+		AND grantee_id = $2 
+		AND status IN ('idle', 'denied')
+`
+
+const sqlDenyEmergencyAccess = `
+	UPDATE emergency_contacts 
+	SET status = 'denied' 
+	WHERE grantor_id = $1 
+		AND grantee_id = $2 
+		AND status = 'requested'
+`
+
+const sqlApproveEmergencyRequests = `
+	UPDATE emergency_contacts 
+	SET status = 'approved' 
+	WHERE status = 'requested' 
+		AND requested_at + wait_seconds * INTERVAL '1 second' <= $1
+`
+
+const sqliteApproveEmergencyRequests = `
+	UPDATE emergency_contacts 
+	SET status = 'approved' 
+	WHERE status = 'requested' 
+		AND unixepoch(requested_at) + wait_seconds <= unixepoch($1)
+`
+
+const sqlGetEmergencyKey = `
+	SELECT wrapped_key 
+	FROM emergency_contacts 
+	WHERE grantor_id = $1 
+		AND grantee_id = $2 
+		AND status = 'approved'
+`
+
+const sqlAddAuditEvent = `
+	INSERT INTO audit_events (user_id, device, ip, action, result, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+const sqlGetAuditEvents = `
+	SELECT 
+		id,
+		device,
+		ip,
+		action,
+		result,
+		created_at
+	FROM audit_events
+	WHERE user_id = $1 AND ($2 = 0 OR id < $2)
+	ORDER BY id DESC
+	LIMIT $3
+`
+
+const sqlListUsers = `
+	SELECT 
+		id, 
+		username, 
+		disabled_at IS NOT NULL 
+	FROM users 
+	WHERE username_key LIKE '%' || $1 || '%' ESCAPE '\' 
+		AND username_key > $2 
+	ORDER BY username_key 
+	LIMIT $3
+`
+
+const sqlSetUserDisabled = `
+	UPDATE users 
+	SET disabled_at = CASE WHEN $1 THEN COALESCE(disabled_at, $2) END, 
+		sessions_revoked_at = CASE WHEN $1 THEN $2 ELSE sessions_revoked_at END 
+	WHERE id = $3
+`
+
+const sqlRevokeSessions = `
+	UPDATE users 
+	SET sessions_revoked_at = $1 
+	WHERE id = $2
+`
+
+const sqlGetSession = `
+	SELECT 
+		disabled_at IS NOT NULL, 
+		sessions_revoked_at 
+	FROM users 
+	WHERE id = $1
+`
+
+const sqlGetUsage = `
+	SELECT 
+		(SELECT COUNT(*) FROM items WHERE user_id = $1 AND is_deleted = false), 
+		(SELECT COALESCE(SUM(octet_length(data)), 0) FROM items WHERE user_id = $1 AND is_deleted = false), 
+		(SELECT COUNT(*) FROM shares WHERE owner_id = $1), 
+		(SELECT COUNT(*) FROM org_members WHERE user_id = $1)
+`
+
+const sqlGetUserDataSize = `
+	SELECT COALESCE(SUM(octet_length(data)), 0) 
+	FROM items 
+	WHERE user_id = $1 AND is_deleted = false
 `
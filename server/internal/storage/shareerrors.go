@@ -0,0 +1,66 @@
+package storage
+
+import "errors"
+
+// Base error definitions for sharing operations
+var (
+	// ErrNoItem indicates that item is missing or belongs to another user
+	ErrNoItem = errors.New("item does not exist")
+
+	// ErrNoShare indicates a missing share record
+	ErrNoShare = errors.New("item is not shared with user")
+)
+
+// errNoItem implements a structured "item not found" error
+type errNoItem struct {
+	err error // Underlying error
+}
+
+// Error implements the error interface
+func (err *errNoItem) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap supports error inspection with errors.Is()/errors.As()
+func (err *errNoItem) Unwrap() error {
+	return err.err
+}
+
+// IsErrNoItem provides type checking method
+func (err *errNoItem) IsErrNoItem() bool {
+	return true
+}
+
+// newErrNoItem constructs a new item not found error
+func newErrNoItem(err error) error {
+	return &errNoItem{
+		err: err,
+	}
+}
+
+// errNoShare implements a structured "share not found" error
+type errNoShare struct {
+	err error // Underlying error
+}
+
+// Error implements the error interface
+func (err *errNoShare) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap supports error inspection with errors.Is()/errors.As()
+func (err *errNoShare) Unwrap() error {
+	return err.err
+}
+
+// IsErrNoShare provides type checking method
+func (err *errNoShare) IsErrNoShare() bool {
+	return true
+}
+
+// newErrNoShare constructs a new share not found error
+func newErrNoShare(err error) error {
+	return &errNoShare{
+		err: err,
+	}
+}
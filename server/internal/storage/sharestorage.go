@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/rycln/gokeep/server/internal/validation"
+	"github.com/rycln/gokeep/shared/models"
+)
+
+// ShareStorage handles database operations for shared items.
+type ShareStorage struct {
+	db *sql.DB
+}
+
+// NewShareStorage creates a new ShareStorage instance.
+func NewShareStorage(db *sql.DB) *ShareStorage {
+	return &ShareStorage{db: db}
+}
+
+// AddShare stores an item shared with another user.
+// Sharing the same item again replaces the previous payload.
+func (s *ShareStorage) AddShare(ctx context.Context, share *models.Share) error {
+	res, err := s.db.ExecContext(
+		ctx,
+		sqlAddShare,
+		share.ItemID,
+		share.OwnerID,
+		share.RecipientID,
+		share.WrappedKey,
+		share.Payload,
+		share.SharedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return newErrNoItem(ErrNoItem)
+	}
+
+	return nil
+}
+
+// GetSharedWith retrieves all items shared with a user.
+func (s *ShareStorage) GetSharedWith(ctx context.Context, uid models.UserID) (shares []models.Share, err error) {
+	rows, err := s.db.QueryContext(ctx, sqlGetSharedWith, uid)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rowsCloseErr := rows.Close(); rowsCloseErr != nil {
+			err = fmt.Errorf("%v; rows close failed: %w", err, rowsCloseErr)
+		}
+	}()
+
+	for rows.Next() {
+		var share = models.Share{
+			RecipientID: uid,
+		}
+
+		err = rows.Scan(
+			&share.ItemID,
+			&share.OwnerID,
+			&share.Owner,
+			&share.WrappedKey,
+			&share.Payload,
+			&share.SharedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		shares = append(shares, share)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return shares, nil
+}
+
+// DeleteShare revokes access of the recipient to the owner's item.
+func (s *ShareStorage) DeleteShare(ctx context.Context, id models.ItemID, owner models.UserID, recipient string) error {
+	res, err := s.db.ExecContext(ctx, sqlDeleteShare, id, owner, validation.UsernameKey(recipient))
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return newErrNoShare(ErrNoShare)
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRecipientID = "550e8400-e29b-41d4-a716-446655440002"
+)
+
+func TestShareStorage_AddShare(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewShareStorage(db)
+
+	testShare := &models.Share{
+		ItemID:      testItemID,
+		OwnerID:     testUserID,
+		RecipientID: testRecipientID,
+		WrappedKey:  []byte("wrapped"),
+		Payload:     []byte("payload"),
+		SharedAt:    time.Now(),
+	}
+
+	expectedQuery := regexp.QuoteMeta(sqlAddShare)
+
+	t.Run("successful share", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(
+				testShare.ItemID,
+				testShare.OwnerID,
+				testShare.RecipientID,
+				testShare.WrappedKey,
+				testShare.Payload,
+				testShare.SharedAt,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.AddShare(context.Background(), testShare)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("item of another user", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(
+				testShare.ItemID,
+				testShare.OwnerID,
+				testShare.RecipientID,
+				testShare.WrappedKey,
+				testShare.Payload,
+				testShare.SharedAt,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := strg.AddShare(context.Background(), testShare)
+		assert.ErrorIs(t, err, ErrNoItem)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("general database error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(
+				testShare.ItemID,
+				testShare.OwnerID,
+				testShare.RecipientID,
+				testShare.WrappedKey,
+				testShare.Payload,
+				testShare.SharedAt,
+			).
+			WillReturnError(errTest)
+
+		err := strg.AddShare(context.Background(), testShare)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestShareStorage_GetSharedWith(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewShareStorage(db)
+
+	testShare := models.Share{
+		ItemID:      testItemID,
+		OwnerID:     testUserID,
+		Owner:       "owner",
+		RecipientID: testRecipientID,
+		WrappedKey:  []byte("wrapped"),
+		Payload:     []byte("payload"),
+		SharedAt:    time.Now(),
+	}
+
+	expectedQuery := regexp.QuoteMeta(sqlGetSharedWith)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		rows := mock.NewRows([]string{"item_id", "owner_id", "username", "wrapped_key", "payload", "shared_at"}).
+			AddRow(
+				testShare.ItemID,
+				testShare.OwnerID,
+				testShare.Owner,
+				testShare.WrappedKey,
+				testShare.Payload,
+				testShare.SharedAt,
+			)
+
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testRecipientID).
+			WillReturnRows(rows)
+
+		shares, err := strg.GetSharedWith(context.Background(), testRecipientID)
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Share{testShare}, shares)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("general database error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testRecipientID).
+			WillReturnError(errTest)
+
+		_, err := strg.GetSharedWith(context.Background(), testRecipientID)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestShareStorage_DeleteShare(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewShareStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlDeleteShare)
+
+	t.Run("successful revoke", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(testItemID, testUserID, "recipient").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.DeleteShare(context.Background(), testItemID, testUserID, "recipient")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("share not found error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(testItemID, testUserID, "recipient").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := strg.DeleteShare(context.Background(), testItemID, testUserID, "recipient")
+		assert.ErrorIs(t, err, ErrNoShare)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("general database error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(testItemID, testUserID, "recipient").
+			WillReturnError(errTest)
+
+		err := strg.DeleteShare(context.Background(), testItemID, testUserID, "recipient")
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
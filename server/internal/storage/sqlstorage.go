@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+
+	schema "github.com/rycln/gokeep/server/internal/db"
+)
+
+// SQLStorage combines all SQL storages sharing a database connection pool.
+type SQLStorage struct {
+	*UserStorage
+	*ItemStorage
+	*ShareStorage
+	*OrgStorage
+	*EmergencyStorage
+	*AuditStorage
+	*AdminStorage
+
+	db *sql.DB
+}
+
+// NewSQLStorage creates all SQL storages on the database.
+func NewSQLStorage(db *sql.DB, dialect schema.Dialect) *SQLStorage {
+	return &SQLStorage{
+		UserStorage:      NewUserStorage(db),
+		ItemStorage:      NewItemStorage(db, dialect),
+		ShareStorage:     NewShareStorage(db),
+		OrgStorage:       NewOrgStorage(db),
+		EmergencyStorage: NewEmergencyStorage(db, dialect),
+		AuditStorage:     NewAuditStorage(db),
+		AdminStorage:     NewAdminStorage(db),
+		db:               db,
+	}
+}
+
+// PingContext checks that the database is reachable.
+func (s *SQLStorage) PingContext(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
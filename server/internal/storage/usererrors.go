@@ -9,6 +9,9 @@ var (
 
 	// ErrNoUser indicates a missing user record
 	ErrNoUser = errors.New("user does not exist")
+
+	// ErrKeyPairExists indicates that sharing keypair of the user is already set
+	ErrKeyPairExists = errors.New("keypair is already set")
 )
 
 // errUsernameConflict implements a structured conflict error
@@ -64,3 +67,30 @@ func newErrNoUser(err error) error {
 		err: err,
 	}
 }
+
+// errKeyPairExists implements a structured keypair conflict error
+type errKeyPairExists struct {
+	err error // Underlying error
+}
+
+// Error implements the error interface
+func (err *errKeyPairExists) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap supports error inspection with errors.Is()/errors.As()
+func (err *errKeyPairExists) Unwrap() error {
+	return err.err
+}
+
+// IsErrKeyPairExists provides type checking method
+func (err *errKeyPairExists) IsErrKeyPairExists() bool {
+	return true
+}
+
+// newErrKeyPairExists constructs a new keypair conflict error
+func newErrKeyPairExists(err error) error {
+	return &errKeyPairExists{
+		err: err,
+	}
+}
@@ -4,10 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 
-	"github.com/jackc/pgerrcode"
-	"github.com/jackc/pgx/v5/pgconn"
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/rycln/gokeep/server/internal/validation"
 	"github.com/rycln/gokeep/shared/models"
 )
 
@@ -23,10 +22,22 @@ func NewUserStorage(db *sql.DB) *UserStorage {
 
 // AddUser persists a new user to the database
 func (s *UserStorage) AddUser(ctx context.Context, user *models.UserDB) error {
-	_, err := s.db.ExecContext(ctx, sqlAddUser, user.ID, user.Username, user.PassHash, user.Salt)
+	_, err := s.db.ExecContext(
+		ctx,
+		sqlAddUser,
+		user.ID,
+		user.Username,
+		validation.UsernameKey(user.Username),
+		user.PassHash,
+		user.Salt,
+		user.EncryptedKey,
+		user.RecoveryKey,
+		user.RecoveryHash,
+		user.PublicKey,
+		user.EncryptedPrivateKey,
+	)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(pgErr.Code) {
+		if isConstraintViolation(err) {
 			return newErrUsernameConflict(ErrUsernameConflict)
 		}
 		return err
@@ -36,10 +47,29 @@ func (s *UserStorage) AddUser(ctx context.Context, user *models.UserDB) error {
 
 // GetUserByUsername retrieves a user by their username
 func (s *UserStorage) GetUserByUsername(ctx context.Context, username string) (*models.UserDB, error) {
-	row := s.db.QueryRowContext(ctx, sqlGetUserByUsername, username)
+	return scanUser(s.db.QueryRowContext(ctx, sqlGetUserByUsername, validation.UsernameKey(username)))
+}
+
+// GetUserByID retrieves a user by their ID
+func (s *UserStorage) GetUserByID(ctx context.Context, uid models.UserID) (*models.UserDB, error) {
+	return scanUser(s.db.QueryRowContext(ctx, sqlGetUserByID, uid))
+}
 
+// scanUser reads a single user row
+func scanUser(row *sql.Row) (*models.UserDB, error) {
 	var userDB models.UserDB
-	err := row.Scan(&userDB.ID, &userDB.Username, &userDB.PassHash, &userDB.Salt)
+	err := row.Scan(
+		&userDB.ID,
+		&userDB.Username,
+		&userDB.PassHash,
+		&userDB.Salt,
+		&userDB.EncryptedKey,
+		&userDB.RecoveryKey,
+		&userDB.RecoveryHash,
+		&userDB.PublicKey,
+		&userDB.EncryptedPrivateKey,
+		&userDB.Disabled,
+	)
 
 	switch {
 	case errors.Is(err, sql.ErrNoRows):
@@ -50,3 +80,124 @@ func (s *UserStorage) GetUserByUsername(ctx context.Context, username string) (*
 		return &userDB, nil
 	}
 }
+
+// UpdateUserCredentials replaces password hash, salt and wrapped vault key of a user
+func (s *UserStorage) UpdateUserCredentials(ctx context.Context, user *models.UserDB) error {
+	res, err := s.db.ExecContext(ctx, sqlUpdateUserCredentials, user.PassHash, user.Salt, user.EncryptedKey, user.ID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return newErrNoUser(ErrNoUser)
+	}
+
+	return nil
+}
+
+// SetKeyPair stores sharing keypair of a user who has none yet.
+// Keys shared with the user are wrapped to the public key, so it is never replaced.
+// Requests come with a valid session of an existing user, so no updated row
+// means the keypair is already set.
+func (s *UserStorage) SetKeyPair(ctx context.Context, uid models.UserID, kp *models.KeyPair) error {
+	res, err := s.db.ExecContext(ctx, sqlSetKeyPair, kp.PublicKey, kp.EncryptedPrivateKey, uid)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return newErrKeyPairExists(ErrKeyPairExists)
+	}
+
+	return nil
+}
+
+// DeleteUser removes a user with all personal items in a single transaction.
+// Shares, memberships, collection keys and emergency contacts are removed by cascade.
+// Organizations of a sole owner pass to the member with the highest role,
+// organizations left without members are removed.
+func (s *UserStorage) DeleteUser(ctx context.Context, uid models.UserID) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			err = fmt.Errorf("%v; rollback failed: %w", err, rollbackErr)
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx, sqlDeleteUserItems, uid); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlTransferOwnership, uid); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, sqlDeleteUser, uid)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return newErrNoUser(ErrNoUser)
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlDeleteEmptyOrganizations); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetPublicKey retrieves public key of a user by username.
+// Users without a keypair are reported as missing because nothing can be shared with them.
+func (s *UserStorage) GetPublicKey(ctx context.Context, username string) (*models.PublicKey, error) {
+	row := s.db.QueryRowContext(ctx, sqlGetPublicKey, validation.UsernameKey(username))
+
+	var pk models.PublicKey
+	err := row.Scan(&pk.UserID, &pk.Key)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, newErrNoUser(ErrNoUser)
+	case err != nil:
+		return nil, err
+	default:
+		return &pk, nil
+	}
+}
+
+// GetSession retrieves token validity state of a user.
+func (s *UserStorage) GetSession(ctx context.Context, uid models.UserID) (*models.Session, error) {
+	row := s.db.QueryRowContext(ctx, sqlGetSession, uid)
+
+	var (
+		session   models.Session
+		revokedAt sql.NullTime
+	)
+	err := row.Scan(&session.Disabled, &revokedAt)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, newErrNoUser(ErrNoUser)
+	case err != nil:
+		return nil, err
+	default:
+		session.RevokedAt = revokedAt.Time
+		return &session, nil
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jackc/pgerrcode"
@@ -16,12 +17,18 @@ import (
 )
 
 const (
-	testUserID = "550e8400-e29b-41d4-a716-446655440000"
-	testSalt   = "salt"
+	testUserID       = "550e8400-e29b-41d4-a716-446655440000"
+	testSalt         = "salt"
+	testEncryptedKey = "encrypted_key"
+	testRecoveryKey  = "recovery_key"
+
+	testEncryptedPrivateKey = "encrypted_private_key"
 )
 
 var (
 	errTest = errors.New("test error")
+
+	testPublicKey = []byte("public_key")
 )
 
 func TestUserStorage_AddUser(t *testing.T) {
@@ -32,17 +39,35 @@ func TestUserStorage_AddUser(t *testing.T) {
 	strg := NewUserStorage(db)
 
 	testUser := &models.UserDB{
-		ID:       testUserID,
-		Username: "testuser",
-		PassHash: "hashed_password",
-		Salt:     testSalt,
+		ID:           testUserID,
+		Username:     "testuser",
+		PassHash:     "hashed_password",
+		Salt:         testSalt,
+		EncryptedKey: testEncryptedKey,
+		RecoveryKey:  testRecoveryKey,
+		RecoveryHash: "hashed_recovery",
+		KeyPair: models.KeyPair{
+			PublicKey:           testPublicKey,
+			EncryptedPrivateKey: testEncryptedPrivateKey,
+		},
 	}
 
 	expectedQuery := regexp.QuoteMeta(sqlAddUser)
 
 	t.Run("successful user creation", func(t *testing.T) {
 		mock.ExpectExec(expectedQuery).
-			WithArgs(testUser.ID, testUser.Username, testUser.PassHash, testUser.Salt).
+			WithArgs(
+				testUser.ID,
+				testUser.Username,
+				"testuser",
+				testUser.PassHash,
+				testUser.Salt,
+				testUser.EncryptedKey,
+				testUser.RecoveryKey,
+				testUser.RecoveryHash,
+				testUser.PublicKey,
+				testUser.EncryptedPrivateKey,
+			).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err := strg.AddUser(context.Background(), testUser)
@@ -56,7 +81,18 @@ func TestUserStorage_AddUser(t *testing.T) {
 		}
 
 		mock.ExpectExec(expectedQuery).
-			WithArgs(testUser.ID, testUser.Username, testUser.PassHash, testUser.Salt).
+			WithArgs(
+				testUser.ID,
+				testUser.Username,
+				"testuser",
+				testUser.PassHash,
+				testUser.Salt,
+				testUser.EncryptedKey,
+				testUser.RecoveryKey,
+				testUser.RecoveryHash,
+				testUser.PublicKey,
+				testUser.EncryptedPrivateKey,
+			).
 			WillReturnError(pgErr)
 
 		err := strg.AddUser(context.Background(), testUser)
@@ -66,7 +102,18 @@ func TestUserStorage_AddUser(t *testing.T) {
 
 	t.Run("general database error", func(t *testing.T) {
 		mock.ExpectExec(expectedQuery).
-			WithArgs(testUser.ID, testUser.Username, testUser.PassHash, testUser.Salt).
+			WithArgs(
+				testUser.ID,
+				testUser.Username,
+				"testuser",
+				testUser.PassHash,
+				testUser.Salt,
+				testUser.EncryptedKey,
+				testUser.RecoveryKey,
+				testUser.RecoveryHash,
+				testUser.PublicKey,
+				testUser.EncryptedPrivateKey,
+			).
 			WillReturnError(errTest)
 
 		err := strg.AddUser(context.Background(), testUser)
@@ -83,17 +130,38 @@ func TestUserStorage_GetUserByUsername(t *testing.T) {
 	strg := NewUserStorage(db)
 
 	testUser := &models.UserDB{
-		ID:       testUserID,
-		Username: "testuser",
-		PassHash: "hashed_password",
-		Salt:     testSalt,
+		ID:           testUserID,
+		Username:     "testuser",
+		PassHash:     "hashed_password",
+		Salt:         testSalt,
+		EncryptedKey: testEncryptedKey,
+		RecoveryKey:  testRecoveryKey,
+		RecoveryHash: "hashed_recovery",
+		KeyPair: models.KeyPair{
+			PublicKey:           testPublicKey,
+			EncryptedPrivateKey: testEncryptedPrivateKey,
+		},
 	}
 
 	expectedQuery := regexp.QuoteMeta(sqlGetUserByUsername)
 
 	t.Run("successful user retrieval", func(t *testing.T) {
-		rows := mock.NewRows([]string{"id", "username", "pass_hash", "salt"}).
-			AddRow(testUser.ID, testUser.Username, testUser.PassHash, testUser.Salt)
+		rows := mock.NewRows([]string{
+			"id", "username", "pass_hash", "salt", "encrypted_key", "recovery_key", "recovery_hash",
+			"public_key", "encrypted_private_key", "disabled",
+		}).
+			AddRow(
+				testUser.ID,
+				testUser.Username,
+				testUser.PassHash,
+				testUser.Salt,
+				testUser.EncryptedKey,
+				testUser.RecoveryKey,
+				testUser.RecoveryHash,
+				testUser.PublicKey,
+				testUser.EncryptedPrivateKey,
+				testUser.Disabled,
+			)
 
 		mock.ExpectQuery(expectedQuery).
 			WithArgs(testUser.Username).
@@ -110,7 +178,7 @@ func TestUserStorage_GetUserByUsername(t *testing.T) {
 			WithArgs(testUser.Username).
 			WillReturnError(sql.ErrNoRows)
 
-		_, err := strg.GetUserByUsername(context.Background(), testUser.Username)
+		_, err := strg.GetUserByUsername(context.Background(), "TestUser")
 		assert.ErrorIs(t, err, ErrNoUser)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
@@ -125,3 +193,294 @@ func TestUserStorage_GetUserByUsername(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestUserStorage_GetUserByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewUserStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlGetUserByID)
+
+	t.Run("successful user retrieval", func(t *testing.T) {
+		rows := mock.NewRows([]string{
+			"id", "username", "pass_hash", "salt", "encrypted_key", "recovery_key", "recovery_hash",
+			"public_key", "encrypted_private_key", "disabled",
+		}).
+			AddRow(testUserID, "testuser", "hashed_password", testSalt, testEncryptedKey, "", "", nil, "", true)
+
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testUserID).
+			WillReturnRows(rows)
+
+		user, err := strg.GetUserByID(context.Background(), testUserID)
+		require.NoError(t, err)
+		assert.Equal(t, models.UserID(testUserID), user.ID)
+		assert.Equal(t, "hashed_password", user.PassHash)
+		assert.True(t, user.Disabled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user not found error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testUserID).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := strg.GetUserByID(context.Background(), testUserID)
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserStorage_DeleteUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewUserStorage(db)
+
+	t.Run("user and items removed in one transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteUserItems)).
+			WithArgs(testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(regexp.QuoteMeta(sqlTransferOwnership)).
+			WithArgs(testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteUser)).
+			WithArgs(testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteEmptyOrganizations)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := strg.DeleteUser(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user not found error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteUserItems)).
+			WithArgs(testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(sqlTransferOwnership)).
+			WithArgs(testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteUser)).
+			WithArgs(testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := strg.DeleteUser(context.Background(), testUserID)
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("items removal error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteUserItems)).
+			WithArgs(testUserID).
+			WillReturnError(errTest)
+		mock.ExpectRollback()
+
+		err := strg.DeleteUser(context.Background(), testUserID)
+		assert.ErrorIs(t, err, errTest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ownership transfer error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteUserItems)).
+			WithArgs(testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(sqlTransferOwnership)).
+			WithArgs(testUserID).
+			WillReturnError(errTest)
+		mock.ExpectRollback()
+
+		err := strg.DeleteUser(context.Background(), testUserID)
+		assert.ErrorIs(t, err, errTest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserStorage_UpdateUserCredentials(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewUserStorage(db)
+
+	testUser := &models.UserDB{
+		ID:           testUserID,
+		PassHash:     "new_hashed_password",
+		Salt:         "new_salt",
+		EncryptedKey: testEncryptedKey,
+	}
+
+	expectedQuery := regexp.QuoteMeta(sqlUpdateUserCredentials)
+
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(testUser.PassHash, testUser.Salt, testUser.EncryptedKey, testUser.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.UpdateUserCredentials(context.Background(), testUser)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user not found error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(testUser.PassHash, testUser.Salt, testUser.EncryptedKey, testUser.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := strg.UpdateUserCredentials(context.Background(), testUser)
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("general database error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(testUser.PassHash, testUser.Salt, testUser.EncryptedKey, testUser.ID).
+			WillReturnError(errTest)
+
+		err := strg.UpdateUserCredentials(context.Background(), testUser)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserStorage_SetKeyPair(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewUserStorage(db)
+
+	kp := &models.KeyPair{
+		PublicKey:           testPublicKey,
+		EncryptedPrivateKey: testEncryptedPrivateKey,
+	}
+
+	expectedQuery := regexp.QuoteMeta(sqlSetKeyPair)
+
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(kp.PublicKey, kp.EncryptedPrivateKey, testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.SetKeyPair(context.Background(), testUserID, kp)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("keypair already set", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(kp.PublicKey, kp.EncryptedPrivateKey, testUserID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := strg.SetKeyPair(context.Background(), testUserID, kp)
+		assert.ErrorIs(t, err, ErrKeyPairExists)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("general database error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).
+			WithArgs(kp.PublicKey, kp.EncryptedPrivateKey, testUserID).
+			WillReturnError(errTest)
+
+		err := strg.SetKeyPair(context.Background(), testUserID, kp)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserStorage_GetPublicKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewUserStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlGetPublicKey)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		rows := mock.NewRows([]string{"id", "public_key"}).
+			AddRow(testUserID, testPublicKey)
+
+		mock.ExpectQuery(expectedQuery).
+			WithArgs("testuser").
+			WillReturnRows(rows)
+
+		pk, err := strg.GetPublicKey(context.Background(), "testuser")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.PublicKey{UserID: testUserID, Key: testPublicKey}, pk)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user not found error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs("testuser").
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := strg.GetPublicKey(context.Background(), "testuser")
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("general database error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs("testuser").
+			WillReturnError(errTest)
+
+		_, err := strg.GetPublicKey(context.Background(), "testuser")
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserStorage_GetSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewUserStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlGetSession)
+
+	t.Run("revoked sessions", func(t *testing.T) {
+		revokedAt := time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testUserID).
+			WillReturnRows(mock.NewRows([]string{"disabled", "sessions_revoked_at"}).AddRow(true, revokedAt))
+
+		session, err := strg.GetSession(context.Background(), testUserID)
+		require.NoError(t, err)
+		assert.Equal(t, &models.Session{Disabled: true, RevokedAt: revokedAt}, session)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("never revoked", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testUserID).
+			WillReturnRows(mock.NewRows([]string{"disabled", "sessions_revoked_at"}).AddRow(false, nil))
+
+		session, err := strg.GetSession(context.Background(), testUserID)
+		require.NoError(t, err)
+		assert.Equal(t, &models.Session{}, session)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user not found error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(testUserID).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := strg.GetSession(context.Background(), testUserID)
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,2 @@
+// Package tracing configures OpenTelemetry trace export of the server.
+package tracing
@@ -0,0 +1,123 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies spans created by the server
+const instrumentationName = "github.com/rycln/gokeep/server"
+
+// Stdout is the trace file name that writes spans to standard output
+const Stdout = "stdout"
+
+// Options defines trace exporters.
+// Spans are only propagated when no exporter is configured.
+type Options struct {
+	Endpoint string // OTLP gRPC collector address
+	Insecure bool   // Disable TLS to the collector
+	File     string // File to write spans as JSON, or Stdout
+
+	ServiceName    string
+	ServiceVersion string
+}
+
+// Setup installs global trace provider and W3C trace context propagation.
+// Returned function flushes pending spans and releases exporters.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if opts.Endpoint == "" && opts.File == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(opts.ServiceName),
+		semconv.ServiceVersion(opts.ServiceVersion),
+	)
+	providerOpts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+
+	if opts.Endpoint != "" {
+		clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+		if opts.Insecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+		exp, err := otlptracegrpc.New(ctx, clientOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("can't create otlp exporter: %w", err)
+		}
+		providerOpts = append(providerOpts, sdktrace.WithBatcher(exp))
+	}
+
+	var file io.WriteCloser
+	if opts.File != "" {
+		w, err := openFile(opts.File)
+		if err != nil {
+			return nil, fmt.Errorf("can't open trace file: %w", err)
+		}
+		file = w
+
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(w))
+		if err != nil {
+			return nil, fmt.Errorf("can't create file exporter: %w", err)
+		}
+		providerOpts = append(providerOpts, sdktrace.WithBatcher(exp))
+	}
+
+	tp := sdktrace.NewTracerProvider(providerOpts...)
+	otel.SetTracerProvider(tp)
+
+	return func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		if file != nil {
+			err = errors.Join(err, file.Close())
+		}
+		return err
+	}, nil
+}
+
+// openFile opens trace file for appending
+// Standard output is never closed
+func openFile(name string) (io.WriteCloser, error) {
+	if name == Stdout {
+		return nopCloser{os.Stdout}, nil
+	}
+	return os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+}
+
+// nopCloser keeps the wrapped writer open on Close
+type nopCloser struct{ io.Writer }
+
+// Close does nothing
+func (nopCloser) Close() error { return nil }
+
+// Tracer returns the server tracer of the global provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// End marks span failed when err is set and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
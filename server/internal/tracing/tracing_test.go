@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetup(t *testing.T) {
+	oldProvider := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(oldProvider)
+
+	t.Run("spans written to file", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "traces.json")
+
+		shutdown, err := Setup(context.Background(), Options{File: name, ServiceName: "test"})
+		require.NoError(t, err)
+
+		_, span := Tracer().Start(context.Background(), "test-span")
+		span.End()
+
+		require.NoError(t, shutdown(context.Background()))
+
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "test-span")
+	})
+
+	t.Run("no exporters configured", func(t *testing.T) {
+		shutdown, err := Setup(context.Background(), Options{})
+		require.NoError(t, err)
+		assert.NoError(t, shutdown(context.Background()))
+	})
+
+	t.Run("wrong file path", func(t *testing.T) {
+		_, err := Setup(context.Background(), Options{File: filepath.Join(t.TempDir(), "missing", "traces.json")})
+		assert.Error(t, err)
+	})
+}
+
+func TestEnd(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	t.Run("failed span", func(t *testing.T) {
+		_, span := tracer.Start(context.Background(), "failed")
+		End(span, errors.New("test error"))
+
+		ended := recorder.Ended()
+		require.Len(t, ended, 1)
+		assert.Equal(t, otelcodes.Error, ended[0].Status().Code)
+		assert.Len(t, ended[0].Events(), 1)
+	})
+
+	t.Run("successful span", func(t *testing.T) {
+		_, span := tracer.Start(context.Background(), "ok")
+		End(span, nil)
+
+		ended := recorder.Ended()
+		assert.Equal(t, otelcodes.Unset, ended[len(ended)-1].Status().Code)
+	})
+}
@@ -0,0 +1,126 @@
+// Package validation implements account credentials policy.
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rycln/gokeep/shared/models"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Policy defines requirements for new account credentials.
+type Policy struct {
+	usernameMin   int
+	usernameMax   int
+	usernameChars *regexp.Regexp
+	passwordMin   int
+	passwordKinds int // Required kinds of characters: lower, upper, digits, other
+}
+
+// NewPolicy creates credentials policy.
+// Username charset is a regular expression the whole normalized username must match.
+func NewPolicy(usernameMin, usernameMax int, usernameChars string, passwordMin, passwordKinds int) (*Policy, error) {
+	re, err := regexp.Compile(usernameChars)
+	if err != nil {
+		return nil, fmt.Errorf("invalid username charset: %w", err)
+	}
+
+	return &Policy{
+		usernameMin:   usernameMin,
+		usernameMax:   usernameMax,
+		usernameChars: re,
+		passwordMin:   passwordMin,
+		passwordKinds: passwordKinds,
+	}, nil
+}
+
+// NormalizeUsername brings username to the stored form.
+// NFKC folds visually identical compositions so they cannot register twice.
+func NormalizeUsername(username string) string {
+	return norm.NFKC.String(strings.TrimSpace(username))
+}
+
+// UsernameKey returns case-insensitive identity of a username.
+func UsernameKey(username string) string {
+	return strings.ToLower(NormalizeUsername(username))
+}
+
+// Validate checks normalized username and password of a new account.
+// Returns *models.ValidationError listing every violated field.
+func (p *Policy) Validate(username, password string) error {
+	var violations []models.FieldViolation
+
+	if n := utf8.RuneCountInString(username); n < p.usernameMin || n > p.usernameMax {
+		violations = append(violations, models.FieldViolation{
+			Field:       models.FieldUsername,
+			Reason:      models.ReasonUsernameLength,
+			Description: fmt.Sprintf("must be %d to %d characters long", p.usernameMin, p.usernameMax),
+		})
+	} else if !p.usernameChars.MatchString(username) {
+		violations = append(violations, models.FieldViolation{
+			Field:       models.FieldUsername,
+			Reason:      models.ReasonUsernameCharset,
+			Description: "contains unsupported characters",
+		})
+	}
+
+	if v, ok := p.passwordViolation(username, password); ok {
+		violations = append(violations, v)
+	}
+
+	if len(violations) > 0 {
+		return &models.ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// ValidatePassword checks a new password of an existing account.
+// Username is not validated, so accounts created under an older policy can still change passwords.
+func (p *Policy) ValidatePassword(username, password string) error {
+	if v, ok := p.passwordViolation(username, password); ok {
+		return &models.ValidationError{Violations: []models.FieldViolation{v}}
+	}
+	return nil
+}
+
+// passwordViolation returns the password rule broken by password, if any
+func (p *Policy) passwordViolation(username, password string) (models.FieldViolation, bool) {
+	if utf8.RuneCountInString(password) < p.passwordMin {
+		return models.FieldViolation{
+			Field:       models.FieldPassword,
+			Reason:      models.ReasonPasswordLength,
+			Description: fmt.Sprintf("must be at least %d characters long", p.passwordMin),
+		}, true
+	}
+	if passwordKinds(password) < p.passwordKinds ||
+		(username != "" && strings.Contains(strings.ToLower(password), strings.ToLower(username))) {
+		return models.FieldViolation{
+			Field:       models.FieldPassword,
+			Reason:      models.ReasonPasswordWeak,
+			Description: fmt.Sprintf("must mix at least %d kinds of characters and not contain the username", p.passwordKinds),
+		}, true
+	}
+	return models.FieldViolation{}, false
+}
+
+// passwordKinds counts kinds of characters used in password
+func passwordKinds(password string) int {
+	var lower, upper, digit, other int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
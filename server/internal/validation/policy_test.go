@@ -0,0 +1,96 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/rycln/gokeep/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCharset = `^[\p{L}\p{N}._-]+$`
+
+func TestPolicy_Validate(t *testing.T) {
+	p, err := NewPolicy(3, 16, testCharset, 8, 2)
+	require.NoError(t, err)
+
+	violations := func(t *testing.T, err error) []models.FieldViolation {
+		t.Helper()
+		var verr *models.ValidationError
+		require.ErrorAs(t, err, &verr)
+		return verr.Violations
+	}
+
+	t.Run("valid credentials", func(t *testing.T) {
+		assert.NoError(t, p.Validate("алиса_01", "correct horse 7"))
+	})
+
+	t.Run("empty username and short password", func(t *testing.T) {
+		got := violations(t, p.Validate("", "abc"))
+		require.Len(t, got, 2)
+		assert.Equal(t, models.FieldUsername, got[0].Field)
+		assert.Equal(t, models.ReasonUsernameLength, got[0].Reason)
+		assert.Equal(t, models.FieldPassword, got[1].Field)
+		assert.Equal(t, models.ReasonPasswordLength, got[1].Reason)
+	})
+
+	t.Run("length counted in characters", func(t *testing.T) {
+		assert.NoError(t, p.Validate("ёжик", "пароль12"))
+	})
+
+	t.Run("unsupported characters", func(t *testing.T) {
+		got := violations(t, p.Validate("bob smith", "Password1"))
+		require.Len(t, got, 1)
+		assert.Equal(t, models.ReasonUsernameCharset, got[0].Reason)
+	})
+
+	t.Run("single kind of characters", func(t *testing.T) {
+		got := violations(t, p.Validate("bob", "passwordpassword"))
+		require.Len(t, got, 1)
+		assert.Equal(t, models.ReasonPasswordWeak, got[0].Reason)
+	})
+
+	t.Run("password containing username", func(t *testing.T) {
+		got := violations(t, p.Validate("robert", "Robert2000"))
+		require.Len(t, got, 1)
+		assert.Equal(t, models.ReasonPasswordWeak, got[0].Reason)
+	})
+
+	t.Run("invalid charset expression", func(t *testing.T) {
+		_, err := NewPolicy(3, 16, "[", 8, 2)
+		assert.Error(t, err)
+	})
+}
+
+func TestPolicy_ValidatePassword(t *testing.T) {
+	p, err := NewPolicy(3, 16, testCharset, 8, 2)
+	require.NoError(t, err)
+
+	t.Run("strong password", func(t *testing.T) {
+		assert.NoError(t, p.ValidatePassword("bob", "correct horse 7"))
+	})
+
+	t.Run("username not validated", func(t *testing.T) {
+		assert.NoError(t, p.ValidatePassword("bob smith", "correct horse 7"))
+	})
+
+	t.Run("weak password", func(t *testing.T) {
+		var verr *models.ValidationError
+		require.ErrorAs(t, p.ValidatePassword("robert", "Robert2000"), &verr)
+		require.Len(t, verr.Violations, 1)
+		assert.Equal(t, models.FieldPassword, verr.Violations[0].Field)
+		assert.Equal(t, models.ReasonPasswordWeak, verr.Violations[0].Reason)
+	})
+}
+
+func TestNormalizeUsername(t *testing.T) {
+	t.Run("compatibility forms folded", func(t *testing.T) {
+		// Fullwidth letters and decomposed "й" are stored in canonical form
+		assert.Equal(t, "alice", NormalizeUsername(" ａｌｉｃｅ "))
+		assert.Equal(t, "\u0439", NormalizeUsername("\u0438\u0306"))
+	})
+
+	t.Run("key ignores case", func(t *testing.T) {
+		assert.Equal(t, UsernameKey("Alice"), UsernameKey("ALICE"))
+	})
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// AdminUser represents an account as listed to operators.
+type AdminUser struct {
+	ID       UserID
+	Username string
+	Disabled bool
+}
+
+// AdminUserPage represents a page of accounts ordered by username.
+type AdminUserPage struct {
+	Users     []AdminUser
+	NextToken string // Empty on the last page
+}
+
+// Usage represents server-side resources held by an account.
+type Usage struct {
+	Items      int64 // Personal items not marked as deleted
+	Bytes      int64 // Encrypted payload size of personal items
+	Shares     int64 // Items shared by the account
+	Orgs       int64 // Organization memberships
+	QuotaBytes int64 // Zero means unlimited
+}
+
+// Session represents validity of tokens issued to an account.
+type Session struct {
+	Disabled  bool
+	RevokedAt time.Time // Tokens issued before are rejected, zero when never revoked
+}
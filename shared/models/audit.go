@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// AuditAction defines a security relevant account operation.
+type AuditAction string
+
+// Audited account operations
+const (
+	AuditRegister       AuditAction = "register"
+	AuditLogin          AuditAction = "login"
+	AuditRecover        AuditAction = "recover"
+	AuditChangePassword AuditAction = "change_password"
+	AuditDeleteAccount  AuditAction = "delete_account"
+	AuditSync           AuditAction = "sync"
+)
+
+// AuditResult defines outcome of an audited operation.
+type AuditResult string
+
+// Audited operation outcomes
+const (
+	AuditSuccess AuditResult = "success"
+	AuditFailure AuditResult = "failure"
+)
+
+// AuditEvent represents a single entry of the account audit log.
+type AuditEvent struct {
+	ID        int64
+	UserID    UserID // Empty when the account could not be identified
+	Device    string // Client user agent
+	IP        string // Address of the gRPC peer
+	Action    AuditAction
+	Result    AuditResult
+	CreatedAt time.Time
+}
+
+// AuditPage represents a page of audit events, newest first.
+type AuditPage struct {
+	Events    []AuditEvent
+	NextToken string // Empty on the last page
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// EmergencyStatus defines state of an emergency access request.
+type EmergencyStatus string
+
+// Supported emergency access states
+const (
+	EmergencyIdle      EmergencyStatus = "idle"      // Contact nominated, no pending request
+	EmergencyRequested EmergencyStatus = "requested" // Waiting period is running
+	EmergencyDenied    EmergencyStatus = "denied"    // Owner denied the last request
+	EmergencyApproved  EmergencyStatus = "approved"  // Waiting period elapsed, vault key released
+)
+
+// EmergencyContact represents a trusted contact nominated for emergency access.
+// Grantor is the vault owner, grantee is the trusted contact.
+type EmergencyContact struct {
+	GrantorID   UserID
+	Grantor     string
+	GranteeID   UserID
+	Grantee     string
+	WaitPeriod  time.Duration
+	Status      EmergencyStatus
+	RequestedAt time.Time // Zero when access was never requested
+	WrappedKey  []byte    // Grantor vault key sealed to the grantee public key
+}
+
+// EmergencyVault contains vault of a grantor released to the trusted contact.
+type EmergencyVault struct {
+	WrappedKey []byte
+	Items      []Item
+}
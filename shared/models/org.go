@@ -0,0 +1,70 @@
+package models
+
+// OrgID represents a unique identifier for organizations.
+type OrgID string
+
+// CollectionID represents a unique identifier for organization collections.
+type CollectionID string
+
+// Role defines permissions of an organization member.
+type Role string
+
+// Supported member roles
+const (
+	RoleOwner    Role = "owner"    // Full control including owner management
+	RoleAdmin    Role = "admin"    // Manages members and collections
+	RoleMember   Role = "member"   // Reads and writes collection items
+	RoleReadOnly Role = "readonly" // Reads collection items only
+)
+
+// Valid reports whether role is one of the supported roles.
+func (r Role) Valid() bool {
+	switch r {
+	case RoleOwner, RoleAdmin, RoleMember, RoleReadOnly:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanWrite reports whether role allows changing collection items.
+func (r Role) CanWrite() bool {
+	return r == RoleOwner || r == RoleAdmin || r == RoleMember
+}
+
+// CanManage reports whether role allows managing members and collections.
+func (r Role) CanManage() bool {
+	return r == RoleOwner || r == RoleAdmin
+}
+
+// Organization represents a group of accounts sharing collections.
+type Organization struct {
+	ID   OrgID
+	Name string
+}
+
+// Collection represents a set of items owned by an organization.
+// Items of a collection are encrypted with the collection key.
+type Collection struct {
+	ID         CollectionID
+	OrgID      OrgID
+	OrgName    string
+	Name       string
+	Role       Role   // Role of the current user in the organization
+	WrappedKey []byte // Collection key sealed to the current user public key
+}
+
+// Member represents an organization membership.
+type Member struct {
+	UserID    UserID
+	Username  string
+	Role      Role
+	PublicKey []byte
+}
+
+// CollectionKey contains collection key sealed to a member public key.
+type CollectionKey struct {
+	CollectionID CollectionID
+	UserID       UserID
+	WrappedKey   []byte
+}
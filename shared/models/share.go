@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// KeyPair contains X25519 keys of an account used for item sharing.
+// The private key never leaves the client unencrypted.
+type KeyPair struct {
+	PublicKey           []byte
+	EncryptedPrivateKey string // Private key wrapped with the vault key
+}
+
+// PublicKey contains public key of a sharing recipient.
+type PublicKey struct {
+	UserID UserID
+	Key    []byte
+}
+
+// Share represents an item shared with another account.
+// Server stores it as is and is unable to read its content.
+type Share struct {
+	ItemID      ItemID
+	OwnerID     UserID
+	Owner       string // Owner username
+	RecipientID UserID
+	Recipient   string // Recipient username
+	WrappedKey  []byte // Item key sealed to the recipient public key
+	Payload     []byte // Item encrypted with the item key
+	SharedAt    time.Time
+}
+
+// SharedItem is a decrypted item received from another account.
+type SharedItem struct {
+	ID       ItemID
+	Owner    string
+	ItemType ItemType
+	Name     string
+	Metadata string
+	Content  []byte
+	SharedAt time.Time
+}
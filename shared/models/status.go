@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// ErrorDomain identifies ErrorInfo details attached by the server.
+const ErrorDomain = "gophkeeper"
+
+// Machine readable reasons of failed calls, sent in ErrorInfo details.
+// Registration conflicts use ReasonUsernameTaken.
+// Clients map them to localized messages.
+const (
+	ReasonInvalidCredentials = "INVALID_CREDENTIALS"
+	ReasonAccountDisabled    = "ACCOUNT_DISABLED"
+	ReasonTooManyAttempts    = "TOO_MANY_ATTEMPTS"
+	ReasonSessionExpired     = "SESSION_EXPIRED"
+	ReasonQuotaExceeded      = "QUOTA_EXCEEDED"
+	ReasonForbidden          = "FORBIDDEN"
+	ReasonNotFound           = "NOT_FOUND"
+	ReasonAlreadyExists      = "ALREADY_EXISTS"
+	ReasonInvalidRequest     = "INVALID_REQUEST"
+	ReasonTimeout            = "TIMEOUT"
+	ReasonUnavailable        = "UNAVAILABLE"
+	ReasonInternal           = "INTERNAL"
+)
+
+// ServerError is a failed call with the reason reported by the server.
+// The original error stays available through errors.Unwrap.
+type ServerError struct {
+	Reason     string
+	RetryAfter time.Duration // Delay before the next attempt, zero when not set
+	Err        error
+}
+
+// Error implements the error interface
+func (err *ServerError) Error() string {
+	return err.Err.Error()
+}
+
+// Unwrap supports error inspection with errors.Is()/errors.As()
+func (err *ServerError) Unwrap() error {
+	return err.Err
+}
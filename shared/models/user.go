@@ -5,9 +5,13 @@ type UserID string
 
 // UserRegReq contains registration request data.
 type UserRegReq struct {
-	Username string
-	Password string
-	Salt     string
+	Username     string
+	Password     string
+	Salt         string
+	EncryptedKey string // Vault key wrapped with the password-derived key
+	RecoveryKey  string // Vault key wrapped with the recovery key
+	RecoveryAuth string // Recovery key verifier sent to the server
+	KeyPair
 }
 
 // UserLoginReq contains authentication request data.
@@ -16,19 +20,55 @@ type UserLoginReq struct {
 	Password string
 }
 
+// UserRecoverReq contains account recovery request data.
+type UserRecoverReq struct {
+	Username     string
+	RecoveryAuth string
+}
+
+// PasswordChangeReq contains new credentials of an authenticated user.
+// The vault key itself stays the same and is only rewrapped.
+// Either CurrentPassword or RecoveryAuth proves account ownership.
+type PasswordChangeReq struct {
+	Password        string
+	Salt            string
+	EncryptedKey    string
+	CurrentPassword string
+	RecoveryAuth    string
+}
+
 // UserDB represents the persisted user model.
 // Contains fields as stored in the database.
 type UserDB struct {
-	ID       UserID
-	Username string
-	PassHash string
-	Salt     string
+	ID           UserID
+	Username     string
+	PassHash     string
+	Salt         string
+	EncryptedKey string
+	RecoveryKey  string
+	RecoveryHash string
+	Disabled     bool // Set by an operator, blocks logins and issued tokens
+	KeyPair
 }
 
 // User represents the public user model.
 // Contains fields returned to clients after authentication.
 type User struct {
-	ID   UserID
-	JWT  string
-	Salt string
+	ID           UserID
+	JWT          string
+	Salt         string
+	EncryptedKey string
+	RecoveryKey  string
+	Offline      bool // Unlocked from local cache without a server session
+	KeyPair
+}
+
+// LocalAccount contains credentials cached on the client for offline unlock.
+type LocalAccount struct {
+	Username      string
+	UserID        UserID
+	Salt          string
+	KDFIterations int
+	EncryptedKey  string
+	KeyCheck      string // Known value encrypted with the vault key
 }
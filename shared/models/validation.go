@@ -0,0 +1,43 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Validated request fields
+const (
+	FieldUsername = "username"
+	FieldPassword = "password"
+)
+
+// Machine readable reasons of field violations.
+// Clients map them to localized messages.
+const (
+	ReasonUsernameLength  = "USERNAME_LENGTH"
+	ReasonUsernameCharset = "USERNAME_CHARSET"
+	ReasonUsernameTaken   = "USERNAME_TAKEN"
+	ReasonPasswordLength  = "PASSWORD_LENGTH"
+	ReasonPasswordWeak    = "PASSWORD_WEAK"
+)
+
+// FieldViolation describes a rejected request field.
+type FieldViolation struct {
+	Field       string
+	Reason      string
+	Description string // Human readable explanation in English
+}
+
+// ValidationError reports all rejected fields of a request.
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+// Error implements the error interface
+func (err *ValidationError) Error() string {
+	descs := make([]string, len(err.Violations))
+	for i, v := range err.Violations {
+		descs[i] = fmt.Sprintf("%s: %s", v.Field, v.Description)
+	}
+	return "invalid request: " + strings.Join(descs, "; ")
+}